@@ -2,19 +2,28 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"golang.org/x/term"
 
+	"github.com/SOMUCHDOG/azb/internal/api"
 	"github.com/SOMUCHDOG/azb/internal/auth"
+	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/output"
 )
 
 var (
-	patFlag string
+	patFlag     string
+	expiresFlag string
+	scopesFlag  []string
 
 	authCmd = &cobra.Command{
 		Use:   "auth",
@@ -25,8 +34,16 @@ var (
 	loginCmd = &cobra.Command{
 		Use:   "login",
 		Short: "Authenticate with Azure DevOps",
-		Long:  `Authenticate with Azure DevOps using a Personal Access Token (PAT).`,
-		RunE:  runLogin,
+		Long: `Authenticate with Azure DevOps using a Personal Access Token (PAT).
+
+Azure DevOps does not tell a token its own expiry or scopes, so enter them as
+shown when the token was created. 'azb auth status' and the dashboard then warn
+before the token expires.
+
+Examples:
+  azb auth login
+  azb auth login --pat "$PAT" --expires 2026-12-31 --scopes vso.work_write,vso.code`,
+		RunE: runLogin,
 	}
 
 	logoutCmd = &cobra.Command{
@@ -39,8 +56,11 @@ var (
 	statusCmd = &cobra.Command{
 		Use:   "status",
 		Short: "Check authentication status",
-		Long:  `Check if you are currently authenticated with Azure DevOps.`,
-		RunE:  runStatus,
+		Long: `Check that the token works by signing in to the configured organization,
+and show the user it belongs to and its recorded scopes and expiry.
+
+Exits with an error when the organization rejects the token.`,
+		RunE: runStatus,
 	}
 )
 
@@ -51,6 +71,8 @@ func init() {
 	authCmd.AddCommand(statusCmd)
 
 	loginCmd.Flags().StringVar(&patFlag, "pat", "", "Personal Access Token")
+	loginCmd.Flags().StringVar(&expiresFlag, "expires", "", "Expiry date of the token (YYYY-MM-DD)")
+	loginCmd.Flags().StringSliceVar(&scopesFlag, "scopes", nil, "Scopes of the token (comma-separated, e.g. vso.work_write)")
 }
 
 func runLogin(cmd *cobra.Command, args []string) error {
@@ -62,34 +84,72 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		// Prompt for PAT
 		fmt.Println("Enter your Personal Access Token (PAT):")
 		fmt.Println("You can create a PAT at: https://dev.azure.com/{org}/_usersSettings/tokens")
-		fmt.Print("PAT: ")
 
-		// Read password from terminal without echoing
-		bytePwd, err := term.ReadPassword(int(syscall.Stdin))
-		if err != nil {
-			return fmt.Errorf("failed to read token: %w", err)
+		var err error
+		if token, err = readToken("PAT"); err != nil {
+			return err
 		}
-		fmt.Println() // Add newline after password input
-
-		token = strings.TrimSpace(string(bytePwd))
 	}
 
 	if token == "" {
 		return fmt.Errorf("token cannot be empty")
 	}
 
+	// Ask for the expiry only when the token was entered interactively
+	expires := expiresFlag
+	if expires == "" && patFlag == "" {
+		var err error
+		if expires, err = promptOptional("Expiry date shown when the token was created (YYYY-MM-DD, optional)"); err != nil {
+			return err
+		}
+	}
+	info := &auth.TokenInfo{Scopes: scopesFlag}
+	if expires != "" {
+		date, err := time.Parse(auth.ExpiryDateLayout, expires)
+		if err != nil {
+			return fmt.Errorf("invalid expiry date '%s' (expected YYYY-MM-DD)", expires)
+		}
+		info.Expires = date
+	}
+
 	// Save token
 	if err := auth.SaveToken(token); err != nil {
 		return fmt.Errorf("failed to save token: %w", err)
 	}
+	if !info.Expires.IsZero() || len(info.Scopes) > 0 {
+		if err := auth.SaveTokenInfo(info); err != nil {
+			return err
+		}
+	}
 
 	fmt.Println("✓ Authentication successful")
 	fmt.Println("✓ Token saved")
+	if warning := info.ExpiryWarning(time.Now()); warning != "" {
+		fmt.Printf("⚠ %s\n", warning)
+	}
 
 	return nil
 }
 
+// readToken prompts for a token without echoing it
+func readToken(prompt string) (string, error) {
+	fmt.Printf("%s: ", prompt)
+
+	// Read password from terminal without echoing
+	bytePwd, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		return "", fmt.Errorf("failed to read token: %w", err)
+	}
+	fmt.Println() // Add newline after password input
+
+	return strings.TrimSpace(string(bytePwd)), nil
+}
+
 func runLogout(cmd *cobra.Command, args []string) error {
+	if auth.TokenFromEnv() {
+		fmt.Printf("The token is set by %s; unset it to sign out\n", auth.TokenEnvVar)
+		return nil
+	}
 	if !auth.IsAuthenticated() {
 		fmt.Println("Not currently authenticated")
 		return nil
@@ -118,18 +178,168 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// authStatus is what 'azb auth status' reports in a data format
+type authStatus struct {
+	Authenticated bool       `json:"authenticated"`
+	TokenSource   string     `json:"tokenSource,omitempty"`
+	Organization  string     `json:"organization,omitempty"`
+	Verified      bool       `json:"verified"`
+	User          string     `json:"user,omitempty"`
+	Scopes        []string   `json:"scopes,omitempty"`
+	Expires       *time.Time `json:"expires,omitempty"`
+	DaysLeft      *int       `json:"daysLeft,omitempty"`
+}
+
 func runStatus(cmd *cobra.Command, args []string) error {
-	if auth.IsAuthenticated() {
-		fmt.Println("✓ Authenticated")
+	format, err := outputFormat(cmd, withOutputFormats("text")...)
+	if err != nil {
+		return err
+	}
+	if format != "text" {
+		return writeAuthStatus(format)
+	}
+
+	token, err := auth.GetToken()
+	if err != nil {
+		fmt.Println("✗ Not authenticated")
+		fmt.Println("Run 'azb auth login' to authenticate")
+		return nil
+	}
+
+	if auth.TokenFromEnv() {
+		fmt.Printf("Token read from %s\n", auth.TokenEnvVar)
+	} else {
 		tokenPath, err := auth.GetTokenPath()
 		if err != nil {
 			return fmt.Errorf("failed to get token path: %w", err)
 		}
 		fmt.Printf("Token stored at: %s\n", tokenPath)
+	}
+
+	// Sign in to the organization to check the token still works
+	org := statusOrganization()
+	if org == "" {
+		fmt.Println("? Token not verified: organization not configured. Run 'azb config set organization <org>'")
 	} else {
-		fmt.Println("✗ Not authenticated")
-		fmt.Println("Run 'azb auth login' to authenticate")
+		orgURL, err := organizationURL(org)
+		if err != nil {
+			return err
+		}
+		user, err := api.VerifyToken(orgURL, token)
+		if errors.Is(err, api.ErrTokenRejected) {
+			fmt.Printf("✗ %s rejected the token; it may have expired or been revoked\n", orgURL)
+			return fmt.Errorf("authentication failed. Run 'azb auth login' with a new token")
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ Authenticated as %s\n", describeUser(user))
+	}
+
+	info, err := auth.LoadTokenInfo()
+	if err != nil {
+		return err
 	}
+	printTokenInfo(info, time.Now())
 
 	return nil
 }
+
+// writeAuthStatus writes the authentication status as JSON, YAML or a table
+func writeAuthStatus(format string) error {
+	status := authStatus{}
+	var verifyErr error
+	if token, err := auth.GetToken(); err == nil {
+		status.Authenticated = true
+		status.TokenSource = auth.TokenEnvVar
+		if !auth.TokenFromEnv() {
+			if status.TokenSource, err = auth.GetTokenPath(); err != nil {
+				return fmt.Errorf("failed to get token path: %w", err)
+			}
+		}
+
+		if status.Organization = statusOrganization(); status.Organization != "" {
+			orgURL, err := organizationURL(status.Organization)
+			if err != nil {
+				return err
+			}
+			user, err := api.VerifyToken(orgURL, token)
+			switch {
+			case errors.Is(err, api.ErrTokenRejected):
+				verifyErr = fmt.Errorf("authentication failed. Run 'azb auth login' with a new token")
+			case err != nil:
+				return err
+			default:
+				status.Verified = true
+				status.User = describeUser(user)
+			}
+		}
+
+		info, err := auth.LoadTokenInfo()
+		if err != nil {
+			return err
+		}
+		status.Scopes = info.Scopes
+		if !info.Expires.IsZero() {
+			days := info.DaysLeft(time.Now())
+			status.Expires, status.DaysLeft = &info.Expires, &days
+		}
+	}
+
+	table := output.Table{Headers: []string{"Authenticated", "Verified", "User", "Organization", "Token"}}
+	table.Rows = append(table.Rows, []string{
+		strconv.FormatBool(status.Authenticated), strconv.FormatBool(status.Verified),
+		status.User, status.Organization, status.TokenSource,
+	})
+	if err := writeResult(format, status, table); err != nil {
+		return err
+	}
+	return verifyErr
+}
+
+// statusOrganization returns the organization 'azb auth status' signs in to
+func statusOrganization() string {
+	org := viper.GetString("organization")
+	if cfg, err := config.Load(); err == nil && org == "" {
+		org = cfg.Organization
+	}
+	return org
+}
+
+// describeUser returns a user's display name with the sign-in name when known
+func describeUser(user *api.User) string {
+	if user.UniqueName != "" && user.UniqueName != user.DisplayName {
+		return fmt.Sprintf("%s (%s)", user.DisplayName, user.UniqueName)
+	}
+	return user.DisplayName
+}
+
+// printTokenInfo shows the recorded scopes and expiry of the token and warns when it expires soon
+func printTokenInfo(info *auth.TokenInfo, now time.Time) {
+	if len(info.Scopes) > 0 {
+		fmt.Printf("Scopes: %s\n", strings.Join(info.Scopes, ", "))
+	} else {
+		fmt.Println("Scopes: not recorded (see 'azb auth login --scopes')")
+	}
+
+	if info.Expires.IsZero() {
+		fmt.Println("Expires: not recorded (see 'azb auth login --expires')")
+		return
+	}
+
+	days := info.DaysLeft(now)
+	expires := info.Expires.Format(auth.ExpiryDateLayout)
+	switch {
+	case days < 0:
+		fmt.Printf("Expires: %s (expired)\n", expires)
+	case days == 0:
+		fmt.Printf("Expires: %s (today)\n", expires)
+	case days == 1:
+		fmt.Printf("Expires: %s (in 1 day)\n", expires)
+	default:
+		fmt.Printf("Expires: %s (in %d days)\n", expires, days)
+	}
+	if warning := info.ExpiryWarning(now); warning != "" {
+		fmt.Printf("⚠ %s\n", warning)
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/auth"
+	"github.com/SOMUCHDOG/azb/internal/config"
+)
+
+// newClient authenticates and builds an API client from the configured organization and project
+func newClient() (api.APIClient, error) {
+	// Check authentication
+	token, err := auth.GetToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Get organization and project
+	org := viper.GetString("organization")
+	if org == "" {
+		org = cfg.Organization
+	}
+	if org == "" {
+		return nil, fmt.Errorf("organization not configured. Run 'azb config set organization <org>'")
+	}
+
+	project := viper.GetString("project")
+	if project == "" {
+		project = cfg.Project
+	}
+	if project == "" {
+		return nil, fmt.Errorf("project not configured. Run 'azb config set project <project>'")
+	}
+
+	// Build organization URL
+	orgURL, err := organizationURL(org)
+	if err != nil {
+		return nil, err
+	}
+
+	return connectClient(orgURL, project, token)
+}
+
+// connectClient builds an API client for an organization URL and project,
+// honoring --read-only. newClient is the usual way in; init calls it directly
+// with the values it prompted for before they are saved.
+func connectClient(orgURL, project, token string) (api.APIClient, error) {
+	client, err := api.NewClient(orgURL, project, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API client: %w", err)
+	}
+	client.SetReadOnly(readOnlyEnabled())
+	return client, nil
+}
+
+// organizationURL returns the API URL of an organization, normalized unless
+// skip_url_normalization is set for servers whose URLs normalization gets wrong
+func organizationURL(org string) (string, error) {
+	if !viper.GetBool("skip_url_normalization") {
+		return api.NormalizeOrganizationURL(org)
+	}
+
+	org = strings.TrimSuffix(strings.TrimSpace(org), "/")
+	if !strings.HasPrefix(org, "https://") && !strings.HasPrefix(org, "http://") {
+		return "", fmt.Errorf("organization must be a full URL when skip_url_normalization is set, got '%s'", org)
+	}
+	return org, nil
+}
+
+// traceFile receives the HTTP trace requested with --trace
+var traceFile *os.File
+
+// configureTracing starts writing the HTTP trace requested with --trace
+func configureTracing() {
+	if traceFlag == "" {
+		return
+	}
+	file, err := os.OpenFile(traceFlag, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to open trace file: %v\n", err)
+		return
+	}
+	traceFile = file
+	api.EnableTracing(file)
+}
+
+// closeTracing closes the HTTP trace file
+func closeTracing() {
+	if traceFile != nil {
+		traceFile.Close()
+		traceFile = nil
+	}
+}
+
+// configureCABundle trusts the certificate authorities of ca_bundle in Azure
+// DevOps requests
+func configureCABundle() {
+	bundle := viper.GetString("ca_bundle")
+	if bundle == "" {
+		return
+	}
+	if err := api.SetCABundle(bundle); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using the system certificates only\n", err)
+	}
+}
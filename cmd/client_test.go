@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestOrganizationURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		skip    bool
+		org     string
+		want    string
+		wantErr bool
+	}{
+		{"normalized name", false, "contoso", "https://dev.azure.com/contoso", false},
+		{"normalized server URL", false, "https://tfs.contoso.com/DefaultCollection/Web", "https://tfs.contoso.com/DefaultCollection", false},
+		{"verbatim server URL", true, "https://ado.contoso.com/prefix/tfs/Main/", "https://ado.contoso.com/prefix/tfs/Main", false},
+		{"verbatim needs a URL", true, "contoso", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			viper.Set("skip_url_normalization", tt.skip)
+
+			got, err := organizationURL(tt.org)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("organizationURL(%q) error = %v, wantErr %v", tt.org, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("organizationURL(%q) = %q, want %q", tt.org, got, tt.want)
+			}
+		})
+	}
+}
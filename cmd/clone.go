@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/model"
+)
+
+var (
+	cloneIncludeChildrenFlag bool
+	cloneTitlePrefixFlag     string
+	cloneExcludeFlag         []string
+
+	cloneCmd = &cobra.Command{
+		Use:   "clone <id>",
+		Short: "Duplicate a work item",
+		Long: `Create a copy of a work item with the same type and fields, under the same
+parent. Workflow and bookkeeping fields such as State, Reason, the created,
+changed, resolved and closed dates, and board columns are not copied, so the
+copy starts in the initial state. Comments, history and links other than the
+parent are not copied either.
+
+With --include-children each child work item is copied too and linked under
+the new copy.
+
+Examples:
+  azb clone 1234
+  azb clone 1234 --title-prefix "[Copy]"
+  azb clone 1234 --include-children --exclude System.AssignedTo`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: mutatingAnnotations,
+		RunE:        runClone,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(cloneCmd)
+
+	cloneCmd.Flags().BoolVar(&cloneIncludeChildrenFlag, "include-children", false, "Also copy child work items")
+	cloneCmd.Flags().StringVar(&cloneTitlePrefixFlag, "title-prefix", "", "Text to put before the title of each copy (e.g. \"[Copy]\")")
+	cloneCmd.Flags().StringSliceVar(&cloneExcludeFlag, "exclude", nil, "Additional fields not to copy (reference names, comma-separated)")
+}
+
+// cloneExcludedFields are never copied: they are read-only, computed, or
+// describe where the original is in its workflow
+var cloneExcludedFields = map[string]bool{
+	"System.Id":                               true,
+	"System.Rev":                              true,
+	"System.WorkItemType":                     true,
+	"System.TeamProject":                      true,
+	"System.State":                            true,
+	"System.Reason":                           true,
+	"System.History":                          true,
+	"System.Parent":                           true,
+	"System.CreatedDate":                      true,
+	"System.CreatedBy":                        true,
+	"System.ChangedDate":                      true,
+	"System.ChangedBy":                        true,
+	"System.AuthorizedDate":                   true,
+	"System.AuthorizedAs":                     true,
+	"System.RevisedDate":                      true,
+	"System.Watermark":                        true,
+	"System.PersonId":                         true,
+	"System.NodeName":                         true,
+	"System.AreaId":                           true,
+	"System.IterationId":                      true,
+	"System.CommentCount":                     true,
+	"System.AttachedFileCount":                true,
+	"System.ExternalLinkCount":                true,
+	"System.HyperLinkCount":                   true,
+	"System.RelatedLinkCount":                 true,
+	"System.RemoteLinkCount":                  true,
+	api.BoardColumnField:                      true,
+	api.BoardColumnDoneField:                  true,
+	api.BoardLaneField:                        true,
+	"Microsoft.VSTS.Common.StateChangeDate":   true,
+	"Microsoft.VSTS.Common.ActivatedDate":     true,
+	"Microsoft.VSTS.Common.ActivatedBy":       true,
+	"Microsoft.VSTS.Common.ResolvedDate":      true,
+	"Microsoft.VSTS.Common.ResolvedBy":        true,
+	"Microsoft.VSTS.Common.ResolvedReason":    true,
+	"Microsoft.VSTS.Common.ClosedDate":        true,
+	"Microsoft.VSTS.Common.ClosedBy":          true,
+	"Microsoft.VSTS.Scheduling.CompletedWork": true,
+	"Microsoft.VSTS.Common.StackRank":         true,
+	"Microsoft.VSTS.Common.BacklogPriority":   true,
+}
+
+// cloneExcludedPrefixes cover computed path levels and per-board fields
+var cloneExcludedPrefixes = []string{"System.AreaLevel", "System.IterationLevel", "WEF_"}
+
+func runClone(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	source, err := client.GetWorkItem(id)
+	if err != nil {
+		return err
+	}
+
+	parentID := 0
+	if parents := relatedIDs(*source, api.ParentLinkRelation); len(parents) > 0 {
+		parentID = parents[0]
+	}
+
+	clone, err := cloneWorkItem(client, source, parentID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Cloned #%d to #%d: %s\n", id, *clone.Id, workItemTitle(clone))
+	if parentID > 0 {
+		fmt.Printf("  Parent ID: %d\n", parentID)
+	}
+	fmt.Printf("  URL: %s\n", client.WorkItemURL(*clone.Id))
+
+	if !cloneIncludeChildrenFlag {
+		return nil
+	}
+
+	childIDs := relatedIDs(*source, api.ChildLinkRelation)
+	if len(childIDs) == 0 {
+		fmt.Println("\nNo child work items to copy")
+		return nil
+	}
+
+	children, err := client.GetWorkItems(childIDs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nCopying %d child work item(s)...\n", len(children))
+	failed := 0
+	for i := range children {
+		child := &children[i]
+		childClone, err := cloneWorkItem(client, child, *clone.Id)
+		if err != nil {
+			fmt.Printf("  ✗ Failed to copy #%d (%s): %v\n", *child.Id, workItemTitle(child), err)
+			failed++
+			continue
+		}
+		fmt.Printf("  ✓ #%d → #%d %s\n", *child.Id, *childClone.Id, workItemTitle(childClone))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d child work item(s) could not be copied", failed)
+	}
+	return nil
+}
+
+// cloneWorkItem creates a copy of source under parentID (0 for none)
+func cloneWorkItem(client api.APIClient, source *workitemtracking.WorkItem, parentID int) (*workitemtracking.WorkItem, error) {
+	workItemType := model.FieldString(source.Fields, "System.WorkItemType")
+	if workItemType == "" {
+		return nil, fmt.Errorf("work item %d has no type", *source.Id)
+	}
+
+	var fields map[string]interface{}
+	if source.Fields != nil {
+		fields = cloneFields(*source.Fields, cloneTitlePrefixFlag, cloneExcludeFlag)
+	}
+
+	clone, err := client.CreateWorkItem(workItemType, fields, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create copy of work item %d: %w", *source.Id, err)
+	}
+	return clone, nil
+}
+
+// cloneFields returns the fields to create a copy with: excluded fields are
+// dropped, identities are reduced to their unique name and the title is prefixed
+func cloneFields(source map[string]interface{}, titlePrefix string, exclude []string) map[string]interface{} {
+	fields := make(map[string]interface{})
+	for name, value := range source {
+		if cloneExcludedFields[name] || hasAnyPrefix(name, cloneExcludedPrefixes) || containsFold(exclude, name) {
+			continue
+		}
+		if _, ok := value.(map[string]interface{}); ok {
+			value = planValueString(value)
+		}
+		fields[name] = value
+	}
+
+	if title, ok := fields["System.Title"].(string); ok && titlePrefix != "" {
+		if !strings.HasSuffix(titlePrefix, " ") {
+			titlePrefix += " "
+		}
+		fields["System.Title"] = titlePrefix + title
+	}
+	return fields
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(strings.TrimSpace(value), s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCloneFields(t *testing.T) {
+	source := map[string]interface{}{
+		"System.Title":                     "Fix login",
+		"System.State":                     "Closed",
+		"System.Reason":                    "Fixed",
+		"System.AreaPath":                  `Platform\Web`,
+		"System.AreaLevel1":                "Platform",
+		"System.AssignedTo":                map[string]interface{}{"displayName": "Ada Lovelace", "uniqueName": "ada@example.com"},
+		"System.Tags":                      "auth",
+		"Microsoft.VSTS.Common.ClosedDate": "2024-06-01T10:00:00Z",
+		"Microsoft.VSTS.Common.Priority":   float64(2),
+		"WEF_6CB513B6E70E43499D9FC94E5BBFB784_Kanban.Column": "Done",
+	}
+
+	want := map[string]interface{}{
+		"System.Title":                   "[Copy] Fix login",
+		"System.AreaPath":                `Platform\Web`,
+		"System.AssignedTo":              "ada@example.com",
+		"Microsoft.VSTS.Common.Priority": float64(2),
+	}
+
+	got := cloneFields(source, "[Copy]", []string{"system.tags"})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cloneFields() = %v, want %v", got, want)
+	}
+}
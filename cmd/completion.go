@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/templates"
+	"github.com/SOMUCHDOG/azb/internal/tui"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion <bash|zsh|fish|powershell>",
+	Short: "Generate shell completion scripts",
+	Long: `Generate a completion script for your shell. Besides commands and flags,
+completion suggests real values: work item types from your project, template
+names, saved query names and configuration keys.
+
+Bash:
+  source <(azb completion bash)
+  # To load for every session (Linux):
+  azb completion bash > /etc/bash_completion.d/azb
+
+Zsh:
+  azb completion zsh > "${fpath[1]}/_azb"
+
+Fish:
+  azb completion fish > ~/.config/fish/completions/azb.fish
+
+PowerShell:
+  azb completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE:                  runCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	switch args[0] {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(os.Stdout, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(os.Stdout)
+	case "fish":
+		return rootCmd.GenFishCompletion(os.Stdout, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+	default:
+		return fmt.Errorf("unsupported shell: %s", args[0])
+	}
+}
+
+// completeArgs uses one completion function per positional argument; arguments
+// past the end get no suggestions
+func completeArgs(funcs ...cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= len(funcs) || funcs[len(args)] == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return funcs[len(args)](cmd, args, toComplete)
+	}
+}
+
+// completeWorkItemTypes suggests the project's enabled work item types
+func completeWorkItemTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := newClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	types, err := client.GetWorkItemTypes()
+	if err != nil || types == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, wit := range *types {
+		if wit.Name == nil || (wit.IsDisabled != nil && *wit.IsDisabled) {
+			continue
+		}
+		names = append(names, completion(*wit.Name, stringValue(wit.Description)))
+	}
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTemplateNames suggests saved template names, the team's included
+func completeTemplateNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	list, _, err := templates.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if team, _, err := templates.ListTeam(); err == nil {
+		list = append(list, team...)
+	}
+	return templateCompletions(list, toComplete)
+}
+
+// completeLocalTemplateNames suggests the templates that can be changed, which
+// leaves out the read-only team templates
+func completeLocalTemplateNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	list, _, err := templates.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return templateCompletions(list, toComplete)
+}
+
+// templateCompletions suggests templates by name, described by their description or type
+func templateCompletions(list []*templates.Template, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, tmpl := range list {
+		description := tmpl.Description
+		if description == "" {
+			description = tmpl.Type
+		}
+		names = append(names, completion(tmpl.Name, description))
+	}
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTemplateFolders suggests the folders of the templates directory
+func completeTemplateFolders(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	folders, err := templates.ListFolders()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterCompletions(folders, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeQueryNames suggests saved query names from the top two folder levels,
+// which is as deep as a single request goes
+func completeQueryNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := newClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	queries, err := client.ListQueries("", 2)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	var walk func(items *[]workitemtracking.QueryHierarchyItem)
+	walk = func(items *[]workitemtracking.QueryHierarchyItem) {
+		if items == nil {
+			return
+		}
+		for _, item := range *items {
+			if item.IsFolder != nil && *item.IsFolder {
+				walk(item.Children)
+				continue
+			}
+			if item.Name != nil {
+				names = append(names, completion(*item.Name, stringValue(item.Path)))
+			}
+		}
+	}
+	walk(queries)
+
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTags suggests the tags defined in the project
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := newClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	tags, err := client.ListTags()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConfigKeys suggests the keys 'azb config set' accepts
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var keys []string
+	for _, setting := range configSettings {
+		keys = append(keys, completion(setting.key, setting.description))
+	}
+	return filterCompletions(keys, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConfigValue suggests values for config keys with a fixed set of values
+func completeConfigValue(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch args[0] {
+	case "default_format":
+		return filterCompletions(outputFormats, toComplete), cobra.ShellCompDirectiveNoFileComp
+	case "theme":
+		return filterCompletions(tui.ThemeNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+	case "triage_query":
+		return completeQueryNames(cmd, args, toComplete)
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completion formats a suggestion with an optional description shown by zsh, fish and PowerShell
+func completion(value, description string) string {
+	description = strings.Join(strings.Fields(htmlTagPattern.ReplaceAllString(description, " ")), " ")
+	if description == "" {
+		return value
+	}
+	return cobra.CompletionWithDesc(value, truncateString(description, 60))
+}
+
+// filterCompletions keeps the suggestions that start with toComplete, ignoring case
+func filterCompletions(values []string, toComplete string) []string {
+	prefix := strings.ToLower(toComplete)
+	var matches []string
+	for _, value := range values {
+		if strings.HasPrefix(strings.ToLower(value), prefix) && !slices.Contains(matches, value) {
+			matches = append(matches, value)
+		}
+	}
+	return matches
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
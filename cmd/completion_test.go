@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestFilterCompletions(t *testing.T) {
+	values := []string{"Bug\tA defect", "Task", "bug-report", "Epic", "Task"}
+
+	tests := []struct {
+		toComplete string
+		want       []string
+	}{
+		{"", []string{"Bug\tA defect", "Task", "bug-report", "Epic"}},
+		{"bu", []string{"Bug\tA defect", "bug-report"}},
+		{"T", []string{"Task"}},
+		{"x", nil},
+	}
+
+	for _, tt := range tests {
+		if got := filterCompletions(values, tt.toComplete); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("filterCompletions(%q) = %v, want %v", tt.toComplete, got, tt.want)
+		}
+	}
+}
+
+func TestCompleteArgs(t *testing.T) {
+	first := func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"first"}, cobra.ShellCompDirectiveNoFileComp
+	}
+	second := func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"second:" + args[0]}, cobra.ShellCompDirectiveNoFileComp
+	}
+	complete := completeArgs(first, second)
+
+	if got, _ := complete(nil, nil, ""); !reflect.DeepEqual(got, []string{"first"}) {
+		t.Errorf("first argument: got %v", got)
+	}
+	if got, _ := complete(nil, []string{"a"}, ""); !reflect.DeepEqual(got, []string{"second:a"}) {
+		t.Errorf("second argument: got %v", got)
+	}
+	if got, _ := complete(nil, []string{"a", "b"}, ""); got != nil {
+		t.Errorf("third argument: got %v, want no suggestions", got)
+	}
+	if got, _ := completeArgs(nil, second)(nil, nil, ""); got != nil {
+		t.Errorf("nil completer: got %v, want no suggestions", got)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	if got := completion("Bug", ""); got != "Bug" {
+		t.Errorf("completion without description = %q", got)
+	}
+	if got := completion("Bug", "<div>Describes a\n defect</div>"); got != "Bug\tDescribes a defect" {
+		t.Errorf("completion with description = %q", got)
+	}
+}
@@ -2,14 +2,24 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/auth"
 	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/tui"
 )
 
 var (
+	configNoVerifyFlag bool
+
 	configCmd = &cobra.Command{
 		Use:   "config",
 		Short: "Manage configuration",
@@ -17,19 +27,34 @@ var (
 	}
 
 	configGetCmd = &cobra.Command{
-		Use:   "get <key>",
-		Short: "Get a configuration value",
-		Long:  `Get the value of a configuration key.`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runConfigGet,
+		Use:               "get <key>",
+		Short:             "Get a configuration value",
+		Long:              `Get the value of a configuration key.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeConfigKeys),
+		RunE:              runConfigGet,
 	}
 
 	configSetCmd = &cobra.Command{
 		Use:   "set <key> <value>",
 		Short: "Set a configuration value",
-		Long:  `Set the value of a configuration key.`,
-		Args:  cobra.ExactArgs(2),
-		RunE:  runConfigSet,
+		Long: `Set the value of a configuration key.
+
+When setting the organization or project, the value is checked against
+Azure DevOps so typos are caught immediately. Use --no-verify to skip the
+check, e.g. when setting up offline or before logging in.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArgs(completeConfigKeys, completeConfigValue),
+		RunE:              runConfigSet,
+	}
+
+	configUnsetCmd = &cobra.Command{
+		Use:               "unset <key>",
+		Short:             "Remove a configuration value",
+		Long:              `Remove a configuration key from the config file so its default applies again.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeConfigKeys),
+		RunE:              runConfigUnset,
 	}
 
 	configListCmd = &cobra.Command{
@@ -40,18 +65,317 @@ var (
 	}
 )
 
+// configSetting describes a key 'azb config set' accepts
+type configSetting struct {
+	key         string
+	description string
+	// field returns a *string or *int pointing into cfg
+	field func(cfg *config.Config) interface{}
+	// validate optionally checks a value before it is saved
+	validate func(value string) error
+	// manualFix makes 'azb doctor' report an invalid value instead of dropping it
+	manualFix bool
+}
+
+// configSettings are the keys 'azb config set' and 'azb config unset' accept
+var configSettings = []configSetting{
+	{
+		key:         "organization",
+		description: "Azure DevOps organization name or URL",
+		field:       func(cfg *config.Config) interface{} { return &cfg.Organization },
+		validate: func(value string) error {
+			_, err := organizationURL(value)
+			return err
+		},
+		manualFix: true,
+	},
+	{
+		key:         "project",
+		description: "Project name",
+		field:       func(cfg *config.Config) interface{} { return &cfg.Project },
+	},
+	{
+		key:         "default_area_path",
+		description: "Area path for new work items",
+		field:       func(cfg *config.Config) interface{} { return &cfg.DefaultAreaPath },
+	},
+	{
+		key:         "default_iteration",
+		description: "Iteration for new work items",
+		field:       func(cfg *config.Config) interface{} { return &cfg.DefaultIteration },
+	},
+	{
+		key:         "default_template",
+		description: "Template 'azb create' uses when neither --template nor --type is given",
+		field:       func(cfg *config.Config) interface{} { return &cfg.DefaultTemplate },
+	},
+	{
+		key:         "cache_ttl",
+		description: "Cache lifetime in seconds",
+		field:       func(cfg *config.Config) interface{} { return &cfg.CacheTTL },
+	},
+	{
+		key:         "default_view",
+		description: "What running 'azb' without a command opens: dashboard or help",
+		field:       func(cfg *config.Config) interface{} { return &cfg.DefaultView },
+		validate: func(value string) error {
+			if !slices.Contains(defaultViews, value) {
+				return fmt.Errorf("invalid default_view '%s' (expected one of: %s)", value, strings.Join(defaultViews, ", "))
+			}
+			return nil
+		},
+	},
+	{
+		key:         "default_format",
+		description: "Output format when --format is not given",
+		field:       func(cfg *config.Config) interface{} { return &cfg.DefaultFormat },
+		validate: func(value string) error {
+			if !slices.Contains(outputFormats, value) {
+				return fmt.Errorf("invalid default_format '%s' (expected one of: %s)", value, strings.Join(outputFormats, ", "))
+			}
+			return nil
+		},
+	},
+	{
+		key:         "theme",
+		description: "Dashboard color theme",
+		field:       func(cfg *config.Config) interface{} { return &cfg.Theme },
+		validate: func(value string) error {
+			if !slices.Contains(tui.ThemeNames(), value) {
+				return fmt.Errorf("unknown theme '%s' (available: %s)", value, strings.Join(tui.ThemeNames(), ", "))
+			}
+			return nil
+		},
+	},
+	{
+		key:         "triage_query",
+		description: "Saved query used by 'azb triage'",
+		field:       func(cfg *config.Config) interface{} { return &cfg.TriageQuery },
+	},
+	{
+		key:         "skip_url_normalization",
+		description: "Use the organization URL exactly as configured (true/false), e.g. for unusual Azure DevOps Server paths",
+		field:       func(cfg *config.Config) interface{} { return &cfg.SkipURLNormalization },
+		validate: func(value string) error {
+			if _, err := strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("skip_url_normalization must be true or false, got '%s'", value)
+			}
+			return nil
+		},
+	},
+	{
+		key:         "ca_bundle",
+		description: "PEM file of extra certificate authorities to trust, e.g. of a TLS-intercepting proxy",
+		field:       func(cfg *config.Config) interface{} { return &cfg.CABundle },
+		validate: func(value string) error {
+			_, err := api.LoadCABundle(value)
+			return err
+		},
+		manualFix: true,
+	},
+	{
+		key:         "dashboard.default_query",
+		description: "Saved query name or WIQL the dashboard's Work Items tab loads first",
+		field:       func(cfg *config.Config) interface{} { return &cfg.Dashboard.DefaultQuery },
+	},
+	{
+		key:         "dashboard.notification_timeout",
+		description: "How long dashboard notifications stay on screen, e.g. 5s (0 keeps them until replaced)",
+		field:       func(cfg *config.Config) interface{} { return &cfg.Dashboard.NotificationTimeout },
+		validate: func(value string) error {
+			_, _, err := notificationTimeouts(config.DashboardConfig{NotificationTimeout: value})
+			return err
+		},
+	},
+	{
+		key:         "dashboard.error_timeout",
+		description: "How long dashboard error notifications stay on screen, e.g. 15s (0 keeps them until replaced)",
+		field:       func(cfg *config.Config) interface{} { return &cfg.Dashboard.ErrorTimeout },
+		validate: func(value string) error {
+			_, _, err := notificationTimeouts(config.DashboardConfig{ErrorTimeout: value})
+			return err
+		},
+	},
+	{
+		key:         "dashboard.refresh_interval",
+		description: "How often the dashboard's Work Items tab reloads, e.g. 2m (0 disables auto-refresh)",
+		field:       func(cfg *config.Config) interface{} { return &cfg.Dashboard.RefreshInterval },
+		validate: func(value string) error {
+			_, err := dashboardRefreshInterval(config.DashboardConfig{RefreshInterval: value})
+			return err
+		},
+	},
+	{
+		key:         "dashboard.details_layout",
+		description: "Where the Work Items tab shows details: below, side or auto (side on wide terminals)",
+		field:       func(cfg *config.Config) interface{} { return &cfg.Dashboard.DetailsLayout },
+		validate: func(value string) error {
+			_, err := tui.ParseDetailsLayout(value)
+			return err
+		},
+	},
+	{
+		key:         "dashboard.details_size",
+		description: "Percent of the Work Items tab the details pane takes (20-80)",
+		field:       func(cfg *config.Config) interface{} { return &cfg.Dashboard.DetailsSize },
+		validate: func(value string) error {
+			_, _, err := detailsLayout(config.DashboardConfig{DetailsSize: value})
+			return err
+		},
+	},
+	{
+		key:         "dashboard.confirm_default",
+		description: "Button confirmation dialogs select first: yes or no",
+		field:       func(cfg *config.Config) interface{} { return &cfg.Dashboard.ConfirmDefault },
+		validate: func(value string) error {
+			_, err := confirmDefault(config.DashboardConfig{ConfirmDefault: value})
+			return err
+		},
+	},
+	{
+		key:         "max_retries",
+		description: "Retries for throttled or failed requests",
+		field:       func(cfg *config.Config) interface{} { return &cfg.MaxRetries },
+		validate: func(value string) error {
+			_, err := retryPolicyFromConfig(&config.Config{MaxRetries: value})
+			return err
+		},
+	},
+	{
+		key:         "retry_base_delay",
+		description: "Delay before the first retry, e.g. 500ms",
+		field:       func(cfg *config.Config) interface{} { return &cfg.RetryBaseDelay },
+		validate: func(value string) error {
+			_, err := retryPolicyFromConfig(&config.Config{RetryBaseDelay: value})
+			return err
+		},
+	},
+	{
+		key:         "concurrency",
+		description: "Work items bulk commands and the dashboard process at once",
+		field:       func(cfg *config.Config) interface{} { return &cfg.Concurrency },
+		validate: func(value string) error {
+			_, err := concurrencyFromConfig(&config.Config{Concurrency: value})
+			return err
+		},
+	},
+	{
+		key:         "templates_dir",
+		description: "Templates folder instead of ~/.azure-boards-cli/templates",
+		field:       func(cfg *config.Config) interface{} { return &cfg.TemplatesDir },
+		validate: func(value string) error {
+			if info, err := os.Stat(value); err == nil && !info.IsDir() {
+				return fmt.Errorf("templates_dir '%s' is a file, not a folder", value)
+			}
+			return nil
+		},
+	},
+	{
+		key:         "team_templates_repo",
+		description: "Git repository of shared team templates, pulled by azb template sync",
+		field:       func(cfg *config.Config) interface{} { return &cfg.TeamTemplatesRepo },
+	},
+	{
+		key:         "team_templates_dir",
+		description: "Shared team templates folder instead of ~/.azure-boards-cli/team-templates",
+		field:       func(cfg *config.Config) interface{} { return &cfg.TeamTemplatesDir },
+		validate: func(value string) error {
+			if info, err := os.Stat(value); err == nil && !info.IsDir() {
+				return fmt.Errorf("team_templates_dir '%s' is a file, not a folder", value)
+			}
+			return nil
+		},
+	},
+	{
+		key:         "read_only",
+		description: "Disable commands and dashboard actions that change work items (true/false)",
+		field:       func(cfg *config.Config) interface{} { return &cfg.ReadOnly },
+		validate: func(value string) error {
+			if _, err := strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("read_only must be true or false, got '%s'", value)
+			}
+			return nil
+		},
+	},
+	{
+		key:         "git_branch_pattern",
+		description: "Regular expression whose first group captures the work item ID in branch names",
+		field:       func(cfg *config.Config) interface{} { return &cfg.GitBranchPattern },
+		validate: func(value string) error {
+			_, err := compileBranchPattern(value)
+			return err
+		},
+	},
+}
+
+// configSetKeys are the keys 'azb config set' accepts
+var configSetKeys = func() []string {
+	keys := make([]string, len(configSettings))
+	for i, setting := range configSettings {
+		keys[i] = setting.key
+	}
+	return keys
+}()
+
+// lookupConfigSetting finds a settable key or explains which keys are valid
+func lookupConfigSetting(key string) (*configSetting, error) {
+	for i := range configSettings {
+		if configSettings[i].key == key {
+			return &configSettings[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown configuration key '%s' (valid keys: %s)", key, strings.Join(configSetKeys, ", "))
+}
+
+// value returns the value of the setting in cfg
+func (s *configSetting) value(cfg *config.Config) interface{} {
+	switch field := s.field(cfg).(type) {
+	case *string:
+		return *field
+	case *int:
+		return *field
+	}
+	return nil
+}
+
+// set parses and validates value and stores it in cfg
+func (s *configSetting) set(cfg *config.Config, value string) error {
+	switch field := s.field(cfg).(type) {
+	case *string:
+		*field = value
+	case *int:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid %s '%s' (expected a whole number of 0 or more)", s.key, value)
+		}
+		*field = n
+	}
+
+	if s.validate != nil {
+		return s.validate(value)
+	}
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
 	configCmd.AddCommand(configListCmd)
+
+	configSetCmd.Flags().BoolVar(&configNoVerifyFlag, "no-verify", false, "Skip checking the organization or project against Azure DevOps")
 }
 
 func runConfigGet(cmd *cobra.Command, args []string) error {
+	if err := textOutputOnly(cmd); err != nil {
+		return err
+	}
+
 	key := args[0]
 	value := viper.Get(key)
 
-	if value == nil {
+	if value == nil || value == "" {
 		fmt.Printf("%s is not set\n", key)
 		return nil
 	}
@@ -64,7 +388,22 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 	value := args[1]
 
-	viper.Set(key, value)
+	setting, err := lookupConfigSetting(key)
+	if err != nil {
+		return err
+	}
+
+	// Normalize the organization before saving it
+	if key == "organization" {
+		orgURL, err := organizationURL(value)
+		if err != nil {
+			return err
+		}
+		if name := strings.TrimPrefix(orgURL, "https://dev.azure.com/"); value != name && value != orgURL {
+			fmt.Printf("Normalized organization URL: %s\n", orgURL)
+			value = orgURL
+		}
+	}
 
 	// Load current config
 	cfg, err := config.Load()
@@ -73,48 +412,218 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		cfg = &config.Config{}
 	}
 
-	// Update the specific field based on key
-	switch key {
-	case "organization":
-		cfg.Organization = value
-	case "project":
-		cfg.Project = value
-	case "default_area_path":
-		cfg.DefaultAreaPath = value
-	case "default_iteration":
-		cfg.DefaultIteration = value
-	case "default_view":
-		cfg.DefaultView = value
+	// Check organization and project against the API before saving
+	if !configNoVerifyFlag && (key == "organization" || key == "project") {
+		verified, err := verifyOrganizationProject(key, value, cfg)
+		if err != nil {
+			return err
+		}
+		value = verified
+	}
+
+	if err := setting.set(cfg, value); err != nil {
+		return err
 	}
 
 	// Save config
-	if err := config.Save(cfg); err != nil {
+	if err := config.Save(cfg, setting.key); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
 	fmt.Printf("✓ Set %s = %s\n", key, value)
+	warnProjectOverride(key)
+
+	return nil
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	setting, err := lookupConfigSetting(args[0])
+	if err != nil {
+		return err
+	}
 
+	if err := config.Unset(setting.key); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✓ Unset %s\n", setting.key)
+	warnProjectOverride(setting.key)
 	return nil
 }
 
+// warnProjectOverride points out that the project config of the current repository overrides a key
+func warnProjectOverride(key string) {
+	if slices.Contains(config.ProjectOverrides(), key) {
+		fmt.Printf("Note: %s sets %s in this repository and takes precedence\n", config.ProjectConfigFile(), key)
+	}
+}
+
+// configValue is a configuration key and its value as 'azb config list' outputs it
+type configValue struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
 func runConfigList(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd, withOutputFormats("text")...)
+	if err != nil {
+		return err
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if format != "text" {
+		entries := make([]configValue, 0, len(configSettings))
+		table := output.Table{Headers: []string{"Key", "Value"}}
+		for i := range configSettings {
+			value := configSettings[i].value(cfg)
+			entries = append(entries, configValue{Key: configSettings[i].key, Value: value})
+			table.Rows = append(table.Rows, []string{configSettings[i].key, fmt.Sprint(value)})
+		}
+		return writeResult(format, entries, table)
+	}
+
 	fmt.Println("Configuration:")
+	if file := config.ProjectConfigFile(); file != "" {
+		fmt.Printf("  (%s overrides %s)\n", file, strings.Join(config.ProjectOverrides(), ", "))
+	}
 	fmt.Printf("  organization:        %s\n", cfg.Organization)
 	fmt.Printf("  project:             %s\n", cfg.Project)
 	fmt.Printf("  default_area_path:   %s\n", cfg.DefaultAreaPath)
 	fmt.Printf("  default_iteration:   %s\n", cfg.DefaultIteration)
+	fmt.Printf("  default_template:    %s\n", cfg.DefaultTemplate)
 	fmt.Printf("  cache_ttl:           %d\n", cfg.CacheTTL)
 	fmt.Printf("  default_view:        %s\n", cfg.DefaultView)
+	fmt.Printf("  default_format:      %s\n", cfg.DefaultFormat)
+	fmt.Printf("  theme:               %s\n", cfg.Theme)
+	fmt.Printf("  triage_query:        %s\n", cfg.TriageQuery)
+	fmt.Printf("  max_retries:         %s\n", cfg.MaxRetries)
+	fmt.Printf("  retry_base_delay:    %s\n", cfg.RetryBaseDelay)
+	fmt.Printf("  concurrency:         %s\n", cfg.Concurrency)
+	fmt.Printf("  templates_dir:       %s\n", cfg.TemplatesDir)
+	if cfg.TeamTemplatesRepo != "" {
+		fmt.Printf("  team_templates_repo: %s\n", cfg.TeamTemplatesRepo)
+	}
+	if cfg.TeamTemplatesDir != "" {
+		fmt.Printf("  team_templates_dir:  %s\n", cfg.TeamTemplatesDir)
+	}
+	fmt.Printf("  read_only:           %s\n", cfg.ReadOnly)
+	fmt.Printf("  git_branch_pattern:  %s\n", cfg.GitBranchPattern)
+	fmt.Printf("  skip_url_normalization: %s\n", cfg.SkipURLNormalization)
+	fmt.Printf("  ca_bundle:           %s\n", cfg.CABundle)
+	fmt.Printf("  dashboard.default_query: %s\n", cfg.Dashboard.DefaultQuery)
+	if cfg.Dashboard.NotificationTimeout != "" {
+		fmt.Printf("  dashboard.notification_timeout: %s\n", cfg.Dashboard.NotificationTimeout)
+	}
+	if cfg.Dashboard.ErrorTimeout != "" {
+		fmt.Printf("  dashboard.error_timeout: %s\n", cfg.Dashboard.ErrorTimeout)
+	}
+	if cfg.Dashboard.RefreshInterval != "" {
+		fmt.Printf("  dashboard.refresh_interval: %s\n", cfg.Dashboard.RefreshInterval)
+	}
+	if cfg.Dashboard.DetailsLayout != "" {
+		fmt.Printf("  dashboard.details_layout: %s\n", cfg.Dashboard.DetailsLayout)
+	}
+	if cfg.Dashboard.DetailsSize != "" {
+		fmt.Printf("  dashboard.details_size: %s\n", cfg.Dashboard.DetailsSize)
+	}
+	if cfg.Dashboard.ConfirmDefault != "" {
+		fmt.Printf("  dashboard.confirm_default: %s\n", cfg.Dashboard.ConfirmDefault)
+	}
+	if len(cfg.Dashboard.Views) > 0 {
+		fmt.Printf("  dashboard.views:     %d configured\n", len(cfg.Dashboard.Views))
+	}
 
 	// Show the computed organization URL for debugging
 	if cfg.Organization != "" {
-		fmt.Printf("\nComputed organization URL: https://dev.azure.com/%s\n", cfg.Organization)
+		if orgURL, err := organizationURL(cfg.Organization); err != nil {
+			fmt.Printf("\nOrganization URL is invalid: %v\n", err)
+		} else {
+			fmt.Printf("\nComputed organization URL: %s\n", orgURL)
+		}
 	}
 
 	return nil
 }
+
+// verifyOrganizationProject checks that the organization is reachable and, when a project
+// is known, that it exists. It returns the value to save: a project is matched ignoring
+// case, like Azure DevOps does, and saved with the name the service uses. Verification is
+// skipped when not authenticated yet.
+func verifyOrganizationProject(key, value string, cfg *config.Config) (string, error) {
+	token, err := auth.GetToken()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: not authenticated, skipping verification. Run 'azb auth login' first or pass --no-verify.")
+		return value, nil
+	}
+
+	org, project := cfg.Organization, cfg.Project
+	if key == "organization" {
+		org = value
+	} else {
+		project = value
+	}
+	if org == "" {
+		// Nothing to check the project against yet
+		return value, nil
+	}
+
+	orgURL, err := organizationURL(org)
+	if err != nil {
+		return "", err
+	}
+
+	projects, err := api.ListProjects(orgURL, token)
+	if err != nil {
+		return "", fmt.Errorf("could not verify organization '%s': %w (use --no-verify to skip)", org, err)
+	}
+
+	if project == "" {
+		return value, nil
+	}
+	if name, ok := matchProject(project, projects); ok {
+		if key == "project" {
+			return name, nil
+		}
+		return value, nil
+	}
+
+	message := fmt.Sprintf("project '%s' not found in organization '%s'", project, org)
+	if suggestions := suggestProjects(project, projects); len(suggestions) > 0 {
+		message += fmt.Sprintf(" (did you mean: %s?)", strings.Join(suggestions, ", "))
+	}
+
+	// A new organization is still saved; the configured project is what needs fixing
+	if key == "organization" {
+		fmt.Fprintf(os.Stderr, "Warning: %s. Run 'azb config set project <project>'\n", message)
+		return value, nil
+	}
+	return "", fmt.Errorf("%s. Use --no-verify to save it anyway", message)
+}
+
+// matchProject finds a project by name, ignoring case as Azure DevOps does, and
+// returns its name as the service spells it
+func matchProject(name string, projects []string) (string, bool) {
+	for _, project := range projects {
+		if strings.EqualFold(project, name) {
+			return project, true
+		}
+	}
+	return "", false
+}
+
+// suggestProjects returns projects whose names resemble name
+func suggestProjects(name string, projects []string) []string {
+	lower := strings.ToLower(name)
+	var suggestions []string
+	for _, project := range projects {
+		candidate := strings.ToLower(project)
+		if candidate == lower || strings.Contains(candidate, lower) || strings.Contains(lower, candidate) {
+			suggestions = append(suggestions, project)
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/SOMUCHDOG/azb/internal/config"
+)
+
+func TestMatchProject(t *testing.T) {
+	projects := []string{"MyProject", "Website"}
+
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{"MyProject", "MyProject", true},
+		{"myproject", "MyProject", true},
+		{"My", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got, ok := matchProject(tt.in, projects); got != tt.want || ok != tt.wantOK {
+				t.Errorf("matchProject(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSuggestProjects(t *testing.T) {
+	projects := []string{"Platform", "Platform Tools", "Website"}
+
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"different case", "platform", []string{"Platform", "Platform Tools"}},
+		{"partial name", "web", []string{"Website"}},
+		{"no match", "Mobile", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggestProjects(tt.in, projects); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("suggestProjects(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigSettingSet(t *testing.T) {
+	tests := []struct {
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{"project", "Platform", false},
+		{"cache_ttl", "600", false},
+		{"cache_ttl", "soon", true},
+		{"cache_ttl", "-1", true},
+		{"default_format", "json", false},
+		{"default_format", "yaml", false},
+		{"default_format", "xml", true},
+		{"max_retries", "3", false},
+		{"max_retries", "many", true},
+		{"retry_base_delay", "2s", false},
+		{"retry_base_delay", "2", true},
+		{"dashboard.confirm_default", "yes", false},
+		{"dashboard.confirm_default", "No", false},
+		{"dashboard.confirm_default", "maybe", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			setting, err := lookupConfigSetting(tt.key)
+			if err != nil {
+				t.Fatalf("lookupConfigSetting(%q) error: %v", tt.key, err)
+			}
+			err = setting.set(&config.Config{}, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("set(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigUnsetRemovesKey(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	initial := "project: Platform\ncache_ttl: 600\ndashboard:\n  refresh_interval: 2m\n"
+	if err := os.WriteFile(configFile, []byte(initial), 0644); err != nil {
+		t.Fatal(err)
+	}
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(configFile)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"cache_ttl", "dashboard.refresh_interval"} {
+		if err := runConfigUnset(configUnsetCmd, []string{key}); err != nil {
+			t.Fatalf("runConfigUnset(%q) error = %v", key, err)
+		}
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "project: Platform\n" {
+		t.Errorf("config file = %q, want only the project left", got)
+	}
+}
+
+func TestLookupConfigSettingUnknownKey(t *testing.T) {
+	_, err := lookupConfigSetting("org")
+	if err == nil {
+		t.Fatal("lookupConfigSetting(\"org\") succeeded, want error")
+	}
+	if !strings.Contains(err.Error(), "default_area_path") {
+		t.Errorf("error %q does not list the valid keys", err)
+	}
+}
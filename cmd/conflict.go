@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+// updateAtRevision updates a work item only if it is still at the revision it
+// was read at, so that changes chosen while looking at it do not silently
+// overwrite someone else's. On a conflict, their changes are listed and the
+// user is asked whether to re-apply fields on top of the latest revision.
+func updateAtRevision(client api.APIClient, id int, workItem *workitemtracking.WorkItem, fields map[string]interface{}) (*workitemtracking.WorkItem, error) {
+	for {
+		if workItem.Rev == nil || workItem.Fields == nil {
+			return client.UpdateWorkItem(id, fields)
+		}
+
+		updated, err := client.UpdateWorkItemAtRevision(id, *workItem.Rev, fields)
+		if !errors.Is(err, api.ErrRevisionConflict) {
+			return updated, err
+		}
+
+		latest, getErr := client.GetWorkItem(id)
+		if getErr != nil || latest.Fields == nil {
+			return nil, err
+		}
+		printUpdateConflict(os.Stdout, id, api.CompareFields(*workItem.Fields, *latest.Fields), fields)
+
+		fmt.Print("\nRe-apply your changes? (y/N): ")
+		//nolint:errcheck // User input is optional; errors default to empty string
+		confirm, _ := promptOptional("")
+		if confirm != "y" && confirm != "Y" {
+			return nil, err
+		}
+		workItem = latest
+	}
+}
+
+// printUpdateConflict lists the changes someone else made to a work item since
+// it was read, marking the fields that are about to be changed too
+func printUpdateConflict(out io.Writer, id int, theirs []api.FieldChange, fields map[string]interface{}) {
+	fmt.Fprintf(out, "\n⚠ Work item %d was changed by someone else since it was read:\n\n", id)
+
+	if len(theirs) == 0 {
+		fmt.Fprintln(out, "  No field changes; links or comments were changed")
+	} else {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "  FIELD\tTHEIRS (OLD → NEW)\tYOURS")
+		for _, change := range theirs {
+			yours := ""
+			if value, ok := fields[change.Field]; ok {
+				yours = truncateString(planValueString(value), 30)
+			}
+			fmt.Fprintf(w, "  %s\t%s → %s\t%s\n", change.Field,
+				truncateString(planValueString(change.OldValue), 30),
+				truncateString(planValueString(change.NewValue), 30), yours)
+		}
+		w.Flush()
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(out, "\nYour changes: %s\n", strings.Join(names, ", "))
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+func TestPrintUpdateConflict(t *testing.T) {
+	theirs := []api.FieldChange{
+		{Field: "System.AssignedTo", OldValue: map[string]interface{}{"uniqueName": "ada@example.com"}, NewValue: map[string]interface{}{"uniqueName": "bob@example.com"}},
+		{Field: "System.State", OldValue: "Active", NewValue: "Closed"},
+	}
+	fields := map[string]interface{}{"System.State": "Resolved", "System.Title": "Login fails on Safari"}
+
+	var out bytes.Buffer
+	printUpdateConflict(&out, 42, theirs, fields)
+	got := out.String()
+
+	for _, want := range []string{
+		"Work item 42 was changed",
+		"ada@example.com → bob@example.com",
+		"Active → Closed",
+		"Resolved",
+		"Your changes: System.State, System.Title",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("printUpdateConflict() = %q, missing %q", got, want)
+		}
+	}
+}
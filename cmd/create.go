@@ -8,11 +8,10 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
-	"github.com/SOMUCHDOG/azb/internal/auth"
 	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/recent"
 	"github.com/SOMUCHDOG/azb/internal/templates"
 )
 
@@ -28,12 +27,24 @@ var (
 	createFieldsFlag      []string
 	createTemplateFlag    string
 	createParentIDFlag    int
+	createRelatedFlag     []int
+	createValidateFlag    bool
+	createEditFlag        bool
 
 	createCmd = &cobra.Command{
 		Use:   "create",
 		Short: "Create a new work item",
-		Long:  `Create a new work item in Azure Boards. Run without flags for interactive mode.`,
-		RunE:  runCreate,
+		Long: `Create a new work item in Azure Boards. Run without flags for interactive mode.
+
+Use --validate to check the work item against the rules of its type, such as
+required fields and allowed values, without creating it.
+
+Use --edit to draft the work item in $EDITOR: a YAML file with the template's
+fields, or the type's common and required fields, is opened, and the work item
+is created when you save and close it. Invalid drafts are reopened after the
+problems are listed.`,
+		Annotations: mutatingAnnotations,
+		RunE:        runCreate,
 	}
 )
 
@@ -51,11 +62,20 @@ func init() {
 	createCmd.Flags().StringArrayVar(&createFieldsFlag, "field", []string{}, "Custom field in format 'FieldName=value' (can be repeated)")
 	createCmd.Flags().StringVarP(&createTemplateFlag, "template", "t", "", "Use a template")
 	createCmd.Flags().IntVar(&createParentIDFlag, "parent-id", 0, "Parent work item ID (to create as a child)")
+	createCmd.Flags().IntSliceVar(&createRelatedFlag, "related", nil, "IDs of work items to link as related (comma-separated)")
+	createCmd.Flags().BoolVar(&createValidateFlag, "validate", false, "Check the fields against the work item type's rules without creating anything")
+	createCmd.Flags().BoolVarP(&createEditFlag, "edit", "e", false, "Draft the work item in $EDITOR before creating it")
+	addConcurrencyFlag(createCmd)
+	createCmd.MarkFlagsMutuallyExclusive("edit", "validate")
+
+	//nolint:errcheck // Flags are registered above
+	createCmd.RegisterFlagCompletionFunc("type", completeWorkItemTypes)
+	//nolint:errcheck // Flags are registered above
+	createCmd.RegisterFlagCompletionFunc("template", completeTemplateNames)
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
-	// Check authentication
-	token, err := auth.GetToken()
+	client, err := newClient()
 	if err != nil {
 		return err
 	}
@@ -66,36 +86,14 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Get organization and project
-	org := viper.GetString("organization")
-	if org == "" {
-		org = cfg.Organization
-	}
-	if org == "" {
-		return fmt.Errorf("organization not configured. Run 'azb config set organization <org>'")
-	}
-
-	project := viper.GetString("project")
-	if project == "" {
-		project = cfg.Project
-	}
-	if project == "" {
-		return fmt.Errorf("project not configured. Run 'azb config set project <project>'")
-	}
-
-	// Build organization URL
-	orgURL := api.NormalizeOrganizationURL(org)
-
-	// Create API client
-	client, err := api.NewClient(orgURL, project, token)
-	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+	// Load template if specified, falling back to the configured default template
+	templateName := createTemplateFlag
+	if templateName == "" && createTypeFlag == "" {
+		templateName = cfg.DefaultTemplate
 	}
-
-	// Load template if specified
 	var template *templates.Template
-	if createTemplateFlag != "" {
-		template, err = templates.Load(createTemplateFlag)
+	if templateName != "" {
+		template, err = templates.Load(templateName)
 		if err != nil {
 			return fmt.Errorf("failed to load template: %w", err)
 		}
@@ -106,8 +104,12 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
+	if createEditFlag {
+		return runCreateEditor(client, cfg, template)
+	}
+
 	// Determine if interactive mode or CLI mode
-	isInteractive := createTitleFlag == "" && createTypeFlag == "" && createTemplateFlag == ""
+	isInteractive := createTitleFlag == "" && createTypeFlag == "" && templateName == ""
 
 	var workItemType, title, description, assignedTo, areaPath, iteration, tags string
 	var priority int
@@ -342,11 +344,31 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		parentID = template.Relations.ParentID
 	}
 
+	// Links are added in the same request that creates the work item
+	var relations []api.WorkItemLink
+	if parentID > 0 {
+		relations = append(relations, client.ParentLink(parentID))
+	}
+	for _, relatedID := range createRelatedFlag {
+		relations = append(relations, client.RelatedLink(relatedID))
+	}
+
+	if createValidateFlag {
+		return validateNewWorkItems(client, workItemType, fields, relations, template)
+	}
+
+	return createWorkItemWithChildren(client, workItemType, fields, relations, parentID, template)
+}
+
+// createWorkItemWithChildren creates a work item with its links, prints it and
+// creates the children of its template, if any
+func createWorkItemWithChildren(client api.APIClient, workItemType string, fields map[string]interface{}, relations []api.WorkItemLink, parentID int, template *templates.Template) error {
 	// Create the work item
-	workItem, err := client.CreateWorkItem(workItemType, fields, parentID)
+	workItem, err := client.CreateWorkItemWithRelations(workItemType, fields, relations)
 	if err != nil {
 		return fmt.Errorf("failed to create work item: %w", err)
 	}
+	recent.TrackWorkItem(client.GetOrganizationURL(), workItem, recent.Created)
 
 	// Display result
 	fmt.Println("\n✓ Work item created successfully!")
@@ -354,10 +376,17 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  ID: %d\n", *workItem.Id)
 	}
 	fmt.Printf("  Type: %s\n", workItemType)
-	fmt.Printf("  Title: %s\n", title)
+	fmt.Printf("  Title: %v\n", fields["System.Title"])
 	if parentID > 0 {
 		fmt.Printf("  Parent ID: %d\n", parentID)
 	}
+	if len(createRelatedFlag) > 0 {
+		related := make([]string, len(createRelatedFlag))
+		for i, relatedID := range createRelatedFlag {
+			related[i] = fmt.Sprintf("#%d", relatedID)
+		}
+		fmt.Printf("  Related: %s\n", strings.Join(related, ", "))
+	}
 	if workItem.Url != nil {
 		fmt.Printf("  URL: %s\n", *workItem.Url)
 	}
@@ -366,70 +395,73 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	if template != nil && template.Relations != nil && len(template.Relations.Children) > 0 && workItem.Id != nil {
 		fmt.Printf("\nCreating %d child work items...\n", len(template.Relations.Children))
 
-		for i, child := range template.Relations.Children {
-			childFields := make(map[string]interface{})
-
-			// Use child-specific values or defaults
-			childType := child.Type
-			if childType == "" {
-				childType = "Task" // Default child type
-			}
-
-			childFields["System.Title"] = child.Title
-
-			if child.Description != "" {
-				childFields["System.Description"] = child.Description
-			}
-
-			if child.AssignedTo != "" {
-				if child.AssignedTo == "@me" {
-					// Leave empty for current user
-				} else {
-					childFields["System.AssignedTo"] = child.AssignedTo
-				}
+		// Children are created a few at a time, each with its parent link
+		children := template.Relations.Children
+		parentLink := client.ParentLink(*workItem.Id)
+		progress := newBulkProgress(len(children))
+		api.ForEach(len(children), func(i int) {
+			childType, childFields := children[i].WorkItemFields(fields)
+			childWorkItem, err := client.CreateWorkItemWithRelations(childType, childFields, []api.WorkItemLink{parentLink})
+			if err != nil {
+				progress.Failure(fmt.Sprintf("  ✗ Failed to create child %d (%s): %v", i+1, children[i].Title, err))
+				return
 			}
+			progress.Success(fmt.Sprintf("  ✓ Child %d created: ID %d - %s", i+1, *childWorkItem.Id, children[i].Title))
+		})
+		progress.Finish()
 
-			// Add any additional fields from the child template
-			for fieldName, fieldValue := range child.Fields {
-				childFields[fieldName] = fieldValue
-			}
+		if _, failed := progress.Counts(); failed > 0 {
+			return fmt.Errorf("%d of %d child work item(s) could not be created under #%d", failed, len(children), *workItem.Id)
+		}
+	}
 
-			// Inherit fields from parent if not specified in child
-			// Inherit AreaPath
-			if _, hasAreaPath := childFields["System.AreaPath"]; !hasAreaPath && areaPath != "" {
-				childFields["System.AreaPath"] = areaPath
-			}
+	return nil
+}
 
-			// Inherit IterationPath
-			if _, hasIteration := childFields["System.IterationPath"]; !hasIteration && iteration != "" {
-				childFields["System.IterationPath"] = iteration
-			}
+// validateNewWorkItems checks a work item, and the children of its template,
+// against the rules of their types and reports every rule they break
+func validateNewWorkItems(client api.APIClient, workItemType string, fields map[string]interface{}, relations []api.WorkItemLink, template *templates.Template) error {
+	invalid := 0
+	report := func(label, itemType string, itemFields map[string]interface{}, relations []api.WorkItemLink) error {
+		fieldErrors, err := client.ValidateWorkItem(itemType, itemFields, relations)
+		if err != nil {
+			return err
+		}
+		if len(fieldErrors) == 0 {
+			fmt.Printf("✓ %s is valid\n", label)
+			return nil
+		}
 
-			// Inherit Custom.ApplicationName from parent
-			if parentAppName, hasParentAppName := fields["Custom.ApplicationName"]; hasParentAppName {
-				if _, hasChildAppName := childFields["Custom.ApplicationName"]; !hasChildAppName {
-					childFields["Custom.ApplicationName"] = parentAppName
-				}
+		invalid++
+		fmt.Printf("✗ %s is not valid:\n", label)
+		for _, fieldError := range fieldErrors {
+			if fieldError.Field != "" {
+				fmt.Printf("  %s: %s\n", fieldError.Field, fieldError.Message)
+			} else {
+				fmt.Printf("  %s\n", fieldError.Message)
 			}
+		}
+		return nil
+	}
 
-			// Inherit other custom fields from parent template if present
-			for customFieldKey, customFieldValue := range customFields {
-				if _, hasField := childFields[customFieldKey]; !hasField {
-					childFields[customFieldKey] = customFieldValue
-				}
-			}
+	if err := report(fmt.Sprintf("%s %q", workItemType, fields["System.Title"]), workItemType, fields, relations); err != nil {
+		return err
+	}
 
-			// Create child work item with parent relationship
-			childWorkItem, err := client.CreateWorkItem(childType, childFields, *workItem.Id)
-			if err != nil {
-				fmt.Printf("  ✗ Failed to create child %d (%s): %v\n", i+1, child.Title, err)
-				continue
+	if template != nil && template.Relations != nil {
+		for i, child := range template.Relations.Children {
+			childType, childFields := child.WorkItemFields(fields)
+			// The parent does not exist yet, so children are checked without the link
+			if err := report(fmt.Sprintf("Child %d (%s %q)", i+1, childType, child.Title), childType, childFields, nil); err != nil {
+				return err
 			}
-
-			fmt.Printf("  ✓ Child %d created: ID %d - %s\n", i+1, *childWorkItem.Id, child.Title)
 		}
 	}
 
+	if invalid > 0 {
+		return fmt.Errorf("%d work item(s) failed validation; nothing was created", invalid)
+	}
+	fmt.Println("\nNothing was created (--validate)")
 	return nil
 }
 
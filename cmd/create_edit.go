@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/editor"
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+// draftHeader explains the draft file opened by 'azb create --edit'
+const draftHeader = `# New work item. Fill in the fields, then save and close the editor to create it.
+# Fields left empty are not set. Clear the title to cancel.
+`
+
+// runCreateEditor drafts a new work item in $EDITOR, starting from a template
+// or from the fields of the work item type, and creates it once it validates
+func runCreateEditor(client api.APIClient, cfg *config.Config, template *templates.Template) error {
+	workItemType := createTypeFlag
+	if workItemType == "" && template != nil {
+		workItemType = template.Type
+	}
+	if workItemType == "" {
+		var err error
+		if workItemType, err = promptWorkItemType(); err != nil {
+			return err
+		}
+	}
+
+	requiredFields, err := client.GetRequiredFields(workItemType)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Could not determine required fields: %v\n", err)
+	}
+	draft := newWorkItemDraft(workItemType, template, requiredFields, cfg)
+	if err := applyCreateFlags(draft); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(draft)
+	if err != nil {
+		return fmt.Errorf("failed to serialize work item draft: %w", err)
+	}
+	file, err := os.CreateTemp("", "azb-create-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to create draft file: %w", err)
+	}
+	path := file.Name()
+	defer os.Remove(path)
+	if _, err := file.Write(append([]byte(draftHeader), data...)); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write draft file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write draft file: %w", err)
+	}
+
+	for {
+		if err := openEditor(path); err != nil {
+			return err
+		}
+
+		edited, problems, err := readWorkItemDraft(path)
+		if err != nil {
+			problems = []string{err.Error()}
+		} else if edited.Fields["System.Title"] == nil {
+			fmt.Println("Title is empty; nothing was created")
+			return nil
+		}
+
+		relations := draftRelations(client, edited)
+		if len(problems) == 0 {
+			fieldErrors, err := client.ValidateWorkItem(edited.Type, edited.Fields, relations)
+			if err != nil {
+				return err
+			}
+			for _, fieldError := range fieldErrors {
+				if fieldError.Field != "" {
+					problems = append(problems, fmt.Sprintf("%s: %s", fieldError.Field, fieldError.Message))
+				} else {
+					problems = append(problems, fieldError.Message)
+				}
+			}
+		}
+
+		if len(problems) == 0 {
+			return createDraftedWorkItem(client, edited, relations)
+		}
+
+		fmt.Println("✗ The work item is not valid:")
+		for _, problem := range problems {
+			fmt.Printf("  - %s\n", problem)
+		}
+		//nolint:errcheck // User input is optional; errors default to empty string
+		answer, _ := promptOptional("Edit again? (Y/n)")
+		if answer == "n" || answer == "N" {
+			fmt.Println("Nothing was created")
+			return nil
+		}
+	}
+}
+
+// newWorkItemDraft returns the draft opened in the editor: the template's
+// fields, or the common and required fields of the type with config defaults
+func newWorkItemDraft(workItemType string, template *templates.Template, requiredFields []string, cfg *config.Config) *templates.Template {
+	draft := &templates.Template{Name: "draft", Type: workItemType, Fields: map[string]interface{}{}}
+	if template != nil {
+		draft.Description = template.Description
+		if template.Relations != nil {
+			relations := *template.Relations
+			draft.Relations = &relations
+		}
+		for name, value := range template.Fields {
+			draft.Fields[name] = value
+		}
+	} else {
+		draft.Fields["System.Title"] = ""
+		draft.Fields["System.Description"] = ""
+		draft.Fields["System.AssignedTo"] = ""
+		draft.Fields["System.AreaPath"] = cfg.DefaultAreaPath
+		draft.Fields["System.IterationPath"] = cfg.DefaultIteration
+		draft.Fields["System.Tags"] = ""
+		draft.Fields["Microsoft.VSTS.Common.Priority"] = 2
+	}
+	for _, name := range requiredFields {
+		if _, ok := draft.Fields[name]; !ok {
+			draft.Fields[name] = ""
+		}
+	}
+	// State is set by Azure DevOps when the work item is created
+	delete(draft.Fields, "System.State")
+	return draft
+}
+
+// applyCreateFlags puts the field flags given to 'azb create' into a draft
+func applyCreateFlags(draft *templates.Template) error {
+	set := func(name, value string) {
+		if value != "" {
+			draft.Fields[name] = value
+		}
+	}
+	set("System.Title", createTitleFlag)
+	set("System.Description", createDescriptionFlag)
+	set("System.AssignedTo", createAssignedToFlag)
+	set("System.AreaPath", createAreaPathFlag)
+	set("System.IterationPath", createIterationFlag)
+	set("System.Tags", createTagsFlag)
+	if createPriorityFlag > 0 {
+		draft.Fields["Microsoft.VSTS.Common.Priority"] = createPriorityFlag
+	}
+	for _, fieldArg := range createFieldsFlag {
+		name, value, ok := strings.Cut(fieldArg, "=")
+		if !ok {
+			return fmt.Errorf("invalid field format '%s', expected 'FieldName=value'", fieldArg)
+		}
+		draft.Fields[name] = value
+	}
+	if createParentIDFlag > 0 {
+		if draft.Relations == nil {
+			draft.Relations = &templates.Relations{}
+		}
+		draft.Relations.ParentID = createParentIDFlag
+	}
+	return nil
+}
+
+// readWorkItemDraft reads an edited draft. Empty fields are dropped and @me
+// assignments are left to Azure DevOps, like in the other create modes.
+func readWorkItemDraft(path string) (*templates.Template, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read draft: %w", err)
+	}
+	var draft templates.Template
+	if err := yaml.Unmarshal(data, &draft); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse draft: %w", err)
+	}
+
+	for name, value := range draft.Fields {
+		if value == nil || strings.TrimSpace(fmt.Sprint(value)) == "" {
+			delete(draft.Fields, name)
+		}
+	}
+	if draft.Fields["System.AssignedTo"] == "@me" {
+		delete(draft.Fields, "System.AssignedTo")
+	}
+
+	var problems []string
+	if strings.TrimSpace(draft.Type) == "" {
+		problems = append(problems, "type is required")
+	}
+	if draft.Fields == nil {
+		draft.Fields = map[string]interface{}{}
+	}
+	return &draft, problems, nil
+}
+
+// draftRelations returns the links of a draft: its parent and the --related work items
+func draftRelations(client api.APIClient, draft *templates.Template) []api.WorkItemLink {
+	if draft == nil {
+		return nil
+	}
+	var relations []api.WorkItemLink
+	if draft.Relations != nil && draft.Relations.ParentID > 0 {
+		relations = append(relations, client.ParentLink(draft.Relations.ParentID))
+	}
+	for _, relatedID := range createRelatedFlag {
+		relations = append(relations, client.RelatedLink(relatedID))
+	}
+	return relations
+}
+
+// createDraftedWorkItem creates a validated draft and the children it lists
+func createDraftedWorkItem(client api.APIClient, draft *templates.Template, relations []api.WorkItemLink) error {
+	parentID := 0
+	if draft.Relations != nil {
+		parentID = draft.Relations.ParentID
+	}
+	return createWorkItemWithChildren(client, draft.Type, draft.Fields, relations, parentID, draft)
+}
+
+// openEditor opens a file in $EDITOR, or $VISUAL, or vi, and waits for it to close
+func openEditor(path string) error {
+	editorCmd := editor.Command(path)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf("failed to open editor: %w", err)
+	}
+	return nil
+}
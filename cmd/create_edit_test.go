@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+func TestNewWorkItemDraft(t *testing.T) {
+	cfg := &config.Config{DefaultAreaPath: `Fabrikam\Web`}
+
+	draft := newWorkItemDraft("Bug", nil, []string{"Microsoft.VSTS.Common.Severity", "System.Title"}, cfg)
+	if draft.Type != "Bug" || draft.Fields["System.AreaPath"] != `Fabrikam\Web` {
+		t.Errorf("draft = %+v, want a Bug in the default area", draft)
+	}
+	if value, ok := draft.Fields["Microsoft.VSTS.Common.Severity"]; !ok || value != "" {
+		t.Errorf("required field missing from draft: %+v", draft.Fields)
+	}
+
+	template := &templates.Template{Type: "Task", Fields: map[string]interface{}{"System.Title": "Deploy", "System.State": "New"}}
+	draft = newWorkItemDraft("Task", template, nil, cfg)
+	want := map[string]interface{}{"System.Title": "Deploy"}
+	if !reflect.DeepEqual(draft.Fields, want) {
+		t.Errorf("draft from template fields = %v, want %v", draft.Fields, want)
+	}
+	if _, ok := template.Fields["System.State"]; !ok {
+		t.Error("newWorkItemDraft() changed the template")
+	}
+}
+
+func TestReadWorkItemDraft(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "draft.yaml")
+	content := draftHeader + `name: draft
+type: Bug
+fields:
+  System.Title: Login fails
+  System.Description: ""
+  System.AssignedTo: "@me"
+  Microsoft.VSTS.Common.Priority: 1
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	draft, problems, err := readWorkItemDraft(path)
+	if err != nil || len(problems) > 0 {
+		t.Fatalf("readWorkItemDraft() = %v, %v", problems, err)
+	}
+	want := map[string]interface{}{"System.Title": "Login fails", "Microsoft.VSTS.Common.Priority": 1}
+	if !reflect.DeepEqual(draft.Fields, want) {
+		t.Errorf("fields = %v, want %v", draft.Fields, want)
+	}
+
+	if err := os.WriteFile(path, []byte("fields:\n  System.Title: x\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, problems, _ := readWorkItemDraft(path); len(problems) != 1 {
+		t.Errorf("draft without a type: problems = %v, want one", problems)
+	}
+}
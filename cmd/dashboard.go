@@ -2,9 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
 	"github.com/SOMUCHDOG/azb/internal/auth"
@@ -12,20 +17,51 @@ import (
 	"github.com/SOMUCHDOG/azb/internal/tui"
 )
 
-var dashboardCmd = &cobra.Command{
-	Use:   "dashboard",
-	Short: "Launch interactive TUI dashboard",
-	Long:  `Launch an interactive terminal user interface for managing Azure Boards work items.`,
-	RunE:  runDashboard,
-}
+var (
+	dashboardDemoFlag bool
+
+	dashboardCmd = &cobra.Command{
+		Use:   "dashboard",
+		Short: "Launch interactive TUI dashboard",
+		Long: `Launch an interactive terminal user interface for managing Azure Boards work items.
+
+With --demo the dashboard shows a sample project built into azb instead of
+your organization, without needing a token or network access. The demo is
+read-only.
+
+Examples:
+  azb dashboard
+  azb dashboard --demo`,
+		RunE: runDashboard,
+	}
+)
 
 func init() {
 	rootCmd.AddCommand(dashboardCmd)
+
+	dashboardCmd.Flags().BoolVar(&dashboardDemoFlag, "demo", false, "Show a built-in sample project instead of your organization")
 }
 
 func runDashboard(cmd *cobra.Command, args []string) error {
-	// Check authentication
-	token, err := auth.GetToken()
+	if dashboardDemoFlag {
+		return runDemoDashboard()
+	}
+
+	// Offer the setup wizard instead of failing on a missing token, organization or project
+	if !dashboardConfigured() && term.IsTerminal(int(os.Stdin.Fd())) {
+		response, err := promptOptional("azb is not set up yet. Run the setup wizard now? (Y/n)")
+		if err != nil {
+			return err
+		}
+		if response := strings.ToLower(response); response == "" || response == "y" || response == "yes" {
+			if err := runInit(cmd, nil); err != nil {
+				return err
+			}
+			fmt.Println()
+		}
+	}
+
+	client, err := newClient()
 	if err != nil {
 		return err
 	}
@@ -36,32 +72,177 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Get organization and project
-	org := viper.GetString("organization")
-	if org == "" {
-		org = cfg.Organization
-	}
-	if org == "" {
-		return fmt.Errorf("organization not configured. Run 'azb config set organization <org>'")
+	applyDashboardTheme(cfg)
+
+	// Configure the queries the Work Items tab can show
+	views := make([]tui.WorkItemView, len(cfg.Dashboard.Views))
+	for i, view := range cfg.Dashboard.Views {
+		views[i] = tui.WorkItemView{Name: view.Name, Query: view.Query}
 	}
+	tui.SetWorkItemViews(cfg.Dashboard.DefaultQuery, views)
+	tui.SetCreateDefaults(cfg.DefaultAreaPath, cfg.DefaultIteration)
 
-	project := viper.GetString("project")
-	if project == "" {
-		project = cfg.Project
+	if err := applyNotificationTimeouts(cfg); err != nil {
+		return err
 	}
-	if project == "" {
-		return fmt.Errorf("project not configured. Run 'azb config set project <project>'")
+	interval, err := dashboardRefreshInterval(cfg.Dashboard)
+	if err != nil {
+		return err
+	}
+	tui.SetRefreshInterval(interval)
+	if err := applyDetailsLayout(cfg); err != nil {
+		return err
+	}
+	if err := applyConfirmDefault(cfg); err != nil {
+		return err
 	}
 
-	// Build organization URL
-	orgURL := api.NormalizeOrganizationURL(org)
+	// Create and run TUI
+	return tui.Run(client)
+}
 
-	// Create API client
-	client, err := api.NewClient(orgURL, project, token)
+// runDemoDashboard runs the dashboard on the sample project built into azb
+func runDemoDashboard() error {
+	client, err := api.NewDemoClient()
 	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+		return fmt.Errorf("failed to start the demo: %w", err)
 	}
 
-	// Create and run TUI
+	// Saved views refer to the user's project, so only the theme, timeouts, layout and dialogs are configured
+	if cfg, err := config.Load(); err == nil {
+		applyDashboardTheme(cfg)
+		if err := applyNotificationTimeouts(cfg); err != nil {
+			return err
+		}
+		if err := applyDetailsLayout(cfg); err != nil {
+			return err
+		}
+		if err := applyConfirmDefault(cfg); err != nil {
+			return err
+		}
+	}
 	return tui.Run(client)
 }
+
+// applyDashboardTheme applies the configured color theme
+func applyDashboardTheme(cfg *config.Config) {
+	theme, err := tui.LoadTheme(cfg.Theme)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using the default theme\n", err)
+		return
+	}
+	tui.ApplyTheme(theme)
+}
+
+// applyNotificationTimeouts applies dashboard.notification_timeout and
+// dashboard.error_timeout; unset timeouts keep the defaults
+func applyNotificationTimeouts(cfg *config.Config) error {
+	success, errorTimeout, err := notificationTimeouts(cfg.Dashboard)
+	if err != nil {
+		return err
+	}
+	tui.SetNotificationTimeouts(success, errorTimeout)
+	return nil
+}
+
+// notificationTimeouts parses the notification timeouts of the dashboard config
+func notificationTimeouts(dashboard config.DashboardConfig) (time.Duration, time.Duration, error) {
+	success, errorTimeout := tui.DefaultNotificationTimeout, tui.DefaultErrorTimeout
+	parse := func(key, value string, timeout *time.Duration) error {
+		if value == "" {
+			return nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid %s '%s' (expected a duration such as 5s, or 0 to keep notifications until replaced)", key, value)
+		}
+		*timeout = d
+		return nil
+	}
+	if err := parse("dashboard.notification_timeout", dashboard.NotificationTimeout, &success); err != nil {
+		return 0, 0, err
+	}
+	if err := parse("dashboard.error_timeout", dashboard.ErrorTimeout, &errorTimeout); err != nil {
+		return 0, 0, err
+	}
+	return success, errorTimeout, nil
+}
+
+// dashboardRefreshInterval parses dashboard.refresh_interval; unset disables auto-refresh
+func dashboardRefreshInterval(dashboard config.DashboardConfig) (time.Duration, error) {
+	if dashboard.RefreshInterval == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(dashboard.RefreshInterval)
+	if err != nil || interval < 0 {
+		return 0, fmt.Errorf("invalid dashboard.refresh_interval '%s' (expected a duration such as 2m, or 0 to disable auto-refresh)", dashboard.RefreshInterval)
+	}
+	return interval, nil
+}
+
+// applyDetailsLayout applies dashboard.details_layout and dashboard.details_size
+func applyDetailsLayout(cfg *config.Config) error {
+	layout, size, err := detailsLayout(cfg.Dashboard)
+	if err != nil {
+		return err
+	}
+	tui.SetDetailsLayout(layout, size)
+	return nil
+}
+
+// detailsLayout parses dashboard.details_layout and dashboard.details_size
+func detailsLayout(dashboard config.DashboardConfig) (tui.DetailsLayout, int, error) {
+	layout, err := tui.ParseDetailsLayout(dashboard.DetailsLayout)
+	if err != nil {
+		return "", 0, err
+	}
+	size := tui.DefaultDetailsSize
+	if dashboard.DetailsSize != "" {
+		size, err = strconv.Atoi(strings.TrimSuffix(dashboard.DetailsSize, "%"))
+		if err != nil || size < tui.MinDetailsSize || size > tui.MaxDetailsSize {
+			return "", 0, fmt.Errorf("invalid dashboard.details_size '%s' (expected a percentage from %d to %d)", dashboard.DetailsSize, tui.MinDetailsSize, tui.MaxDetailsSize)
+		}
+	}
+	return layout, size, nil
+}
+
+// applyConfirmDefault applies dashboard.confirm_default
+func applyConfirmDefault(cfg *config.Config) error {
+	yes, err := confirmDefault(cfg.Dashboard)
+	if err != nil {
+		return err
+	}
+	tui.SetConfirmDefault(yes)
+	return nil
+}
+
+// confirmDefault parses dashboard.confirm_default; unset selects "No"
+func confirmDefault(dashboard config.DashboardConfig) (bool, error) {
+	switch strings.ToLower(dashboard.ConfirmDefault) {
+	case "", "no":
+		return false, nil
+	case "yes":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid dashboard.confirm_default '%s' (expected yes or no)", dashboard.ConfirmDefault)
+}
+
+// dashboardConfigured reports whether a token, organization and project are all set
+func dashboardConfigured() bool {
+	if !auth.IsAuthenticated() {
+		return false
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return false
+	}
+	org := viper.GetString("organization")
+	if org == "" {
+		org = cfg.Organization
+	}
+	project := viper.GetString("project")
+	if project == "" {
+		project = cfg.Project
+	}
+	return org != "" && project != ""
+}
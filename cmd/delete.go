@@ -4,26 +4,30 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
-	"github.com/SOMUCHDOG/azb/internal/auth"
-	"github.com/SOMUCHDOG/azb/internal/config"
 )
 
 var (
-	deleteForceFlag bool
+	deleteForceFlag      bool
+	deleteDryRunFlag     bool
+	deletePlanFormatFlag string
+	deleteApplyFlag      string
 
 	deleteCmd = &cobra.Command{
-		Use:   "delete <id> [id2,id3...]",
+		Use:   "delete <id|range>...",
 		Short: "Delete work item(s)",
-		Long:  `Delete one or more work items. Provide a single ID or comma-separated IDs for bulk deletion.`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runDelete,
+		Long: `Delete one or more work items. IDs can be given as separate arguments, as
+comma-separated lists and as ranges, e.g. 'azb delete 100-105 120'.
+
+Use --dry-run to review which work items would be deleted. A plan saved with
+--plan-format json can be applied later with --apply <file>.`,
+		Args:        cobra.ArbitraryArgs,
+		Annotations: mutatingAnnotations,
+		RunE:        runDelete,
 	}
 )
 
@@ -31,58 +35,50 @@ func init() {
 	rootCmd.AddCommand(deleteCmd)
 
 	deleteCmd.Flags().BoolVarP(&deleteForceFlag, "force", "f", false, "Skip confirmation prompt")
+	deleteCmd.Flags().BoolVar(&deleteDryRunFlag, "dry-run", false, "Show the work items that would be deleted without deleting them")
+	deleteCmd.Flags().StringVar(&deletePlanFormatFlag, "plan-format", "table", "Dry run output format (table, json)")
+	deleteCmd.Flags().StringVar(&deleteApplyFlag, "apply", "", "Apply a plan file created with --dry-run --plan-format json")
+	addConcurrencyFlag(deleteCmd)
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
-	// Parse work item IDs (supports single ID or comma-separated list)
-	idsStr := args[0]
-	idsList := strings.Split(idsStr, ",")
-
-	var ids []int
-	for _, idStr := range idsList {
-		id, err := strconv.Atoi(strings.TrimSpace(idStr))
+	// Apply a previously reviewed plan
+	if deleteApplyFlag != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--apply takes the work item IDs from the plan; do not pass IDs")
+		}
+		client, err := newClient()
 		if err != nil {
-			return fmt.Errorf("invalid work item ID: %s", idStr)
+			return err
 		}
-		ids = append(ids, id)
+		return applyDeletePlan(client, deleteApplyFlag)
 	}
 
-	// Check authentication
-	token, err := auth.GetToken()
+	// Parse work item IDs
+	ids, err := parseWorkItemIDs(args)
 	if err != nil {
 		return err
 	}
 
-	// Load config
-	cfg, err := config.Load()
+	client, err := newClient()
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// Get organization and project
-	org := viper.GetString("organization")
-	if org == "" {
-		org = cfg.Organization
-	}
-	if org == "" {
-		return fmt.Errorf("organization not configured. Run 'azb config set organization <org>'")
-	}
-
-	project := viper.GetString("project")
-	if project == "" {
-		project = cfg.Project
-	}
-	if project == "" {
-		return fmt.Errorf("project not configured. Run 'azb config set project <project>'")
+		return err
 	}
 
-	// Build organization URL
-	orgURL := api.NormalizeOrganizationURL(org)
-
-	// Create API client
-	client, err := api.NewClient(orgURL, project, token)
-	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+	if deleteDryRunFlag {
+		if err := resolveFormat(cmd, "plan-format", &deletePlanFormatFlag, "table", "json"); err != nil {
+			return err
+		}
+		p := newPlan("delete", client)
+		for _, id := range ids {
+			workItem, err := client.GetWorkItem(id)
+			if err != nil {
+				p.Items = append(p.Items, planItem{ID: id, Error: err.Error()})
+				continue
+			}
+			p.Items = append(p.Items, planItem{ID: id, Title: workItemTitle(workItem), Delete: true})
+		}
+		return printPlan(p, deletePlanFormatFlag)
 	}
 
 	// Confirmation prompt unless --force is specified
@@ -121,21 +117,53 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Delete each work item
-	var successCount, failCount int
-	for _, id := range ids {
-		err := client.DeleteWorkItem(id)
-		if err != nil {
-			fmt.Printf("✗ Failed to delete work item %d: %v\n", id, err)
-			failCount++
-			continue
+	// Delete the work items, a few at a time
+	progress := newBulkProgress(len(ids))
+	api.ForEach(len(ids), func(i int) {
+		if err := client.DeleteWorkItem(ids[i]); err != nil {
+			progress.Failure(fmt.Sprintf("✗ Failed to delete work item %d: %v", ids[i], err))
+			return
 		}
+		progress.Success(fmt.Sprintf("✓ Deleted work item %d", ids[i]))
+	})
+	progress.Finish()
+	successCount, failCount := progress.Counts()
 
-		fmt.Printf("✓ Deleted work item %d\n", id)
-		successCount++
+	// Summary
+	fmt.Printf("\nSummary: %d deleted, %d failed\n", successCount, failCount)
+
+	if failCount > 0 {
+		return fmt.Errorf("some work items failed to delete")
 	}
 
-	// Summary
+	return nil
+}
+
+// applyDeletePlan deletes the work items listed in a saved plan
+func applyDeletePlan(client api.APIClient, path string) error {
+	p, err := loadPlan(path, "delete", client)
+	if err != nil {
+		return err
+	}
+
+	progress := newBulkProgress(len(p.Items))
+	api.ForEach(len(p.Items), func(i int) {
+		item := p.Items[i]
+		if !item.Delete {
+			progress.Failure(fmt.Sprintf("✗ Skipped work item %d: nothing was planned", item.ID))
+			return
+		}
+
+		if err := client.DeleteWorkItem(item.ID); err != nil {
+			progress.Failure(fmt.Sprintf("✗ Failed to delete work item %d: %v", item.ID, err))
+			return
+		}
+
+		progress.Success(fmt.Sprintf("✓ Deleted work item %d", item.ID))
+	})
+	progress.Finish()
+	successCount, failCount := progress.Counts()
+
 	fmt.Printf("\nSummary: %d deleted, %d failed\n", successCount, failCount)
 
 	if failCount > 0 {
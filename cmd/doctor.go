@@ -0,0 +1,496 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/SOMUCHDOG/azb/internal/auth"
+	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/tui"
+)
+
+var (
+	doctorFixFlag bool
+
+	doctorCmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Check configuration and keybindings for problems",
+		Long: `Check config.yaml and keybinds.yaml for malformed YAML, unknown keys and
+invalid values, and check that you are logged in.
+
+With --fix, fixable problems are repaired: files are rewritten in a consistent
+format, invalid values are dropped so their defaults apply, and sections of a
+malformed file that can still be read are kept. The original file is backed
+up next to it first.
+
+Examples:
+  azb doctor
+  azb doctor --fix`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runDoctor,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVar(&doctorFixFlag, "fix", false, "Repair fixable problems (originals are backed up)")
+}
+
+// handEditedConfigKeys are config keys that 'azb config set' does not manage;
+// every other key config.yaml understands is one of configSettings
+var handEditedConfigKeys = []string{"notifications", "dashboard.views", "personal_access_token"}
+
+// knownConfigKey reports whether key, e.g. "dashboard.refresh_interval", is a
+// config key, and whether it is a section holding config keys
+func knownConfigKey(key string) (known, section bool) {
+	keys := append(slices.Clone(handEditedConfigKeys), configSetKeys...)
+	for _, k := range keys {
+		if k == key {
+			known = true
+		} else if strings.HasPrefix(k, key+".") {
+			section = true
+		}
+	}
+	return known, section
+}
+
+// configEntry returns the mapping of doc that holds key and the key's last part
+func configEntry(doc map[string]interface{}, key string) (map[string]interface{}, string) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		section, ok := doc[part].(map[string]interface{})
+		if !ok {
+			return nil, ""
+		}
+		doc = section
+	}
+	return doc, parts[len(parts)-1]
+}
+
+// doctorReport collects the findings for one file
+type doctorReport struct {
+	fixes    []string // Problems --fix repairs
+	warnings []string // Problems that need a manual change
+}
+
+func (r *doctorReport) fix(format string, args ...interface{}) {
+	r.fixes = append(r.fixes, fmt.Sprintf(format, args...))
+}
+
+func (r *doctorReport) warn(format string, args ...interface{}) {
+	r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	if err := textOutputOnly(cmd); err != nil {
+		return err
+	}
+
+	configDir, err := config.EnsureConfigDir()
+	if err != nil {
+		return err
+	}
+
+	configPath := viper.ConfigFileUsed()
+	if configPath == "" {
+		configPath = filepath.Join(configDir, "config.yaml")
+	}
+
+	problems := 0
+	problems += doctorFile("config", configPath, repairConfig)
+	problems += doctorFile("keybindings", filepath.Join(configDir, "keybinds.yaml"), repairKeybinds)
+
+	fmt.Println("\nAuthentication")
+	if _, err := auth.GetToken(); err != nil {
+		fmt.Printf("  ✗ %v\n", err)
+		problems++
+	} else {
+		fmt.Println("  ✓ Token found")
+	}
+
+	fmt.Println()
+	if problems > 0 {
+		return fmt.Errorf("found %d problem(s)", problems)
+	}
+	fmt.Println("✓ No problems found")
+	return nil
+}
+
+// doctorFile checks a YAML file with repair and, with --fix, writes the repaired
+// version after backing up the original. Returns the number of problems left.
+func doctorFile(label, path string, repair func(map[string]interface{}, *doctorReport) ([]byte, error)) int {
+	fmt.Printf("\n%s (%s)\n", strings.ToUpper(label[:1])+label[1:], path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Println("  ✓ Not created yet, defaults apply")
+			return 0
+		}
+		fmt.Printf("  ✗ Failed to read file: %v\n", err)
+		return 1
+	}
+
+	report := &doctorReport{}
+	doc := parseYAMLSections(data, report)
+	fixed, err := repair(doc, report)
+	if err != nil {
+		fmt.Printf("  ✗ %v\n", err)
+		return 1
+	}
+
+	for _, warning := range report.warnings {
+		fmt.Printf("  ✗ %s (fix manually)\n", warning)
+	}
+
+	if !doctorFixFlag {
+		for _, fix := range report.fixes {
+			fmt.Printf("  ✗ %s\n", fix)
+		}
+		if len(report.fixes) == 0 && len(report.warnings) == 0 {
+			fmt.Println("  ✓ No problems found")
+		} else if len(report.fixes) > 0 {
+			fmt.Println("  Run 'azb doctor --fix' to repair")
+		}
+		return len(report.fixes) + len(report.warnings)
+	}
+
+	// Rewrite even without problems so hand-edited files get consistent formatting
+	if bytes.Equal(bytes.TrimSpace(data), bytes.TrimSpace(fixed)) {
+		if len(report.warnings) == 0 {
+			fmt.Println("  ✓ No problems found")
+		}
+		return len(report.warnings)
+	}
+
+	backup := fmt.Sprintf("%s.%s.bak", path, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(backup, data, 0600); err != nil {
+		fmt.Printf("  ✗ Failed to back up %s: %v\n", path, err)
+		return len(report.fixes) + len(report.warnings)
+	}
+	if err := os.WriteFile(path, fixed, 0600); err != nil {
+		fmt.Printf("  ✗ Failed to write %s: %v\n", path, err)
+		return len(report.fixes) + len(report.warnings)
+	}
+
+	for _, fix := range report.fixes {
+		fmt.Printf("  ✓ Fixed: %s\n", fix)
+	}
+	fmt.Printf("  ✓ Rewrote %s (original saved as %s)\n", filepath.Base(path), filepath.Base(backup))
+	return len(report.warnings)
+}
+
+// parseYAMLSections parses a YAML mapping. If the document is malformed, each
+// top-level key is parsed on its own so one broken section doesn't lose the rest.
+func parseYAMLSections(data []byte, report *doctorReport) map[string]interface{} {
+	var doc map[string]interface{}
+	err := yaml.Unmarshal(data, &doc)
+	if err == nil {
+		if doc == nil {
+			doc = make(map[string]interface{})
+		}
+		return doc
+	}
+	report.fix("invalid YAML: %v", err)
+
+	doc = make(map[string]interface{})
+	for _, section := range splitTopLevelSections(data) {
+		var part map[string]interface{}
+		if err := yaml.Unmarshal(section.text, &part); err != nil {
+			report.fix("line %d: dropped unreadable section '%s'", section.line, section.key)
+			continue
+		}
+		for k, v := range part {
+			doc[k] = v
+		}
+	}
+	return doc
+}
+
+// yamlSection is a top-level key and the indented lines below it
+type yamlSection struct {
+	key  string
+	line int
+	text []byte
+}
+
+// splitTopLevelSections splits a YAML document at unindented keys
+func splitTopLevelSections(data []byte) []yamlSection {
+	var sections []yamlSection
+	for i, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		topLevel := line != "" && line[0] != ' ' && line[0] != '\t' && line[0] != '-' && !strings.HasPrefix(trimmed, "#")
+		if topLevel {
+			key := trimmed
+			if idx := strings.Index(key, ":"); idx >= 0 {
+				key = key[:idx]
+			}
+			sections = append(sections, yamlSection{key: key, line: i + 1})
+		}
+		if len(sections) > 0 {
+			current := &sections[len(sections)-1]
+			current.text = append(current.text, line+"\n"...)
+		}
+	}
+	return sections
+}
+
+// normalizeKey converts hand-written key variants such as "Next-Tab" to "next_tab"
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(key), "-", "_"))
+}
+
+// repairConfig validates config.yaml and returns it pretty printed with fixes applied
+func repairConfig(doc map[string]interface{}, report *doctorReport) ([]byte, error) {
+	fixed := repairConfigKeys(doc, "", report)
+	validateConfigValues(fixed, report)
+	return yaml.Marshal(fixed)
+}
+
+// repairConfigKeys normalizes the keys of a config section, drops duplicates
+// and warns about unknown keys. prefix is the section's key followed by a dot.
+func repairConfigKeys(doc map[string]interface{}, prefix string, report *doctorReport) map[string]interface{} {
+	fixed := make(map[string]interface{})
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := doc[key]
+		name := normalizeKey(key)
+		if name != key {
+			report.fix("renamed key '%s%s' to '%s%s'", prefix, key, prefix, name)
+		}
+
+		if _, exists := fixed[name]; exists {
+			report.fix("dropped duplicate key '%s%s'", prefix, key)
+			continue
+		}
+
+		known, section := knownConfigKey(prefix + name)
+		if nested, ok := value.(map[string]interface{}); ok && section && !known {
+			value = repairConfigKeys(nested, prefix+name+".", report)
+		} else if !known {
+			report.warn("unknown key '%s%s'", prefix, key)
+		}
+		fixed[name] = value
+	}
+	return fixed
+}
+
+// validateConfigValues checks every value with the validation 'azb config set'
+// uses. Invalid values are dropped so their defaults apply, except where that
+// would lose more than it fixes; those are left for a manual fix.
+func validateConfigValues(doc map[string]interface{}, report *doctorReport) {
+	text := func(key string) string {
+		if value, ok := doc[key]; ok && value != nil {
+			return strings.TrimSpace(fmt.Sprintf("%v", value))
+		}
+		return ""
+	}
+
+	if text("organization") == "" {
+		report.warn("organization is not set; run 'azb config set organization <org>'")
+	}
+	if text("project") == "" {
+		report.warn("project is not set; run 'azb config set project <project>'")
+	}
+	if text("personal_access_token") != "" {
+		report.warn("personal_access_token is stored in plain text; run 'azb auth login' and remove it")
+	}
+	if text("default_view") == "assigned-to-me" {
+		// The old default, from before default_view chose between the dashboard and help
+		report.fix("changed default_view 'assigned-to-me' to 'dashboard'")
+		doc["default_view"] = "dashboard"
+	}
+
+	for _, setting := range configSettings {
+		section, name := configEntry(doc, setting.key)
+		value, ok := section[name]
+		if !ok || value == nil {
+			continue
+		}
+
+		err := setting.set(&config.Config{}, strings.TrimSpace(fmt.Sprintf("%v", value)))
+		switch {
+		case err == nil:
+		case setting.manualFix:
+			report.warn("%s: %v", setting.key, err)
+		default:
+			report.fix("dropped %v", err)
+			delete(section, name)
+		}
+	}
+}
+
+// repairKeybinds validates keybinds.yaml against the default keybindings. Valid
+// bindings are kept; missing, empty or invalid ones fall back to the defaults.
+func repairKeybinds(doc map[string]interface{}, report *doctorReport) ([]byte, error) {
+	defaults, err := keybindsAsMap(tui.DefaultKeybindConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make([]string, 0, len(doc))
+	for section := range doc {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+
+	for _, section := range sections {
+		name := normalizeKey(section)
+		if name != section {
+			report.fix("renamed section '%s' to '%s'", section, name)
+		}
+
+		actions, ok := defaults[name]
+		if !ok {
+			report.fix("dropped unknown section '%s'", section)
+			continue
+		}
+
+		bindings, ok := doc[section].(map[string]interface{})
+		if !ok {
+			if doc[section] != nil {
+				report.fix("section '%s' is not a list of actions; using defaults", section)
+			}
+			continue
+		}
+
+		names := make([]string, 0, len(bindings))
+		for action := range bindings {
+			names = append(names, action)
+		}
+		sort.Strings(names)
+
+		for _, action := range names {
+			value := bindings[action]
+			actionName := normalizeKey(action)
+			if actionName != action {
+				report.fix("renamed %s action '%s' to '%s'", name, action, actionName)
+			}
+			if _, ok := actions[actionName]; !ok {
+				report.fix("dropped unknown %s action '%s'", name, action)
+				continue
+			}
+
+			keys, problem := keybindKeys(value)
+			if problem != "" {
+				report.fix("%s.%s: %s", name, actionName, problem)
+			}
+			if len(keys) > 0 {
+				actions[actionName] = keys
+			}
+		}
+	}
+
+	// Round trip through KeybindConfig so sections and actions keep their usual order
+	data, err := yaml.Marshal(defaults)
+	if err != nil {
+		return nil, err
+	}
+	var config tui.KeybindConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return marshalKeybinds(config)
+}
+
+// keybindKeys reads the keys bound to an action. A single key written as a
+// string is accepted and converted to a list; anything else falls back to the default.
+func keybindKeys(value interface{}) ([]string, string) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil, "empty key, using the default"
+		}
+		return []string{v}, "single key converted to a list"
+	case []interface{}:
+		var keys []string
+		for _, item := range v {
+			k, ok := item.(string)
+			if !ok || k == "" {
+				return nil, fmt.Sprintf("invalid key '%v', using the default", item)
+			}
+			keys = append(keys, k)
+		}
+		if len(keys) == 0 {
+			return nil, "no keys bound, using the default"
+		}
+		return keys, ""
+	default:
+		return nil, fmt.Sprintf("invalid value '%v', using the default", value)
+	}
+}
+
+// keybindsAsMap converts keybindings to section -> action -> keys
+func keybindsAsMap(config tui.KeybindConfig) (map[string]map[string][]string, error) {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]map[string][]string
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// marshalKeybinds writes keybindings in the style of the default keybinds.yaml: one action per line with flow lists
+func marshalKeybinds(config tui.KeybindConfig) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(config); err != nil {
+		return nil, err
+	}
+	setFlowLists(&node)
+
+	var buf bytes.Buffer
+	buf.WriteString("# Azure Boards CLI Keybindings\n# Customize keybindings for the TUI dashboard\n# Multiple keys can be bound to the same action\n\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	// Separate sections with a blank line
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	var out strings.Builder
+	for i, line := range lines {
+		if i > 0 && line != "" && line[0] != ' ' && lines[i-1] != "" && !strings.HasPrefix(lines[i-1], "#") {
+			out.WriteString("\n")
+		}
+		out.WriteString(line + "\n")
+	}
+	return []byte(out.String()), nil
+}
+
+// setFlowLists renders sequences as ["a", "b"]
+func setFlowLists(node *yaml.Node) {
+	if node.Kind == yaml.SequenceNode {
+		node.Style = yaml.FlowStyle
+		for _, item := range node.Content {
+			item.Style = yaml.DoubleQuotedStyle
+		}
+		return
+	}
+	for _, child := range node.Content {
+		setFlowLists(child)
+	}
+}
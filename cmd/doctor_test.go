@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/SOMUCHDOG/azb/internal/tui"
+)
+
+func TestParseYAMLSectionsSalvagesReadableSections(t *testing.T) {
+	data := []byte("global:\n  quit: [\"q\"]\nqueries:\n  execute: [\"enter\"\ntemplates:\n  copy: [\"c\"]\n")
+
+	report := &doctorReport{}
+	doc := parseYAMLSections(data, report)
+
+	if _, ok := doc["global"]; !ok {
+		t.Error("expected 'global' section to be kept")
+	}
+	if _, ok := doc["templates"]; !ok {
+		t.Error("expected 'templates' section to be kept")
+	}
+	if _, ok := doc["queries"]; ok {
+		t.Error("expected malformed 'queries' section to be dropped")
+	}
+	if len(report.fixes) != 2 {
+		t.Errorf("expected 2 fixes, got %v", report.fixes)
+	}
+}
+
+func TestRepairKeybinds(t *testing.T) {
+	input := `
+global:
+  Next-Tab: ["n"]
+  quit: "x"
+  help: []
+work_items:
+  bogus: ["z"]
+`
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal([]byte(input), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	report := &doctorReport{}
+	data, err := repairKeybinds(doc, report)
+	if err != nil {
+		t.Fatalf("repairKeybinds() error = %v", err)
+	}
+
+	var config tui.KeybindConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("repaired keybinds do not parse: %v", err)
+	}
+	defaults := tui.DefaultKeybindConfig()
+
+	if got := strings.Join(config.Global.NextTab, ","); got != "n" {
+		t.Errorf("next_tab = %q, want %q", got, "n")
+	}
+	if got := strings.Join(config.Global.Quit, ","); got != "x" {
+		t.Errorf("quit = %q, want %q", got, "x")
+	}
+	if got, want := strings.Join(config.Global.Help, ","), strings.Join(defaults.Global.Help, ","); got != want {
+		t.Errorf("help = %q, want default %q", got, want)
+	}
+
+	wantFixes := []string{
+		"renamed global action 'Next-Tab' to 'next_tab'",
+		"global.quit: single key converted to a list",
+		"global.help: no keys bound, using the default",
+		"dropped unknown work_items action 'bogus'",
+	}
+	for _, want := range wantFixes {
+		found := false
+		for _, fix := range report.fixes {
+			if fix == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing fix %q in %v", want, report.fixes)
+		}
+	}
+}
+
+func TestRepairConfig(t *testing.T) {
+	doc := map[string]interface{}{
+		"organization":   "myorg",
+		"project":        "MyProject",
+		"default_format": "xml",
+		"max_retries":    "2",
+		"Default-View":   "assigned-to-me",
+		"dashboard": map[string]interface{}{
+			"refresh_interval": "soon",
+			"details_size":     40,
+			"views":            []interface{}{},
+			"colour":           "red",
+		},
+	}
+
+	report := &doctorReport{}
+	data, err := repairConfig(doc, report)
+	if err != nil {
+		t.Fatalf("repairConfig() error = %v", err)
+	}
+
+	var fixed map[string]interface{}
+	if err := yaml.Unmarshal(data, &fixed); err != nil {
+		t.Fatal(err)
+	}
+
+	if fixed["organization"] != "myorg" {
+		t.Errorf("organization = %v, want myorg", fixed["organization"])
+	}
+	if _, ok := fixed["default_format"]; ok {
+		t.Error("invalid default_format was not dropped")
+	}
+	if fixed["default_view"] != "dashboard" {
+		t.Errorf("default_view = %v, want dashboard", fixed["default_view"])
+	}
+	if fixed["max_retries"] != "2" {
+		t.Errorf("max_retries = %v, want 2", fixed["max_retries"])
+	}
+	dashboard, _ := fixed["dashboard"].(map[string]interface{})
+	if _, ok := dashboard["refresh_interval"]; ok {
+		t.Error("invalid dashboard.refresh_interval was not dropped")
+	}
+	if dashboard["details_size"] != 40 {
+		t.Errorf("dashboard.details_size = %v, want 40", dashboard["details_size"])
+	}
+	if want := []string{"unknown key 'dashboard.colour'"}; !reflect.DeepEqual(report.warnings, want) {
+		t.Errorf("warnings = %v, want %v", report.warnings, want)
+	}
+}
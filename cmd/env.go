@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// envPrefix prefixes the environment variables that override config keys,
+	// e.g. AZB_PROJECT for project or AZB_DASHBOARD_DEFAULT_QUERY for dashboard.default_query
+	envPrefix = "AZB"
+
+	// profileEnvVar names a config file in ~/.azure-boards-cli/profiles to use instead of config.yaml
+	profileEnvVar = "AZB_PROFILE"
+)
+
+// envAliases are shorter names accepted in addition to the prefixed key
+var envAliases = map[string][]string{
+	"organization": {"AZB_ORG"},
+}
+
+// envVarName returns the environment variable that overrides a config key
+func envVarName(key string) string {
+	return envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// bindEnvironment lets AZB_* environment variables override every settable
+// config key. Binding them explicitly makes them visible to config.Load even
+// when no config file exists, as in CI.
+func bindEnvironment() {
+	viper.SetEnvPrefix(envPrefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	for _, setting := range configSettings {
+		names := append([]string{setting.key, envVarName(setting.key)}, envAliases[setting.key]...)
+		//nolint:errcheck // BindEnv only fails without a key
+		viper.BindEnv(names...)
+	}
+}
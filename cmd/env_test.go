@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/SOMUCHDOG/azb/internal/config"
+)
+
+func TestBindEnvironment(t *testing.T) {
+	t.Setenv("AZB_ORG", "contoso")
+	t.Setenv("AZB_PROJECT", "Web Shop")
+	t.Setenv("AZB_DASHBOARD_DEFAULT_QUERY", "Active Bugs")
+	t.Setenv("PROJECT", "unprefixed")
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	bindEnvironment()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Organization != "contoso" || cfg.Project != "Web Shop" {
+		t.Errorf("Load() = %s/%s, want contoso/Web Shop", cfg.Organization, cfg.Project)
+	}
+	if cfg.Dashboard.DefaultQuery != "Active Bugs" {
+		t.Errorf("dashboard.default_query = %q, want Active Bugs", cfg.Dashboard.DefaultQuery)
+	}
+}
+
+func TestEnvVarName(t *testing.T) {
+	tests := map[string]string{
+		"project":                 "AZB_PROJECT",
+		"read_only":               "AZB_READ_ONLY",
+		"dashboard.default_query": "AZB_DASHBOARD_DEFAULT_QUERY",
+	}
+	for key, want := range tests {
+		if got := envVarName(key); got != want {
+			t.Errorf("envVarName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestConfigSetKeepsEnvironmentOverridesOutOfTheFile(t *testing.T) {
+	t.Setenv("AZB_PROJECT", "ciproj")
+	t.Setenv("AZB_ORG", "https://dev.azure.com/x")
+	t.Setenv("AZB_DASHBOARD_DEFAULT_QUERY", "CI Bugs")
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte("organization: contoso\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(configFile)
+	bindEnvironment()
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runConfigSet(configSetCmd, []string{"theme", "dark"}); err != nil {
+		t.Fatalf("runConfigSet() error = %v", err)
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := string(data)
+	if !strings.Contains(saved, "theme: dark") || !strings.Contains(saved, "organization: contoso") {
+		t.Errorf("config file = %q, want theme dark and the file's organization", saved)
+	}
+	for _, leaked := range []string{"ciproj", "dev.azure.com/x", "CI Bugs"} {
+		if strings.Contains(saved, leaked) {
+			t.Errorf("config file = %q, saved the environment override %q", saved, leaked)
+		}
+	}
+}
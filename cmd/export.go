@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+var (
+	exportDirFlag             string
+	exportQueryFlag           string
+	exportLimitFlag           int
+	exportWithAttachmentsFlag bool
+
+	exportCmd = &cobra.Command{
+		Use:   "export [id...]",
+		Short: "Export work items to a folder",
+		Long: `Export work items, including their comments, history and relations, to a folder.
+
+Each item is written to items/<id>/item.json in the same shape as
+'azb show --format json --comments --history --relations'.
+
+With --with-attachments the files attached to each item are downloaded into
+items/<id>/attachments, together with an index.json describing them, so the
+export is a complete offline archive.
+
+Examples:
+  azb export 1234 1235 --dir backup
+  azb export --query "My Active Bugs" --with-attachments`,
+		RunE: runExport,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVarP(&exportDirFlag, "dir", "d", "azb-export", "Folder to write the export to")
+	exportCmd.Flags().StringVarP(&exportQueryFlag, "query", "q", "", "Export the results of a saved query")
+	exportCmd.Flags().IntVarP(&exportLimitFlag, "limit", "l", 200, "Maximum number of query results to export")
+	exportCmd.Flags().BoolVar(&exportWithAttachmentsFlag, "with-attachments", false, "Download each item's attachments")
+
+	//nolint:errcheck // Flags are registered above
+	exportCmd.RegisterFlagCompletionFunc("query", completeQueryNames)
+}
+
+// exportAttachment is an entry of an item's attachments/index.json
+type exportAttachment struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	File    string `json:"file"`
+	Size    int64  `json:"size"`
+	Comment string `json:"comment,omitempty"`
+	URL     string `json:"url"`
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && exportQueryFlag == "" {
+		return fmt.Errorf("specify work item IDs or --query")
+	}
+	if len(args) > 0 && exportQueryFlag != "" {
+		return fmt.Errorf("cannot combine work item IDs with --query")
+	}
+
+	var ids []int
+	for _, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid work item ID: %s", arg)
+		}
+		ids = append(ids, id)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	if exportQueryFlag != "" {
+		query, err := api.FindQuery(client, exportQueryFlag)
+		if err != nil {
+			return err
+		}
+		if query.Id == nil {
+			return fmt.Errorf("query has no ID")
+		}
+		results, err := client.ExecuteQuery(query.Id.String(), exportLimitFlag)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		for _, wi := range *results {
+			if wi.Id != nil {
+				ids = append(ids, *wi.Id)
+			}
+		}
+	}
+
+	if len(ids) == 0 {
+		fmt.Println("No work items to export")
+		return nil
+	}
+
+	var successCount, failCount int
+	progress := newBulkProgress(len(ids))
+	for _, id := range ids {
+		attachments, err := exportWorkItem(client, id, exportDirFlag)
+		if err != nil {
+			progress.Failure(fmt.Sprintf("✗ Failed to export work item %d: %v", id, err))
+			failCount++
+			continue
+		}
+		message := fmt.Sprintf("✓ Exported work item %d", id)
+		if exportWithAttachmentsFlag {
+			message += fmt.Sprintf(" with %d attachment(s)", attachments)
+		}
+		progress.Success(message)
+		successCount++
+	}
+	progress.Finish()
+
+	fmt.Printf("\nSummary: %d exported to %s, %d failed\n", successCount, exportDirFlag, failCount)
+
+	if failCount > 0 {
+		return fmt.Errorf("some work items failed to export")
+	}
+
+	return nil
+}
+
+// exportWorkItem writes one work item and, if requested, its attachments.
+// It returns the number of attachments downloaded.
+func exportWorkItem(client api.APIClient, id int, dir string) (int, error) {
+	workItem, err := client.GetWorkItem(id)
+	if err != nil {
+		return 0, err
+	}
+
+	comments, err := client.GetComments(id)
+	if err != nil {
+		return 0, err
+	}
+
+	revisions, err := client.GetWorkItemUpdates(id)
+	if err != nil {
+		return 0, err
+	}
+
+	doc := showDocument{
+		WorkItem:     workItem,
+		Comments:     convertComments(comments),
+		History:      historyEntries(revisions),
+		RelatedItems: convertRelations(workItem.Relations),
+	}
+	resolveBuildRelations(client, *doc.RelatedItems)
+
+	itemDir := filepath.Join(dir, "items", strconv.Itoa(id))
+	if err := os.MkdirAll(itemDir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := writeJSONFile(filepath.Join(itemDir, "item.json"), doc); err != nil {
+		return 0, err
+	}
+
+	if !exportWithAttachmentsFlag {
+		return 0, nil
+	}
+	return exportAttachments(client, workItem, filepath.Join(itemDir, "attachments"))
+}
+
+// exportAttachments downloads the attached files of a work item and writes index.json
+func exportAttachments(client api.APIClient, workItem *workitemtracking.WorkItem, dir string) (int, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	index := []exportAttachment{}
+	used := make(map[string]bool)
+	if workItem.Relations != nil {
+		for _, r := range *workItem.Relations {
+			if r.Rel == nil || *r.Rel != api.AttachedFileRelation || r.Url == nil {
+				continue
+			}
+
+			attachmentID, err := api.AttachmentIDFromURL(*r.Url)
+			if err != nil {
+				return len(index), err
+			}
+
+			entry := exportAttachment{ID: attachmentID.String(), URL: *r.Url}
+			if r.Attributes != nil {
+				entry.Name, _ = (*r.Attributes)["name"].(string)
+				entry.Comment, _ = (*r.Attributes)["comment"].(string)
+			}
+			if entry.Name == "" {
+				entry.Name = entry.ID
+			}
+			entry.File = attachmentFileName(entry.Name, used)
+
+			size, err := downloadAttachment(client, attachmentID, entry.Name, filepath.Join(dir, entry.File))
+			if err != nil {
+				return len(index), err
+			}
+			entry.Size = size
+			index = append(index, entry)
+		}
+	}
+
+	if err := writeJSONFile(filepath.Join(dir, "index.json"), index); err != nil {
+		return len(index), err
+	}
+	return len(index), nil
+}
+
+// downloadAttachment saves an attachment to filePath and returns its size
+func downloadAttachment(client api.APIClient, id uuid.UUID, name, filePath string) (int64, error) {
+	content, err := client.DownloadAttachment(id, name)
+	if err != nil {
+		return 0, err
+	}
+	defer content.Close()
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	size, err := io.Copy(file, content)
+	if err != nil {
+		return size, fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return size, nil
+}
+
+// attachmentFileName returns a safe, unique file name for an attachment.
+// Work items can have several attachments with the same name, so later ones get a numeric prefix.
+func attachmentFileName(name string, used map[string]bool) string {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, "\\", "/")))
+	switch name {
+	case "", ".", "..", "/":
+		name = "attachment"
+	case "index.json":
+		// Keep the index file name free
+		name = "attachment-index.json"
+	}
+
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%d-%s", i, name)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// writeJSONFile writes v as indented JSON
+func writeJSONFile(filePath string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filePath, err)
+	}
+	if err := os.WriteFile(filePath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import "testing"
+
+func TestAttachmentFileName(t *testing.T) {
+	used := make(map[string]bool)
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"log.txt", "log.txt"},
+		{"log.txt", "2-log.txt"},
+		{"log.txt", "3-log.txt"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\temp\screen.png`, "screen.png"},
+		{"index.json", "attachment-index.json"},
+		{"..", "attachment"},
+	}
+
+	for _, tt := range tests {
+		if got := attachmentFileName(tt.name, used); got != tt.want {
+			t.Errorf("attachmentFileName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
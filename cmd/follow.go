@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	followCmd = &cobra.Command{
+		Use:   "follow <id>",
+		Short: "Get notified about changes to a work item",
+		Long: `Follow a work item, like the Follow button in the web UI. Azure DevOps sends
+you a notification whenever the work item changes, even if it is not assigned
+to you.
+
+List the work items you follow with 'azb list --following'.
+
+Examples:
+  azb follow 1234
+  azb unfollow 1234`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: mutatingAnnotations,
+		RunE:        runFollow,
+	}
+
+	unfollowCmd = &cobra.Command{
+		Use:         "unfollow <id>",
+		Short:       "Stop following a work item",
+		Args:        cobra.ExactArgs(1),
+		Annotations: mutatingAnnotations,
+		RunE:        runUnfollow,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(followCmd)
+	rootCmd.AddCommand(unfollowCmd)
+}
+
+func runFollow(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	// Fail early with a clear message if the work item does not exist
+	workItem, err := client.GetWorkItem(id)
+	if err != nil {
+		return err
+	}
+
+	added, err := client.FollowWorkItem(id)
+	if err != nil {
+		return err
+	}
+	if !added {
+		fmt.Printf("Already following #%d: %s\n", id, workItemTitle(workItem))
+		return nil
+	}
+	fmt.Printf("✓ Following #%d: %s\n", id, workItemTitle(workItem))
+	return nil
+}
+
+func runUnfollow(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	removed, err := client.UnfollowWorkItem(id)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		fmt.Printf("Not following #%d\n", id)
+		return nil
+	}
+	fmt.Printf("✓ Stopped following #%d\n", id)
+	return nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/SOMUCHDOG/azb/internal/output"
+)
+
+// formatEnvVar overrides the default_format config key
+const formatEnvVar = "AZB_FORMAT"
+
+// outputFormats are the values accepted for default_format
+var outputFormats = []string{"table", "text", "json", "yaml", "csv", "tsv", "ids", "markdown", "card"}
+
+// outputFlag is the global -o/--output format of read commands
+var outputFlag string
+
+// preferredFormat returns the user's preferred output format from AZB_FORMAT or
+// the default_format config key, or an empty string if none is set
+func preferredFormat() string {
+	if format := strings.TrimSpace(os.Getenv(formatEnvVar)); format != "" {
+		return strings.ToLower(format)
+	}
+	return strings.ToLower(strings.TrimSpace(viper.GetString("default_format")))
+}
+
+// resolveFormat sets a format flag that was not given explicitly to the
+// --output format or else the preferred output format. An --output format the
+// command does not support is an error, while an unsupported preferred format
+// keeps the command's own default, so e.g. a preferred markdown still shows
+// text for 'show'. "table" and "text" both name the human-readable format.
+func resolveFormat(cmd *cobra.Command, flagName string, value *string, supported ...string) error {
+	if cmd.Flags().Changed(flagName) {
+		return nil
+	}
+
+	if outputFlag != "" {
+		format := humanFormat(strings.ToLower(outputFlag), supported)
+		if !slices.Contains(supported, format) {
+			return fmt.Errorf("'%s' does not support --output %s (supported: %s)", cmd.CommandPath(), outputFlag, strings.Join(supported, ", "))
+		}
+		*value = format
+		return nil
+	}
+
+	if preferred := humanFormat(preferredFormat(), supported); slices.Contains(supported, preferred) {
+		*value = preferred
+	}
+	return nil
+}
+
+// humanFormat maps "table" to "text" for commands that only have text, and the other way round
+func humanFormat(format string, supported []string) string {
+	switch {
+	case format == "table" && !slices.Contains(supported, "table") && slices.Contains(supported, "text"):
+		return "text"
+	case format == "text" && !slices.Contains(supported, "text") && slices.Contains(supported, "table"):
+		return "table"
+	}
+	return format
+}
+
+// withOutputFormats adds the formats every read command supports to a command's own
+func withOutputFormats(formats ...string) []string {
+	for _, format := range output.Formats {
+		if format != "table" && !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	return formats
+}
+
+// outputFormat returns the --output or preferred format of a command without a
+// format flag of its own, or the first supported format when none is set
+func outputFormat(cmd *cobra.Command, supported ...string) (string, error) {
+	format := supported[0]
+	if err := resolveFormat(cmd, "", &format, supported...); err != nil {
+		return "", err
+	}
+	return format, nil
+}
+
+// textOutputOnly rejects an --output format other than table or text for
+// commands that only print text, so it is not silently ignored
+func textOutputOnly(cmd *cobra.Command) error {
+	format := "text"
+	return resolveFormat(cmd, "", &format, "text")
+}
+
+// writeResult writes value as JSON, filtered by --jq, or YAML, and table as
+// CSV, TSV, IDs or an aligned table
+func writeResult(format string, value interface{}, table output.Table) error {
+	if format == "json" {
+		return outputJSON(value)
+	}
+	return output.Write(os.Stdout, format, value, table)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestResolveFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		config   string
+		explicit bool
+		output   string
+		want     string
+		wantErr  bool
+	}{
+		{"no preference", "", "", false, "", "table", false},
+		{"config preference", "", "json", false, "", "json", false},
+		{"env overrides config", "csv", "json", false, "", "csv", false},
+		{"env is case insensitive", "JSON", "", false, "", "json", false},
+		{"unsupported preference keeps default", "yaml", "", false, "", "table", false},
+		{"explicit flag wins", "json", "", true, "csv", "ids", false},
+		{"output overrides preference", "json", "", false, "csv", "csv", false},
+		{"output text means table", "", "", false, "text", "table", false},
+		{"unsupported output", "", "", false, "yaml", "table", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(formatEnvVar, tt.env)
+			viper.Set("default_format", tt.config)
+			outputFlag = tt.output
+			defer func() {
+				viper.Set("default_format", "")
+				outputFlag = ""
+			}()
+
+			var format string
+			cmd := &cobra.Command{}
+			cmd.Flags().StringVar(&format, "format", "table", "")
+			if tt.explicit {
+				if err := cmd.Flags().Set("format", "ids"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := resolveFormat(cmd, "format", &format, "table", "json", "csv", "ids")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if format != tt.want {
+				t.Errorf("resolveFormat() = %q, want %q", format, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextOutputOnly(t *testing.T) {
+	tests := []struct {
+		output  string
+		wantErr bool
+	}{
+		{"", false},
+		{"table", false},
+		{"text", false},
+		{"json", true},
+		{"csv", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			outputFlag = tt.output
+			defer func() { outputFlag = "" }()
+
+			if err := textOutputOnly(&cobra.Command{Use: "doctor"}); (err != nil) != tt.wantErr {
+				t.Errorf("textOutputOnly() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{"", "text", false},
+		{"table", "text", false},
+		{"json", "json", false},
+		{"ids", "ids", false},
+		{"markdown", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			outputFlag = tt.output
+			defer func() { outputFlag = "" }()
+			t.Setenv(formatEnvVar, "")
+
+			got, err := outputFormat(&cobra.Command{Use: "tree"}, withOutputFormats("text")...)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("outputFormat() = %q, %v; want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,458 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
+)
+
+// branchSlugLength caps the title part of generated branch names
+const branchSlugLength = 40
+
+var (
+	gitFormatFlag     string
+	gitPrefixFlag     string
+	gitNoCheckoutFlag bool
+	gitHookForceFlag  bool
+
+	gitCmd = &cobra.Command{
+		Use:   "git",
+		Short: "Connect git branches to work items",
+		Long: `Find the work item of the current git branch and create branches named after work items.
+
+When the current branch names a work item, e.g. feature/1234-login-fix, 'azb show'
+and 'azb update' (including 'azb update --comment') use it when no ID is given.`,
+	}
+
+	gitCurrentCmd = &cobra.Command{
+		Use:   "current",
+		Short: "Show the work item of the current git branch",
+		Long: `Show the work item named by the current git branch.
+
+The ID is the first number in the branch name that stands on its own, looking at
+the last path segment first: feature/1234-login-fix, bug/AB#1234 and 1234 all
+resolve to work item 1234. Set git_branch_pattern to a regular expression whose
+first group captures the ID to use another naming scheme.`,
+		Args: cobra.NoArgs,
+		RunE: runGitCurrent,
+	}
+
+	gitBranchCmd = &cobra.Command{
+		Use:   "branch <id>",
+		Short: "Create a git branch named after a work item",
+		Long: `Create and check out a branch named <prefix>/<id>-<title>, e.g. feature/1234-login-fix.
+Bugs get the bugfix prefix and other types feature, unless --prefix is given.
+
+Examples:
+  azb git branch 1234
+  azb git branch 1234 --prefix hotfix
+  azb git branch 1234 --no-checkout`,
+		Args: cobra.ExactArgs(1),
+		RunE: runGitBranch,
+	}
+
+	gitInstallHookCmd = &cobra.Command{
+		Use:   "install-hook",
+		Short: "Link commits to the work item of their branch",
+		Long: `Install a prepare-commit-msg hook in the current repository that appends
+AB#<id> to commit messages, with the ID taken from the branch name. Azure Boards
+links commits that mention AB#<id> to the work item.
+
+The hook calls azb, so changes to git_branch_pattern apply without reinstalling.
+An existing hook that azb did not install is kept unless --force is given, in
+which case it is backed up as prepare-commit-msg.bak.`,
+		Args: cobra.NoArgs,
+		RunE: runGitInstallHook,
+	}
+
+	// gitPrepareCommitMsgCmd is run by the installed hook
+	gitPrepareCommitMsgCmd = &cobra.Command{
+		Use:    "prepare-commit-msg <message-file> [source]",
+		Short:  "Append AB#<id> to a commit message (used by the git hook)",
+		Args:   cobra.RangeArgs(1, 3),
+		Hidden: true,
+		RunE:   runGitPrepareCommitMsg,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(gitCmd)
+	gitCmd.AddCommand(gitCurrentCmd)
+	gitCmd.AddCommand(gitBranchCmd)
+	gitCmd.AddCommand(gitInstallHookCmd)
+	gitCmd.AddCommand(gitPrepareCommitMsgCmd)
+
+	gitCurrentCmd.Flags().StringVarP(&gitFormatFlag, "format", "f", "text", "Output format (text, json, yaml, csv, tsv, ids)")
+	gitBranchCmd.Flags().StringVar(&gitPrefixFlag, "prefix", "", "Branch prefix instead of feature or bugfix")
+	gitBranchCmd.Flags().BoolVar(&gitNoCheckoutFlag, "no-checkout", false, "Create the branch without switching to it")
+	gitInstallHookCmd.Flags().BoolVar(&gitHookForceFlag, "force", false, "Replace an existing prepare-commit-msg hook")
+}
+
+func runGitCurrent(cmd *cobra.Command, args []string) error {
+	formats := withOutputFormats("text")
+	if err := resolveFormat(cmd, "format", &gitFormatFlag, formats...); err != nil {
+		return err
+	}
+	if !slices.Contains(formats, gitFormatFlag) {
+		return fmt.Errorf("unsupported format: %s", gitFormatFlag)
+	}
+
+	branch, err := currentBranch()
+	if err != nil {
+		return err
+	}
+	id, ok, err := branchWorkItemID(branch)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("branch '%s' does not name a work item", branch)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	workItem, err := client.GetWorkItem(id)
+	if err != nil {
+		return fmt.Errorf("failed to get work item: %w", err)
+	}
+
+	if gitFormatFlag != "text" {
+		title := workItemTitle(workItem)
+		workItemType := model.FieldString(workItem.Fields, "System.WorkItemType")
+		state := model.FieldString(workItem.Fields, "System.State")
+		assignee := model.FieldString(workItem.Fields, "System.AssignedTo")
+		url := client.WorkItemURL(id)
+		return writeResult(gitFormatFlag, map[string]interface{}{
+			"branch":   branch,
+			"id":       id,
+			"title":    title,
+			"type":     workItemType,
+			"state":    state,
+			"assignee": assignee,
+			"url":      url,
+		}, output.Table{
+			Headers: []string{"ID", "Title", "Type", "State", "Assignee", "Branch", "URL"},
+			Rows:    [][]string{{strconv.Itoa(id), title, workItemType, state, assignee, branch, url}},
+		})
+	}
+
+	fmt.Printf("#%d %s\n", id, workItemTitle(workItem))
+	fmt.Printf("  Type:     %s\n", model.FieldString(workItem.Fields, "System.WorkItemType"))
+	fmt.Printf("  State:    %s\n", model.FieldString(workItem.Fields, "System.State"))
+	fmt.Printf("  Assigned: %s\n", model.FieldString(workItem.Fields, "System.AssignedTo"))
+	fmt.Printf("  Branch:   %s\n", branch)
+	fmt.Printf("  URL:      %s\n", client.WorkItemURL(id))
+	return nil
+}
+
+func runGitBranch(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	workItem, err := client.GetWorkItem(id)
+	if err != nil {
+		return fmt.Errorf("failed to get work item: %w", err)
+	}
+
+	prefix := gitPrefixFlag
+	if prefix == "" {
+		prefix = branchPrefix(model.FieldString(workItem.Fields, "System.WorkItemType"))
+	}
+	name := branchName(prefix, id, workItemTitle(workItem))
+
+	if gitNoCheckoutFlag {
+		_, err = runGit("branch", name)
+	} else {
+		_, err = runGit("checkout", "-b", name)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Created branch %s for #%d\n", name, id)
+	return nil
+}
+
+// workItemIDArg returns the work item ID given as the only argument, or the
+// one named by the current git branch when no argument is given
+func workItemIDArg(args []string) (int, error) {
+	if len(args) > 0 {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return 0, fmt.Errorf("invalid work item ID: %s", args[0])
+		}
+		return id, nil
+	}
+
+	branch, err := currentBranch()
+	if err != nil {
+		return 0, fmt.Errorf("work item ID is required")
+	}
+	id, ok, err := branchWorkItemID(branch)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("work item ID is required (branch '%s' does not name a work item)", branch)
+	}
+	return id, nil
+}
+
+// branchIDPattern matches a number that is not part of a longer word
+var branchIDPattern = regexp.MustCompile(`(?:^|[^0-9A-Za-z])([0-9]+)(?:$|[^0-9A-Za-z])`)
+
+// branchWorkItemID finds the work item ID in a branch name with the
+// git_branch_pattern config, or with defaultBranchWorkItemID when it is unset
+func branchWorkItemID(branch string) (int, bool, error) {
+	value := viper.GetString("git_branch_pattern")
+	if value == "" {
+		id, ok := defaultBranchWorkItemID(branch)
+		return id, ok, nil
+	}
+	pattern, err := compileBranchPattern(value)
+	if err != nil {
+		return 0, false, err
+	}
+	id, ok := patternBranchWorkItemID(branch, pattern)
+	return id, ok, nil
+}
+
+// defaultBranchWorkItemID finds the work item ID in a branch name, preferring
+// the last path segment, e.g. 1234 in feature/1234-login-fix or users/ada/AB#1234
+func defaultBranchWorkItemID(branch string) (int, bool) {
+	segments := strings.Split(branch, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if id, ok := patternBranchWorkItemID(segments[i], branchIDPattern); ok {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// patternBranchWorkItemID returns the ID captured by the first group of a pattern
+func patternBranchWorkItemID(branch string, pattern *regexp.Regexp) (int, bool) {
+	match := pattern.FindStringSubmatch(branch)
+	if len(match) < 2 {
+		return 0, false
+	}
+	if id, err := strconv.Atoi(match[1]); err == nil && id > 0 {
+		return id, true
+	}
+	return 0, false
+}
+
+// compileBranchPattern compiles a git_branch_pattern, which must capture the ID in a group
+func compileBranchPattern(value string) (*regexp.Regexp, error) {
+	pattern, err := regexp.Compile(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid git_branch_pattern: %w", err)
+	}
+	if pattern.NumSubexp() < 1 {
+		return nil, fmt.Errorf("git_branch_pattern must capture the work item ID in a group, e.g. ^[a-z]+/([0-9]+)-")
+	}
+	return pattern, nil
+}
+
+// branchPrefix returns the branch prefix for a work item type
+func branchPrefix(workItemType string) string {
+	if strings.EqualFold(workItemType, "Bug") {
+		return "bugfix"
+	}
+	return "feature"
+}
+
+// branchName builds <prefix>/<id>-<slug of title>
+func branchName(prefix string, id int, title string) string {
+	name := strconv.Itoa(id)
+	if slug := branchSlug(title); slug != "" {
+		name += "-" + slug
+	}
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		name = prefix + "/" + name
+	}
+	return name
+}
+
+// branchSlug lowercases a title and joins its words with dashes, stopping at
+// a word boundary before branchSlugLength
+func branchSlug(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+
+	slug := ""
+	for _, word := range words {
+		next := word
+		if slug != "" {
+			next = slug + "-" + word
+		}
+		if len(next) > branchSlugLength {
+			if slug == "" {
+				return word[:branchSlugLength]
+			}
+			break
+		}
+		slug = next
+	}
+	return slug
+}
+
+func runGitInstallHook(cmd *cobra.Command, args []string) error {
+	hooksDir, err := runGit("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return fmt.Errorf("failed to create hooks folder: %w", err)
+	}
+	path := filepath.Join(hooksDir, "prepare-commit-msg")
+
+	if existing, err := os.ReadFile(path); err == nil && !strings.Contains(string(existing), commitHookMarker) {
+		if !gitHookForceFlag {
+			return fmt.Errorf("%s already exists; use --force to replace it (it is backed up as prepare-commit-msg.bak)", path)
+		}
+		if err := os.WriteFile(path+".bak", existing, 0755); err != nil {
+			return fmt.Errorf("failed to back up existing hook: %w", err)
+		}
+		fmt.Printf("Backed up the existing hook to %s.bak\n", path)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		executable = "azb"
+	}
+	if err := os.WriteFile(path, []byte(commitHookScript(executable)), 0755); err != nil {
+		return fmt.Errorf("failed to write hook: %w", err)
+	}
+
+	fmt.Printf("✓ Installed %s\n", path)
+	fmt.Println("  Commits on branches that name a work item now mention AB#<id>")
+	return nil
+}
+
+func runGitPrepareCommitMsg(cmd *cobra.Command, args []string) error {
+	// Merge messages are written by git and should not be touched
+	if len(args) > 1 && args[1] == "merge" {
+		return nil
+	}
+
+	branch, err := currentBranch()
+	if err != nil {
+		return nil
+	}
+	id, ok, err := branchWorkItemID(branch)
+	if err != nil || !ok {
+		return err
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read commit message: %w", err)
+	}
+	message, changed := addWorkItemMention(string(data), id)
+	if !changed {
+		return nil
+	}
+	if err := os.WriteFile(args[0], []byte(message), 0644); err != nil {
+		return fmt.Errorf("failed to write commit message: %w", err)
+	}
+	return nil
+}
+
+// commitHookMarker identifies hooks installed by azb
+const commitHookMarker = "azb git install-hook"
+
+// commitHookScript is the prepare-commit-msg hook. It never fails the commit.
+func commitHookScript(executable string) string {
+	return fmt.Sprintf(`#!/bin/sh
+# Installed by '%s': mentions AB#<id> of the branch's work item
+%s git prepare-commit-msg "$@" || true
+`, commitHookMarker, shellQuote(executable))
+}
+
+// shellQuote quotes a value for sh
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// addWorkItemMention adds AB#<id> on its own line after the message text and
+// before git's comment lines, unless the message already mentions it
+func addWorkItemMention(message string, id int) (string, bool) {
+	mention := fmt.Sprintf("AB#%d", id)
+	mentionPattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(mention) + `\b`)
+
+	lines := strings.Split(message, "\n")
+	end := len(lines)
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			end = i
+			break
+		}
+	}
+	text := strings.TrimRight(strings.Join(lines[:end], "\n"), "\n")
+	if mentionPattern.MatchString(text) {
+		return message, false
+	}
+
+	// With no text yet, the first line stays empty for the subject the user types
+	rest := strings.Join(lines[end:], "\n")
+	result := text + "\n\n" + mention + "\n"
+	if rest != "" {
+		result += "\n" + rest
+	}
+	return result, true
+}
+
+// currentBranch returns the name of the checked out git branch. Unlike
+// rev-parse, symbolic-ref also works before the first commit.
+func currentBranch() (string, error) {
+	branch, err := runGit("symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		if _, repoErr := runGit("rev-parse", "--git-dir"); repoErr != nil {
+			return "", repoErr
+		}
+		return "", fmt.Errorf("not on a branch (detached HEAD)")
+	}
+	return branch, nil
+}
+
+// runGit runs git in the current directory and returns its trimmed output
+func runGit(args ...string) (string, error) {
+	return runGitIn("", args...)
+}
+
+// runGitIn runs git in dir and returns its trimmed output
+func runGitIn(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s failed: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
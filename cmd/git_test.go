@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestBranchWorkItemID(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   int
+		wantOK bool
+	}{
+		{"feature/1234-login-fix", 1234, true},
+		{"1234", 1234, true},
+		{"bug/AB#1234", 1234, true},
+		{"users/ada/login-fix-42", 42, true},
+		{"release/2024/1234-fix", 1234, true},
+		{"release/2024/hotfix", 2024, true},
+		{"feature/v2-login", 0, false},
+		{"main", 0, false},
+		{"feature/0-empty", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			got, ok := defaultBranchWorkItemID(tt.branch)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("defaultBranchWorkItemID(%q) = %d, %v, want %d, %v", tt.branch, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestBranchName(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		title  string
+		want   string
+	}{
+		{"feature", "feature", "Login fix", "feature/1234-login-fix"},
+		{"punctuation", "bugfix", "Crash: can't save (v2)!", "bugfix/1234-crash-can-t-save-v2"},
+		{"long title stops at a word", "feature", "Support exporting every work item in the backlog to spreadsheets", "feature/1234-support-exporting-every-work-item-in-the"},
+		{"no prefix", "", "Login", "1234-login"},
+		{"empty title", "feature/", "", "feature/1234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := branchName(tt.prefix, 1234, tt.title); got != tt.want {
+				t.Errorf("branchName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchWorkItemIDPattern(t *testing.T) {
+	viper.Set("git_branch_pattern", `^[A-Z]+-([0-9]+)`)
+	defer viper.Set("git_branch_pattern", "")
+
+	if id, ok, err := branchWorkItemID("PROJ-42/feature-1234"); err != nil || !ok || id != 42 {
+		t.Errorf("branchWorkItemID() = %d, %v, %v, want 42", id, ok, err)
+	}
+	if _, ok, _ := branchWorkItemID("feature/1234"); ok {
+		t.Error("branchWorkItemID() matched a branch outside the pattern")
+	}
+
+	viper.Set("git_branch_pattern", `[0-9]+`)
+	if _, _, err := branchWorkItemID("feature/1234"); err == nil {
+		t.Error("branchWorkItemID() accepted a pattern without a group")
+	}
+}
+
+func TestAddWorkItemMention(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		want        string
+		wantChanged bool
+	}{
+		{"message flag", "Fix login\n", "Fix login\n\nAB#1234\n", true},
+		{"before comments", "Fix login\n\n# Please enter the commit message\n", "Fix login\n\nAB#1234\n\n# Please enter the commit message\n", true},
+		{"empty message", "\n# Please enter the commit message\n", "\n\nAB#1234\n\n# Please enter the commit message\n", true},
+		{"already mentioned", "Fix login\n\nFixes AB#1234\n", "Fix login\n\nFixes AB#1234\n", false},
+		{"other item mentioned", "Fix login AB#12345\n", "Fix login AB#12345\n\nAB#1234\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := addWorkItemMention(tt.message, 1234)
+			if got != tt.want || changed != tt.wantChanged {
+				t.Errorf("addWorkItemMention() = %q, %v, want %q, %v", got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+}
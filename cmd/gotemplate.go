@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/SOMUCHDOG/azb/internal/model"
+)
+
+// outputTemplateFlag is the --template of the running command
+var outputTemplateFlag string
+
+// outputTemplateFuncs are the functions available to --template besides the builtins
+var outputTemplateFuncs = template.FuncMap{
+	"join":  func(sep string, items []interface{}) string { return joinValues(items, sep) },
+	"json":  templateJSON,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"truncate": func(length int, text string) string {
+		return truncateString(text, length)
+	},
+}
+
+// workItemTemplateFields are the fields a work item offers to templates under
+// short names, next to id, rev, url, tags and the full fields map
+var workItemTemplateFields = map[string]string{
+	"title":         model.FieldTitle,
+	"type":          model.FieldType,
+	"state":         model.FieldState,
+	"assignedTo":    model.FieldAssignedTo,
+	"areaPath":      model.FieldAreaPath,
+	"iterationPath": model.FieldIterationPath,
+	"createdDate":   model.FieldCreatedDate,
+	"changedDate":   model.FieldChangedDate,
+	"priority":      model.FieldPriority,
+}
+
+func parseOutputTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(outputTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// writeTemplate executes text against v, once per item when v is a list, and
+// ends each non-empty result with a newline. The template sees the JSON form of v, with
+// work items given short names for common fields, e.g. {{.id}} {{.title}}.
+func writeTemplate(w io.Writer, v interface{}, text string) error {
+	tmpl, err := parseOutputTemplate(text)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	// Numbers stay json.Number so large IDs are not printed as floats
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var input interface{}
+	if err := decoder.Decode(&input); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	items, ok := input.([]interface{})
+	if !ok {
+		items = []interface{}{input}
+	}
+	for _, item := range items {
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, templateWorkItem(item)); err != nil {
+			return fmt.Errorf("template: %w", err)
+		}
+		if out.Len() > 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+			out.WriteByte('\n')
+		}
+		if _, err := w.Write(out.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateWorkItem adds the short field names to a work item in JSON form.
+// Other values are returned unchanged.
+func templateWorkItem(value interface{}) interface{} {
+	item, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	fields, ok := item["fields"].(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	workItem := model.FromFields(fields)
+	for name, field := range workItemTemplateFields {
+		if _, taken := item[name]; !taken {
+			item[name] = workItem.Field(field)
+		}
+	}
+	if _, taken := item["tags"]; !taken {
+		tags := make([]interface{}, len(workItem.Tags))
+		for i, tag := range workItem.Tags {
+			tags[i] = tag
+		}
+		item["tags"] = tags
+	}
+	return item
+}
+
+// templateJSON renders a value as compact JSON
+func templateJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// joinValues joins the string forms of values
+func joinValues(values []interface{}, sep string) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprint(value)
+	}
+	return strings.Join(parts, sep)
+}
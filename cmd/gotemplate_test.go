@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTemplate(t *testing.T) {
+	items := []map[string]interface{}{
+		{"id": 1234567, "fields": map[string]interface{}{
+			"System.Title":      "Fix login",
+			"System.State":      "Active",
+			"System.AssignedTo": map[string]interface{}{"displayName": "Ada Lovelace"},
+			"System.Tags":       "auth; urgent",
+		}},
+		{"id": 2, "fields": map[string]interface{}{"System.Title": "Add export", "System.State": "New"}},
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{"one line per item", `{{.id}} {{.title}}`, items, "1234567 Fix login\n2 Add export\n", false},
+		{"identities and missing fields", `{{.assignedTo}}|{{.state}}`, items, "Ada Lovelace|Active\n|New\n", false},
+		{"empty results are skipped", `{{join ", " .tags}}`, items, "auth, urgent\n", false},
+		{"full field names", `{{index .fields "System.Title" | upper}}`, items, "FIX LOGIN\nADD EXPORT\n", false},
+		{"single value", `{{.name}}`, map[string]string{"name": "Shared Queries"}, "Shared Queries\n", false},
+		{"trailing newline kept", "{{.id}}\n", items[:1], "1234567\n", false},
+		{"syntax error", `{{.id`, items, "", true},
+		{"execution error", `{{index .id 1}}`, items, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := writeTemplate(&out, tt.value, tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("writeTemplate(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+			if !tt.wantErr && out.String() != tt.want {
+				t.Errorf("writeTemplate(%q) = %q, want %q", tt.text, out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxIDRange is the largest number of IDs a single range may expand to, so a
+// mistyped range does not touch thousands of work items
+const maxIDRange = 1000
+
+// parseWorkItemIDs reads work item IDs from arguments. Each argument is an ID,
+// a range like 100-110, or a comma-separated list of them. IDs are returned in
+// the order given, without duplicates.
+func parseWorkItemIDs(args []string) ([]int, error) {
+	var ids []int
+	seen := make(map[int]bool)
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			first, last, err := parseIDRange(part)
+			if err != nil {
+				return nil, err
+			}
+			for id := first; id <= last; id++ {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("work item ID is required")
+	}
+	return ids, nil
+}
+
+// parseIDRange reads an ID or a range of IDs, e.g. "42" or "100-110"
+func parseIDRange(value string) (int, int, error) {
+	start, end, isRange := strings.Cut(value, "-")
+	first, err := strconv.Atoi(strings.TrimSpace(start))
+	if err != nil || first <= 0 {
+		return 0, 0, fmt.Errorf("invalid work item ID: %s", value)
+	}
+	if !isRange {
+		return first, first, nil
+	}
+
+	last, err := strconv.Atoi(strings.TrimSpace(end))
+	if err != nil || last <= 0 {
+		return 0, 0, fmt.Errorf("invalid work item ID range: %s", value)
+	}
+	if last < first {
+		return 0, 0, fmt.Errorf("invalid work item ID range: %s (the end is before the start)", value)
+	}
+	if last-first+1 > maxIDRange {
+		return 0, 0, fmt.Errorf("work item ID range %s is larger than %d IDs", value, maxIDRange)
+	}
+	return first, last, nil
+}
+
+// workItemIDsArg parses the IDs given as arguments, falling back to the work
+// item named by the current git branch when there are none
+func workItemIDsArg(args []string) ([]int, error) {
+	if len(args) > 0 {
+		return parseWorkItemIDs(args)
+	}
+	id, err := workItemIDArg(args)
+	if err != nil {
+		return nil, err
+	}
+	return []int{id}, nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWorkItemIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []int
+		wantErr bool
+	}{
+		{"single", []string{"42"}, []int{42}, false},
+		{"comma list", []string{"1,2, 3"}, []int{1, 2, 3}, false},
+		{"several arguments", []string{"5", "3"}, []int{5, 3}, false},
+		{"range and ID", []string{"100-103", "120"}, []int{100, 101, 102, 103, 120}, false},
+		{"ranges in a list", []string{"1-2,7"}, []int{1, 2, 7}, false},
+		{"duplicates removed", []string{"2-4", "3,2"}, []int{2, 3, 4}, false},
+		{"one-ID range", []string{"9-9"}, []int{9}, false},
+		{"not a number", []string{"abc"}, nil, true},
+		{"reversed range", []string{"10-5"}, nil, true},
+		{"open range", []string{"10-"}, nil, true},
+		{"negative", []string{"-5"}, nil, true},
+		{"too large", []string{"1-5000"}, nil, true},
+		{"empty", []string{","}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWorkItemIDs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWorkItemIDs(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWorkItemIDs(%q) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/auth"
+	"github.com/SOMUCHDOG/azb/internal/config"
+)
+
+// initClassificationDepth is how many levels of area and iteration paths are offered
+const initClassificationDepth = 3
+
+// initMaxChoices is how many options are listed before asking the user to type a name instead
+const initMaxChoices = 40
+
+var (
+	initPATFlag string
+
+	initCmd = &cobra.Command{
+		Use:   "init",
+		Short: "Set up organization, project and authentication interactively",
+		Long: `Guide first-time setup in one step: enter your organization and a Personal
+Access Token, which is checked against Azure DevOps right away, then pick the
+project and optionally a default area path and iteration from the values that
+exist in your project. The configuration and token are saved at the end.
+
+Running init again shows the current values as defaults, so it can also be
+used to switch projects.
+
+Examples:
+  azb init
+  azb init --pat <token>`,
+		Args: cobra.NoArgs,
+		RunE: runInit,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().StringVar(&initPATFlag, "pat", "", "Personal Access Token (prompted for when omitted)")
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+
+	fmt.Println("Azure Boards CLI setup")
+	fmt.Println()
+
+	orgURL, err := promptOrganization(cfg.Organization)
+	if err != nil {
+		return err
+	}
+
+	token, projects, err := promptValidToken(orgURL)
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		return fmt.Errorf("no projects found in %s; check that the token has access to at least one project", orgURL)
+	}
+
+	fmt.Println()
+	project, err := promptChoice("Project", projects, cfg.Project, true)
+	if err != nil {
+		return err
+	}
+
+	client, err := connectClient(orgURL, project, token)
+	if err != nil {
+		return err
+	}
+	if user, err := client.GetCurrentUser(); err == nil && user.DisplayName != "" {
+		fmt.Printf("✓ Signed in as %s\n", user.DisplayName)
+	}
+
+	// Keep the previous defaults only if they belong to the chosen project
+	areaDefault, iterationDefault := cfg.DefaultAreaPath, cfg.DefaultIteration
+	if project != cfg.Project {
+		areaDefault, iterationDefault = "", ""
+	}
+
+	fmt.Println()
+	areaPath, err := promptClassification(client.ListAreaPaths, "Default area path", areaDefault)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	iteration, err := promptClassification(client.ListIterationPaths, "Default iteration", iterationDefault)
+	if err != nil {
+		return err
+	}
+
+	cfg.Organization = strings.TrimPrefix(orgURL, "https://dev.azure.com/")
+	cfg.Project = project
+	cfg.DefaultAreaPath = areaPath
+	cfg.DefaultIteration = iteration
+
+	if err := auth.SaveToken(token); err != nil {
+		return fmt.Errorf("failed to save token: %w", err)
+	}
+	if err := config.Save(cfg, "organization", "project", "default_area_path", "default_iteration"); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println("✓ Token saved")
+	fmt.Println("✓ Configuration saved")
+	fmt.Printf("  organization:      %s\n", cfg.Organization)
+	fmt.Printf("  project:           %s\n", cfg.Project)
+	fmt.Printf("  default_area_path: %s\n", cfg.DefaultAreaPath)
+	fmt.Printf("  default_iteration: %s\n", cfg.DefaultIteration)
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Println("  azb list --assigned-to @me   # your work items")
+	fmt.Println("  azb dashboard                # interactive dashboard")
+
+	return nil
+}
+
+// promptOrganization asks for the organization until it is a valid name or URL
+func promptOrganization(current string) (string, error) {
+	prompt := "Organization (name or URL)"
+	if current != "" {
+		prompt += fmt.Sprintf(" [%s]", current)
+	}
+
+	for {
+		input, err := promptOptional(prompt)
+		if err != nil {
+			return "", err
+		}
+		if input == "" {
+			input = current
+		}
+		if input == "" {
+			fmt.Println("This field is required. Please enter a value.")
+			continue
+		}
+
+		orgURL, err := organizationURL(input)
+		if err != nil {
+			fmt.Printf("✗ %v\n", err)
+			continue
+		}
+		return orgURL, nil
+	}
+}
+
+// promptValidToken asks for a token and checks it by listing the organization's
+// projects, which also confirms the organization exists. A stored token is
+// offered first. Returns the token and the project names.
+func promptValidToken(orgURL string) (string, []string, error) {
+	const maxAttempts = 3
+
+	token := initPATFlag
+	if token == "" {
+		if stored, err := auth.GetToken(); err == nil && stored != "" {
+			fmt.Println("Press Enter to keep the stored Personal Access Token, or paste a new one.")
+			input, err := readToken("PAT")
+			if err != nil {
+				return "", nil, err
+			}
+			token = stored
+			if input != "" {
+				token = input
+			}
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		if token == "" {
+			fmt.Printf("Create a PAT with Work Items (Read & Write) scope at: %s/_usersSettings/tokens\n", orgURL)
+			input, err := readToken("PAT")
+			if err != nil {
+				return "", nil, err
+			}
+			if input == "" {
+				fmt.Println("This field is required. Please enter a value.")
+				continue
+			}
+			token = input
+		}
+
+		fmt.Println("Checking token...")
+		projects, err := api.ListProjects(orgURL, token)
+		if err == nil {
+			fmt.Println("✓ Token is valid")
+			return token, projects, nil
+		}
+
+		fmt.Fprintf(os.Stderr, "✗ %v\n", err)
+		if attempt >= maxAttempts || initPATFlag != "" {
+			return "", nil, fmt.Errorf("could not authenticate with %s; check the organization and that the token has not expired", orgURL)
+		}
+		token = ""
+	}
+}
+
+// promptClassification lists area or iteration paths and asks for one. Lookup
+// failures are reported and the setting is left to be configured later.
+func promptClassification(list func(depth int) ([]string, error), label, current string) (string, error) {
+	paths, err := list(initClassificationDepth)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; skipping %s\n", err, strings.ToLower(label))
+		return current, nil
+	}
+	return promptChoice(label, paths, current, false)
+}
+
+// promptChoice lists options and asks for one by number or name. Empty input
+// keeps current; when there is no current value it skips an optional choice.
+func promptChoice(label string, options []string, current string, required bool) (string, error) {
+	fmt.Printf("%ss:\n", label)
+	for i, option := range options {
+		if i == initMaxChoices {
+			fmt.Printf("  ... and %d more (type the name)\n", len(options)-initMaxChoices)
+			break
+		}
+		fmt.Printf("  %2d) %s\n", i+1, option)
+	}
+
+	prompt := fmt.Sprintf("%s [1-%d or name", label, len(options))
+	switch {
+	case current != "":
+		prompt += ", Enter for " + current
+	case !required:
+		prompt += ", Enter to skip"
+	}
+	prompt += "]"
+
+	for {
+		input, err := promptOptional(prompt)
+		if err != nil {
+			return "", err
+		}
+		if input == "" && (current != "" || !required) {
+			return current, nil
+		}
+
+		choice, err := resolveChoice(input, options)
+		if err != nil {
+			fmt.Printf("✗ %v\n", err)
+			continue
+		}
+		return choice, nil
+	}
+}
+
+// resolveChoice maps a 1-based number or a name (ignoring case) to one of options
+func resolveChoice(input string, options []string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("please choose one of the options")
+	}
+
+	if n, err := strconv.Atoi(input); err == nil {
+		if n < 1 || n > len(options) {
+			return "", fmt.Errorf("choose a number from 1 to %d", len(options))
+		}
+		return options[n-1], nil
+	}
+
+	for _, option := range options {
+		if strings.EqualFold(option, input) {
+			return option, nil
+		}
+	}
+	return "", fmt.Errorf("'%s' is not one of the options", input)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import "testing"
+
+func TestResolveChoice(t *testing.T) {
+	options := []string{"Fabrikam", "Contoso Web", "Tailspin"}
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"1", "Fabrikam", false},
+		{" 3 ", "Tailspin", false},
+		{"contoso web", "Contoso Web", false},
+		{"0", "", true},
+		{"4", "", true},
+		{"Northwind", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveChoice(tt.input, options)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolveChoice(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("resolveChoice(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
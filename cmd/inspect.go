@@ -2,22 +2,21 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
-	"github.com/SOMUCHDOG/azb/internal/api"
-	"github.com/SOMUCHDOG/azb/internal/auth"
-	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/output"
 )
 
 var (
 	inspectCmd = &cobra.Command{
-		Use:   "inspect <work-item-type>",
-		Short: "Inspect work item type fields",
-		Long:  `Show all fields and requirements for a work item type.`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runInspect,
+		Use:               "inspect <work-item-type>",
+		Short:             "Inspect work item type fields",
+		Long:              `Show all fields and requirements for a work item type.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeWorkItemTypes),
+		RunE:              runInspect,
 	}
 )
 
@@ -25,45 +24,33 @@ func init() {
 	rootCmd.AddCommand(inspectCmd)
 }
 
-func runInspect(cmd *cobra.Command, args []string) error {
-	workItemTypeName := args[0]
+// inspectResult is a work item type as 'azb inspect' outputs it
+type inspectResult struct {
+	Type        string         `json:"type"`
+	Description string         `json:"description,omitempty"`
+	Fields      []inspectField `json:"fields"`
+}
 
-	// Check authentication
-	token, err := auth.GetToken()
-	if err != nil {
-		return err
-	}
+// inspectField is a field of a work item type
+type inspectField struct {
+	Name          string      `json:"name"`
+	ReferenceName string      `json:"referenceName"`
+	Required      bool        `json:"required"`
+	Default       interface{} `json:"default,omitempty"`
+	HelpText      string      `json:"helpText,omitempty"`
+}
 
-	// Load config
-	cfg, err := config.Load()
+func runInspect(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd, withOutputFormats("text")...)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// Get organization and project
-	org := viper.GetString("organization")
-	if org == "" {
-		org = cfg.Organization
-	}
-	if org == "" {
-		return fmt.Errorf("organization not configured")
-	}
-
-	project := viper.GetString("project")
-	if project == "" {
-		project = cfg.Project
-	}
-	if project == "" {
-		return fmt.Errorf("project not configured")
+		return err
 	}
 
-	// Build organization URL
-	orgURL := api.NormalizeOrganizationURL(org)
+	workItemTypeName := args[0]
 
-	// Create API client
-	client, err := api.NewClient(orgURL, project, token)
+	client, err := newClient()
 	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+		return err
 	}
 
 	// Get work item type
@@ -72,6 +59,37 @@ func runInspect(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if format != "text" {
+		result := inspectResult{Type: workItemTypeName, Description: stringValue(workItemType.Description), Fields: []inspectField{}}
+		if workItemType.Fields != nil {
+			for _, field := range *workItemType.Fields {
+				if field.Name == nil || field.ReferenceName == nil {
+					continue
+				}
+				item := inspectField{
+					Name:          *field.Name,
+					ReferenceName: *field.ReferenceName,
+					Required:      field.AlwaysRequired != nil && *field.AlwaysRequired,
+					HelpText:      stringValue(field.HelpText),
+				}
+				if field.DefaultValue != nil {
+					item.Default = *field.DefaultValue
+				}
+				result.Fields = append(result.Fields, item)
+			}
+		}
+
+		table := output.Table{Headers: []string{"Reference Name", "Name", "Required", "Default"}}
+		for _, field := range result.Fields {
+			defaultValue := ""
+			if field.Default != nil {
+				defaultValue = fmt.Sprint(field.Default)
+			}
+			table.Rows = append(table.Rows, []string{field.ReferenceName, field.Name, strconv.FormatBool(field.Required), defaultValue})
+		}
+		return writeResult(format, result, table)
+	}
+
 	fmt.Printf("Work Item Type: %s\n", workItemTypeName)
 	if workItemType.Description != nil {
 		fmt.Printf("Description: %s\n", *workItemType.Description)
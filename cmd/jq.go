@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+)
+
+// jqFlag is the --jq expression of the running command
+var jqFlag string
+
+// addJQFlag registers --jq and --template on a command with JSON output
+func addJQFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&jqFlag, "jq", "q", "", "Filter JSON output with a jq expression (implies --format json)")
+	cmd.Flags().StringVar(&outputTemplateFlag, "template", "", "Format output with a Go template, once per item of a list (implies --format json)")
+	cmd.MarkFlagsMutuallyExclusive("jq", "template")
+}
+
+// resolveJQFormat switches the output to JSON when --jq or --template is given
+// and checks the expression up front, so mistakes are reported before any API calls
+func resolveJQFormat(cmd *cobra.Command, flagName string, format *string) error {
+	name := "--jq"
+	switch {
+	case jqFlag != "":
+	case outputTemplateFlag != "":
+		name = "--template"
+	default:
+		return nil
+	}
+	if cmd.Flags().Changed(flagName) && *format != "json" {
+		return fmt.Errorf("%s cannot be combined with --%s %s", name, flagName, *format)
+	}
+	if outputFlag != "" && *format != "json" {
+		return fmt.Errorf("%s cannot be combined with --output %s", name, outputFlag)
+	}
+	*format = "json"
+
+	if jqFlag == "" {
+		_, err := parseOutputTemplate(outputTemplateFlag)
+		return err
+	}
+	_, err := compileJQ(jqFlag)
+	return err
+}
+
+func compileJQ(expr string) (*gojq.Code, error) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression: %w", err)
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression: %w", err)
+	}
+	return code, nil
+}
+
+// writeJQ runs expr against v and writes each result on its own line. Strings
+// are written without quotes, like jq -r; other values as indented JSON.
+func writeJQ(w io.Writer, v interface{}, expr string) error {
+	code, err := compileJQ(expr)
+	if err != nil {
+		return err
+	}
+
+	// gojq works on plain JSON values, so round-trip through encoding/json
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	iter := code.Run(input)
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+		if err, ok := result.(error); ok {
+			if err, ok := err.(*gojq.HaltError); ok && err.Value() == nil {
+				return nil
+			}
+			return fmt.Errorf("jq: %w", err)
+		}
+
+		if text, ok := result.(string); ok {
+			fmt.Fprintln(w, text)
+			continue
+		}
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Fprintln(w, string(out))
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteJQ(t *testing.T) {
+	items := []map[string]interface{}{
+		{"id": 1, "fields": map[string]interface{}{"System.Title": "Fix login", "System.State": "Active"}},
+		{"id": 2, "fields": map[string]interface{}{"System.Title": "Add export", "System.State": "New"}},
+	}
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    string
+		wantErr bool
+	}{
+		{"strings are raw", `.[].fields["System.Title"]`, "Fix login\nAdd export\n", false},
+		{"numbers", `.[].id`, "1\n2\n", false},
+		{"select and reshape", `map(select(.fields["System.State"] == "Active") | {id})`, "[\n  {\n    \"id\": 1\n  }\n]\n", false},
+		{"syntax error", `.[`, "", true},
+		{"runtime error", `.[] | .id | keys`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := writeJQ(&out, items, tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("writeJQ(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+			if !tt.wantErr && out.String() != tt.want {
+				t.Errorf("writeJQ(%q) = %q, want %q", tt.expr, out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+// workItemLinks adds web links to work item output. On a terminal the links
+// are OSC 8 hyperlinks; otherwise the plain URL is printed.
+type workItemLinks struct {
+	client     api.APIClient
+	hyperlinks bool
+}
+
+// newWorkItemLinks returns a link builder, or nil when links are disabled
+func newWorkItemLinks(client api.APIClient, enabled bool) *workItemLinks {
+	if !enabled {
+		return nil
+	}
+	return &workItemLinks{
+		client:     client,
+		hyperlinks: supportsHyperlinks(),
+	}
+}
+
+// URL returns the web URL of a work item
+func (l *workItemLinks) URL(id int) string {
+	return l.client.WorkItemURL(id)
+}
+
+// supportsHyperlinks reports whether stdout is a terminal that can render OSC 8 links
+func supportsHyperlinks() bool {
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// hyperlink wraps text in an OSC 8 escape sequence pointing at url
+func hyperlink(url, text string) string {
+	return "\033]8;;" + url + "\033\\" + text + "\033]8;;\033\\"
+}
@@ -1,19 +1,22 @@
 package cmd
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
-	"github.com/SOMUCHDOG/azb/internal/auth"
-	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/log"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/snooze"
+	"github.com/SOMUCHDOG/azb/internal/wiql"
 )
 
 var (
@@ -25,12 +28,32 @@ var (
 	tagsFlag       string
 	formatFlag     string
 	limitFlag      int
+	linksFlag      bool
+	pageFlag       int
+	pageSizeFlag   int
+	mineFlag       bool
+	followingFlag  bool
+	boardFlag      bool
+
+	// listDateFilters are the --created-since, --changed-before, ... flags
+	listDateFilters = newDateFilters()
 
 	listCmd = &cobra.Command{
 		Use:   "list",
 		Short: "List work items",
-		Long:  `List work items with optional filters.`,
-		RunE:  runList,
+		Long: `List work items with optional filters.
+
+--mine lists work items assigned to you and hides the ones you have snoozed
+with 'azb snooze'. Items whose snooze has ended are listed again with a
+reminder.
+
+--following lists the work items you follow with 'azb follow'; other filters
+still apply.
+
+The date filters take days or weeks back from today (7d, 2w), today,
+yesterday or a date (2024-01-01), e.g. --changed-since 7d or
+--closed-before 2024-01-01.`,
+		RunE: runList,
 	}
 )
 
@@ -43,58 +66,68 @@ func init() {
 	listCmd.Flags().StringVar(&sprintFlag, "sprint", "", "Filter by sprint/iteration")
 	listCmd.Flags().StringVar(&areaPathFlag, "area-path", "", "Filter by area path")
 	listCmd.Flags().StringVar(&tagsFlag, "tags", "", "Filter by tags (comma-separated)")
-	listCmd.Flags().StringVarP(&formatFlag, "format", "f", "table", "Output format (table, json, csv, ids)")
+	addDateFilterFlags(listCmd, listDateFilters)
+	listCmd.Flags().StringVarP(&formatFlag, "format", "f", "table", "Output format (table, json, yaml, csv, tsv, ids, markdown, card)")
 	listCmd.Flags().IntVarP(&limitFlag, "limit", "l", 50, "Maximum number of results")
+	listCmd.Flags().BoolVar(&mineFlag, "mine", false, "List your work items, hiding snoozed ones")
+	listCmd.Flags().BoolVar(&followingFlag, "following", false, "List work items you follow")
+	listCmd.Flags().BoolVar(&boardFlag, "board", false, "Add board column and swimlane columns to table, csv and tsv output")
+	listCmd.Flags().BoolVar(&linksFlag, "links", false, "Include web links to each work item in table, markdown and card output")
+	addPageFlags(listCmd, &pageFlag, &pageSizeFlag)
+	addJQFlag(listCmd)
+
+	//nolint:errcheck // Flags are registered above
+	listCmd.RegisterFlagCompletionFunc("type", completeWorkItemTypes)
 }
 
 func runList(cmd *cobra.Command, args []string) error {
-	// Check authentication
-	token, err := auth.GetToken()
-	if err != nil {
+	if err := resolveFormat(cmd, "format", &formatFlag, workItemFormats...); err != nil {
+		return err
+	}
+	if err := resolveJQFormat(cmd, "format", &formatFlag); err != nil {
 		return err
 	}
 
-	// Load config
-	cfg, err := config.Load()
+	client, err := newClient()
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
+	project := client.GetProject()
 
-	// Override with flags if provided
-	org := viper.GetString("organization")
-	if org == "" {
-		org = cfg.Organization
-	}
-	if org == "" {
-		return fmt.Errorf("organization not configured. Run 'azb config set organization <org>'")
+	if mineFlag && assignedToFlag == "" {
+		assignedToFlag = "@me"
 	}
 
-	project := viper.GetString("project")
-	if project == "" {
-		project = cfg.Project
+	// Leave snoozed work items out of the query, so --limit and --page count
+	// only the work items shown
+	var snoozed []int
+	if mineFlag {
+		snoozed = snoozedIDs()
 	}
-	if project == "" {
-		return fmt.Errorf("project not configured. Run 'azb config set project <project>'")
-	}
-
-	// Build organization URL
-	orgURL := api.NormalizeOrganizationURL(org)
 
-	// Create API client
-	client, err := api.NewClient(orgURL, project, token)
+	// Build WIQL query
+	wiql, err := buildWIQLQuery(project, snoozed)
 	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+		return err
 	}
 
-	// Build WIQL query
-	wiql := buildWIQLQuery(project)
+	log.Debug("listing work items", "organization", client.GetOrganizationURL(), "project", project, "wiql", wiql, "limit", limitFlag)
 
-	// Debug output
-	if os.Getenv("DEBUG") != "" {
-		fmt.Fprintf(os.Stderr, "Organization URL: %s\n", orgURL)
-		fmt.Fprintf(os.Stderr, "Project: %s\n", project)
-		fmt.Fprintf(os.Stderr, "WIQL Query: %s\n", wiql)
-		fmt.Fprintf(os.Stderr, "Limit: %d\n", limitFlag)
+	// Fetch a single page for scripted consumption
+	if cmd.Flags().Changed("page") {
+		workItems, hasMore, err := fetchWorkItemPage(client, wiql, pageFlag, pageSizeFlag)
+		if err != nil {
+			return fmt.Errorf("failed to list work items: %w", err)
+		}
+		if len(workItems) == 0 {
+			fmt.Println("No work items found")
+			return nil
+		}
+		if err := outputListedWorkItems(workItems, client); err != nil {
+			return err
+		}
+		printPageHint(pageFlag, pageSizeFlag, len(workItems), hasMore)
+		return nil
 	}
 
 	// Execute query
@@ -109,78 +142,161 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output results based on format
-	return outputWorkItems(*workItems, formatFlag)
+	return outputListedWorkItems(*workItems, client)
+}
+
+// listBoardColumns are the table and csv columns of 'azb list --board'
+var listBoardColumns = []api.QueryColumn{
+	{ReferenceName: "System.Id", Name: "ID"},
+	{ReferenceName: "System.Title", Name: "Title"},
+	{ReferenceName: "System.WorkItemType", Name: "Type"},
+	{ReferenceName: "System.State", Name: "State"},
+	{ReferenceName: api.BoardColumnField, Name: "Board Column"},
+	{ReferenceName: api.BoardLaneField, Name: "Swimlane"},
+	{ReferenceName: "System.AssignedTo", Name: "Assigned To"},
 }
 
-func buildWIQLQuery(project string) string {
-	var conditions []string
+// outputListedWorkItems prints the results of 'azb list' in the chosen format
+func outputListedWorkItems(workItems []workitemtracking.WorkItem, client api.APIClient) error {
+	links := newWorkItemLinks(client, linksFlag)
+	if boardFlag {
+		switch formatFlag {
+		case "table":
+			return outputColumnTable(workItems, listBoardColumns, links)
+		case "csv", "tsv":
+			return output.Write(os.Stdout, formatFlag, workItems, columnTable(workItems, listBoardColumns))
+		}
+	}
+	return outputWorkItems(workItems, formatFlag, links)
+}
 
+// snoozedIDs returns the IDs of the work items snoozed now and mentions them
+// on stderr, with a reminder for each snooze that ended
+func snoozedIDs() []int {
+	now := time.Now()
+	store, err := snooze.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return nil
+	}
+	due := store.TakeDue(now)
+	if len(due) > 0 {
+		if err := store.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	printDueSnoozes(due)
+
+	var ids []int
+	for _, reminder := range store.Active(now) {
+		ids = append(ids, reminder.ID)
+	}
+	if len(ids) > 0 {
+		fmt.Fprintf(os.Stderr, "Hiding %d snoozed work item(s); see 'azb snooze list'\n", len(ids))
+	}
+	return ids
+}
+
+// buildWIQLQuery returns the query of 'azb list', leaving out the work items in exclude
+func buildWIQLQuery(project string, exclude []int) (string, error) {
 	// Base query (limit is handled via API parameter, not in WIQL)
-	query := fmt.Sprintf("SELECT [System.Id], [System.Title], [System.State], [System.AssignedTo], [System.WorkItemType] FROM WorkItems WHERE [System.TeamProject] = '%s'", project)
+	query := wiql.Select("System.Id", "System.Title", "System.State", "System.AssignedTo", "System.WorkItemType").
+		Where(wiql.Field("System.TeamProject").Eq(wiql.String(project)))
+
+	excluded := make([]wiql.Value, len(exclude))
+	for i, id := range exclude {
+		excluded[i] = wiql.Int(id)
+	}
+	query.Where(wiql.Field("System.Id").NotIn(excluded...))
 
 	// Add filters
 	if typeFlag != "" {
-		conditions = append(conditions, fmt.Sprintf("[System.WorkItemType] = '%s'", typeFlag))
+		query.Where(wiql.Field("System.WorkItemType").Eq(wiql.String(typeFlag)))
 	}
 
 	if stateFlag != "" {
-		conditions = append(conditions, fmt.Sprintf("[System.State] = '%s'", stateFlag))
+		query.Where(wiql.Field("System.State").Eq(wiql.String(stateFlag)))
 	}
 
 	if assignedToFlag != "" {
 		if assignedToFlag == "@me" {
-			conditions = append(conditions, "[System.AssignedTo] = @me")
+			query.Where(wiql.Field("System.AssignedTo").Eq(wiql.Me))
 		} else {
-			conditions = append(conditions, fmt.Sprintf("[System.AssignedTo] = '%s'", assignedToFlag))
+			query.Where(wiql.Field("System.AssignedTo").Eq(wiql.String(assignedToFlag)))
 		}
 	}
 
 	if sprintFlag != "" {
 		if sprintFlag == "current" || sprintFlag == "@current" {
-			conditions = append(conditions, "[System.IterationPath] = @currentIteration")
+			query.Where(wiql.Field("System.IterationPath").Eq(wiql.CurrentIteration))
 		} else {
-			conditions = append(conditions, fmt.Sprintf("[System.IterationPath] = '%s'", sprintFlag))
+			query.Where(wiql.Field("System.IterationPath").Eq(wiql.String(sprintFlag)))
 		}
 	}
 
+	if followingFlag {
+		query.Where(wiql.Field("System.Id").In(wiql.Follows))
+	}
+
 	if areaPathFlag != "" {
-		conditions = append(conditions, fmt.Sprintf("[System.AreaPath] = '%s'", areaPathFlag))
+		query.Where(wiql.Field("System.AreaPath").Eq(wiql.String(areaPathFlag)))
 	}
 
 	if tagsFlag != "" {
 		tags := strings.Split(tagsFlag, ",")
 		for _, tag := range tags {
-			conditions = append(conditions, fmt.Sprintf("[System.Tags] CONTAINS '%s'", strings.TrimSpace(tag)))
+			query.Where(wiql.Field("System.Tags").Contains(wiql.String(strings.TrimSpace(tag))))
 		}
 	}
 
-	// Add conditions to query
-	if len(conditions) > 0 {
-		query += " AND " + strings.Join(conditions, " AND ")
+	dates, err := dateConditions(listDateFilters)
+	if err != nil {
+		return "", err
 	}
+	query.Where(dates...)
 
 	// Add order by
-	query += " ORDER BY [System.ChangedDate] DESC"
+	query.OrderBy("System.ChangedDate", true)
 
-	return query
+	return query.String(), nil
 }
 
-func outputWorkItems(workItems interface{}, format string) error {
+// workItemFormats are the output formats of commands that list work items
+var workItemFormats = withOutputFormats("table", "json", "csv", "ids", "markdown", "card")
+
+func outputWorkItems(workItems interface{}, format string, links *workItemLinks) error {
 	switch format {
 	case "json":
 		return outputJSON(workItems)
+	case "yaml", "tsv":
+		items, ok := workItems.([]workitemtracking.WorkItem)
+		if !ok {
+			return fmt.Errorf("invalid work items type")
+		}
+		return writeResult(format, items, workItemTable(items))
 	case "csv":
 		return outputCSV(workItems)
 	case "ids":
 		return outputIDs(workItems)
+	case "markdown":
+		return outputMarkdown(workItems, links)
+	case "card":
+		return outputCards(workItems, links)
 	case "table":
 		fallthrough
 	default:
-		return outputTable(workItems)
+		return outputTable(workItems, links)
 	}
 }
 
 func outputJSON(workItems interface{}) error {
+	if jqFlag != "" {
+		return writeJQ(os.Stdout, workItems, jqFlag)
+	}
+	if outputTemplateFlag != "" {
+		return writeTemplate(os.Stdout, workItems, outputTemplateFlag)
+	}
+
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(workItems)
@@ -191,35 +307,38 @@ func outputCSV(workItems interface{}) error {
 	if !ok {
 		return fmt.Errorf("invalid work items type")
 	}
+	return output.WriteCSV(os.Stdout, workItemTable(items))
+}
 
-	writer := csv.NewWriter(os.Stdout)
-	defer writer.Flush()
-
-	// Write header
-	header := []string{"ID", "Title", "Type", "State", "Assigned To"}
-	if err := writer.Write(header); err != nil {
-		return err
+// workItemTable is the tabular form of work items for csv, tsv and ids output
+func workItemTable(items []workitemtracking.WorkItem) output.Table {
+	table := output.Table{Headers: []string{"ID", "Title", "Type", "State", "Assigned To"}}
+	for _, item := range model.FromAPIList(items) {
+		table.Rows = append(table.Rows, []string{
+			strconv.Itoa(item.ID),
+			item.Title,
+			item.Type,
+			item.State,
+			item.AssignedTo.String(),
+		})
 	}
+	return table
+}
 
-	// Write rows
-	for _, item := range items {
-		id := ""
-		if item.Id != nil {
-			id = fmt.Sprintf("%d", *item.Id)
-		}
-
-		title := getFieldValue(item.Fields, "System.Title")
-		workItemType := getFieldValue(item.Fields, "System.WorkItemType")
-		state := getFieldValue(item.Fields, "System.State")
-		assignedTo := getFieldValue(item.Fields, "System.AssignedTo")
-
-		row := []string{id, title, workItemType, state, assignedTo}
-		if err := writer.Write(row); err != nil {
-			return err
+// columnTable is the tabular form of work items with the given columns
+func columnTable(workItems []workitemtracking.WorkItem, columns []api.QueryColumn) output.Table {
+	table := output.Table{Headers: make([]string, len(columns))}
+	for i, column := range columns {
+		table.Headers[i] = column.Name
+	}
+	for i := range workItems {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = queryCellValue(&workItems[i], column.ReferenceName)
 		}
+		table.Rows = append(table.Rows, row)
 	}
-
-	return nil
+	return table
 }
 
 func outputIDs(workItems interface{}) error {
@@ -227,16 +346,10 @@ func outputIDs(workItems interface{}) error {
 	if !ok {
 		return fmt.Errorf("invalid work items type")
 	}
-
-	for _, item := range items {
-		if item.Id != nil {
-			fmt.Println(*item.Id)
-		}
-	}
-	return nil
+	return output.WriteIDs(os.Stdout, workItemTable(items))
 }
 
-func outputTable(workItems interface{}) error {
+func outputTable(workItems interface{}, links *workItemLinks) error {
 	items, ok := workItems.([]workitemtracking.WorkItem)
 	if !ok {
 		return fmt.Errorf("invalid work items type")
@@ -247,30 +360,43 @@ func outputTable(workItems interface{}) error {
 		return nil
 	}
 
+	// Without hyperlink support the URL gets its own column
+	urlColumn := links != nil && !links.hyperlinks
+
 	// Print header
-	fmt.Printf("%-8s %-50s %-15s %-15s %-30s\n", "ID", "Title", "Type", "State", "Assigned To")
-	fmt.Println(strings.Repeat("-", 120))
+	header := fmt.Sprintf("%-8s %-50s %-15s %-15s %-30s", "ID", "Title", "Type", "State", "Assigned To")
+	width := 120
+	if urlColumn {
+		header += " URL"
+		width += 60
+	}
+	fmt.Println(header)
+	fmt.Println(strings.Repeat("-", width))
 
 	// Print rows
-	for _, item := range items {
-		id := ""
-		if item.Id != nil {
-			id = fmt.Sprintf("%d", *item.Id)
-		}
+	for _, item := range model.FromAPIList(items) {
+		id := strconv.Itoa(item.ID)
 
-		title := getFieldValue(item.Fields, "System.Title")
+		title := item.Title
 		if len(title) > 50 {
 			title = title[:47] + "..."
 		}
 
-		workItemType := getFieldValue(item.Fields, "System.WorkItemType")
-		state := getFieldValue(item.Fields, "System.State")
-		assignedTo := getFieldValue(item.Fields, "System.AssignedTo")
+		assignedTo := item.AssignedTo.String()
 		if len(assignedTo) > 30 {
 			assignedTo = assignedTo[:27] + "..."
 		}
 
-		fmt.Printf("%-8s %-50s %-15s %-15s %-30s\n", id, title, workItemType, state, assignedTo)
+		idCell := fmt.Sprintf("%-8s", id)
+		if links != nil && links.hyperlinks {
+			idCell = hyperlink(links.URL(item.ID), id) + strings.Repeat(" ", len(idCell)-len(id))
+		}
+
+		row := fmt.Sprintf("%s %-50s %-15s %-15s %-30s", idCell, title, item.Type, item.State, assignedTo)
+		if urlColumn {
+			row += " " + links.URL(item.ID)
+		}
+		fmt.Println(row)
 	}
 
 	fmt.Printf("\nTotal: %d work items\n", len(items))
@@ -278,29 +404,62 @@ func outputTable(workItems interface{}) error {
 	return nil
 }
 
-func getFieldValue(fields *map[string]interface{}, fieldName string) string {
-	if fields == nil {
-		return ""
+func outputMarkdown(workItems interface{}, links *workItemLinks) error {
+	items, ok := workItems.([]workitemtracking.WorkItem)
+	if !ok {
+		return fmt.Errorf("invalid work items type")
 	}
 
-	value, ok := (*fields)[fieldName]
+	fmt.Println("| ID | Title | Type | State | Assigned To |")
+	fmt.Println("|----|-------|------|-------|-------------|")
+
+	for _, item := range model.FromAPIList(items) {
+		id := strconv.Itoa(item.ID)
+		if links != nil {
+			id = fmt.Sprintf("[%d](%s)", item.ID, links.URL(item.ID))
+		}
+
+		fmt.Printf("| %s | %s | %s | %s | %s |\n", id,
+			escapeMarkdownCell(item.Title),
+			escapeMarkdownCell(item.Type),
+			escapeMarkdownCell(item.State),
+			escapeMarkdownCell(item.AssignedTo.String()))
+	}
+
+	return nil
+}
+
+// escapeMarkdownCell keeps a value from breaking a markdown table row
+func escapeMarkdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+func outputCards(workItems interface{}, links *workItemLinks) error {
+	items, ok := workItems.([]workitemtracking.WorkItem)
 	if !ok {
-		return ""
+		return fmt.Errorf("invalid work items type")
 	}
 
-	// Handle different field types
-	switch v := value.(type) {
-	case string:
-		return v
-	case map[string]interface{}:
-		// For user fields like AssignedTo, extract display name
-		if displayName, ok := v["displayName"].(string); ok {
-			return displayName
+	for i, item := range model.FromAPIList(items) {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		heading := fmt.Sprintf("#%d", item.ID)
+		if links != nil && links.hyperlinks {
+			heading = hyperlink(links.URL(item.ID), heading)
 		}
-		if uniqueName, ok := v["uniqueName"].(string); ok {
-			return uniqueName
+		fmt.Printf("%s %s\n", heading, item.Title)
+		fmt.Printf("  %s · %s", item.Type, item.State)
+		if !item.AssignedTo.IsZero() {
+			fmt.Printf(" · %s", item.AssignedTo)
+		}
+		fmt.Println()
+		if links != nil && !links.hyperlinks {
+			fmt.Printf("  %s\n", links.URL(item.ID))
 		}
 	}
 
-	return fmt.Sprintf("%v", value)
+	return nil
 }
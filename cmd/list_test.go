@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildWIQLQueryExcludesSnoozed(t *testing.T) {
+	query, err := buildWIQLQuery("Fabrikam", []int{7, 9})
+	if err != nil {
+		t.Fatalf("buildWIQLQuery() error = %v", err)
+	}
+	if !strings.Contains(query, "[System.Id] NOT IN (7, 9)") {
+		t.Errorf("query does not leave out the snoozed work items: %s", query)
+	}
+
+	query, err = buildWIQLQuery("Fabrikam", nil)
+	if err != nil {
+		t.Fatalf("buildWIQLQuery() error = %v", err)
+	}
+	if strings.Contains(query, "NOT IN") {
+		t.Errorf("query without snoozes has a NOT IN condition: %s", query)
+	}
+}
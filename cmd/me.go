@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/wiql"
+)
+
+// doneStateNames are the states counted as finished when showing sprint progress
+var doneStateNames = []string{"Closed", "Done", "Resolved", "Completed"}
+
+const meRecentLimit = 5
+
+var (
+	meTeamFlag   string
+	meFormatFlag string
+
+	meCmd = &cobra.Command{
+		Use:   "me",
+		Short: "Show a summary of your work",
+		Long: `Print a compact summary of your work: items assigned to you by state,
+items you follow, items changed in the last 24 hours and the progress of
+the current sprint.
+
+The output is short enough to run from a shell login or profile script.
+Sections that cannot be loaded are skipped with a warning.`,
+		Args: cobra.NoArgs,
+		RunE: runMe,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(meCmd)
+
+	meCmd.Flags().StringVar(&meTeamFlag, "team", "", "Team used for the current sprint (default: the project's default team)")
+	meCmd.Flags().StringVarP(&meFormatFlag, "format", "f", "text", "Output format (text, json, yaml, csv, tsv, ids)")
+}
+
+// meSummary is the data shown by 'azb me'. Sections that failed to load are nil.
+type meSummary struct {
+	User      string          `json:"user"`
+	Project   string          `json:"project"`
+	Assigned  map[string]int  `json:"assigned,omitempty"`
+	Following *int            `json:"following,omitempty"`
+	Changed   []meChangedItem `json:"changed"`
+	Sprint    *meSprint       `json:"sprint,omitempty"`
+
+	changedLoaded bool
+}
+
+// meChangedItem is a work item changed in the last 24 hours
+type meChangedItem struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	State string `json:"state"`
+}
+
+// meSprint is the progress of the current sprint
+type meSprint struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Done     int    `json:"done"`
+	Total    int    `json:"total"`
+	DaysLeft *int   `json:"daysLeft,omitempty"`
+}
+
+func runMe(cmd *cobra.Command, args []string) error {
+	formats := withOutputFormats("text")
+	if err := resolveFormat(cmd, "format", &meFormatFlag, formats...); err != nil {
+		return err
+	}
+	if !slices.Contains(formats, meFormatFlag) {
+		return fmt.Errorf("unsupported format: %s", meFormatFlag)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	summary := &meSummary{User: user.DisplayName, Project: client.GetProject()}
+
+	// Assigned to me, grouped by state
+	assigned, err := client.ListWorkItems(`SELECT [System.Id] FROM WorkItems
+WHERE [System.TeamProject] = @project
+AND [System.AssignedTo] = @me
+AND [System.State] NOT IN ('Closed', 'Done', 'Removed')
+ORDER BY [System.ChangedDate] DESC`, 200)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load assigned work items: %v\n", err)
+	} else {
+		summary.Assigned = make(map[string]int)
+		for _, count := range countByState(*assigned) {
+			summary.Assigned[count.state] = count.count
+		}
+	}
+
+	// Followed items
+	followed, err := client.ListWorkItems(`SELECT [System.Id] FROM WorkItems
+WHERE [System.Id] IN (@Follows)
+AND [System.State] NOT IN ('Closed', 'Done', 'Removed')`, 200)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load followed work items: %v\n", err)
+	} else {
+		count := len(*followed)
+		summary.Following = &count
+	}
+
+	// Changed in the last 24 hours. @Today has day precision, so trim to 24h locally.
+	changed, err := client.ListWorkItems(`SELECT [System.Id] FROM WorkItems
+WHERE ([System.AssignedTo] = @me OR [System.Id] IN (@Follows))
+AND [System.ChangedDate] >= @Today - 1
+ORDER BY [System.ChangedDate] DESC`, 200)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load recently changed work items: %v\n", err)
+	} else {
+		summary.changedLoaded = true
+		summary.Changed = []meChangedItem{}
+		for _, wi := range changedSince(*changed, time.Now().Add(-24*time.Hour)) {
+			summary.Changed = append(summary.Changed, meChangedItem{
+				ID:    *wi.Id,
+				Title: workItemTitle(&wi),
+				State: model.FieldString(wi.Fields, "System.State"),
+			})
+		}
+	}
+
+	sprint, err := loadSprintProgress(client, meTeamFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	summary.Sprint = sprint
+
+	if meFormatFlag != "text" {
+		return writeResult(meFormatFlag, summary, meChangedTable(summary.Changed))
+	}
+	printMeSummary(summary)
+	return nil
+}
+
+// meChangedTable lists the recently changed work items for the tabular formats
+func meChangedTable(items []meChangedItem) output.Table {
+	table := output.Table{Headers: []string{"ID", "Title", "State"}}
+	for _, item := range items {
+		table.Rows = append(table.Rows, []string{strconv.Itoa(item.ID), item.Title, item.State})
+	}
+	return table
+}
+
+// printMeSummary prints the summary as compact text
+func printMeSummary(summary *meSummary) {
+	fmt.Printf("%s · %s\n\n", summary.User, summary.Project)
+
+	if summary.Assigned != nil {
+		total := 0
+		counts := make([]stateCount, 0, len(summary.Assigned))
+		for state, count := range summary.Assigned {
+			counts = append(counts, stateCount{state: state, count: count})
+			total += count
+		}
+		fmt.Printf("Assigned to me: %d\n", total)
+		sortStateCounts(counts)
+		for _, count := range counts {
+			fmt.Printf("  %-14s %d\n", count.state, count.count)
+		}
+		fmt.Println()
+	}
+
+	if summary.Following != nil {
+		fmt.Printf("Following: %d\n\n", *summary.Following)
+	}
+
+	if summary.changedLoaded {
+		fmt.Printf("Changed in the last 24h: %d\n", len(summary.Changed))
+		for i, item := range summary.Changed {
+			if i == meRecentLimit {
+				fmt.Printf("  ... and %d more\n", len(summary.Changed)-meRecentLimit)
+				break
+			}
+			fmt.Printf("  #%d %s [%s]\n", item.ID, truncateString(item.Title, 50), item.State)
+		}
+		fmt.Println()
+	}
+
+	if sprint := summary.Sprint; sprint != nil {
+		line := fmt.Sprintf("Sprint %s: %s %d/%d done", sprint.Name, formatSprintBar(sprint.Done, sprint.Total), sprint.Done, sprint.Total)
+		if sprint.DaysLeft != nil {
+			line += fmt.Sprintf(", %d day(s) left", *sprint.DaysLeft)
+		}
+		fmt.Println(line)
+	}
+}
+
+// loadSprintProgress counts the done and total work items of the current sprint
+func loadSprintProgress(client api.APIClient, team string) (*meSprint, error) {
+	iteration, err := client.GetCurrentIteration(team)
+	if err != nil {
+		return nil, err
+	}
+	if iteration.Path == nil {
+		return nil, fmt.Errorf("current iteration has no path")
+	}
+
+	query := wiql.Select().Where(
+		wiql.Field("System.TeamProject").Eq(wiql.Project),
+		wiql.Field("System.IterationPath").Eq(wiql.String(*iteration.Path)),
+		wiql.Field("System.State").Ne(wiql.String("Removed")),
+	)
+	items, err := client.ListWorkItems(query.String(), 200)
+	if err != nil {
+		return nil, fmt.Errorf("could not load sprint work items: %w", err)
+	}
+
+	sprint := &meSprint{Name: *iteration.Path, Path: *iteration.Path, Total: len(*items)}
+	if iteration.Name != nil {
+		sprint.Name = *iteration.Name
+	}
+
+	for _, wi := range *items {
+		state := model.FieldString(wi.Fields, "System.State")
+		for _, name := range doneStateNames {
+			if strings.EqualFold(state, name) {
+				sprint.Done++
+				break
+			}
+		}
+	}
+
+	if iteration.Attributes != nil && iteration.Attributes.FinishDate != nil {
+		daysLeft := int(time.Until(iteration.Attributes.FinishDate.Time).Hours()/24) + 1
+		if daysLeft < 0 {
+			daysLeft = 0
+		}
+		sprint.DaysLeft = &daysLeft
+	}
+
+	return sprint, nil
+}
+
+// formatSprintBar renders a short progress bar
+func formatSprintBar(done, total int) string {
+	const width = 20
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// stateCount is the number of work items in a state
+type stateCount struct {
+	state string
+	count int
+}
+
+// countByState groups work items by state, largest group first
+func countByState(items []workitemtracking.WorkItem) []stateCount {
+	counts := make(map[string]int)
+	for _, wi := range items {
+		state := "Unknown"
+		if wi.Fields != nil {
+			if s, ok := (*wi.Fields)["System.State"].(string); ok && s != "" {
+				state = s
+			}
+		}
+		counts[state]++
+	}
+
+	result := make([]stateCount, 0, len(counts))
+	for state, count := range counts {
+		result = append(result, stateCount{state: state, count: count})
+	}
+	sortStateCounts(result)
+	return result
+}
+
+// sortStateCounts orders state counts largest first, then by name
+func sortStateCounts(counts []stateCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].count != counts[j].count {
+			return counts[i].count > counts[j].count
+		}
+		return counts[i].state < counts[j].state
+	})
+}
+
+// changedSince returns the work items whose System.ChangedDate is after since
+func changedSince(items []workitemtracking.WorkItem, since time.Time) []workitemtracking.WorkItem {
+	var result []workitemtracking.WorkItem
+	for _, wi := range items {
+		if wi.Id == nil || wi.Fields == nil {
+			continue
+		}
+		value, _ := (*wi.Fields)["System.ChangedDate"].(string)
+		changed, err := time.Parse(time.RFC3339, value)
+		if err != nil || changed.Before(since) {
+			continue
+		}
+		result = append(result, wi)
+	}
+	return result
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func testWorkItem(id int, fields map[string]interface{}) workitemtracking.WorkItem {
+	return workitemtracking.WorkItem{Id: &id, Fields: &fields}
+}
+
+func TestCountByState(t *testing.T) {
+	items := []workitemtracking.WorkItem{
+		testWorkItem(1, map[string]interface{}{"System.State": "Active"}),
+		testWorkItem(2, map[string]interface{}{"System.State": "New"}),
+		testWorkItem(3, map[string]interface{}{"System.State": "Active"}),
+		testWorkItem(4, map[string]interface{}{}),
+	}
+
+	got := countByState(items)
+	want := []stateCount{{"Active", 2}, {"New", 1}, {"Unknown", 1}}
+	if len(got) != len(want) {
+		t.Fatalf("countByState() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("countByState()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestChangedSince(t *testing.T) {
+	now := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	items := []workitemtracking.WorkItem{
+		testWorkItem(1, map[string]interface{}{"System.ChangedDate": "2024-05-02T09:00:00Z"}),
+		testWorkItem(2, map[string]interface{}{"System.ChangedDate": "2024-05-01T08:00:00Z"}),
+		testWorkItem(3, map[string]interface{}{"System.ChangedDate": "not a date"}),
+	}
+
+	got := changedSince(items, now.Add(-24*time.Hour))
+	if len(got) != 1 || *got[0].Id != 1 {
+		t.Errorf("changedSince() returned %d items, want only #1", len(got))
+	}
+}
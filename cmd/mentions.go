@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/mentions"
+)
+
+// expandMentions turns @alias mentions in a comment into identity mentions so
+// the people mentioned are notified, warning about the ones it cannot resolve
+func expandMentions(client api.APIClient, text string) string {
+	cache, err := mentions.LoadCache()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	resolver := mentions.NewResolver(client, cache)
+	expanded, warnings := resolver.Expand(text)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	if err := resolver.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return expanded
+}
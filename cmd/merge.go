@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/model"
+)
+
+const (
+	duplicateLinkRelation = "System.LinkTypes.Duplicate-Forward"
+	resolvedReasonField   = "Microsoft.VSTS.Common.ResolvedReason"
+)
+
+var (
+	mergeYesFlag        bool
+	mergeNoCommentsFlag bool
+	mergeStateFlag      string
+
+	mergeCmd = &cobra.Command{
+		Use:   "merge <keep-id> <duplicate-id>",
+		Short: "Merge a duplicate work item into the one you keep",
+		Long: `Resolve a duplicate in one step:
+
+  1. Fields that are empty on the kept work item are copied from the duplicate,
+     and tags are combined
+  2. Links of the duplicate (related work items, pull requests, commits,
+     builds, hyperlinks and attachments) are added to the kept work item;
+     parent and child links are not moved
+  3. Comments of the duplicate are copied to the kept work item
+  4. A Duplicate link is added from the kept work item to the duplicate
+  5. The duplicate is closed with Resolved Reason "Duplicate" where its type
+     has that field, and a comment pointing to the kept work item
+
+The changes are shown first and applied after confirmation.
+
+Examples:
+  azb merge 1234 1250
+  azb merge 1234 1250 --yes --no-comments`,
+		Args:        cobra.ExactArgs(2),
+		Annotations: mutatingAnnotations,
+		RunE:        runMerge,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+
+	mergeCmd.Flags().BoolVarP(&mergeYesFlag, "yes", "y", false, "Skip the confirmation prompt")
+	mergeCmd.Flags().BoolVar(&mergeNoCommentsFlag, "no-comments", false, "Do not copy comments")
+	mergeCmd.Flags().StringVar(&mergeStateFlag, "state", "", "State to close the duplicate with (default: the type's first completed state)")
+}
+
+// mergePlan is what 'azb merge' will change on the kept work item
+type mergePlan struct {
+	Fields   map[string]interface{}
+	Links    []api.WorkItemLink
+	Comments []workitemtracking.Comment
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	keepID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+	dupID, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[1])
+	}
+	if keepID == dupID {
+		return fmt.Errorf("cannot merge work item %d into itself", keepID)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	keep, err := client.GetWorkItem(keepID)
+	if err != nil {
+		return err
+	}
+	dup, err := client.GetWorkItem(dupID)
+	if err != nil {
+		return err
+	}
+
+	plan := mergePlan{
+		Fields: mergeFields(keep, dup),
+		Links:  mergeLinks(keep, dup, client.WorkItemAPIURL(keepID)),
+	}
+	if !mergeNoCommentsFlag {
+		plan.Comments, err = client.GetComments(dupID)
+		if err != nil {
+			return err
+		}
+	}
+
+	dupType := model.FieldString(dup.Fields, "System.WorkItemType")
+	closeState := mergeStateFlag
+	if closeState == "" {
+		closeState, err = client.GetStateForCategory(dupType, "Completed")
+		if err != nil {
+			closeState = "Closed"
+		}
+	}
+
+	printMergePlan(keep, dup, plan, closeState)
+	if !mergeYesFlag {
+		answer, err := promptOptional("\nApply these changes? (y/N)")
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if answer = strings.ToLower(answer); answer != "y" && answer != "yes" {
+			fmt.Println("Merge cancelled")
+			return nil
+		}
+	}
+
+	links := append(plan.Links, api.WorkItemLink{Rel: duplicateLinkRelation, URL: client.WorkItemAPIURL(dupID)})
+	if _, err := client.UpdateWorkItemWithLinks(keepID, plan.Fields, links); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Updated #%d with %d field(s) and %d link(s)\n", keepID, len(plan.Fields), len(links))
+
+	copied := 0
+	for _, comment := range plan.Comments {
+		if _, err := client.AddComment(keepID, mergedCommentText(dupID, comment)); err != nil {
+			fmt.Printf("  ✗ Failed to copy comment: %v\n", err)
+			continue
+		}
+		copied++
+	}
+	if len(plan.Comments) > 0 {
+		fmt.Printf("✓ Copied %d of %d comment(s)\n", copied, len(plan.Comments))
+	}
+
+	closeFields := map[string]interface{}{
+		"System.State":   closeState,
+		"System.History": fmt.Sprintf("Closed as a duplicate of #%d", keepID),
+	}
+	if hasDuplicateResolution(client, dupType) {
+		closeFields[resolvedReasonField] = "Duplicate"
+	}
+	if _, err := client.UpdateWorkItem(dupID, closeFields); err != nil {
+		return fmt.Errorf("merged into #%d, but closing the duplicate failed: %w", keepID, err)
+	}
+	fmt.Printf("✓ Closed #%d as %s\n", dupID, closeState)
+	fmt.Printf("  URL: %s\n", client.WorkItemURL(keepID))
+	return nil
+}
+
+// mergeFields returns the fields of dup to set on keep: those that are empty
+// on keep, plus the combined tags. Identity and workflow fields are skipped
+// like in 'azb clone'.
+func mergeFields(keep, dup *workitemtracking.WorkItem) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if dup.Fields == nil {
+		return fields
+	}
+
+	for name, value := range *dup.Fields {
+		if cloneExcludedFields[name] || hasAnyPrefix(name, cloneExcludedPrefixes) || name == "System.Tags" {
+			continue
+		}
+		if planValueString(value) == "" || model.FieldString(keep.Fields, name) != "" {
+			continue
+		}
+		if _, ok := value.(map[string]interface{}); ok {
+			value = planValueString(value)
+		}
+		fields[name] = value
+	}
+
+	keepTags := make(map[string]bool)
+	for _, tag := range strings.Split(workItemTags(keep), ";") {
+		keepTags[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+	var missing []string
+	for _, tag := range strings.Split(workItemTags(dup), ";") {
+		if tag = strings.TrimSpace(tag); tag != "" && !keepTags[strings.ToLower(tag)] {
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) > 0 {
+		fields["System.Tags"] = processTagUpdates(workItemTags(keep), strings.Join(missing, ","), "")
+	}
+
+	return fields
+}
+
+// mergeLinks returns the links of dup that keep does not have yet. Parent and
+// child links stay on the duplicate, since a work item has only one parent.
+func mergeLinks(keep, dup *workitemtracking.WorkItem, keepURL string) []api.WorkItemLink {
+	existing := make(map[string]bool)
+	if keep.Relations != nil {
+		for _, r := range *keep.Relations {
+			if r.Rel != nil && r.Url != nil {
+				existing[*r.Rel+" "+strings.ToLower(*r.Url)] = true
+			}
+		}
+	}
+
+	var links []api.WorkItemLink
+	if dup.Relations == nil {
+		return links
+	}
+	for _, r := range *dup.Relations {
+		if r.Rel == nil || r.Url == nil {
+			continue
+		}
+		rel, url := *r.Rel, *r.Url
+		if rel == api.ParentLinkRelation || rel == api.ChildLinkRelation || strings.HasPrefix(rel, "System.LinkTypes.Duplicate") {
+			continue
+		}
+		if strings.EqualFold(url, keepURL) || existing[rel+" "+strings.ToLower(url)] {
+			continue
+		}
+
+		link := api.WorkItemLink{Rel: rel, URL: url}
+		// Only the descriptive attributes can be set; the rest are read-only
+		if r.Attributes != nil {
+			for _, name := range []string{"name", "comment"} {
+				if value, ok := (*r.Attributes)[name]; ok && value != "" {
+					if link.Attributes == nil {
+						link.Attributes = make(map[string]interface{})
+					}
+					link.Attributes[name] = value
+				}
+			}
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// mergedCommentText prefixes a copied comment with where it came from
+func mergedCommentText(dupID int, comment workitemtracking.Comment) string {
+	author := "unknown"
+	if comment.CreatedBy != nil && comment.CreatedBy.DisplayName != nil {
+		author = *comment.CreatedBy.DisplayName
+	}
+	date := ""
+	if comment.CreatedDate != nil {
+		date = " on " + comment.CreatedDate.Time.Format("2006-01-02")
+	}
+	text := ""
+	if comment.Text != nil {
+		text = *comment.Text
+	}
+	return fmt.Sprintf("<p><i>From #%d, %s%s:</i></p>%s", dupID, author, date, text)
+}
+
+// hasDuplicateResolution reports whether a work item type can record "Duplicate" as its resolved reason
+func hasDuplicateResolution(client api.APIClient, workItemType string) bool {
+	field, err := client.GetFieldDefinition(workItemType, resolvedReasonField)
+	if err != nil || field == nil {
+		return false
+	}
+	if field.AllowedValues == nil || len(*field.AllowedValues) == 0 {
+		return true
+	}
+	for _, value := range *field.AllowedValues {
+		if strings.EqualFold(value, "Duplicate") {
+			return true
+		}
+	}
+	return false
+}
+
+// printMergePlan shows what 'azb merge' is about to do
+func printMergePlan(keep, dup *workitemtracking.WorkItem, plan mergePlan, closeState string) {
+	fmt.Printf("Merge #%d %s\n", *dup.Id, workItemTitle(dup))
+	fmt.Printf(" into #%d %s\n\n", *keep.Id, workItemTitle(keep))
+
+	names := make([]string, 0, len(plan.Fields))
+	for name := range plan.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		fmt.Println("  No fields to copy")
+	}
+	for _, name := range names {
+		fmt.Printf("  + %s: %s\n", name, truncateString(planValueString(plan.Fields[name]), 60))
+	}
+
+	for _, link := range plan.Links {
+		target := link.URL
+		if id := api.WorkItemIDFromURL(link.URL); id > 0 && strings.Contains(strings.ToLower(link.URL), "/_apis/wit/workitems/") {
+			target = fmt.Sprintf("#%d", id)
+		}
+		fmt.Printf("  + Link %s: %s\n", link.Rel, target)
+	}
+	fmt.Printf("  + Link %s: #%d\n", duplicateLinkRelation, *dup.Id)
+	if len(plan.Comments) > 0 {
+		fmt.Printf("  + %d comment(s)\n", len(plan.Comments))
+	}
+
+	fmt.Printf("\n  Close #%d as %s\n", *dup.Id, closeState)
+}
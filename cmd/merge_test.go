@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+func TestMergeFields(t *testing.T) {
+	keep := testWorkItem(1, map[string]interface{}{
+		"System.Title":       "Login fails",
+		"System.State":       "Active",
+		"System.Description": "",
+		"System.Tags":        "auth; web",
+	})
+	dup := testWorkItem(2, map[string]interface{}{
+		"System.Title":                   "Cannot log in",
+		"System.State":                   "New",
+		"System.Description":             "Steps to reproduce",
+		"System.AssignedTo":              map[string]interface{}{"uniqueName": "ada@example.com"},
+		"Microsoft.VSTS.Common.Priority": 1.0,
+		"System.Tags":                    "Web; regression",
+		"System.CreatedDate":             "2024-05-01T00:00:00Z",
+	})
+
+	got := mergeFields(&keep, &dup)
+	want := map[string]interface{}{
+		"System.Description":             "Steps to reproduce",
+		"System.AssignedTo":              "ada@example.com",
+		"Microsoft.VSTS.Common.Priority": 1.0,
+	}
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("%s = %v, want %v", name, got[name], value)
+		}
+	}
+	for _, name := range []string{"System.Title", "System.State", "System.CreatedDate"} {
+		if _, ok := got[name]; ok {
+			t.Errorf("%s should not be copied", name)
+		}
+	}
+
+	tags := map[string]bool{}
+	for _, tag := range strings.Split(got["System.Tags"].(string), ";") {
+		tags[strings.TrimSpace(tag)] = true
+	}
+	if len(tags) != 3 || !tags["auth"] || !tags["web"] || !tags["regression"] {
+		t.Errorf("System.Tags = %q, want auth, web and regression", got["System.Tags"])
+	}
+}
+
+func TestMergeLinks(t *testing.T) {
+	const keepURL = "https://dev.azure.com/org/_apis/wit/workItems/1"
+	relation := func(rel, url string, attributes map[string]interface{}) workitemtracking.WorkItemRelation {
+		return workitemtracking.WorkItemRelation{Rel: &rel, Url: &url, Attributes: &attributes}
+	}
+
+	keep := testWorkItem(1, nil)
+	keep.Relations = &[]workitemtracking.WorkItemRelation{
+		relation("System.LinkTypes.Related", "https://dev.azure.com/org/_apis/wit/workItems/5", nil),
+	}
+	dup := testWorkItem(2, nil)
+	dup.Relations = &[]workitemtracking.WorkItemRelation{
+		relation("System.LinkTypes.Related", "https://dev.azure.com/org/_apis/wit/workItems/5", nil),
+		relation("System.LinkTypes.Related", keepURL, nil),
+		relation(api.ParentLinkRelation, "https://dev.azure.com/org/_apis/wit/workItems/9", nil),
+		relation("System.LinkTypes.Related", "https://dev.azure.com/org/_apis/wit/workItems/6", nil),
+		relation("ArtifactLink", "vstfs:///Git/PullRequestId/p%2Fr%2F7", map[string]interface{}{"name": "Pull Request", "id": 123.0}),
+	}
+
+	got := mergeLinks(&keep, &dup, keepURL)
+	if len(got) != 2 {
+		t.Fatalf("mergeLinks() returned %d links, want 2: %+v", len(got), got)
+	}
+	if got[0].URL != "https://dev.azure.com/org/_apis/wit/workItems/6" {
+		t.Errorf("first link = %s, want the related work item 6", got[0].URL)
+	}
+	if got[1].Rel != "ArtifactLink" || got[1].Attributes["name"] != "Pull Request" || got[1].Attributes["id"] != nil {
+		t.Errorf("artifact link = %+v, want only its name attribute", got[1])
+	}
+}
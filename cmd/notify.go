@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/notify"
+)
+
+var (
+	notifySinkFlag string
+
+	notifyCmd = &cobra.Command{
+		Use:   "notify",
+		Short: "Manage notification sinks",
+		Long: `Notification sinks receive the changes that 'azb watch' finds. They are
+configured under the notifications key of the config file:
+
+  notifications:
+    - name: team-channel
+      type: slack            # webhook, slack, teams or command
+      url: https://hooks.slack.com/services/...
+      template: "{{.Author}} updated #{{.ID}} {{.Title}}: {{.URL}}"
+    - name: log
+      type: command
+      command: jq -c . >> ~/azb-events.jsonl
+      events: [commented]    # updated, commented; default all
+
+Templates use Go template syntax with the fields ID, Title, URL, Rev, Author,
+Time, Kind, Changes and Comment, and .Summary for a one-line description.
+Webhooks receive the event as JSON with the formatted text in "message";
+commands receive it on stdin, with the text in $AZB_MESSAGE.`,
+	}
+
+	notifyTestCmd = &cobra.Command{
+		Use:   "test",
+		Short: "Send a test event to the notification sinks",
+		Long: `Send a sample change event to every configured sink, or to the one named
+with --sink, and report which deliveries failed.
+
+Examples:
+  azb notify test
+  azb notify test --sink team-channel`,
+		Args: cobra.NoArgs,
+		RunE: runNotifyTest,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.AddCommand(notifyTestCmd)
+
+	notifyTestCmd.Flags().StringVar(&notifySinkFlag, "sink", "", "Only test the sink with this name")
+}
+
+func runNotifyTest(cmd *cobra.Command, args []string) error {
+	sinks, err := notify.Load()
+	if err != nil {
+		return err
+	}
+	if len(sinks) == 0 {
+		return fmt.Errorf("no notification sinks configured (see 'azb notify --help')")
+	}
+
+	event := notify.Event{
+		Kind:    notify.KindUpdated,
+		ID:      1234,
+		Title:   "Test notification from azb",
+		URL:     "https://dev.azure.com/example/project/_workitems/edit/1234",
+		Rev:     2,
+		Author:  "azb",
+		Time:    time.Now(),
+		Changes: []notify.Change{{Field: "System.State", OldValue: "New", NewValue: "Active"}},
+	}
+
+	tested, failed := 0, 0
+	for _, sink := range sinks {
+		if notifySinkFlag != "" && sink.Name() != notifySinkFlag {
+			continue
+		}
+		tested++
+		if err := sink.Deliver(context.Background(), event); err != nil {
+			fmt.Printf("✗ %s: %v\n", sink.Name(), err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ %s\n", sink.Name())
+	}
+
+	if tested == 0 {
+		return fmt.Errorf("no notification sink named %q", notifySinkFlag)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d sink(s) failed", failed, tested)
+	}
+	return nil
+}
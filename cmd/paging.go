@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/wiql"
+)
+
+// defaultPageSize is the page size used when --page is given without --page-size
+const defaultPageSize = 100
+
+// maxQueryIDs is the most IDs the service returns for one WIQL query
+const maxQueryIDs = 20000
+
+// addPageFlags registers --page and --page-size on a command that lists work items
+func addPageFlags(cmd *cobra.Command, page, pageSize *int) {
+	cmd.Flags().IntVar(page, "page", 0, "Return only this page of results (starting at 1); overrides --limit")
+	cmd.Flags().IntVar(pageSize, "page-size", defaultPageSize, "Number of work items per page when using --page")
+}
+
+// fetchWorkItemPage runs a WIQL query and returns one page of its work items.
+// hasMore reports whether another page follows.
+func fetchWorkItemPage(client api.APIClient, wiql string, page, pageSize int) ([]workitemtracking.WorkItem, bool, error) {
+	if page < 1 {
+		return nil, false, fmt.Errorf("--page must be 1 or greater")
+	}
+	if pageSize < 1 {
+		return nil, false, fmt.Errorf("--page-size must be 1 or greater")
+	}
+
+	// Ask for one more ID than needed to learn whether a next page exists
+	ids, err := client.QueryWorkItemIDs(wiql, page*pageSize+1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	pageIDs, hasMore := pageSlice(ids, page, pageSize)
+	if len(pageIDs) == 0 {
+		return nil, false, nil
+	}
+
+	workItems, err := client.GetWorkItems(pageIDs)
+	if err != nil {
+		return nil, false, err
+	}
+	return workItems, hasMore, nil
+}
+
+// queryAllWorkItemIDs returns the IDs of all work items matching conditions,
+// however many there are. It queries in ID order and starts each query after
+// the last ID of the one before, as one query stops at maxQueryIDs.
+func queryAllWorkItemIDs(client api.APIClient, conditions ...wiql.Condition) ([]int, error) {
+	var ids []int
+	last := 0
+	for {
+		query := wiql.Select().
+			Where(conditions...).
+			Where(wiql.Field("System.Id").Gt(wiql.Int(last))).
+			OrderBy("System.Id", false)
+		page, err := client.QueryWorkItemIDs(query.String(), maxQueryIDs)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, page...)
+		if len(page) < maxQueryIDs {
+			return ids, nil
+		}
+		last = page[len(page)-1]
+	}
+}
+
+// pageSlice returns the IDs on a 1-based page and whether IDs remain after it
+func pageSlice(ids []int, page, pageSize int) ([]int, bool) {
+	start := (page - 1) * pageSize
+	if start >= len(ids) {
+		return nil, false
+	}
+	end := start + pageSize
+	if end >= len(ids) {
+		return ids[start:], false
+	}
+	return ids[start:end], true
+}
+
+// printPageHint tells scripted callers on stderr where the page ends and how to get the next one
+func printPageHint(page, pageSize, count int, hasMore bool) {
+	if count == 0 {
+		fmt.Fprintf(os.Stderr, "Page %d: no work items\n", page)
+		return
+	}
+	first := (page-1)*pageSize + 1
+	if !hasMore {
+		fmt.Fprintf(os.Stderr, "Page %d: items %d-%d (last page)\n", page, first, first+count-1)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Page %d: items %d-%d (next: --page %d)\n", page, first, first+count-1, page+1)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SOMUCHDOG/azb/internal/api/apitest"
+	"github.com/SOMUCHDOG/azb/internal/wiql"
+)
+
+func TestPageSlice(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name        string
+		page        int
+		pageSize    int
+		want        []int
+		wantHasMore bool
+	}{
+		{"first page", 1, 2, []int{1, 2}, true},
+		{"middle page", 2, 2, []int{3, 4}, true},
+		{"last partial page", 3, 2, []int{5}, false},
+		{"exact last page", 1, 5, []int{1, 2, 3, 4, 5}, false},
+		{"past the end", 4, 2, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hasMore := pageSlice(ids, tt.page, tt.pageSize)
+			if !reflect.DeepEqual(got, tt.want) || hasMore != tt.wantHasMore {
+				t.Errorf("pageSlice(page %d, size %d) = %v, %v; want %v, %v", tt.page, tt.pageSize, got, hasMore, tt.want, tt.wantHasMore)
+			}
+		})
+	}
+}
+
+func TestQueryAllWorkItemIDs(t *testing.T) {
+	const total = maxQueryIDs + 5
+
+	var queries []string
+	client := apitest.New()
+	client.QueryWorkItemIDsFunc = func(query string, top int) ([]int, error) {
+		queries = append(queries, query)
+		var after int
+		if _, err := fmt.Sscanf(query[strings.Index(query, "[System.Id] > "):], "[System.Id] > %d", &after); err != nil {
+			t.Fatalf("query without an ID watermark: %s", query)
+		}
+		var ids []int
+		for id := after + 1; id <= total && len(ids) < top; id++ {
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+
+	ids, err := queryAllWorkItemIDs(client, wiql.Field("System.Tags").Contains(wiql.String("ux")))
+	if err != nil {
+		t.Fatalf("queryAllWorkItemIDs() error = %v", err)
+	}
+	if len(ids) != total || ids[len(ids)-1] != total {
+		t.Errorf("got %d IDs ending at %d, want %d", len(ids), ids[len(ids)-1], total)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("ran %d queries, want 2", len(queries))
+	}
+	want := fmt.Sprintf("SELECT [System.Id] FROM WorkItems WHERE [System.Tags] CONTAINS 'ux' AND [System.Id] > %d ORDER BY [System.Id] ASC", maxQueryIDs)
+	if queries[1] != want {
+		t.Errorf("second query = %s\nwant %s", queries[1], want)
+	}
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+// planVersion is bumped when the plan file format changes incompatibly
+const planVersion = 1
+
+// plan is a reviewable description of what a bulk command would change.
+// It is produced by --dry-run and consumed by --apply.
+type plan struct {
+	Version   int        `json:"version"`
+	Command   string     `json:"command"`
+	Project   string     `json:"project"`
+	CreatedAt time.Time  `json:"createdAt"`
+	Items     []planItem `json:"items"`
+}
+
+// planItem is the change planned for a single work item
+type planItem struct {
+	ID      int          `json:"id"`
+	Title   string       `json:"title,omitempty"`
+	Delete  bool         `json:"delete,omitempty"`
+	Changes []planChange `json:"changes,omitempty"`
+	Error   string       `json:"error,omitempty"` // Set when the item could not be planned
+}
+
+// planChange is a single field change with its value at planning time
+type planChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"oldValue"`
+	NewValue interface{} `json:"newValue"`
+}
+
+// newPlan creates an empty plan for a command
+func newPlan(command string, client api.APIClient) *plan {
+	return &plan{
+		Version:   planVersion,
+		Command:   command,
+		Project:   client.GetProject(),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// planFieldChanges compares new field values with a work item's current values
+func planFieldChanges(workItem *workitemtracking.WorkItem, fields map[string]interface{}) []planChange {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []planChange
+	for _, name := range names {
+		var old interface{}
+		if workItem.Fields != nil {
+			old = (*workItem.Fields)[name]
+		}
+		changes = append(changes, planChange{Field: name, OldValue: old, NewValue: fields[name]})
+	}
+	return changes
+}
+
+// workItemTitle returns the title of a work item, or an empty string
+func workItemTitle(workItem *workitemtracking.WorkItem) string {
+	if workItem.Fields == nil {
+		return ""
+	}
+	if title, ok := (*workItem.Fields)["System.Title"].(string); ok {
+		return title
+	}
+	return ""
+}
+
+// printPlan writes a plan as a table or as JSON
+func printPlan(p *plan, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(p)
+	case "table":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tFIELD\tOLD\tNEW")
+		for _, item := range p.Items {
+			switch {
+			case item.Error != "":
+				fmt.Fprintf(w, "%d\t(error)\t%s\t\n", item.ID, item.Error)
+			case item.Delete:
+				fmt.Fprintf(w, "%d\t(delete)\t%s\t\n", item.ID, truncateString(item.Title, 50))
+			default:
+				for _, change := range item.Changes {
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", item.ID, change.Field,
+						truncateString(planValueString(change.OldValue), 40),
+						truncateString(planValueString(change.NewValue), 40))
+				}
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("\nDry run: %d work item(s) would be affected. Save with --plan-format json and run with --apply <file>.\n", len(p.Items))
+		return nil
+	default:
+		return fmt.Errorf("unsupported plan format: %s", format)
+	}
+}
+
+// loadPlan reads a plan file and checks that it belongs to the given command and project
+func loadPlan(path, command string, client api.APIClient) (*plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+
+	var p plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+
+	if p.Version != planVersion {
+		return nil, fmt.Errorf("unsupported plan version %d (expected %d)", p.Version, planVersion)
+	}
+	if p.Command != command {
+		return nil, fmt.Errorf("plan was created by '%s', not '%s'", p.Command, command)
+	}
+	if !strings.EqualFold(p.Project, client.GetProject()) {
+		return nil, fmt.Errorf("plan is for project '%s', but the current project is '%s'", p.Project, client.GetProject())
+	}
+
+	return &p, nil
+}
+
+// planValueString renders a field value for display and comparison.
+// Identity fields are objects, so they are reduced to their unique or display name.
+func planValueString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if identity, ok := value.(map[string]interface{}); ok {
+		if name, ok := identity["uniqueName"].(string); ok {
+			return name
+		}
+		if name, ok := identity["displayName"].(string); ok {
+			return name
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// truncateString shortens s to at most n characters
+func truncateString(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
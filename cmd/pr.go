@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/output"
+)
+
+// pullRequestLinkName is the link name the web UI gives pull request links
+const pullRequestLinkName = "Pull Request"
+
+var (
+	prFormatFlag string
+
+	prCmd = &cobra.Command{
+		Use:   "pr",
+		Short: "Link pull requests to work items",
+		Long:  `Associate Azure Repos pull requests with work items without opening the browser.`,
+	}
+
+	prLinkCmd = &cobra.Command{
+		Use:   "link <work-item-id> <pr-url-or-id>",
+		Short: "Link a pull request to a work item",
+		Long: `Add a pull request link to a work item, as the Development section of the
+work item form does. The pull request can be given by ID or by its web URL.
+
+Examples:
+  azb pr link 1234 567
+  azb pr link 1234 https://dev.azure.com/org/project/_git/repo/pullrequest/567`,
+		Args:        cobra.ExactArgs(2),
+		Annotations: mutatingAnnotations,
+		RunE:        runPRLink,
+	}
+
+	prListCmd = &cobra.Command{
+		Use:   "list <work-item-id>",
+		Short: "List the pull requests linked to a work item",
+		Long: `List the pull requests linked to a work item with their status and branches.
+
+Examples:
+  azb pr list 1234
+  azb pr list 1234 --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runPRList,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(prCmd)
+	prCmd.AddCommand(prLinkCmd)
+	prCmd.AddCommand(prListCmd)
+
+	prListCmd.Flags().StringVarP(&prFormatFlag, "format", "f", "table", "Output format (table, json, yaml, csv, tsv, ids)")
+}
+
+func runPRLink(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+	prID, err := api.ParsePullRequestID(args[1])
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPullRequest(prID)
+	if err != nil {
+		return err
+	}
+	workItem, err := client.GetWorkItem(id)
+	if err != nil {
+		return err
+	}
+
+	for _, linked := range linkedPullRequestIDs(workItem) {
+		if linked == prID {
+			fmt.Printf("Pull request !%d is already linked to #%d\n", prID, id)
+			return nil
+		}
+	}
+
+	link := api.ArtifactLink(pr.ArtifactURL, pullRequestLinkName)
+	if _, err := client.UpdateWorkItemWithLinks(id, nil, []api.WorkItemLink{link}); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Linked pull request !%d to #%d\n", prID, id)
+	fmt.Printf("  %s\n", pr.Title)
+	fmt.Printf("  URL: %s\n", pr.URL)
+	return nil
+}
+
+func runPRList(cmd *cobra.Command, args []string) error {
+	formats := withOutputFormats("table")
+	if err := resolveFormat(cmd, "format", &prFormatFlag, formats...); err != nil {
+		return err
+	}
+	if !slices.Contains(formats, prFormatFlag) {
+		return fmt.Errorf("unsupported format: %s", prFormatFlag)
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	workItem, err := client.GetWorkItem(id)
+	if err != nil {
+		return err
+	}
+
+	prs := []*api.PullRequestSummary{}
+	for _, prID := range linkedPullRequestIDs(workItem) {
+		pr, err := client.GetPullRequest(prID)
+		if err != nil {
+			// The pull request may be in a repository the token cannot read
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			continue
+		}
+		prs = append(prs, pr)
+	}
+
+	if prFormatFlag != "table" {
+		return writeResult(prFormatFlag, prs, pullRequestTable(prs))
+	}
+
+	if len(prs) == 0 {
+		fmt.Printf("No pull requests linked to #%d\n", id)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PR\tStatus\tTitle\tRepository\tBranches\t")
+	for _, pr := range prs {
+		status := pr.Status
+		if pr.IsDraft {
+			status += " (draft)"
+		}
+		fmt.Fprintf(w, "!%d\t%s\t%s\t%s\t%s → %s\t\n", pr.ID, status, truncateString(pr.Title, 50),
+			pr.Repository, pr.SourceBranch, pr.TargetBranch)
+	}
+	w.Flush()
+	return nil
+}
+
+// pullRequestTable lists pull requests for the tabular formats
+func pullRequestTable(prs []*api.PullRequestSummary) output.Table {
+	table := output.Table{Headers: []string{"ID", "Status", "Draft", "Title", "Repository", "Source", "Target", "URL"}}
+	for _, pr := range prs {
+		table.Rows = append(table.Rows, []string{strconv.Itoa(pr.ID), pr.Status, strconv.FormatBool(pr.IsDraft), pr.Title,
+			pr.Repository, pr.SourceBranch, pr.TargetBranch, pr.URL})
+	}
+	return table
+}
+
+// linkedPullRequestIDs returns the IDs of the pull requests linked to a work item
+func linkedPullRequestIDs(workItem *workitemtracking.WorkItem) []int {
+	var ids []int
+	if workItem.Relations == nil {
+		return ids
+	}
+	for _, r := range *workItem.Relations {
+		if r.Rel == nil || r.Url == nil || *r.Rel != api.ArtifactLinkRelation {
+			continue
+		}
+		artifact, ok := api.ParseArtifactURL(*r.Url)
+		if !ok || !artifact.IsPullRequest() {
+			continue
+		}
+		if prID, err := strconv.Atoi(artifact.ShortID()); err == nil {
+			ids = append(ids, prID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestLinkedPullRequestIDs(t *testing.T) {
+	relation := func(rel, url string) workitemtracking.WorkItemRelation {
+		return workitemtracking.WorkItemRelation{Rel: &rel, Url: &url}
+	}
+	workItem := &workitemtracking.WorkItem{Relations: &[]workitemtracking.WorkItemRelation{
+		relation("ArtifactLink", "vstfs:///Git/PullRequestId/p%2Fr%2F12"),
+		relation("ArtifactLink", "vstfs:///Git/Commit/p%2Fr%2Fabc123"),
+		relation("ArtifactLink", "vstfs:///Build/Build/99"),
+		relation("System.LinkTypes.Related", "https://dev.azure.com/org/_apis/wit/workItems/5"),
+		relation("ArtifactLink", "vstfs:///Git/PullRequestId/p%2Fr%2F34"),
+	}}
+
+	if got, want := linkedPullRequestIDs(workItem), []int{12, 34}; !reflect.DeepEqual(got, want) {
+		t.Errorf("linkedPullRequestIDs() = %v, want %v", got, want)
+	}
+}
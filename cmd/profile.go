@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/wiql"
+)
+
+// profileBatchSize is how many work items the batch get operation fetches
+const profileBatchSize = 50
+
+// profileSlowRequest is the median latency above which requests are reported as slow
+const profileSlowRequest = time.Second
+
+var (
+	profileDurationFlag time.Duration
+	profileSamplesFlag  int
+
+	profileCmd = &cobra.Command{
+		Use:   "profile",
+		Short: "Measure Azure DevOps API latency",
+		Long: `Run a representative set of read-only API calls repeatedly and report
+latency percentiles per operation:
+
+  queries   list the top level of saved queries
+  wiql      run a WIQL query for recently changed work items
+  batch     fetch up to 50 of those work items in one batch request
+
+Retries caused by throttling or server errors are counted separately, which
+helps tell apart a slow network or server, organization throttling, and time
+spent in azb itself. Nothing is modified.
+
+Examples:
+  azb profile
+  azb profile --duration 1m --samples 50`,
+		Args: cobra.NoArgs,
+		RunE: runProfile,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+
+	profileCmd.Flags().DurationVarP(&profileDurationFlag, "duration", "d", 30*time.Second, "Stop starting new rounds after this long")
+	profileCmd.Flags().IntVarP(&profileSamplesFlag, "samples", "n", 10, "Maximum calls per operation")
+}
+
+// profileStats collects the measurements of one operation
+type profileStats struct {
+	name       string
+	latencies  []time.Duration
+	errors     int
+	retries    int
+	throttled  int
+	retryDelay time.Duration
+	lastErr    error
+}
+
+func (s *profileStats) record(latency time.Duration, err error) {
+	s.latencies = append(s.latencies, latency)
+	if err != nil {
+		s.errors++
+		s.lastErr = err
+	}
+}
+
+// percentile returns the nearest-rank percentile (0-100) of the recorded latencies
+func (s *profileStats) percentile(p float64) time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), s.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func runProfile(cmd *cobra.Command, args []string) error {
+	if err := textOutputOnly(cmd); err != nil {
+		return err
+	}
+
+	if profileSamplesFlag < 1 {
+		return fmt.Errorf("samples must be at least 1")
+	}
+	if profileDurationFlag <= 0 {
+		return fmt.Errorf("duration must be positive")
+	}
+
+	setupStart := time.Now()
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	setup := time.Since(setupStart)
+
+	queries := &profileStats{name: "queries"}
+	wiqlStats := &profileStats{name: "wiql"}
+	batch := &profileStats{name: "batch"}
+
+	// Retries happen inside the transport; attribute them to the running operation
+	current := queries
+	api.SetThrottleHandler(func(event api.ThrottleEvent) {
+		current.retries++
+		current.retryDelay += event.Delay
+		if event.Throttled {
+			current.throttled++
+		}
+	})
+	defer api.SetThrottleHandler(nil)
+
+	query := wiql.Select().
+		Where(wiql.Field("System.TeamProject").Eq(wiql.String(client.GetProject()))).
+		OrderBy("System.ChangedDate", true).
+		String()
+
+	fmt.Printf("Profiling %s/%s for up to %s (%d call(s) per operation)...\n",
+		client.GetOrganizationURL(), client.GetProject(), profileDurationFlag, profileSamplesFlag)
+
+	start := time.Now()
+	for round := 0; round < profileSamplesFlag && time.Since(start) < profileDurationFlag; round++ {
+		current = queries
+		callStart := time.Now()
+		_, err := client.ListQueries("", 1)
+		queries.record(time.Since(callStart), err)
+
+		current = wiqlStats
+		callStart = time.Now()
+		ids, err := client.QueryWorkItemIDs(query, profileBatchSize)
+		wiqlStats.record(time.Since(callStart), err)
+
+		if len(ids) == 0 {
+			continue
+		}
+		current = batch
+		callStart = time.Now()
+		_, err = client.GetWorkItems(ids)
+		batch.record(time.Since(callStart), err)
+	}
+	elapsed := time.Since(start)
+
+	fmt.Println()
+	printProfileTable([]*profileStats{queries, wiqlStats, batch})
+	fmt.Println()
+	fmt.Printf("Client setup (config, token, connection): %s\n", setup.Round(time.Millisecond))
+	fmt.Printf("Total time: %s\n", elapsed.Round(time.Millisecond))
+	fmt.Println()
+	for _, line := range profileDiagnosis([]*profileStats{queries, wiqlStats, batch}, elapsed) {
+		fmt.Println(line)
+	}
+
+	return nil
+}
+
+func printProfileTable(stats []*profileStats) {
+	fmt.Printf("%-10s %6s %6s %8s %9s %9s %9s %9s %9s\n", "Operation", "Calls", "Errors", "Retries", "Min", "p50", "p90", "p99", "Max")
+	fmt.Println(strings.Repeat("-", 84))
+	round := func(d time.Duration) string { return d.Round(time.Millisecond).String() }
+	for _, s := range stats {
+		if len(s.latencies) == 0 {
+			fmt.Printf("%-10s %6d %6s %8s %9s %9s %9s %9s %9s\n", s.name, 0, "-", "-", "-", "-", "-", "-", "-")
+			continue
+		}
+		fmt.Printf("%-10s %6d %6d %8d %9s %9s %9s %9s %9s\n", s.name, len(s.latencies), s.errors, s.retries,
+			round(s.percentile(0)), round(s.percentile(50)), round(s.percentile(90)), round(s.percentile(99)), round(s.percentile(100)))
+	}
+}
+
+// profileDiagnosis explains what the measurements point to
+func profileDiagnosis(stats []*profileStats, elapsed time.Duration) []string {
+	var lines []string
+	var calls, throttled, retries int
+	var retryDelay time.Duration
+	slow := false
+	for _, s := range stats {
+		calls += len(s.latencies)
+		throttled += s.throttled
+		retries += s.retries
+		retryDelay += s.retryDelay
+		if s.errors > 0 {
+			lines = append(lines, fmt.Sprintf("✗ %s: %d of %d call(s) failed: %v", s.name, s.errors, len(s.latencies), s.lastErr))
+		}
+		if len(s.latencies) > 0 && s.percentile(50) > profileSlowRequest {
+			slow = true
+		}
+	}
+
+	switch {
+	case calls == 0:
+		lines = append(lines, "No calls were made; increase --duration.")
+	case throttled > 0:
+		lines = append(lines, fmt.Sprintf("Azure DevOps throttled %d request(s); %s of %s was spent waiting to retry. Your organization is rate limiting this account.",
+			throttled, retryDelay.Round(time.Millisecond), elapsed.Round(time.Millisecond)))
+	case retries > 0:
+		lines = append(lines, fmt.Sprintf("%d request(s) were retried after server or network errors; %s was spent waiting.", retries, retryDelay.Round(time.Millisecond)))
+	case slow:
+		lines = append(lines, "Requests are slow without throttling, which points to network latency or a slow Azure DevOps region rather than azb.")
+	default:
+		lines = append(lines, "✓ API latency looks healthy. If azb still feels slow, run the command with --verbose to see where time goes.")
+	}
+	return lines
+}
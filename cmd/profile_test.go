@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfileStatsPercentile(t *testing.T) {
+	stats := &profileStats{}
+	for _, ms := range []int{50, 10, 40, 20, 30, 60, 70, 80, 90, 100} {
+		stats.record(time.Duration(ms)*time.Millisecond, nil)
+	}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 10 * time.Millisecond},
+		{50, 50 * time.Millisecond},
+		{90, 90 * time.Millisecond},
+		{99, 100 * time.Millisecond},
+		{100, 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := stats.percentile(tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+
+	if got := (&profileStats{}).percentile(50); got != 0 {
+		t.Errorf("percentile of no calls = %v, want 0", got)
+	}
+}
+
+func TestProfileDiagnosis(t *testing.T) {
+	fast := func() *profileStats {
+		s := &profileStats{name: "wiql"}
+		s.record(100*time.Millisecond, nil)
+		return s
+	}
+
+	tests := []struct {
+		name  string
+		stats *profileStats
+		want  string
+	}{
+		{"healthy", fast(), "looks healthy"},
+		{"throttled", func() *profileStats {
+			s := fast()
+			s.retries, s.throttled, s.retryDelay = 1, 1, 2*time.Second
+			return s
+		}(), "throttled 1 request"},
+		{"slow", func() *profileStats {
+			s := &profileStats{name: "wiql"}
+			s.record(3*time.Second, nil)
+			return s
+		}(), "network latency"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := profileDiagnosis([]*profileStats{tt.stats}, 5*time.Second)
+			if got := strings.Join(lines, "\n"); !strings.Contains(got, tt.want) {
+				t.Errorf("profileDiagnosis() = %q, want it to mention %q", got, tt.want)
+			}
+		})
+	}
+}
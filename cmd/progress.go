@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/config"
+)
+
+const (
+	progressBarWidth       = 30
+	progressRenderInterval = 100 * time.Millisecond
+)
+
+// concurrencyFlag is the --concurrency of the bulk command being run
+var concurrencyFlag int
+
+// addConcurrencyFlag registers --concurrency on a bulk command and applies it,
+// in place of the concurrency setting, before the command runs
+func addConcurrencyFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&concurrencyFlag, "concurrency", api.DefaultConcurrency, "Number of work items to process at once (overrides the concurrency setting)")
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("concurrency") {
+			return nil
+		}
+		if concurrencyFlag < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+		api.SetConcurrency(concurrencyFlag)
+		return nil
+	}
+}
+
+// configureConcurrency applies the concurrency setting to bulk operations
+func configureConcurrency() {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+
+	n, err := concurrencyFromConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, processing %d work items at once\n", err, api.DefaultConcurrency)
+		return
+	}
+	api.SetConcurrency(n)
+}
+
+// concurrencyFromConfig parses the concurrency setting; unset is the default
+func concurrencyFromConfig(cfg *config.Config) (int, error) {
+	if cfg.Concurrency == "" {
+		return api.DefaultConcurrency, nil
+	}
+	n, err := strconv.Atoi(cfg.Concurrency)
+	if err != nil || n < 1 {
+		return api.DefaultConcurrency, fmt.Errorf("invalid concurrency '%s' (expected a number of 1 or more)", cfg.Concurrency)
+	}
+	return n, nil
+}
+
+// bulkProgress reports per-item results of a bulk operation. On a terminal it
+// draws a single progress bar with throughput and ETA instead of one line per
+// item; failures are still printed above the bar. With --verbose, or when
+// output is redirected, it prints every item as before. It is safe for
+// concurrent use.
+type bulkProgress struct {
+	mu         sync.Mutex
+	out        io.Writer
+	bar        bool
+	total      int
+	succeeded  int
+	failed     int
+	start      time.Time
+	lastRender time.Time
+}
+
+// newBulkProgress creates a progress reporter for total items
+func newBulkProgress(total int) *bulkProgress {
+	return &bulkProgress{
+		out:   os.Stderr,
+		bar:   total > 1 && !verboseFlag && term.IsTerminal(int(os.Stderr.Fd())),
+		total: total,
+		start: time.Now(),
+	}
+}
+
+// Success records a successful item
+func (p *bulkProgress) Success(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.succeeded++
+	if !p.bar {
+		fmt.Println(message)
+		return
+	}
+	p.render(false)
+}
+
+// Failure records a failed item; the message is always shown
+func (p *bulkProgress) Failure(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failed++
+	if !p.bar {
+		fmt.Println(message)
+		return
+	}
+	p.clear()
+	fmt.Fprintln(p.out, message)
+	p.render(true)
+}
+
+// Finish draws the final state of the bar and moves to a new line
+func (p *bulkProgress) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.bar {
+		return
+	}
+	p.render(true)
+	fmt.Fprintln(p.out)
+}
+
+// Counts returns the number of items that succeeded and failed
+func (p *bulkProgress) Counts() (succeeded, failed int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.succeeded, p.failed
+}
+
+// render redraws the bar, at most once per progressRenderInterval unless forced
+func (p *bulkProgress) render(force bool) {
+	now := time.Now()
+	done := p.succeeded + p.failed
+	if !force && done < p.total && now.Sub(p.lastRender) < progressRenderInterval {
+		return
+	}
+	p.lastRender = now
+
+	fmt.Fprintf(p.out, "\r%s\033[K", formatProgressLine(done, p.total, p.succeeded, p.failed, now.Sub(p.start)))
+}
+
+// clear erases the current bar line
+func (p *bulkProgress) clear() {
+	fmt.Fprint(p.out, "\r\033[K")
+}
+
+// formatProgressLine renders the bar, counts, throughput and ETA
+func formatProgressLine(done, total, succeeded, failed int, elapsed time.Duration) string {
+	filled := 0
+	if total > 0 {
+		filled = done * progressBarWidth / total
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(done) / elapsed.Seconds()
+	}
+
+	eta := "--"
+	if done >= total {
+		eta = "done"
+	} else if rate > 0 {
+		remaining := time.Duration(float64(total-done) / rate * float64(time.Second))
+		eta = remaining.Round(time.Second).String()
+	}
+
+	return fmt.Sprintf("[%s] %d/%d  ✓ %d  ✗ %d  %.1f/s  ETA %s", bar, done, total, succeeded, failed, rate, eta)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatProgressLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		done      int
+		total     int
+		succeeded int
+		failed    int
+		elapsed   time.Duration
+		want      []string
+	}{
+		{
+			name:    "not started",
+			done:    0,
+			total:   10,
+			elapsed: 0,
+			want:    []string{"0/10", "ETA --", strings.Repeat("░", progressBarWidth)},
+		},
+		{
+			name:      "half way",
+			done:      5,
+			total:     10,
+			succeeded: 4,
+			failed:    1,
+			elapsed:   5 * time.Second,
+			want:      []string{"5/10", "✓ 4", "✗ 1", "1.0/s", "ETA 5s"},
+		},
+		{
+			name:      "complete",
+			done:      10,
+			total:     10,
+			succeeded: 10,
+			elapsed:   2 * time.Second,
+			want:      []string{"10/10", "ETA done", strings.Repeat("█", progressBarWidth)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatProgressLine(tt.done, tt.total, tt.succeeded, tt.failed, tt.elapsed)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("formatProgressLine() = %q, missing %q", got, want)
+				}
+			}
+		})
+	}
+}
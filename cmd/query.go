@@ -4,21 +4,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
-	"github.com/SOMUCHDOG/azb/internal/auth"
-	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
 )
 
 var (
 	queryFormatFlag string
+	queryLinksFlag  bool
 	queryLimitFlag  int
+	queryPageFlag   int
+	queryPageSize   int
 
 	queryCmd = &cobra.Command{
 		Use:   "query",
@@ -34,19 +38,21 @@ var (
 	}
 
 	queryShowCmd = &cobra.Command{
-		Use:   "show <query-name>",
-		Short: "Show query details",
-		Long:  `Show details of a saved query including its WIQL statement.`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runQueryShow,
+		Use:               "show <query-name>",
+		Short:             "Show query details",
+		Long:              `Show details of a saved query including its WIQL statement.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeQueryNames),
+		RunE:              runQueryShow,
 	}
 
 	queryRunCmd = &cobra.Command{
-		Use:   "run <query-name>",
-		Short: "Execute a saved query",
-		Long:  `Execute a saved query and display the results. Supports both personal and shared queries.`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runQueryRun,
+		Use:               "run <query-name>",
+		Short:             "Execute a saved query",
+		Long:              `Execute a saved query and display the results. Supports both personal and shared queries.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeQueryNames),
+		RunE:              runQueryRun,
 	}
 )
 
@@ -57,53 +63,32 @@ func init() {
 	queryCmd.AddCommand(queryRunCmd)
 
 	// Flags for query list
-	queryListCmd.Flags().StringVar(&queryFormatFlag, "format", "table", "Output format (table, json)")
+	queryListCmd.Flags().StringVar(&queryFormatFlag, "format", "table", "Output format (table, json, yaml, csv, tsv, ids)")
 
 	// Flags for query show
-	queryShowCmd.Flags().StringVar(&queryFormatFlag, "format", "text", "Output format (text, json)")
+	queryShowCmd.Flags().StringVar(&queryFormatFlag, "format", "text", "Output format (text, json, yaml, csv, tsv, ids)")
 
 	// Flags for query run
-	queryRunCmd.Flags().StringVar(&queryFormatFlag, "format", "table", "Output format (table, json, csv, ids)")
+	queryRunCmd.Flags().StringVar(&queryFormatFlag, "format", "table", "Output format (table, json, yaml, csv, tsv, ids, markdown, card)")
+	queryRunCmd.Flags().BoolVar(&queryLinksFlag, "links", false, "Include web links to each work item in table, markdown and card output")
 	queryRunCmd.Flags().IntVar(&queryLimitFlag, "limit", 50, "Maximum number of results")
+	addPageFlags(queryRunCmd, &queryPageFlag, &queryPageSize)
+	addJQFlag(queryListCmd)
+	addJQFlag(queryShowCmd)
+	addJQFlag(queryRunCmd)
 }
 
 func runQueryList(cmd *cobra.Command, args []string) error {
-	// Check authentication
-	token, err := auth.GetToken()
-	if err != nil {
+	if err := resolveFormat(cmd, "format", &queryFormatFlag, withOutputFormats("table")...); err != nil {
 		return err
 	}
-
-	// Load config
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// Get organization and project
-	org := viper.GetString("organization")
-	if org == "" {
-		org = cfg.Organization
-	}
-	if org == "" {
-		return fmt.Errorf("organization not configured. Run 'azb config set organization <org>'")
-	}
-
-	project := viper.GetString("project")
-	if project == "" {
-		project = cfg.Project
-	}
-	if project == "" {
-		return fmt.Errorf("project not configured. Run 'azb config set project <project>'")
+	if err := resolveJQFormat(cmd, "format", &queryFormatFlag); err != nil {
+		return err
 	}
 
-	// Build organization URL
-	orgURL := api.NormalizeOrganizationURL(org)
-
-	// Create API client
-	client, err := api.NewClient(orgURL, project, token)
+	client, err := newClient()
 	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+		return err
 	}
 
 	// List queries with depth 2 to get folders and their contents
@@ -118,54 +103,30 @@ func runQueryList(cmd *cobra.Command, args []string) error {
 		return outputQueryJSON(queries)
 	case "table":
 		return outputQueryTable(queries)
+	case "yaml", "csv", "tsv", "ids":
+		return writeResult(queryFormatFlag, queries, queryTable(queries))
 	default:
 		return fmt.Errorf("unsupported format: %s", queryFormatFlag)
 	}
 }
 
 func runQueryShow(cmd *cobra.Command, args []string) error {
-	queryName := args[0]
-
-	// Check authentication
-	token, err := auth.GetToken()
-	if err != nil {
+	if err := resolveFormat(cmd, "format", &queryFormatFlag, withOutputFormats("text")...); err != nil {
 		return err
 	}
-
-	// Load config
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// Get organization and project
-	org := viper.GetString("organization")
-	if org == "" {
-		org = cfg.Organization
-	}
-	if org == "" {
-		return fmt.Errorf("organization not configured. Run 'azb config set organization <org>'")
-	}
-
-	project := viper.GetString("project")
-	if project == "" {
-		project = cfg.Project
-	}
-	if project == "" {
-		return fmt.Errorf("project not configured. Run 'azb config set project <project>'")
+	if err := resolveJQFormat(cmd, "format", &queryFormatFlag); err != nil {
+		return err
 	}
 
-	// Build organization URL
-	orgURL := api.NormalizeOrganizationURL(org)
+	queryName := args[0]
 
-	// Create API client
-	client, err := api.NewClient(orgURL, project, token)
+	client, err := newClient()
 	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+		return err
 	}
 
 	// Find the query
-	query, err := findQueryByName(client, queryName)
+	query, err := api.FindQuery(client, queryName)
 	if err != nil {
 		return err
 	}
@@ -173,11 +134,9 @@ func runQueryShow(cmd *cobra.Command, args []string) error {
 	// Output based on format
 	switch queryFormatFlag {
 	case "json":
-		data, err := json.MarshalIndent(query, "", "  ")
-		if err != nil {
-			return fmt.Errorf("failed to marshal JSON: %w", err)
+		if err := outputJSON(query); err != nil {
+			return err
 		}
-		fmt.Println(string(data))
 	case "text":
 		fmt.Printf("Name: %s\n", *query.Name)
 		if query.Path != nil {
@@ -196,6 +155,15 @@ func runQueryShow(cmd *cobra.Command, args []string) error {
 		if query.Wiql != nil {
 			fmt.Printf("\nWIQL:\n%s\n", *query.Wiql)
 		}
+	case "yaml", "csv", "tsv", "ids":
+		table := queryTable(&[]workitemtracking.QueryHierarchyItem{*query})
+		table.Headers = append(table.Headers, "WIQL")
+		wiql := ""
+		if query.Wiql != nil {
+			wiql = *query.Wiql
+		}
+		table.Rows[0] = append(table.Rows[0], wiql)
+		return writeResult(queryFormatFlag, query, table)
 	default:
 		return fmt.Errorf("unsupported format: %s", queryFormatFlag)
 	}
@@ -204,48 +172,22 @@ func runQueryShow(cmd *cobra.Command, args []string) error {
 }
 
 func runQueryRun(cmd *cobra.Command, args []string) error {
-	queryName := args[0]
-
-	// Check authentication
-	token, err := auth.GetToken()
-	if err != nil {
+	if err := resolveFormat(cmd, "format", &queryFormatFlag, workItemFormats...); err != nil {
 		return err
 	}
-
-	// Load config
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// Get organization and project
-	org := viper.GetString("organization")
-	if org == "" {
-		org = cfg.Organization
-	}
-	if org == "" {
-		return fmt.Errorf("organization not configured. Run 'azb config set organization <org>'")
-	}
-
-	project := viper.GetString("project")
-	if project == "" {
-		project = cfg.Project
-	}
-	if project == "" {
-		return fmt.Errorf("project not configured. Run 'azb config set project <project>'")
+	if err := resolveJQFormat(cmd, "format", &queryFormatFlag); err != nil {
+		return err
 	}
 
-	// Build organization URL
-	orgURL := api.NormalizeOrganizationURL(org)
+	queryName := args[0]
 
-	// Create API client
-	client, err := api.NewClient(orgURL, project, token)
+	client, err := newClient()
 	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+		return err
 	}
 
 	// Find the query
-	query, err := findQueryByName(client, queryName)
+	query, err := api.FindQuery(client, queryName)
 	if err != nil {
 		return err
 	}
@@ -255,91 +197,200 @@ func runQueryRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("query has no ID")
 	}
 
-	workItemsPtr, err := client.ExecuteQuery(query.Id.String(), queryLimitFlag)
+	definition, err := client.GetQueryDefinition(query.Id.String())
 	if err != nil {
-		return fmt.Errorf("failed to execute query: %w", err)
+		return err
 	}
 
-	// Dereference the pointer for output functions
 	var workItems []workitemtracking.WorkItem
-	if workItemsPtr != nil {
-		workItems = *workItemsPtr
+	hasMore, truncated := false, false
+	paged := cmd.Flags().Changed("page")
+	if paged {
+		workItems, hasMore, err = fetchWorkItemPage(client, definition.WIQL, queryPageFlag, queryPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+	} else {
+		workItems, truncated, err = fetchWorkItemsUpTo(client, definition.WIQL, queryLimitFlag)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+	}
+	if paged {
+		defer printPageHint(queryPageFlag, queryPageSize, len(workItems), hasMore)
+	} else if truncated {
+		defer fmt.Fprintf(os.Stderr, "Showing the first %d results; use --limit or --page to see more\n", queryLimitFlag)
 	}
 
 	// Output based on format (reuse output functions from list.go)
+	links := newWorkItemLinks(client, queryLinksFlag)
 	switch queryFormatFlag {
 	case "table":
-		return outputTable(workItems)
-	case "json":
-		return outputJSON(workItems)
-	case "csv":
-		return outputCSV(workItems)
-	case "ids":
-		return outputIDs(workItems)
+		if len(definition.Columns) > 0 {
+			return outputColumnTable(workItems, definition.Columns, links)
+		}
+		return outputTable(workItems, links)
+	case "csv", "tsv":
+		if len(definition.Columns) > 0 {
+			return output.Write(os.Stdout, queryFormatFlag, workItems, columnTable(workItems, definition.Columns))
+		}
+		return outputWorkItems(workItems, queryFormatFlag, links)
+	case "json", "yaml", "ids", "markdown", "card":
+		return outputWorkItems(workItems, queryFormatFlag, links)
 	default:
 		return fmt.Errorf("unsupported format: %s", queryFormatFlag)
 	}
 }
 
-// findQueryByName searches for a query by name in all folders
-func findQueryByName(client *api.Client, name string) (*workitemtracking.QueryHierarchyItem, error) {
-	// List all queries with depth 2 (max allowed by API)
-	queries, err := client.ListQueries("", 2)
+// fetchWorkItemsUpTo runs a WIQL query and returns at most limit work items in
+// query order; truncated reports whether the query matched more
+func fetchWorkItemsUpTo(client api.APIClient, wiql string, limit int) ([]workitemtracking.WorkItem, bool, error) {
+	top := 0
+	if limit > 0 {
+		// One extra ID tells whether results were cut off
+		top = limit + 1
+	}
+	ids, err := client.QueryWorkItemIDs(wiql, top)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list queries: %w", err)
+		return nil, false, err
 	}
 
-	// Search for the query by name
-	var found *workitemtracking.QueryHierarchyItem
-	var searchQueries func(items *[]workitemtracking.QueryHierarchyItem)
+	truncated := limit > 0 && len(ids) > limit
+	if truncated {
+		ids = ids[:limit]
+	}
+	if len(ids) == 0 {
+		return nil, false, nil
+	}
 
-	searchQueries = func(items *[]workitemtracking.QueryHierarchyItem) {
-		if items == nil {
-			return
+	workItems, err := client.GetWorkItems(ids)
+	if err != nil {
+		return nil, false, err
+	}
+	return workItems, truncated, nil
+}
+
+// outputColumnTable prints work items with the columns of a saved query, as in
+// the web UI. Sorted columns are marked with ↑ or ↓ in the header.
+func outputColumnTable(items []workitemtracking.WorkItem, columns []api.QueryColumn, links *workItemLinks) error {
+	if len(items) == 0 {
+		fmt.Println("No work items found")
+		return nil
+	}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Name
+		switch column.Sort {
+		case "asc":
+			headers[i] += " ↑"
+		case "desc":
+			headers[i] += " ↓"
 		}
+	}
 
-		for i := range *items {
-			item := &(*items)[i]
-
-			// Check if this is the query we're looking for
-			if item.Name != nil && strings.EqualFold(*item.Name, name) {
-				// Only match actual queries, not folders
-				// If IsFolder is nil or false, it's a query
-				isFolder := item.IsFolder != nil && *item.IsFolder
-				if !isFolder {
-					found = item
-					return
-				}
-			}
+	rows := make([][]string, len(items))
+	for r, item := range items {
+		rows[r] = make([]string, len(columns))
+		for i, column := range columns {
+			rows[r][i] = queryCellValue(&item, column.ReferenceName)
+		}
+	}
 
-			// Recursively search children
-			if item.Children != nil {
-				searchQueries(item.Children)
+	widths := queryColumnWidths(columns, headers, rows)
+
+	// Without hyperlink support the URL gets its own column
+	urlColumn := links != nil && !links.hyperlinks
+
+	// With hyperlink support the ID cell links to the work item, or the first
+	// cell when the query doesn't show IDs
+	linked := -1
+	if links != nil && links.hyperlinks {
+		linked = 0
+		for i, column := range columns {
+			if column.ReferenceName == "System.Id" {
+				linked = i
+				break
 			}
+		}
+	}
 
-			if found != nil {
-				return
+	headerCells := make([]string, len(headers))
+	for i, header := range headers {
+		headerCells[i] = output.FitCell(header, widths[i])
+	}
+	header := strings.TrimRight(strings.Join(headerCells, "  "), " ")
+	if urlColumn {
+		header += " URL"
+	}
+	fmt.Println(header)
+	fmt.Println(strings.Repeat("-", len([]rune(header))))
+
+	for r, item := range items {
+		cells := make([]string, len(columns))
+		for i, cell := range rows[r] {
+			cells[i] = output.FitCell(cell, widths[i])
+			if i == linked && item.Id != nil {
+				text := strings.TrimRight(cells[i], " ")
+				cells[i] = hyperlink(links.URL(*item.Id), text) + cells[i][len(text):]
 			}
 		}
+
+		row := strings.TrimRight(strings.Join(cells, "  "), " ")
+		if urlColumn && item.Id != nil {
+			row += " " + links.URL(*item.Id)
+		}
+		fmt.Println(row)
 	}
 
-	searchQueries(queries)
+	fmt.Printf("\nTotal: %d work items\n", len(items))
+
+	return nil
+}
 
-	if found == nil {
-		return nil, fmt.Errorf("query '%s' not found", name)
+// queryCellValue formats a field for the column table. Dates are shown in local
+// time and multi-line values are collapsed onto one line.
+func queryCellValue(item *workitemtracking.WorkItem, field string) string {
+	if field == "System.Id" && item.Id != nil {
+		return strconv.Itoa(*item.Id)
 	}
 
-	// Get the full query details including WIQL
-	if found.Id == nil {
-		return nil, fmt.Errorf("query has no ID")
+	value := model.FieldString(item.Fields, field)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Local().Format("2006-01-02 15:04")
+	}
+	if strings.ContainsAny(value, "<\n") {
+		value = strings.Join(strings.Fields(htmlTagPattern.ReplaceAllString(value, " ")), " ")
 	}
+	return value
+}
 
-	fullQuery, err := client.GetQuery(found.Id.String())
-	if err != nil {
-		return nil, fmt.Errorf("failed to get query details: %w", err)
+// queryColumnWidths sizes each column to its widest cell, up to a cap per column
+func queryColumnWidths(columns []api.QueryColumn, headers []string, rows [][]string) []int {
+	const maxWidth, maxTitleWidth = 30, 50
+
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = len([]rune(header))
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if n := len([]rune(cell)); n > widths[i] {
+				widths[i] = n
+			}
+		}
 	}
 
-	return fullQuery, nil
+	for i, column := range columns {
+		limit := maxWidth
+		if column.ReferenceName == "System.Title" {
+			limit = maxTitleWidth
+		}
+		if widths[i] > limit {
+			widths[i] = limit
+		}
+	}
+	return widths
 }
 
 // outputQueryTable outputs queries in table format
@@ -385,8 +436,46 @@ func outputQueryTable(queries *[]workitemtracking.QueryHierarchyItem) error {
 	return nil
 }
 
+// queryTable lists saved queries and folders, including nested ones, for csv, tsv and ids output
+func queryTable(queries *[]workitemtracking.QueryHierarchyItem) output.Table {
+	table := output.Table{Headers: []string{"ID", "Name", "Type", "Path"}}
+
+	var add func(items *[]workitemtracking.QueryHierarchyItem)
+	add = func(items *[]workitemtracking.QueryHierarchyItem) {
+		if items == nil {
+			return
+		}
+		for _, item := range *items {
+			row := []string{"", "", "Query", ""}
+			if item.Id != nil {
+				row[0] = item.Id.String()
+			}
+			if item.Name != nil {
+				row[1] = *item.Name
+			}
+			if item.IsFolder != nil && *item.IsFolder {
+				row[2] = "Folder"
+			}
+			if item.Path != nil {
+				row[3] = *item.Path
+			}
+			table.Rows = append(table.Rows, row)
+			add(item.Children)
+		}
+	}
+	add(queries)
+	return table
+}
+
 // outputQueryJSON outputs queries in JSON format
 func outputQueryJSON(queries *[]workitemtracking.QueryHierarchyItem) error {
+	if jqFlag != "" {
+		return writeJQ(os.Stdout, queries, jqFlag)
+	}
+	if outputTemplateFlag != "" {
+		return writeTemplate(os.Stdout, queries, outputTemplateFlag)
+	}
+
 	data, err := json.MarshalIndent(queries, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+func TestQueryColumnWidths(t *testing.T) {
+	columns := []api.QueryColumn{
+		{ReferenceName: "System.Id"},
+		{ReferenceName: "System.Title"},
+		{ReferenceName: "System.AreaPath"},
+	}
+	headers := []string{"ID", "Title", "Area Path"}
+	long := "This title is much longer than fifty characters and gets cut off"
+	rows := [][]string{
+		{"12345", long, "Project\\Team A\\A very long area path name"},
+		{"7", "Short", "Project"},
+	}
+
+	got := queryColumnWidths(columns, headers, rows)
+	want := []int{5, 50, 30}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("width of %s = %d, want %d", headers[i], got[i], want[i])
+		}
+	}
+}
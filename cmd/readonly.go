@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// mutatingAnnotation marks commands that create, change or delete work items
+const mutatingAnnotation = "azb:mutating"
+
+// mutatingAnnotations is set as the Annotations of mutating commands
+var mutatingAnnotations = map[string]string{mutatingAnnotation: "true"}
+
+// readOnlyEnabled reports whether --read-only or the read_only config key is set.
+// The flag is not bound to viper so 'azb config set' never saves it.
+func readOnlyEnabled() bool {
+	return readOnlyFlag || viper.GetBool("read_only")
+}
+
+// checkReadOnly refuses mutating commands in read-only mode before they prompt
+// or read anything. The API client refuses changes as well, so commands that
+// are not annotated still cannot write.
+func checkReadOnly(cmd *cobra.Command) error {
+	if readOnlyEnabled() && cmd.Annotations[mutatingAnnotation] == "true" {
+		return fmt.Errorf("'%s' changes work items and is disabled in read-only mode", cmd.CommandPath())
+	}
+	return nil
+}
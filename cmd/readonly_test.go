@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestCheckReadOnly(t *testing.T) {
+	mutating := &cobra.Command{Use: "update", Annotations: mutatingAnnotations}
+	reading := &cobra.Command{Use: "show"}
+
+	tests := []struct {
+		name     string
+		flag     bool
+		config   string
+		cmd      *cobra.Command
+		wantFail bool
+	}{
+		{"off", false, "", mutating, false},
+		{"flag blocks mutating command", true, "", mutating, true},
+		{"config blocks mutating command", false, "true", mutating, true},
+		{"config false allows", false, "false", mutating, false},
+		{"read command allowed", true, "true", reading, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readOnlyFlag = tt.flag
+			viper.Set("read_only", tt.config)
+			defer func() {
+				readOnlyFlag = false
+				viper.Set("read_only", "")
+			}()
+
+			err := checkReadOnly(tt.cmd)
+			if (err != nil) != tt.wantFail {
+				t.Errorf("checkReadOnly() error = %v, wantFail %v", err, tt.wantFail)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/recent"
+)
+
+var (
+	recentLimitFlag  int
+	recentFormatFlag string
+
+	recentCmd = &cobra.Command{
+		Use:   "recent",
+		Short: "List work items you recently viewed or edited",
+		Long: `List the work items you last viewed, edited or created with azb, most recent
+first. The list is kept locally in ~/.azure-boards-cli/recent.json, separately
+for each organization, and also shows in the dashboard (press R on the Work
+Items tab).
+
+Examples:
+  azb recent
+  azb recent -n 5
+  azb recent --format ids | xargs azb show`,
+		Args: cobra.NoArgs,
+		RunE: runRecent,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(recentCmd)
+
+	recentCmd.Flags().IntVarP(&recentLimitFlag, "limit", "n", 20, "Maximum number of work items to list")
+	recentCmd.Flags().StringVarP(&recentFormatFlag, "format", "f", "table", "Output format (table, json, yaml, csv, tsv, ids)")
+}
+
+func runRecent(cmd *cobra.Command, args []string) error {
+	formats := withOutputFormats("table")
+	if err := resolveFormat(cmd, "format", &recentFormatFlag, formats...); err != nil {
+		return err
+	}
+	if !slices.Contains(formats, recentFormatFlag) {
+		return fmt.Errorf("unsupported format: %s", recentFormatFlag)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	store, err := recent.Load()
+	if err != nil {
+		return err
+	}
+	items := store.ForOrg(client.GetOrganizationURL(), recentLimitFlag)
+
+	table := output.Table{Headers: []string{"ID", "Type", "Title", "Action", "When"}}
+	for _, item := range items {
+		table.Rows = append(table.Rows, []string{
+			strconv.Itoa(item.ID), item.Type, item.Title, item.Action, item.Time.Local().Format(snoozeTimeFormat),
+		})
+	}
+	if recentFormatFlag != "table" {
+		if items == nil {
+			items = []recent.Item{}
+		}
+		return writeResult(recentFormatFlag, items, table)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No recent work items")
+		return nil
+	}
+	return output.WriteTable(os.Stdout, table)
+}
@@ -0,0 +1,454 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/work"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/wiql"
+)
+
+// storyPointFields hold the size of backlog items in the Agile, Scrum and CMMI processes
+var storyPointFields = []string{
+	"Microsoft.VSTS.Scheduling.StoryPoints",
+	"Microsoft.VSTS.Scheduling.Effort",
+	"Microsoft.VSTS.Scheduling.Size",
+}
+
+// reportBarWidth is the width of the longest bar in report charts
+const reportBarWidth = 40
+
+var (
+	reportSprintFlag string
+	reportTeamFlag   string
+	reportFormatFlag string
+	reportMetricFlag string
+	reportLastFlag   int
+
+	reportCmd = &cobra.Command{
+		Use:   "report",
+		Short: "Sprint burndown and velocity reports",
+		Long:  `Chart sprint progress in the terminal, or export the numbers as JSON or CSV.`,
+	}
+
+	reportBurndownCmd = &cobra.Command{
+		Use:   "burndown",
+		Short: "Show remaining work per day of a sprint",
+		Long: `Show the remaining work (the sum of Remaining Work) and the open story points
+of a sprint for each day so far, next to an ideal line that burns down to zero on
+the last day. Each day is read as the sprint was at the end of that day, so
+work added or removed during the sprint is reflected.
+
+Examples:
+  azb report burndown
+  azb report burndown --sprint "MyProject\Sprint 42" --metric points
+  azb report burndown --format csv > burndown.csv`,
+		Args: cobra.NoArgs,
+		RunE: runReportBurndown,
+	}
+
+	reportVelocityCmd = &cobra.Command{
+		Use:   "velocity",
+		Short: "Show planned and completed story points of past sprints",
+		Long: `Show the story points planned and completed in each of the team's last
+sprints, as they stood when each sprint ended, and the average velocity.
+
+Examples:
+  azb report velocity
+  azb report velocity --last 10 --team "Platform Team"
+  azb report velocity --format json`,
+		Args: cobra.NoArgs,
+		RunE: runReportVelocity,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportBurndownCmd)
+	reportCmd.AddCommand(reportVelocityCmd)
+
+	reportCmd.PersistentFlags().StringVar(&reportTeamFlag, "team", "", "Team whose sprints are used (default: the project's default team)")
+	reportCmd.PersistentFlags().StringVarP(&reportFormatFlag, "format", "f", "table", "Output format (table, json, yaml, csv, tsv, ids)")
+
+	reportBurndownCmd.Flags().StringVar(&reportSprintFlag, "sprint", "current", "Sprint: current, or a sprint name or iteration path")
+	reportBurndownCmd.Flags().StringVar(&reportMetricFlag, "metric", "work", "Metric to chart: work (remaining hours) or points")
+
+	reportVelocityCmd.Flags().IntVar(&reportLastFlag, "last", 6, "Number of past sprints")
+}
+
+// burndownDay is one day of 'azb report burndown'
+type burndownDay struct {
+	Date          string  `json:"date"`
+	RemainingWork float64 `json:"remainingWork"`
+	OpenPoints    float64 `json:"openPoints"`
+	Ideal         float64 `json:"ideal"`
+}
+
+// burndownReport is the output of 'azb report burndown'
+type burndownReport struct {
+	Sprint string        `json:"sprint"`
+	Start  string        `json:"start"`
+	Finish string        `json:"finish"`
+	Metric string        `json:"metric"`
+	Days   []burndownDay `json:"days"`
+}
+
+// velocitySprint is one sprint of 'azb report velocity'
+type velocitySprint struct {
+	Name      string  `json:"name"`
+	Path      string  `json:"path"`
+	Finish    string  `json:"finish"`
+	Planned   float64 `json:"planned"`
+	Completed float64 `json:"completed"`
+}
+
+// velocityReport is the output of 'azb report velocity'
+type velocityReport struct {
+	Sprints []velocitySprint `json:"sprints"`
+	Average float64          `json:"average"`
+}
+
+// reportFormat checks --format, applying the preferred output format
+func reportFormat(cmd *cobra.Command) error {
+	formats := withOutputFormats("table")
+	if err := resolveFormat(cmd, "format", &reportFormatFlag, formats...); err != nil {
+		return err
+	}
+	if !slices.Contains(formats, reportFormatFlag) {
+		return fmt.Errorf("unsupported format: %s", reportFormatFlag)
+	}
+	return nil
+}
+
+func runReportBurndown(cmd *cobra.Command, args []string) error {
+	if err := reportFormat(cmd); err != nil {
+		return err
+	}
+	if reportMetricFlag != "work" && reportMetricFlag != "points" {
+		return fmt.Errorf("unsupported metric: %s (use work or points)", reportMetricFlag)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	sprint, err := findSprint(client, reportTeamFlag, reportSprintFlag)
+	if err != nil {
+		return err
+	}
+	start, finish, err := sprintDates(sprint)
+	if err != nil {
+		return err
+	}
+
+	categories := newStateCategories(client)
+	var days []burndownDay
+	now := time.Now()
+	for day := start; !day.After(finish) && day.Before(now); day = day.AddDate(0, 0, 1) {
+		asOf := day.Add(24*time.Hour - time.Second)
+		if asOf.After(now) {
+			asOf = now
+		}
+		items, err := sprintItemsAsOf(client, *sprint.Path, asOf)
+		if err != nil {
+			return err
+		}
+
+		d := burndownDay{Date: day.Format("2006-01-02")}
+		for i := range items {
+			category := categories.of(&items[i])
+			if category == "Completed" || category == "Removed" {
+				continue
+			}
+			remaining, _ := numberField(*items[i].Fields, remainingWorkField)
+			d.RemainingWork += remaining
+			d.OpenPoints += storyPoints(items[i].Fields)
+		}
+		days = append(days, d)
+	}
+	applyIdealLine(days, start, finish, reportMetricFlag)
+
+	report := &burndownReport{
+		Sprint: *sprint.Path,
+		Start:  start.Format("2006-01-02"),
+		Finish: finish.Format("2006-01-02"),
+		Metric: reportMetricFlag,
+		Days:   days,
+	}
+
+	if reportFormatFlag != "table" {
+		table := output.Table{Headers: []string{"Date", "Remaining Work", "Open Points", "Ideal"}}
+		for _, d := range days {
+			table.Rows = append(table.Rows, []string{d.Date, formatHours(d.RemainingWork), formatHours(d.OpenPoints), formatHours(d.Ideal)})
+		}
+		return writeResult(reportFormatFlag, report, table)
+	}
+
+	unit := "h"
+	if reportMetricFlag == "points" {
+		unit = " pts"
+	}
+	fmt.Printf("Burndown: %s (%s to %s)\n\n", report.Sprint, report.Start, report.Finish)
+	if len(days) == 0 {
+		fmt.Println("The sprint has not started yet")
+		return nil
+	}
+	bars := make([]reportBar, len(days))
+	for i, d := range days {
+		value := d.RemainingWork
+		if reportMetricFlag == "points" {
+			value = d.OpenPoints
+		}
+		bars[i] = reportBar{
+			Label: d.Date[5:],
+			Value: value,
+			Note:  fmt.Sprintf("%s%s (ideal %s)", formatHours(value), unit, formatHours(d.Ideal)),
+		}
+	}
+	fmt.Print(renderBarChart(bars, reportBarWidth))
+	return nil
+}
+
+func runReportVelocity(cmd *cobra.Command, args []string) error {
+	if err := reportFormat(cmd); err != nil {
+		return err
+	}
+	if reportLastFlag < 1 {
+		return fmt.Errorf("--last must be at least 1")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	sprints, err := client.GetTeamIterations(reportTeamFlag, "past")
+	if err != nil {
+		return err
+	}
+	if len(sprints) > reportLastFlag {
+		sprints = sprints[len(sprints)-reportLastFlag:]
+	}
+
+	categories := newStateCategories(client)
+	report := &velocityReport{Sprints: []velocitySprint{}}
+	for _, sprint := range sprints {
+		if sprint.Path == nil {
+			continue
+		}
+		_, finish, err := sprintDates(&sprint)
+		if err != nil {
+			continue
+		}
+		// Read the sprint as it was when it ended, so later moves do not count
+		items, err := sprintItemsAsOf(client, *sprint.Path, finish.Add(24*time.Hour-time.Second))
+		if err != nil {
+			return err
+		}
+
+		v := velocitySprint{Path: *sprint.Path, Finish: finish.Format("2006-01-02")}
+		if sprint.Name != nil {
+			v.Name = *sprint.Name
+		}
+		for i := range items {
+			category := categories.of(&items[i])
+			if category == "Removed" {
+				continue
+			}
+			points := storyPoints(items[i].Fields)
+			v.Planned += points
+			if category == "Completed" {
+				v.Completed += points
+			}
+		}
+		report.Sprints = append(report.Sprints, v)
+		report.Average += v.Completed
+	}
+	if len(report.Sprints) > 0 {
+		report.Average = math.Round(report.Average/float64(len(report.Sprints))*100) / 100
+	}
+
+	if reportFormatFlag != "table" {
+		table := output.Table{Headers: []string{"Sprint", "Path", "Finish", "Planned", "Completed"}}
+		for _, v := range report.Sprints {
+			table.Rows = append(table.Rows, []string{v.Name, v.Path, v.Finish, formatHours(v.Planned), formatHours(v.Completed)})
+		}
+		return writeResult(reportFormatFlag, report, table)
+	}
+
+	if len(report.Sprints) == 0 {
+		fmt.Println("No past sprints found")
+		return nil
+	}
+	fmt.Printf("Velocity: last %d sprint(s)\n\n", len(report.Sprints))
+	bars := make([]reportBar, len(report.Sprints))
+	for i, v := range report.Sprints {
+		bars[i] = reportBar{
+			Label: v.Name,
+			Value: v.Completed,
+			Note:  fmt.Sprintf("%s of %s pts", formatHours(v.Completed), formatHours(v.Planned)),
+		}
+	}
+	fmt.Print(renderBarChart(bars, reportBarWidth))
+	fmt.Printf("\nAverage velocity: %s pts\n", formatHours(report.Average))
+	return nil
+}
+
+// findSprint returns the team sprint matching "current", a sprint name or an iteration path
+func findSprint(client api.APIClient, team, sprint string) (*work.TeamSettingsIteration, error) {
+	if sprint == "" || strings.EqualFold(sprint, "current") || sprint == "@current" {
+		current, err := client.GetCurrentIteration(team)
+		if err != nil {
+			return nil, err
+		}
+		if current.Path == nil {
+			return nil, fmt.Errorf("current iteration has no path")
+		}
+		return current, nil
+	}
+
+	sprints, err := client.GetTeamIterations(team, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range sprints {
+		s := &sprints[i]
+		if (s.Path != nil && strings.EqualFold(*s.Path, sprint)) || (s.Name != nil && strings.EqualFold(*s.Name, sprint)) {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("sprint %q is not one of the team's iterations", sprint)
+}
+
+// sprintDates returns the first and last day of a sprint
+func sprintDates(sprint *work.TeamSettingsIteration) (time.Time, time.Time, error) {
+	if sprint.Attributes == nil || sprint.Attributes.StartDate == nil || sprint.Attributes.FinishDate == nil {
+		name := ""
+		if sprint.Path != nil {
+			name = *sprint.Path
+		}
+		return time.Time{}, time.Time{}, fmt.Errorf("sprint %s has no start or finish date", name)
+	}
+	start := sprint.Attributes.StartDate.Time.UTC().Truncate(24 * time.Hour)
+	finish := sprint.Attributes.FinishDate.Time.UTC().Truncate(24 * time.Hour)
+	return start, finish, nil
+}
+
+// sprintItemsAsOf returns the work items of an iteration as they were at asOf
+func sprintItemsAsOf(client api.APIClient, path string, asOf time.Time) ([]workitemtracking.WorkItem, error) {
+	query := wiql.Select().Where(
+		wiql.Field("System.TeamProject").Eq(wiql.Project),
+		wiql.Field("System.IterationPath").Eq(wiql.String(path)),
+	).AsOf(asOf)
+	ids, err := client.QueryWorkItemIDs(query.String(), 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s as of %s: %w", path, asOf.Format("2006-01-02"), err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return client.GetWorkItemsAsOf(ids, asOf)
+}
+
+// applyIdealLine sets the ideal value of each day: a straight line from the
+// first day's value down to zero on the last day of the sprint
+func applyIdealLine(days []burndownDay, start, finish time.Time, metric string) {
+	if len(days) == 0 {
+		return
+	}
+	total := days[0].RemainingWork
+	if metric == "points" {
+		total = days[0].OpenPoints
+	}
+	length := finish.Sub(start).Hours() / 24
+	for i := range days {
+		if length <= 0 {
+			days[i].Ideal = 0
+			continue
+		}
+		ideal := total * (1 - float64(i)/length)
+		days[i].Ideal = math.Max(math.Round(ideal*100)/100, 0)
+	}
+}
+
+// storyPoints returns the size of a backlog item, or 0 if it has none
+func storyPoints(fields *map[string]interface{}) float64 {
+	if fields == nil {
+		return 0
+	}
+	for _, name := range storyPointFields {
+		if points, ok := numberField(*fields, name); ok {
+			return points
+		}
+	}
+	return 0
+}
+
+// stateCategories looks up the category (Proposed, InProgress, Completed,
+// Removed, ...) of work item states, loading each work item type once
+type stateCategories struct {
+	client api.APIClient
+	byType map[string]map[string]string
+}
+
+func newStateCategories(client api.APIClient) *stateCategories {
+	return &stateCategories{client: client, byType: make(map[string]map[string]string)}
+}
+
+// of returns the category of a work item's state, or an empty string if unknown
+func (s *stateCategories) of(wi *workitemtracking.WorkItem) string {
+	workItemType := model.FieldString(wi.Fields, "System.WorkItemType")
+	states, ok := s.byType[workItemType]
+	if !ok {
+		states = make(map[string]string)
+		if t, err := s.client.GetWorkItemType(workItemType); err == nil && t.States != nil {
+			for _, state := range *t.States {
+				if state.Name != nil && state.Category != nil {
+					states[*state.Name] = *state.Category
+				}
+			}
+		}
+		s.byType[workItemType] = states
+	}
+	return states[model.FieldString(wi.Fields, "System.State")]
+}
+
+// reportBar is one bar of a report chart
+type reportBar struct {
+	Label string
+	Value float64
+	Note  string
+}
+
+// renderBarChart draws horizontal bars scaled so the largest value fills width
+func renderBarChart(bars []reportBar, width int) string {
+	labelWidth := 0
+	maxValue := 0.0
+	for _, bar := range bars {
+		if len(bar.Label) > labelWidth {
+			labelWidth = len(bar.Label)
+		}
+		maxValue = math.Max(maxValue, bar.Value)
+	}
+
+	var b strings.Builder
+	for _, bar := range bars {
+		length := 0
+		if maxValue > 0 {
+			length = int(math.Round(bar.Value / maxValue * float64(width)))
+		}
+		fmt.Fprintf(&b, "%-*s  %s%s  %s\n", labelWidth, bar.Label,
+			strings.Repeat("█", length), strings.Repeat(" ", width-length), bar.Note)
+	}
+	return b.String()
+}
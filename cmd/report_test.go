@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyIdealLine(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	finish := start.AddDate(0, 0, 4)
+	days := []burndownDay{
+		{RemainingWork: 40, OpenPoints: 8},
+		{RemainingWork: 38, OpenPoints: 8},
+		{RemainingWork: 25, OpenPoints: 5},
+	}
+
+	applyIdealLine(days, start, finish, "work")
+	for i, want := range []float64{40, 30, 20} {
+		if days[i].Ideal != want {
+			t.Errorf("day %d ideal work = %v, want %v", i, days[i].Ideal, want)
+		}
+	}
+
+	applyIdealLine(days, start, finish, "points")
+	for i, want := range []float64{8, 6, 4} {
+		if days[i].Ideal != want {
+			t.Errorf("day %d ideal points = %v, want %v", i, days[i].Ideal, want)
+		}
+	}
+}
+
+func TestStoryPoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+		want   float64
+	}{
+		{"agile", map[string]interface{}{"Microsoft.VSTS.Scheduling.StoryPoints": 5.0}, 5},
+		{"scrum", map[string]interface{}{"Microsoft.VSTS.Scheduling.Effort": 3.0}, 3},
+		{"cmmi", map[string]interface{}{"Microsoft.VSTS.Scheduling.Size": 8.0}, 8},
+		{"none", map[string]interface{}{"System.Title": "Task"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := storyPoints(&tt.fields); got != tt.want {
+				t.Errorf("storyPoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderBarChart(t *testing.T) {
+	got := renderBarChart([]reportBar{
+		{Label: "05-01", Value: 10, Note: "10h"},
+		{Label: "05-02", Value: 5, Note: "5h"},
+		{Label: "05-03", Value: 0, Note: "0h"},
+	}, 4)
+	want := "05-01  ████  10h\n" +
+		"05-02  ██    5h\n" +
+		"05-03        0h\n"
+	if got != want {
+		t.Errorf("renderBarChart() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/config"
+)
+
+// configureRetries applies max_retries and retry_base_delay from the config to the API client
+func configureRetries() {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+
+	policy, err := retryPolicyFromConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using the default retry policy\n", err)
+		return
+	}
+	api.SetRetryPolicy(policy)
+}
+
+// retryPolicyFromConfig builds the retry policy; unset values keep their defaults
+func retryPolicyFromConfig(cfg *config.Config) (api.RetryPolicy, error) {
+	policy := api.DefaultRetryPolicy
+
+	if cfg.MaxRetries != "" {
+		retries, err := strconv.Atoi(cfg.MaxRetries)
+		if err != nil || retries < 0 {
+			return policy, fmt.Errorf("invalid max_retries '%s' (expected a number of 0 or more)", cfg.MaxRetries)
+		}
+		policy.MaxRetries = retries
+	}
+
+	if cfg.RetryBaseDelay != "" {
+		delay, err := time.ParseDuration(cfg.RetryBaseDelay)
+		if err != nil || delay <= 0 {
+			return policy, fmt.Errorf("invalid retry_base_delay '%s' (expected a duration such as 500ms or 2s)", cfg.RetryBaseDelay)
+		}
+		policy.BaseDelay = delay
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/config"
+)
+
+func TestRetryPolicyFromConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.Config
+		wantRetry int
+		wantDelay time.Duration
+		wantErr   bool
+	}{
+		{"defaults", config.Config{}, api.DefaultRetryPolicy.MaxRetries, api.DefaultRetryPolicy.BaseDelay, false},
+		{"configured", config.Config{MaxRetries: "5", RetryBaseDelay: "250ms"}, 5, 250 * time.Millisecond, false},
+		{"retries disabled", config.Config{MaxRetries: "0"}, 0, api.DefaultRetryPolicy.BaseDelay, false},
+		{"negative retries", config.Config{MaxRetries: "-1"}, 0, 0, true},
+		{"invalid delay", config.Config{RetryBaseDelay: "soon"}, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := retryPolicyFromConfig(&tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("retryPolicyFromConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if policy.MaxRetries != tt.wantRetry || policy.BaseDelay != tt.wantDelay {
+				t.Errorf("retryPolicyFromConfig() = %d retries, %s; want %d, %s", policy.MaxRetries, policy.BaseDelay, tt.wantRetry, tt.wantDelay)
+			}
+		})
+	}
+}
@@ -3,40 +3,74 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/log"
 )
 
 var (
-	cfgFile     string
-	showVersion bool
-	rootCmd     = &cobra.Command{
+	cfgFile      string
+	showVersion  bool
+	verboseFlag  bool
+	logFileFlag  string
+	logFormat    string
+	readOnlyFlag bool
+	traceFlag    string
+	rootCmd      = &cobra.Command{
 		Use:   "azb",
 		Short: "Azure Boards CLI - Manage work items from your terminal",
 		Long: `Azure Boards CLI is a cross-platform command-line interface for managing
 Azure Boards work items. It provides both a Terminal UI dashboard for
 interactive work and traditional CLI commands for automation and scripting.`,
-		Run: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkReadOnly(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
 			// If version flag is set, show version
 			if showVersion {
 				versionCmd.Run(cmd, args)
-				return
+				return nil
 			}
-			// If no subcommand is provided, launch the dashboard
-			// For now, we'll just show help
-			//nolint:errcheck // Help() error is not critical
-			cmd.Help()
+			return runDefaultView(cmd)
 		},
 	}
 )
 
+// defaultViews are the values of default_view, which selects what a bare azb opens
+var defaultViews = []string{"dashboard", "help"}
+
+// runDefaultView launches the dashboard when azb is set up and runs in a
+// terminal, unless default_view is "help", and shows help otherwise
+func runDefaultView(cmd *cobra.Command) error {
+	configured := dashboardConfigured()
+	if configured && viper.GetString("default_view") != "help" && term.IsTerminal(int(os.Stdout.Fd())) {
+		return runDashboard(cmd, nil)
+	}
+
+	//nolint:errcheck // Help() error is not critical
+	cmd.Help()
+	if !configured {
+		fmt.Fprintln(os.Stderr, "\nRun 'azb init' to set up azb; after that, 'azb' opens the dashboard.")
+	}
+	return nil
+}
+
 // Execute runs the root command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		log.Close()
+		closeTracing()
 		os.Exit(1)
 	}
+	log.Close()
+	closeTracing()
 }
 
 func init() {
@@ -46,6 +80,12 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.azure-boards-cli/config.yaml)")
 	rootCmd.PersistentFlags().String("org", "", "Azure DevOps organization")
 	rootCmd.PersistentFlags().String("project", "", "Azure DevOps project")
+	rootCmd.PersistentFlags().BoolVar(&verboseFlag, "verbose", false, "Show detailed output (e.g., per-item results in bulk operations) and debug logs")
+	rootCmd.PersistentFlags().StringVar(&logFileFlag, "log-file", "", "Write logs to this file instead of stderr")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Log format (text, json)")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "Output format of commands that show data (table, json, yaml, csv, tsv, ids)")
+	rootCmd.PersistentFlags().StringVar(&traceFlag, "trace", "", "Write HTTP requests and responses, without credentials, to this file for bug reports")
+	rootCmd.PersistentFlags().BoolVar(&readOnlyFlag, "read-only", false, "Disable commands and dashboard actions that change work items")
 	rootCmd.Flags().BoolVarP(&showVersion, "version", "v", false, "Print version information")
 
 	// Bind flags to viper
@@ -69,14 +109,40 @@ func initConfig() {
 
 		// Search config in home directory with name ".azure-boards-cli"
 		configPath := home + "/.azure-boards-cli"
-		viper.AddConfigPath(configPath)
-		viper.SetConfigType("yaml")
-		viper.SetConfigName("config")
+		if profile := strings.TrimSpace(os.Getenv(profileEnvVar)); profile != "" {
+			profileFile := filepath.Join(configPath, "profiles", profile+".yaml")
+			if _, err := os.Stat(profileFile); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: profile '%s' not found at %s\n", profile, profileFile)
+			}
+			viper.SetConfigFile(profileFile)
+		} else {
+			viper.AddConfigPath(configPath)
+			viper.SetConfigType("yaml")
+			viper.SetConfigName("config")
+		}
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	// AZB_* environment variables override the config files
+	bindEnvironment()
 
 	// If a config file is found, read it in (ignore error - config file is optional)
 	//nolint:errcheck // Config file is optional
 	viper.ReadInConfig()
+
+	// A .azb.yaml in the current git repository overrides the home config
+	if dir, err := os.Getwd(); err == nil {
+		if _, err := config.LoadProjectConfig(dir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	if err := log.Setup(log.Options{Verbose: verboseFlag, Format: logFormat, File: logFileFlag}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	configureRetries()
+	configureConcurrency()
+	configureCABundle()
+	configureTracing()
 }
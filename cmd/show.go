@@ -1,109 +1,384 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
-	"github.com/SOMUCHDOG/azb/internal/auth"
-	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/recent"
 )
 
 var (
-	showFormatFlag   string
-	showCommentsFlag bool
-	showHistoryFlag  bool
+	showFormatFlag    string
+	showCommentsFlag  bool
+	showHistoryFlag   bool
+	showRelationsFlag bool
 
 	showCmd = &cobra.Command{
-		Use:   "show <id>",
+		Use:   "show [id|range...]",
 		Short: "Show work item details",
-		Long:  `Display detailed information about a work item.`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runShow,
+		Long: `Display detailed information about one or more work items. IDs can be given
+as separate arguments, as comma-separated lists and as ranges (100-105).
+
+Without an ID, shows the work item named by the current git branch (see 'azb git current').`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runShow,
 	}
 )
 
 func init() {
 	rootCmd.AddCommand(showCmd)
 
-	showCmd.Flags().StringVarP(&showFormatFlag, "format", "f", "text", "Output format (text, json)")
+	showCmd.Flags().StringVarP(&showFormatFlag, "format", "f", "text", "Output format (text, json, yaml, csv, tsv, ids)")
 	showCmd.Flags().BoolVar(&showCommentsFlag, "comments", false, "Show comments")
 	showCmd.Flags().BoolVar(&showHistoryFlag, "history", false, "Show history")
+	showCmd.Flags().BoolVar(&showRelationsFlag, "relations", false, "Show related work items and links")
+	addJQFlag(showCmd)
 }
 
 func runShow(cmd *cobra.Command, args []string) error {
-	// Parse work item ID
-	id, err := strconv.Atoi(args[0])
+	if err := resolveFormat(cmd, "format", &showFormatFlag, withOutputFormats("text")...); err != nil {
+		return err
+	}
+	if err := resolveJQFormat(cmd, "format", &showFormatFlag); err != nil {
+		return err
+	}
+
+	// Parse work item IDs, falling back to the current git branch
+	ids, err := workItemIDsArg(args)
 	if err != nil {
-		return fmt.Errorf("invalid work item ID: %s", args[0])
+		return err
 	}
 
-	// Check authentication
-	token, err := auth.GetToken()
+	client, err := newClient()
 	if err != nil {
 		return err
 	}
 
-	// Load config
-	cfg, err := config.Load()
+	docs := make([]showDocument, 0, len(ids))
+	workItems := make([]workitemtracking.WorkItem, 0, len(ids))
+	for _, id := range ids {
+		doc, err := loadShowDocument(client, id)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+		workItems = append(workItems, *doc.WorkItem)
+	}
+
+	// Output based on format
+	switch showFormatFlag {
+	case "json", "yaml", "csv", "tsv", "ids":
+		// Always an array, so scripts get the same shape for one ID or a range
+		return writeResult(showFormatFlag, docs, workItemTable(workItems))
+	case "text":
+		fallthrough
+	default:
+		for i, doc := range docs {
+			if i > 0 {
+				fmt.Printf("\n%s\n\n", strings.Repeat("─", 60))
+			}
+			if err := displayWorkItem(doc.WorkItem); err != nil {
+				return err
+			}
+			displaySections(doc)
+		}
+		return nil
+	}
+}
+
+// loadShowDocument gets a work item with the sections requested by the flags
+func loadShowDocument(client api.APIClient, id int) (showDocument, error) {
+	workItem, err := client.GetWorkItem(id)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return showDocument{}, fmt.Errorf("failed to get work item %d: %w", id, err)
 	}
+	recent.TrackWorkItem(client.GetOrganizationURL(), workItem, recent.Viewed)
 
-	// Get organization and project
-	org := viper.GetString("organization")
-	if org == "" {
-		org = cfg.Organization
+	// Collect the optional sections so every format gets the same data
+	doc := showDocument{WorkItem: workItem}
+
+	if showCommentsFlag {
+		comments, err := client.GetComments(id)
+		if err != nil {
+			return showDocument{}, err
+		}
+		doc.Comments = convertComments(comments)
 	}
-	if org == "" {
-		return fmt.Errorf("organization not configured. Run 'azb config set organization <org>'")
+
+	if showHistoryFlag {
+		revisions, err := client.GetWorkItemUpdates(id)
+		if err != nil {
+			return showDocument{}, err
+		}
+		doc.History = historyEntries(revisions)
 	}
 
-	project := viper.GetString("project")
-	if project == "" {
-		project = cfg.Project
+	if showRelationsFlag {
+		doc.RelatedItems = convertRelations(workItem.Relations)
+		resolveBuildRelations(client, *doc.RelatedItems)
 	}
-	if project == "" {
-		return fmt.Errorf("project not configured. Run 'azb config set project <project>'")
+	return doc, nil
+}
+
+// showDocument is the JSON shape of 'azb show': the work item plus any requested sections
+type showDocument struct {
+	*workitemtracking.WorkItem
+	Comments     *[]showComment      `json:"comments,omitempty"`
+	History      *[]showHistoryEntry `json:"history,omitempty"`
+	RelatedItems *[]showRelation     `json:"relatedItems,omitempty"`
+}
+
+// showComment is a discussion comment
+type showComment struct {
+	ID     int       `json:"id"`
+	Author string    `json:"author"`
+	Date   time.Time `json:"date"`
+	Text   string    `json:"text"`
+}
+
+// showHistoryEntry is a single revision with the fields it changed
+type showHistoryEntry struct {
+	Rev     int                        `json:"rev"`
+	Author  string                     `json:"author"`
+	Date    time.Time                  `json:"date"`
+	Changes map[string]showFieldChange `json:"changes"`
+}
+
+// showFieldChange is the old and new value of a field in a revision
+type showFieldChange struct {
+	OldValue interface{} `json:"oldValue,omitempty"`
+	NewValue interface{} `json:"newValue,omitempty"`
+}
+
+// showRelation is a link from the work item to another work item or artifact
+type showRelation struct {
+	Type  string            `json:"type"`
+	Name  string            `json:"name,omitempty"`
+	ID    int               `json:"id,omitempty"`
+	URL   string            `json:"url"`
+	Build *api.BuildSummary `json:"build,omitempty"`
+}
+
+func convertComments(comments []workitemtracking.Comment) *[]showComment {
+	result := make([]showComment, 0, len(comments))
+	for _, c := range comments {
+		comment := showComment{}
+		if c.Id != nil {
+			comment.ID = *c.Id
+		}
+		if c.CreatedBy != nil && c.CreatedBy.DisplayName != nil {
+			comment.Author = *c.CreatedBy.DisplayName
+		}
+		if c.CreatedDate != nil {
+			comment.Date = c.CreatedDate.Time
+		}
+		if c.Text != nil {
+			comment.Text = *c.Text
+		}
+		result = append(result, comment)
 	}
+	return &result
+}
 
-	// Build organization URL
-	orgURL := api.NormalizeOrganizationURL(org)
+// historyEntries converts the revisions of a work item to the history section
+// of 'azb show' and 'azb export'. A revision's comment is listed as a change
+// to System.History.
+func historyEntries(revisions []api.WorkItemRevision) *[]showHistoryEntry {
+	result := make([]showHistoryEntry, 0, len(revisions))
+	for _, rev := range revisions {
+		entry := showHistoryEntry{Rev: rev.Rev, Author: rev.Author, Date: rev.Date, Changes: make(map[string]showFieldChange)}
+		for _, change := range rev.Changes {
+			entry.Changes[change.Field] = showFieldChange{OldValue: change.OldValue, NewValue: change.NewValue}
+		}
+		if rev.Comment != "" {
+			entry.Changes["System.History"] = showFieldChange{NewValue: rev.Comment}
+		}
+		result = append(result, entry)
+	}
+	return &result
+}
 
-	// Create API client
-	client, err := api.NewClient(orgURL, project, token)
-	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+func convertRelations(relations *[]workitemtracking.WorkItemRelation) *[]showRelation {
+	result := []showRelation{}
+	if relations == nil {
+		return &result
 	}
+	for _, r := range *relations {
+		if r.Rel == nil || r.Url == nil {
+			continue
+		}
+		relation := showRelation{Type: *r.Rel, URL: *r.Url}
+		if r.Attributes != nil {
+			if name, ok := (*r.Attributes)["name"].(string); ok {
+				relation.Name = name
+			}
+		}
+		if strings.Contains(*r.Url, "/workItems/") {
+			relation.ID = api.WorkItemIDFromURL(*r.Url)
+		}
+		result = append(result, relation)
+	}
+	return &result
+}
 
-	// Get work item
-	workItem, err := client.GetWorkItem(id)
-	if err != nil {
-		return fmt.Errorf("failed to get work item: %w", err)
+// resolveBuildRelations looks up the builds behind "Integrated in build" and other build links
+func resolveBuildRelations(client api.APIClient, relations []showRelation) {
+	for i, r := range relations {
+		if r.Type != api.ArtifactLinkRelation {
+			continue
+		}
+		artifact, ok := api.ParseArtifactURL(r.URL)
+		if !ok || !artifact.IsBuild() {
+			continue
+		}
+		buildID, err := strconv.Atoi(artifact.ID)
+		if err != nil {
+			continue
+		}
+		summary, err := client.GetBuildSummary(buildID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			continue
+		}
+		relations[i].Build = summary
 	}
+}
 
-	// Output based on format
-	switch showFormatFlag {
-	case "json":
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(workItem)
-	case "text":
-		fallthrough
-	default:
-		return displayWorkItem(workItem)
+// relationTarget describes what a relation points to for text output
+func relationTarget(r showRelation) string {
+	if r.ID > 0 {
+		return fmt.Sprintf("#%d", r.ID)
+	}
+	if r.Build != nil {
+		return r.Build.String()
+	}
+	if artifact, ok := api.ParseArtifactURL(r.URL); ok {
+		return fmt.Sprintf("%s %s", artifact.Type, artifact.ShortID())
+	}
+	return r.URL
+}
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// displaySections prints the optional comments, history and relations sections
+func displaySections(doc showDocument) {
+	if doc.RelatedItems != nil {
+		fmt.Printf("\nRelations (%d):\n", len(*doc.RelatedItems))
+		for _, r := range *doc.RelatedItems {
+			label := r.Name
+			if label == "" {
+				label = r.Type
+			}
+			fmt.Printf("  %s: %s\n", label, relationTarget(r))
+		}
+	}
+
+	if doc.Comments != nil {
+		fmt.Printf("\nComments (%d):\n", len(*doc.Comments))
+		for _, c := range *doc.Comments {
+			text := strings.TrimSpace(htmlTagPattern.ReplaceAllString(c.Text, ""))
+			fmt.Printf("  [%s] %s:\n    %s\n", c.Date.Local().Format("2006-01-02 15:04"), c.Author, text)
+		}
+	}
+
+	if doc.History != nil {
+		fmt.Printf("\nHistory (%d revisions):\n", len(*doc.History))
+		for _, h := range *doc.History {
+			fmt.Printf("  Rev %d by %s", h.Rev, h.Author)
+			if !h.Date.IsZero() {
+				fmt.Printf(" on %s", h.Date.Local().Format("2006-01-02 15:04"))
+			}
+			fmt.Println()
+
+			names := make([]string, 0, len(h.Changes))
+			for name := range h.Changes {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				change := h.Changes[name]
+				fmt.Printf("    %s: %v -> %v\n", name, formatHistoryValue(change.OldValue), formatHistoryValue(change.NewValue))
+			}
+		}
 	}
 }
 
-func displayWorkItem(workItem interface{}) error {
-	// This is a simplified version - in reality, we'd need to properly format
-	// the work item fields
-	fmt.Println("Work Item Details:")
-	fmt.Printf("%+v\n", workItem)
+// formatHistoryValue renders a field value for the history section
+func formatHistoryValue(value interface{}) string {
+	if value == nil {
+		return "(empty)"
+	}
+	// Identity fields are objects; show the display name
+	if identity, ok := value.(map[string]interface{}); ok {
+		if name, ok := identity["displayName"].(string); ok {
+			return name
+		}
+	}
+	text := strings.TrimSpace(htmlTagPattern.ReplaceAllString(fmt.Sprintf("%v", value), ""))
+	if len(text) > 60 {
+		text = text[:57] + "..."
+	}
+	return text
+}
+
+func displayWorkItem(workItem *workitemtracking.WorkItem) error {
+	id := 0
+	if workItem.Id != nil {
+		id = *workItem.Id
+	}
+	fmt.Printf("#%d %s\n\n", id, workItemTitle(workItem))
+
+	for _, line := range workItemDetailLines(workItem) {
+		fmt.Println(line)
+	}
+
+	description := model.FieldString(workItem.Fields, "System.Description")
+	if description = strings.TrimSpace(htmlTagPattern.ReplaceAllString(description, "")); description != "" {
+		fmt.Printf("\nDescription:\n  %s\n", strings.ReplaceAll(description, "\n", "\n  "))
+	}
 	return nil
 }
+
+// workItemDetailLines lists the set fields of a work item as "Label: value" lines
+func workItemDetailLines(workItem *workitemtracking.WorkItem) []string {
+	details := []struct {
+		label string
+		field string
+	}{
+		{"Type", "System.WorkItemType"},
+		{"State", "System.State"},
+		{"Reason", "System.Reason"},
+		{"Assigned To", "System.AssignedTo"},
+		{"Priority", "Microsoft.VSTS.Common.Priority"},
+		{"Area Path", "System.AreaPath"},
+		{"Iteration", "System.IterationPath"},
+		{"Board Column", api.BoardColumnField},
+		{"Swimlane", api.BoardLaneField},
+		{"Tags", "System.Tags"},
+		{"Created", "System.CreatedDate"},
+		{"Changed", "System.ChangedDate"},
+	}
+
+	var lines []string
+	for _, detail := range details {
+		value := queryCellValue(workItem, detail.field)
+		if value == "" {
+			continue
+		}
+		if detail.field == api.BoardColumnField && model.FieldString(workItem.Fields, api.BoardColumnDoneField) == "true" {
+			value += " (Done)"
+		}
+		lines = append(lines, fmt.Sprintf("%-13s %s", detail.label+":", value))
+	}
+	return lines
+}
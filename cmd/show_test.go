@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+func TestWorkItemDetailLines(t *testing.T) {
+	fields := map[string]interface{}{
+		"System.WorkItemType":    "User Story",
+		"System.State":           "Active",
+		"System.AssignedTo":      map[string]interface{}{"displayName": "Ada Lovelace"},
+		"System.BoardColumn":     "QA",
+		"System.BoardColumnDone": true,
+		"System.BoardLane":       "Expedite",
+	}
+	workItem := &workitemtracking.WorkItem{Fields: &fields}
+
+	want := []string{
+		"Type:         User Story",
+		"State:        Active",
+		"Assigned To:  Ada Lovelace",
+		"Board Column: QA (Done)",
+		"Swimlane:     Expedite",
+	}
+	if got := workItemDetailLines(workItem); !reflect.DeepEqual(got, want) {
+		t.Errorf("workItemDetailLines() = %q, want %q", got, want)
+	}
+}
+
+// TestShowReplay runs azb show end to end against the demo fixtures
+func TestShowReplay(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AZB_REPLAY", "../internal/api/demo")
+	t.Setenv("AZB_PAT", "demo")
+	t.Setenv("AZB_ORG", "contoso")
+	t.Setenv("AZB_PROJECT", "Fabrikam")
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	rootCmd.SetArgs([]string{"show", "103"})
+	err = rootCmd.Execute()
+	w.Close()
+	os.Stdout = stdout
+	rootCmd.SetArgs(nil)
+
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("azb show 103: %v", err)
+	}
+	for _, want := range []string{"#103 Reset password by email", "State:        Active", "Assigned To:  Ada Lovelace"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestHistoryEntries(t *testing.T) {
+	revisions := []api.WorkItemRevision{
+		{Rev: 2, Author: "Ada", Changes: []api.FieldChange{{Field: "System.State", OldValue: "New", NewValue: "Active"}}},
+		{Rev: 3, Author: "Grace", Comment: "<div>Done</div>"},
+	}
+
+	got := *historyEntries(revisions)
+	if len(got) != 2 {
+		t.Fatalf("historyEntries() returned %d entries, want 2", len(got))
+	}
+	if change := got[0].Changes["System.State"]; got[0].Rev != 2 || change.OldValue != "New" || change.NewValue != "Active" {
+		t.Errorf("entry 0 = %+v, want the State change of rev 2", got[0])
+	}
+	if comment := got[1].Changes["System.History"]; comment.NewValue != "<div>Done</div>" {
+		t.Errorf("entry 1 = %+v, want the comment as System.History", got[1])
+	}
+}
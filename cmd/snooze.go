@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/snooze"
+)
+
+// snoozeTimeFormat is how snooze end times are shown
+const snoozeTimeFormat = "Mon Jan 2 15:04"
+
+var (
+	snoozeUntilFlag string
+
+	snoozeCmd = &cobra.Command{
+		Use:   "snooze <id>",
+		Short: "Hide a work item until later",
+		Long: `Hide a work item from 'azb list --mine' and the dashboard's default view
+until a time you choose. When the time passes, the item shows up again with a
+reminder. Snoozes are stored locally in ~/.azure-boards-cli/snoozed.json and
+do not change the work item.
+
+--until accepts durations (3h, 2d, 1w), tomorrow, next week, weekday names
+(monday, fri) and dates (2024-06-01, "2024-06-01 14:00"). Days without a
+time end at 09:00.
+
+Examples:
+  azb snooze 1234 --until monday
+  azb snooze 1234 --until 3h
+  azb snooze list
+  azb snooze cancel 1234`,
+		Args: cobra.ExactArgs(1),
+		RunE: runSnooze,
+	}
+
+	snoozeListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List snoozed work items",
+		Args:  cobra.NoArgs,
+		RunE:  runSnoozeList,
+	}
+
+	snoozeCancelCmd = &cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Show a snoozed work item again now",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runSnoozeCancel,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(snoozeCmd)
+	snoozeCmd.AddCommand(snoozeListCmd)
+	snoozeCmd.AddCommand(snoozeCancelCmd)
+
+	snoozeCmd.Flags().StringVarP(&snoozeUntilFlag, "until", "u", "tomorrow", "When the work item shows up again")
+}
+
+func runSnooze(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	now := time.Now()
+	until, err := snooze.ParseUntil(snoozeUntilFlag, now)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	workItem, err := client.GetWorkItem(id)
+	if err != nil {
+		return err
+	}
+
+	store, err := snooze.Load()
+	if err != nil {
+		return err
+	}
+	store.Snooze(id, workItemTitle(workItem), until, now)
+	if err := store.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Snoozed #%d %s until %s\n", id, workItemTitle(workItem), until.Format(snoozeTimeFormat))
+	return nil
+}
+
+func runSnoozeList(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd, withOutputFormats("table")...)
+	if err != nil {
+		return err
+	}
+
+	store, err := snooze.Load()
+	if err != nil {
+		return err
+	}
+	reportDueSnoozes(store)
+
+	active := store.Active(time.Now())
+	if format != "table" {
+		table := output.Table{Headers: []string{"ID", "Title", "Until"}}
+		for _, reminder := range active {
+			table.Rows = append(table.Rows, []string{strconv.Itoa(reminder.ID), reminder.Title, reminder.Until.Local().Format(snoozeTimeFormat)})
+		}
+		if active == nil {
+			active = []snooze.Reminder{}
+		}
+		return writeResult(format, active, table)
+	}
+	if len(active) == 0 {
+		fmt.Println("No snoozed work items")
+		return nil
+	}
+
+	for _, reminder := range active {
+		fmt.Printf("  %-16s %s\n", reminder.Until.Format(snoozeTimeFormat), truncateString(reminder.String(), 60))
+	}
+	return nil
+}
+
+func runSnoozeCancel(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	store, err := snooze.Load()
+	if err != nil {
+		return err
+	}
+	if !store.Cancel(id) {
+		return fmt.Errorf("work item %d is not snoozed", id)
+	}
+	if err := store.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Work item %d is no longer snoozed\n", id)
+	return nil
+}
+
+// reportDueSnoozes prints a reminder on stderr for each snooze that has ended
+// and forgets it, so every item resurfaces once
+func reportDueSnoozes(store *snooze.Store) {
+	due := store.TakeDue(time.Now())
+	if len(due) == 0 {
+		return
+	}
+	printDueSnoozes(due)
+	if err := store.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// printDueSnoozes prints a reminder on stderr for each ended snooze
+func printDueSnoozes(due []snooze.Reminder) {
+	for _, reminder := range due {
+		fmt.Fprintf(os.Stderr, "Reminder: %s is back (snoozed on %s)\n", reminder, reminder.Created.Format(snoozeTimeFormat))
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/output"
+)
+
+var (
+	statesFormatFlag string
+
+	statesCmd = &cobra.Command{
+		Use:   "states <work-item-type>",
+		Short: "List the states of a work item type",
+		Long: `List the states of a work item type in workflow order with their category
+(Proposed, InProgress, Resolved, Completed, Removed) and board color.
+
+Examples:
+  azb states "User Story"
+  azb states Bug --format json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeWorkItemTypes),
+		RunE:              runStates,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(statesCmd)
+
+	statesCmd.Flags().StringVarP(&statesFormatFlag, "format", "f", "table", "Output format (table, json, yaml, csv, tsv, ids)")
+}
+
+func runStates(cmd *cobra.Command, args []string) error {
+	formats := withOutputFormats("table")
+	if err := resolveFormat(cmd, "format", &statesFormatFlag, formats...); err != nil {
+		return err
+	}
+	if !slices.Contains(formats, statesFormatFlag) {
+		return fmt.Errorf("unsupported format: %s", statesFormatFlag)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	states, err := client.GetWorkItemTypeStates(args[0])
+	if err != nil {
+		return err
+	}
+
+	if statesFormatFlag != "table" {
+		table := output.Table{Headers: []string{"State", "Category", "Color"}}
+		for _, state := range states {
+			table.Rows = append(table.Rows, []string{state.Name, state.Category, state.Color})
+		}
+		return writeResult(statesFormatFlag, states, table)
+	}
+
+	if len(states) == 0 {
+		fmt.Printf("No states found for '%s'\n", args[0])
+		return nil
+	}
+
+	swatches := supportsHyperlinks()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "State\tCategory\tColor\t")
+	for _, state := range states {
+		fmt.Fprintf(w, "%s\t%s\t%s\t\n", state.Name, state.Category, colorSwatch(state.Color, swatches))
+	}
+	w.Flush()
+	return nil
+}
+
+// colorSwatch shows a hex color such as 009CCC, preceded by a dot in that
+// color when the terminal can display it
+func colorSwatch(hex string, enabled bool) string {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return hex
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || !enabled {
+		return "#" + hex
+	}
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm●\033[0m #%s", rgb>>16, rgb>>8&0xff, rgb&0xff, hex)
+}
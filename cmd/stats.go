@@ -0,0 +1,381 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/wiql"
+)
+
+// statsDimension is a --group-by or --sum value with its WIQL field and
+// Analytics property
+type statsDimension struct {
+	Field    string
+	Property string
+}
+
+// statsGroups are the --group-by shortcuts; other values are used as field reference names
+var statsGroups = map[string]statsDimension{
+	"state":     {"System.State", "State"},
+	"type":      {"System.WorkItemType", "WorkItemType"},
+	"assigned":  {"System.AssignedTo", "AssignedTo/UserName"},
+	"area":      {"System.AreaPath", "Area/AreaPath"},
+	"iteration": {"System.IterationPath", "Iteration/IterationPath"},
+	"priority":  {"Microsoft.VSTS.Common.Priority", "Priority"},
+	"reason":    {"System.Reason", "Reason"},
+	"tags":      {"System.Tags", ""},
+}
+
+// statsSums are the --sum shortcuts; other values are used as field reference names
+var statsSums = map[string]statsDimension{
+	"points":    {"Microsoft.VSTS.Scheduling.StoryPoints", "StoryPoints"},
+	"effort":    {"Microsoft.VSTS.Scheduling.Effort", "Effort"},
+	"remaining": {remainingWorkField, "RemainingWork"},
+	"completed": {completedWorkField, "CompletedWork"},
+	"estimate":  {originalEstimateField, "OriginalEstimate"},
+}
+
+var (
+	statsGroupByFlag   string
+	statsTypeFlag      string
+	statsStateFlag     string
+	statsSinceFlag     string
+	statsSumFlag       string
+	statsAnalyticsFlag bool
+	statsFormatFlag    string
+
+	statsCmd = &cobra.Command{
+		Use:   "stats",
+		Short: "Count work items grouped by a field",
+		Long: `Count work items of the project grouped by a field, optionally summing a
+numeric field per group, and show the result as a bar chart.
+
+Group by state, type, assigned, area, iteration, priority, reason or tags, or by
+any field reference name. --since counts work items created in the last
+duration (30d, 2w, 12h) or since a date (2024-05-01).
+
+By default work items are counted with a WIQL query, which returns at most
+20,000 work items. On large projects use --analytics, which aggregates on the
+server through the Analytics OData endpoint (grouping by tags is not supported
+there, and the token needs the Analytics read scope).
+
+Examples:
+  azb stats --group-by state --type Bug --since 30d
+  azb stats --group-by assigned --state Active --sum remaining
+  azb stats --group-by area --analytics --format csv`,
+		Args: cobra.NoArgs,
+		RunE: runStats,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().StringVarP(&statsGroupByFlag, "group-by", "g", "state", "Field to group by (state, type, assigned, area, iteration, priority, reason, tags, or a field reference name)")
+	statsCmd.Flags().StringVarP(&statsTypeFlag, "type", "t", "", "Only count work items of this type")
+	statsCmd.Flags().StringVarP(&statsStateFlag, "state", "s", "", "Only count work items in this state")
+	statsCmd.Flags().StringVar(&statsSinceFlag, "since", "", "Only count work items created since a duration (30d, 2w, 12h) or date")
+	statsCmd.Flags().StringVar(&statsSumFlag, "sum", "", "Numeric field to sum per group (points, effort, remaining, completed, estimate, or a field reference name)")
+	statsCmd.Flags().BoolVar(&statsAnalyticsFlag, "analytics", false, "Aggregate on the server with the Analytics OData endpoint")
+	statsCmd.Flags().StringVarP(&statsFormatFlag, "format", "f", "table", "Output format (table, json, yaml, csv, tsv, ids)")
+}
+
+// statsGroup is one group of 'azb stats'
+type statsGroup struct {
+	Value string  `json:"value"`
+	Count int     `json:"count"`
+	Sum   float64 `json:"sum,omitempty"`
+}
+
+// statsResult is the output of 'azb stats'
+type statsResult struct {
+	GroupBy string       `json:"groupBy"`
+	Sum     string       `json:"sum,omitempty"`
+	Total   int          `json:"total"`
+	Groups  []statsGroup `json:"groups"`
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	formats := withOutputFormats("table")
+	if err := resolveFormat(cmd, "format", &statsFormatFlag, formats...); err != nil {
+		return err
+	}
+	if !slices.Contains(formats, statsFormatFlag) {
+		return fmt.Errorf("unsupported format: %s", statsFormatFlag)
+	}
+
+	group := statsDimensionFor(statsGroups, statsGroupByFlag)
+	var sum *statsDimension
+	if statsSumFlag != "" {
+		d := statsDimensionFor(statsSums, statsSumFlag)
+		sum = &d
+	}
+
+	var since time.Time
+	if statsSinceFlag != "" {
+		var err error
+		since, err = parseSince(statsSinceFlag, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	var groups []statsGroup
+	if statsAnalyticsFlag {
+		groups, err = analyticsStats(client, group, sum, since)
+	} else {
+		groups, err = wiqlStats(client, group, sum, since)
+	}
+	if err != nil {
+		return err
+	}
+	sortStatsGroups(groups)
+
+	result := &statsResult{GroupBy: statsGroupByFlag, Sum: statsSumFlag, Groups: groups}
+	for _, g := range groups {
+		result.Total += g.Count
+	}
+
+	if statsFormatFlag != "table" {
+		table := output.Table{Headers: []string{"Value", "Count"}}
+		if sum != nil {
+			table.Headers = append(table.Headers, "Sum")
+		}
+		for _, g := range groups {
+			row := []string{g.Value, strconv.Itoa(g.Count)}
+			if sum != nil {
+				row = append(row, formatHours(g.Sum))
+			}
+			table.Rows = append(table.Rows, row)
+		}
+		return writeResult(statsFormatFlag, result, table)
+	}
+
+	if len(groups) == 0 {
+		fmt.Println("No work items found")
+		return nil
+	}
+	bars := make([]reportBar, len(groups))
+	for i, g := range groups {
+		note := strconv.Itoa(g.Count)
+		if sum != nil {
+			note += fmt.Sprintf(" (%s: %s)", statsSumFlag, formatHours(g.Sum))
+		}
+		bars[i] = reportBar{Label: truncateString(g.Value, 40), Value: float64(g.Count), Note: note}
+	}
+	fmt.Print(renderBarChart(bars, reportBarWidth))
+	fmt.Printf("\nTotal: %d work item(s)\n", result.Total)
+	return nil
+}
+
+// statsDimensionFor resolves a shortcut, or treats the value as a field reference name
+func statsDimensionFor(shortcuts map[string]statsDimension, value string) statsDimension {
+	if d, ok := shortcuts[strings.ToLower(value)]; ok {
+		return d
+	}
+	property := value
+	if i := strings.LastIndex(value, "."); i >= 0 {
+		property = value[i+1:]
+	}
+	return statsDimension{Field: value, Property: property}
+}
+
+// wiqlStats counts work items client-side from a WIQL query
+func wiqlStats(client api.APIClient, group statsDimension, sum *statsDimension, since time.Time) ([]statsGroup, error) {
+	ids, err := client.QueryWorkItemIDs(statsWIQL(statsTypeFlag, statsStateFlag, since), 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []statsGroup{}, nil
+	}
+	items, err := client.GetWorkItems(ids)
+	if err != nil {
+		return nil, err
+	}
+	return countWorkItems(items, group, sum), nil
+}
+
+// statsWIQL builds the query of work items counted by 'azb stats'
+func statsWIQL(workItemType, state string, since time.Time) string {
+	query := wiql.Select().Where(wiql.Field("System.TeamProject").Eq(wiql.Project))
+	if workItemType != "" {
+		query.Where(wiql.Field("System.WorkItemType").Eq(wiql.String(workItemType)))
+	}
+	if state != "" {
+		query.Where(wiql.Field("System.State").Eq(wiql.String(state)))
+	}
+	if !since.IsZero() {
+		query.Where(wiql.Field("System.CreatedDate").Ge(wiql.String(since.Format("2006-01-02"))))
+	}
+	return query.String()
+}
+
+// countWorkItems groups work items by a field. Tags count each tag separately.
+func countWorkItems(items []workitemtracking.WorkItem, group statsDimension, sum *statsDimension) []statsGroup {
+	byValue := make(map[string]*statsGroup)
+	var groups []statsGroup
+	var order []string
+
+	add := func(value string, amount float64) {
+		if value == "" {
+			value = "(none)"
+		}
+		g, ok := byValue[value]
+		if !ok {
+			g = &statsGroup{Value: value}
+			byValue[value] = g
+			order = append(order, value)
+		}
+		g.Count++
+		g.Sum += amount
+	}
+
+	for _, wi := range items {
+		if wi.Fields == nil {
+			continue
+		}
+		amount := 0.0
+		if sum != nil {
+			amount, _ = numberField(*wi.Fields, sum.Field)
+		}
+
+		value := model.FieldString(wi.Fields, group.Field)
+		if group.Field != "System.Tags" {
+			add(value, amount)
+			continue
+		}
+		tags := strings.Split(value, ";")
+		for _, tag := range tags {
+			add(strings.TrimSpace(tag), amount)
+		}
+	}
+
+	for _, value := range order {
+		groups = append(groups, *byValue[value])
+	}
+	if groups == nil {
+		groups = []statsGroup{}
+	}
+	return groups
+}
+
+// analyticsStats aggregates on the server with an OData groupby query
+func analyticsStats(client api.APIClient, group statsDimension, sum *statsDimension, since time.Time) ([]statsGroup, error) {
+	if group.Property == "" {
+		return nil, fmt.Errorf("grouping by %s is not supported with --analytics", statsGroupByFlag)
+	}
+
+	rows, err := client.QueryAnalytics("WorkItems", statsODataQuery(group, sum, statsTypeFlag, statsStateFlag, since))
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]statsGroup, 0, len(rows))
+	for _, row := range rows {
+		g := statsGroup{Value: odataValue(row, group.Property)}
+		if g.Value == "" {
+			g.Value = "(none)"
+		}
+		if count, ok := row["Count"].(float64); ok {
+			g.Count = int(count)
+		}
+		if sum != nil {
+			g.Sum, _ = row["Sum"].(float64)
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// statsODataQuery builds the $apply query of 'azb stats --analytics'
+func statsODataQuery(group statsDimension, sum *statsDimension, workItemType, state string, since time.Time) url.Values {
+	var filters []string
+	if workItemType != "" {
+		filters = append(filters, fmt.Sprintf("WorkItemType eq '%s'", strings.ReplaceAll(workItemType, "'", "''")))
+	}
+	if state != "" {
+		filters = append(filters, fmt.Sprintf("State eq '%s'", strings.ReplaceAll(state, "'", "''")))
+	}
+	if !since.IsZero() {
+		filters = append(filters, "CreatedDate ge "+since.UTC().Format(time.RFC3339))
+	}
+
+	aggregate := "$count as Count"
+	if sum != nil {
+		aggregate += fmt.Sprintf(", %s with sum as Sum", sum.Property)
+	}
+
+	apply := fmt.Sprintf("groupby((%s), aggregate(%s))", group.Property, aggregate)
+	if len(filters) > 0 {
+		apply = fmt.Sprintf("filter(%s)/%s", strings.Join(filters, " and "), apply)
+	}
+	return url.Values{"$apply": []string{apply}}
+}
+
+// odataValue reads a possibly nested property like AssignedTo/UserName from an OData row
+func odataValue(row map[string]interface{}, property string) string {
+	var value interface{} = row
+	for _, name := range strings.Split(property, "/") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = m[name]
+	}
+	if value == nil {
+		return ""
+	}
+	if f, ok := value.(float64); ok && f == math.Trunc(f) {
+		return strconv.Itoa(int(f))
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// sortStatsGroups orders groups by count, largest first, then by value
+func sortStatsGroups(groups []statsGroup) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Value < groups[j].Value
+	})
+}
+
+// parseSince reads a duration back from now (30d, 2w, 12h) or a date
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if len(value) >= 2 {
+		if n, err := strconv.Atoi(value[:len(value)-1]); err == nil && n > 0 {
+			switch value[len(value)-1] {
+			case 'h':
+				return now.Add(-time.Duration(n) * time.Hour), nil
+			case 'd':
+				return now.AddDate(0, 0, -n), nil
+			case 'w':
+				return now.AddDate(0, 0, -7*n), nil
+			}
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since value %q (use e.g. 30d, 2w, 12h or 2024-05-01)", value)
+}
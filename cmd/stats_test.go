@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestCountWorkItems(t *testing.T) {
+	items := []workitemtracking.WorkItem{
+		testWorkItem(1, map[string]interface{}{"System.State": "Active", "System.Tags": "ui; login", remainingWorkField: 3.0}),
+		testWorkItem(2, map[string]interface{}{"System.State": "New", "System.Tags": "ui", remainingWorkField: 2.0}),
+		testWorkItem(3, map[string]interface{}{"System.State": "Active"}),
+	}
+	sum := statsSums["remaining"]
+
+	byState := countWorkItems(items, statsGroups["state"], &sum)
+	sortStatsGroups(byState)
+	want := []statsGroup{{Value: "Active", Count: 2, Sum: 3}, {Value: "New", Count: 1, Sum: 2}}
+	if len(byState) != len(want) {
+		t.Fatalf("countWorkItems() by state = %+v, want %+v", byState, want)
+	}
+	for i := range want {
+		if byState[i] != want[i] {
+			t.Errorf("group %d = %+v, want %+v", i, byState[i], want[i])
+		}
+	}
+
+	byTag := countWorkItems(items, statsGroups["tags"], nil)
+	sortStatsGroups(byTag)
+	wantTags := []statsGroup{{Value: "ui", Count: 2}, {Value: "(none)", Count: 1}, {Value: "login", Count: 1}}
+	if len(byTag) != len(wantTags) {
+		t.Fatalf("countWorkItems() by tag = %+v, want %+v", byTag, wantTags)
+	}
+	for i := range wantTags {
+		if byTag[i] != wantTags[i] {
+			t.Errorf("tag group %d = %+v, want %+v", i, byTag[i], wantTags[i])
+		}
+	}
+}
+
+func TestStatsODataQuery(t *testing.T) {
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	sum := statsSums["points"]
+
+	got := statsODataQuery(statsGroups["assigned"], &sum, "Bug", "", since).Get("$apply")
+	want := "filter(WorkItemType eq 'Bug' and CreatedDate ge 2024-05-01T00:00:00Z)/groupby((AssignedTo/UserName), aggregate($count as Count, StoryPoints with sum as Sum))"
+	if got != want {
+		t.Errorf("statsODataQuery() = %q, want %q", got, want)
+	}
+
+	got = statsODataQuery(statsGroups["state"], nil, "", "", time.Time{}).Get("$apply")
+	if want := "groupby((State), aggregate($count as Count))"; got != want {
+		t.Errorf("statsODataQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestOdataValue(t *testing.T) {
+	row := map[string]interface{}{
+		"AssignedTo": map[string]interface{}{"UserName": "Ada"},
+		"Priority":   2.0,
+		"State":      nil,
+	}
+	for property, want := range map[string]string{"AssignedTo/UserName": "Ada", "Priority": "2", "State": "", "Missing/Name": ""} {
+		if got := odataValue(row, property); got != want {
+			t.Errorf("odataValue(%q) = %q, want %q", property, got, want)
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "30d", want: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+		{value: "2w", want: time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)},
+		{value: "12h", want: time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)},
+		{value: "2024-05-01", want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "soon", wantErr: true},
+		{value: "0d", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
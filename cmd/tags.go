@@ -0,0 +1,389 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/wiql"
+)
+
+var (
+	tagsFormatFlag string
+	tagsForceFlag  bool
+
+	tagsCmd = &cobra.Command{
+		Use:     "tags",
+		Aliases: []string{"tag"},
+		Short:   "Manage work item tags",
+		Long: `List, rename and delete the work item tags of the project.
+
+Renaming and deleting change every work item with the tag, one revision per
+work item, so the change shows in their history. Tags are matched ignoring
+case, like in the web UI.`,
+	}
+
+	tagsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List tags with the number of work items using them",
+		Long: `List the tags of the project with the number of work items using each one,
+most used first. Tags no work item uses any more are listed with 0 until
+Azure DevOps removes them.
+
+Examples:
+  azb tags list
+  azb tags list --format csv`,
+		Args: cobra.NoArgs,
+		RunE: runTagsList,
+	}
+
+	tagsRenameCmd = &cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename a tag on every work item",
+		Long: `Replace a tag with another on every work item that has it. If the new tag is
+already in use, the two are merged. Changing only the case of a tag renames
+it in place without changing work items.
+
+Examples:
+  azb tags rename frontend ui
+  azb tags rename "tech debt" tech-debt --force`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArgs(completeTags),
+		Annotations:       mutatingAnnotations,
+		RunE:              runTagsRename,
+	}
+
+	tagsDeleteCmd = &cobra.Command{
+		Use:   "delete <tag>",
+		Short: "Remove a tag from every work item and delete it",
+		Long: `Remove a tag from every work item and delete it.
+
+Examples:
+  azb tags delete obsolete
+  azb tags delete obsolete --force`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeTags),
+		Annotations:       mutatingAnnotations,
+		RunE:              runTagsDelete,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+	tagsCmd.AddCommand(tagsListCmd)
+	tagsCmd.AddCommand(tagsRenameCmd)
+	tagsCmd.AddCommand(tagsDeleteCmd)
+
+	tagsListCmd.Flags().StringVarP(&tagsFormatFlag, "format", "f", "table", "Output format (table, json, yaml, csv, tsv, ids)")
+	for _, cmd := range []*cobra.Command{tagsRenameCmd, tagsDeleteCmd} {
+		cmd.Flags().BoolVarP(&tagsForceFlag, "force", "f", false, "Skip confirmation prompt")
+		addConcurrencyFlag(cmd)
+	}
+}
+
+// tagUsage is a tag with the number of work items using it
+type tagUsage struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func runTagsList(cmd *cobra.Command, args []string) error {
+	formats := withOutputFormats("table")
+	if err := resolveFormat(cmd, "format", &tagsFormatFlag, formats...); err != nil {
+		return err
+	}
+	if !slices.Contains(formats, tagsFormatFlag) {
+		return fmt.Errorf("unsupported format: %s", tagsFormatFlag)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	defined, err := client.ListTags()
+	if err != nil {
+		return err
+	}
+	ids, err := queryAllWorkItemIDs(client,
+		wiql.Field("System.TeamProject").Eq(wiql.Project),
+		wiql.Field("System.Tags").Ne(wiql.String("")),
+	)
+	if err != nil {
+		return err
+	}
+	var items []workitemtracking.WorkItem
+	if len(ids) > 0 {
+		if items, err = client.GetWorkItemFields(ids, []string{"System.Tags"}); err != nil {
+			return err
+		}
+	}
+
+	usage := countTagUsage(defined, items)
+	table := output.Table{Headers: []string{"Tag", "Work Items"}}
+	for _, tag := range usage {
+		table.Rows = append(table.Rows, []string{tag.Name, strconv.Itoa(tag.Count)})
+	}
+	if tagsFormatFlag != "table" {
+		return writeResult(tagsFormatFlag, usage, table)
+	}
+
+	if len(usage) == 0 {
+		fmt.Println("No tags found")
+		return nil
+	}
+	if err := output.WriteTable(os.Stdout, table); err != nil {
+		return err
+	}
+	fmt.Printf("\nTotal: %d tags\n", len(usage))
+	return nil
+}
+
+// countTagUsage counts the work items using each tag, most used first. Tags
+// are matched ignoring case and listed as defined; tags of work items missing
+// from the definitions are counted too.
+func countTagUsage(defined []api.Tag, items []workitemtracking.WorkItem) []tagUsage {
+	byName := make(map[string]*tagUsage)
+	var usage []*tagUsage
+	add := func(name string) *tagUsage {
+		key := strings.ToLower(name)
+		if tag, ok := byName[key]; ok {
+			return tag
+		}
+		tag := &tagUsage{Name: name}
+		byName[key] = tag
+		usage = append(usage, tag)
+		return tag
+	}
+
+	for _, tag := range defined {
+		add(tag.Name)
+	}
+	for _, item := range model.FromAPIList(items) {
+		for _, tag := range item.Tags {
+			add(tag).Count++
+		}
+	}
+
+	result := make([]tagUsage, len(usage))
+	for i, tag := range usage {
+		result[i] = *tag
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+	return result
+}
+
+func runTagsRename(cmd *cobra.Command, args []string) error {
+	oldName, newName := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+	if oldName == "" || newName == "" {
+		return fmt.Errorf("tag names cannot be empty")
+	}
+	if strings.ContainsAny(newName, ";,") {
+		return fmt.Errorf("tag names cannot contain ';' or ','")
+	}
+	if oldName == newName {
+		return fmt.Errorf("the new name is the same as the old one")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	// Work items refer to tags ignoring case, so only the definition can change case
+	if strings.EqualFold(oldName, newName) {
+		if err := client.RenameTag(oldName, newName); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Renamed tag '%s' to '%s'\n", oldName, newName)
+		return nil
+	}
+
+	ids, err := taggedWorkItemIDs(client, oldName)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no work items are tagged '%s'", oldName)
+	}
+
+	ok, err := confirmTagChange(fmt.Sprintf("Rename tag '%s' to '%s' on %d work item(s)?", oldName, newName, len(ids)))
+	if err != nil || !ok {
+		return err
+	}
+	if err := retagWorkItems(client, ids, oldName, newName); err != nil {
+		return err
+	}
+
+	// The old definition outlives the work items using it, so drop it too
+	definition, err := tagDefinition(client, oldName)
+	if err != nil || definition == "" {
+		return err
+	}
+	return client.DeleteTag(definition)
+}
+
+func runTagsDelete(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("tag name cannot be empty")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	definition, err := tagDefinition(client, name)
+	if err != nil {
+		return err
+	}
+	ids, err := taggedWorkItemIDs(client, name)
+	if err != nil {
+		return err
+	}
+	if definition == "" && len(ids) == 0 {
+		return fmt.Errorf("tag '%s' not found", name)
+	}
+
+	if len(ids) > 0 {
+		ok, err := confirmTagChange(fmt.Sprintf("Remove tag '%s' from %d work item(s) and delete it?", name, len(ids)))
+		if err != nil || !ok {
+			return err
+		}
+		if err := retagWorkItems(client, ids, name, ""); err != nil {
+			return err
+		}
+	}
+
+	if definition != "" {
+		if err := client.DeleteTag(definition); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Deleted tag '%s'\n", definition)
+	}
+	return nil
+}
+
+// tagDefinition returns the name of the project's tag definition matching
+// name ignoring case, or "" if there is none
+func tagDefinition(client api.APIClient, name string) (string, error) {
+	defined, err := client.ListTags()
+	if err != nil {
+		return "", err
+	}
+	for _, tag := range defined {
+		if strings.EqualFold(tag.Name, name) {
+			return tag.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// taggedWorkItemIDs returns the IDs of the project's work items with a tag
+func taggedWorkItemIDs(client api.APIClient, tag string) ([]int, error) {
+	return queryAllWorkItemIDs(client,
+		wiql.Field("System.TeamProject").Eq(wiql.Project),
+		wiql.Field("System.Tags").Contains(wiql.String(tag)),
+	)
+}
+
+// confirmTagChange asks before changing work items, unless --force is set
+func confirmTagChange(question string) (bool, error) {
+	if tagsForceFlag {
+		return true, nil
+	}
+	fmt.Printf("%s (y/N): ", question)
+
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		fmt.Println("Cancelled")
+		return false, nil
+	}
+	return true, nil
+}
+
+// retagWorkItems replaces a tag on work items, or removes it when newTag is empty
+func retagWorkItems(client api.APIClient, ids []int, oldTag, newTag string) error {
+	progress := newBulkProgress(len(ids))
+	api.ForEach(len(ids), func(i int) {
+		id := ids[i]
+		changed, err := updateTags(client, id, func(tags string) string {
+			return replaceTag(tags, oldTag, newTag)
+		})
+		switch {
+		case err != nil:
+			progress.Failure(fmt.Sprintf("✗ Failed to update work item %d: %v", id, err))
+		case !changed:
+			progress.Success(fmt.Sprintf("✓ Work item %d already up to date", id))
+		default:
+			progress.Success(fmt.Sprintf("✓ Updated work item %d", id))
+		}
+	})
+	progress.Finish()
+	successCount, failCount := progress.Counts()
+
+	fmt.Printf("\nSummary: %d updated, %d failed\n", successCount, failCount)
+	if failCount > 0 {
+		return fmt.Errorf("some work items failed to update")
+	}
+	return nil
+}
+
+// updateTags rewrites the tags of a work item with change and saves them at
+// the revision they were read at, so a concurrent change is not overwritten.
+// It reports whether the tags changed.
+func updateTags(client api.APIClient, id int, change func(tags string) string) (bool, error) {
+	updated, err := api.UpdateFromCurrent(client, id, func(workItem *workitemtracking.WorkItem) (map[string]interface{}, error) {
+		current := workItemTags(workItem)
+		tags := change(current)
+		if tags == current {
+			return nil, nil
+		}
+		return map[string]interface{}{"System.Tags": tags}, nil
+	})
+	return updated != nil, err
+}
+
+// replaceTag replaces oldTag, matched ignoring case, in a System.Tags value
+// with newTag, or removes it when newTag is empty. The order of the other tags
+// is kept and newTag is not added twice.
+func replaceTag(tags, oldTag, newTag string) string {
+	current := model.ParseTags(tags)
+	result := make([]string, 0, len(current)+1)
+	found := false
+	for _, tag := range current {
+		if strings.EqualFold(tag, oldTag) {
+			found = true
+			continue
+		}
+		result = append(result, tag)
+	}
+	if !found {
+		return tags
+	}
+	if newTag != "" && !slices.ContainsFunc(result, func(tag string) bool { return strings.EqualFold(tag, newTag) }) {
+		result = append(result, newTag)
+	}
+	return model.FormatTags(result)
+}
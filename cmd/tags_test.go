@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/api/apitest"
+)
+
+func TestReplaceTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		tags   string
+		oldTag string
+		newTag string
+		want   string
+	}{
+		{"rename", "auth; frontend; urgent", "frontend", "ui", "auth; urgent; ui"},
+		{"ignores case", "auth; Frontend", "frontend", "ui", "auth; ui"},
+		{"merges into existing tag", "frontend; UI", "frontend", "ui", "UI"},
+		{"remove", "auth; frontend", "frontend", "", "auth"},
+		{"remove last tag", "frontend", "frontend", "", ""},
+		{"tag not present", "auth;urgent", "frontend", "ui", "auth;urgent"},
+		{"partial names do not match", "frontend-legacy", "frontend", "ui", "frontend-legacy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replaceTag(tt.tags, tt.oldTag, tt.newTag); got != tt.want {
+				t.Errorf("replaceTag(%q, %q, %q) = %q, want %q", tt.tags, tt.oldTag, tt.newTag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountTagUsage(t *testing.T) {
+	item := func(tags string) workitemtracking.WorkItem {
+		fields := map[string]interface{}{"System.Tags": tags}
+		return workitemtracking.WorkItem{Fields: &fields}
+	}
+	defined := []api.Tag{{Name: "Auth"}, {Name: "obsolete"}, {Name: "urgent"}}
+	items := []workitemtracking.WorkItem{item("auth; urgent"), item("urgent"), item("Auth; legacy")}
+
+	got := countTagUsage(defined, items)
+	want := []tagUsage{{"Auth", 2}, {"urgent", 2}, {"legacy", 1}, {"obsolete", 0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("countTagUsage() = %+v, want %+v", got, want)
+	}
+}
+
+func TestUpdateTags(t *testing.T) {
+	// Someone adds "web" between the first read and the update
+	revisions := []string{"bug; ui", "bug; ui; web"}
+	rev := 0
+	client := apitest.New()
+	client.GetWorkItemFunc = func(id int) (*workitemtracking.WorkItem, error) {
+		rev = min(rev+1, len(revisions))
+		tags, current := revisions[rev-1], rev
+		return &workitemtracking.WorkItem{Id: &id, Rev: &current, Fields: &map[string]interface{}{"System.Tags": tags}}, nil
+	}
+	var saved []interface{}
+	client.UpdateWorkItemAtRevisionFunc = func(id, rev int, fields map[string]interface{}) (*workitemtracking.WorkItem, error) {
+		if rev == 1 {
+			return nil, api.ErrRevisionConflict
+		}
+		saved = append(saved, fields["System.Tags"])
+		return &workitemtracking.WorkItem{Id: &id}, nil
+	}
+
+	changed, err := updateTags(client, 7, func(tags string) string { return replaceTag(tags, "ui", "frontend") })
+	if err != nil || !changed {
+		t.Fatalf("updateTags() = %v, %v; want changed", changed, err)
+	}
+	if !reflect.DeepEqual(saved, []interface{}{"bug; web; frontend"}) {
+		t.Errorf("saved tags = %v, want the rename re-applied to the latest tags", saved)
+	}
+
+	changed, err = updateTags(client, 7, func(tags string) string { return tags })
+	if err != nil || changed {
+		t.Errorf("updateTags() without a change = %v, %v; want unchanged", changed, err)
+	}
+}
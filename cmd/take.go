@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+// inProgressStateNames are tried when a work item type has no InProgress state category
+var inProgressStateNames = []string{"Active", "In Progress", "Doing", "Committed"}
+
+var (
+	takeCmd = &cobra.Command{
+		Use:         "take <id>",
+		Short:       "Assign a work item to yourself",
+		Long:        `Assign a work item to the user the personal access token belongs to.`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: mutatingAnnotations,
+		RunE:        runTake,
+	}
+
+	startCmd = &cobra.Command{
+		Use:   "start <id>",
+		Short: "Assign a work item to yourself and start working on it",
+		Long: `Assign a work item to yourself and move it to its in-progress state.
+
+The state is discovered from the work item type (e.g. Active for Agile,
+In Progress for Basic, Committed for Scrum).`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: mutatingAnnotations,
+		RunE:        runStart,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(takeCmd)
+	rootCmd.AddCommand(startCmd)
+}
+
+func runTake(cmd *cobra.Command, args []string) error {
+	return takeWorkItem(args[0], false)
+}
+
+func runStart(cmd *cobra.Command, args []string) error {
+	return takeWorkItem(args[0], true)
+}
+
+// takeWorkItem assigns a work item to the current user and optionally moves it to its in-progress state
+func takeWorkItem(idArg string, start bool) error {
+	id, err := strconv.Atoi(strings.TrimSpace(idArg))
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", idArg)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]interface{}{
+		"System.AssignedTo": user.AssignableName(),
+	}
+
+	state := ""
+	if start {
+		workItem, err := client.GetWorkItem(id)
+		if err != nil {
+			return fmt.Errorf("failed to get work item: %w", err)
+		}
+
+		var workItemType, currentState string
+		if workItem.Fields != nil {
+			workItemType, _ = (*workItem.Fields)["System.WorkItemType"].(string)
+			currentState, _ = (*workItem.Fields)["System.State"].(string)
+		}
+
+		state, err = findInProgressState(client, workItemType)
+		if err != nil {
+			return err
+		}
+
+		if state != currentState {
+			fields["System.State"] = state
+		}
+	}
+
+	if _, err := client.UpdateWorkItem(id, fields); err != nil {
+		return fmt.Errorf("failed to update work item: %w", err)
+	}
+
+	if start {
+		fmt.Printf("✓ Work item %d assigned to %s and set to %s\n", id, user.DisplayName, state)
+	} else {
+		fmt.Printf("✓ Work item %d assigned to %s\n", id, user.DisplayName)
+	}
+
+	return nil
+}
+
+// findInProgressState returns the state a work item type uses for active work
+func findInProgressState(client api.APIClient, workItemType string) (string, error) {
+	if state, err := client.GetStateForCategory(workItemType, "InProgress"); err == nil {
+		return state, nil
+	}
+
+	// Fall back to well-known names for processes without state categories
+	states, err := client.GetWorkItemStates(workItemType)
+	if err != nil {
+		return "", err
+	}
+	for _, name := range inProgressStateNames {
+		for _, state := range states {
+			if strings.EqualFold(state, name) {
+				return state, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("could not determine an in-progress state for '%s' (available: %s)", workItemType, strings.Join(states, ", "))
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SOMUCHDOG/azb/internal/api/apitest"
+)
+
+func TestFindInProgressState(t *testing.T) {
+	tests := []struct {
+		name     string
+		category func(string, string) (string, error)
+		states   []string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "state category",
+			category: func(string, string) (string, error) { return "Doing", nil },
+			want:     "Doing",
+		},
+		{
+			name:     "well-known name without categories",
+			category: func(string, string) (string, error) { return "", errors.New("no categories") },
+			states:   []string{"New", "Active", "Closed"},
+			want:     "Active",
+		},
+		{
+			name:     "no in-progress state",
+			category: func(string, string) (string, error) { return "", errors.New("no categories") },
+			states:   []string{"Open", "Done"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := apitest.New()
+			client.GetStateForCategoryFunc = tt.category
+			client.GetWorkItemStatesFunc = func(string) ([]string, error) { return tt.states, nil }
+
+			got, err := findInProgressState(client, "Task")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findInProgressState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("findInProgressState() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/SOMUCHDOG/azb/internal/output"
 	"github.com/SOMUCHDOG/azb/internal/templates"
 )
 
@@ -29,19 +32,31 @@ var (
 	}
 
 	templateShowCmd = &cobra.Command{
-		Use:   "show <template-name>",
-		Short: "Show template details",
-		Long:  `Show the contents of a work item template.`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runTemplateShow,
+		Use:               "show <template-name>",
+		Short:             "Show template details",
+		Long:              `Show the contents of a work item template.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeTemplateNames),
+		RunE:              runTemplateShow,
 	}
 
 	templateDeleteCmd = &cobra.Command{
-		Use:   "delete <template-name>",
-		Short: "Delete a template",
-		Long:  `Delete a work item template.`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runTemplateDelete,
+		Use:               "delete <template-name>",
+		Short:             "Delete a template",
+		Long:              `Delete a work item template.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeLocalTemplateNames),
+		RunE:              runTemplateDelete,
+	}
+
+	templateMoveCmd = &cobra.Command{
+		Use:   "move <template-name> <folder>",
+		Short: "Move a template to another folder",
+		Long: `Move a template into another folder of the templates directory, keeping
+its file name. The folder is created if needed; use "." for the top level.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArgs(completeLocalTemplateNames, completeTemplateFolders),
+		RunE:              runTemplateMove,
 	}
 
 	templateSaveCmd = &cobra.Command{
@@ -58,6 +73,7 @@ func init() {
 	templateCmd.AddCommand(templateListCmd)
 	templateCmd.AddCommand(templateShowCmd)
 	templateCmd.AddCommand(templateDeleteCmd)
+	templateCmd.AddCommand(templateMoveCmd)
 	templateCmd.AddCommand(templateSaveCmd)
 
 	templateShowCmd.Flags().StringVarP(&templateFormatFlag, "format", "f", "yaml", "Output format (yaml, json)")
@@ -73,55 +89,123 @@ func init() {
 	templateSaveCmd.Flags().StringVar(&createTagsFlag, "tags", "", "Default tags")
 	templateSaveCmd.Flags().StringArrayVar(&createFieldsFlag, "field", []string{}, "Custom field in format 'FieldName=value'")
 
+	//nolint:errcheck // Flags are registered above
+	templateSaveCmd.RegisterFlagCompletionFunc("type", completeWorkItemTypes)
+
 	//nolint:errcheck // Flag requirement error is non-critical at init time
 	templateSaveCmd.MarkFlagRequired("type")
 }
 
+// templateListItem is a template as 'azb template list' outputs it
+type templateListItem struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Fields      int    `json:"fields"`
+	Team        bool   `json:"team"`
+	Error       string `json:"error,omitempty"`
+}
+
 func runTemplateList(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd, withOutputFormats("table")...)
+	if err != nil {
+		return err
+	}
+
 	// Get templates directory
 	templatesDir, err := templates.GetTemplatesDir()
 	if err != nil {
 		return fmt.Errorf("failed to get templates directory: %w", err)
 	}
 
-	templatesList, err := templates.List()
+	templatesList, problems, err := templates.List()
 	if err != nil {
 		return fmt.Errorf("failed to list templates: %w", err)
 	}
+	teamList, teamProblems, err := templates.ListTeam()
+	if err != nil {
+		return fmt.Errorf("failed to list team templates: %w", err)
+	}
+	problems = append(problems, teamProblems...)
+
+	if format != "table" {
+		items := []templateListItem{}
+		for _, tmpl := range append(templatesList, teamList...) {
+			items = append(items, templateListItem{
+				Name: tmpl.Name, Type: tmpl.Type, Description: tmpl.Description,
+				Fields: len(tmpl.Fields), Team: templates.IsTeam(tmpl.Name),
+			})
+		}
+		for _, problem := range problems {
+			name := templateName(problem.Path)
+			items = append(items, templateListItem{Name: name, Team: templates.IsTeam(name), Error: problem.Err.Error()})
+		}
+		table := output.Table{Headers: []string{"Name", "Type", "Description", "Fields", "Error"}}
+		for _, item := range items {
+			table.Rows = append(table.Rows, []string{item.Name, item.Type, item.Description, strconv.Itoa(item.Fields), item.Error})
+		}
+		return writeResult(format, items, table)
+	}
 
 	fmt.Printf("Templates directory: %s\n\n", templatesDir)
 
-	if len(templatesList) == 0 {
+	if len(templatesList) == 0 && len(teamList) == 0 && len(problems) == 0 {
 		fmt.Println("No templates found")
 		fmt.Println("\nCreate a template with: azb template save <name> --type <type> [options]")
 		return nil
 	}
 
-	fmt.Println("Available Templates:")
-	fmt.Println()
+	if len(templatesList) > 0 {
+		fmt.Println("Available Templates:")
+		fmt.Println()
+		printTemplates(templatesList)
+	}
+
+	if len(teamList) > 0 {
+		fmt.Println("Team Templates (read-only, updated by 'azb template sync'):")
+		fmt.Println()
+		printTemplates(teamList)
+	}
 
-	for _, tmpl := range templatesList {
+	if len(problems) > 0 {
+		fmt.Println("Broken Templates (cannot be used until fixed):")
+		fmt.Println()
+		for _, problem := range problems {
+			fmt.Printf("  %s\n    Error: %v\n\n", templateName(problem.Path), problem.Err)
+		}
+	}
+
+	fmt.Printf("Total: %d templates\n", len(templatesList)+len(teamList)+len(problems))
+	fmt.Println("\nUse 'azb template show <name>' to view template details")
+	fmt.Println("Use 'azb create --template <name>' to create a work item from a template")
+
+	return nil
+}
+
+// printTemplates prints the name, description, type and field count of templates
+func printTemplates(list []*templates.Template) {
+	for _, tmpl := range list {
 		fmt.Printf("  %s\n", tmpl.Name)
 		if tmpl.Description != "" {
 			fmt.Printf("    Description: %s\n", tmpl.Description)
 		}
-		fmt.Printf("    Type: %s\n", tmpl.Type)
+		if tmpl.Type != "" {
+			fmt.Printf("    Type: %s\n", tmpl.Type)
+		}
 		if len(tmpl.Fields) > 0 {
 			fmt.Printf("    Fields: %d configured\n", len(tmpl.Fields))
 		}
 		fmt.Println()
 	}
-
-	fmt.Printf("Total: %d templates\n", len(templatesList))
-	fmt.Println("\nUse 'azb template show <name>' to view template details")
-	fmt.Println("Use 'azb create --template <name>' to create a work item from a template")
-
-	return nil
 }
 
 func runTemplateShow(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
+	if err := resolveFormat(cmd, "format", &templateFormatFlag, "yaml", "json"); err != nil {
+		return err
+	}
+
 	template, err := templates.Load(name)
 	if err != nil {
 		return err
@@ -174,6 +258,19 @@ func runTemplateDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runTemplateMove(cmd *cobra.Command, args []string) error {
+	name, folder := args[0], args[1]
+
+	newName, err := templates.Move(name, folder)
+	if err != nil {
+		return fmt.Errorf("failed to move template: %w", err)
+	}
+
+	fmt.Printf("✓ Template '%s' moved to '%s'\n", name, strings.TrimSuffix(newName, filepath.Ext(newName)))
+
+	return nil
+}
+
 func runTemplateSave(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
@@ -2,8 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
 
 	"github.com/spf13/cobra"
 
@@ -12,19 +10,21 @@ import (
 
 var (
 	templateEditCmd = &cobra.Command{
-		Use:   "edit <template-name>",
-		Short: "Edit a template file",
-		Long:  `Open a template file in your default editor ($EDITOR or $VISUAL).`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runTemplateEdit,
+		Use:               "edit <template-name>",
+		Short:             "Edit a template file",
+		Long:              `Open a template file in your default editor ($EDITOR or $VISUAL).`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeLocalTemplateNames),
+		RunE:              runTemplateEdit,
 	}
 
 	templatePathCmd = &cobra.Command{
-		Use:   "path [template-name]",
-		Short: "Show template file path",
-		Long:  `Show the path to a template file or the templates directory.`,
-		Args:  cobra.MaximumNArgs(1),
-		RunE:  runTemplatePath,
+		Use:               "path [template-name]",
+		Short:             "Show template file path",
+		Long:              `Show the path to a template file or the templates directory.`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeArgs(completeTemplateNames),
+		RunE:              runTemplatePath,
 	}
 )
 
@@ -35,6 +35,9 @@ func init() {
 
 func runTemplateEdit(cmd *cobra.Command, args []string) error {
 	name := args[0]
+	if templates.IsTeam(name) {
+		return templates.ErrTeamReadOnly
+	}
 
 	// Check if template exists
 	exists, err := templates.Exists(name)
@@ -52,23 +55,8 @@ func runTemplateEdit(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Get editor from environment
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = os.Getenv("VISUAL")
-	}
-	if editor == "" {
-		editor = "vi" // fallback to vi
-	}
-
-	// Open editor
-	editorCmd := exec.Command(editor, path)
-	editorCmd.Stdin = os.Stdin
-	editorCmd.Stdout = os.Stdout
-	editorCmd.Stderr = os.Stderr
-
-	if err := editorCmd.Run(); err != nil {
-		return fmt.Errorf("failed to open editor: %w", err)
+	if err := openEditor(path); err != nil {
+		return err
 	}
 
 	fmt.Printf("\n✓ Template '%s' updated\n", name)
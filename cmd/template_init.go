@@ -10,11 +10,12 @@ import (
 
 var (
 	templateInitCmd = &cobra.Command{
-		Use:   "init <template-name> <work-item-type>",
-		Short: "Create a new template file with example fields",
-		Long:  `Create a new template file with all common fields as examples that you can customize.`,
-		Args:  cobra.ExactArgs(2),
-		RunE:  runTemplateInit,
+		Use:               "init <template-name> <work-item-type>",
+		Short:             "Create a new template file with example fields",
+		Long:              `Create a new template file with all common fields as examples that you can customize.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArgs(nil, completeWorkItemTypes),
+		RunE:              runTemplateInit,
 	}
 )
 
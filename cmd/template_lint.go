@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+var (
+	templateLintCmd = &cobra.Command{
+		Use:   "lint [template-name...]",
+		Short: "Check template files for mistakes",
+		Long: `Check template files without contacting Azure DevOps: YAML that cannot be
+parsed, keys the template format does not know, such as a misspelt "relations",
+and a missing work item type or child title. Unknown keys are ignored when a
+template is used, a template without a type takes the one given with --type and
+a child may set its title in fields as System.Title, so these are reported as
+warnings.
+
+Without names every template is checked, the team's included. The command fails
+when a template cannot be used. Use 'azb template validate' to check the fields
+against the work item type in Azure DevOps.
+
+Examples:
+  azb template lint
+  azb template lint bugs/regression`,
+		ValidArgsFunction: completeTemplateNames,
+		SilenceUsage:      true,
+		RunE:              runTemplateLint,
+	}
+)
+
+func init() {
+	templateCmd.AddCommand(templateLintCmd)
+}
+
+// templateLint is the result of checking one template file
+type templateLint struct {
+	name     string
+	warnings []string
+	err      error
+}
+
+// templateLintResult is the check of a template as 'azb template lint' outputs it
+type templateLintResult struct {
+	Name     string   `json:"name"`
+	Warnings []string `json:"warnings,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+func runTemplateLint(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd, withOutputFormats("text")...)
+	if err != nil {
+		return err
+	}
+
+	var results []templateLint
+	if len(args) > 0 {
+		for _, name := range args {
+			warnings, err := templates.Lint(name)
+			results = append(results, templateLint{name: name, warnings: warnings, err: err})
+		}
+	} else {
+		nodes, problems, err := templates.ListTree()
+		if err != nil {
+			return fmt.Errorf("failed to list templates: %w", err)
+		}
+		results = lintNodes(nodes)
+		for _, problem := range problems {
+			results = append(results, templateLint{name: templateName(problem.Path), err: problem.Err})
+		}
+	}
+
+	if format != "text" {
+		return writeTemplateLint(format, results)
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No templates found")
+		return nil
+	}
+
+	broken, warned := 0, 0
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			broken++
+			fmt.Printf("✗ %s: %v\n", result.name, result.err)
+		case len(result.warnings) > 0:
+			warned++
+			fmt.Printf("⚠ %s\n", result.name)
+		default:
+			fmt.Printf("✓ %s\n", result.name)
+		}
+		for _, warning := range result.warnings {
+			fmt.Printf("    %s\n", warning)
+		}
+	}
+
+	fmt.Printf("\nChecked %d template(s): %d with errors, %d with warnings\n", len(results), broken, warned)
+	if broken > 0 {
+		return fmt.Errorf("%d template(s) cannot be used", broken)
+	}
+	return nil
+}
+
+// writeTemplateLint writes the check results as JSON, YAML or a table, and
+// fails like the text output when a template cannot be used
+func writeTemplateLint(format string, results []templateLint) error {
+	items := []templateLintResult{}
+	table := output.Table{Headers: []string{"Template", "Status", "Problems"}}
+	broken := 0
+	for _, result := range results {
+		item := templateLintResult{Name: result.name, Warnings: result.warnings}
+		status, problems := "ok", result.warnings
+		switch {
+		case result.err != nil:
+			broken++
+			item.Error = result.err.Error()
+			status, problems = "error", append([]string{item.Error}, problems...)
+		case len(result.warnings) > 0:
+			status = "warning"
+		}
+		items = append(items, item)
+		table.Rows = append(table.Rows, []string{result.name, status, strings.Join(problems, "; ")})
+	}
+	if err := writeResult(format, items, table); err != nil {
+		return err
+	}
+	if broken > 0 {
+		return fmt.Errorf("%d template(s) cannot be used", broken)
+	}
+	return nil
+}
+
+// lintNodes returns the check results of the template files in a template tree
+func lintNodes(nodes []*templates.TemplateNode) []templateLint {
+	var results []templateLint
+	for _, node := range nodes {
+		if node.IsDir {
+			results = append(results, lintNodes(node.Children)...)
+			continue
+		}
+		results = append(results, templateLint{name: templateName(node.Path), warnings: node.Warnings, err: node.Err})
+	}
+	return results
+}
+
+// templateName returns the name a template file is used by, e.g. "bugs/regression"
+func templateName(path string) string {
+	path = filepath.ToSlash(path)
+	return strings.TrimSuffix(strings.TrimSuffix(path, ".yaml"), ".yml")
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+func TestLintNodes(t *testing.T) {
+	nodes := []*templates.TemplateNode{
+		{Name: "bugs", Path: "bugs", IsDir: true, Children: []*templates.TemplateNode{
+			{Name: "regression", Path: "bugs/regression.yaml", Warnings: []string{"line 3: unknown key 'feilds'"}},
+		}},
+		{Name: "broken", Path: "broken.yml", Err: errors.New("failed to parse template")},
+		{Name: "task", Path: "task.yaml"},
+	}
+
+	want := []string{
+		"bugs/regression [line 3: unknown key 'feilds'] <nil>",
+		"broken [] failed to parse template",
+		"task [] <nil>",
+	}
+	results := lintNodes(nodes)
+	if len(results) != len(want) {
+		t.Fatalf("lintNodes() returned %d results, want %d", len(results), len(want))
+	}
+	for i, result := range results {
+		if got := fmt.Sprintf("%s %v %v", result.name, result.warnings, result.err); got != want[i] {
+			t.Errorf("result %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
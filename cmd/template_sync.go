@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+var (
+	templateSyncRepoFlag string
+
+	templateSyncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Pull the team's shared templates",
+		Long: `Pull the team's shared templates from a git repository. The first sync
+clones it into ~/.azure-boards-cli/team-templates (or team_templates_dir) and
+remembers the repository; later syncs pull the latest changes.
+
+Team templates are read-only and named with the @team/ prefix, e.g.
+'azb create --template @team/bug'. They are listed alongside your own in
+'azb template list' and in the dashboard's Templates tab.
+
+To use an org-level shared folder instead of a repository, set team_templates_dir:
+  azb config set team_templates_dir /mnt/shared/boards-templates
+
+Examples:
+  azb template sync --repo git@ssh.dev.azure.com:v3/org/project/boards-templates
+  azb template sync`,
+		Args: cobra.NoArgs,
+		RunE: runTemplateSync,
+	}
+)
+
+func init() {
+	templateCmd.AddCommand(templateSyncCmd)
+	templateSyncCmd.Flags().StringVar(&templateSyncRepoFlag, "repo", "", "Git repository of the team templates (saved for later syncs)")
+}
+
+func runTemplateSync(cmd *cobra.Command, args []string) error {
+	dir, err := templates.GetTeamTemplatesDir()
+	if err != nil {
+		return err
+	}
+
+	repo := strings.TrimSpace(templateSyncRepoFlag)
+	if repo == "" {
+		repo = viper.GetString("team_templates_repo")
+	}
+	if repo == "" {
+		if viper.GetString("team_templates_dir") != "" {
+			fmt.Printf("Team templates are read from %s; there is nothing to sync\n", dir)
+			return nil
+		}
+		return fmt.Errorf("no team templates repository; pass --repo <url> or set team_templates_dir to a shared folder")
+	}
+
+	if err := syncTeamRepo(repo, dir); err != nil {
+		return err
+	}
+
+	// Remember the repository for the next sync
+	if templateSyncRepoFlag != "" && repo != viper.GetString("team_templates_repo") {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg.TeamTemplatesRepo = repo
+		if err := config.Save(cfg, "team_templates_repo"); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+
+	list, problems, err := templates.ListTeam()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Synced %d team templates from %s\n", len(list), repo)
+	if len(problems) > 0 {
+		fmt.Printf("⚠ %d team template(s) cannot be used; run 'azb template lint' for details\n", len(problems))
+	}
+	return nil
+}
+
+// syncTeamRepo clones repo into dir, or pulls it when dir is already its clone
+func syncTeamRepo(repo, dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		origin, err := runGitIn(dir, "remote", "get-url", "origin")
+		if err != nil {
+			return err
+		}
+		if origin != repo {
+			return fmt.Errorf("%s is a clone of %s, not %s; remove it to sync from another repository", dir, origin, repo)
+		}
+		fmt.Printf("Pulling team templates into %s...\n", dir)
+		_, err = runGitIn(dir, "pull", "--ff-only")
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%s is not empty and not a git clone; move it or set team_templates_dir to another folder", dir)
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(dir), err)
+	}
+
+	fmt.Printf("Cloning team templates into %s...\n", dir)
+	_, err = runGit("clone", "--depth", "1", repo, dir)
+	return err
+}
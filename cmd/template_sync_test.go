@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestSyncTeamRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	commit := func(name string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, name), []byte("name: "+name+"\ntype: Bug\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		for _, args := range [][]string{
+			{"add", name},
+			{"-c", "user.name=azb", "-c", "user.email=azb@example.com", "commit", "-q", "-m", name},
+		} {
+			if _, err := runGitIn(repo, args...); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if _, err := runGitIn(repo, "init", "-q"); err != nil {
+		t.Fatal(err)
+	}
+	commit("bug.yaml")
+
+	dir := filepath.Join(t.TempDir(), "team-templates")
+	if err := syncTeamRepo("file://"+repo, dir); err != nil {
+		t.Fatalf("first sync error: %v", err)
+	}
+	commit("epic.yaml")
+	if err := syncTeamRepo("file://"+repo, dir); err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	for _, name := range []string{"bug.yaml", "epic.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s not synced: %v", name, err)
+		}
+	}
+
+	if err := syncTeamRepo("file:///elsewhere", dir); err == nil {
+		t.Error("expected an error syncing another repository into the clone")
+	}
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+var (
+	templateValidateCmd = &cobra.Command{
+		Use:   "validate <template-name>",
+		Short: "Validate a template against the work item type schema",
+		Long: `Validate a template against the live work item type definition in Azure DevOps.
+
+Checks that every field in the template exists on the work item type, that
+required fields are present, and that picklist fields use allowed values.
+All problems are reported at once.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeTemplateNames),
+		RunE:              runTemplateValidate,
+	}
+)
+
+func init() {
+	templateCmd.AddCommand(templateValidateCmd)
+}
+
+// templateValidation is the result of 'azb template validate' in a data format
+type templateValidation struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type,omitempty"`
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems"`
+}
+
+func runTemplateValidate(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd, withOutputFormats("text")...)
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+
+	template, err := templates.Load(name)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	// Cache field definitions per work item type (children often share a type)
+	schemas := make(map[string]map[string]workitemtracking.WorkItemTypeFieldWithReferences)
+	getSchema := func(workItemType string) (map[string]workitemtracking.WorkItemTypeFieldWithReferences, error) {
+		if schema, ok := schemas[workItemType]; ok {
+			return schema, nil
+		}
+		fields, err := client.GetWorkItemTypeFields(workItemType)
+		if err != nil {
+			return nil, err
+		}
+		schema := make(map[string]workitemtracking.WorkItemTypeFieldWithReferences)
+		if fields != nil {
+			for _, field := range *fields {
+				if field.ReferenceName != nil {
+					schema[*field.ReferenceName] = field
+				}
+			}
+		}
+		schemas[workItemType] = schema
+		return schema, nil
+	}
+
+	var problems []string
+
+	if template.Type == "" {
+		problems = append(problems, "template has no work item type")
+	} else {
+		schema, err := getSchema(template.Type)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, validateTemplateFields("", template.Fields, schema, true)...)
+	}
+
+	if template.Relations != nil {
+		for i, child := range template.Relations.Children {
+			childType := child.Type
+			if childType == "" {
+				childType = "Task" // Default child type
+			}
+
+			prefix := fmt.Sprintf("child %d (%s): ", i+1, child.Title)
+			if child.Title == "" {
+				problems = append(problems, prefix+"missing title")
+			}
+
+			schema, err := getSchema(childType)
+			if err != nil {
+				problems = append(problems, prefix+err.Error())
+				continue
+			}
+			// Children inherit area, iteration and custom fields from the parent,
+			// so only check that the fields they do set are valid
+			problems = append(problems, validateTemplateFields(prefix, child.Fields, schema, false)...)
+		}
+	}
+
+	if format != "text" {
+		table := output.Table{Headers: []string{"Problem"}}
+		for _, problem := range problems {
+			table.Rows = append(table.Rows, []string{problem})
+		}
+		result := templateValidation{Name: name, Type: template.Type, Valid: len(problems) == 0, Problems: append([]string{}, problems...)}
+		if err := writeResult(format, result, table); err != nil || result.Valid {
+			return err
+		}
+		return fmt.Errorf("template '%s' failed validation", name)
+	}
+
+	if len(problems) == 0 {
+		fmt.Printf("✓ Template '%s' is valid for type '%s'\n", name, template.Type)
+		return nil
+	}
+
+	fmt.Printf("✗ Template '%s' has %d problem(s):\n", name, len(problems))
+	for _, problem := range problems {
+		fmt.Printf("  - %s\n", problem)
+	}
+
+	return fmt.Errorf("template '%s' failed validation", name)
+}
+
+// validateTemplateFields checks template field values against a work item type schema
+// and returns a description of every problem found
+func validateTemplateFields(prefix string, fields map[string]interface{}, schema map[string]workitemtracking.WorkItemTypeFieldWithReferences, checkRequired bool) []string {
+	var problems []string
+
+	// Sort for stable output
+	names := make([]string, 0, len(fields))
+	for fieldName := range fields {
+		names = append(names, fieldName)
+	}
+	sort.Strings(names)
+
+	for _, fieldName := range names {
+		field, ok := schema[fieldName]
+		if !ok {
+			problem := fmt.Sprintf("%sfield '%s' does not exist on this work item type", prefix, fieldName)
+			if suggestion := suggestFieldName(fieldName, schema); suggestion != "" {
+				problem += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+			}
+			problems = append(problems, problem)
+			continue
+		}
+
+		if field.AllowedValues == nil || len(*field.AllowedValues) == 0 {
+			continue
+		}
+
+		value := fmt.Sprintf("%v", fields[fieldName])
+		var allowed []string
+		legal := false
+		for _, allowedValue := range *field.AllowedValues {
+			allowedStr := fmt.Sprintf("%v", allowedValue)
+			allowed = append(allowed, allowedStr)
+			if allowedStr == value {
+				legal = true
+			}
+		}
+		if !legal {
+			problems = append(problems, fmt.Sprintf("%sfield '%s' has value '%s', allowed values: %s",
+				prefix, fieldName, value, strings.Join(allowed, ", ")))
+		}
+	}
+
+	if checkRequired {
+		var required []string
+		for refName, field := range schema {
+			if field.AlwaysRequired == nil || !*field.AlwaysRequired {
+				continue
+			}
+			// Standard fields are filled in by 'azb create' (flags, prompts or config defaults)
+			if isStandardField(refName) || field.DefaultValue != nil {
+				continue
+			}
+			if _, ok := fields[refName]; !ok {
+				required = append(required, refName)
+			}
+		}
+		sort.Strings(required)
+		for _, refName := range required {
+			problems = append(problems, fmt.Sprintf("%srequired field '%s' is missing", prefix, refName))
+		}
+	}
+
+	return problems
+}
+
+// suggestFieldName finds a schema field whose reference or display name matches case-insensitively
+func suggestFieldName(fieldName string, schema map[string]workitemtracking.WorkItemTypeFieldWithReferences) string {
+	for refName, field := range schema {
+		if strings.EqualFold(refName, fieldName) {
+			return refName
+		}
+		if field.Name != nil && strings.EqualFold(*field.Name, fieldName) {
+			return refName
+		}
+	}
+	return ""
+}
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/wiql"
+)
+
+// Scheduling fields updated by 'azb time log' and summed by 'azb time report'
+const (
+	originalEstimateField = "Microsoft.VSTS.Scheduling.OriginalEstimate"
+	completedWorkField    = "Microsoft.VSTS.Scheduling.CompletedWork"
+	remainingWorkField    = "Microsoft.VSTS.Scheduling.RemainingWork"
+)
+
+var (
+	timeHoursFlag  float64
+	timeSprintFlag string
+	timeTeamFlag   string
+	timeFormatFlag string
+
+	timeCmd = &cobra.Command{
+		Use:   "time",
+		Short: "Track time spent on work items",
+		Long: `Log hours against work items and report time per person, using the
+Completed Work and Remaining Work fields of the Agile, Scrum and CMMI processes.`,
+	}
+
+	timeLogCmd = &cobra.Command{
+		Use:   "log <id>",
+		Short: "Log hours spent on a work item",
+		Long: `Add hours to Completed Work and take them off Remaining Work (not below
+zero) in a single update. If someone else changes the work item at the same
+time, the update is retried with the new values, so no hours are lost.
+
+Examples:
+  azb time log 1234 --hours 2
+  azb time log 1234 -H 0.5`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: mutatingAnnotations,
+		RunE:        runTimeLog,
+	}
+
+	timeReportCmd = &cobra.Command{
+		Use:   "report",
+		Short: "Summarize estimated, completed and remaining work per person",
+		Long: `Sum Original Estimate, Completed Work and Remaining Work of the work items in
+a sprint, grouped by the person they are assigned to.
+
+Examples:
+  azb time report
+  azb time report --sprint "MyProject\Sprint 42"
+  azb time report --team "Platform Team" --format json`,
+		Args: cobra.NoArgs,
+		RunE: runTimeReport,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(timeCmd)
+	timeCmd.AddCommand(timeLogCmd)
+	timeCmd.AddCommand(timeReportCmd)
+
+	timeLogCmd.Flags().Float64VarP(&timeHoursFlag, "hours", "H", 0, "Hours spent (required)")
+	//nolint:errcheck // Flag is registered above
+	timeLogCmd.MarkFlagRequired("hours")
+
+	timeReportCmd.Flags().StringVar(&timeSprintFlag, "sprint", "current", "Sprint: current or an iteration path")
+	timeReportCmd.Flags().StringVar(&timeTeamFlag, "team", "", "Team used for the current sprint (default: the project's default team)")
+	timeReportCmd.Flags().StringVarP(&timeFormatFlag, "format", "f", "table", "Output format (table, json, yaml, csv, tsv, ids)")
+}
+
+func runTimeLog(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+	if timeHoursFlag <= 0 {
+		return fmt.Errorf("--hours must be greater than zero")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	var workItem *workitemtracking.WorkItem
+	var completed [2]float64
+	var remaining *[2]float64
+	_, err = api.UpdateFromCurrent(client, id, func(current *workitemtracking.WorkItem) (map[string]interface{}, error) {
+		if current.Fields == nil {
+			return nil, fmt.Errorf("work item %d has no fields", id)
+		}
+		var fields map[string]interface{}
+		workItem = current
+		fields, completed, remaining = logWorkFields(*current.Fields, timeHoursFlag)
+		return fields, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Logged %sh on #%d: %s\n", formatHours(timeHoursFlag), id, workItemTitle(workItem))
+	fmt.Printf("  Completed Work: %s → %s\n", formatHours(completed[0]), formatHours(completed[1]))
+	if remaining != nil {
+		fmt.Printf("  Remaining Work: %s → %s\n", formatHours(remaining[0]), formatHours(remaining[1]))
+	}
+	return nil
+}
+
+// logWorkFields returns the field updates for logging hours, along with the
+// old and new completed work and, if the work item tracks it, remaining work
+func logWorkFields(fields map[string]interface{}, hours float64) (map[string]interface{}, [2]float64, *[2]float64) {
+	completed, _ := numberField(fields, completedWorkField)
+	newCompleted := completed + hours
+	updates := map[string]interface{}{completedWorkField: newCompleted}
+
+	remaining, ok := numberField(fields, remainingWorkField)
+	if !ok {
+		return updates, [2]float64{completed, newCompleted}, nil
+	}
+	newRemaining := math.Max(remaining-hours, 0)
+	updates[remainingWorkField] = newRemaining
+	return updates, [2]float64{completed, newCompleted}, &[2]float64{remaining, newRemaining}
+}
+
+// timePerson is one row of 'azb time report'
+type timePerson struct {
+	Name      string  `json:"name"`
+	Items     int     `json:"items"`
+	Estimate  float64 `json:"originalEstimate"`
+	Completed float64 `json:"completedWork"`
+	Remaining float64 `json:"remainingWork"`
+}
+
+// timeReport is the output of 'azb time report'
+type timeReport struct {
+	Sprint string        `json:"sprint"`
+	People []*timePerson `json:"people"`
+	Total  timePerson    `json:"total"`
+}
+
+func runTimeReport(cmd *cobra.Command, args []string) error {
+	formats := withOutputFormats("table")
+	if err := resolveFormat(cmd, "format", &timeFormatFlag, formats...); err != nil {
+		return err
+	}
+	if !slices.Contains(formats, timeFormatFlag) {
+		return fmt.Errorf("unsupported format: %s", timeFormatFlag)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	path := timeSprintFlag
+	if path == "" || strings.EqualFold(path, "current") || path == "@current" {
+		iteration, err := client.GetCurrentIteration(timeTeamFlag)
+		if err != nil {
+			return err
+		}
+		if iteration.Path == nil {
+			return fmt.Errorf("current iteration has no path")
+		}
+		path = *iteration.Path
+	}
+
+	query := wiql.Select().Where(
+		wiql.Field("System.TeamProject").Eq(wiql.Project),
+		wiql.Field("System.IterationPath").Eq(wiql.String(path)),
+		wiql.Field("System.State").Ne(wiql.String("Removed")),
+	)
+	items, err := client.ListWorkItems(query.String(), 0)
+	if err != nil {
+		return fmt.Errorf("failed to load sprint work items: %w", err)
+	}
+
+	report := buildTimeReport(path, *items)
+	if timeFormatFlag != "table" {
+		return writeResult(timeFormatFlag, report, timeReportTable(report))
+	}
+	printTimeReport(report)
+	return nil
+}
+
+// buildTimeReport sums the scheduling fields of work items per assignee.
+// Work items without any of the fields, such as most backlog items, are skipped.
+func buildTimeReport(sprint string, items []workitemtracking.WorkItem) *timeReport {
+	report := &timeReport{Sprint: sprint, People: []*timePerson{}, Total: timePerson{Name: "Total"}}
+	byName := make(map[string]*timePerson)
+
+	for _, wi := range items {
+		if wi.Fields == nil {
+			continue
+		}
+		estimate, hasEstimate := numberField(*wi.Fields, originalEstimateField)
+		completed, hasCompleted := numberField(*wi.Fields, completedWorkField)
+		remaining, hasRemaining := numberField(*wi.Fields, remainingWorkField)
+		if !hasEstimate && !hasCompleted && !hasRemaining {
+			continue
+		}
+
+		name := model.FieldString(wi.Fields, "System.AssignedTo")
+		if name == "" {
+			name = "Unassigned"
+		}
+		person, ok := byName[name]
+		if !ok {
+			person = &timePerson{Name: name}
+			byName[name] = person
+			report.People = append(report.People, person)
+		}
+
+		for _, p := range []*timePerson{person, &report.Total} {
+			p.Items++
+			p.Estimate += estimate
+			p.Completed += completed
+			p.Remaining += remaining
+		}
+	}
+
+	sort.Slice(report.People, func(i, j int) bool {
+		return strings.ToLower(report.People[i].Name) < strings.ToLower(report.People[j].Name)
+	})
+	return report
+}
+
+// timeReportTable lists the hours per person for the tabular formats
+func timeReportTable(report *timeReport) output.Table {
+	table := output.Table{Headers: []string{"Person", "Items", "Estimate", "Completed", "Remaining"}}
+	for _, p := range report.People {
+		table.Rows = append(table.Rows, []string{p.Name, strconv.Itoa(p.Items), formatHours(p.Estimate), formatHours(p.Completed), formatHours(p.Remaining)})
+	}
+	return table
+}
+
+// printTimeReport prints the report as a table with a total row
+func printTimeReport(report *timeReport) {
+	fmt.Printf("Sprint: %s\n\n", report.Sprint)
+	if len(report.People) == 0 {
+		fmt.Println("No work items with estimated, completed or remaining work")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Person\tItems\tEstimate\tCompleted\tRemaining\t")
+	row := func(p *timePerson) {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t\n", p.Name, p.Items, formatHours(p.Estimate), formatHours(p.Completed), formatHours(p.Remaining))
+	}
+	for _, p := range report.People {
+		row(p)
+	}
+	fmt.Fprintln(w, "\t\t\t\t\t")
+	row(&report.Total)
+	w.Flush()
+}
+
+// numberField reads a numeric field; the service returns numbers as float64
+func numberField(fields map[string]interface{}, name string) (float64, bool) {
+	switch v := fields[name].(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// formatHours prints hours with at most two decimals and no trailing zeros
+func formatHours(hours float64) string {
+	return strconv.FormatFloat(math.Round(hours*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestLogWorkFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		fields        map[string]interface{}
+		hours         float64
+		wantCompleted float64
+		wantRemaining interface{}
+	}{
+		{"both fields", map[string]interface{}{completedWorkField: 3.0, remainingWorkField: 5.0}, 2, 5, 3.0},
+		{"remaining stops at zero", map[string]interface{}{completedWorkField: 3.0, remainingWorkField: 1.0}, 2, 5, 0.0},
+		{"no completed work yet", map[string]interface{}{remainingWorkField: 4.0}, 1.5, 1.5, 2.5},
+		{"no remaining work field", map[string]interface{}{}, 2, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates, _, _ := logWorkFields(tt.fields, tt.hours)
+			if got := updates[completedWorkField]; got != tt.wantCompleted {
+				t.Errorf("completed work = %v, want %v", got, tt.wantCompleted)
+			}
+			if got := updates[remainingWorkField]; got != tt.wantRemaining {
+				t.Errorf("remaining work = %v, want %v", got, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestBuildTimeReport(t *testing.T) {
+	ada := map[string]interface{}{"displayName": "Ada"}
+	items := []workitemtracking.WorkItem{
+		testWorkItem(1, map[string]interface{}{"System.AssignedTo": ada, originalEstimateField: 8.0, completedWorkField: 6.0, remainingWorkField: 2.0}),
+		testWorkItem(2, map[string]interface{}{"System.AssignedTo": ada, completedWorkField: 1.5}),
+		testWorkItem(3, map[string]interface{}{remainingWorkField: 4.0}),
+		testWorkItem(4, map[string]interface{}{"System.AssignedTo": ada, "System.Title": "Story without hours"}),
+	}
+
+	report := buildTimeReport("Sprint 1", items)
+	want := []timePerson{
+		{Name: "Ada", Items: 2, Estimate: 8, Completed: 7.5, Remaining: 2},
+		{Name: "Unassigned", Items: 1, Remaining: 4},
+	}
+	if len(report.People) != len(want) {
+		t.Fatalf("got %d people, want %d", len(report.People), len(want))
+	}
+	for i := range want {
+		if *report.People[i] != want[i] {
+			t.Errorf("People[%d] = %+v, want %+v", i, *report.People[i], want[i])
+		}
+	}
+	if total := (timePerson{Name: "Total", Items: 3, Estimate: 8, Completed: 7.5, Remaining: 6}); report.Total != total {
+		t.Errorf("Total = %+v, want %+v", report.Total, total)
+	}
+}
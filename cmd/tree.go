@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
+)
+
+var (
+	treeDepthFlag int
+
+	treeCmd = &cobra.Command{
+		Use:   "tree <id>",
+		Short: "Show the parent/child hierarchy of a work item",
+		Long: `Print the hierarchy of a work item as an indented tree with types and states.
+
+The tree starts at the topmost parent (e.g. the Epic) and shows every
+descendant of the given work item. Each level is fetched in a single
+batched request.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runTree,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(treeCmd)
+
+	treeCmd.Flags().IntVar(&treeDepthFlag, "depth", 0, "Maximum levels of children to show (0 for unlimited)")
+}
+
+// treeNode is a work item with its loaded children
+type treeNode struct {
+	workItem workitemtracking.WorkItem
+	children []*treeNode
+}
+
+// treeEntry is a work item of a tree as 'azb tree' outputs it
+type treeEntry struct {
+	ID       int         `json:"id"`
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	State    string      `json:"state"`
+	Children []treeEntry `json:"children,omitempty"`
+}
+
+func runTree(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd, withOutputFormats("text")...)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	items, err := client.GetWorkItems([]int{id})
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("work item %d not found", id)
+	}
+	target := &treeNode{workItem: items[0]}
+
+	// Walk up to the topmost parent
+	var ancestors []workitemtracking.WorkItem
+	seen := map[int]bool{id: true}
+	current := items[0]
+	for {
+		parentIDs := relatedIDs(current, api.ParentLinkRelation)
+		if len(parentIDs) == 0 || seen[parentIDs[0]] {
+			break
+		}
+		seen[parentIDs[0]] = true
+
+		parents, err := client.GetWorkItems(parentIDs[:1])
+		if err != nil {
+			return err
+		}
+		if len(parents) == 0 {
+			break
+		}
+		ancestors = append([]workitemtracking.WorkItem{parents[0]}, ancestors...)
+		current = parents[0]
+	}
+
+	// Load descendants one level at a time
+	if err := loadTreeChildren(client, []*treeNode{target}, treeDepthFlag, seen); err != nil {
+		return err
+	}
+
+	// Ancestors form a single chain down to the requested work item
+	root := target
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		root = &treeNode{workItem: ancestors[i], children: []*treeNode{root}}
+	}
+	if format != "text" {
+		table := output.Table{Headers: []string{"ID", "Parent", "Type", "Title", "State"}}
+		tree := treeEntryOf(root)
+		addTreeRows(&table, tree, "")
+		return writeResult(format, tree, table)
+	}
+	printTree(root, id, "", true, true)
+
+	return nil
+}
+
+// treeEntryOf returns a node and its children as they are output
+func treeEntryOf(node *treeNode) treeEntry {
+	entry := treeEntry{
+		Type:  model.FieldString(node.workItem.Fields, "System.WorkItemType"),
+		Title: model.FieldString(node.workItem.Fields, "System.Title"),
+		State: model.FieldString(node.workItem.Fields, "System.State"),
+	}
+	if node.workItem.Id != nil {
+		entry.ID = *node.workItem.Id
+	}
+	for _, child := range node.children {
+		entry.Children = append(entry.Children, treeEntryOf(child))
+	}
+	return entry
+}
+
+// addTreeRows adds a work item and its descendants to a table, one row per
+// work item with the ID of its parent
+func addTreeRows(table *output.Table, entry treeEntry, parent string) {
+	id := strconv.Itoa(entry.ID)
+	table.Rows = append(table.Rows, []string{id, parent, entry.Type, entry.Title, entry.State})
+	for _, child := range entry.Children {
+		addTreeRows(table, child, id)
+	}
+}
+
+// loadTreeChildren fetches the children of all nodes in a level with one batched request,
+// then moves on to the next level until maxDepth is reached (0 for unlimited)
+func loadTreeChildren(client api.APIClient, level []*treeNode, maxDepth int, seen map[int]bool) error {
+	for depth := 1; len(level) > 0 && (maxDepth == 0 || depth <= maxDepth); depth++ {
+		var ids []int
+		owners := make(map[int]*treeNode)
+		for _, node := range level {
+			for _, childID := range relatedIDs(node.workItem, api.ChildLinkRelation) {
+				if seen[childID] {
+					continue
+				}
+				seen[childID] = true
+				ids = append(ids, childID)
+				owners[childID] = node
+			}
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		children, err := client.GetWorkItems(ids)
+		if err != nil {
+			return err
+		}
+
+		var next []*treeNode
+		for _, child := range children {
+			if child.Id == nil {
+				continue
+			}
+			parent, ok := owners[*child.Id]
+			if !ok {
+				continue
+			}
+			node := &treeNode{workItem: child}
+			parent.children = append(parent.children, node)
+			next = append(next, node)
+		}
+		level = next
+	}
+
+	return nil
+}
+
+// printTree prints a node and its children using box-drawing connectors
+func printTree(node *treeNode, targetID int, prefix string, last, root bool) {
+	highlight := node.workItem.Id != nil && *node.workItem.Id == targetID
+
+	childPrefix := prefix
+	switch {
+	case root:
+		fmt.Println(formatTreeItem(node.workItem, highlight))
+	case last:
+		fmt.Println(prefix + "└── " + formatTreeItem(node.workItem, highlight))
+		childPrefix += "    "
+	default:
+		fmt.Println(prefix + "├── " + formatTreeItem(node.workItem, highlight))
+		childPrefix += "│   "
+	}
+
+	for i, child := range node.children {
+		printTree(child, targetID, childPrefix, i == len(node.children)-1, false)
+	}
+}
+
+// formatTreeItem renders a work item as "Type #id: Title [State]"
+func formatTreeItem(wi workitemtracking.WorkItem, highlight bool) string {
+	id := 0
+	if wi.Id != nil {
+		id = *wi.Id
+	}
+
+	var workItemType, title, state string
+	if wi.Fields != nil {
+		workItemType = fmt.Sprintf("%v", (*wi.Fields)["System.WorkItemType"])
+		title = fmt.Sprintf("%v", (*wi.Fields)["System.Title"])
+		state = fmt.Sprintf("%v", (*wi.Fields)["System.State"])
+	}
+
+	line := fmt.Sprintf("%s #%d: %s [%s]", workItemType, id, title, state)
+	if highlight {
+		line += " ◀"
+	}
+	return line
+}
+
+// relatedIDs returns the IDs of work items linked with the given relation type
+func relatedIDs(wi workitemtracking.WorkItem, relationType string) []int {
+	var ids []int
+	if wi.Relations == nil {
+		return ids
+	}
+	for _, rel := range *wi.Relations {
+		if rel.Rel == nil || rel.Url == nil || *rel.Rel != relationType {
+			continue
+		}
+		if id := api.WorkItemIDFromURL(*rel.Url); id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/output"
+)
+
+func TestTreeEntryRows(t *testing.T) {
+	node := func(id int, workItemType, title string, children ...*treeNode) *treeNode {
+		return &treeNode{
+			workItem: workitemtracking.WorkItem{Id: &id, Fields: &map[string]interface{}{
+				"System.WorkItemType": workItemType, "System.Title": title, "System.State": "Active",
+			}},
+			children: children,
+		}
+	}
+	root := node(1, "Epic", "Checkout", node(2, "Feature", "Payments", node(3, "Task", "Card form")), node(4, "Feature", "Receipts"))
+
+	tree := treeEntryOf(root)
+	if len(tree.Children) != 2 || len(tree.Children[0].Children) != 1 || tree.Children[0].Children[0].Title != "Card form" {
+		t.Fatalf("treeEntryOf() = %+v, want the nested children", tree)
+	}
+
+	var table output.Table
+	addTreeRows(&table, tree, "")
+	want := "[[1  Epic Checkout Active] [2 1 Feature Payments Active] [3 2 Task Card form Active] [4 1 Feature Receipts Active]]"
+	if got := fmt.Sprint(table.Rows); got != want {
+		t.Errorf("addTreeRows() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/model"
+)
+
+// defaultTriageWIQL selects new, unassigned work items when no triage query is configured
+const defaultTriageWIQL = `SELECT [System.Id] FROM WorkItems
+WHERE [System.TeamProject] = @project
+AND [System.State] = 'New'
+AND [System.AssignedTo] = ''
+ORDER BY [System.CreatedDate] ASC`
+
+var (
+	triageLimitFlag int
+
+	triageCmd = &cobra.Command{
+		Use:   "triage [query-name]",
+		Short: "Step through untriaged work items",
+		Long: `Step through the results of a triage query one item at a time and act on
+each with a single key:
+
+  a  assign            p  set priority       t  add tags
+  c  close             s  skip (or Enter)    q  quit
+
+Assign, priority and tag keep the item open for more actions; close and skip
+move to the next item. A session summary is printed at the end and saved to
+~/.azure-boards-cli/triage/.
+
+The query is the saved query named on the command line, else the
+triage_query config value, else new unassigned work items in the project.
+
+Examples:
+  azb triage
+  azb triage "Incoming Bugs"
+  azb config set triage_query "Incoming Bugs"`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeArgs(completeQueryNames),
+		Annotations:       mutatingAnnotations,
+		RunE:              runTriage,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(triageCmd)
+
+	triageCmd.Flags().IntVarP(&triageLimitFlag, "limit", "l", 50, "Maximum number of work items to triage")
+}
+
+// triageSession records what was done during a triage run
+type triageSession struct {
+	Query    string         `json:"query"`
+	Started  time.Time      `json:"started"`
+	Finished time.Time      `json:"finished"`
+	Items    []triageResult `json:"items"`
+}
+
+// triageResult is the outcome of triaging one work item
+type triageResult struct {
+	ID      int      `json:"id"`
+	Title   string   `json:"title"`
+	Actions []string `json:"actions"`
+}
+
+func runTriage(cmd *cobra.Command, args []string) error {
+	if err := textOutputOnly(cmd); err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	queryName := ""
+	if len(args) > 0 {
+		queryName = args[0]
+	} else if cfg, err := config.Load(); err == nil {
+		queryName = cfg.TriageQuery
+	}
+
+	items, label, err := loadTriageItems(client, queryName)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		fmt.Printf("Nothing to triage in %s\n", label)
+		return nil
+	}
+
+	session := &triageSession{Query: label, Started: time.Now()}
+	fmt.Printf("Triaging %d work item(s) from %s\n", len(items), label)
+
+	quit := false
+	for i := range items {
+		if quit {
+			break
+		}
+		workItem := &items[i]
+		result := triageResult{ID: *workItem.Id, Title: workItemTitle(workItem)}
+
+		fmt.Printf("\n── %d/%d ──\n", i+1, len(items))
+		printTriageCard(workItem)
+
+		for done := false; !done; {
+			input, err := promptOptional("[a]ssign [p]riority [t]ag [c]lose [s]kip [q]uit")
+			if err != nil {
+				return err
+			}
+
+			action, err := parseTriageAction(input)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+
+			switch action {
+			case "skip":
+				done = true
+				if len(result.Actions) == 0 {
+					result.Actions = append(result.Actions, "skipped")
+				}
+			case "quit":
+				done, quit = true, true
+			default:
+				description, err := applyTriageAction(client, workItem, action)
+				if err != nil {
+					fmt.Printf("✗ %v\n", err)
+					continue
+				}
+				if description == "" {
+					continue
+				}
+				fmt.Printf("✓ Work item %d %s\n", result.ID, description)
+				result.Actions = append(result.Actions, description)
+				done = action == "close"
+			}
+		}
+
+		if len(result.Actions) > 0 {
+			session.Items = append(session.Items, result)
+		}
+	}
+	session.Finished = time.Now()
+
+	fmt.Println()
+	printTriageSummary(session)
+
+	path, err := saveTriageSession(session)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not save triage session: %v\n", err)
+	} else {
+		fmt.Printf("\nSession saved to %s\n", path)
+	}
+
+	return nil
+}
+
+// loadTriageItems runs the named saved query, or the default triage query when name is empty
+func loadTriageItems(client api.APIClient, queryName string) ([]workitemtracking.WorkItem, string, error) {
+	var results *[]workitemtracking.WorkItem
+	label := "new unassigned work items"
+
+	if queryName != "" {
+		query, err := api.FindQuery(client, queryName)
+		if err != nil {
+			return nil, "", err
+		}
+		if query.Id == nil {
+			return nil, "", fmt.Errorf("query has no ID")
+		}
+		results, err = client.ExecuteQuery(query.Id.String(), triageLimitFlag)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to execute query: %w", err)
+		}
+		label = fmt.Sprintf("query '%s'", queryName)
+	} else {
+		var err error
+		results, err = client.ListWorkItems(defaultTriageWIQL, triageLimitFlag)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	var items []workitemtracking.WorkItem
+	for _, wi := range *results {
+		if wi.Id != nil {
+			items = append(items, wi)
+		}
+	}
+	return items, label, nil
+}
+
+// printTriageCard shows the fields needed to triage a work item
+func printTriageCard(workItem *workitemtracking.WorkItem) {
+	outputCards([]workitemtracking.WorkItem{*workItem}, nil)
+
+	if priority := model.FieldString(workItem.Fields, "Microsoft.VSTS.Common.Priority"); priority != "" {
+		fmt.Printf("  Priority: %s\n", priority)
+	}
+	if tags := model.FieldString(workItem.Fields, "System.Tags"); tags != "" {
+		fmt.Printf("  Tags: %s\n", tags)
+	}
+	if created := model.FieldString(workItem.Fields, "System.CreatedDate"); created != "" {
+		if t, err := time.Parse(time.RFC3339, created); err == nil {
+			created = t.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Printf("  Created: %s by %s\n", created, model.FieldString(workItem.Fields, "System.CreatedBy"))
+	}
+	description := model.FieldString(workItem.Fields, "System.Description")
+	if description = strings.TrimSpace(htmlTagPattern.ReplaceAllString(description, "")); description != "" {
+		fmt.Printf("  %s\n", truncateString(strings.ReplaceAll(description, "\n", " "), 200))
+	}
+}
+
+// parseTriageAction maps a one-key answer to an action
+func parseTriageAction(input string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "a":
+		return "assign", nil
+	case "p":
+		return "priority", nil
+	case "t":
+		return "tag", nil
+	case "c":
+		return "close", nil
+	case "s", "":
+		return "skip", nil
+	case "q":
+		return "quit", nil
+	default:
+		return "", fmt.Errorf("unknown action '%s'", strings.TrimSpace(input))
+	}
+}
+
+// applyTriageAction prompts for the action's value and updates the work item.
+// It returns a description of the change, or "" when the user cancelled.
+func applyTriageAction(client api.APIClient, workItem *workitemtracking.WorkItem, action string) (string, error) {
+	id := *workItem.Id
+	fields := make(map[string]interface{})
+	description := ""
+
+	switch action {
+	case "assign":
+		input, err := promptOptional("Assign to (email, name or @me)")
+		if err != nil || input == "" {
+			return "", err
+		}
+		if input == "@me" {
+			user, err := client.GetCurrentUser()
+			if err != nil {
+				return "", err
+			}
+			input = user.AssignableName()
+		}
+		fields["System.AssignedTo"] = input
+		description = "assigned to " + input
+	case "priority":
+		priority, err := promptPriority(model.FieldString(workItem.Fields, "Microsoft.VSTS.Common.Priority"))
+		if err != nil || priority == 0 {
+			return "", err
+		}
+		fields["Microsoft.VSTS.Common.Priority"] = priority
+		description = fmt.Sprintf("set to priority %d", priority)
+	case "tag":
+		input, err := promptOptional("Tags to add (comma-separated)")
+		if err != nil || input == "" {
+			return "", err
+		}
+		fields["System.Tags"] = processTagUpdates(workItemTags(workItem), input, "")
+		description = "tagged " + input
+	case "close":
+		workItemType := model.FieldString(workItem.Fields, "System.WorkItemType")
+		state, err := client.GetStateForCategory(workItemType, "Completed")
+		if err != nil {
+			state = "Closed"
+		}
+		fields["System.State"] = state
+		description = "set to " + state
+	}
+
+	updated, err := updateAtRevision(client, id, workItem, fields)
+	if err != nil {
+		return "", fmt.Errorf("failed to update work item %d: %w", id, err)
+	}
+	*workItem = *updated
+	return description, nil
+}
+
+// printTriageSummary prints what was done during the session
+func printTriageSummary(session *triageSession) {
+	fmt.Printf("Triage summary (%s, %s):\n", session.Query, session.Finished.Sub(session.Started).Round(time.Second))
+	if len(session.Items) == 0 {
+		fmt.Println("  No work items were triaged")
+		return
+	}
+	for _, item := range session.Items {
+		fmt.Printf("  #%d %s: %s\n", item.ID, truncateString(item.Title, 40), strings.Join(item.Actions, ", "))
+	}
+}
+
+// saveTriageSession writes the session to ~/.azure-boards-cli/triage/<timestamp>.json
+func saveTriageSession(session *triageSession) (string, error) {
+	configDir, err := config.EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(configDir, "triage")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create triage directory: %w", err)
+	}
+
+	path := filepath.Join(dir, session.Started.Format("20060102-150405")+".json")
+	if err := writeJSONFile(path, session); err != nil {
+		return "", err
+	}
+	return path, nil
+}
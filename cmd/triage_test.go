@@ -0,0 +1,31 @@
+package cmd
+
+import "testing"
+
+func TestParseTriageAction(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"a", "assign", false},
+		{"P", "priority", false},
+		{" t ", "tag", false},
+		{"c", "close", false},
+		{"s", "skip", false},
+		{"", "skip", false},
+		{"q", "quit", false},
+		{"x", "", true},
+		{"assign", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTriageAction(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseTriageAction(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("parseTriageAction(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+// undoSkippedFields are set by the service when other fields change, so they
+// are left for it to recompute rather than restored, like the fields
+// api.IsDerivedField reports
+var undoSkippedFields = map[string]bool{
+	"System.Reason":                         true,
+	"System.BoardColumn":                    true,
+	"System.BoardLane":                      true,
+	"System.TeamProject":                    true,
+	"System.CreatedDate":                    true,
+	"System.CreatedBy":                      true,
+	"Microsoft.VSTS.Common.StateChangeDate": true,
+	"Microsoft.VSTS.Common.ActivatedDate":   true,
+	"Microsoft.VSTS.Common.ActivatedBy":     true,
+	"Microsoft.VSTS.Common.ResolvedDate":    true,
+	"Microsoft.VSTS.Common.ResolvedBy":      true,
+	"Microsoft.VSTS.Common.ClosedDate":      true,
+	"Microsoft.VSTS.Common.ClosedBy":        true,
+}
+
+var (
+	undoForceFlag bool
+
+	undoCmd = &cobra.Command{
+		Use:   "undo <id>",
+		Short: "Revert the most recent change to a work item",
+		Long: `Revert the field changes of the most recent revision of a work item.
+
+The fields are shown with their current and restored values before anything
+is changed. Fields the service derives from others, such as Reason or the
+board column, are recomputed rather than restored. Comments and links are not
+removed.
+
+The undo is itself a new revision, so running undo again re-applies the
+change. If someone changes the work item while you confirm, nothing is
+changed and the command fails.
+
+Examples:
+  azb undo 123
+  azb undo 123 --force`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: mutatingAnnotations,
+		RunE:        runUndo,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+
+	undoCmd.Flags().BoolVarP(&undoForceFlag, "force", "f", false, "Skip confirmation prompt")
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(strings.TrimSpace(args[0]))
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	revisions, err := client.GetWorkItemUpdates(id)
+	if err != nil {
+		return err
+	}
+	if len(revisions) < 2 {
+		return fmt.Errorf("work item %d has not been changed since it was created", id)
+	}
+
+	latest := revisions[len(revisions)-1]
+	changes := undoChanges(latest)
+	if len(changes) == 0 {
+		return fmt.Errorf("revision %d of work item %d has no field changes to undo", latest.Rev, id)
+	}
+
+	fmt.Printf("Revision %d of work item #%d", latest.Rev, id)
+	if latest.Author != "" {
+		fmt.Printf(" by %s", latest.Author)
+	}
+	if !latest.Date.IsZero() {
+		fmt.Printf(" on %s", latest.Date.Local().Format("2006-01-02 15:04"))
+	}
+	fmt.Println(" will be reverted:")
+	for _, change := range changes {
+		fmt.Printf("  %s: %s -> %s\n", change.Field, formatHistoryValue(change.NewValue), formatHistoryValue(change.OldValue))
+	}
+	fmt.Println()
+
+	if !undoForceFlag {
+		fmt.Print("Revert these changes? (y/N): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Undo cancelled")
+			return nil
+		}
+	}
+
+	fields := make(map[string]interface{}, len(changes))
+	for _, change := range changes {
+		fields[change.Field] = undoValue(change.OldValue)
+	}
+
+	if _, err := client.UpdateWorkItemAtRevision(id, latest.Rev, fields); err != nil {
+		if errors.Is(err, api.ErrRevisionConflict) {
+			return fmt.Errorf("work item %d was changed after revision %d; run undo again to review the latest change", id, latest.Rev)
+		}
+		return err
+	}
+
+	fmt.Printf("✓ Reverted revision %d of work item #%d (%d fields)\n", latest.Rev, id, len(changes))
+	return nil
+}
+
+// undoChanges returns the field changes of a revision that undo restores
+func undoChanges(rev api.WorkItemRevision) []api.FieldChange {
+	var changes []api.FieldChange
+	for _, change := range rev.Changes {
+		if undoSkippedFields[change.Field] || api.IsDerivedField(change.Field) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// undoValue converts a previous field value into one that can be written back.
+// Identity fields are written by unique name and empty fields are cleared.
+func undoValue(value interface{}) interface{} {
+	if value == nil {
+		return ""
+	}
+	if identity, ok := value.(map[string]interface{}); ok {
+		if name, ok := identity["uniqueName"].(string); ok {
+			return name
+		}
+		if name, ok := identity["displayName"].(string); ok {
+			return name
+		}
+	}
+	return value
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+func TestUndoChanges(t *testing.T) {
+	rev := api.WorkItemRevision{Changes: []api.FieldChange{
+		{Field: "System.BoardColumn", OldValue: "New", NewValue: "Active"},
+		{Field: "System.Reason", OldValue: "New", NewValue: "Implementation started"},
+		{Field: "System.State", OldValue: "New", NewValue: "Active"},
+		{Field: "System.Tags", NewValue: "urgent"},
+	}}
+
+	got := undoChanges(rev)
+	want := []api.FieldChange{
+		{Field: "System.State", OldValue: "New", NewValue: "Active"},
+		{Field: "System.Tags", NewValue: "urgent"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("undoChanges() = %+v, want %+v", got, want)
+	}
+}
+
+func TestUndoValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{"empty clears the field", nil, ""},
+		{"identity by unique name", map[string]interface{}{"displayName": "Ada", "uniqueName": "ada@contoso.com"}, "ada@contoso.com"},
+		{"identity without unique name", map[string]interface{}{"displayName": "Ada"}, "Ada"},
+		{"number", 2.0, 2.0},
+		{"text", "Active", "Active"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := undoValue(tt.value); got != tt.want {
+				t.Errorf("undoValue(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
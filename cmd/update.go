@@ -2,15 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
-	"github.com/SOMUCHDOG/azb/internal/auth"
-	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/recent"
 )
 
 var (
@@ -23,15 +24,28 @@ var (
 	updatePriorityFlag    int
 	updateAddTagsFlag     string
 	updateRemoveTagsFlag  string
+	updateBoardColumnFlag string
 	updateFieldsFlag      []string
+	updateCommentFlag     string
 	updateInteractiveFlag bool
+	updateDryRunFlag      bool
+	updatePlanFormatFlag  string
+	updateApplyFlag       string
 
 	updateCmd = &cobra.Command{
-		Use:   "update <id> [id2,id3...]",
+		Use:   "update [id|range...]",
 		Short: "Update work item(s)",
-		Long:  `Update one or more work items. Provide a single ID or comma-separated IDs for bulk updates.`,
-		Args:  cobra.ExactArgs(1),
-		RunE:  runUpdate,
+		Long: `Update one or more work items. IDs can be given as separate arguments, as
+comma-separated lists and as ranges, e.g. 'azb update 100-110 120 --state Closed'.
+
+Without an ID, updates the work item named by the current git branch (see
+'azb git current').
+
+Use --dry-run to review the planned changes without applying them. A plan saved
+with --plan-format json can be applied later with --apply <file>.`,
+		Args:        cobra.ArbitraryArgs,
+		Annotations: mutatingAnnotations,
+		RunE:        runUpdate,
 	}
 )
 
@@ -47,60 +61,38 @@ func init() {
 	updateCmd.Flags().IntVar(&updatePriorityFlag, "priority", 0, "Update priority (1-4)")
 	updateCmd.Flags().StringVar(&updateAddTagsFlag, "add-tag", "", "Add tags (comma-separated)")
 	updateCmd.Flags().StringVar(&updateRemoveTagsFlag, "remove-tag", "", "Remove tags (comma-separated)")
+	updateCmd.Flags().StringVar(&updateBoardColumnFlag, "board-column", "", "Move to a board column (e.g., QA)")
 	updateCmd.Flags().StringArrayVar(&updateFieldsFlag, "field", []string{}, "Update custom field in format 'FieldName=value' (can be repeated)")
+	updateCmd.Flags().StringVar(&updateCommentFlag, "comment", "", "Add a discussion comment in the same revision (e.g., reason for a state change)")
 	updateCmd.Flags().BoolVarP(&updateInteractiveFlag, "interactive", "i", false, "Interactive edit mode (prompts for each field)")
+	updateCmd.Flags().BoolVar(&updateDryRunFlag, "dry-run", false, "Show the planned changes without applying them")
+	updateCmd.Flags().StringVar(&updatePlanFormatFlag, "plan-format", "table", "Dry run output format (table, json)")
+	updateCmd.Flags().StringVar(&updateApplyFlag, "apply", "", "Apply a plan file created with --dry-run --plan-format json")
+	addConcurrencyFlag(updateCmd)
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
-	// Parse work item IDs (supports single ID or comma-separated list)
-	idsStr := args[0]
-	idsList := strings.Split(idsStr, ",")
-
-	var ids []int
-	for _, idStr := range idsList {
-		id, err := strconv.Atoi(strings.TrimSpace(idStr))
+	// Apply a previously reviewed plan
+	if updateApplyFlag != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--apply takes the work item IDs from the plan; do not pass IDs")
+		}
+		client, err := newClient()
 		if err != nil {
-			return fmt.Errorf("invalid work item ID: %s", idStr)
+			return err
 		}
-		ids = append(ids, id)
+		return applyUpdatePlan(client, updateApplyFlag)
 	}
 
-	// Check authentication
-	token, err := auth.GetToken()
+	// Parse work item IDs, falling back to the current git branch
+	ids, err := workItemIDsArg(args)
 	if err != nil {
 		return err
 	}
 
-	// Load config
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// Get organization and project
-	org := viper.GetString("organization")
-	if org == "" {
-		org = cfg.Organization
-	}
-	if org == "" {
-		return fmt.Errorf("organization not configured. Run 'azb config set organization <org>'")
-	}
-
-	project := viper.GetString("project")
-	if project == "" {
-		project = cfg.Project
-	}
-	if project == "" {
-		return fmt.Errorf("project not configured. Run 'azb config set project <project>'")
-	}
-
-	// Build organization URL
-	orgURL := api.NormalizeOrganizationURL(org)
-
-	// Create API client
-	client, err := api.NewClient(orgURL, project, token)
+	client, err := newClient()
 	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+		return err
 	}
 
 	// Interactive mode only works with single ID
@@ -156,58 +148,85 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		fields[parts[0]] = parts[1]
 	}
 
-	// Handle tag operations separately since they require reading current tags
-	hasTagOperation := updateAddTagsFlag != "" || updateRemoveTagsFlag != ""
+	// Comments are written through System.History so they land in the same
+	// revision as the field changes and either both apply or neither does
+	if updateCommentFlag != "" {
+		fields["System.History"] = expandMentions(client, updateCommentFlag)
+	}
+
+	// Tags and the board column depend on the current work item and are handled per item
+	needsWorkItem := updateAddTagsFlag != "" || updateRemoveTagsFlag != "" || updateBoardColumnFlag != ""
 
 	// Check if any fields to update
-	if len(fields) == 0 && !hasTagOperation {
-		return fmt.Errorf("no fields to update. Specify at least one --field flag")
+	if len(fields) == 0 && !needsWorkItem {
+		return fmt.Errorf("no fields to update. Specify at least one --field or --comment flag")
 	}
 
-	// Update each work item
-	var successCount, failCount int
-	for _, id := range ids {
-		// Handle tag operations
-		updateFields := make(map[string]interface{})
-		for k, v := range fields {
-			updateFields[k] = v
+	if updateDryRunFlag {
+		if err := resolveFormat(cmd, "plan-format", &updatePlanFormatFlag, "table", "json"); err != nil {
+			return err
 		}
-
-		if hasTagOperation {
-			// Get current work item to read tags
+		p := newPlan("update", client)
+		for _, id := range ids {
 			workItem, err := client.GetWorkItem(id)
 			if err != nil {
-				fmt.Printf("✗ Failed to get work item %d: %v\n", id, err)
-				failCount++
+				p.Items = append(p.Items, planItem{ID: id, Error: err.Error()})
 				continue
 			}
 
-			// Get current tags
-			currentTags := ""
-			if workItem.Fields != nil {
-				if tagsValue, ok := (*workItem.Fields)["System.Tags"]; ok {
-					if tagsStr, ok := tagsValue.(string); ok {
-						currentTags = tagsStr
-					}
-				}
+			updateFields := make(map[string]interface{})
+			for k, v := range fields {
+				updateFields[k] = v
+			}
+			if err := addWorkItemDependentFields(workItem, updateFields); err != nil {
+				p.Items = append(p.Items, planItem{ID: id, Title: workItemTitle(workItem), Error: err.Error()})
+				continue
 			}
 
-			// Process tag updates
-			newTags := processTagUpdates(currentTags, updateAddTagsFlag, updateRemoveTagsFlag)
-			updateFields["System.Tags"] = newTags
+			p.Items = append(p.Items, planItem{
+				ID:      id,
+				Title:   workItemTitle(workItem),
+				Changes: planFieldChanges(workItem, updateFields),
+			})
+		}
+		return printPlan(p, updatePlanFormatFlag)
+	}
+
+	// Update the work items, a few at a time
+	progress := newBulkProgress(len(ids))
+	api.ForEach(len(ids), func(i int) {
+		id := ids[i]
+
+		// Tags and the board column are computed from the work item, so the
+		// update applies at the revision they were computed from
+		var updated *workitemtracking.WorkItem
+		var err error
+		if needsWorkItem {
+			updated, err = api.UpdateFromCurrent(client, id, func(workItem *workitemtracking.WorkItem) (map[string]interface{}, error) {
+				updateFields := make(map[string]interface{})
+				for k, v := range fields {
+					updateFields[k] = v
+				}
+				if err := addWorkItemDependentFields(workItem, updateFields); err != nil {
+					return nil, err
+				}
+				return updateFields, nil
+			})
+		} else {
+			updated, err = client.UpdateWorkItem(id, fields)
 		}
-
-		// Update work item
-		_, err := client.UpdateWorkItem(id, updateFields)
 		if err != nil {
-			fmt.Printf("✗ Failed to update work item %d: %v\n", id, err)
-			failCount++
-			continue
+			progress.Failure(fmt.Sprintf("✗ Failed to update work item %d: %v", id, err))
+			return
+		}
+		if len(ids) == 1 {
+			recent.TrackWorkItem(client.GetOrganizationURL(), updated, recent.Edited)
 		}
 
-		fmt.Printf("✓ Updated work item %d\n", id)
-		successCount++
-	}
+		progress.Success(fmt.Sprintf("✓ Updated work item %d", id))
+	})
+	progress.Finish()
+	successCount, failCount := progress.Counts()
 
 	// Summary
 	fmt.Printf("\nSummary: %d updated, %d failed\n", successCount, failCount)
@@ -219,6 +238,95 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// applyUpdatePlan applies the field changes of a saved plan. Work items whose
+// fields changed since the plan was created are skipped.
+func applyUpdatePlan(client api.APIClient, path string) error {
+	p, err := loadPlan(path, "update", client)
+	if err != nil {
+		return err
+	}
+
+	progress := newBulkProgress(len(p.Items))
+	api.ForEach(len(p.Items), func(i int) {
+		item := p.Items[i]
+		if item.Error != "" || len(item.Changes) == 0 {
+			progress.Failure(fmt.Sprintf("✗ Skipped work item %d: nothing was planned", item.ID))
+			return
+		}
+
+		// The planned values are checked against the revision they are applied at
+		conflict := ""
+		_, err := api.UpdateFromCurrent(client, item.ID, func(workItem *workitemtracking.WorkItem) (map[string]interface{}, error) {
+			fields := make(map[string]interface{})
+			for _, change := range item.Changes {
+				var current interface{}
+				if workItem.Fields != nil {
+					current = (*workItem.Fields)[change.Field]
+				}
+				if planValueString(current) != planValueString(change.OldValue) {
+					conflict = change.Field
+					return nil, nil
+				}
+				fields[change.Field] = change.NewValue
+			}
+			return fields, nil
+		})
+		if conflict != "" {
+			progress.Failure(fmt.Sprintf("✗ Skipped work item %d: %s changed since the plan was created", item.ID, conflict))
+			return
+		}
+		if err != nil {
+			progress.Failure(fmt.Sprintf("✗ Failed to update work item %d: %v", item.ID, err))
+			return
+		}
+
+		progress.Success(fmt.Sprintf("✓ Updated work item %d", item.ID))
+	})
+	progress.Finish()
+	successCount, failCount := progress.Counts()
+
+	fmt.Printf("\nSummary: %d updated, %d failed\n", successCount, failCount)
+
+	if failCount > 0 {
+		return fmt.Errorf("some work items failed to update")
+	}
+
+	return nil
+}
+
+// addWorkItemDependentFields adds the tag and board column changes, which are
+// computed from the current work item
+func addWorkItemDependentFields(workItem *workitemtracking.WorkItem, fields map[string]interface{}) error {
+	if updateAddTagsFlag != "" || updateRemoveTagsFlag != "" {
+		fields["System.Tags"] = processTagUpdates(workItemTags(workItem), updateAddTagsFlag, updateRemoveTagsFlag)
+	}
+
+	if updateBoardColumnFlag != "" {
+		var current map[string]interface{}
+		if workItem.Fields != nil {
+			current = *workItem.Fields
+		}
+		field, err := api.KanbanColumnField(current)
+		if err != nil {
+			return err
+		}
+		fields[field] = updateBoardColumnFlag
+	}
+
+	return nil
+}
+
+// workItemTags returns the raw System.Tags value of a work item
+func workItemTags(workItem *workitemtracking.WorkItem) string {
+	if workItem.Fields == nil {
+		return ""
+	}
+	if tags, ok := (*workItem.Fields)["System.Tags"].(string); ok {
+		return tags
+	}
+	return ""
+}
+
 // processTagUpdates adds and removes tags from the current tag string
 func processTagUpdates(currentTags, addTags, removeTags string) string {
 	// Parse current tags
@@ -259,8 +367,23 @@ func processTagUpdates(currentTags, addTags, removeTags string) string {
 	return strings.Join(tags, "; ")
 }
 
+// typedFields are offered in interactive update when present on the work item type
+var typedFields = []string{
+	"Microsoft.VSTS.Scheduling.StoryPoints",
+	"Microsoft.VSTS.Scheduling.Effort",
+	"Microsoft.VSTS.Scheduling.OriginalEstimate",
+	"Microsoft.VSTS.Scheduling.RemainingWork",
+	"Microsoft.VSTS.Scheduling.CompletedWork",
+	"Microsoft.VSTS.Scheduling.StartDate",
+	"Microsoft.VSTS.Scheduling.TargetDate",
+	"Microsoft.VSTS.Scheduling.DueDate",
+}
+
+// priorityLabels describes the Azure DevOps priority scale
+var priorityLabels = []string{"Critical", "High", "Medium", "Low"}
+
 // runInteractiveUpdate prompts the user for each field to update
-func runInteractiveUpdate(client *api.Client, id int) error {
+func runInteractiveUpdate(client api.APIClient, id int) error {
 	fmt.Printf("Interactive update for work item %d\n", id)
 	fmt.Println("Leave blank to keep current value, enter new value to update")
 	fmt.Println()
@@ -284,11 +407,13 @@ func runInteractiveUpdate(client *api.Client, id int) error {
 		return ""
 	}
 
+	// Load the type schema so fields get a matching editor; fall back to free text
+	schema := loadFieldSchema(client, getCurrentValue("System.WorkItemType"))
+
 	// Title
 	currentTitle := getCurrentValue("System.Title")
-	fmt.Printf("Title [%s]: ", currentTitle)
 	//nolint:errcheck // User input is optional; errors default to empty string
-	newTitle, _ := promptOptional("")
+	newTitle, _ := promptOptional(fmt.Sprintf("Title [%s]", currentTitle))
 	if newTitle != "" {
 		fields["System.Title"] = newTitle
 	}
@@ -299,27 +424,32 @@ func runInteractiveUpdate(client *api.Client, id int) error {
 	if len(descPreview) > 50 {
 		descPreview = descPreview[:47] + "..."
 	}
-	fmt.Printf("Description [%s]: ", descPreview)
 	//nolint:errcheck // User input is optional; errors default to empty string
-	newDesc, _ := promptOptional("")
+	newDesc, _ := promptOptional(fmt.Sprintf("Description [%s]", descPreview))
 	if newDesc != "" {
 		fields["System.Description"] = newDesc
 	}
 
 	// State
-	currentState := getCurrentValue("System.State")
-	fmt.Printf("State [%s]: ", currentState)
-	//nolint:errcheck // User input is optional; errors default to empty string
-	newState, _ := promptOptional("")
+	newState, err := promptPicklist("State", getCurrentValue("System.State"), schema.allowedValues("System.State"))
+	if err != nil {
+		return err
+	}
 	if newState != "" {
 		fields["System.State"] = newState
+
+		// Record the reason for the state change in the discussion
+		//nolint:errcheck // User input is optional; errors default to empty string
+		comment, _ := promptOptional("Comment (reason for state change)")
+		if comment != "" {
+			fields["System.History"] = expandMentions(client, comment)
+		}
 	}
 
 	// Assigned To
 	currentAssignedTo := getCurrentValue("System.AssignedTo")
-	fmt.Printf("Assigned To [%s]: ", currentAssignedTo)
 	//nolint:errcheck // User input is optional; errors default to empty string
-	newAssignedTo, _ := promptOptional("")
+	newAssignedTo, _ := promptOptional(fmt.Sprintf("Assigned To [%s]", currentAssignedTo))
 	if newAssignedTo != "" {
 		if newAssignedTo == "@me" {
 			fields["System.AssignedTo"] = ""
@@ -330,22 +460,34 @@ func runInteractiveUpdate(client *api.Client, id int) error {
 
 	// Tags
 	currentTags := getCurrentValue("System.Tags")
-	fmt.Printf("Tags [%s]: ", currentTags)
 	//nolint:errcheck // User input is optional; errors default to empty string
-	newTags, _ := promptOptional("")
+	newTags, _ := promptOptional(fmt.Sprintf("Tags [%s]", currentTags))
 	if newTags != "" {
 		fields["System.Tags"] = newTags
 	}
 
 	// Priority
-	currentPriority := getCurrentValue("Microsoft.VSTS.Common.Priority")
-	fmt.Printf("Priority [%s]: ", currentPriority)
-	//nolint:errcheck // User input is optional; errors default to empty string
-	newPriority, _ := promptOptional("")
-	if newPriority != "" {
-		priority, err := strconv.Atoi(newPriority)
-		if err == nil && priority >= 1 && priority <= 4 {
-			fields["Microsoft.VSTS.Common.Priority"] = priority
+	newPriority, err := promptPriority(getCurrentValue("Microsoft.VSTS.Common.Priority"))
+	if err != nil {
+		return err
+	}
+	if newPriority > 0 {
+		fields["Microsoft.VSTS.Common.Priority"] = newPriority
+	}
+
+	// Numeric and date fields defined on this work item type
+	for _, refName := range typedFields {
+		field, ok := schema.fields[refName]
+		if !ok {
+			continue
+		}
+
+		value, err := promptTypedField(field, getCurrentValue(refName))
+		if err != nil {
+			return err
+		}
+		if value != nil {
+			fields[refName] = value
 		}
 	}
 
@@ -369,13 +511,263 @@ func runInteractiveUpdate(client *api.Client, id int) error {
 		return nil
 	}
 
-	// Update work item
-	_, err = client.UpdateWorkItem(id, fields)
+	// Update work item, unless someone else changed it while the fields were chosen
+	updated, err := updateAtRevision(client, id, workItem, fields)
 	if err != nil {
 		return fmt.Errorf("failed to update work item: %w", err)
 	}
+	recent.TrackWorkItem(client.GetOrganizationURL(), updated, recent.Edited)
 
 	fmt.Printf("\n✓ Updated work item %d\n", id)
 
 	return nil
 }
+
+// fieldSchema holds the field definitions used to pick an editor for each field
+type fieldSchema struct {
+	fields map[string]typedField
+}
+
+// typedField describes a work item type field and its data type
+type typedField struct {
+	Name          string
+	Type          workitemtracking.FieldType
+	AllowedValues []string
+}
+
+// allowedValues returns the picklist values for a field, if any
+func (s fieldSchema) allowedValues(refName string) []string {
+	return s.fields[refName].AllowedValues
+}
+
+// loadFieldSchema fetches field types and allowed values for a work item type.
+// Errors are reported as warnings since every field can still be edited as free text.
+func loadFieldSchema(client api.APIClient, workItemType string) fieldSchema {
+	schema := fieldSchema{fields: make(map[string]typedField)}
+	if workItemType == "" {
+		return schema
+	}
+
+	typeFields, err := client.GetWorkItemTypeFields(workItemType)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return schema
+	}
+
+	fieldTypes := make(map[string]workitemtracking.FieldType)
+	if allFields, err := client.GetFields(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	} else if allFields != nil {
+		for _, field := range *allFields {
+			if field.ReferenceName != nil && field.Type != nil {
+				fieldTypes[*field.ReferenceName] = *field.Type
+			}
+		}
+	}
+
+	if typeFields != nil {
+		for _, field := range *typeFields {
+			if field.ReferenceName == nil {
+				continue
+			}
+
+			tf := typedField{Type: fieldTypes[*field.ReferenceName]}
+			if field.Name != nil {
+				tf.Name = *field.Name
+			}
+			if field.AllowedValues != nil {
+				for _, value := range *field.AllowedValues {
+					tf.AllowedValues = append(tf.AllowedValues, fmt.Sprintf("%v", value))
+				}
+			}
+			schema.fields[*field.ReferenceName] = tf
+		}
+	}
+
+	return schema
+}
+
+// promptTypedField prompts for a field using an editor that matches its type.
+// Returns nil when the user keeps the current value.
+func promptTypedField(field typedField, current string) (interface{}, error) {
+	if len(field.AllowedValues) > 0 {
+		value, err := promptPicklist(field.Name, current, field.AllowedValues)
+		if err != nil || value == "" {
+			return nil, err
+		}
+		return value, nil
+	}
+
+	switch field.Type {
+	case workitemtracking.FieldTypeValues.DateTime:
+		value, err := promptDate(field.Name, current)
+		if err != nil || value == "" {
+			return nil, err
+		}
+		return value, nil
+	case workitemtracking.FieldTypeValues.Integer, workitemtracking.FieldTypeValues.Double:
+		return promptNumber(field.Name, current, field.Type == workitemtracking.FieldTypeValues.Integer)
+	default:
+		value, err := promptOptional(fmt.Sprintf("%s [%s]", field.Name, current))
+		if err != nil || value == "" {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// promptPriority shows the priority scale and re-prompts until the input is blank or 1-4
+func promptPriority(current string) (int, error) {
+	fmt.Println("Priority:")
+	for i, label := range priorityLabels {
+		fmt.Printf("  %d. %s\n", i+1, label)
+	}
+
+	for {
+		input, err := promptOptional(fmt.Sprintf("Choice (1-4) [%s]", current))
+		if err != nil {
+			return 0, err
+		}
+		if input == "" {
+			return 0, nil
+		}
+
+		priority, err := parsePriority(input)
+		if err == nil {
+			return priority, nil
+		}
+		fmt.Println(err)
+	}
+}
+
+// promptPicklist shows the allowed values and re-prompts until the input is blank or legal.
+// Without allowed values it behaves like a free text prompt.
+func promptPicklist(label, current string, allowed []string) (string, error) {
+	if len(allowed) == 0 {
+		return promptOptional(fmt.Sprintf("%s [%s]", label, current))
+	}
+
+	fmt.Printf("%s:\n", label)
+	for i, value := range allowed {
+		fmt.Printf("  %d. %s\n", i+1, value)
+	}
+
+	for {
+		input, err := promptOptional(fmt.Sprintf("Choice (1-%d or value) [%s]", len(allowed), current))
+		if err != nil {
+			return "", err
+		}
+		if input == "" {
+			return "", nil
+		}
+
+		value, err := matchPicklistValue(input, allowed)
+		if err == nil {
+			return value, nil
+		}
+		fmt.Println(err)
+	}
+}
+
+// promptDate re-prompts until the input is blank or a valid date
+func promptDate(label, current string) (string, error) {
+	// Show stored timestamps as plain dates
+	if t, err := time.Parse(time.RFC3339, current); err == nil {
+		current = t.Local().Format("2006-01-02")
+	}
+
+	for {
+		input, err := promptOptional(fmt.Sprintf("%s (YYYY-MM-DD) [%s]", label, current))
+		if err != nil {
+			return "", err
+		}
+		if input == "" {
+			return "", nil
+		}
+
+		value, err := parseDateInput(input)
+		if err == nil {
+			return value, nil
+		}
+		fmt.Println(err)
+	}
+}
+
+// promptNumber re-prompts until the input is blank or a valid number.
+// Returns nil when the user keeps the current value.
+func promptNumber(label, current string, integer bool) (interface{}, error) {
+	for {
+		input, err := promptOptional(fmt.Sprintf("%s [%s]", label, current))
+		if err != nil {
+			return nil, err
+		}
+		if input == "" {
+			return nil, nil
+		}
+
+		value, err := parseNumberInput(input, integer)
+		if err == nil {
+			return value, nil
+		}
+		fmt.Println(err)
+	}
+}
+
+// parsePriority validates a priority between 1 and 4
+func parsePriority(input string) (int, error) {
+	priority, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || priority < 1 || priority > len(priorityLabels) {
+		return 0, fmt.Errorf("priority must be a number between 1 and %d", len(priorityLabels))
+	}
+	return priority, nil
+}
+
+// matchPicklistValue resolves a list number or a case-insensitive value to an allowed value
+func matchPicklistValue(input string, allowed []string) (string, error) {
+	input = strings.TrimSpace(input)
+
+	for _, value := range allowed {
+		if strings.EqualFold(value, input) {
+			return value, nil
+		}
+	}
+
+	if n, err := strconv.Atoi(input); err == nil && n >= 1 && n <= len(allowed) {
+		return allowed[n-1], nil
+	}
+
+	return "", fmt.Errorf("'%s' is not one of: %s", input, strings.Join(allowed, ", "))
+}
+
+// parseDateInput accepts YYYY-MM-DD (local midnight) or RFC 3339 and returns an RFC 3339 timestamp
+func parseDateInput(input string) (string, error) {
+	input = strings.TrimSpace(input)
+
+	if t, err := time.ParseInLocation("2006-01-02", input, time.Local); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+	if t, err := time.Parse(time.RFC3339, input); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+
+	return "", fmt.Errorf("invalid date '%s', expected YYYY-MM-DD", input)
+}
+
+// parseNumberInput validates an integer or decimal field value
+func parseNumberInput(input string, integer bool) (interface{}, error) {
+	input = strings.TrimSpace(input)
+
+	if integer {
+		value, err := strconv.Atoi(input)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a whole number", input)
+		}
+		return value, nil
+	}
+
+	value, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a number", input)
+	}
+	return value, nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{input: "1", want: 1},
+		{input: " 4 ", want: 4},
+		{input: "0", wantErr: true},
+		{input: "5", wantErr: true},
+		{input: "high", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parsePriority(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePriority(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parsePriority(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPicklistValue(t *testing.T) {
+	allowed := []string{"New", "Active", "Resolved", "Closed"}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "exact value", input: "Active", want: "Active"},
+		{name: "case insensitive", input: "closed", want: "Closed"},
+		{name: "list number", input: "3", want: "Resolved"},
+		{name: "number out of range", input: "5", wantErr: true},
+		{name: "unknown value", input: "Done", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchPicklistValue(tt.input, allowed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("matchPicklistValue(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("matchPicklistValue(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantDay string
+		wantErr bool
+	}{
+		{name: "plain date", input: "2024-03-15", wantDay: "2024-03-15"},
+		{name: "rfc3339", input: "2024-03-15T10:00:00Z", wantDay: "2024-03-15"},
+		{name: "invalid month", input: "2024-13-01", wantErr: true},
+		{name: "wrong format", input: "15/03/2024", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDateInput(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDateInput(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			parsed, err := time.Parse(time.RFC3339, got)
+			if err != nil {
+				t.Fatalf("parseDateInput(%q) = %q, not RFC 3339: %v", tt.input, got, err)
+			}
+			if day := parsed.Format("2006-01-02"); day != tt.wantDay {
+				t.Errorf("parseDateInput(%q) day = %s, want %s", tt.input, day, tt.wantDay)
+			}
+		})
+	}
+}
+
+func TestParseNumberInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		integer bool
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "integer", input: "8", integer: true, want: 8},
+		{name: "integer rejects decimal", input: "2.5", integer: true, wantErr: true},
+		{name: "double", input: "2.5", want: 2.5},
+		{name: "double rejects text", input: "lots", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNumberInput(tt.input, tt.integer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNumberInput(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseNumberInput(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/notify"
+)
+
+var (
+	watchIntervalFlag time.Duration
+	watchNoNotifyFlag bool
+
+	watchCmd = &cobra.Command{
+		Use:   "watch <id>",
+		Short: "Stream changes to a work item",
+		Long: `Poll a work item and print a timestamped line for every field change and
+new comment until interrupted with Ctrl+C.
+
+Changes are also delivered to the sinks under the notifications config key,
+such as a Slack channel or a script; see 'azb notify test'.
+
+Examples:
+  azb watch 1234
+  azb watch 1234 --interval 10s`,
+		Args: cobra.ExactArgs(1),
+		RunE: runWatch,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().DurationVarP(&watchIntervalFlag, "interval", "i", 30*time.Second, "How often to check for changes")
+	watchCmd.Flags().BoolVar(&watchNoNotifyFlag, "no-notify", false, "Do not deliver changes to the configured notification sinks")
+}
+
+// watchState is what has already been printed for the watched work item
+type watchState struct {
+	rev      int
+	comments map[int]bool
+	sinks    []*notify.Sink
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if err := textOutputOnly(cmd); err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid work item ID: %s", args[0])
+	}
+	if watchIntervalFlag < time.Second {
+		return fmt.Errorf("interval must be at least 1s")
+	}
+
+	var sinks []*notify.Sink
+	if !watchNoNotifyFlag {
+		if sinks, err = notify.Load(); err != nil {
+			return err
+		}
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	workItem, err := client.GetWorkItem(id)
+	if err != nil {
+		return fmt.Errorf("failed to get work item: %w", err)
+	}
+
+	comments, err := client.GetComments(id)
+	if err != nil {
+		return err
+	}
+
+	state := &watchState{comments: make(map[int]bool), sinks: sinks}
+	if workItem.Rev != nil {
+		state.rev = *workItem.Rev
+	}
+	for _, c := range *convertComments(comments) {
+		state.comments[c.ID] = true
+	}
+
+	fmt.Printf("Watching work item #%d: %s (rev %d, %d comment(s))\n", id, workItemTitle(workItem), state.rev, len(state.comments))
+	if len(sinks) > 0 {
+		fmt.Printf("Delivering changes to %d notification sink(s).\n", len(sinks))
+	}
+	fmt.Printf("Checking every %s. Press Ctrl+C to stop.\n\n", watchIntervalFlag)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(watchIntervalFlag)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println("\nStopped watching")
+			return nil
+		case <-ticker.C:
+			if err := pollWatchedWorkItem(client, id, state); err != nil {
+				// Keep watching through transient failures
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+}
+
+// pollWatchedWorkItem prints revisions and comments added since the last poll
+func pollWatchedWorkItem(client api.APIClient, id int, state *watchState) error {
+	workItem, err := client.GetWorkItem(id)
+	if err != nil {
+		return err
+	}
+	if workItem.Rev == nil || *workItem.Rev == state.rev {
+		return nil
+	}
+
+	revisions, err := client.GetWorkItemUpdates(id)
+	if err != nil {
+		return err
+	}
+	title := workItemTitle(workItem)
+	for _, entry := range revisionsAfter(*historyEntries(revisions), state.rev) {
+		lines := formatWatchRevision(entry)
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		if len(lines) > 0 {
+			state.notify(revisionEvent(id, title, client.WorkItemURL(id), entry))
+		}
+	}
+	state.rev = *workItem.Rev
+
+	// Adding a comment creates a revision, so comments only need checking here
+	comments, err := client.GetComments(id)
+	if err != nil {
+		return err
+	}
+	for _, c := range *convertComments(comments) {
+		if state.comments[c.ID] {
+			continue
+		}
+		state.comments[c.ID] = true
+		text := strings.TrimSpace(htmlTagPattern.ReplaceAllString(c.Text, ""))
+		fmt.Printf("[%s] %s commented: %s\n", watchTimestamp(c.Date), c.Author, text)
+		state.notify(notify.Event{
+			Kind:    notify.KindCommented,
+			ID:      id,
+			Title:   title,
+			URL:     client.WorkItemURL(id),
+			Author:  c.Author,
+			Time:    c.Date,
+			Comment: text,
+		})
+	}
+
+	return nil
+}
+
+// notify delivers an event to the notification sinks; failures are warnings
+// so one unreachable sink does not stop the watch
+func (s *watchState) notify(event notify.Event) {
+	for _, err := range notify.Dispatch(context.Background(), s.sinks, event) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// revisionEvent converts a history entry to a notification event, skipping
+// the fields formatWatchRevision skips
+func revisionEvent(id int, title, url string, entry showHistoryEntry) notify.Event {
+	event := notify.Event{
+		Kind:   notify.KindUpdated,
+		ID:     id,
+		Title:  title,
+		URL:    url,
+		Rev:    entry.Rev,
+		Author: entry.Author,
+		Time:   entry.Date,
+	}
+
+	names := make([]string, 0, len(entry.Changes))
+	for name := range entry.Changes {
+		if name != "System.History" && name != "System.CommentCount" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		change := entry.Changes[name]
+		event.Changes = append(event.Changes, notify.Change{
+			Field:    name,
+			OldValue: formatHistoryValue(change.OldValue),
+			NewValue: formatHistoryValue(change.NewValue),
+		})
+	}
+	return event
+}
+
+// revisionsAfter returns the history entries newer than rev that changed at least one field
+func revisionsAfter(history []showHistoryEntry, rev int) []showHistoryEntry {
+	var result []showHistoryEntry
+	for _, entry := range history {
+		if entry.Rev > rev && len(entry.Changes) > 0 {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// formatWatchRevision renders one line per changed field of a revision
+func formatWatchRevision(entry showHistoryEntry) []string {
+	names := make([]string, 0, len(entry.Changes))
+	for name := range entry.Changes {
+		// Comment text is printed from the comments API instead
+		if name == "System.History" || name == "System.CommentCount" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		change := entry.Changes[name]
+		lines = append(lines, fmt.Sprintf("[%s] Rev %d by %s: %s: %s -> %s",
+			watchTimestamp(entry.Date), entry.Rev, entry.Author, name,
+			formatHistoryValue(change.OldValue), formatHistoryValue(change.NewValue)))
+	}
+	return lines
+}
+
+// watchTimestamp formats a change time, falling back to now when the date is unknown
+func watchTimestamp(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRevisionsAfter(t *testing.T) {
+	history := []showHistoryEntry{
+		{Rev: 1, Changes: map[string]showFieldChange{"System.Title": {NewValue: "A"}}},
+		{Rev: 2, Changes: map[string]showFieldChange{}},
+		{Rev: 3, Changes: map[string]showFieldChange{"System.State": {OldValue: "New", NewValue: "Active"}}},
+	}
+
+	got := revisionsAfter(history, 1)
+	if len(got) != 1 || got[0].Rev != 3 {
+		t.Fatalf("revisionsAfter() = %+v, want only rev 3", got)
+	}
+}
+
+func TestFormatWatchRevision(t *testing.T) {
+	entry := showHistoryEntry{
+		Rev:    4,
+		Author: "Alice",
+		Date:   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Changes: map[string]showFieldChange{
+			"System.State":        {OldValue: "Active", NewValue: "Resolved"},
+			"System.History":      {NewValue: "Fixed"},
+			"System.AssignedTo":   {NewValue: map[string]interface{}{"displayName": "Bob"}},
+			"System.CommentCount": {OldValue: 1.0, NewValue: 2.0},
+		},
+	}
+
+	lines := formatWatchRevision(entry)
+	if len(lines) != 2 {
+		t.Fatalf("formatWatchRevision() returned %d lines, want 2: %v", len(lines), lines)
+	}
+	if !strings.HasSuffix(lines[0], "Rev 4 by Alice: System.AssignedTo: (empty) -> Bob") {
+		t.Errorf("lines[0] = %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "Rev 4 by Alice: System.State: Active -> Resolved") {
+		t.Errorf("lines[1] = %q", lines[1])
+	}
+}
+
+func TestRevisionEvent(t *testing.T) {
+	entry := showHistoryEntry{
+		Rev:    4,
+		Author: "Alice",
+		Changes: map[string]showFieldChange{
+			"System.State":   {OldValue: "Active", NewValue: "Resolved"},
+			"System.History": {NewValue: "Fixed"},
+		},
+	}
+
+	event := revisionEvent(7, "Login fails", "https://example/7", entry)
+	if event.ID != 7 || event.Rev != 4 || event.Author != "Alice" || event.Kind != "updated" {
+		t.Errorf("revisionEvent() = %+v", event)
+	}
+	if len(event.Changes) != 1 || event.Changes[0].Field != "System.State" || event.Changes[0].NewValue != "Resolved" {
+		t.Errorf("revisionEvent() changes = %+v, want only the state change", event.Changes)
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SOMUCHDOG/azb/internal/wiql"
+)
+
+// dateFilter is a flag that filters work items by a date field
+type dateFilter struct {
+	flag   string
+	field  wiql.Field
+	before bool
+	usage  string
+	value  string
+}
+
+// newDateFilters returns the created, changed and closed since/before filters
+func newDateFilters() []*dateFilter {
+	var filters []*dateFilter
+	for _, date := range []struct {
+		name  string
+		field wiql.Field
+	}{
+		{"created", "System.CreatedDate"},
+		{"changed", "System.ChangedDate"},
+		{"closed", "Microsoft.VSTS.Common.ClosedDate"},
+	} {
+		filters = append(filters,
+			&dateFilter{flag: date.name + "-since", field: date.field, usage: fmt.Sprintf("Only work items %s on or after a date (7d, 2w, today, 2024-01-01)", date.name)},
+			&dateFilter{flag: date.name + "-before", field: date.field, before: true, usage: fmt.Sprintf("Only work items %s before a date (7d, 2w, today, 2024-01-01)", date.name)},
+		)
+	}
+	return filters
+}
+
+// addDateFilterFlags registers the flags of filters on a command
+func addDateFilterFlags(cmd *cobra.Command, filters []*dateFilter) {
+	for _, filter := range filters {
+		cmd.Flags().StringVar(&filter.value, filter.flag, "", filter.usage)
+	}
+}
+
+// dateConditions returns the WIQL conditions of the filters that are set
+func dateConditions(filters []*dateFilter) ([]wiql.Condition, error) {
+	var conditions []wiql.Condition
+	for _, filter := range filters {
+		if filter.value == "" {
+			continue
+		}
+		value, err := wiqlDate(filter.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s: %w", filter.flag, err)
+		}
+		if filter.before {
+			conditions = append(conditions, filter.field.Lt(value))
+		} else {
+			conditions = append(conditions, filter.field.Ge(value))
+		}
+	}
+	return conditions, nil
+}
+
+// wiqlDate converts a date given on the command line into a WIQL value. Days
+// and weeks back from today become @Today macros, so saved queries built from
+// them stay relative; dates are quoted as they are.
+func wiqlDate(value string) (wiql.Value, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	switch value {
+	case "today":
+		return wiql.Today(0), nil
+	case "yesterday":
+		return wiql.Today(-1), nil
+	}
+
+	if len(value) >= 2 {
+		if n, err := strconv.Atoi(value[:len(value)-1]); err == nil && n >= 0 {
+			switch value[len(value)-1] {
+			case 'd':
+				return wiql.Today(-n), nil
+			case 'w':
+				return wiql.Today(-7 * n), nil
+			}
+		}
+	}
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		return wiql.String(value), nil
+	}
+	return wiql.Value{}, fmt.Errorf("%q is not a date (use e.g. 7d, 2w, today or 2024-01-01)", value)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWIQLDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"7d", "@Today - 7", false},
+		{"2W", "@Today - 14", false},
+		{"0d", "@Today", false},
+		{"today", "@Today", false},
+		{"yesterday", "@Today - 1", false},
+		{"2024-01-01", "'2024-01-01'", false},
+		{"12h", "", true},
+		{"-3d", "", true},
+		{"2024-13-01", "", true},
+		{"last week", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := wiqlDate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wiqlDate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got.String() != tt.want {
+				t.Errorf("wiqlDate(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateConditions(t *testing.T) {
+	filters := newDateFilters()
+	values := map[string]string{"changed-since": "7d", "created-since": "2024-01-01", "closed-before": "1w"}
+	for _, filter := range filters {
+		filter.value = values[filter.flag]
+	}
+
+	conditions, err := dateConditions(filters)
+	if err != nil {
+		t.Fatalf("dateConditions() error = %v", err)
+	}
+	var got []string
+	for _, c := range conditions {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"[System.CreatedDate] >= '2024-01-01'",
+		"[System.ChangedDate] >= @Today - 7",
+		"[Microsoft.VSTS.Common.ClosedDate] < @Today - 7",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dateConditions() = %q, want %q", got, want)
+	}
+
+	filters[0].value = "soon"
+	if _, err := dateConditions(filters); err == nil {
+		t.Error("dateConditions() with an invalid date succeeded")
+	}
+}
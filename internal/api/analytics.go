@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// analyticsVersion is the Analytics OData version queried
+const analyticsVersion = "v4.0-preview"
+
+// AnalyticsURL returns the Analytics OData endpoint of a project:
+// analytics.dev.azure.com for dev.azure.com organizations,
+// <org>.analytics.visualstudio.com for legacy URLs, and the collection URL
+// itself for Azure DevOps Server
+func AnalyticsURL(organizationURL, project string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSuffix(organizationURL, "/"))
+	if err != nil {
+		return "", fmt.Errorf("invalid organization URL '%s': %w", organizationURL, err)
+	}
+
+	host := strings.ToLower(parsed.Host)
+	switch {
+	case host == "dev.azure.com":
+		parsed.Host = "analytics.dev.azure.com"
+	case strings.HasSuffix(host, ".visualstudio.com"):
+		parsed.Host = strings.TrimSuffix(host, ".visualstudio.com") + ".analytics.visualstudio.com"
+	}
+
+	return fmt.Sprintf("%s/%s/_odata/%s", parsed.String(), url.PathEscape(project), analyticsVersion), nil
+}
+
+// QueryAnalytics runs an OData query against an Analytics entity set, such as
+// WorkItems, and returns the rows of all result pages
+func (c *Client) QueryAnalytics(entitySet string, query url.Values) ([]map[string]interface{}, error) {
+	base, err := AnalyticsURL(c.organizationURL, c.project)
+	if err != nil {
+		return nil, err
+	}
+
+	client := c.connection.GetClientByUrl(c.connection.BaseUrl)
+	next := base + "/" + entitySet + "?" + strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	var rows []map[string]interface{}
+	for next != "" {
+		req, err := client.CreateRequestMessage(c.ctx, http.MethodGet, next, "", nil, "", "application/json", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create analytics request: %w", err)
+		}
+		resp, err := client.SendRequest(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query analytics: %w", err)
+		}
+
+		var page struct {
+			Value    []map[string]interface{} `json:"value"`
+			NextLink string                   `json:"@odata.nextLink"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse analytics response: %w", err)
+		}
+
+		rows = append(rows, page.Value...)
+		next = page.NextLink
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,27 @@
+package api
+
+import "testing"
+
+func TestAnalyticsURL(t *testing.T) {
+	tests := []struct {
+		organizationURL string
+		project         string
+		want            string
+	}{
+		{"https://dev.azure.com/contoso", "Web", "https://analytics.dev.azure.com/contoso/Web/_odata/v4.0-preview"},
+		{"https://dev.azure.com/contoso/", "My Project", "https://analytics.dev.azure.com/contoso/My%20Project/_odata/v4.0-preview"},
+		{"https://contoso.visualstudio.com", "Web", "https://contoso.analytics.visualstudio.com/Web/_odata/v4.0-preview"},
+		{"https://tfs.contoso.local/DefaultCollection", "Web", "https://tfs.contoso.local/DefaultCollection/Web/_odata/v4.0-preview"},
+	}
+
+	for _, tt := range tests {
+		got, err := AnalyticsURL(tt.organizationURL, tt.project)
+		if err != nil {
+			t.Errorf("AnalyticsURL(%q, %q) error: %v", tt.organizationURL, tt.project, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("AnalyticsURL(%q, %q) = %q, want %q", tt.organizationURL, tt.project, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Package apitest provides a mock of the Azure DevOps API for tests of the
+// commands and the dashboard.
+package apitest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrNotMocked is returned by methods of Client without a function set
+var ErrNotMocked = errors.New("not mocked")
+
+// New returns a Client for organization "https://dev.azure.com/test" and
+// project "Test"
+func New() *Client {
+	return &Client{
+		GetOrganizationURLFunc: func() string { return "https://dev.azure.com/test" },
+		GetProjectFunc:         func() string { return "Test" },
+		GetContextFunc:         context.Background,
+	}
+}
+
+// Calls returns the names of the methods called so far, in order
+func (m *Client) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.calls)
+}
+
+// Called reports whether a method was called
+func (m *Client) Called(method string) bool {
+	return slices.Contains(m.Calls(), method)
+}
+
+func (m *Client) record(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, method)
+}
+
+func notMocked(method string) error {
+	return fmt.Errorf("apitest: %s: %w", method, ErrNotMocked)
+}
@@ -0,0 +1,655 @@
+// Code generated by gen.go from the APIClient interface; DO NOT EDIT.
+
+package apitest
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/work"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+// Client is an api.APIClient whose methods call the function field of the
+// same name. Methods without one return zero values and ErrNotMocked.
+type Client struct {
+	GetOrganizationURLFunc          func() string
+	GetProjectFunc                  func() string
+	GetContextFunc                  func() context.Context
+	SetReadOnlyFunc                 func(bool)
+	ReadOnlyFunc                    func() bool
+	WorkItemURLFunc                 func(int) string
+	WorkItemAPIURLFunc              func(int) string
+	GetWorkItemFunc                 func(int) (*workitemtracking.WorkItem, error)
+	GetWorkItemsFunc                func([]int) ([]workitemtracking.WorkItem, error)
+	GetWorkItemsAsOfFunc            func([]int, time.Time) ([]workitemtracking.WorkItem, error)
+	GetWorkItemFieldsFunc           func([]int, []string) ([]workitemtracking.WorkItem, error)
+	ListWorkItemsFunc               func(string, int) (*[]workitemtracking.WorkItem, error)
+	QueryWorkItemIDsFunc            func(string, int) ([]int, error)
+	CreateWorkItemFunc              func(string, map[string]interface{}, int) (*workitemtracking.WorkItem, error)
+	CreateWorkItemWithRelationsFunc func(string, map[string]interface{}, []api.WorkItemLink) (*workitemtracking.WorkItem, error)
+	CreateWorkItemsFunc             func([]api.NewWorkItem) []api.CreateResult
+	UpdateWorkItemFunc              func(int, map[string]interface{}) (*workitemtracking.WorkItem, error)
+	UpdateWorkItemAtRevisionFunc    func(int, int, map[string]interface{}) (*workitemtracking.WorkItem, error)
+	UpdateWorkItemWithLinksFunc     func(int, map[string]interface{}, []api.WorkItemLink) (*workitemtracking.WorkItem, error)
+	DeleteWorkItemFunc              func(int) error
+	ValidateWorkItemFunc            func(string, map[string]interface{}, []api.WorkItemLink) ([]api.FieldError, error)
+	ParentLinkFunc                  func(int) api.WorkItemLink
+	RelatedLinkFunc                 func(int) api.WorkItemLink
+	GetUpdatesFunc                  func(int) ([]workitemtracking.WorkItemUpdate, error)
+	GetWorkItemUpdatesFunc          func(int) ([]api.WorkItemRevision, error)
+	GetCommentsFunc                 func(int) ([]workitemtracking.Comment, error)
+	AddCommentFunc                  func(int, string) (*workitemtracking.Comment, error)
+	FollowWorkItemFunc              func(int) (bool, error)
+	UnfollowWorkItemFunc            func(int) (bool, error)
+	DownloadAttachmentFunc          func(uuid.UUID, string) (io.ReadCloser, error)
+	GetWorkItemTypeFunc             func(string) (*workitemtracking.WorkItemType, error)
+	GetWorkItemTypesFunc            func() (*[]workitemtracking.WorkItemType, error)
+	GetRequiredFieldsFunc           func(string) ([]string, error)
+	GetFieldDefinitionFunc          func(string, string) (*workitemtracking.WorkItemTypeFieldInstance, error)
+	GetWorkItemTypeStatesFunc       func(string) ([]api.WorkItemState, error)
+	GetWorkItemStatesFunc           func(string) ([]string, error)
+	GetWorkItemTypeFieldsFunc       func(string) (*[]workitemtracking.WorkItemTypeFieldWithReferences, error)
+	GetFieldsFunc                   func() (*[]workitemtracking.WorkItemField, error)
+	GetStateForCategoryFunc         func(string, string) (string, error)
+	ListTagsFunc                    func() ([]api.Tag, error)
+	RenameTagFunc                   func(string, string) error
+	DeleteTagFunc                   func(string) error
+	GetQueryFunc                    func(string) (*workitemtracking.QueryHierarchyItem, error)
+	ListQueriesFunc                 func(string, int) (*[]workitemtracking.QueryHierarchyItem, error)
+	GetQueryChildrenFunc            func(string) (*[]workitemtracking.QueryHierarchyItem, error)
+	ExecuteQueryFunc                func(string, int) (*[]workitemtracking.WorkItem, error)
+	GetQueryWIQLFunc                func(string) (string, error)
+	GetQueryDefinitionFunc          func(string) (*api.QueryDefinition, error)
+	GetCurrentUserFunc              func() (*api.User, error)
+	SearchUsersFunc                 func(string) ([]api.User, error)
+	GetMyTeamsFunc                  func() ([]string, error)
+	GetCurrentIterationFunc         func(string) (*work.TeamSettingsIteration, error)
+	GetTeamIterationsFunc           func(string, string) ([]work.TeamSettingsIteration, error)
+	ListAreaPathsFunc               func(int) ([]string, error)
+	ListIterationPathsFunc          func(int) ([]string, error)
+	QueryAnalyticsFunc              func(string, url.Values) ([]map[string]interface{}, error)
+	GetBuildSummaryFunc             func(int) (*api.BuildSummary, error)
+	GetPullRequestFunc              func(int) (*api.PullRequestSummary, error)
+
+	mu    sync.Mutex
+	calls []string
+}
+
+var _ api.APIClient = (*Client)(nil)
+
+// GetOrganizationURL calls GetOrganizationURLFunc
+func (m *Client) GetOrganizationURL() (r0 string) {
+	m.record("GetOrganizationURL")
+	if m.GetOrganizationURLFunc == nil {
+		return
+	}
+	return m.GetOrganizationURLFunc()
+}
+
+// GetProject calls GetProjectFunc
+func (m *Client) GetProject() (r0 string) {
+	m.record("GetProject")
+	if m.GetProjectFunc == nil {
+		return
+	}
+	return m.GetProjectFunc()
+}
+
+// GetContext calls GetContextFunc
+func (m *Client) GetContext() (r0 context.Context) {
+	m.record("GetContext")
+	if m.GetContextFunc == nil {
+		return
+	}
+	return m.GetContextFunc()
+}
+
+// SetReadOnly calls SetReadOnlyFunc
+func (m *Client) SetReadOnly(p0 bool) {
+	m.record("SetReadOnly")
+	if m.SetReadOnlyFunc == nil {
+		return
+	}
+	m.SetReadOnlyFunc(p0)
+}
+
+// ReadOnly calls ReadOnlyFunc
+func (m *Client) ReadOnly() (r0 bool) {
+	m.record("ReadOnly")
+	if m.ReadOnlyFunc == nil {
+		return
+	}
+	return m.ReadOnlyFunc()
+}
+
+// WorkItemURL calls WorkItemURLFunc
+func (m *Client) WorkItemURL(p0 int) (r0 string) {
+	m.record("WorkItemURL")
+	if m.WorkItemURLFunc == nil {
+		return
+	}
+	return m.WorkItemURLFunc(p0)
+}
+
+// WorkItemAPIURL calls WorkItemAPIURLFunc
+func (m *Client) WorkItemAPIURL(p0 int) (r0 string) {
+	m.record("WorkItemAPIURL")
+	if m.WorkItemAPIURLFunc == nil {
+		return
+	}
+	return m.WorkItemAPIURLFunc(p0)
+}
+
+// GetWorkItem calls GetWorkItemFunc
+func (m *Client) GetWorkItem(p0 int) (r0 *workitemtracking.WorkItem, r1 error) {
+	m.record("GetWorkItem")
+	if m.GetWorkItemFunc == nil {
+		r1 = notMocked("GetWorkItem")
+		return
+	}
+	return m.GetWorkItemFunc(p0)
+}
+
+// GetWorkItems calls GetWorkItemsFunc
+func (m *Client) GetWorkItems(p0 []int) (r0 []workitemtracking.WorkItem, r1 error) {
+	m.record("GetWorkItems")
+	if m.GetWorkItemsFunc == nil {
+		r1 = notMocked("GetWorkItems")
+		return
+	}
+	return m.GetWorkItemsFunc(p0)
+}
+
+// GetWorkItemsAsOf calls GetWorkItemsAsOfFunc
+func (m *Client) GetWorkItemsAsOf(p0 []int, p1 time.Time) (r0 []workitemtracking.WorkItem, r1 error) {
+	m.record("GetWorkItemsAsOf")
+	if m.GetWorkItemsAsOfFunc == nil {
+		r1 = notMocked("GetWorkItemsAsOf")
+		return
+	}
+	return m.GetWorkItemsAsOfFunc(p0, p1)
+}
+
+// GetWorkItemFields calls GetWorkItemFieldsFunc
+func (m *Client) GetWorkItemFields(p0 []int, p1 []string) (r0 []workitemtracking.WorkItem, r1 error) {
+	m.record("GetWorkItemFields")
+	if m.GetWorkItemFieldsFunc == nil {
+		r1 = notMocked("GetWorkItemFields")
+		return
+	}
+	return m.GetWorkItemFieldsFunc(p0, p1)
+}
+
+// ListWorkItems calls ListWorkItemsFunc
+func (m *Client) ListWorkItems(p0 string, p1 int) (r0 *[]workitemtracking.WorkItem, r1 error) {
+	m.record("ListWorkItems")
+	if m.ListWorkItemsFunc == nil {
+		r1 = notMocked("ListWorkItems")
+		return
+	}
+	return m.ListWorkItemsFunc(p0, p1)
+}
+
+// QueryWorkItemIDs calls QueryWorkItemIDsFunc
+func (m *Client) QueryWorkItemIDs(p0 string, p1 int) (r0 []int, r1 error) {
+	m.record("QueryWorkItemIDs")
+	if m.QueryWorkItemIDsFunc == nil {
+		r1 = notMocked("QueryWorkItemIDs")
+		return
+	}
+	return m.QueryWorkItemIDsFunc(p0, p1)
+}
+
+// CreateWorkItem calls CreateWorkItemFunc
+func (m *Client) CreateWorkItem(p0 string, p1 map[string]interface{}, p2 int) (r0 *workitemtracking.WorkItem, r1 error) {
+	m.record("CreateWorkItem")
+	if m.CreateWorkItemFunc == nil {
+		r1 = notMocked("CreateWorkItem")
+		return
+	}
+	return m.CreateWorkItemFunc(p0, p1, p2)
+}
+
+// CreateWorkItemWithRelations calls CreateWorkItemWithRelationsFunc
+func (m *Client) CreateWorkItemWithRelations(p0 string, p1 map[string]interface{}, p2 []api.WorkItemLink) (r0 *workitemtracking.WorkItem, r1 error) {
+	m.record("CreateWorkItemWithRelations")
+	if m.CreateWorkItemWithRelationsFunc == nil {
+		r1 = notMocked("CreateWorkItemWithRelations")
+		return
+	}
+	return m.CreateWorkItemWithRelationsFunc(p0, p1, p2)
+}
+
+// CreateWorkItems calls CreateWorkItemsFunc
+func (m *Client) CreateWorkItems(p0 []api.NewWorkItem) (r0 []api.CreateResult) {
+	m.record("CreateWorkItems")
+	if m.CreateWorkItemsFunc == nil {
+		return
+	}
+	return m.CreateWorkItemsFunc(p0)
+}
+
+// UpdateWorkItem calls UpdateWorkItemFunc
+func (m *Client) UpdateWorkItem(p0 int, p1 map[string]interface{}) (r0 *workitemtracking.WorkItem, r1 error) {
+	m.record("UpdateWorkItem")
+	if m.UpdateWorkItemFunc == nil {
+		r1 = notMocked("UpdateWorkItem")
+		return
+	}
+	return m.UpdateWorkItemFunc(p0, p1)
+}
+
+// UpdateWorkItemAtRevision calls UpdateWorkItemAtRevisionFunc
+func (m *Client) UpdateWorkItemAtRevision(p0 int, p1 int, p2 map[string]interface{}) (r0 *workitemtracking.WorkItem, r1 error) {
+	m.record("UpdateWorkItemAtRevision")
+	if m.UpdateWorkItemAtRevisionFunc == nil {
+		r1 = notMocked("UpdateWorkItemAtRevision")
+		return
+	}
+	return m.UpdateWorkItemAtRevisionFunc(p0, p1, p2)
+}
+
+// UpdateWorkItemWithLinks calls UpdateWorkItemWithLinksFunc
+func (m *Client) UpdateWorkItemWithLinks(p0 int, p1 map[string]interface{}, p2 []api.WorkItemLink) (r0 *workitemtracking.WorkItem, r1 error) {
+	m.record("UpdateWorkItemWithLinks")
+	if m.UpdateWorkItemWithLinksFunc == nil {
+		r1 = notMocked("UpdateWorkItemWithLinks")
+		return
+	}
+	return m.UpdateWorkItemWithLinksFunc(p0, p1, p2)
+}
+
+// DeleteWorkItem calls DeleteWorkItemFunc
+func (m *Client) DeleteWorkItem(p0 int) (r0 error) {
+	m.record("DeleteWorkItem")
+	if m.DeleteWorkItemFunc == nil {
+		r0 = notMocked("DeleteWorkItem")
+		return
+	}
+	return m.DeleteWorkItemFunc(p0)
+}
+
+// ValidateWorkItem calls ValidateWorkItemFunc
+func (m *Client) ValidateWorkItem(p0 string, p1 map[string]interface{}, p2 []api.WorkItemLink) (r0 []api.FieldError, r1 error) {
+	m.record("ValidateWorkItem")
+	if m.ValidateWorkItemFunc == nil {
+		r1 = notMocked("ValidateWorkItem")
+		return
+	}
+	return m.ValidateWorkItemFunc(p0, p1, p2)
+}
+
+// ParentLink calls ParentLinkFunc
+func (m *Client) ParentLink(p0 int) (r0 api.WorkItemLink) {
+	m.record("ParentLink")
+	if m.ParentLinkFunc == nil {
+		return
+	}
+	return m.ParentLinkFunc(p0)
+}
+
+// RelatedLink calls RelatedLinkFunc
+func (m *Client) RelatedLink(p0 int) (r0 api.WorkItemLink) {
+	m.record("RelatedLink")
+	if m.RelatedLinkFunc == nil {
+		return
+	}
+	return m.RelatedLinkFunc(p0)
+}
+
+// GetUpdates calls GetUpdatesFunc
+func (m *Client) GetUpdates(p0 int) (r0 []workitemtracking.WorkItemUpdate, r1 error) {
+	m.record("GetUpdates")
+	if m.GetUpdatesFunc == nil {
+		r1 = notMocked("GetUpdates")
+		return
+	}
+	return m.GetUpdatesFunc(p0)
+}
+
+// GetWorkItemUpdates calls GetWorkItemUpdatesFunc
+func (m *Client) GetWorkItemUpdates(p0 int) (r0 []api.WorkItemRevision, r1 error) {
+	m.record("GetWorkItemUpdates")
+	if m.GetWorkItemUpdatesFunc == nil {
+		r1 = notMocked("GetWorkItemUpdates")
+		return
+	}
+	return m.GetWorkItemUpdatesFunc(p0)
+}
+
+// GetComments calls GetCommentsFunc
+func (m *Client) GetComments(p0 int) (r0 []workitemtracking.Comment, r1 error) {
+	m.record("GetComments")
+	if m.GetCommentsFunc == nil {
+		r1 = notMocked("GetComments")
+		return
+	}
+	return m.GetCommentsFunc(p0)
+}
+
+// AddComment calls AddCommentFunc
+func (m *Client) AddComment(p0 int, p1 string) (r0 *workitemtracking.Comment, r1 error) {
+	m.record("AddComment")
+	if m.AddCommentFunc == nil {
+		r1 = notMocked("AddComment")
+		return
+	}
+	return m.AddCommentFunc(p0, p1)
+}
+
+// FollowWorkItem calls FollowWorkItemFunc
+func (m *Client) FollowWorkItem(p0 int) (r0 bool, r1 error) {
+	m.record("FollowWorkItem")
+	if m.FollowWorkItemFunc == nil {
+		r1 = notMocked("FollowWorkItem")
+		return
+	}
+	return m.FollowWorkItemFunc(p0)
+}
+
+// UnfollowWorkItem calls UnfollowWorkItemFunc
+func (m *Client) UnfollowWorkItem(p0 int) (r0 bool, r1 error) {
+	m.record("UnfollowWorkItem")
+	if m.UnfollowWorkItemFunc == nil {
+		r1 = notMocked("UnfollowWorkItem")
+		return
+	}
+	return m.UnfollowWorkItemFunc(p0)
+}
+
+// DownloadAttachment calls DownloadAttachmentFunc
+func (m *Client) DownloadAttachment(p0 uuid.UUID, p1 string) (r0 io.ReadCloser, r1 error) {
+	m.record("DownloadAttachment")
+	if m.DownloadAttachmentFunc == nil {
+		r1 = notMocked("DownloadAttachment")
+		return
+	}
+	return m.DownloadAttachmentFunc(p0, p1)
+}
+
+// GetWorkItemType calls GetWorkItemTypeFunc
+func (m *Client) GetWorkItemType(p0 string) (r0 *workitemtracking.WorkItemType, r1 error) {
+	m.record("GetWorkItemType")
+	if m.GetWorkItemTypeFunc == nil {
+		r1 = notMocked("GetWorkItemType")
+		return
+	}
+	return m.GetWorkItemTypeFunc(p0)
+}
+
+// GetWorkItemTypes calls GetWorkItemTypesFunc
+func (m *Client) GetWorkItemTypes() (r0 *[]workitemtracking.WorkItemType, r1 error) {
+	m.record("GetWorkItemTypes")
+	if m.GetWorkItemTypesFunc == nil {
+		r1 = notMocked("GetWorkItemTypes")
+		return
+	}
+	return m.GetWorkItemTypesFunc()
+}
+
+// GetRequiredFields calls GetRequiredFieldsFunc
+func (m *Client) GetRequiredFields(p0 string) (r0 []string, r1 error) {
+	m.record("GetRequiredFields")
+	if m.GetRequiredFieldsFunc == nil {
+		r1 = notMocked("GetRequiredFields")
+		return
+	}
+	return m.GetRequiredFieldsFunc(p0)
+}
+
+// GetFieldDefinition calls GetFieldDefinitionFunc
+func (m *Client) GetFieldDefinition(p0 string, p1 string) (r0 *workitemtracking.WorkItemTypeFieldInstance, r1 error) {
+	m.record("GetFieldDefinition")
+	if m.GetFieldDefinitionFunc == nil {
+		r1 = notMocked("GetFieldDefinition")
+		return
+	}
+	return m.GetFieldDefinitionFunc(p0, p1)
+}
+
+// GetWorkItemTypeStates calls GetWorkItemTypeStatesFunc
+func (m *Client) GetWorkItemTypeStates(p0 string) (r0 []api.WorkItemState, r1 error) {
+	m.record("GetWorkItemTypeStates")
+	if m.GetWorkItemTypeStatesFunc == nil {
+		r1 = notMocked("GetWorkItemTypeStates")
+		return
+	}
+	return m.GetWorkItemTypeStatesFunc(p0)
+}
+
+// GetWorkItemStates calls GetWorkItemStatesFunc
+func (m *Client) GetWorkItemStates(p0 string) (r0 []string, r1 error) {
+	m.record("GetWorkItemStates")
+	if m.GetWorkItemStatesFunc == nil {
+		r1 = notMocked("GetWorkItemStates")
+		return
+	}
+	return m.GetWorkItemStatesFunc(p0)
+}
+
+// GetWorkItemTypeFields calls GetWorkItemTypeFieldsFunc
+func (m *Client) GetWorkItemTypeFields(p0 string) (r0 *[]workitemtracking.WorkItemTypeFieldWithReferences, r1 error) {
+	m.record("GetWorkItemTypeFields")
+	if m.GetWorkItemTypeFieldsFunc == nil {
+		r1 = notMocked("GetWorkItemTypeFields")
+		return
+	}
+	return m.GetWorkItemTypeFieldsFunc(p0)
+}
+
+// GetFields calls GetFieldsFunc
+func (m *Client) GetFields() (r0 *[]workitemtracking.WorkItemField, r1 error) {
+	m.record("GetFields")
+	if m.GetFieldsFunc == nil {
+		r1 = notMocked("GetFields")
+		return
+	}
+	return m.GetFieldsFunc()
+}
+
+// GetStateForCategory calls GetStateForCategoryFunc
+func (m *Client) GetStateForCategory(p0 string, p1 string) (r0 string, r1 error) {
+	m.record("GetStateForCategory")
+	if m.GetStateForCategoryFunc == nil {
+		r1 = notMocked("GetStateForCategory")
+		return
+	}
+	return m.GetStateForCategoryFunc(p0, p1)
+}
+
+// ListTags calls ListTagsFunc
+func (m *Client) ListTags() (r0 []api.Tag, r1 error) {
+	m.record("ListTags")
+	if m.ListTagsFunc == nil {
+		r1 = notMocked("ListTags")
+		return
+	}
+	return m.ListTagsFunc()
+}
+
+// RenameTag calls RenameTagFunc
+func (m *Client) RenameTag(p0 string, p1 string) (r0 error) {
+	m.record("RenameTag")
+	if m.RenameTagFunc == nil {
+		r0 = notMocked("RenameTag")
+		return
+	}
+	return m.RenameTagFunc(p0, p1)
+}
+
+// DeleteTag calls DeleteTagFunc
+func (m *Client) DeleteTag(p0 string) (r0 error) {
+	m.record("DeleteTag")
+	if m.DeleteTagFunc == nil {
+		r0 = notMocked("DeleteTag")
+		return
+	}
+	return m.DeleteTagFunc(p0)
+}
+
+// GetQuery calls GetQueryFunc
+func (m *Client) GetQuery(p0 string) (r0 *workitemtracking.QueryHierarchyItem, r1 error) {
+	m.record("GetQuery")
+	if m.GetQueryFunc == nil {
+		r1 = notMocked("GetQuery")
+		return
+	}
+	return m.GetQueryFunc(p0)
+}
+
+// ListQueries calls ListQueriesFunc
+func (m *Client) ListQueries(p0 string, p1 int) (r0 *[]workitemtracking.QueryHierarchyItem, r1 error) {
+	m.record("ListQueries")
+	if m.ListQueriesFunc == nil {
+		r1 = notMocked("ListQueries")
+		return
+	}
+	return m.ListQueriesFunc(p0, p1)
+}
+
+// GetQueryChildren calls GetQueryChildrenFunc
+func (m *Client) GetQueryChildren(p0 string) (r0 *[]workitemtracking.QueryHierarchyItem, r1 error) {
+	m.record("GetQueryChildren")
+	if m.GetQueryChildrenFunc == nil {
+		r1 = notMocked("GetQueryChildren")
+		return
+	}
+	return m.GetQueryChildrenFunc(p0)
+}
+
+// ExecuteQuery calls ExecuteQueryFunc
+func (m *Client) ExecuteQuery(p0 string, p1 int) (r0 *[]workitemtracking.WorkItem, r1 error) {
+	m.record("ExecuteQuery")
+	if m.ExecuteQueryFunc == nil {
+		r1 = notMocked("ExecuteQuery")
+		return
+	}
+	return m.ExecuteQueryFunc(p0, p1)
+}
+
+// GetQueryWIQL calls GetQueryWIQLFunc
+func (m *Client) GetQueryWIQL(p0 string) (r0 string, r1 error) {
+	m.record("GetQueryWIQL")
+	if m.GetQueryWIQLFunc == nil {
+		r1 = notMocked("GetQueryWIQL")
+		return
+	}
+	return m.GetQueryWIQLFunc(p0)
+}
+
+// GetQueryDefinition calls GetQueryDefinitionFunc
+func (m *Client) GetQueryDefinition(p0 string) (r0 *api.QueryDefinition, r1 error) {
+	m.record("GetQueryDefinition")
+	if m.GetQueryDefinitionFunc == nil {
+		r1 = notMocked("GetQueryDefinition")
+		return
+	}
+	return m.GetQueryDefinitionFunc(p0)
+}
+
+// GetCurrentUser calls GetCurrentUserFunc
+func (m *Client) GetCurrentUser() (r0 *api.User, r1 error) {
+	m.record("GetCurrentUser")
+	if m.GetCurrentUserFunc == nil {
+		r1 = notMocked("GetCurrentUser")
+		return
+	}
+	return m.GetCurrentUserFunc()
+}
+
+// SearchUsers calls SearchUsersFunc
+func (m *Client) SearchUsers(p0 string) (r0 []api.User, r1 error) {
+	m.record("SearchUsers")
+	if m.SearchUsersFunc == nil {
+		r1 = notMocked("SearchUsers")
+		return
+	}
+	return m.SearchUsersFunc(p0)
+}
+
+// GetMyTeams calls GetMyTeamsFunc
+func (m *Client) GetMyTeams() (r0 []string, r1 error) {
+	m.record("GetMyTeams")
+	if m.GetMyTeamsFunc == nil {
+		r1 = notMocked("GetMyTeams")
+		return
+	}
+	return m.GetMyTeamsFunc()
+}
+
+// GetCurrentIteration calls GetCurrentIterationFunc
+func (m *Client) GetCurrentIteration(p0 string) (r0 *work.TeamSettingsIteration, r1 error) {
+	m.record("GetCurrentIteration")
+	if m.GetCurrentIterationFunc == nil {
+		r1 = notMocked("GetCurrentIteration")
+		return
+	}
+	return m.GetCurrentIterationFunc(p0)
+}
+
+// GetTeamIterations calls GetTeamIterationsFunc
+func (m *Client) GetTeamIterations(p0 string, p1 string) (r0 []work.TeamSettingsIteration, r1 error) {
+	m.record("GetTeamIterations")
+	if m.GetTeamIterationsFunc == nil {
+		r1 = notMocked("GetTeamIterations")
+		return
+	}
+	return m.GetTeamIterationsFunc(p0, p1)
+}
+
+// ListAreaPaths calls ListAreaPathsFunc
+func (m *Client) ListAreaPaths(p0 int) (r0 []string, r1 error) {
+	m.record("ListAreaPaths")
+	if m.ListAreaPathsFunc == nil {
+		r1 = notMocked("ListAreaPaths")
+		return
+	}
+	return m.ListAreaPathsFunc(p0)
+}
+
+// ListIterationPaths calls ListIterationPathsFunc
+func (m *Client) ListIterationPaths(p0 int) (r0 []string, r1 error) {
+	m.record("ListIterationPaths")
+	if m.ListIterationPathsFunc == nil {
+		r1 = notMocked("ListIterationPaths")
+		return
+	}
+	return m.ListIterationPathsFunc(p0)
+}
+
+// QueryAnalytics calls QueryAnalyticsFunc
+func (m *Client) QueryAnalytics(p0 string, p1 url.Values) (r0 []map[string]interface{}, r1 error) {
+	m.record("QueryAnalytics")
+	if m.QueryAnalyticsFunc == nil {
+		r1 = notMocked("QueryAnalytics")
+		return
+	}
+	return m.QueryAnalyticsFunc(p0, p1)
+}
+
+// GetBuildSummary calls GetBuildSummaryFunc
+func (m *Client) GetBuildSummary(p0 int) (r0 *api.BuildSummary, r1 error) {
+	m.record("GetBuildSummary")
+	if m.GetBuildSummaryFunc == nil {
+		r1 = notMocked("GetBuildSummary")
+		return
+	}
+	return m.GetBuildSummaryFunc(p0)
+}
+
+// GetPullRequest calls GetPullRequestFunc
+func (m *Client) GetPullRequest(p0 int) (r0 *api.PullRequestSummary, r1 error) {
+	m.record("GetPullRequest")
+	if m.GetPullRequestFunc == nil {
+		r1 = notMocked("GetPullRequest")
+		return
+	}
+	return m.GetPullRequestFunc(p0)
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// AttachedFileRelation is the relation type of files attached to a work item
+const AttachedFileRelation = "AttachedFile"
+
+// DownloadAttachment opens the content of a work item attachment. The caller closes the reader.
+func (c *Client) DownloadAttachment(id uuid.UUID, fileName string) (io.ReadCloser, error) {
+	download := true
+	content, err := c.workItemClient.GetAttachmentContent(c.ctx, workitemtracking.GetAttachmentContentArgs{
+		Id:       &id,
+		Project:  &c.project,
+		FileName: &fileName,
+		Download: &download,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download attachment %s: %w", id, err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"sync"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// DefaultConcurrency is the number of work items bulk operations process at
+// once unless SetConcurrency is called
+const DefaultConcurrency = 4
+
+// concurrency is the number of calls ForEach runs at once
+var concurrency = DefaultConcurrency
+
+// SetConcurrency sets the number of work items bulk operations, such as
+// ForEach and CreateWorkItems, process at once. Values below 1 mean 1.
+func SetConcurrency(n int) {
+	concurrency = max(n, 1)
+}
+
+// ForEach calls fn for every index below n, running up to the configured
+// number of calls at once, and returns when all have finished
+func ForEach(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// NewWorkItem is a work item to create with CreateWorkItems
+type NewWorkItem struct {
+	Type      string
+	Fields    map[string]interface{}
+	Relations []WorkItemLink
+}
+
+// CreateResult is the outcome of creating one work item of a batch
+type CreateResult struct {
+	WorkItem *workitemtracking.WorkItem
+	Error    error
+}
+
+// CreateWorkItems creates several work items concurrently, a few at a time (see SetConcurrency).
+// Results are in the order of items and one failure does not stop the others.
+// The items are not created in order, so IDs may not follow it.
+func (c *Client) CreateWorkItems(items []NewWorkItem) []CreateResult {
+	results := make([]CreateResult, len(items))
+	if err := c.checkWritable(); err != nil {
+		for i := range results {
+			results[i].Error = err
+		}
+		return results
+	}
+
+	ForEach(len(items), func(i int) {
+		item := items[i]
+		workItem, err := c.CreateWorkItemWithRelations(item.Type, item.Fields, item.Relations)
+		results[i] = CreateResult{WorkItem: workItem, Error: err}
+	})
+	return results
+}
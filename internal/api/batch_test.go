@@ -0,0 +1,38 @@
+package api
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestForEach(t *testing.T) {
+	defer SetConcurrency(DefaultConcurrency)
+	SetConcurrency(3)
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	seen := make([]bool, 20)
+	ForEach(len(seen), func(i int) {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		seen[i] = true
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	for i, ok := range seen {
+		if !ok {
+			t.Errorf("index %d was not processed", i)
+		}
+	}
+	if peak > 3 {
+		t.Errorf("%d calls ran at once, want at most 3", peak)
+	}
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Read-only fields describing where a work item sits on its team's board
+const (
+	BoardColumnField     = "System.BoardColumn"
+	BoardColumnDoneField = "System.BoardColumnDone"
+	BoardLaneField       = "System.BoardLane"
+)
+
+// KanbanColumnField returns the writable WEF_<board>_Kanban.Column field that
+// moves a work item between board columns. Each board has its own field, so
+// when a work item is on several boards the one whose value matches
+// System.BoardColumn (the board of the work item's area path) is used.
+func KanbanColumnField(fields map[string]interface{}) (string, error) {
+	var candidates []string
+	for name := range fields {
+		if strings.HasPrefix(name, "WEF_") && strings.HasSuffix(name, "_Kanban.Column") {
+			candidates = append(candidates, name)
+		}
+	}
+	sort.Strings(candidates)
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("work item is not on a board")
+	case 1:
+		return candidates[0], nil
+	}
+
+	current, _ := fields[BoardColumnField].(string)
+	var matches []string
+	for _, name := range candidates {
+		if value, _ := fields[name].(string); value != "" && value == current {
+			matches = append(matches, name)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("work item is on several boards; set one of %s with --field <name>=<column>", strings.Join(candidates, ", "))
+}
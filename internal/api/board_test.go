@@ -0,0 +1,60 @@
+package api
+
+import "testing"
+
+func TestKanbanColumnField(t *testing.T) {
+	const teamA = "WEF_6CB513B6E70E43499D9FC94E5BBFB784_Kanban.Column"
+	const teamB = "WEF_1F2E3D4C5B6A47988796A5B4C3D2E1F0_Kanban.Column"
+
+	tests := []struct {
+		name    string
+		fields  map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "not on a board",
+			fields:  map[string]interface{}{"System.Title": "Fix login"},
+			wantErr: true,
+		},
+		{
+			name: "one board",
+			fields: map[string]interface{}{
+				BoardColumnField: "Active",
+				teamA:            "Active",
+				"WEF_6CB513B6E70E43499D9FC94E5BBFB784_Kanban.Column.Done": false,
+			},
+			want: teamA,
+		},
+		{
+			name: "several boards, current column decides",
+			fields: map[string]interface{}{
+				BoardColumnField: "QA",
+				teamA:            "QA",
+				teamB:            "Doing",
+			},
+			want: teamA,
+		},
+		{
+			name: "several boards in the same column",
+			fields: map[string]interface{}{
+				BoardColumnField: "New",
+				teamA:            "New",
+				teamB:            "New",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KanbanColumnField(tt.fields)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KanbanColumnField() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("KanbanColumnField() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+)
+
+// ArtifactLinkRelation is the relation type of links to builds, releases, commits and pull requests
+const ArtifactLinkRelation = "ArtifactLink"
+
+// Artifact is a parsed artifact URI such as vstfs:///Build/Build/1234
+type Artifact struct {
+	Tool string // e.g. Build, Git, ReleaseManagement
+	Type string // e.g. Build, PullRequestId, Commit
+	ID   string
+}
+
+// IsBuild reports whether the artifact is a pipeline build
+func (a Artifact) IsBuild() bool {
+	return strings.EqualFold(a.Tool, "Build") && strings.EqualFold(a.Type, "Build")
+}
+
+// ShortID returns the last segment of the ID; Git artifact IDs are
+// <project>/<repository>/<id>, so this is the commit or pull request ID
+func (a Artifact) ShortID() string {
+	if i := strings.LastIndex(a.ID, "/"); i >= 0 {
+		return a.ID[i+1:]
+	}
+	return a.ID
+}
+
+// ParseArtifactURL splits a vstfs:/// artifact URI into its tool, type and ID
+func ParseArtifactURL(artifactURL string) (Artifact, bool) {
+	const prefix = "vstfs:///"
+	if !strings.HasPrefix(strings.ToLower(artifactURL), prefix) {
+		return Artifact{}, false
+	}
+
+	parts := strings.SplitN(artifactURL[len(prefix):], "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return Artifact{}, false
+	}
+
+	id, err := url.PathUnescape(parts[2])
+	if err != nil {
+		id = parts[2]
+	}
+	return Artifact{Tool: parts[0], Type: parts[1], ID: id}, true
+}
+
+// BuildSummary is the part of a build shown next to linked work items
+type BuildSummary struct {
+	ID         int    `json:"id"`
+	Number     string `json:"number"`
+	Definition string `json:"definition,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Result     string `json:"result,omitempty"`
+	URL        string `json:"url"`
+}
+
+// String describes the build, e.g. "CI 20240101.3 (succeeded)"
+func (b BuildSummary) String() string {
+	name := b.Number
+	if b.Definition != "" {
+		name = b.Definition + " " + b.Number
+	}
+	outcome := b.Result
+	if outcome == "" || outcome == "none" {
+		outcome = b.Status
+	}
+	if outcome == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, outcome)
+}
+
+// GetBuildSummary looks up the number, pipeline and result of a build
+func (c *Client) GetBuildSummary(id int) (*BuildSummary, error) {
+	buildClient, err := c.getBuildClient()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := buildClient.GetBuild(c.ctx, build.GetBuildArgs{
+		Project: &c.project,
+		BuildId: &id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build %d: %w", id, err)
+	}
+
+	summary := &BuildSummary{
+		ID:     id,
+		Number: fmt.Sprintf("#%d", id),
+		URL:    BuildWebURL(c.organizationURL, c.project, id),
+	}
+	if b.BuildNumber != nil {
+		summary.Number = *b.BuildNumber
+	}
+	if b.Definition != nil && b.Definition.Name != nil {
+		summary.Definition = *b.Definition.Name
+	}
+	if b.Status != nil {
+		summary.Status = string(*b.Status)
+	}
+	if b.Result != nil {
+		summary.Result = string(*b.Result)
+	}
+
+	return summary, nil
+}
+
+// getBuildClient creates the build client on first use, so commands that
+// never look at builds don't pay for the extra connection lookup
+func (c *Client) getBuildClient() (build.Client, error) {
+	c.buildClientOnce.Do(func() {
+		c.buildClient, c.buildClientErr = build.NewClient(c.ctx, c.connection)
+		if c.buildClientErr != nil {
+			c.buildClientErr = fmt.Errorf("failed to create build client: %w", c.buildClientErr)
+		}
+	})
+	return c.buildClient, c.buildClientErr
+}
+
+// BuildWebURL builds the browser URL of a build's results page
+func BuildWebURL(organizationURL, project string, id int) string {
+	return fmt.Sprintf("%s/%s/_build/results?buildId=%d", strings.TrimSuffix(organizationURL, "/"), url.PathEscape(project), id)
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// ListAreaPaths returns the project's area paths (e.g. "Project\Team A"), up to depth levels below the root
+func (c *Client) ListAreaPaths(depth int) ([]string, error) {
+	return c.listClassificationPaths(workitemtracking.TreeStructureGroupValues.Areas, depth)
+}
+
+// ListIterationPaths returns the project's iteration paths (e.g. "Project\Sprint 1"), up to depth levels below the root
+func (c *Client) ListIterationPaths(depth int) ([]string, error) {
+	return c.listClassificationPaths(workitemtracking.TreeStructureGroupValues.Iterations, depth)
+}
+
+func (c *Client) listClassificationPaths(group workitemtracking.TreeStructureGroup, depth int) ([]string, error) {
+	root, err := c.workItemClient.GetClassificationNode(c.ctx, workitemtracking.GetClassificationNodeArgs{
+		Project:        &c.project,
+		StructureGroup: &group,
+		Depth:          &depth,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", group, err)
+	}
+
+	return classificationPaths(root, ""), nil
+}
+
+// classificationPaths flattens a classification tree into paths as used by
+// System.AreaPath and System.IterationPath, parents before their children
+func classificationPaths(node *workitemtracking.WorkItemClassificationNode, parent string) []string {
+	if node == nil || node.Name == nil {
+		return nil
+	}
+
+	path := *node.Name
+	if parent != "" {
+		path = parent + `\` + path
+	}
+
+	paths := []string{path}
+	if node.Children != nil {
+		for i := range *node.Children {
+			paths = append(paths, classificationPaths(&(*node.Children)[i], path)...)
+		}
+	}
+	return paths
+}
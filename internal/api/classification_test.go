@@ -0,0 +1,37 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestClassificationPaths(t *testing.T) {
+	node := func(name string, children ...workitemtracking.WorkItemClassificationNode) workitemtracking.WorkItemClassificationNode {
+		n := workitemtracking.WorkItemClassificationNode{Name: &name}
+		if len(children) > 0 {
+			n.Children = &children
+		}
+		return n
+	}
+
+	root := node("Fabrikam",
+		node("Team A", node("Backend")),
+		node("Team B"),
+	)
+
+	want := []string{
+		`Fabrikam`,
+		`Fabrikam\Team A`,
+		`Fabrikam\Team A\Backend`,
+		`Fabrikam\Team B`,
+	}
+	if got := classificationPaths(&root, ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("classificationPaths() = %v, want %v", got, want)
+	}
+
+	if got := classificationPaths(nil, ""); got != nil {
+		t.Errorf("classificationPaths(nil) = %v, want nil", got)
+	}
+}
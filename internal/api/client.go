@@ -3,9 +3,13 @@ package api
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/identity"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 )
 
@@ -17,6 +21,23 @@ type Client struct {
 	organizationURL string
 	project         string
 	ctx             context.Context
+	readOnly        bool
+
+	// states caches GetWorkItemTypeStates by lowercase type name
+	states   map[string][]WorkItemState
+	statesMu sync.Mutex
+
+	buildClient     build.Client
+	buildClientErr  error
+	buildClientOnce sync.Once
+
+	gitClient     git.Client
+	gitClientErr  error
+	gitClientOnce sync.Once
+
+	identityClient     identity.Client
+	identityClientErr  error
+	identityClientOnce sync.Once
 }
 
 // NewClient creates a new Azure DevOps API client
@@ -34,7 +55,10 @@ func NewClient(organizationURL, project, token string) (*Client, error) {
 	}
 
 	// Create a connection to Azure DevOps
-	connection := azuredevops.NewPatConnection(organizationURL, token)
+	connection, err := newConnection(organizationURL, token)
+	if err != nil {
+		return nil, err
+	}
 
 	ctx := context.Background()
 
@@ -60,6 +84,16 @@ func NewClient(organizationURL, project, token string) (*Client, error) {
 	}, nil
 }
 
+// newConnection creates a PAT connection whose requests are retried per the
+// retry policy, and recorded or replayed in fixture mode
+func newConnection(organizationURL, token string) (*azuredevops.Connection, error) {
+	installRetryTransport()
+	if err := installFixtureTransport(organizationURL); err != nil {
+		return nil, err
+	}
+	return azuredevops.NewPatConnection(organizationURL, token), nil
+}
+
 // GetOrganizationURL returns the organization URL
 func (c *Client) GetOrganizationURL() string {
 	return c.organizationURL
@@ -70,6 +104,16 @@ func (c *Client) GetProject() string {
 	return c.project
 }
 
+// WorkItemURL returns the browser URL of a work item in the client's project
+func (c *Client) WorkItemURL(id int) string {
+	return WorkItemWebURL(c.organizationURL, c.project, id)
+}
+
+// WorkItemAPIURL returns the REST URL of a work item, which links to it use
+func (c *Client) WorkItemAPIURL(id int) string {
+	return fmt.Sprintf("%s/_apis/wit/workItems/%d", c.organizationURL, id)
+}
+
 // GetContext returns the context
 func (c *Client) GetContext() context.Context {
 	return c.ctx
@@ -98,3 +98,139 @@ func TestClient_Getters(t *testing.T) {
 		t.Log("Note: Client creation succeeded with fake token - this may mean we're not actually connecting")
 	}
 }
+
+func TestWorkItemWebURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		orgURL  string
+		project string
+		id      int
+		want    string
+	}{
+		{"simple project", "https://dev.azure.com/myorg", "MyProject", 42, "https://dev.azure.com/myorg/MyProject/_workitems/edit/42"},
+		{"project with spaces", "https://dev.azure.com/myorg/", "My Project", 7, "https://dev.azure.com/myorg/My%20Project/_workitems/edit/7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WorkItemWebURL(tt.orgURL, tt.project, tt.id); got != tt.want {
+				t.Errorf("WorkItemWebURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeOrganizationURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"bare name", "myorg", "https://dev.azure.com/myorg", false},
+		{"bare name with whitespace", "  myorg  ", "https://dev.azure.com/myorg", false},
+		{"bare name with trailing slash", "myorg/", "https://dev.azure.com/myorg", false},
+		{"full URL", "https://dev.azure.com/myorg", "https://dev.azure.com/myorg", false},
+		{"full URL with trailing slash", "https://dev.azure.com/myorg/", "https://dev.azure.com/myorg", false},
+		{"URL with project", "https://dev.azure.com/myorg/MyProject", "https://dev.azure.com/myorg", false},
+		{"URL with work item path", "https://dev.azure.com/myorg/MyProject/_workitems/edit/42?view=full#top", "https://dev.azure.com/myorg", false},
+		{"URL without scheme", "dev.azure.com/myorg", "https://dev.azure.com/myorg", false},
+		{"http URL", "http://dev.azure.com/myorg", "https://dev.azure.com/myorg", false},
+		{"legacy visualstudio.com", "https://myorg.visualstudio.com", "https://dev.azure.com/myorg", false},
+		{"legacy with collection and project", "https://myorg.visualstudio.com/DefaultCollection/MyProject/", "https://dev.azure.com/myorg", false},
+		{"legacy without scheme", "myorg.visualstudio.com", "https://dev.azure.com/myorg", false},
+		{"empty", "", "", true},
+		{"missing organization", "https://dev.azure.com/", "", true},
+		{"server collection", "https://tfs.contoso.com/DefaultCollection", "https://tfs.contoso.com/DefaultCollection", false},
+		{"server collection with project", "https://tfs.contoso.com/DefaultCollection/MyProject/_workitems/edit/42", "https://tfs.contoso.com/DefaultCollection", false},
+		{"server with tfs directory and port", "http://tfs.contoso.com:8080/tfs/DefaultCollection/", "http://tfs.contoso.com:8080/tfs/DefaultCollection", false},
+		{"server without scheme", "tfs.contoso.com/DefaultCollection", "https://tfs.contoso.com/DefaultCollection", false},
+		{"server without collection", "https://tfs.contoso.com/", "", true},
+		{"server with tfs directory only", "https://tfs.contoso.com/tfs", "", true},
+		{"invalid characters", "my_org", "", true},
+		{"leading hyphen", "-myorg", "", true},
+		{"unsupported scheme", "ftp://dev.azure.com/myorg", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeOrganizationURL(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeOrganizationURL(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeOrganizationURL(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentIDFromURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{"with file name", "https://dev.azure.com/myorg/_apis/wit/attachments/0f1c2d3e-4a5b-6c7d-8e9f-a0b1c2d3e4f5?fileName=log.txt", "0f1c2d3e-4a5b-6c7d-8e9f-a0b1c2d3e4f5", false},
+		{"without query", "https://dev.azure.com/myorg/_apis/wit/attachments/0f1c2d3e-4a5b-6c7d-8e9f-a0b1c2d3e4f5", "0f1c2d3e-4a5b-6c7d-8e9f-a0b1c2d3e4f5", false},
+		{"work item URL", "https://dev.azure.com/myorg/_apis/wit/workItems/42", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AttachmentIDFromURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AttachmentIDFromURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("AttachmentIDFromURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseArtifactURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    Artifact
+		wantOK  bool
+		isBuild bool
+		shortID string
+	}{
+		{"build", "vstfs:///Build/Build/1234", Artifact{Tool: "Build", Type: "Build", ID: "1234"}, true, true, "1234"},
+		{"pull request", "vstfs:///Git/PullRequestId/proj%2Frepo%2F56", Artifact{Tool: "Git", Type: "PullRequestId", ID: "proj/repo/56"}, true, false, "56"},
+		{"work item URL", "https://dev.azure.com/myorg/_apis/wit/workItems/42", Artifact{}, false, false, ""},
+		{"incomplete", "vstfs:///Build/Build", Artifact{}, false, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseArtifactURL(tt.url)
+			if ok != tt.wantOK || got != tt.want {
+				t.Fatalf("ParseArtifactURL(%q) = %+v, %v; want %+v, %v", tt.url, got, ok, tt.want, tt.wantOK)
+			}
+			if got.IsBuild() != tt.isBuild || got.ShortID() != tt.shortID {
+				t.Errorf("IsBuild() = %v, ShortID() = %q; want %v, %q", got.IsBuild(), got.ShortID(), tt.isBuild, tt.shortID)
+			}
+		})
+	}
+}
+
+func TestBuildSummaryString(t *testing.T) {
+	tests := []struct {
+		summary BuildSummary
+		want    string
+	}{
+		{BuildSummary{Number: "20240101.3", Definition: "CI", Status: "completed", Result: "succeeded"}, "CI 20240101.3 (succeeded)"},
+		{BuildSummary{Number: "20240101.4", Definition: "CI", Status: "inProgress", Result: "none"}, "CI 20240101.4 (inProgress)"},
+		{BuildSummary{Number: "#7"}, "#7"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.summary.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
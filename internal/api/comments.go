@@ -0,0 +1,55 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// GetComments retrieves all discussion comments for a work item, oldest first
+func (c *Client) GetComments(id int) ([]workitemtracking.Comment, error) {
+	order := workitemtracking.CommentSortOrderValues.Asc
+	args := workitemtracking.GetCommentsArgs{
+		Project:    &c.project,
+		WorkItemId: &id,
+		Order:      &order,
+	}
+
+	var comments []workitemtracking.Comment
+	for {
+		page, err := c.workItemClient.GetComments(c.ctx, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get comments for work item %d: %w", id, err)
+		}
+
+		if page.Comments != nil {
+			comments = append(comments, *page.Comments...)
+		}
+
+		// Follow continuation tokens until all pages are read
+		if page.ContinuationToken == nil || *page.ContinuationToken == "" {
+			break
+		}
+		args.ContinuationToken = page.ContinuationToken
+	}
+
+	return comments, nil
+}
+
+// AddComment posts a new discussion comment on a work item
+func (c *Client) AddComment(id int, text string) (*workitemtracking.Comment, error) {
+	if err := c.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	comment, err := c.workItemClient.AddComment(c.ctx, workitemtracking.AddCommentArgs{
+		Request:    &workitemtracking.CommentCreate{Text: &text},
+		Project:    &c.project,
+		WorkItemId: &id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add comment to work item %d: %w", id, err)
+	}
+
+	return comment, nil
+}
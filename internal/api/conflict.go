@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// rereadAttempts is how often an update computed from a work item is re-read
+// and retried when the work item changes under it
+const rereadAttempts = 3
+
+// ErrRevisionConflict means a work item was changed by someone else between
+// reading it and writing it back
+var ErrRevisionConflict = errors.New("work item was changed by someone else; try again")
+
+// UpdateFromCurrent reads a work item, computes the fields to change from it
+// and updates it at the revision read, so a concurrent change is not
+// overwritten. On a conflict the work item is read again and the fields are
+// recomputed. When fields returns none, nothing is updated and nil is returned.
+func UpdateFromCurrent(client APIClient, id int, fields func(*workitemtracking.WorkItem) (map[string]interface{}, error)) (*workitemtracking.WorkItem, error) {
+	for attempt := 1; ; attempt++ {
+		workItem, err := client.GetWorkItem(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get work item %d: %w", id, err)
+		}
+		if workItem.Rev == nil {
+			return nil, fmt.Errorf("work item %d has no revision", id)
+		}
+
+		changes, err := fields(workItem)
+		if err != nil || len(changes) == 0 {
+			return nil, err
+		}
+
+		updated, err := client.UpdateWorkItemAtRevision(id, *workItem.Rev, changes)
+		if errors.Is(err, ErrRevisionConflict) && attempt < rereadAttempts {
+			continue
+		}
+		return updated, err
+	}
+}
+
+// isTestOperationFailure reports whether err is the service rejecting a JSON
+// patch "test" operation, e.g. on /rev when the revision has moved on
+func isTestOperationFailure(err error) bool {
+	wrapped, ok := asWrappedError(err)
+	return ok && wrapped.TypeKey != nil && *wrapped.TypeKey == "TestPatchOperationFailedException"
+}
+
+// CompareFields returns the fields that differ between two reads of a work
+// item, e.g. to show what changed on the server after ErrRevisionConflict.
+// Fields that change with every revision are left out.
+func CompareFields(before, after map[string]interface{}) []FieldChange {
+	var changes []FieldChange
+	for name, oldValue := range before {
+		if newValue := after[name]; !historyBookkeepingFields[name] && !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: name, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	for name, newValue := range after {
+		if _, ok := before[name]; !ok && !historyBookkeepingFields[name] {
+			changes = append(changes, FieldChange{Field: name, NewValue: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
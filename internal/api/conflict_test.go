@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+)
+
+func TestIsTestOperationFailure(t *testing.T) {
+	testFailed := "TestPatchOperationFailedException"
+	other := "WorkItemFieldInvalidException"
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"test operation failed", azuredevops.WrappedError{TypeKey: &testFailed}, true},
+		{"wrapped pointer", fmt.Errorf("update: %w", &azuredevops.WrappedError{TypeKey: &testFailed}), true},
+		{"other service error", azuredevops.WrappedError{TypeKey: &other}, false},
+		{"plain error", errors.New("connection reset"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTestOperationFailure(tt.err); got != tt.want {
+				t.Errorf("isTestOperationFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareFields(t *testing.T) {
+	before := map[string]interface{}{
+		"System.Title":       "Login fails",
+		"System.State":       "Active",
+		"System.Tags":        "web",
+		"System.Rev":         3,
+		"System.AssignedTo":  map[string]interface{}{"uniqueName": "ada@example.com"},
+		"System.Description": "Steps",
+	}
+	after := map[string]interface{}{
+		"System.Title":      "Login fails",
+		"System.State":      "Closed",
+		"System.Rev":        4,
+		"System.AssignedTo": map[string]interface{}{"uniqueName": "ada@example.com"},
+		"System.Reason":     "Fixed",
+	}
+
+	want := []FieldChange{
+		{Field: "System.Description", OldValue: "Steps"},
+		{Field: "System.Reason", NewValue: "Fixed"},
+		{Field: "System.State", OldValue: "Active", NewValue: "Closed"},
+		{Field: "System.Tags", OldValue: "web"},
+	}
+
+	got := CompareFields(before, after)
+	if len(got) != len(want) {
+		t.Fatalf("CompareFields() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("CompareFields()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
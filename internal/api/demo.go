@@ -0,0 +1,65 @@
+package api
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/http"
+)
+
+//go:generate go run ./demo/gen.go
+
+// demoFixtures are the responses of a sample project, recorded by demo/gen.go
+//
+//go:embed demo/*.json
+var demoFixtures embed.FS
+
+// The sample project the demo fixtures were recorded from
+const (
+	DemoOrganizationURL = "https://dev.azure.com/contoso"
+	DemoProject         = "Fabrikam"
+)
+
+// demoTransport replays the demo fixtures once NewDemoClient installed it
+var demoTransport *fixtureRoundTripper
+
+// NewDemoClient returns a read-only client for the sample project whose
+// responses are replayed from fixtures built into azb, so it needs no token
+// or network access. Requests the fixtures don't cover fail.
+func NewDemoClient() (*Client, error) {
+	// Replay outside the retry transport, as with AZB_REPLAY
+	installRetryTransport()
+	fixtureOnce.Do(func() {})
+
+	switch {
+	case fixtureTransport == nil:
+		replayer, err := demoReplayer()
+		if err != nil {
+			return nil, err
+		}
+		fixtureTransport, demoTransport = replayer, replayer
+		http.DefaultTransport = traced(replayer)
+	case fixtureTransport != demoTransport:
+		return nil, fmt.Errorf("the demo cannot be combined with %s or %s", RecordEnvVar, ReplayEnvVar)
+	}
+
+	client, err := NewClient(DemoOrganizationURL, DemoProject, "demo")
+	if err != nil {
+		return nil, err
+	}
+	client.SetReadOnly(true)
+	return client, nil
+}
+
+// demoReplayer returns a transport replaying the demo fixtures
+func demoReplayer() (*fixtureRoundTripper, error) {
+	fsys, err := fs.Sub(demoFixtures, "demo")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open demo fixtures: %w", err)
+	}
+	fixtures, err := loadFixtures(fsys, "demo")
+	if err != nil {
+		return nil, err
+	}
+	return newReplayer(fixtures), nil
+}
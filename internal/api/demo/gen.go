@@ -0,0 +1,415 @@
+//go:build ignore
+
+// gen records the demo fixtures: it serves a sample project from a fake Azure
+// DevOps server, makes the requests the dashboard makes with AZB_RECORD set,
+// and rewrites the recorded URLs to dev.azure.com. Run it with go generate in
+// internal/api.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+const (
+	organization = "contoso"
+	project      = "Fabrikam"
+	projectID    = "6b4f8a2e-3c1d-4e5f-9a7b-8c9d0e1f2a3b"
+	out          = "demo"
+)
+
+// workItemWIQL is the default query of the Work Items tab
+const workItemWIQL = "SELECT [System.Id] FROM WorkItems WHERE [System.AssignedTo] = @me"
+
+type person struct{ name, email string }
+
+var (
+	ada   = person{"Ada Lovelace", "ada@contoso.com"}
+	grace = person{"Grace Hopper", "grace@contoso.com"}
+	alan  = person{"Alan Turing", "alan@contoso.com"}
+)
+
+type demoItem struct {
+	id       int
+	kind     string
+	title    string
+	state    string
+	assignee person
+	parent   int
+	priority int
+	tags     string
+	created  string
+	changed  string
+	desc     string
+	criteria string
+	comments []string
+}
+
+var items = []demoItem{
+	{id: 100, kind: "Epic", title: "Customer self-service portal", state: "Active", assignee: grace, priority: 1, tags: "portal",
+		created: "2026-08-03T09:00:00Z", changed: "2026-10-01T14:20:00Z",
+		desc: "<div>Let customers manage their accounts, orders and support tickets without calling the help desk.</div>"},
+	{id: 101, kind: "Feature", title: "Account management", state: "Active", assignee: ada, parent: 100, priority: 1, tags: "portal; accounts",
+		created: "2026-08-04T10:15:00Z", changed: "2026-10-08T11:05:00Z",
+		desc: "<div>Profile, password and notification settings.</div>"},
+	{id: 102, kind: "Feature", title: "Order history", state: "New", assignee: alan, parent: 100, priority: 2, tags: "portal; orders",
+		created: "2026-08-04T10:20:00Z", changed: "2026-09-22T16:40:00Z",
+		desc: "<div>Show past orders with invoices and shipment tracking.</div>"},
+	{id: 103, kind: "User Story", title: "Reset password by email", state: "Active", assignee: ada, parent: 101, priority: 1, tags: "security",
+		created: "2026-09-01T08:30:00Z", changed: "2026-10-14T09:12:00Z",
+		desc:     "<div>As a customer I want to reset my password from the sign-in page so that I don't need to call support.</div>",
+		criteria: "<ul><li>A reset link is emailed within a minute</li><li>The link expires after 24 hours</li><li>Old sessions are signed out</li></ul>",
+		comments: []string{"<p>Security reviewed the token expiry, 24 hours is fine.</p>", "<p>Email template is ready in the design system.</p>"}},
+	{id: 104, kind: "User Story", title: "Edit profile details", state: "Resolved", assignee: ada, parent: 101, priority: 2,
+		created: "2026-09-01T08:45:00Z", changed: "2026-10-12T15:30:00Z",
+		desc:     "<div>As a customer I want to update my name, address and phone number.</div>",
+		criteria: "<ul><li>Changes are validated and saved</li><li>An audit entry is written</li></ul>"},
+	{id: 105, kind: "User Story", title: "Choose notification preferences", state: "New", assignee: ada, parent: 101, priority: 3, tags: "notifications",
+		created: "2026-09-02T13:00:00Z", changed: "2026-09-02T13:00:00Z",
+		desc: "<div>As a customer I want to pick which emails and text messages I receive.</div>"},
+	{id: 106, kind: "User Story", title: "List past orders", state: "Active", assignee: alan, parent: 102, priority: 2,
+		created: "2026-09-03T09:10:00Z", changed: "2026-10-13T10:45:00Z",
+		desc: "<div>As a customer I want to see my orders of the last two years, newest first.</div>"},
+	{id: 107, kind: "User Story", title: "Download invoices as PDF", state: "New", assignee: ada, parent: 102, priority: 3, tags: "orders; pdf",
+		created: "2026-09-03T09:20:00Z", changed: "2026-09-18T12:00:00Z",
+		desc: "<div>As a customer I want to download the invoice of an order for my records.</div>"},
+	{id: 108, kind: "Task", title: "Add rate limiting to the reset endpoint", state: "Active", assignee: ada, parent: 103, priority: 1, tags: "security",
+		created: "2026-10-02T10:00:00Z", changed: "2026-10-15T08:55:00Z",
+		desc: "<div>Allow five reset emails per address per hour.</div>"},
+	{id: 109, kind: "Task", title: "Write reset email template", state: "Closed", assignee: grace, parent: 103, priority: 2,
+		created: "2026-10-02T10:05:00Z", changed: "2026-10-09T17:20:00Z"},
+	{id: 110, kind: "Bug", title: "Sign-in page loops after session timeout", state: "Active", assignee: ada, priority: 1, tags: "bug; auth",
+		created: "2026-10-10T07:45:00Z", changed: "2026-10-15T13:10:00Z",
+		desc:     "<div>After 30 minutes idle, signing in redirects back to the sign-in page until cookies are cleared.</div>",
+		comments: []string{"<p>Reproduced in Edge and Firefox, not in Chrome.</p>"}},
+	{id: 111, kind: "Bug", title: "Order dates shown in UTC", state: "New", assignee: alan, parent: 106, priority: 3, tags: "bug",
+		created: "2026-10-11T11:30:00Z", changed: "2026-10-11T11:30:00Z",
+		desc: "<div>Order dates should use the customer's time zone.</div>"},
+}
+
+type savedQuery struct {
+	id, folder, name, wiql string
+}
+
+var queries = []savedQuery{
+	{"3f1e2d4c-0001-4000-8000-000000000001", "My Queries", "Assigned to me", workItemWIQL},
+	{"3f1e2d4c-0001-4000-8000-000000000002", "My Queries", "Open bugs", "SELECT [System.Id] FROM WorkItems WHERE [System.WorkItemType] = 'Bug' AND [System.State] <> 'Closed'"},
+	{"3f1e2d4c-0002-4000-8000-000000000003", "Shared Queries", "Current sprint", "SELECT [System.Id] FROM WorkItems WHERE [System.IterationPath] = @CurrentIteration"},
+	{"3f1e2d4c-0002-4000-8000-000000000004", "Shared Queries", "Portal backlog", "SELECT [System.Id] FROM WorkItems WHERE [System.Tags] CONTAINS 'portal'"},
+}
+
+// locations are the APIs the fake server answers, with their real route templates
+var locations = []map[string]interface{}{
+	location("e81700f7-3be2-46de-8624-2eb35882fcaa", "Location", "ResourceAreas", "_apis/{resource}/{areaId}"),
+	location("1a9c53f7-f243-4447-b110-35ef023636e4", "wit", "wiql", "{project}/{team}/_apis/wit/{resource}/{id}"),
+	location("908509b6-4248-4475-a1cd-829139ba419f", "wit", "workitemsbatch", "{project}/_apis/wit/{resource}"),
+	location("72c7ddf8-2cdc-4f60-90cd-ab71c14a399b", "wit", "workItems", "{project}/_apis/wit/{resource}/{id}"),
+	location("a67d190c-c41f-424b-814d-0e906f659301", "wit", "queries", "{project}/_apis/wit/{resource}/{*query}"),
+	location("6570bf97-d02c-4a91-8d93-3abe9895b1a9", "wit", "updates", "{project}/_apis/wit/workItems/{id}/{resource}/{updateNumber}"),
+	location("608aac0a-32e1-4493-a863-b9cf4566d257", "wit", "comments", "{project}/_apis/wit/workItems/{workItemId}/{resource}/{commentId}"),
+	location("d30a3dd1-f8ba-442a-b86a-bd0c0c383e59", "core", "teams", "_apis/projects/{projectId}/{resource}/{teamId}"),
+}
+
+func location(id, area, resource, template string) map[string]interface{} {
+	return map[string]interface{}{
+		"id": id, "area": area, "resourceName": resource, "routeTemplate": template,
+		"minVersion": "1.0", "maxVersion": "7.1", "releasedVersion": "7.0", "resourceVersion": 3,
+	}
+}
+
+var server *httptest.Server
+
+func main() {
+	server = httptest.NewServer(http.HandlerFunc(serve))
+	defer server.Close()
+
+	record, err := os.MkdirTemp("", "azb-demo")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(record)
+	os.Setenv(api.RecordEnvVar, record)
+
+	client, err := api.NewClient(server.URL+"/"+organization, project, "demo")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The requests of the dashboard tabs, in the order they are made
+	must(client.ListWorkItems(workItemWIQL, 100))
+	must(client.GetMyTeams())
+	must(client.QueryWorkItemIDs("SELECT [System.Id] FROM WorkItems WHERE [System.ChangedDate] >= @Today - 1", 50))
+	must(client.ListQueries("", 2))
+	for _, query := range queries {
+		must(client.GetQueryDefinition(query.id))
+	}
+	for _, item := range items {
+		must(client.GetWorkItem(item.id))
+		must(client.GetUpdates(item.id))
+		must(client.GetComments(item.id))
+	}
+
+	if err := rewrite(record); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func must(_ interface{}, err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// rewrite copies the recorded fixtures to the demo folder with dev.azure.com URLs
+func rewrite(record string) error {
+	old, _ := filepath.Glob(filepath.Join(out, "*.json"))
+	for _, path := range old {
+		os.Remove(path)
+	}
+	paths, err := filepath.Glob(filepath.Join(record, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		data = []byte(strings.ReplaceAll(string(data), server.URL, "https://dev.azure.com"))
+		if err := os.WriteFile(filepath.Join(out, filepath.Base(path)), data, 0644); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("wrote %d fixtures to %s\n", len(paths), out)
+	return nil
+}
+
+func serve(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/"+organization+"/")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	switch {
+	case r.Method == http.MethodOptions:
+		reply(w, collection(locations))
+	case path == "_apis/ResourceAreas":
+		reply(w, collection([]map[string]interface{}{
+			{"id": "5264459e-e5e0-4bd8-b118-0985e68a4ec5", "name": "wit", "locationUrl": server.URL + "/" + organization + "/"},
+			{"id": "79134c72-4a58-4b42-976c-04e7115f32bf", "name": "core", "locationUrl": server.URL + "/" + organization + "/"},
+		}))
+	case strings.HasSuffix(path, "/_apis/wit/wiql"):
+		var body struct{ Query string }
+		json.NewDecoder(r.Body).Decode(&body)
+		refs := []map[string]interface{}{}
+		if !strings.Contains(body.Query, "@Today") {
+			for _, item := range items {
+				refs = append(refs, map[string]interface{}{"id": item.id, "url": itemURL(item.id)})
+			}
+		}
+		reply(w, map[string]interface{}{"queryType": "flat", "asOf": "2026-10-16T09:00:00Z", "workItems": refs})
+	case strings.HasSuffix(path, "/_apis/wit/workitemsbatch"):
+		var body struct{ Ids []int }
+		json.NewDecoder(r.Body).Decode(&body)
+		var result []map[string]interface{}
+		for _, id := range body.Ids {
+			result = append(result, workItem(find(id)))
+		}
+		reply(w, collection(result))
+	case strings.Contains(path, "_apis/wit/workItems/") && strings.HasSuffix(path, "/updates"):
+		reply(w, collection(updates(find(pathID(path, 2)))))
+	case strings.Contains(path, "_apis/wit/workItems/") && strings.HasSuffix(path, "/comments"):
+		item := find(pathID(path, 2))
+		var comments []map[string]interface{}
+		for i, text := range item.comments {
+			author := []person{grace, alan}[i%2]
+			comments = append(comments, map[string]interface{}{
+				"id": i + 1, "workItemId": item.id, "version": 1, "text": text,
+				"createdBy":   identity(author),
+				"createdDate": item.changed,
+			})
+		}
+		reply(w, map[string]interface{}{"totalCount": len(comments), "count": len(comments), "comments": comments})
+	case strings.Contains(path, "_apis/wit/workItems/"):
+		reply(w, workItem(find(pathID(path, 1))))
+	case strings.HasSuffix(path, "/_apis/wit/queries"):
+		reply(w, collection(queryFolders()))
+	case strings.Contains(path, "/_apis/wit/queries/"):
+		id := path[strings.LastIndex(path, "/")+1:]
+		for _, query := range queries {
+			if query.id == id {
+				reply(w, queryItem(query, true))
+				return
+			}
+		}
+		http.NotFound(w, r)
+	case strings.HasSuffix(path, "/teams"):
+		reply(w, collection([]map[string]interface{}{{"id": "0c5f7f6e-1a2b-4c3d-8e9f-0a1b2c3d4e5f", "name": "Fabrikam Team"}}))
+	default:
+		log.Fatalf("unexpected request %s %s", r.Method, r.URL)
+	}
+}
+
+func reply(w http.ResponseWriter, value interface{}) {
+	json.NewEncoder(w).Encode(value)
+}
+
+func collection[T any](values []T) map[string]interface{} {
+	return map[string]interface{}{"count": len(values), "value": values}
+}
+
+// pathID returns the number n segments from the end of a path
+func pathID(path string, n int) int {
+	parts := strings.Split(path, "/")
+	id, _ := strconv.Atoi(parts[len(parts)-n])
+	return id
+}
+
+func find(id int) demoItem {
+	for _, item := range items {
+		if item.id == id {
+			return item
+		}
+	}
+	log.Fatalf("unknown work item %d", id)
+	return demoItem{}
+}
+
+func itemURL(id int) string {
+	return fmt.Sprintf("%s/%s/%s/_apis/wit/workItems/%d", server.URL, organization, projectID, id)
+}
+
+func identity(p person) map[string]interface{} {
+	return map[string]interface{}{"displayName": p.name, "uniqueName": p.email}
+}
+
+func workItem(item demoItem) map[string]interface{} {
+	fields := map[string]interface{}{
+		"System.Id":                             item.id,
+		"System.AreaPath":                       project + `\Portal`,
+		"System.TeamProject":                    project,
+		"System.IterationPath":                  project + `\Sprint 14`,
+		"System.WorkItemType":                   item.kind,
+		"System.State":                          item.state,
+		"System.Reason":                         reason(item.state),
+		"System.AssignedTo":                     identity(item.assignee),
+		"System.CreatedDate":                    item.created,
+		"System.CreatedBy":                      identity(grace),
+		"System.ChangedDate":                    item.changed,
+		"System.ChangedBy":                      identity(item.assignee),
+		"System.CommentCount":                   len(item.comments),
+		"System.Title":                          item.title,
+		"Microsoft.VSTS.Common.Priority":        item.priority,
+		"Microsoft.VSTS.Common.StateChangeDate": item.changed,
+	}
+	if item.desc != "" {
+		fields["System.Description"] = item.desc
+	}
+	if item.criteria != "" {
+		fields["Microsoft.VSTS.Common.AcceptanceCriteria"] = item.criteria
+	}
+	if item.tags != "" {
+		fields["System.Tags"] = item.tags
+	}
+	if item.kind == "Bug" {
+		fields["Microsoft.VSTS.TCM.ReproSteps"] = item.desc
+	}
+
+	var relations []map[string]interface{}
+	if item.parent != 0 {
+		fields["System.Parent"] = item.parent
+		relations = append(relations, map[string]interface{}{
+			"rel": "System.LinkTypes.Hierarchy-Reverse", "url": itemURL(item.parent), "attributes": map[string]interface{}{"isLocked": false, "name": "Parent"},
+		})
+	}
+	for _, child := range items {
+		if child.parent == item.id {
+			relations = append(relations, map[string]interface{}{
+				"rel": "System.LinkTypes.Hierarchy-Forward", "url": itemURL(child.id), "attributes": map[string]interface{}{"isLocked": false, "name": "Child"},
+			})
+		}
+	}
+
+	result := map[string]interface{}{"id": item.id, "rev": len(updates(item)), "fields": fields, "url": itemURL(item.id)}
+	if relations != nil {
+		result["relations"] = relations
+	}
+	return result
+}
+
+// updates returns the history of a work item: created as New, then moved to its current state
+func updates(item demoItem) []map[string]interface{} {
+	history := []map[string]interface{}{{
+		"id": 1, "rev": 1, "workItemId": item.id, "revisedBy": identity(grace), "revisedDate": item.created,
+		"fields": map[string]interface{}{
+			"System.Title":        map[string]interface{}{"newValue": item.title},
+			"System.State":        map[string]interface{}{"newValue": "New"},
+			"System.WorkItemType": map[string]interface{}{"newValue": item.kind},
+		},
+	}}
+	if item.state != "New" {
+		history = append(history, map[string]interface{}{
+			"id": 2, "rev": 2, "workItemId": item.id, "revisedBy": identity(item.assignee), "revisedDate": item.changed,
+			"fields": map[string]interface{}{
+				"System.State":      map[string]interface{}{"oldValue": "New", "newValue": item.state},
+				"System.Reason":     map[string]interface{}{"oldValue": "New", "newValue": reason(item.state)},
+				"System.AssignedTo": map[string]interface{}{"newValue": identity(item.assignee)},
+			},
+		})
+	}
+	return history
+}
+
+func reason(state string) string {
+	switch state {
+	case "Active":
+		return "Implementation started"
+	case "Resolved":
+		return "Code complete and unit tests pass"
+	case "Closed":
+		return "Acceptance tests pass"
+	default:
+		return "New"
+	}
+}
+
+func queryFolders() []map[string]interface{} {
+	var folders []map[string]interface{}
+	for i, name := range []string{"My Queries", "Shared Queries"} {
+		var children []map[string]interface{}
+		for _, query := range queries {
+			if query.folder == name {
+				children = append(children, queryItem(query, false))
+			}
+		}
+		folders = append(folders, map[string]interface{}{
+			"id": fmt.Sprintf("3f1e2d4c-000%d-4000-8000-000000000000", i+1), "name": name, "path": name,
+			"isFolder": true, "hasChildren": true, "isPublic": name == "Shared Queries", "children": children,
+		})
+	}
+	return folders
+}
+
+func queryItem(query savedQuery, withWIQL bool) map[string]interface{} {
+	item := map[string]interface{}{
+		"id": query.id, "name": query.name, "path": query.folder + "/" + query.name,
+		"isFolder": false, "queryType": "flat", "isPublic": query.folder == "Shared Queries",
+	}
+	if withWIQL {
+		item["wiql"] = query.wiql
+		item["columns"] = []map[string]interface{}{
+			{"referenceName": "System.Id", "name": "ID"},
+			{"referenceName": "System.WorkItemType", "name": "Work Item Type"},
+			{"referenceName": "System.Title", "name": "Title"},
+			{"referenceName": "System.State", "name": "State"},
+			{"referenceName": "System.AssignedTo", "name": "Assigned To"},
+		}
+	}
+	return item
+}
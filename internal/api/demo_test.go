@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDemoFixtures(t *testing.T) {
+	replayer, err := demoReplayer()
+	if err != nil {
+		t.Fatalf("demoReplayer() error = %v", err)
+	}
+	previous := http.DefaultTransport
+	http.DefaultTransport = replayer
+	defer func() { http.DefaultTransport = previous }()
+
+	client, err := NewClient(DemoOrganizationURL, DemoProject, "demo")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	// The requests the dashboard makes when it starts
+	workItems, err := client.ListWorkItems("SELECT [System.Id] FROM WorkItems", 100)
+	if err != nil || len(*workItems) == 0 {
+		t.Fatalf("ListWorkItems() = %v, %v, want work items", workItems, err)
+	}
+	if _, err := client.ListQueries("", 2); err != nil {
+		t.Errorf("ListQueries() error = %v", err)
+	}
+	if _, err := client.GetMyTeams(); err != nil {
+		t.Errorf("GetMyTeams() error = %v", err)
+	}
+	for _, wi := range *workItems {
+		if _, err := client.GetWorkItemUpdates(*wi.Id); err != nil {
+			t.Errorf("GetWorkItemUpdates(%d) error = %v", *wi.Id, err)
+		}
+		if _, err := client.GetComments(*wi.Id); err != nil {
+			t.Errorf("GetComments(%d) error = %v", *wi.Id, err)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/SOMUCHDOG/azb/internal/log"
+)
+
+// Environment variables that switch the client into fixture mode. Each names a
+// folder of fixtures, one JSON file per request.
+const (
+	RecordEnvVar = "AZB_RECORD"
+	ReplayEnvVar = "AZB_REPLAY"
+)
+
+// fixtureHeaders are the response headers kept in fixtures. Request headers,
+// including Authorization, are never written.
+var fixtureHeaders = []string{"Content-Type", "X-TFS-Session", "X-VSS-E2EID"}
+
+// Fixture is one recorded request and its response
+type Fixture struct {
+	Method   string            `json:"method"`
+	URL      string            `json:"url"`
+	Request  json.RawMessage   `json:"request,omitempty"`
+	Status   int               `json:"status"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Response json.RawMessage   `json:"response,omitempty"`
+	Text     string            `json:"text,omitempty"` // response body that is not JSON
+}
+
+var (
+	fixtureOnce      sync.Once
+	fixtureErr       error
+	fixtureTransport *fixtureRoundTripper
+)
+
+// installFixtureTransport wraps http.DefaultTransport with a recorder or
+// replayer when AZB_RECORD or AZB_REPLAY is set. It sits outside the retry
+// transport so only final responses are recorded and replays never wait.
+func installFixtureTransport(organizationURL string) error {
+	fixtureOnce.Do(func() {
+		replay, record := os.Getenv(ReplayEnvVar), os.Getenv(RecordEnvVar)
+		switch {
+		case replay != "":
+			if record != "" {
+				log.Warn("both fixture modes are set, replaying", "replay", replay, "record", record)
+			}
+			fixtures, err := LoadFixtures(replay)
+			if err != nil {
+				fixtureErr = err
+				return
+			}
+			fixtureTransport = newReplayer(fixtures)
+			http.DefaultTransport = traced(fixtureTransport)
+			return
+		case record != "":
+			if err := os.MkdirAll(record, 0755); err != nil {
+				fixtureErr = fmt.Errorf("failed to create fixture folder: %w", err)
+				return
+			}
+			fixtureTransport = newRecorder(record, chainBase())
+		default:
+			return
+		}
+		http.DefaultTransport = fixtureTransport
+	})
+	if fixtureErr != nil {
+		return fixtureErr
+	}
+	if fixtureTransport != nil {
+		fixtureTransport.addHost(organizationURL)
+	}
+	return nil
+}
+
+// LoadFixtures reads the fixtures of a folder in recording order
+func LoadFixtures(dir string) ([]Fixture, error) {
+	return loadFixtures(os.DirFS(dir), dir)
+}
+
+// loadFixtures reads the fixtures of a file system in recording order; dir names it in errors
+func loadFixtures(fsys fs.FS, dir string) ([]Fixture, error) {
+	paths, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fixtures: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no fixtures found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	fixtures := make([]Fixture, 0, len(paths))
+	for _, path := range paths {
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		var fixture Fixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", filepath.Base(path), err)
+		}
+		fixtures = append(fixtures, fixture)
+	}
+	return fixtures, nil
+}
+
+// fixtureRoundTripper records or replays requests to Azure DevOps hosts.
+// Requests to other hosts, such as notification webhooks, pass through.
+type fixtureRoundTripper struct {
+	base  http.RoundTripper
+	dir   string // set when recording
+	hosts map[string]bool
+
+	mu      sync.Mutex
+	count   int
+	queues  map[string][]Fixture
+	replays map[string]int
+}
+
+func newRecorder(dir string, base http.RoundTripper) *fixtureRoundTripper {
+	existing, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	return &fixtureRoundTripper{base: base, dir: dir, hosts: map[string]bool{}, count: len(existing)}
+}
+
+func newReplayer(fixtures []Fixture) *fixtureRoundTripper {
+	t := &fixtureRoundTripper{
+		base:    http.DefaultTransport,
+		hosts:   map[string]bool{},
+		queues:  map[string][]Fixture{},
+		replays: map[string]int{},
+	}
+	for _, f := range fixtures {
+		key := fixtureKey(f.Method, f.URL)
+		t.queues[key] = append(t.queues[key], f)
+	}
+	return t
+}
+
+// addHost handles requests to an organization's host, for servers outside dev.azure.com
+func (t *fixtureRoundTripper) addHost(organizationURL string) {
+	if parsed, err := url.Parse(organizationURL); err == nil && parsed.Host != "" {
+		t.mu.Lock()
+		t.hosts[strings.ToLower(parsed.Host)] = true
+		t.mu.Unlock()
+	}
+}
+
+// handles reports whether a request goes to Azure DevOps
+func (t *fixtureRoundTripper) handles(req *http.Request) bool {
+	host := strings.ToLower(req.URL.Hostname())
+	if host == "dev.azure.com" || strings.HasSuffix(host, ".dev.azure.com") || strings.HasSuffix(host, ".visualstudio.com") {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.hosts[strings.ToLower(req.URL.Host)]
+}
+
+func (t *fixtureRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.handles(req) {
+		return t.base.RoundTrip(req)
+	}
+	if t.dir == "" {
+		return t.replay(req)
+	}
+	return t.record(req)
+}
+
+// replay answers a request with the next fixture recorded for it. Once the
+// fixtures of a request run out, the last one is repeated.
+func (t *fixtureRoundTripper) replay(req *http.Request) (*http.Response, error) {
+	key := fixtureKey(req.Method, req.URL.String())
+
+	t.mu.Lock()
+	queue := t.queues[key]
+	n := t.replays[key]
+	t.replays[key]++
+	t.mu.Unlock()
+
+	if len(queue) == 0 {
+		return nil, fmt.Errorf("no fixture recorded for %s %s", req.Method, req.URL.Redacted())
+	}
+	if n >= len(queue) {
+		n = len(queue) - 1
+	}
+	return queue[n].response(req), nil
+}
+
+// record sends a request and saves it with its response as the next fixture
+func (t *fixtureRoundTripper) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = body
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	fixture := Fixture{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Request: jsonOrNil(reqBody),
+		Status:  resp.StatusCode,
+		Headers: map[string]string{},
+	}
+	for _, name := range fixtureHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			fixture.Headers[name] = value
+		}
+	}
+	if fixture.Response = jsonOrNil(respBody); fixture.Response == nil {
+		fixture.Text = string(respBody)
+	}
+
+	if err := t.save(fixture); err != nil {
+		// A failed recording should not fail the command
+		log.Warn("failed to record fixture", "url", req.URL.Redacted(), "error", err)
+	}
+	return resp, nil
+}
+
+// save writes a fixture to the next numbered file
+func (t *fixtureRoundTripper) save(fixture Fixture) error {
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixture: %w", err)
+	}
+
+	t.mu.Lock()
+	t.count++
+	name := fmt.Sprintf("%04d-%s.json", t.count, strings.ToLower(fixture.Method))
+	t.mu.Unlock()
+
+	return os.WriteFile(filepath.Join(t.dir, name), data, 0600)
+}
+
+// response builds the recorded response for a request
+func (f Fixture) response(req *http.Request) *http.Response {
+	body := []byte(f.Text)
+	if f.Response != nil {
+		// Fixtures are indented for reading; responses are compact like the service's
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, f.Response); err == nil {
+			body = compact.Bytes()
+		} else {
+			body = f.Response
+		}
+	}
+	header := http.Header{}
+	for name, value := range f.Headers {
+		header.Set(name, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+		StatusCode:    f.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// fixtureKey matches requests to fixtures by method and URL. Bodies are not
+// compared because they may contain timestamps.
+func fixtureKey(method, rawURL string) string {
+	return strings.ToUpper(method) + " " + rawURL
+}
+
+// jsonOrNil returns a body as raw JSON, or nil when it is empty or not JSON
+func jsonOrNil(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 || !json.Valid(body) {
+		return nil
+	}
+	return json.RawMessage(body)
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFixtureRecordAndReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path == "/text" {
+			fmt.Fprint(w, "plain")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"rev":%d}`, calls)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder := newRecorder(dir, http.DefaultTransport)
+	recorder.addHost(server.URL)
+
+	get := func(rt http.RoundTripper, path string) (int, string, error) {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		req.Header.Set("Authorization", "Basic secret")
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			return 0, "", err
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, strings.TrimSpace(string(body)), nil
+	}
+
+	for _, path := range []string{"/item", "/item", "/text"} {
+		if _, _, err := get(recorder, path); err != nil {
+			t.Fatalf("recording %s: %v", path, err)
+		}
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(paths) != 3 {
+		t.Fatalf("recorded %d fixtures, want 3", len(paths))
+	}
+	for _, path := range paths {
+		data, _ := os.ReadFile(path)
+		if strings.Contains(string(data), "secret") {
+			t.Errorf("%s contains the Authorization header", filepath.Base(path))
+		}
+	}
+
+	fixtures, err := LoadFixtures(dir)
+	if err != nil {
+		t.Fatalf("LoadFixtures() error = %v", err)
+	}
+	server.Close()
+	replayer := newReplayer(fixtures)
+	replayer.addHost(server.URL)
+
+	// Repeated requests replay in recording order, then repeat the last response
+	for _, tt := range []struct{ path, want string }{
+		{"/item", `{"rev":1}`},
+		{"/item", `{"rev":2}`},
+		{"/item", `{"rev":2}`},
+		{"/text", "plain"},
+	} {
+		status, body, err := get(replayer, tt.path)
+		if err != nil {
+			t.Fatalf("replaying %s: %v", tt.path, err)
+		}
+		if status != http.StatusOK || body != tt.want {
+			t.Errorf("replay %s = %d %s, want 200 %s", tt.path, status, body, tt.want)
+		}
+	}
+
+	if _, _, err := get(replayer, "/missing"); err == nil {
+		t.Error("replaying an unrecorded request succeeded, want an error")
+	}
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/notification"
+)
+
+// subscriptionsLocationID is the notification subscriptions REST resource
+var subscriptionsLocationID = uuid.MustParse("70f911d6-abac-488c-85b3-a206bf57e165")
+
+// artifactFilter is the filter of a "follow" subscription. The SDK's
+// ISubscriptionFilter only models the filter type, so follows are created and
+// listed with this type instead.
+type artifactFilter struct {
+	Type         string `json:"type"`
+	EventType    string `json:"eventType"`
+	ArtifactType string `json:"artifactType"`
+	ArtifactID   string `json:"artifactId"`
+	ArtifactURI  string `json:"artifactUri,omitempty"`
+}
+
+// followSubscription is the part of a notification subscription needed to find follows
+type followSubscription struct {
+	ID          string         `json:"id"`
+	Description string         `json:"description,omitempty"`
+	Filter      artifactFilter `json:"filter"`
+}
+
+// workItemFollowID returns the work item followed by s, or 0 if s is not a work item follow
+func (s followSubscription) workItemFollowID() int {
+	if s.Filter.Type != "Artifact" || s.Filter.ArtifactType != "WorkItem" {
+		return 0
+	}
+	id, err := strconv.Atoi(s.Filter.ArtifactID)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// FollowWorkItem subscribes the current user to notifications about changes to
+// a work item, like the Follow button in the web UI. It returns false if the
+// user already follows the work item.
+func (c *Client) FollowWorkItem(id int) (bool, error) {
+	if err := c.checkWritable(); err != nil {
+		return false, err
+	}
+
+	follows, err := c.listFollows()
+	if err != nil {
+		return false, err
+	}
+	for _, s := range follows {
+		if s.workItemFollowID() == id {
+			return false, nil
+		}
+	}
+
+	body, err := json.Marshal(followSubscription{
+		Description: fmt.Sprintf("Follow work item %d", id),
+		Filter: artifactFilter{
+			Type:         "Artifact",
+			ArtifactType: "WorkItem",
+			ArtifactID:   strconv.Itoa(id),
+			ArtifactURI:  fmt.Sprintf("vstfs:///WorkItemTracking/WorkItem/%d", id),
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal subscription: %w", err)
+	}
+
+	client := c.connection.GetClientByUrl(c.connection.BaseUrl)
+	resp, err := client.Send(c.ctx, http.MethodPost, subscriptionsLocationID, "5.1", nil, nil, bytes.NewReader(body), "application/json", "application/json", nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to follow work item %d: %w", id, err)
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+// UnfollowWorkItem removes the current user's follow of a work item. It
+// returns false if the user did not follow the work item.
+func (c *Client) UnfollowWorkItem(id int) (bool, error) {
+	if err := c.checkWritable(); err != nil {
+		return false, err
+	}
+
+	follows, err := c.listFollows()
+	if err != nil {
+		return false, err
+	}
+
+	notificationClient := notification.NewClient(c.ctx, c.connection)
+	found := false
+	for _, s := range follows {
+		if s.workItemFollowID() != id {
+			continue
+		}
+		subscriptionID := s.ID
+		if err := notificationClient.DeleteSubscription(c.ctx, notification.DeleteSubscriptionArgs{SubscriptionId: &subscriptionID}); err != nil {
+			return false, fmt.Errorf("failed to unfollow work item %d: %w", id, err)
+		}
+		found = true
+	}
+	return found, nil
+}
+
+// listFollows returns the current user's artifact subscriptions
+func (c *Client) listFollows() ([]followSubscription, error) {
+	client := c.connection.GetClientByUrl(c.connection.BaseUrl)
+	query := url.Values{}
+	query.Add("queryFlags", string(notification.SubscriptionQueryFlagsValues.IncludeFilterDetails))
+
+	resp, err := client.Send(c.ctx, http.MethodGet, subscriptionsLocationID, "5.1", nil, query, nil, "", "application/json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	var subscriptions []followSubscription
+	if err := client.UnmarshalCollectionBody(resp, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to parse subscriptions: %w", err)
+	}
+
+	var follows []followSubscription
+	for _, s := range subscriptions {
+		if s.Filter.Type == "Artifact" {
+			follows = append(follows, s)
+		}
+	}
+	return follows, nil
+}
@@ -0,0 +1,25 @@
+package api
+
+import "testing"
+
+func TestWorkItemFollowID(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter artifactFilter
+		want   int
+	}{
+		{"work item follow", artifactFilter{Type: "Artifact", ArtifactType: "WorkItem", ArtifactID: "1234"}, 1234},
+		{"pull request follow", artifactFilter{Type: "Artifact", ArtifactType: "PullRequest", ArtifactID: "7"}, 0},
+		{"expression subscription", artifactFilter{Type: "Expression"}, 0},
+		{"invalid artifact ID", artifactFilter{Type: "Artifact", ArtifactType: "WorkItem", ArtifactID: "abc"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := followSubscription{Filter: tt.filter}
+			if got := s.workItemFollowID(); got != tt.want {
+				t.Errorf("workItemFollowID() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// historyBookkeepingFields change on every revision and are left out of the history
+var historyBookkeepingFields = map[string]bool{
+	"System.Rev":            true,
+	"System.RevisedDate":    true,
+	"System.ChangedDate":    true,
+	"System.ChangedBy":      true,
+	"System.AuthorizedDate": true,
+	"System.AuthorizedAs":   true,
+	"System.Watermark":      true,
+	"System.PersonId":       true,
+	"System.History":        true, // returned as the revision's comment
+}
+
+// derivedFields are recomputed by the service whenever other fields or links
+// change, so a change to them says nothing new
+var derivedFields = map[string]bool{
+	"System.CommentCount":      true,
+	"System.BoardColumnDone":   true,
+	"System.AreaId":            true,
+	"System.IterationId":       true,
+	"System.NodeName":          true,
+	"System.ExternalLinkCount": true,
+	"System.RelatedLinkCount":  true,
+	"System.HyperLinkCount":    true,
+	"System.AttachedFileCount": true,
+	"System.RemoteLinkCount":   true,
+}
+
+// IsDerivedField reports whether the service recomputes a field when others
+// change, like System.CommentCount or System.AreaId. Revisions still list
+// these fields; summaries and reverts skip them.
+func IsDerivedField(name string) bool {
+	return derivedFields[name]
+}
+
+// FieldChange is one field changed by a revision
+type FieldChange struct {
+	Field    string
+	OldValue interface{}
+	NewValue interface{}
+}
+
+// WorkItemRevision is one revision of a work item: who changed it, when, and what
+type WorkItemRevision struct {
+	Rev          int
+	Author       string
+	Date         time.Time
+	Changes      []FieldChange // sorted by field reference name
+	Comment      string        // discussion comment added with the revision, as HTML
+	LinksAdded   int
+	LinksRemoved int
+}
+
+// GetWorkItemUpdates retrieves the revision history of a work item, oldest first
+func (c *Client) GetWorkItemUpdates(id int) ([]WorkItemRevision, error) {
+	updates, err := c.GetUpdates(id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]WorkItemRevision, 0, len(updates))
+	for _, u := range updates {
+		revisions = append(revisions, revisionFromUpdate(u))
+	}
+	return revisions, nil
+}
+
+// revisionFromUpdate converts an update from the API into a revision
+func revisionFromUpdate(u workitemtracking.WorkItemUpdate) WorkItemRevision {
+	var rev WorkItemRevision
+	if u.Rev != nil {
+		rev.Rev = *u.Rev
+	}
+	if u.RevisedBy != nil && u.RevisedBy.DisplayName != nil {
+		rev.Author = *u.RevisedBy.DisplayName
+	}
+
+	if u.Fields != nil {
+		fields := *u.Fields
+		if changed, ok := fields["System.ChangedDate"].NewValue.(string); ok {
+			if date, err := time.Parse(time.RFC3339, changed); err == nil {
+				rev.Date = date
+			}
+		}
+		if comment, ok := fields["System.History"].NewValue.(string); ok {
+			rev.Comment = comment
+		}
+
+		for name, change := range fields {
+			if historyBookkeepingFields[name] {
+				continue
+			}
+			rev.Changes = append(rev.Changes, FieldChange{Field: name, OldValue: change.OldValue, NewValue: change.NewValue})
+		}
+		sort.Slice(rev.Changes, func(i, j int) bool { return rev.Changes[i].Field < rev.Changes[j].Field })
+	}
+
+	if u.Relations != nil {
+		if u.Relations.Added != nil {
+			rev.LinksAdded = len(*u.Relations.Added)
+		}
+		if u.Relations.Removed != nil {
+			rev.LinksRemoved = len(*u.Relations.Removed)
+		}
+	}
+	return rev
+}
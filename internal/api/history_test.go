@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// updatesClient answers GetUpdates with a fixed history
+type updatesClient struct {
+	workitemtracking.Client
+	updates []workitemtracking.WorkItemUpdate
+}
+
+func (u *updatesClient) GetUpdates(ctx context.Context, args workitemtracking.GetUpdatesArgs) (*[]workitemtracking.WorkItemUpdate, error) {
+	return &u.updates, nil
+}
+
+func TestGetWorkItemUpdates(t *testing.T) {
+	rev, author := 2, "Alice Smith"
+	fields := map[string]workitemtracking.WorkItemFieldUpdate{
+		"System.State":       {OldValue: "New", NewValue: "Active"},
+		"System.AssignedTo":  {NewValue: map[string]interface{}{"displayName": "Bob"}},
+		"System.Rev":         {OldValue: 1.0, NewValue: 2.0},
+		"System.ChangedDate": {NewValue: "2026-10-01T14:03:00Z"},
+		"System.History":     {NewValue: "<div>Starting</div>"},
+	}
+	added := []workitemtracking.WorkItemRelation{{}}
+	fake := &updatesClient{updates: []workitemtracking.WorkItemUpdate{{
+		Rev:       &rev,
+		RevisedBy: &workitemtracking.IdentityReference{DisplayName: &author},
+		Fields:    &fields,
+		Relations: &workitemtracking.WorkItemRelationUpdates{Added: &added},
+	}}}
+	c := &Client{workItemClient: fake, project: "Project", ctx: context.Background()}
+
+	revisions, err := c.GetWorkItemUpdates(1)
+	if err != nil {
+		t.Fatalf("GetWorkItemUpdates() error = %v", err)
+	}
+	if len(revisions) != 1 {
+		t.Fatalf("GetWorkItemUpdates() returned %d revisions, want 1", len(revisions))
+	}
+
+	got := revisions[0]
+	if got.Rev != 2 || got.Author != author || !got.Date.Equal(time.Date(2026, 10, 1, 14, 3, 0, 0, time.UTC)) {
+		t.Errorf("revision = %d by %q at %v", got.Rev, got.Author, got.Date)
+	}
+	if got.Comment != "<div>Starting</div>" || got.LinksAdded != 1 || got.LinksRemoved != 0 {
+		t.Errorf("revision comment = %q, links +%d -%d", got.Comment, got.LinksAdded, got.LinksRemoved)
+	}
+	if len(got.Changes) != 2 || got.Changes[0].Field != "System.AssignedTo" || got.Changes[1].Field != "System.State" {
+		t.Errorf("revision changes = %+v, want AssignedTo and State", got.Changes)
+	}
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/identity"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/location"
+)
+
+// User describes an Azure DevOps identity
+type User struct {
+	ID          string
+	DisplayName string
+	UniqueName  string // Usually the sign-in email address
+}
+
+// AssignableName returns the value to use for identity fields such as System.AssignedTo
+func (u *User) AssignableName() string {
+	if u.UniqueName != "" {
+		return u.UniqueName
+	}
+	return u.DisplayName
+}
+
+// ErrTokenRejected is returned by VerifyToken when the organization does not accept the token
+var ErrTokenRejected = errors.New("the token was rejected; it may have expired or been revoked")
+
+// anonymousUserID is the ID connection data reports for unauthenticated requests
+const anonymousUserID = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+
+// GetCurrentUser returns the user the personal access token belongs to
+func (c *Client) GetCurrentUser() (*User, error) {
+	return currentUser(c.ctx, c.connection)
+}
+
+// VerifyToken checks a personal access token against an organization and
+// returns the user it belongs to. The request is made directly because
+// Azure DevOps answers rejected tokens with HTML rather than an API error.
+func VerifyToken(organizationURL, token string) (*User, error) {
+	connection, err := newConnection(organizationURL, token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(connection.BaseUrl, "/")+"/_apis/connectionData", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify token: %w", err)
+	}
+	req.Header.Set("Authorization", connection.AuthorizationString)
+	req.Header.Set("Accept", "application/json")
+	// Without this, unauthenticated requests are redirected to a sign-in page
+	req.Header.Set("X-TFS-FedAuthRedirect", "Suppress")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNonAuthoritativeInfo:
+		return nil, ErrTokenRejected
+	default:
+		return nil, fmt.Errorf("failed to verify token: Azure DevOps returned %s", resp.Status)
+	}
+
+	var connectionData location.ConnectionData
+	if err := json.NewDecoder(resp.Body).Decode(&connectionData); err != nil {
+		return nil, fmt.Errorf("failed to verify token: %w", err)
+	}
+	if connectionData.AuthenticatedUser == nil || connectionData.AuthenticatedUser.Id == nil ||
+		connectionData.AuthenticatedUser.Id.String() == anonymousUserID {
+		return nil, ErrTokenRejected
+	}
+	return userFromIdentity(connectionData.AuthenticatedUser), nil
+}
+
+// currentUser reads the authenticated user from the connection data of the organization
+func currentUser(ctx context.Context, connection *azuredevops.Connection) (*User, error) {
+	locationClient := location.NewClient(ctx, connection)
+
+	connectionData, err := locationClient.GetConnectionData(ctx, location.GetConnectionDataArgs{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	if connectionData == nil || connectionData.AuthenticatedUser == nil {
+		return nil, fmt.Errorf("failed to get current user: no authenticated user in response")
+	}
+
+	return userFromIdentity(connectionData.AuthenticatedUser), nil
+}
+
+// SearchUsers finds active users whose name, display name or email matches query
+func (c *Client) SearchUsers(query string) ([]User, error) {
+	identityClient, err := c.getIdentityClient()
+	if err != nil {
+		return nil, err
+	}
+
+	searchFilter := "General"
+	membership := identity.QueryMembershipValues.None
+	identities, err := identityClient.ReadIdentities(c.ctx, identity.ReadIdentitiesArgs{
+		SearchFilter:    &searchFilter,
+		FilterValue:     &query,
+		QueryMembership: &membership,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users for '%s': %w", query, err)
+	}
+
+	var users []User
+	if identities != nil {
+		for i := range *identities {
+			found := &(*identities)[i]
+			// Groups cannot be mentioned like people
+			if (found.IsContainer != nil && *found.IsContainer) || (found.IsActive != nil && !*found.IsActive) {
+				continue
+			}
+			users = append(users, *userFromIdentity(found))
+		}
+	}
+	return users, nil
+}
+
+// MentionHTML returns the comment markup that mentions and notifies the user
+func (u *User) MentionHTML() string {
+	return fmt.Sprintf(`<a href="#" data-vss-mention="version:2.0,%s">@%s</a>`, u.ID, html.EscapeString(u.DisplayName))
+}
+
+// userFromIdentity converts an identity to a User
+func userFromIdentity(from *identity.Identity) *User {
+	user := &User{}
+	if from.Id != nil {
+		user.ID = from.Id.String()
+	}
+	if from.ProviderDisplayName != nil {
+		user.DisplayName = *from.ProviderDisplayName
+	}
+	if from.CustomDisplayName != nil && *from.CustomDisplayName != "" {
+		user.DisplayName = *from.CustomDisplayName
+	}
+
+	// The account name is stored as {"Account": {"$type": "System.String", "$value": "..."}}
+	if properties, ok := from.Properties.(map[string]interface{}); ok {
+		if account, ok := properties["Account"].(map[string]interface{}); ok {
+			if value, ok := account["$value"].(string); ok {
+				user.UniqueName = value
+			}
+		}
+	}
+
+	return user
+}
+
+// getIdentityClient creates the identity client on first use
+func (c *Client) getIdentityClient() (identity.Client, error) {
+	c.identityClientOnce.Do(func() {
+		c.identityClient, c.identityClientErr = identity.NewClient(c.ctx, c.connection)
+		if c.identityClientErr != nil {
+			c.identityClientErr = fmt.Errorf("failed to create identity client: %w", c.identityClientErr)
+		}
+	})
+	return c.identityClient, c.identityClientErr
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantUser string
+		wantErr  error
+	}{
+		{
+			name:     "valid token",
+			status:   http.StatusOK,
+			body:     `{"authenticatedUser": {"id": "3b5f0c1e-4b1a-4f8e-9a57-0c1d2e3f4a5b", "providerDisplayName": "Ada Lovelace", "properties": {"Account": {"$type": "System.String", "$value": "ada@contoso.com"}}}}`,
+			wantUser: "Ada Lovelace",
+		},
+		{
+			name:    "anonymous",
+			status:  http.StatusOK,
+			body:    `{"authenticatedUser": {"id": "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "providerDisplayName": "Anonymous"}}`,
+			wantErr: ErrTokenRejected,
+		},
+		{
+			name:    "expired token",
+			status:  http.StatusUnauthorized,
+			body:    `<html>Access Denied: The Personal Access Token used has expired.</html>`,
+			wantErr: ErrTokenRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/contoso/_apis/connectionData" {
+					t.Errorf("request path = %s", r.URL.Path)
+				}
+				if r.Header.Get("Authorization") == "" {
+					t.Error("request has no Authorization header")
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			user, err := VerifyToken(server.URL+"/contoso", "token")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("VerifyToken() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyToken() error = %v", err)
+			}
+			if user.DisplayName != tt.wantUser || user.UniqueName != "ada@contoso.com" {
+				t.Errorf("VerifyToken() user = %+v", user)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/work"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+//go:generate go run ./apitest/gen.go
+
+// APIClient is the Azure DevOps API used by the commands and the dashboard.
+// Client implements it; apitest.Client is a mock for tests without network calls.
+type APIClient interface {
+	// Connection
+	GetOrganizationURL() string
+	GetProject() string
+	GetContext() context.Context
+	SetReadOnly(readOnly bool)
+	ReadOnly() bool
+	WorkItemURL(id int) string
+	WorkItemAPIURL(id int) string
+
+	// Work items
+	GetWorkItem(id int) (*workitemtracking.WorkItem, error)
+	GetWorkItems(ids []int) ([]workitemtracking.WorkItem, error)
+	GetWorkItemsAsOf(ids []int, asOf time.Time) ([]workitemtracking.WorkItem, error)
+	GetWorkItemFields(ids []int, fields []string) ([]workitemtracking.WorkItem, error)
+	ListWorkItems(wiql string, top int) (*[]workitemtracking.WorkItem, error)
+	QueryWorkItemIDs(wiql string, top int) ([]int, error)
+	CreateWorkItem(workItemType string, fields map[string]interface{}, parentID int) (*workitemtracking.WorkItem, error)
+	CreateWorkItemWithRelations(workItemType string, fields map[string]interface{}, relations []WorkItemLink) (*workitemtracking.WorkItem, error)
+	CreateWorkItems(items []NewWorkItem) []CreateResult
+	UpdateWorkItem(id int, fields map[string]interface{}) (*workitemtracking.WorkItem, error)
+	UpdateWorkItemAtRevision(id, rev int, fields map[string]interface{}) (*workitemtracking.WorkItem, error)
+	UpdateWorkItemWithLinks(id int, fields map[string]interface{}, links []WorkItemLink) (*workitemtracking.WorkItem, error)
+	DeleteWorkItem(id int) error
+	ValidateWorkItem(workItemType string, fields map[string]interface{}, relations []WorkItemLink) ([]FieldError, error)
+	ParentLink(id int) WorkItemLink
+	RelatedLink(id int) WorkItemLink
+
+	// History, comments and following
+	GetUpdates(id int) ([]workitemtracking.WorkItemUpdate, error)
+	GetWorkItemUpdates(id int) ([]WorkItemRevision, error)
+	GetComments(id int) ([]workitemtracking.Comment, error)
+	AddComment(id int, text string) (*workitemtracking.Comment, error)
+	FollowWorkItem(id int) (bool, error)
+	UnfollowWorkItem(id int) (bool, error)
+	DownloadAttachment(id uuid.UUID, fileName string) (io.ReadCloser, error)
+
+	// Work item types and fields
+	GetWorkItemType(workItemTypeName string) (*workitemtracking.WorkItemType, error)
+	GetWorkItemTypes() (*[]workitemtracking.WorkItemType, error)
+	GetRequiredFields(workItemTypeName string) ([]string, error)
+	GetFieldDefinition(workItemTypeName, fieldReferenceName string) (*workitemtracking.WorkItemTypeFieldInstance, error)
+	GetWorkItemTypeStates(workItemTypeName string) ([]WorkItemState, error)
+	GetWorkItemStates(workItemTypeName string) ([]string, error)
+	GetWorkItemTypeFields(workItemTypeName string) (*[]workitemtracking.WorkItemTypeFieldWithReferences, error)
+	GetFields() (*[]workitemtracking.WorkItemField, error)
+	GetStateForCategory(workItemTypeName, category string) (string, error)
+
+	// Tags
+	ListTags() ([]Tag, error)
+	RenameTag(name, newName string) error
+	DeleteTag(name string) error
+
+	// Saved queries
+	GetQuery(queryPath string) (*workitemtracking.QueryHierarchyItem, error)
+	ListQueries(folderPath string, depth int) (*[]workitemtracking.QueryHierarchyItem, error)
+	GetQueryChildren(folder string) (*[]workitemtracking.QueryHierarchyItem, error)
+	ExecuteQuery(queryID string, top int) (*[]workitemtracking.WorkItem, error)
+	GetQueryWIQL(queryID string) (string, error)
+	GetQueryDefinition(queryID string) (*QueryDefinition, error)
+
+	// Users, teams and iterations
+	GetCurrentUser() (*User, error)
+	SearchUsers(query string) ([]User, error)
+	GetMyTeams() ([]string, error)
+	GetCurrentIteration(team string) (*work.TeamSettingsIteration, error)
+	GetTeamIterations(team, timeframe string) ([]work.TeamSettingsIteration, error)
+	ListAreaPaths(depth int) ([]string, error)
+	ListIterationPaths(depth int) ([]string, error)
+
+	// Analytics, builds and pull requests
+	QueryAnalytics(entitySet string, query url.Values) ([]map[string]interface{}, error)
+	GetBuildSummary(id int) (*BuildSummary, error)
+	GetPullRequest(id int) (*PullRequestSummary, error)
+}
+
+var _ APIClient = (*Client)(nil)
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/work"
+)
+
+// GetCurrentIteration returns the current sprint of a team.
+// An empty team name uses the project's default team.
+func (c *Client) GetCurrentIteration(team string) (*work.TeamSettingsIteration, error) {
+	iterations, err := c.GetTeamIterations(team, "current")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current iteration: %w", err)
+	}
+
+	if len(iterations) == 0 {
+		return nil, fmt.Errorf("no current iteration found")
+	}
+
+	return &iterations[0], nil
+}
+
+// GetTeamIterations returns the sprints of a team in date order. timeframe is
+// "past", "current" or "future"; an empty timeframe returns all sprints. An
+// empty team name uses the project's default team.
+func (c *Client) GetTeamIterations(team, timeframe string) ([]work.TeamSettingsIteration, error) {
+	workClient, err := work.NewClient(c.ctx, c.connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work client: %w", err)
+	}
+
+	args := work.GetTeamIterationsArgs{
+		Project: &c.project,
+	}
+	if timeframe != "" {
+		args.Timeframe = &timeframe
+	}
+	if team != "" {
+		args.Team = &team
+	}
+
+	iterations, err := workClient.GetTeamIterations(c.ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team iterations: %w", err)
+	}
+	if iterations == nil {
+		return nil, nil
+	}
+
+	sorted := *iterations
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return iterationStart(sorted[i]).Before(iterationStart(sorted[j]))
+	})
+	return sorted, nil
+}
+
+// iterationStart returns the start date of an iteration, or the zero time for
+// iterations without dates, which sort first
+func iterationStart(iteration work.TeamSettingsIteration) time.Time {
+	if iteration.Attributes == nil || iteration.Attributes.StartDate == nil {
+		return time.Time{}
+	}
+	return iteration.Attributes.StartDate.Time
+}
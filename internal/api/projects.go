@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
+)
+
+// ListProjects returns the names of all projects in an organization.
+// It does not need a project, so it can be used to validate configuration.
+func ListProjects(organizationURL, token string) ([]string, error) {
+	ctx := context.Background()
+	connection, err := newConnection(organizationURL, token)
+	if err != nil {
+		return nil, err
+	}
+
+	coreClient, err := core.NewClient(ctx, connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create core client: %w", err)
+	}
+
+	var names []string
+	args := core.GetProjectsArgs{}
+	for {
+		page, err := coreClient.GetProjects(ctx, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+
+		for _, project := range page.Value {
+			if project.Name != nil {
+				names = append(names, *project.Name)
+			}
+		}
+
+		// Follow continuation tokens until all pages are read
+		if page.ContinuationToken == "" {
+			break
+		}
+		args.ContinuationToken = &page.ContinuationToken
+	}
+
+	return names, nil
+}
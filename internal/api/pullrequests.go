@@ -0,0 +1,133 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+// PullRequestSummary is the part of a pull request shown next to linked work items
+type PullRequestSummary struct {
+	ID           int    `json:"id"`
+	Title        string `json:"title"`
+	Status       string `json:"status"`
+	IsDraft      bool   `json:"isDraft,omitempty"`
+	Repository   string `json:"repository"`
+	SourceBranch string `json:"sourceBranch"`
+	TargetBranch string `json:"targetBranch"`
+	CreatedBy    string `json:"createdBy,omitempty"`
+	ArtifactURL  string `json:"artifactUrl"`
+	URL          string `json:"url"`
+}
+
+// IsPullRequest reports whether the artifact is a Git pull request
+func (a Artifact) IsPullRequest() bool {
+	return strings.EqualFold(a.Tool, "Git") && strings.EqualFold(a.Type, "PullRequestId")
+}
+
+// GetPullRequest looks up a pull request by ID in any repository of the organization
+func (c *Client) GetPullRequest(id int) (*PullRequestSummary, error) {
+	gitClient, err := c.getGitClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := gitClient.GetPullRequestById(c.ctx, git.GetPullRequestByIdArgs{PullRequestId: &id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request %d: %w", id, err)
+	}
+
+	summary := &PullRequestSummary{ID: id}
+	if pr.Title != nil {
+		summary.Title = *pr.Title
+	}
+	if pr.Status != nil {
+		summary.Status = string(*pr.Status)
+	}
+	if pr.IsDraft != nil {
+		summary.IsDraft = *pr.IsDraft
+	}
+	if pr.SourceRefName != nil {
+		summary.SourceBranch = strings.TrimPrefix(*pr.SourceRefName, "refs/heads/")
+	}
+	if pr.TargetRefName != nil {
+		summary.TargetBranch = strings.TrimPrefix(*pr.TargetRefName, "refs/heads/")
+	}
+	if pr.CreatedBy != nil && pr.CreatedBy.DisplayName != nil {
+		summary.CreatedBy = *pr.CreatedBy.DisplayName
+	}
+
+	var projectID, projectName, repositoryID string
+	if repo := pr.Repository; repo != nil {
+		if repo.Name != nil {
+			summary.Repository = *repo.Name
+		}
+		if repo.Id != nil {
+			repositoryID = repo.Id.String()
+		}
+		if repo.Project != nil && repo.Project.Id != nil {
+			projectID = repo.Project.Id.String()
+		}
+		if repo.Project != nil && repo.Project.Name != nil {
+			projectName = *repo.Project.Name
+		}
+	}
+	if projectName == "" {
+		projectName = c.project
+	}
+
+	summary.URL = PullRequestWebURL(c.organizationURL, projectName, summary.Repository, id)
+	if pr.ArtifactId != nil && *pr.ArtifactId != "" {
+		summary.ArtifactURL = *pr.ArtifactId
+	} else {
+		summary.ArtifactURL = PullRequestArtifactURL(projectID, repositoryID, id)
+	}
+	return summary, nil
+}
+
+// getGitClient creates the git client on first use
+func (c *Client) getGitClient() (git.Client, error) {
+	c.gitClientOnce.Do(func() {
+		c.gitClient, c.gitClientErr = git.NewClient(c.ctx, c.connection)
+		if c.gitClientErr != nil {
+			c.gitClientErr = fmt.Errorf("failed to create git client: %w", c.gitClientErr)
+		}
+	})
+	return c.gitClient, c.gitClientErr
+}
+
+// PullRequestArtifactURL builds the artifact URI that links a work item to a pull request
+func PullRequestArtifactURL(projectID, repositoryID string, id int) string {
+	return fmt.Sprintf("vstfs:///Git/PullRequestId/%s%%2F%s%%2F%d", projectID, repositoryID, id)
+}
+
+// PullRequestWebURL builds the browser URL of a pull request
+func PullRequestWebURL(organizationURL, project, repository string, id int) string {
+	return fmt.Sprintf("%s/%s/_git/%s/pullrequest/%d", strings.TrimSuffix(organizationURL, "/"),
+		url.PathEscape(project), url.PathEscape(repository), id)
+}
+
+// ParsePullRequestID reads a pull request ID, a "!123" reference or a pull
+// request web URL such as https://dev.azure.com/org/project/_git/repo/pullrequest/123
+func ParsePullRequestID(value string) (int, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "!")
+	if id, err := strconv.Atoi(value); err == nil && id > 0 {
+		return id, nil
+	}
+
+	parsed, err := url.Parse(value)
+	if err == nil && parsed.Scheme != "" {
+		segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+		for i := 0; i+1 < len(segments); i++ {
+			if strings.EqualFold(segments[i], "pullrequest") {
+				if id, err := strconv.Atoi(segments[i+1]); err == nil && id > 0 {
+					return id, nil
+				}
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid pull request %q (use an ID or a pull request URL)", value)
+}
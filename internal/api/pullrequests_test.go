@@ -0,0 +1,41 @@
+package api
+
+import "testing"
+
+func TestParsePullRequestID(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "567", want: 567},
+		{value: "!567", want: 567},
+		{value: "https://dev.azure.com/org/project/_git/repo/pullrequest/567", want: 567},
+		{value: "https://org.visualstudio.com/project/_git/repo/pullrequest/567?_a=files", want: 567},
+		{value: "https://dev.azure.com/org/project/_git/repo", wantErr: true},
+		{value: "abc", wantErr: true},
+		{value: "0", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePullRequestID(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePullRequestID(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePullRequestID(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPullRequestArtifactURL(t *testing.T) {
+	got := PullRequestArtifactURL("p-1", "r-2", 567)
+	if want := "vstfs:///Git/PullRequestId/p-1%2Fr-2%2F567"; got != want {
+		t.Fatalf("PullRequestArtifactURL() = %q, want %q", got, want)
+	}
+
+	artifact, ok := ParseArtifactURL(got)
+	if !ok || !artifact.IsPullRequest() || artifact.ShortID() != "567" {
+		t.Errorf("ParseArtifactURL(%q) = %+v, %v; want pull request 567", got, artifact, ok)
+	}
+}
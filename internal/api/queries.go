@@ -2,8 +2,11 @@ package api
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/log"
 )
 
 // GetQuery retrieves a saved query by path
@@ -42,7 +45,39 @@ func (c *Client) ListQueries(folderPath string, depth int) (*[]workitemtracking.
 
 // ExecuteQuery executes a saved query and returns work items
 func (c *Client) ExecuteQuery(queryId string, top int) (*[]workitemtracking.WorkItem, error) {
-	// Get the query with WIQL
+	wiql, err := c.GetQueryWIQL(queryId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute the query
+	return c.ListWorkItems(wiql, top)
+}
+
+// GetQueryWIQL returns the WIQL statement of a saved query
+func (c *Client) GetQueryWIQL(queryId string) (string, error) {
+	definition, err := c.GetQueryDefinition(queryId)
+	if err != nil {
+		return "", err
+	}
+	return definition.WIQL, nil
+}
+
+// QueryColumn is a field a saved query displays
+type QueryColumn struct {
+	ReferenceName string
+	Name          string
+	Sort          string // "asc" or "desc" when the query sorts by this field
+}
+
+// QueryDefinition is a saved query's WIQL and the columns it displays, in order
+type QueryDefinition struct {
+	WIQL    string
+	Columns []QueryColumn
+}
+
+// GetQueryDefinition returns the WIQL statement and column list of a saved query
+func (c *Client) GetQueryDefinition(queryId string) (*QueryDefinition, error) {
 	expand := workitemtracking.QueryExpandValues.Wiql
 
 	query, err := c.workItemClient.GetQuery(c.ctx, workitemtracking.GetQueryArgs{
@@ -55,10 +90,137 @@ func (c *Client) ExecuteQuery(queryId string, top int) (*[]workitemtracking.Work
 		return nil, fmt.Errorf("failed to get query: %w", err)
 	}
 
+	return queryDefinition(query)
+}
+
+// queryDefinition extracts the WIQL, columns and sort order from a query
+func queryDefinition(query *workitemtracking.QueryHierarchyItem) (*QueryDefinition, error) {
 	if query.Wiql == nil {
 		return nil, fmt.Errorf("query does not have a WIQL statement")
 	}
 
-	// Execute the query
-	return c.ListWorkItems(*query.Wiql, top)
+	definition := &QueryDefinition{WIQL: *query.Wiql}
+	if query.Columns == nil {
+		return definition, nil
+	}
+
+	sorts := make(map[string]string)
+	if query.SortColumns != nil {
+		for _, sortColumn := range *query.SortColumns {
+			if sortColumn.Field == nil || sortColumn.Field.ReferenceName == nil {
+				continue
+			}
+			direction := "asc"
+			if sortColumn.Descending != nil && *sortColumn.Descending {
+				direction = "desc"
+			}
+			sorts[*sortColumn.Field.ReferenceName] = direction
+		}
+	}
+
+	for _, field := range *query.Columns {
+		if field.ReferenceName == nil {
+			continue
+		}
+		column := QueryColumn{
+			ReferenceName: *field.ReferenceName,
+			Name:          *field.ReferenceName,
+			Sort:          sorts[*field.ReferenceName],
+		}
+		if field.Name != nil && *field.Name != "" {
+			column.Name = *field.Name
+		}
+		definition.Columns = append(definition.Columns, column)
+	}
+
+	return definition, nil
+}
+
+// GetQueryChildren retrieves the immediate children of a query folder.
+// ListQueries is limited to a depth of 2 by the API, so folders nested deeper
+// come back with HasChildren set but no Children; use this to load them on demand.
+func (c *Client) GetQueryChildren(folder string) (*[]workitemtracking.QueryHierarchyItem, error) {
+	depth := 1
+
+	query, err := c.workItemClient.GetQuery(c.ctx, workitemtracking.GetQueryArgs{
+		Project: &c.project,
+		Query:   &folder,
+		Depth:   &depth,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get children of query folder '%s': %w", folder, err)
+	}
+
+	if query.Children == nil {
+		return &[]workitemtracking.QueryHierarchyItem{}, nil
+	}
+
+	return query.Children, nil
+}
+
+// NeedsChildren reports whether a query folder has children that were not
+// included in the response and must be fetched with GetQueryChildren
+func NeedsChildren(item *workitemtracking.QueryHierarchyItem) bool {
+	isFolder := item.IsFolder != nil && *item.IsFolder
+	hasChildren := item.HasChildren != nil && *item.HasChildren
+	return isFolder && hasChildren && item.Children == nil
+}
+
+// FindQuery searches all query folders for a saved query with the given name,
+// ignoring case, and returns it with its WIQL. Folders deeper than the first
+// two levels are loaded as the search reaches them.
+func FindQuery(client APIClient, name string) (*workitemtracking.QueryHierarchyItem, error) {
+	queries, err := client.ListQueries("", 2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queries: %w", err)
+	}
+
+	var search func(items *[]workitemtracking.QueryHierarchyItem) *workitemtracking.QueryHierarchyItem
+	search = func(items *[]workitemtracking.QueryHierarchyItem) *workitemtracking.QueryHierarchyItem {
+		if items == nil {
+			return nil
+		}
+		for i := range *items {
+			item := &(*items)[i]
+			isFolder := item.IsFolder != nil && *item.IsFolder
+			if !isFolder && item.Id != nil && item.Name != nil && strings.EqualFold(*item.Name, name) {
+				return item
+			}
+			if NeedsChildren(item) && item.Id != nil {
+				children, err := client.GetQueryChildren(item.Id.String())
+				if err != nil {
+					log.Warnf("Failed to load query folder '%s': %v", queryName(item), err)
+				} else {
+					item.Children = children
+				}
+			}
+			if found := search(item.Children); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+
+	found := search(queries)
+	if found == nil {
+		return nil, fmt.Errorf("query '%s' not found", name)
+	}
+
+	query, err := client.GetQuery(found.Id.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query details: %w", err)
+	}
+	return query, nil
+}
+
+// queryName returns the path of a query or folder, or its name if it has none
+func queryName(item *workitemtracking.QueryHierarchyItem) string {
+	switch {
+	case item.Path != nil:
+		return *item.Path
+	case item.Name != nil:
+		return *item.Name
+	}
+	return ""
 }
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestQueryDefinition(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	descending := true
+
+	query := &workitemtracking.QueryHierarchyItem{
+		Wiql: strPtr("SELECT [System.Id] FROM WorkItems ORDER BY [System.ChangedDate] DESC"),
+		Columns: &[]workitemtracking.WorkItemFieldReference{
+			{ReferenceName: strPtr("System.Id"), Name: strPtr("ID")},
+			{ReferenceName: strPtr("System.Title"), Name: strPtr("Title")},
+			{ReferenceName: strPtr("System.ChangedDate")},
+		},
+		SortColumns: &[]workitemtracking.WorkItemQuerySortColumn{
+			{Field: &workitemtracking.WorkItemFieldReference{ReferenceName: strPtr("System.ChangedDate")}, Descending: &descending},
+			{Field: &workitemtracking.WorkItemFieldReference{ReferenceName: strPtr("System.Id")}},
+		},
+	}
+
+	definition, err := queryDefinition(query)
+	if err != nil {
+		t.Fatalf("queryDefinition() error = %v", err)
+	}
+
+	want := []QueryColumn{
+		{ReferenceName: "System.Id", Name: "ID", Sort: "asc"},
+		{ReferenceName: "System.Title", Name: "Title"},
+		{ReferenceName: "System.ChangedDate", Name: "System.ChangedDate", Sort: "desc"},
+	}
+	if len(definition.Columns) != len(want) {
+		t.Fatalf("got %d columns, want %d", len(definition.Columns), len(want))
+	}
+	for i, column := range definition.Columns {
+		if column != want[i] {
+			t.Errorf("column %d = %+v, want %+v", i, column, want[i])
+		}
+	}
+
+	if _, err := queryDefinition(&workitemtracking.QueryHierarchyItem{}); err == nil {
+		t.Error("expected an error for a query without WIQL")
+	}
+}
+
+func TestFindQuery(t *testing.T) {
+	replayer, err := demoReplayer()
+	if err != nil {
+		t.Fatalf("demoReplayer() error = %v", err)
+	}
+	previous := http.DefaultTransport
+	http.DefaultTransport = replayer
+	defer func() { http.DefaultTransport = previous }()
+
+	client, err := NewClient(DemoOrganizationURL, DemoProject, "demo")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	query, err := FindQuery(client, "open BUGS")
+	if err != nil {
+		t.Fatalf("FindQuery() error = %v", err)
+	}
+	if query.Wiql == nil || !strings.Contains(*query.Wiql, "'Bug'") {
+		t.Errorf("FindQuery() WIQL = %v, want the Open bugs query", query.Wiql)
+	}
+
+	if _, err := FindQuery(client, "My Queries"); err == nil {
+		t.Error("FindQuery() found a folder, want only queries")
+	}
+}
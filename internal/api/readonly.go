@@ -0,0 +1,24 @@
+package api
+
+import "errors"
+
+// ErrReadOnly is returned by methods that would change data when the client is read-only
+var ErrReadOnly = errors.New("read-only mode: changes are disabled (unset read_only or drop --read-only)")
+
+// SetReadOnly makes the client refuse to create, update or delete anything
+func (c *Client) SetReadOnly(readOnly bool) {
+	c.readOnly = readOnly
+}
+
+// ReadOnly reports whether the client refuses changes
+func (c *Client) ReadOnly() bool {
+	return c != nil && c.readOnly
+}
+
+// checkWritable returns ErrReadOnly when the client is read-only
+func (c *Client) checkWritable() error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReadOnlyClientRefusesChanges(t *testing.T) {
+	// The guard runs before any request, so no connection is needed
+	c := &Client{}
+	c.SetReadOnly(true)
+
+	if _, err := c.CreateWorkItem("Task", map[string]interface{}{"System.Title": "x"}, 0); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("CreateWorkItem() error = %v, want ErrReadOnly", err)
+	}
+	if _, err := c.UpdateWorkItem(1, map[string]interface{}{"System.Title": "x"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("UpdateWorkItem() error = %v, want ErrReadOnly", err)
+	}
+	if err := c.DeleteWorkItem(1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("DeleteWorkItem() error = %v, want ErrReadOnly", err)
+	}
+	if _, err := c.AddComment(1, "hello"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("AddComment() error = %v, want ErrReadOnly", err)
+	}
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/SOMUCHDOG/azb/internal/log"
+)
+
+// RetryPolicy controls how requests are retried after throttling and transient server errors
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// DefaultRetryPolicy is used unless SetRetryPolicy is called
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  time.Second,
+	MaxDelay:   time.Minute,
+}
+
+// ThrottleEvent describes a request that failed and is about to be retried
+type ThrottleEvent struct {
+	Method     string
+	URL        string
+	StatusCode int // 0 for network errors
+	Attempt    int
+	MaxRetries int
+	Delay      time.Duration
+	Throttled  bool // Azure DevOps asked the client to slow down
+}
+
+// String describes the event for logs and notifications
+func (e ThrottleEvent) String() string {
+	reason := "request failed"
+	switch {
+	case e.Throttled:
+		reason = "Azure DevOps is throttling requests"
+	case e.StatusCode != 0:
+		reason = fmt.Sprintf("Azure DevOps returned %d", e.StatusCode)
+	}
+	return fmt.Sprintf("%s, retrying in %s (attempt %d/%d)", reason, e.Delay.Round(time.Second), e.Attempt, e.MaxRetries)
+}
+
+var (
+	retryMu         sync.RWMutex
+	retryPolicy     = DefaultRetryPolicy
+	throttleHandler = logThrottleHandler
+
+	installRetryOnce sync.Once
+)
+
+// SetRetryPolicy replaces the retry policy used by all clients
+func SetRetryPolicy(policy RetryPolicy) {
+	retryMu.Lock()
+	defer retryMu.Unlock()
+	retryPolicy = policy
+}
+
+// SetThrottleHandler sets the function called before each retry. A nil handler
+// restores the default, which logs events at info level.
+func SetThrottleHandler(handler func(ThrottleEvent)) {
+	retryMu.Lock()
+	defer retryMu.Unlock()
+	if handler == nil {
+		handler = logThrottleHandler
+	}
+	throttleHandler = handler
+}
+
+func currentRetrySettings() (RetryPolicy, func(ThrottleEvent)) {
+	retryMu.RLock()
+	defer retryMu.RUnlock()
+	return retryPolicy, throttleHandler
+}
+
+// logThrottleHandler logs retry events
+func logThrottleHandler(event ThrottleEvent) {
+	log.Info("retrying request",
+		"method", event.Method,
+		"url", event.URL,
+		"status", event.StatusCode,
+		"attempt", event.Attempt,
+		"max_retries", event.MaxRetries,
+		"delay", event.Delay,
+		"throttled", event.Throttled,
+	)
+}
+
+// installRetryTransport wraps http.DefaultTransport with retries. The Azure
+// DevOps SDK builds its http.Client without a transport, so the default
+// transport is the only place every request passes through.
+func installRetryTransport() {
+	installRetryOnce.Do(func() {
+		http.DefaultTransport = &retryTransport{base: chainBase()}
+	})
+}
+
+// retryTransport retries throttled (429) and unavailable (502, 503, 504)
+// responses, and network errors on requests that are safe to repeat
+type retryTransport struct {
+	base http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	policy, handler := currentRetrySettings()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt > policy.MaxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		// Requests whose body cannot be replayed are not retried
+		var body io.ReadCloser
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			if body, err = req.GetBody(); err != nil {
+				return resp, err
+			}
+		}
+
+		delay, throttled := retryDelay(resp, attempt, policy, time.Now())
+		event := ThrottleEvent{
+			Method:     req.Method,
+			URL:        req.URL.Redacted(),
+			Attempt:    attempt,
+			MaxRetries: policy.MaxRetries,
+			Delay:      delay,
+			Throttled:  throttled,
+		}
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+			// Drain so the connection can be reused
+			io.Copy(io.Discard, resp.Body) //nolint:errcheck // Best effort before retrying
+			resp.Body.Close()
+		}
+		handler(event)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		req = req.Clone(req.Context())
+		if body != nil {
+			req.Body = body
+		}
+	}
+}
+
+// shouldRetry reports whether a response or error is worth retrying
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// The request may have reached the server, so only repeat reads
+		return req.Context().Err() == nil && (req.Method == http.MethodGet || req.Method == http.MethodHead)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before the next attempt. It honors
+// Retry-After and, when the rate limit is exhausted, X-RateLimit-Reset;
+// otherwise it backs off exponentially from the policy's base delay.
+func retryDelay(resp *http.Response, attempt int, policy RetryPolicy, now time.Time) (time.Duration, bool) {
+	throttled := resp != nil && resp.StatusCode == http.StatusTooManyRequests
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			return capDelay(delay, policy), true
+		}
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+				return capDelay(time.Unix(reset, 0).Sub(now), policy), true
+			}
+		}
+	}
+
+	delay := policy.BaseDelay << (attempt - 1)
+	if delay <= 0 {
+		// Shifting overflowed
+		delay = policy.MaxDelay
+	}
+	return capDelay(delay, policy), throttled
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return date.Sub(now), true
+	}
+	return 0, false
+}
+
+// capDelay keeps a delay between zero and the policy's maximum
+func capDelay(delay time.Duration, policy RetryPolicy) time.Duration {
+	if delay < 0 {
+		return 0
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		return policy.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	SetRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second})
+	defer SetRetryPolicy(DefaultRetryPolicy)
+
+	var events []ThrottleEvent
+	SetThrottleHandler(func(event ThrottleEvent) { events = append(events, event) })
+	defer SetThrottleHandler(nil)
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryTransport{base: &http.Transport{}}}
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"a":1}` {
+		t.Errorf("request bodies = %q, want the body sent twice", bodies)
+	}
+	if len(events) != 1 || !events[0].Throttled || events[0].StatusCode != http.StatusTooManyRequests {
+		t.Errorf("events = %+v, want one throttling event", events)
+	}
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	SetRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second})
+	defer SetRetryPolicy(DefaultRetryPolicy)
+	SetThrottleHandler(func(ThrottleEvent) {})
+	defer SetThrottleHandler(nil)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryTransport{base: &http.Transport{}}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable || requests != 3 {
+		t.Errorf("status = %d after %d requests, want 503 after 3", resp.StatusCode, requests)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+
+	response := func(status int, headers map[string]string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		for k, v := range headers {
+			resp.Header.Set(k, v)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name          string
+		resp          *http.Response
+		attempt       int
+		want          time.Duration
+		wantThrottled bool
+	}{
+		{"retry-after seconds", response(429, map[string]string{"Retry-After": "5"}), 1, 5 * time.Second, true},
+		{"retry-after date", response(503, map[string]string{"Retry-After": "Mon, 01 Jan 2024 12:00:10 GMT"}), 1, 10 * time.Second, true},
+		{"rate limit reset", response(429, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1704110420"}), 1, 20 * time.Second, true},
+		{"capped at max delay", response(429, map[string]string{"Retry-After": "3600"}), 1, 30 * time.Second, true},
+		{"exponential first attempt", response(503, nil), 1, time.Second, false},
+		{"exponential third attempt", response(503, nil), 3, 4 * time.Second, false},
+		{"throttled without headers", response(429, nil), 2, 2 * time.Second, true},
+		{"network error", nil, 2, 2 * time.Second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, throttled := retryDelay(tt.resp, tt.attempt, policy, now)
+			if got != tt.want || throttled != tt.wantThrottled {
+				t.Errorf("retryDelay() = %s, %v; want %s, %v", got, throttled, tt.want, tt.wantThrottled)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// tagsLocationID identifies the work item tags REST resource, which the SDK
+// does not cover. Requests go through the SDK's resource locations so the
+// API version is negotiated with the server like every other call.
+var tagsLocationID = uuid.MustParse("bc15bc60-e7a8-43cb-ab01-2106be3983a1")
+
+// tagsAPIVersion is the newest version of the tags resource azb knows; older
+// servers answer with the highest version they support
+const tagsAPIVersion = "6.0-preview.1"
+
+// Tag is a work item tag defined in the project
+type Tag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ListTags returns the tags defined in the project. Tags stay defined for a
+// while after the last work item using them drops them.
+func (c *Client) ListTags() ([]Tag, error) {
+	resp, err := c.sendTagRequest(http.MethodGet, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Value []Tag `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse tags: %w", err)
+	}
+	return result.Value, nil
+}
+
+// RenameTag renames a tag definition, which renames it on every work item at
+// once without adding revisions
+func (c *Client) RenameTag(name, newName string) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(Tag{Name: newName})
+	if err != nil {
+		return fmt.Errorf("failed to marshal tag: %w", err)
+	}
+	resp, err := c.sendTagRequest(http.MethodPatch, name, body)
+	if err != nil {
+		return fmt.Errorf("failed to rename tag '%s': %w", name, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// DeleteTag deletes a tag definition from the project
+func (c *Client) DeleteTag(name string) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+
+	resp, err := c.sendTagRequest(http.MethodDelete, name, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag '%s': %w", name, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// sendTagRequest calls the tags resource of the project, or a single tag when name is set
+func (c *Client) sendTagRequest(method, name string, body []byte) (*http.Response, error) {
+	routeValues := map[string]string{"project": c.project}
+	if name != "" {
+		routeValues["tagIdOrName"] = name
+	}
+
+	mediaType := ""
+	if body != nil {
+		mediaType = "application/json"
+	}
+	client := c.connection.GetClientByUrl(c.connection.BaseUrl)
+	return client.Send(c.ctx, method, tagsLocationID, tagsAPIVersion, routeValues, nil, bytes.NewReader(body), mediaType, "application/json", nil)
+}
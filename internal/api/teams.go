@@ -0,0 +1,31 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
+)
+
+// GetMyTeams returns the names of the project's teams the current user is a member of
+func (c *Client) GetMyTeams() ([]string, error) {
+	mine := true
+	teams, err := c.coreClient.GetTeams(c.ctx, core.GetTeamsArgs{
+		ProjectId: &c.project,
+		Mine:      &mine,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	var names []string
+	if teams != nil {
+		for _, team := range *teams {
+			if team.Name != nil {
+				names = append(names, *team.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
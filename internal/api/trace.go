@@ -0,0 +1,154 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// traceBodyLimit is how much of a request or response body is traced
+const traceBodyLimit = 4096
+
+// traceHeaders are the headers traced; others, such as Authorization and
+// cookies, are left out
+var traceHeaders = []string{
+	"Accept",
+	"Content-Type",
+	"User-Agent",
+	"Retry-After",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+}
+
+// traceCorrelationHeaders identify a request in Azure DevOps diagnostics
+var traceCorrelationHeaders = []string{
+	"ActivityId",
+	"X-TFS-Session",
+	"X-VSS-E2EID",
+	"X-MSEdge-Ref",
+}
+
+// traceOut receives the trace once EnableTracing was called
+var traceOut io.Writer
+
+// EnableTracing writes every HTTP request and response, without credentials
+// and with bodies shortened, to w. The trace sits below the retry transport,
+// so each retry attempt is traced. Call it before creating clients.
+func EnableTracing(w io.Writer) {
+	traceOut = w
+	if retry, ok := http.DefaultTransport.(*retryTransport); ok {
+		retry.base = &traceTransport{base: retry.base, out: w}
+		return
+	}
+	http.DefaultTransport = &traceTransport{base: chainBase(), out: w}
+}
+
+// traced wraps a transport that replaces the whole chain, such as a fixture
+// replayer, with the trace when tracing is enabled
+func traced(base http.RoundTripper) http.RoundTripper {
+	if traceOut == nil {
+		return base
+	}
+	return &traceTransport{base: base, out: traceOut}
+}
+
+// traceTransport records requests and responses for bug reports
+type traceTransport struct {
+	base http.RoundTripper
+	out  io.Writer
+
+	mu   sync.Mutex
+	next int
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.next++
+	seq := t.next
+	t.mu.Unlock()
+
+	var b strings.Builder
+	started := time.Now()
+	fmt.Fprintf(&b, "#%d %s → %s %s\n", seq, started.UTC().Format(time.RFC3339Nano), req.Method, req.URL.Redacted())
+	writeTraceHeaders(&b, req.Header, traceHeaders)
+	writeTraceHeaders(&b, req.Header, traceCorrelationHeaders)
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			writeTraceBody(&b, "request", body)
+			body.Close()
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(started).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(&b, "#%d ← error after %s: %v\n\n", seq, elapsed, err)
+		t.write(b.String())
+		return resp, err
+	}
+
+	fmt.Fprintf(&b, "#%d ← %s in %s\n", seq, resp.Status, elapsed)
+	writeTraceHeaders(&b, resp.Header, traceCorrelationHeaders)
+	writeTraceHeaders(&b, resp.Header, traceHeaders)
+	if resp.Body != nil {
+		// Trace the start of the body and hand the whole body on unchanged
+		head := make([]byte, traceBodyLimit+1)
+		n, readErr := io.ReadFull(resp.Body, head)
+		head = head[:n]
+		writeTraceBody(&b, "response", bytes.NewReader(head))
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(head))
+		} else {
+			resp.Body = readCloser{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+		}
+	}
+	b.WriteString("\n")
+	t.write(b.String())
+	return resp, nil
+}
+
+// write appends one finished exchange so concurrent requests don't interleave
+func (t *traceTransport) write(entry string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	io.WriteString(t.out, entry) //nolint:errcheck // Tracing must not fail requests
+}
+
+// writeTraceHeaders writes the listed headers that are set
+func writeTraceHeaders(b *strings.Builder, header http.Header, names []string) {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			fmt.Fprintf(b, "    %s: %s\n", name, value)
+		}
+	}
+}
+
+// writeTraceBody writes up to traceBodyLimit bytes of a body
+func writeTraceBody(b *strings.Builder, kind string, body io.Reader) {
+	data, err := io.ReadAll(io.LimitReader(body, traceBodyLimit+1))
+	if err != nil || len(data) == 0 {
+		return
+	}
+	truncated := len(data) > traceBodyLimit
+	if truncated {
+		data = data[:traceBodyLimit]
+	}
+	fmt.Fprintf(b, "    %s body:\n", kind)
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		b.WriteString("      " + line + "\n")
+	}
+	if truncated {
+		fmt.Fprintf(b, "      ... (truncated after %d bytes)\n", traceBodyLimit)
+	}
+}
+
+// readCloser reads from one reader and closes another
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
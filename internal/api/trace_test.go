@@ -0,0 +1,93 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTraceTransport(t *testing.T) {
+	body := strings.Repeat("x", traceBodyLimit+100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ActivityId", "activity-123")
+		io.WriteString(w, body) //nolint:errcheck // Test server
+	}))
+	defer server.Close()
+
+	var trace bytes.Buffer
+	client := &http.Client{Transport: &traceTransport{base: http.DefaultTransport, out: &trace}}
+
+	req, _ := http.NewRequest("POST", server.URL+"/_apis/wit/wiql", strings.NewReader(`{"query":"SELECT"}`))
+	req.SetBasicAuth("", "secret-token")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	got, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(got) != body {
+		t.Errorf("response body was %d bytes, want %d", len(got), len(body))
+	}
+
+	out := trace.String()
+	for _, want := range []string{"POST " + server.URL + "/_apis/wit/wiql", "200 OK", "ActivityId: activity-123", `{"query":"SELECT"}`, "truncated after"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("trace does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Authorization") || strings.Contains(out, "Basic ") {
+		t.Errorf("trace contains credentials:\n%s", out)
+	}
+}
+
+func TestEnableTracingBelowRetries(t *testing.T) {
+	SetRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second})
+	defer SetRetryPolicy(DefaultRetryPolicy)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, "ok") //nolint:errcheck // Test server
+	}))
+	defer server.Close()
+
+	saved := http.DefaultTransport
+	defer func() { http.DefaultTransport, traceOut = saved, nil }()
+	http.DefaultTransport = &retryTransport{base: &http.Transport{}}
+
+	var trace bytes.Buffer
+	EnableTracing(&trace)
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	out := trace.String()
+	if !strings.Contains(out, "503 Service Unavailable") || !strings.Contains(out, "200 OK") {
+		t.Errorf("trace does not contain both attempts:\n%s", out)
+	}
+}
+
+func TestTraced(t *testing.T) {
+	replayer := &http.Transport{}
+	if got := traced(replayer); got != replayer {
+		t.Errorf("traced() without tracing = %T, want the transport itself", got)
+	}
+
+	traceOut = io.Discard
+	defer func() { traceOut = nil }()
+	if got, ok := traced(replayer).(*traceTransport); !ok || got.base != replayer {
+		t.Errorf("traced() with tracing = %T, want a trace of the transport", got)
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// systemTransport is http.DefaultTransport as Go set it up. It sends requests
+// through the proxy named by HTTPS_PROXY, HTTP_PROXY and NO_PROXY.
+var systemTransport = http.DefaultTransport
+
+// caTransport replaces systemTransport at the bottom of the API transport
+// chain once SetCABundle was called
+var caTransport *http.Transport
+
+// chainBase returns the transport to wrap when adding to the API transport
+// chain: http.DefaultTransport, or the CA bundle transport while the default
+// transport is still the system one
+func chainBase() http.RoundTripper {
+	if caTransport != nil && http.DefaultTransport == systemTransport {
+		return caTransport
+	}
+	return http.DefaultTransport
+}
+
+// LoadCABundle returns the system certificate pool extended with the
+// certificates of a PEM file
+func LoadCABundle(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in CA bundle %s", path)
+	}
+	return pool, nil
+}
+
+// SetCABundle makes Azure DevOps requests trust the certificates of a PEM file
+// in addition to the system ones, for TLS-intercepting proxies and servers
+// with a private certificate authority. The system transport is copied, not
+// changed. Call it before EnableTracing and before creating clients.
+func SetCABundle(path string) error {
+	pool, err := LoadCABundle(path)
+	if err != nil {
+		return err
+	}
+	system, ok := systemTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("cannot use CA bundle: the default HTTP transport was replaced")
+	}
+
+	transport := system.Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	transport.Proxy = http.ProxyFromEnvironment
+	caTransport = transport
+	return nil
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetCABundle(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	// The first request fails the handshake on purpose
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	t.Cleanup(func() {
+		if caTransport != nil {
+			caTransport.CloseIdleConnections()
+		}
+		caTransport = nil
+	})
+
+	system := &http.Client{Transport: systemTransport}
+	if _, err := system.Get(server.URL); err == nil {
+		t.Fatal("request to a server with an untrusted certificate succeeded")
+	}
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, certificate, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetCABundle(bundle); err != nil {
+		t.Fatalf("SetCABundle() error = %v", err)
+	}
+
+	resp, err := (&http.Client{Transport: caTransport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with the CA bundle failed: %v", err)
+	}
+	resp.Body.Close()
+
+	// Other clients keep the system certificates
+	if _, err := system.Get(server.URL); err == nil {
+		t.Error("the CA bundle changed the system transport")
+	}
+}
+
+func TestLoadCABundleWithoutCertificates(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(bundle, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCABundle(bundle); err == nil {
+		t.Error("LoadCABundle() succeeded without certificates")
+	}
+}
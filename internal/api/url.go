@@ -2,35 +2,114 @@ package api
 
 import (
 	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/google/uuid"
 )
 
-// NormalizeOrganizationURL normalizes the organization input to a proper Azure DevOps URL
-func NormalizeOrganizationURL(org string) string {
-	org = strings.TrimSpace(org)
+// organizationNamePattern matches valid Azure DevOps organization names
+var organizationNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,48}[A-Za-z0-9])?$`)
+
+// NormalizeOrganizationURL normalizes the organization input to a proper Azure DevOps URL.
+// It accepts a bare organization name, a dev.azure.com URL (optionally including the
+// project or a deeper path) or a legacy <org>.visualstudio.com URL, and returns
+// https://dev.azure.com/<org>. Any other host is taken to be Azure DevOps Server,
+// whose collection URL is returned, e.g. https://tfs.contoso.com/DefaultCollection.
+// Input that cannot be made valid returns a descriptive error.
+func NormalizeOrganizationURL(org string) (string, error) {
+	input := strings.TrimSpace(org)
+	if input == "" {
+		return "", fmt.Errorf("organization is empty")
+	}
+
+	raw := input
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
 
-	// If it's already a full URL, return it
-	if strings.HasPrefix(org, "https://dev.azure.com/") {
-		// Remove trailing slash if present
-		return strings.TrimSuffix(org, "/")
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid organization URL '%s': %w", input, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("invalid organization URL '%s': unsupported scheme '%s'", input, parsed.Scheme)
 	}
 
-	// If it starts with http:// or https://, but not the correct domain, try to extract org name
-	if strings.HasPrefix(org, "http://") || strings.HasPrefix(org, "https://") {
-		// Try to extract organization name from URL
-		parts := strings.Split(org, "/")
-		if len(parts) > 0 {
-			org = parts[len(parts)-1]
+	host := parsed.Hostname()
+	lowerHost := strings.ToLower(host)
+	segments := strings.FieldsFunc(parsed.Path, func(r rune) bool { return r == '/' })
+
+	var name string
+	switch {
+	case lowerHost == "dev.azure.com":
+		// https://dev.azure.com/<org>[/<project>/...]
+		if len(segments) == 0 {
+			return "", fmt.Errorf("organization URL '%s' is missing the organization name (expected https://dev.azure.com/<org>)", input)
 		}
+		name = segments[0]
+	case strings.HasSuffix(lowerHost, ".visualstudio.com"):
+		// Legacy https://<org>.visualstudio.com[/DefaultCollection/<project>/...]
+		name = host[:len(host)-len(".visualstudio.com")]
+	case !strings.Contains(host, ".") && parsed.Port() == "" && !strings.Contains(input, "://"):
+		// Bare organization name, possibly followed by a project or trailing slash
+		name = host
+	case strings.Contains(host, ".") || strings.Contains(input, "://"):
+		return serverCollectionURL(parsed, segments, input)
+	default:
+		return "", fmt.Errorf("unrecognized organization URL '%s' (expected an organization name, https://dev.azure.com/<org>, https://<org>.visualstudio.com or an Azure DevOps Server collection URL)", input)
 	}
 
-	// Remove dev.azure.com if user included it
-	org = strings.TrimPrefix(org, "dev.azure.com/")
-	org = strings.TrimPrefix(org, "dev.azure.com")
+	if !organizationNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid organization name '%s': use 1-50 letters, numbers or hyphens, starting and ending with a letter or number", name)
+	}
 
-	// Remove any leading/trailing slashes
-	org = strings.Trim(org, "/")
+	return "https://dev.azure.com/" + name, nil
+}
+
+// serverCollectionURL returns the collection URL of an Azure DevOps Server
+// URL, dropping the project and anything after it. The collection follows the
+// optional tfs virtual directory, as in https://server/tfs/DefaultCollection.
+func serverCollectionURL(parsed *url.URL, segments []string, input string) (string, error) {
+	collection := 1
+	if len(segments) > 0 && strings.EqualFold(segments[0], "tfs") {
+		collection = 2
+	}
+	if len(segments) < collection || strings.HasPrefix(segments[collection-1], "_") {
+		return "", fmt.Errorf("organization URL '%s' is missing the collection (expected e.g. https://%s/DefaultCollection)", input, parsed.Host)
+	}
+
+	return fmt.Sprintf("%s://%s/%s", parsed.Scheme, parsed.Host, strings.Join(segments[:collection], "/")), nil
+}
+
+// WorkItemIDFromURL extracts the work item ID from a work item relation URL.
+// Returns 0 if the URL does not end in a numeric ID (e.g. artifact links).
+func WorkItemIDFromURL(relationURL string) int {
+	parts := strings.Split(relationURL, "/")
+	if id, err := strconv.Atoi(parts[len(parts)-1]); err == nil {
+		return id
+	}
+	return 0
+}
+
+// AttachmentIDFromURL extracts the attachment ID from an AttachedFile relation URL,
+// e.g. https://dev.azure.com/org/_apis/wit/attachments/<id>?fileName=log.txt
+func AttachmentIDFromURL(relationURL string) (uuid.UUID, error) {
+	parsed, err := url.Parse(relationURL)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("invalid attachment URL '%s': %w", relationURL, err)
+	}
+	id, err := uuid.Parse(path.Base(parsed.Path))
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("attachment URL '%s' has no attachment ID", relationURL)
+	}
+	return id, nil
+}
 
-	// Build proper URL
-	return fmt.Sprintf("https://dev.azure.com/%s", org)
+// WorkItemWebURL builds the browser URL of a work item
+func WorkItemWebURL(organizationURL, project string, id int) string {
+	return fmt.Sprintf("%s/%s/_workitems/edit/%d", strings.TrimSuffix(organizationURL, "/"), url.PathEscape(project), id)
 }
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+)
+
+// FieldError is a rule broken by a field value, as reported by Azure DevOps
+type FieldError struct {
+	Field   string // reference name; empty when the error is not about one field
+	Message string
+}
+
+// ValidateWorkItem checks a new work item against the rules of its type
+// without creating it. Rule violations, such as a missing required field or
+// a value that is not in a picklist, are returned as field errors; the error
+// is only set when the check itself could not be made.
+func (c *Client) ValidateWorkItem(workItemType string, fields map[string]interface{}, relations []WorkItemLink) ([]FieldError, error) {
+	_, err := c.createWorkItem(workItemType, fields, relations, true)
+	if err == nil {
+		return nil, nil
+	}
+
+	wrapped, ok := asWrappedError(err)
+	if !ok || wrapped.StatusCode == nil || *wrapped.StatusCode != http.StatusBadRequest {
+		return nil, fmt.Errorf("failed to validate work item: %w", err)
+	}
+	if fieldErrors := ruleValidationErrors(wrapped); len(fieldErrors) > 0 {
+		return fieldErrors, nil
+	}
+	// Unknown fields and values of the wrong type are rejected without rule details
+	return []FieldError{{Message: wrapped.Error()}}, nil
+}
+
+// ruleValidationErrors reads the per-field errors of a RuleValidationException
+func ruleValidationErrors(wrapped *azuredevops.WrappedError) []FieldError {
+	if wrapped.CustomProperties == nil {
+		return nil
+	}
+	rules, _ := (*wrapped.CustomProperties)["RuleValidationErrors"].([]interface{})
+
+	var fieldErrors []FieldError
+	for _, rule := range rules {
+		properties, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		field, _ := properties["fieldReferenceName"].(string)
+		message, _ := properties["errorMessage"].(string)
+		if message == "" {
+			continue
+		}
+		fieldErrors = append(fieldErrors, FieldError{Field: field, Message: strings.TrimSpace(message)})
+	}
+	return fieldErrors
+}
+
+// asWrappedError finds the service error in err; the SDK returns it both by
+// value and by pointer
+func asWrappedError(err error) (*azuredevops.WrappedError, bool) {
+	var wrapped azuredevops.WrappedError
+	if errors.As(err, &wrapped) {
+		return &wrapped, true
+	}
+	var wrappedPtr *azuredevops.WrappedError
+	if errors.As(err, &wrappedPtr) && wrappedPtr != nil {
+		return wrappedPtr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// validateClient answers CreateWorkItem with a fixed error and records whether it was validate-only
+type validateClient struct {
+	workitemtracking.Client
+	err          error
+	validateOnly bool
+}
+
+func (v *validateClient) CreateWorkItem(ctx context.Context, args workitemtracking.CreateWorkItemArgs) (*workitemtracking.WorkItem, error) {
+	v.validateOnly = args.ValidateOnly != nil && *args.ValidateOnly
+	return &workitemtracking.WorkItem{}, v.err
+}
+
+func TestValidateWorkItem(t *testing.T) {
+	badRequest, unauthorized := 400, 401
+	ruleMessage, fieldMessage := "TF401320: Rule Error for field Title.", "TF51535: Cannot find field Custom.Missing."
+	ruleError := azuredevops.WrappedError{
+		Message:    &ruleMessage,
+		StatusCode: &badRequest,
+		CustomProperties: &map[string]interface{}{"RuleValidationErrors": []interface{}{
+			map[string]interface{}{"fieldReferenceName": "System.Title", "errorMessage": "TF401320: Rule Error for field Title. Error code: Required, InvalidEmpty."},
+			map[string]interface{}{"fieldReferenceName": "System.State", "errorMessage": "TF401320: Rule Error for field State. Error code: HasValues, LimitedToValues."},
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		err     error
+		want    []FieldError
+		wantErr bool
+	}{
+		{"valid", nil, nil, false},
+		{"rule errors", ruleError, []FieldError{
+			{Field: "System.Title", Message: "TF401320: Rule Error for field Title. Error code: Required, InvalidEmpty."},
+			{Field: "System.State", Message: "TF401320: Rule Error for field State. Error code: HasValues, LimitedToValues."},
+		}, false},
+		{"unknown field", &azuredevops.WrappedError{Message: &fieldMessage, StatusCode: &badRequest}, []FieldError{{Message: fieldMessage}}, false},
+		{"unauthorized", azuredevops.WrappedError{Message: &fieldMessage, StatusCode: &unauthorized}, nil, true},
+		{"network", errors.New("connection reset"), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &validateClient{err: tt.err}
+			c := &Client{workItemClient: fake, project: "Project", ctx: context.Background(), readOnly: true}
+
+			got, err := c.ValidateWorkItem("Bug", map[string]interface{}{"System.Title": ""}, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateWorkItem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateWorkItem() = %+v, want %+v", got, tt.want)
+			}
+			if !fake.validateOnly {
+				t.Errorf("ValidateWorkItem() did not set ValidateOnly")
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package api
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 )
@@ -21,8 +23,29 @@ func (c *Client) GetWorkItem(id int) (*workitemtracking.WorkItem, error) {
 	return workItem, nil
 }
 
-// ListWorkItems retrieves a list of work items based on a WIQL query
+// ListWorkItems retrieves a list of work items based on a WIQL query.
+// Work items are fetched in batches, so results are not capped at the 200 items a single batch returns.
 func (c *Client) ListWorkItems(wiql string, top int) (*[]workitemtracking.WorkItem, error) {
+	ids, err := c.QueryWorkItemIDs(wiql, top)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return &[]workitemtracking.WorkItem{}, nil
+	}
+
+	workItems, err := c.GetWorkItems(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workItems, nil
+}
+
+// QueryWorkItemIDs runs a WIQL query and returns the matching IDs in query order.
+// The service returns at most 20,000 IDs; top limits the result further when positive.
+func (c *Client) QueryWorkItemIDs(wiql string, top int) ([]int, error) {
 	args := workitemtracking.QueryByWiqlArgs{
 		Wiql: &workitemtracking.Wiql{
 			Query: &wiql,
@@ -42,46 +65,97 @@ func (c *Client) ListWorkItems(wiql string, top int) (*[]workitemtracking.WorkIt
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 
-	if queryResult.WorkItems == nil || len(*queryResult.WorkItems) == 0 {
-		return &[]workitemtracking.WorkItem{}, nil
-	}
-
-	// Extract work item IDs
 	var ids []int
-	for _, ref := range *queryResult.WorkItems {
-		if ref.Id != nil {
-			ids = append(ids, *ref.Id)
+	if queryResult.WorkItems != nil {
+		for _, ref := range *queryResult.WorkItems {
+			if ref.Id != nil {
+				ids = append(ids, *ref.Id)
+			}
 		}
 	}
 
-	if len(ids) == 0 {
-		return &[]workitemtracking.WorkItem{}, nil
-	}
+	return ids, nil
+}
 
-	// Get full work item details using the batch endpoint
-	// Note: We use Expand instead of Fields to get all data
+// GetWorkItems retrieves work items by ID, including their relations.
+// IDs are fetched in batches of 200, the maximum the batch endpoint accepts.
+func (c *Client) GetWorkItems(ids []int) ([]workitemtracking.WorkItem, error) {
 	expand := workitemtracking.WorkItemExpandValues.All
+	return c.getWorkItemsBatch(ids, workitemtracking.WorkItemBatchGetRequest{Expand: &expand})
+}
 
-	workItems, err := c.workItemClient.GetWorkItemsBatch(c.ctx, workitemtracking.GetWorkItemsBatchArgs{
-		Project: &c.project,
-		WorkItemGetRequest: &workitemtracking.WorkItemBatchGetRequest{
-			Ids:    &ids,
-			Expand: &expand,
-		},
+// GetWorkItemsAsOf returns work items as they were at the given time
+func (c *Client) GetWorkItemsAsOf(ids []int, asOf time.Time) ([]workitemtracking.WorkItem, error) {
+	expand := workitemtracking.WorkItemExpandValues.All
+	return c.getWorkItemsBatch(ids, workitemtracking.WorkItemBatchGetRequest{
+		Expand: &expand,
+		AsOf:   &azuredevops.Time{Time: asOf},
 	})
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to get work items: %w", err)
+// GetWorkItemFields returns work items with only the given fields, for
+// commands that read one or two fields of many work items
+func (c *Client) GetWorkItemFields(ids []int, fields []string) ([]workitemtracking.WorkItem, error) {
+	return c.getWorkItemsBatch(ids, workitemtracking.WorkItemBatchGetRequest{Fields: &fields})
+}
+
+// getWorkItemsBatch fetches work items in batches with the options of request
+func (c *Client) getWorkItemsBatch(ids []int, request workitemtracking.WorkItemBatchGetRequest) ([]workitemtracking.WorkItem, error) {
+	const batchSize = 200
+
+	var workItems []workitemtracking.WorkItem
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+		request.Ids = &batch
+
+		result, err := c.workItemClient.GetWorkItemsBatch(c.ctx, workitemtracking.GetWorkItemsBatchArgs{
+			Project:            &c.project,
+			WorkItemGetRequest: &request,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get work items: %w", err)
+		}
+
+		if result != nil {
+			workItems = append(workItems, *result...)
+		}
 	}
 
 	return workItems, nil
 }
 
-// CreateWorkItem creates a new work item
+// CreateWorkItem creates a new work item, as a child of parentID unless it is 0
 func (c *Client) CreateWorkItem(workItemType string, fields map[string]interface{}, parentID int) (*workitemtracking.WorkItem, error) {
-	// Build JSON patch document
-	var patchDocument []webapi.JsonPatchOperation
+	var relations []WorkItemLink
+	if parentID > 0 {
+		relations = append(relations, c.ParentLink(parentID))
+	}
+	return c.CreateWorkItemWithRelations(workItemType, fields, relations)
+}
+
+// CreateWorkItemWithRelations creates a new work item with its links, such as
+// its parent, related work items and artifacts, in a single request
+func (c *Client) CreateWorkItemWithRelations(workItemType string, fields map[string]interface{}, relations []WorkItemLink) (*workitemtracking.WorkItem, error) {
+	if err := c.checkWritable(); err != nil {
+		return nil, err
+	}
 
+	workItem, err := c.createWorkItem(workItemType, fields, relations, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work item: %w", err)
+	}
+
+	return workItem, nil
+}
+
+// createWorkItem sends the create request; with validateOnly the service
+// checks the fields against the type's rules without saving anything
+func (c *Client) createWorkItem(workItemType string, fields map[string]interface{}, relations []WorkItemLink, validateOnly bool) (*workitemtracking.WorkItem, error) {
+	var patchDocument []webapi.JsonPatchOperation
 	for field, value := range fields {
 		op := webapi.OperationValues.Add
 		path := fmt.Sprintf("/fields/%s", field)
@@ -91,43 +165,97 @@ func (c *Client) CreateWorkItem(workItemType string, fields map[string]interface
 			Value: value,
 		})
 	}
+	patchDocument = append(patchDocument, linkPatch(relations)...)
 
-	// Add parent relationship if specified
-	if parentID > 0 {
-		parentURL := fmt.Sprintf("%s/_apis/wit/workItems/%d", c.organizationURL, parentID)
-		op := webapi.OperationValues.Add
-		path := "/relations/-"
-		patchDocument = append(patchDocument, webapi.JsonPatchOperation{
-			Op:   &op,
-			Path: &path,
-			Value: map[string]interface{}{
-				"rel": "System.LinkTypes.Hierarchy-Reverse",
-				"url": parentURL,
-			},
-		})
-	}
-
-	validateOnly := false
-	// Create work item
-	workItem, err := c.workItemClient.CreateWorkItem(c.ctx, workitemtracking.CreateWorkItemArgs{
+	return c.workItemClient.CreateWorkItem(c.ctx, workitemtracking.CreateWorkItemArgs{
 		Document:     &patchDocument,
 		Project:      &c.project,
 		Type:         &workItemType,
 		ValidateOnly: &validateOnly,
 	})
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to create work item: %w", err)
+// UpdateWorkItem updates an existing work item
+func (c *Client) UpdateWorkItem(id int, fields map[string]interface{}) (*workitemtracking.WorkItem, error) {
+	return c.updateWorkItem(id, fieldPatch(fields))
+}
+
+// UpdateWorkItemAtRevision updates a work item only if it is still at revision
+// rev, so that changes computed from the fields read at that revision are
+// applied atomically. It returns ErrRevisionConflict if the work item has
+// changed since.
+func (c *Client) UpdateWorkItemAtRevision(id, rev int, fields map[string]interface{}) (*workitemtracking.WorkItem, error) {
+	op := webapi.OperationValues.Test
+	path := "/rev"
+	patchDocument := append([]webapi.JsonPatchOperation{{Op: &op, Path: &path, Value: rev}}, fieldPatch(fields)...)
+
+	workItem, err := c.updateWorkItem(id, patchDocument)
+	if err != nil && isTestOperationFailure(err) {
+		return nil, fmt.Errorf("failed to update work item %d: %w", id, ErrRevisionConflict)
 	}
+	return workItem, err
+}
 
-	return workItem, nil
+// WorkItemLink is a link to add to a work item
+type WorkItemLink struct {
+	Rel        string // e.g. System.LinkTypes.Related, ArtifactLink, Hyperlink
+	URL        string
+	Attributes map[string]interface{} // e.g. name, comment
 }
 
-// UpdateWorkItem updates an existing work item
-func (c *Client) UpdateWorkItem(id int, fields map[string]interface{}) (*workitemtracking.WorkItem, error) {
-	// Build JSON patch document
+// Relation types of links between work items
+const (
+	ParentLinkRelation  = "System.LinkTypes.Hierarchy-Reverse"
+	ChildLinkRelation   = "System.LinkTypes.Hierarchy-Forward"
+	RelatedLinkRelation = "System.LinkTypes.Related"
+)
+
+// ParentLink links a work item to its parent
+func (c *Client) ParentLink(id int) WorkItemLink {
+	return WorkItemLink{Rel: ParentLinkRelation, URL: c.WorkItemAPIURL(id)}
+}
+
+// RelatedLink links a work item to a related work item
+func (c *Client) RelatedLink(id int) WorkItemLink {
+	return WorkItemLink{Rel: RelatedLinkRelation, URL: c.WorkItemAPIURL(id)}
+}
+
+// ArtifactLink links a work item to an artifact such as a build, commit or pull
+// request (vstfs:///...); name is the link type shown in the UI, e.g. "Build"
+func ArtifactLink(artifactURL, name string) WorkItemLink {
+	return WorkItemLink{Rel: ArtifactLinkRelation, URL: artifactURL, Attributes: map[string]interface{}{"name": name}}
+}
+
+// UpdateWorkItemWithLinks sets fields and adds links to a work item in a single update
+func (c *Client) UpdateWorkItemWithLinks(id int, fields map[string]interface{}, links []WorkItemLink) (*workitemtracking.WorkItem, error) {
+	return c.updateWorkItem(id, append(fieldPatch(fields), linkPatch(links)...))
+}
+
+// linkPatch builds the JSON patch operations that add links
+func linkPatch(links []WorkItemLink) []webapi.JsonPatchOperation {
 	var patchDocument []webapi.JsonPatchOperation
+	for _, link := range links {
+		op := webapi.OperationValues.Add
+		path := "/relations/-"
+		value := map[string]interface{}{
+			"rel": link.Rel,
+			"url": link.URL,
+		}
+		if len(link.Attributes) > 0 {
+			value["attributes"] = link.Attributes
+		}
+		patchDocument = append(patchDocument, webapi.JsonPatchOperation{
+			Op:    &op,
+			Path:  &path,
+			Value: value,
+		})
+	}
+	return patchDocument
+}
 
+// fieldPatch builds a JSON patch document that sets fields
+func fieldPatch(fields map[string]interface{}) []webapi.JsonPatchOperation {
+	var patchDocument []webapi.JsonPatchOperation
 	for field, value := range fields {
 		op := webapi.OperationValues.Replace
 		path := fmt.Sprintf("/fields/%s", field)
@@ -137,9 +265,15 @@ func (c *Client) UpdateWorkItem(id int, fields map[string]interface{}) (*workite
 			Value: value,
 		})
 	}
+	return patchDocument
+}
+
+func (c *Client) updateWorkItem(id int, patchDocument []webapi.JsonPatchOperation) (*workitemtracking.WorkItem, error) {
+	if err := c.checkWritable(); err != nil {
+		return nil, err
+	}
 
 	validateOnly := false
-	// Update work item
 	workItem, err := c.workItemClient.UpdateWorkItem(c.ctx, workitemtracking.UpdateWorkItemArgs{
 		Id:           &id,
 		Document:     &patchDocument,
@@ -155,6 +289,10 @@ func (c *Client) UpdateWorkItem(id int, fields map[string]interface{}) (*workite
 
 // DeleteWorkItem deletes a work item
 func (c *Client) DeleteWorkItem(id int) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+
 	_, err := c.workItemClient.DeleteWorkItem(c.ctx, workitemtracking.DeleteWorkItemArgs{
 		Id: &id,
 	})
@@ -165,3 +303,32 @@ func (c *Client) DeleteWorkItem(id int) error {
 
 	return nil
 }
+
+// GetUpdates retrieves the revision history of a work item as field-level changes
+func (c *Client) GetUpdates(id int) ([]workitemtracking.WorkItemUpdate, error) {
+	const pageSize = 200
+
+	var updates []workitemtracking.WorkItemUpdate
+	for skip := 0; ; skip += pageSize {
+		top := pageSize
+		page, err := c.workItemClient.GetUpdates(c.ctx, workitemtracking.GetUpdatesArgs{
+			Id:      &id,
+			Project: &c.project,
+			Top:     &top,
+			Skip:    &skip,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get history for work item %d: %w", id, err)
+		}
+
+		if page == nil {
+			break
+		}
+		updates = append(updates, *page...)
+		if len(*page) < pageSize {
+			break
+		}
+	}
+
+	return updates, nil
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// createClient records the create requests it receives
+type createClient struct {
+	workitemtracking.Client
+	requests []workitemtracking.CreateWorkItemArgs
+}
+
+func (c *createClient) CreateWorkItem(ctx context.Context, args workitemtracking.CreateWorkItemArgs) (*workitemtracking.WorkItem, error) {
+	c.requests = append(c.requests, args)
+	id := 100
+	return &workitemtracking.WorkItem{Id: &id}, nil
+}
+
+func TestCreateWorkItemWithRelations(t *testing.T) {
+	fake := &createClient{}
+	c := &Client{workItemClient: fake, project: "Project", organizationURL: "https://dev.azure.com/org", ctx: context.Background()}
+
+	relations := []WorkItemLink{
+		c.ParentLink(1),
+		c.RelatedLink(2),
+		ArtifactLink("vstfs:///Build/Build/3", "Build"),
+	}
+	if _, err := c.CreateWorkItemWithRelations("Task", map[string]interface{}{"System.Title": "Fix login"}, relations); err != nil {
+		t.Fatalf("CreateWorkItemWithRelations() error = %v", err)
+	}
+
+	if len(fake.requests) != 1 {
+		t.Fatalf("CreateWorkItemWithRelations() sent %d requests, want 1", len(fake.requests))
+	}
+	document := *fake.requests[0].Document
+	if len(document) != 4 {
+		t.Fatalf("patch document has %d operations, want 4", len(document))
+	}
+	if *document[0].Path != "/fields/System.Title" || document[0].Value != "Fix login" {
+		t.Errorf("operation 0 = %s %v, want the title", *document[0].Path, document[0].Value)
+	}
+
+	want := []map[string]interface{}{
+		{"rel": ParentLinkRelation, "url": "https://dev.azure.com/org/_apis/wit/workItems/1"},
+		{"rel": RelatedLinkRelation, "url": "https://dev.azure.com/org/_apis/wit/workItems/2"},
+		{"rel": ArtifactLinkRelation, "url": "vstfs:///Build/Build/3", "attributes": map[string]interface{}{"name": "Build"}},
+	}
+	for i, value := range want {
+		op := document[i+1]
+		if *op.Path != "/relations/-" || !reflect.DeepEqual(op.Value, value) {
+			t.Errorf("operation %d = %s %v, want %v", i+1, *op.Path, op.Value, value)
+		}
+	}
+}
+
+// failingCreateClient fails to create work items with a given title
+type failingCreateClient struct {
+	workitemtracking.Client
+	fail string
+
+	mu   sync.Mutex
+	next int
+}
+
+func (c *failingCreateClient) CreateWorkItem(ctx context.Context, args workitemtracking.CreateWorkItemArgs) (*workitemtracking.WorkItem, error) {
+	for _, op := range *args.Document {
+		if *op.Path == "/fields/System.Title" && op.Value == c.fail {
+			return nil, errors.New("TF401320: Rule Error")
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.next++
+	id := c.next
+	return &workitemtracking.WorkItem{Id: &id}, nil
+}
+
+func TestCreateWorkItems(t *testing.T) {
+	c := &Client{workItemClient: &failingCreateClient{fail: "Broken"}, project: "Project", ctx: context.Background()}
+
+	titles := []string{"One", "Broken", "Three", "Four", "Five", "Six"}
+	items := make([]NewWorkItem, len(titles))
+	for i, title := range titles {
+		items[i] = NewWorkItem{Type: "Task", Fields: map[string]interface{}{"System.Title": title}}
+	}
+
+	results := c.CreateWorkItems(items)
+	if len(results) != len(items) {
+		t.Fatalf("CreateWorkItems() returned %d results, want %d", len(results), len(items))
+	}
+	ids := map[int]bool{}
+	for i, result := range results {
+		if titles[i] == "Broken" {
+			if result.Error == nil {
+				t.Errorf("result %d error = nil, want the create error", i)
+			}
+			continue
+		}
+		if result.Error != nil || result.WorkItem == nil {
+			t.Fatalf("result %d = %+v, want a work item", i, result)
+		}
+		ids[*result.WorkItem.Id] = true
+	}
+	if len(ids) != len(items)-1 {
+		t.Errorf("created %d distinct work items, want %d", len(ids), len(items)-1)
+	}
+
+	c.SetReadOnly(true)
+	for _, result := range c.CreateWorkItems(items[:2]) {
+		if !errors.Is(result.Error, ErrReadOnly) {
+			t.Errorf("read-only CreateWorkItems() error = %v, want ErrReadOnly", result.Error)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 )
@@ -73,22 +74,117 @@ func (c *Client) GetFieldDefinition(workItemTypeName, fieldReferenceName string)
 	return nil, fmt.Errorf("field '%s' not found for work item type '%s'", fieldReferenceName, workItemTypeName)
 }
 
+// WorkItemState is a state of a work item type with its category and board color
+type WorkItemState struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Color    string `json:"color"`
+}
+
+// GetWorkItemTypeStates returns the states of a work item type in workflow
+// order. Results are cached per type for the life of the client.
+func (c *Client) GetWorkItemTypeStates(workItemTypeName string) ([]WorkItemState, error) {
+	key := strings.ToLower(workItemTypeName)
+
+	c.statesMu.Lock()
+	cached, ok := c.states[key]
+	c.statesMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	colors, err := c.workItemClient.GetWorkItemTypeStates(c.ctx, workitemtracking.GetWorkItemTypeStatesArgs{
+		Project: &c.project,
+		Type:    &workItemTypeName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get states for work item type '%s': %w", workItemTypeName, err)
+	}
+
+	var states []WorkItemState
+	if colors != nil {
+		for _, color := range *colors {
+			if color.Name == nil {
+				continue
+			}
+			state := WorkItemState{Name: *color.Name}
+			if color.Category != nil {
+				state.Category = *color.Category
+			}
+			if color.Color != nil {
+				state.Color = *color.Color
+			}
+			states = append(states, state)
+		}
+	}
+
+	c.statesMu.Lock()
+	if c.states == nil {
+		c.states = make(map[string][]WorkItemState)
+	}
+	c.states[key] = states
+	c.statesMu.Unlock()
+
+	return states, nil
+}
+
 // GetWorkItemStates returns a list of valid states for a work item type
 func (c *Client) GetWorkItemStates(workItemTypeName string) ([]string, error) {
-	workItemType, err := c.GetWorkItemType(workItemTypeName)
+	states, err := c.GetWorkItemTypeStates(workItemTypeName)
 	if err != nil {
 		return nil, err
 	}
 
-	var states []string
+	names := make([]string, len(states))
+	for i, state := range states {
+		names[i] = state.Name
+	}
+	return names, nil
+}
+
+// GetWorkItemTypeFields returns the fields of a work item type including their allowed values
+func (c *Client) GetWorkItemTypeFields(workItemTypeName string) (*[]workitemtracking.WorkItemTypeFieldWithReferences, error) {
+	expand := workitemtracking.WorkItemTypeFieldsExpandLevelValues.AllowedValues
 
-	if workItemType.States != nil {
-		for _, state := range *workItemType.States {
-			if state.Name != nil {
-				states = append(states, *state.Name)
-			}
+	fields, err := c.workItemClient.GetWorkItemTypeFieldsWithReferences(c.ctx, workitemtracking.GetWorkItemTypeFieldsWithReferencesArgs{
+		Project: &c.project,
+		Type:    &workItemTypeName,
+		Expand:  &expand,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fields for work item type '%s': %w", workItemTypeName, err)
+	}
+
+	return fields, nil
+}
+
+// GetFields returns all field definitions for the project, including their data types
+func (c *Client) GetFields() (*[]workitemtracking.WorkItemField, error) {
+	fields, err := c.workItemClient.GetFields(c.ctx, workitemtracking.GetFieldsArgs{
+		Project: &c.project,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fields: %w", err)
+	}
+
+	return fields, nil
+}
+
+// GetStateForCategory returns the first state of a work item type in the given
+// state category (Proposed, InProgress, Resolved, Completed, Removed)
+func (c *Client) GetStateForCategory(workItemTypeName, category string) (string, error) {
+	states, err := c.GetWorkItemTypeStates(workItemTypeName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, state := range states {
+		if state.Category == category {
+			return state.Name, nil
 		}
 	}
 
-	return states, nil
+	return "", fmt.Errorf("no '%s' state found for work item type '%s'", category, workItemTypeName)
 }
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// statesClient answers GetWorkItemTypeStates and counts the calls
+type statesClient struct {
+	workitemtracking.Client
+	calls int
+}
+
+func (s *statesClient) GetWorkItemTypeStates(ctx context.Context, args workitemtracking.GetWorkItemTypeStatesArgs) (*[]workitemtracking.WorkItemStateColor, error) {
+	s.calls++
+	name, category, color := "Active", "InProgress", "007acc"
+	return &[]workitemtracking.WorkItemStateColor{{Name: &name, Category: &category, Color: &color}}, nil
+}
+
+func TestGetWorkItemTypeStatesCaches(t *testing.T) {
+	fake := &statesClient{}
+	c := &Client{workItemClient: fake, project: "Project", ctx: context.Background()}
+
+	for _, name := range []string{"User Story", "user story"} {
+		states, err := c.GetWorkItemTypeStates(name)
+		if err != nil {
+			t.Fatalf("GetWorkItemTypeStates(%q) error = %v", name, err)
+		}
+		if len(states) != 1 || states[0] != (WorkItemState{Name: "Active", Category: "InProgress", Color: "007acc"}) {
+			t.Errorf("GetWorkItemTypeStates(%q) = %+v", name, states)
+		}
+	}
+	if fake.calls != 1 {
+		t.Errorf("GetWorkItemTypeStates made %d requests, want 1", fake.calls)
+	}
+
+	if state, err := c.GetStateForCategory("User Story", "InProgress"); err != nil || state != "Active" {
+		t.Errorf("GetStateForCategory() = %q, %v, want Active", state, err)
+	}
+}
@@ -9,6 +9,13 @@ import (
 
 const (
 	tokenFileName = "token"
+
+	// TokenEnvVar holds a Personal Access Token that takes precedence over the token file
+	TokenEnvVar = "AZB_PAT"
+
+	// replayEnvVar is api.ReplayEnvVar; replayed requests need no real token
+	replayEnvVar = "AZB_REPLAY"
+	replayToken  = "replay"
 )
 
 // GetTokenPath returns the path to the token file
@@ -38,11 +45,19 @@ func SaveToken(token string) error {
 		return fmt.Errorf("failed to save token: %w", err)
 	}
 
-	return nil
+	// The expiry and scopes recorded for the previous token no longer apply
+	return removeTokenInfo()
 }
 
 // GetToken retrieves the stored Personal Access Token
 func GetToken() (string, error) {
+	if os.Getenv(replayEnvVar) != "" {
+		return replayToken, nil
+	}
+	if token := strings.TrimSpace(os.Getenv(TokenEnvVar)); token != "" {
+		return token, nil
+	}
+
 	tokenPath, err := GetTokenPath()
 	if err != nil {
 		return "", err
@@ -67,6 +82,11 @@ func GetToken() (string, error) {
 	return token, nil
 }
 
+// TokenFromEnv reports whether the token is taken from the AZB_PAT environment variable
+func TokenFromEnv() bool {
+	return strings.TrimSpace(os.Getenv(TokenEnvVar)) != ""
+}
+
 // IsAuthenticated checks if the user is authenticated
 func IsAuthenticated() bool {
 	_, err := GetToken()
@@ -90,5 +110,5 @@ func Logout() error {
 		return fmt.Errorf("failed to remove token: %w", err)
 	}
 
-	return nil
+	return removeTokenInfo()
 }
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	tokenInfoFileName = "token_info.json"
+
+	// ExpiryWarningDays is how many days before its expiry a token is reported as expiring
+	ExpiryWarningDays = 7
+
+	// ExpiryDateLayout is the format of expiry dates entered at login
+	ExpiryDateLayout = "2006-01-02"
+)
+
+// TokenInfo describes the stored token as entered at login. Azure DevOps does
+// not tell a personal access token its own expiry or scopes, so they are
+// recorded when the token is saved.
+type TokenInfo struct {
+	Expires time.Time `json:"expires,omitempty"`
+	Scopes  []string  `json:"scopes,omitempty"`
+}
+
+// tokenInfoPath returns the path of the token info file, next to the token file
+func tokenInfoPath() (string, error) {
+	tokenPath, err := GetTokenPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(tokenPath), tokenInfoFileName), nil
+}
+
+// SaveTokenInfo records the expiry and scopes of the stored token
+func SaveTokenInfo(info *TokenInfo) error {
+	path, err := tokenInfoPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode token info: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to save token info: %w", err)
+	}
+	return nil
+}
+
+// LoadTokenInfo returns what is known about the token in use. It is empty
+// when nothing was recorded or the token comes from AZB_PAT.
+func LoadTokenInfo() (*TokenInfo, error) {
+	info := &TokenInfo{}
+	if TokenFromEnv() {
+		return info, nil
+	}
+
+	path, err := tokenInfoPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return info, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token info: %w", err)
+	}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, fmt.Errorf("failed to parse token info: %w", err)
+	}
+	return info, nil
+}
+
+// removeTokenInfo deletes the token info file, if any
+func removeTokenInfo() error {
+	path, err := tokenInfoPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove token info: %w", err)
+	}
+	return nil
+}
+
+// DaysLeft returns the number of days from now until the token expires,
+// negative once it has expired
+func (i *TokenInfo) DaysLeft(now time.Time) int {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	expires := time.Date(i.Expires.Year(), i.Expires.Month(), i.Expires.Day(), 0, 0, 0, 0, time.UTC)
+	return int(expires.Sub(today).Hours() / 24)
+}
+
+// ExpiryWarning describes a token that has expired or expires within
+// ExpiryWarningDays, or returns an empty string
+func (i *TokenInfo) ExpiryWarning(now time.Time) string {
+	if i.Expires.IsZero() {
+		return ""
+	}
+
+	days := i.DaysLeft(now)
+	switch {
+	case days < 0:
+		return fmt.Sprintf("Your access token expired on %s. Run 'azb auth login' with a new token.", i.Expires.Format(ExpiryDateLayout))
+	case days == 0:
+		return "Your access token expires today. Run 'azb auth login' with a new token."
+	case days == 1:
+		return "Your access token expires tomorrow. Run 'azb auth login' with a new token."
+	case days <= ExpiryWarningDays:
+		return fmt.Sprintf("Your access token expires in %d days. Run 'azb auth login' with a new token.", days)
+	}
+	return ""
+}
+
+// TokenExpiryWarning returns the expiry warning of the token in use, if any
+func TokenExpiryWarning(now time.Time) string {
+	info, err := LoadTokenInfo()
+	if err != nil {
+		return ""
+	}
+	return info.ExpiryWarning(now)
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiryWarning(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		expires string
+		want    string
+	}{
+		{"not recorded", "", ""},
+		{"far away", "2026-12-31", ""},
+		{"within a week", "2026-10-20", "Your access token expires in 4 days. Run 'azb auth login' with a new token."},
+		{"tomorrow", "2026-10-17", "Your access token expires tomorrow. Run 'azb auth login' with a new token."},
+		{"today", "2026-10-16", "Your access token expires today. Run 'azb auth login' with a new token."},
+		{"expired", "2026-10-01", "Your access token expired on 2026-10-01. Run 'azb auth login' with a new token."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &TokenInfo{}
+			if tt.expires != "" {
+				expires, err := time.Parse(ExpiryDateLayout, tt.expires)
+				if err != nil {
+					t.Fatal(err)
+				}
+				info.Expires = expires
+			}
+			if got := info.ExpiryWarning(now); got != tt.want {
+				t.Errorf("ExpiryWarning() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveTokenClearsTokenInfo(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(TokenEnvVar, "")
+
+	if err := SaveToken("old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveTokenInfo(&TokenInfo{Scopes: []string{"vso.work"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveToken("new"); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := LoadTokenInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Scopes) != 0 {
+		t.Errorf("token info of the previous token was kept: %+v", info)
+	}
+}
@@ -4,19 +4,57 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Organization        string `mapstructure:"organization"`
-	Project             string `mapstructure:"project"`
-	DefaultAreaPath     string `mapstructure:"default_area_path"`
-	DefaultIteration    string `mapstructure:"default_iteration"`
-	CacheTTL            int    `mapstructure:"cache_ttl"`
-	DefaultView         string `mapstructure:"default_view"`
-	PersonalAccessToken string `mapstructure:"personal_access_token"`
+	Organization         string          `mapstructure:"organization"`
+	Project              string          `mapstructure:"project"`
+	DefaultAreaPath      string          `mapstructure:"default_area_path"`
+	DefaultIteration     string          `mapstructure:"default_iteration"`
+	DefaultTemplate      string          `mapstructure:"default_template"`
+	CacheTTL             int             `mapstructure:"cache_ttl"`
+	DefaultView          string          `mapstructure:"default_view"`
+	DefaultFormat        string          `mapstructure:"default_format"`
+	Theme                string          `mapstructure:"theme"`
+	TriageQuery          string          `mapstructure:"triage_query"`
+	MaxRetries           string          `mapstructure:"max_retries"`
+	RetryBaseDelay       string          `mapstructure:"retry_base_delay"`
+	Concurrency          string          `mapstructure:"concurrency"`
+	TemplatesDir         string          `mapstructure:"templates_dir"`
+	TeamTemplatesRepo    string          `mapstructure:"team_templates_repo"`
+	TeamTemplatesDir     string          `mapstructure:"team_templates_dir"`
+	ReadOnly             string          `mapstructure:"read_only"`
+	GitBranchPattern     string          `mapstructure:"git_branch_pattern"`
+	SkipURLNormalization string          `mapstructure:"skip_url_normalization"`
+	CABundle             string          `mapstructure:"ca_bundle"`
+	Dashboard            DashboardConfig `mapstructure:"dashboard"`
+	PersonalAccessToken  string          `mapstructure:"personal_access_token"`
+}
+
+// DashboardConfig configures what the dashboard's Work Items tab shows and how,
+// how often it reloads and how long notifications stay on screen
+type DashboardConfig struct {
+	DefaultQuery        string          `mapstructure:"default_query"`
+	Views               []DashboardView `mapstructure:"views"`
+	NotificationTimeout string          `mapstructure:"notification_timeout"`
+	ErrorTimeout        string          `mapstructure:"error_timeout"`
+	RefreshInterval     string          `mapstructure:"refresh_interval"`
+	DetailsLayout       string          `mapstructure:"details_layout"`
+	DetailsSize         string          `mapstructure:"details_size"`
+	ConfirmDefault      string          `mapstructure:"confirm_default"`
+}
+
+// DashboardView is a named query the Work Items tab can switch to. Query is
+// inline WIQL or the name or path of a saved query.
+type DashboardView struct {
+	Name  string `mapstructure:"name"`
+	Query string `mapstructure:"query"`
 }
 
 // Load loads the configuration from file and environment variables
@@ -31,36 +69,121 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
-// Save saves the configuration to file
-func Save(cfg *Config) error {
-	viper.Set("organization", cfg.Organization)
-	viper.Set("project", cfg.Project)
-	viper.Set("default_area_path", cfg.DefaultAreaPath)
-	viper.Set("default_iteration", cfg.DefaultIteration)
-	viper.Set("cache_ttl", cfg.CacheTTL)
-	viper.Set("default_view", cfg.DefaultView)
+// Save writes the given keys of cfg, e.g. "project" or "dashboard.default_query",
+// to the config file. Other keys keep what the file says, so values that come
+// from AZB_* variables, flags or a project config are never saved by accident.
+func Save(cfg *Config, keys ...string) error {
+	values := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		value, ok := cfg.lookup(key)
+		if !ok {
+			return fmt.Errorf("unknown configuration key '%s'", key)
+		}
+		values[key] = value
+	}
 
-	// Don't save PAT in config file - use auth package for that
-	// viper.Set("personal_access_token", cfg.PersonalAccessToken)
+	return updateConfigFile(func(doc map[string]interface{}) {
+		for key, value := range values {
+			setKey(doc, key, value)
+			viper.Set(key, value)
+		}
+	})
+}
 
-	// Get config file path
-	configFile := viper.ConfigFileUsed()
-	if configFile == "" {
-		// Create default config path
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
+// Unset removes keys from the config file so their defaults apply again.
+// Sections left empty are removed too.
+func Unset(keys ...string) error {
+	return updateConfigFile(func(doc map[string]interface{}) {
+		for _, key := range keys {
+			deleteKey(doc, strings.Split(key, "."))
+			viper.Set(key, nil)
 		}
+	})
+}
 
-		configDir := filepath.Join(home, ".azure-boards-cli")
-		if err := os.MkdirAll(configDir, 0755); err != nil {
-			return fmt.Errorf("failed to create config directory: %w", err)
+// deleteKey removes a key path from a YAML document and reports whether the
+// document is empty afterwards
+func deleteKey(doc map[string]interface{}, path []string) bool {
+	if len(path) > 1 {
+		if section, ok := doc[path[0]].(map[string]interface{}); ok && deleteKey(section, path[1:]) {
+			delete(doc, path[0])
 		}
+	} else {
+		delete(doc, path[0])
+	}
+	return len(doc) == 0
+}
 
-		configFile = filepath.Join(configDir, "config.yaml")
+// lookup returns the field of cfg whose mapstructure tag path is key
+func (cfg *Config) lookup(key string) (interface{}, bool) {
+	value := reflect.ValueOf(cfg).Elem()
+	for _, name := range strings.Split(key, ".") {
+		if value.Kind() != reflect.Struct {
+			return nil, false
+		}
+		found := false
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).Tag.Get("mapstructure") == name {
+				value, found = value.Field(i), true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
 	}
+	return value.Interface(), true
+}
 
-	return viper.WriteConfigAs(configFile)
+// setKey sets a dotted key in a YAML document, creating sections as needed
+func setKey(doc map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		section, ok := doc[part].(map[string]interface{})
+		if !ok {
+			section = make(map[string]interface{})
+			doc[part] = section
+		}
+		doc = section
+	}
+	doc[parts[len(parts)-1]] = value
+}
+
+// updateConfigFile reads the config file, lets update change it and writes it back
+func updateConfigFile(update func(doc map[string]interface{})) error {
+	configFile := viper.ConfigFileUsed()
+	if configFile == "" {
+		var err error
+		if configFile, err = GetConfigPath(); err != nil {
+			return err
+		}
+	}
+
+	doc := make(map[string]interface{})
+	data, err := os.ReadFile(configFile)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", configFile, err)
+	}
+	if doc == nil {
+		doc = make(map[string]interface{})
+	}
+
+	update(doc)
+
+	data, err = yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(configFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
 }
 
 // EnsureConfigDir ensures the config directory exists
@@ -87,9 +210,3 @@ func GetConfigPath() (string, error) {
 
 	return filepath.Join(configDir, "config.yaml"), nil
 }
-
-// SetDefaults sets default configuration values
-func SetDefaults() {
-	viper.SetDefault("cache_ttl", 300)
-	viper.SetDefault("default_view", "assigned-to-me")
-}
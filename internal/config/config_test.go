@@ -48,10 +48,10 @@ func TestConfig_Save(t *testing.T) {
 		DefaultAreaPath:  "test-area",
 		DefaultIteration: "test-iteration",
 		CacheTTL:         300,
-		DefaultView:      "assigned-to-me",
+		DefaultView:      "dashboard",
 	}
 
-	err := Save(cfg)
+	err := Save(cfg, "organization", "project", "default_area_path", "default_iteration", "cache_ttl", "default_view")
 	if err != nil {
 		t.Fatalf("Save() failed: %v", err)
 	}
@@ -78,19 +78,6 @@ func TestConfig_Save(t *testing.T) {
 	}
 }
 
-func TestSetDefaults(t *testing.T) {
-	viper.Reset()
-	SetDefaults()
-
-	if viper.GetInt("cache_ttl") != 300 {
-		t.Errorf("Expected default cache_ttl 300, got %d", viper.GetInt("cache_ttl"))
-	}
-
-	if viper.GetString("default_view") != "assigned-to-me" {
-		t.Errorf("Expected default_view 'assigned-to-me', got '%s'", viper.GetString("default_view"))
-	}
-}
-
 func TestGetConfigPath(t *testing.T) {
 	path, err := GetConfigPath()
 	if err != nil {
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigName is the per-project config file looked up in git repositories
+const ProjectConfigName = ".azb.yaml"
+
+// ProjectKeys are the settings a project config file may override
+var ProjectKeys = []string{
+	"organization",
+	"project",
+	"default_area_path",
+	"default_iteration",
+	"default_template",
+}
+
+var (
+	// projectFile is the project config merged by LoadProjectConfig
+	projectFile string
+	// projectValues are the settings it overrode
+	projectValues map[string]string
+)
+
+// FindProjectConfig returns the project config file nearest to dir, searching
+// up to the root of the git repository containing dir. It returns an empty
+// string outside git repositories or when the repository has none.
+func FindProjectConfig(dir string) string {
+	found := ""
+	for {
+		if found == "" {
+			candidate := filepath.Join(dir, ProjectConfigName)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				found = candidate
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return found
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadProjectConfig merges the project config of the git repository containing
+// dir over the settings read so far, so it overrides the home config but not
+// flags or environment variables. It returns the file used, if any.
+func LoadProjectConfig(dir string) (string, error) {
+	path := FindProjectConfig(dir)
+	if path == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(doc))
+	for key, value := range doc {
+		if !slices.Contains(ProjectKeys, key) {
+			return "", fmt.Errorf("%s: unsupported key '%s' (supported: %s)", path, key, strings.Join(ProjectKeys, ", "))
+		}
+		if value != nil {
+			values[key] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	settings := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		settings[key] = value
+	}
+	if err := viper.MergeConfigMap(settings); err != nil {
+		return "", fmt.Errorf("failed to apply %s: %w", path, err)
+	}
+
+	projectFile, projectValues = path, values
+	return path, nil
+}
+
+// ProjectConfigFile returns the project config in use, or an empty string
+func ProjectConfigFile() string {
+	return projectFile
+}
+
+// ProjectOverrides returns the keys the project config in use sets
+func ProjectOverrides() []string {
+	keys := make([]string, 0, len(projectValues))
+	for key := range projectValues {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
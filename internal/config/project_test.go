@@ -0,0 +1,103 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestFindProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	nested := filepath.Join(repo, "src", "app")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	repoConfig := filepath.Join(repo, ProjectConfigName)
+	if err := os.WriteFile(repoConfig, []byte("project: app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Outside the repository, so never used
+	if err := os.WriteFile(filepath.Join(root, ProjectConfigName), []byte("project: other\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := FindProjectConfig(nested); got != repoConfig {
+		t.Errorf("FindProjectConfig(nested) = %q, want %q", got, repoConfig)
+	}
+	if got := FindProjectConfig(root); got != "" {
+		t.Errorf("FindProjectConfig(outside repo) = %q, want none", got)
+	}
+}
+
+func TestLoadProjectConfigKeepsHomeConfig(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ProjectConfigName), []byte("project: app\ndefault_iteration: Sprint 7\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { projectFile, projectValues = "", nil })
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte("organization: contoso\nproject: web\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	viper.Reset()
+	viper.SetConfigFile(configFile)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadProjectConfig(repo); err != nil {
+		t.Fatalf("LoadProjectConfig() error = %v", err)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Organization != "contoso" || cfg.Project != "app" || cfg.DefaultIteration != "Sprint 7" {
+		t.Fatalf("Load() = %s/%s %q, want contoso/app \"Sprint 7\"", cfg.Organization, cfg.Project, cfg.DefaultIteration)
+	}
+
+	cfg.Theme = "light"
+	if err := Save(cfg, "theme"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	viper.Reset()
+	viper.SetConfigFile(configFile)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if got := viper.GetString("project"); got != "web" {
+		t.Errorf("saved project = %q, want the home value web", got)
+	}
+	if got := viper.GetString("default_iteration"); got != "" {
+		t.Errorf("saved default_iteration = %q, want none", got)
+	}
+	if got := viper.GetString("theme"); got != "light" {
+		t.Errorf("saved theme = %q, want light", got)
+	}
+}
+
+func TestLoadProjectConfigRejectsUnknownKeys(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ProjectConfigName), []byte("theme: light\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	if _, err := LoadProjectConfig(repo); err == nil {
+		t.Error("LoadProjectConfig() succeeded, want error for unsupported key")
+	}
+}
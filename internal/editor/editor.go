@@ -0,0 +1,33 @@
+// Package editor opens files in the user's text editor.
+package editor
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// fallback is the editor used when neither $EDITOR nor $VISUAL is set
+const fallback = "vi"
+
+// Name returns the editor command from $EDITOR, or $VISUAL, or vi
+func Name() string {
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+	if editor := os.Getenv("VISUAL"); editor != "" {
+		return editor
+	}
+	return fallback
+}
+
+// Command returns a command opening path in the user's editor. Like git, it
+// runs the editor through the shell, so the editor can carry arguments such
+// as "code -w" and paths with spaces stay one argument.
+func Command(path string) *exec.Cmd {
+	editor := Name()
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", editor+` "`+path+`"`)
+	}
+	return exec.Command("sh", "-c", editor+` "$@"`, editor, path)
+}
@@ -0,0 +1,54 @@
+package editor
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		name   string
+		editor string
+		visual string
+		want   string
+	}{
+		{"editor", "nano", "code -w", "nano"},
+		{"visual", "", "code -w", "code -w"},
+		{"fallback", "", "", "vi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EDITOR", tt.editor)
+			t.Setenv("VISUAL", tt.visual)
+			if got := Name(); got != tt.want {
+				t.Errorf("Name() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the editor runs through sh")
+	}
+
+	// An editor with arguments appends a line to the file it is given
+	dir := t.TempDir()
+	path := filepath.Join(dir, "work item.yaml")
+	t.Setenv("EDITOR", `sh -c 'echo edited >> "$1"' editor`)
+
+	cmd := Command(path)
+	if want := []string{"sh", "-c", os.Getenv("EDITOR") + ` "$@"`, os.Getenv("EDITOR"), path}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("Command() args = %q, want %q", cmd.Args, want)
+	}
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Command() failed: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "edited\n" {
+		t.Errorf("file = %q, %v; want the editor to have written it", data, err)
+	}
+}
@@ -0,0 +1,162 @@
+// Package log is the shared logger for the CLI commands, the TUI and the API client.
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Options controls where and how much is logged
+type Options struct {
+	Verbose bool   // Log debug messages
+	Format  string // "text" (default) or "json"
+	File    string // Log to this file instead of stderr
+}
+
+// Formats are the supported log formats
+var Formats = []string{"text", "json"}
+
+var (
+	mu      sync.Mutex
+	options Options
+	file    *os.File
+	logger  = slog.New(newHandler(os.Stderr, Options{}, false))
+)
+
+// Setup configures the shared logger. Without a file, logs go to stderr.
+func Setup(opts Options) error {
+	if opts.Format == "" {
+		opts.Format = "text"
+	}
+	if opts.Format != "text" && opts.Format != "json" {
+		return fmt.Errorf("invalid log format '%s' (expected one of: %s)", opts.Format, strings.Join(Formats, ", "))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	options = opts
+	if opts.File == "" {
+		closeFile()
+		logger = slog.New(newHandler(os.Stderr, opts, false))
+		return nil
+	}
+	return openFile(opts.File)
+}
+
+// UseFile sends logs to path unless a log file was already configured. The
+// TUI uses this because writing to stderr would draw over the dashboard.
+func UseFile(path string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if options.File != "" {
+		return nil
+	}
+	options.File = path
+	return openFile(path)
+}
+
+// openFile replaces the current log file; mu must be held
+func openFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	closeFile()
+	file = f
+	logger = slog.New(newHandler(f, options, true))
+	return nil
+}
+
+// closeFile closes the current log file, if any; mu must be held
+func closeFile() {
+	if file != nil {
+		file.Close()
+		file = nil
+	}
+}
+
+// Close flushes and closes the log file. Later messages go to stderr.
+func Close() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	closeFile()
+	options.File = ""
+	logger = slog.New(newHandler(os.Stderr, options, false))
+}
+
+// Level returns the lowest level that is logged. Debug messages need --verbose;
+// log files also record info messages, while stderr only shows warnings and errors.
+func Level(opts Options, toFile bool) slog.Level {
+	switch {
+	case opts.Verbose:
+		return slog.LevelDebug
+	case toFile:
+		return slog.LevelInfo
+	default:
+		return slog.LevelWarn
+	}
+}
+
+func newHandler(w io.Writer, opts Options, toFile bool) slog.Handler {
+	handlerOpts := &slog.HandlerOptions{Level: Level(opts, toFile)}
+	if opts.Format == "json" {
+		return slog.NewJSONHandler(w, handlerOpts)
+	}
+	return slog.NewTextHandler(w, handlerOpts)
+}
+
+func current() *slog.Logger {
+	mu.Lock()
+	defer mu.Unlock()
+	return logger
+}
+
+// Enabled reports whether messages at level are logged
+func Enabled(level slog.Level) bool {
+	return current().Enabled(context.Background(), level)
+}
+
+// Debug logs a message with key-value attributes at debug level
+func Debug(msg string, args ...interface{}) { current().Debug(msg, args...) }
+
+// Info logs a message with key-value attributes at info level
+func Info(msg string, args ...interface{}) { current().Info(msg, args...) }
+
+// Warn logs a message with key-value attributes at warning level
+func Warn(msg string, args ...interface{}) { current().Warn(msg, args...) }
+
+// Error logs a message with key-value attributes at error level
+func Error(msg string, args ...interface{}) { current().Error(msg, args...) }
+
+// Debugf logs a formatted message at debug level
+func Debugf(format string, args ...interface{}) { logf(slog.LevelDebug, format, args...) }
+
+// Infof logs a formatted message at info level
+func Infof(format string, args ...interface{}) { logf(slog.LevelInfo, format, args...) }
+
+// Warnf logs a formatted message at warning level
+func Warnf(format string, args ...interface{}) { logf(slog.LevelWarn, format, args...) }
+
+// Errorf logs a formatted message at error level
+func Errorf(format string, args ...interface{}) { logf(slog.LevelError, format, args...) }
+
+func logf(level slog.Level, format string, args ...interface{}) {
+	l := current()
+	if !l.Enabled(context.Background(), level) {
+		return
+	}
+	l.Log(context.Background(), level, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,68 @@
+package log
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   Options
+		toFile bool
+		want   slog.Level
+	}{
+		{"stderr", Options{}, false, slog.LevelWarn},
+		{"file", Options{}, true, slog.LevelInfo},
+		{"verbose stderr", Options{Verbose: true}, false, slog.LevelDebug},
+		{"verbose file", Options{Verbose: true}, true, slog.LevelDebug},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Level(tt.opts, tt.toFile); got != tt.want {
+				t.Errorf("Level() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetupJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "azb.log")
+	if err := Setup(Options{Format: "json", File: path}); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	defer Close()
+
+	Debugf("hidden %d", 1)
+	Infof("fetched %d items", 3)
+	Warn("slow request", "attempt", 2)
+	Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d:\n%s", len(lines), data)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("log line is not JSON: %v", err)
+	}
+	if entry["msg"] != "slow request" || entry["level"] != "WARN" || entry["attempt"] != float64(2) {
+		t.Errorf("unexpected entry: %v", entry)
+	}
+}
+
+func TestSetupInvalidFormat(t *testing.T) {
+	if err := Setup(Options{Format: "xml"}); err == nil {
+		t.Error("expected an error for an invalid format")
+	}
+}
@@ -0,0 +1,176 @@
+// Package mentions turns @name mentions in comments into identity mentions
+// that notify the people mentioned.
+package mentions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/config"
+)
+
+// mentionPattern matches @alias, or @alias@domain for email addresses, at the
+// start of the text or after a space, bracket, tag or punctuation
+var mentionPattern = regexp.MustCompile(`(^|[\s(\[>,;:])@([A-Za-z0-9_][A-Za-z0-9_.\-]*(?:@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)+)?)`)
+
+// Cache remembers which user each alias resolved to, in ~/.azure-boards-cli/mentions.json
+type Cache struct {
+	Aliases map[string]api.User `json:"aliases"`
+
+	path string
+}
+
+// LoadCache reads the alias cache; a missing file is an empty cache
+func LoadCache() (*Cache, error) {
+	configDir, err := config.EnsureConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return LoadCacheFile(filepath.Join(configDir, "mentions.json"))
+}
+
+// LoadCacheFile reads the alias cache from path
+func LoadCacheFile(path string) (*Cache, error) {
+	cache := &Cache{Aliases: map[string]api.User{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("failed to read mention cache: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil, fmt.Errorf("failed to parse mention cache: %w", err)
+	}
+	if cache.Aliases == nil {
+		cache.Aliases = map[string]api.User{}
+	}
+	return cache, nil
+}
+
+// Save writes the alias cache back to disk
+func (c *Cache) Save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize mention cache: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write mention cache: %w", err)
+	}
+	return nil
+}
+
+// Resolver replaces @alias mentions with identity mentions
+type Resolver struct {
+	search func(query string) ([]api.User, error)
+	cache  *Cache
+	dirty  bool
+}
+
+// NewResolver resolves aliases with the cache first and then the client's user search
+func NewResolver(client api.APIClient, cache *Cache) *Resolver {
+	return &Resolver{search: client.SearchUsers, cache: cache}
+}
+
+// Expand replaces the @alias mentions in comment HTML that resolve to exactly
+// one user. Mentions that do not resolve are left as text and described in
+// the returned warnings; the comment can still be posted.
+func (r *Resolver) Expand(text string) (string, []string) {
+	var warnings []string
+	resolved := map[string]*api.User{}
+
+	result := mentionPattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := mentionPattern.FindStringSubmatch(match)
+		prefix, alias := parts[1], parts[2]
+
+		// Sentence punctuation after a name is not part of it
+		trimmed := strings.TrimRight(alias, ".-")
+		rest := alias[len(trimmed):]
+		alias = trimmed
+
+		user, seen := resolved[strings.ToLower(alias)]
+		if !seen {
+			var warning string
+			user, warning = r.resolve(alias)
+			if warning != "" {
+				warnings = append(warnings, warning)
+			}
+			resolved[strings.ToLower(alias)] = user
+		}
+		if user == nil {
+			return match
+		}
+		return prefix + user.MentionHTML() + rest
+	})
+
+	return result, warnings
+}
+
+// Save writes newly resolved aliases to the cache file
+func (r *Resolver) Save() error {
+	if !r.dirty || r.cache == nil {
+		return nil
+	}
+	r.dirty = false
+	return r.cache.Save()
+}
+
+// resolve finds the user an alias stands for, or explains why it cannot
+func (r *Resolver) resolve(alias string) (*api.User, string) {
+	key := strings.ToLower(alias)
+	if r.cache != nil {
+		if user, ok := r.cache.Aliases[key]; ok {
+			return &user, ""
+		}
+	}
+
+	users, err := r.search(alias)
+	if err != nil {
+		return nil, fmt.Sprintf("@%s was not resolved: %v", alias, err)
+	}
+
+	user, ok := pickUser(alias, users)
+	if !ok {
+		if len(users) == 0 {
+			return nil, fmt.Sprintf("@%s does not match any user and was left as text", alias)
+		}
+		names := make([]string, len(users))
+		for i, u := range users {
+			names[i] = u.AssignableName()
+		}
+		return nil, fmt.Sprintf("@%s matches several users (%s) and was left as text; mention one by email", alias, strings.Join(names, ", "))
+	}
+
+	if r.cache != nil {
+		r.cache.Aliases[key] = user
+		r.dirty = true
+	}
+	return &user, ""
+}
+
+// pickUser chooses the user an alias stands for: the only result, or the one
+// whose email, email name or display name equals the alias
+func pickUser(alias string, users []api.User) (api.User, bool) {
+	if len(users) == 1 {
+		return users[0], true
+	}
+
+	var matches []api.User
+	for _, user := range users {
+		local, _, _ := strings.Cut(user.UniqueName, "@")
+		if strings.EqualFold(user.UniqueName, alias) || strings.EqualFold(local, alias) || strings.EqualFold(user.DisplayName, alias) {
+			matches = append(matches, user)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], true
+	}
+	return api.User{}, false
+}
@@ -0,0 +1,93 @@
+package mentions
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+var (
+	alice    = api.User{ID: "a1", DisplayName: "Alice Smith", UniqueName: "alice@contoso.com"}
+	alicia   = api.User{ID: "a2", DisplayName: "Alicia Jones", UniqueName: "alicia@contoso.com"}
+	bob      = api.User{ID: "b1", DisplayName: "Bob <Ops>", UniqueName: "bob@contoso.com"}
+	searches = map[string][]api.User{
+		"alice":           {alice, alicia},
+		"ali":             {alice, alicia},
+		"bob":             {bob},
+		"bob@contoso.com": {bob},
+	}
+)
+
+func fakeSearch(calls *int) func(string) ([]api.User, error) {
+	return func(query string) ([]api.User, error) {
+		*calls++
+		if query == "broken" {
+			return nil, errors.New("forbidden")
+		}
+		return searches[strings.ToLower(query)], nil
+	}
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		want         string
+		wantWarnings int
+	}{
+		{"single match", "Thanks @bob!", `Thanks <a href="#" data-vss-mention="version:2.0,b1">@Bob &lt;Ops&gt;</a>!`, 0},
+		{"exact email name among several", "@alice please review.", `<a href="#" data-vss-mention="version:2.0,a1">@Alice Smith</a> please review.`, 0},
+		{"trailing period", "ask @bob.", `ask <a href="#" data-vss-mention="version:2.0,b1">@Bob &lt;Ops&gt;</a>.`, 0},
+		{"email", "cc @bob@contoso.com", `cc <a href="#" data-vss-mention="version:2.0,b1">@Bob &lt;Ops&gt;</a>`, 0},
+		{"after a line break", "done<br>@bob", `done<br><a href="#" data-vss-mention="version:2.0,b1">@Bob &lt;Ops&gt;</a>`, 0},
+		{"ambiguous", "@ali look", "@ali look", 1},
+		{"unknown", "@nobody look", "@nobody look", 1},
+		{"search error", "@broken look", "@broken look", 1},
+		{"email address is not a mention", "mail bob@contoso.com", "mail bob@contoso.com", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			r := &Resolver{search: fakeSearch(&calls)}
+			got, warnings := r.Expand(tt.text)
+			if got != tt.want {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("Expand() warnings = %v, want %d", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestResolverCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mentions.json")
+	cache, err := LoadCacheFile(path)
+	if err != nil {
+		t.Fatalf("LoadCacheFile() error = %v", err)
+	}
+
+	calls := 0
+	r := &Resolver{search: fakeSearch(&calls), cache: cache}
+	r.Expand("@bob and @Bob again")
+	if calls != 1 {
+		t.Errorf("searched %d times, want 1", calls)
+	}
+	if err := r.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	cache, err = LoadCacheFile(path)
+	if err != nil {
+		t.Fatalf("LoadCacheFile() error = %v", err)
+	}
+	calls = 0
+	r = &Resolver{search: fakeSearch(&calls), cache: cache}
+	if got, _ := r.Expand("@bob"); !strings.Contains(got, "b1") || calls != 0 {
+		t.Errorf("Expand() = %q with %d searches, want a cached mention", got, calls)
+	}
+}
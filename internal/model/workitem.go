@@ -0,0 +1,256 @@
+// Package model holds a work item representation for rendering that does not
+// depend on the Azure DevOps SDK types.
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// Reference names of the fields WorkItem has typed fields for
+const (
+	FieldID            = "System.Id"
+	FieldRev           = "System.Rev"
+	FieldType          = "System.WorkItemType"
+	FieldTitle         = "System.Title"
+	FieldState         = "System.State"
+	FieldAssignedTo    = "System.AssignedTo"
+	FieldAreaPath      = "System.AreaPath"
+	FieldIterationPath = "System.IterationPath"
+	FieldTags          = "System.Tags"
+	FieldPriority      = "Microsoft.VSTS.Common.Priority"
+	FieldCreatedDate   = "System.CreatedDate"
+	FieldChangedDate   = "System.ChangedDate"
+)
+
+// typedFields are the fields kept out of WorkItem.Custom
+var typedFields = map[string]bool{
+	FieldID: true, FieldRev: true, FieldType: true, FieldTitle: true, FieldState: true,
+	FieldAssignedTo: true, FieldAreaPath: true, FieldIterationPath: true, FieldTags: true,
+	FieldPriority: true, FieldCreatedDate: true, FieldChangedDate: true,
+}
+
+// Identity is a user in an identity field such as Assigned To
+type Identity struct {
+	DisplayName string `json:"displayName,omitempty"`
+	UniqueName  string `json:"uniqueName,omitempty"`
+}
+
+// String returns the display name, or the unique name when there is none
+func (i Identity) String() string {
+	if i.DisplayName != "" {
+		return i.DisplayName
+	}
+	return i.UniqueName
+}
+
+// IsZero reports whether the identity is empty, e.g. for unassigned work items
+func (i Identity) IsZero() bool {
+	return i.DisplayName == "" && i.UniqueName == ""
+}
+
+// WorkItem is a work item with typed common fields. Fields without a typed
+// counterpart are kept in Custom by reference name.
+type WorkItem struct {
+	ID            int                    `json:"id"`
+	Rev           int                    `json:"rev,omitempty"`
+	Type          string                 `json:"type"`
+	Title         string                 `json:"title"`
+	State         string                 `json:"state"`
+	AssignedTo    Identity               `json:"assignedTo"`
+	AreaPath      string                 `json:"areaPath,omitempty"`
+	IterationPath string                 `json:"iterationPath,omitempty"`
+	Tags          []string               `json:"tags"`
+	Priority      int                    `json:"priority,omitempty"`
+	CreatedDate   time.Time              `json:"createdDate"`
+	ChangedDate   time.Time              `json:"changedDate"`
+	URL           string                 `json:"url,omitempty"`
+	Custom        map[string]interface{} `json:"custom,omitempty"`
+}
+
+// FromAPI converts an SDK work item
+func FromAPI(wi *workitemtracking.WorkItem) WorkItem {
+	var fields map[string]interface{}
+	if wi.Fields != nil {
+		fields = *wi.Fields
+	}
+	item := FromFields(fields)
+	if wi.Id != nil {
+		item.ID = *wi.Id
+	}
+	if wi.Rev != nil {
+		item.Rev = *wi.Rev
+	}
+	if wi.Url != nil {
+		item.URL = *wi.Url
+	}
+	return item
+}
+
+// FromAPIList converts a list of SDK work items
+func FromAPIList(items []workitemtracking.WorkItem) []WorkItem {
+	result := make([]WorkItem, len(items))
+	for i := range items {
+		result[i] = FromAPI(&items[i])
+	}
+	return result
+}
+
+// FromFields converts a fields map as returned by the API, keyed by reference name
+func FromFields(fields map[string]interface{}) WorkItem {
+	item := WorkItem{
+		ID:            intValue(fields[FieldID]),
+		Rev:           intValue(fields[FieldRev]),
+		Type:          String(fields[FieldType]),
+		Title:         String(fields[FieldTitle]),
+		State:         String(fields[FieldState]),
+		AssignedTo:    IdentityOf(fields[FieldAssignedTo]),
+		AreaPath:      String(fields[FieldAreaPath]),
+		IterationPath: String(fields[FieldIterationPath]),
+		Tags:          ParseTags(String(fields[FieldTags])),
+		Priority:      intValue(fields[FieldPriority]),
+		CreatedDate:   timeValue(fields[FieldCreatedDate]),
+		ChangedDate:   timeValue(fields[FieldChangedDate]),
+	}
+	for name, value := range fields {
+		if typedFields[name] {
+			continue
+		}
+		if item.Custom == nil {
+			item.Custom = make(map[string]interface{})
+		}
+		item.Custom[name] = value
+	}
+	return item
+}
+
+// Field returns a field by reference name as text, from the typed fields or Custom
+func (w WorkItem) Field(name string) string {
+	switch name {
+	case FieldID:
+		return strconv.Itoa(w.ID)
+	case FieldRev:
+		return strconv.Itoa(w.Rev)
+	case FieldType:
+		return w.Type
+	case FieldTitle:
+		return w.Title
+	case FieldState:
+		return w.State
+	case FieldAssignedTo:
+		return w.AssignedTo.String()
+	case FieldAreaPath:
+		return w.AreaPath
+	case FieldIterationPath:
+		return w.IterationPath
+	case FieldTags:
+		return FormatTags(w.Tags)
+	case FieldPriority:
+		if w.Priority == 0 {
+			return ""
+		}
+		return strconv.Itoa(w.Priority)
+	case FieldCreatedDate:
+		return formatTime(w.CreatedDate)
+	case FieldChangedDate:
+		return formatTime(w.ChangedDate)
+	}
+	return String(w.Custom[name])
+}
+
+// ParseTags splits the semicolon-separated tags of a work item
+func ParseTags(tags string) []string {
+	result := []string{}
+	for _, tag := range strings.Split(tags, ";") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// FormatTags joins tags the way the API stores them
+func FormatTags(tags []string) string {
+	return strings.Join(tags, "; ")
+}
+
+// IdentityOf reads an identity field value, which is an object in API
+// responses and sometimes a plain name in older payloads
+func IdentityOf(value interface{}) Identity {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		identity := Identity{}
+		identity.DisplayName, _ = v["displayName"].(string)
+		identity.UniqueName, _ = v["uniqueName"].(string)
+		return identity
+	case string:
+		return Identity{DisplayName: v}
+	}
+	return Identity{}
+}
+
+// String returns a field value as text, identities by display name
+func String(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}:
+		if identity := IdentityOf(v); !identity.IsZero() {
+			return identity.String()
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// FieldString returns a field of an API work item's fields as text, like
+// String, or "" when the fields or the field are missing
+func FieldString(fields *map[string]interface{}, name string) string {
+	if fields == nil {
+		return ""
+	}
+	return String((*fields)[name])
+}
+
+// intValue reads a numeric field, which JSON decodes as float64 or json.Number
+func intValue(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case json.Number:
+		n, _ := v.Float64()
+		return int(n)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return 0
+}
+
+// timeValue reads a date field, which the API returns as an RFC 3339 string
+func timeValue(value interface{}) time.Time {
+	text, ok := value.(string)
+	if !ok {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
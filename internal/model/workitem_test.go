@@ -0,0 +1,117 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestFromAPI(t *testing.T) {
+	id, rev, url := 42, 3, "https://dev.azure.com/contoso/_apis/wit/workItems/42"
+	fields := map[string]interface{}{
+		"System.WorkItemType":                   "Bug",
+		"System.Title":                          "Fix login",
+		"System.State":                          "Active",
+		"System.AssignedTo":                     map[string]interface{}{"displayName": "Ada Lovelace", "uniqueName": "ada@contoso.com"},
+		"System.Tags":                           "auth; urgent ;",
+		"Microsoft.VSTS.Common.Priority":        2.0,
+		"System.ChangedDate":                    "2026-10-14T09:12:00.5Z",
+		"Microsoft.VSTS.Scheduling.Effort":      5.0,
+		"Custom.Team":                           "Portal",
+		"System.AreaPath":                       "Fabrikam\\Portal",
+		"System.IterationPath":                  "Fabrikam\\Sprint 1",
+		"Microsoft.VSTS.Common.BacklogPriority": 1000.0,
+	}
+
+	got := FromAPI(&workitemtracking.WorkItem{Id: &id, Rev: &rev, Url: &url, Fields: &fields})
+	want := WorkItem{
+		ID:            42,
+		Rev:           3,
+		Type:          "Bug",
+		Title:         "Fix login",
+		State:         "Active",
+		AssignedTo:    Identity{DisplayName: "Ada Lovelace", UniqueName: "ada@contoso.com"},
+		AreaPath:      "Fabrikam\\Portal",
+		IterationPath: "Fabrikam\\Sprint 1",
+		Tags:          []string{"auth", "urgent"},
+		Priority:      2,
+		ChangedDate:   time.Date(2026, 10, 14, 9, 12, 0, 500000000, time.UTC),
+		URL:           url,
+		Custom: map[string]interface{}{
+			"Microsoft.VSTS.Scheduling.Effort":      5.0,
+			"Custom.Team":                           "Portal",
+			"Microsoft.VSTS.Common.BacklogPriority": 1000.0,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromAPI() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestWorkItemField(t *testing.T) {
+	item := FromFields(map[string]interface{}{
+		"System.Id":         json.Number("1234567"),
+		"System.Title":      "Fix login",
+		"System.AssignedTo": map[string]interface{}{"uniqueName": "ada@contoso.com"},
+		"System.Tags":       "auth;urgent",
+		"System.CreatedBy":  map[string]interface{}{"displayName": "Grace Hopper"},
+		"Custom.Points":     3.5,
+	})
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"System.Id", "1234567"},
+		{"System.Title", "Fix login"},
+		{"System.AssignedTo", "ada@contoso.com"},
+		{"System.Tags", "auth; urgent"},
+		{"System.CreatedBy", "Grace Hopper"},
+		{"Custom.Points", "3.5"},
+		{"Microsoft.VSTS.Common.Priority", ""},
+		{"System.ChangedDate", ""},
+		{"Custom.Missing", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if got := item.Field(tt.field); got != tt.want {
+				t.Errorf("Field(%q) = %q, want %q", tt.field, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldString(t *testing.T) {
+	fields := &map[string]interface{}{
+		"System.Title":                   "Fix login",
+		"System.AssignedTo":              map[string]interface{}{"displayName": "Ada Lovelace", "uniqueName": "ada@contoso.com"},
+		"System.CreatedBy":               map[string]interface{}{"uniqueName": "grace@contoso.com"},
+		"Microsoft.VSTS.Common.Priority": 1,
+	}
+
+	tests := []struct {
+		name   string
+		fields *map[string]interface{}
+		field  string
+		want   string
+	}{
+		{"string", fields, "System.Title", "Fix login"},
+		{"identity", fields, "System.AssignedTo", "Ada Lovelace"},
+		{"identity without display name", fields, "System.CreatedBy", "grace@contoso.com"},
+		{"number", fields, "Microsoft.VSTS.Common.Priority", "1"},
+		{"missing field", fields, "System.Description", ""},
+		{"nil fields", nil, "System.Title", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FieldString(tt.fields, tt.field); got != tt.want {
+				t.Errorf("FieldString(%q) = %q, want %q", tt.field, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// Package notify delivers work item change events to configured webhooks, chat channels and commands.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Event kinds
+const (
+	KindUpdated   = "updated"
+	KindCommented = "commented"
+)
+
+// DefaultTemplate formats events for sinks without a template
+const DefaultTemplate = `#{{.ID}} {{.Title}}: {{.Summary}}`
+
+const (
+	httpTimeout    = 10 * time.Second
+	commandTimeout = 30 * time.Second
+)
+
+// Change is one field changed by an event
+type Change struct {
+	Field    string `json:"field"`
+	OldValue string `json:"oldValue,omitempty"`
+	NewValue string `json:"newValue,omitempty"`
+}
+
+// Event is a change to a work item
+type Event struct {
+	Kind    string    `json:"kind"`
+	ID      int       `json:"id"`
+	Title   string    `json:"title"`
+	URL     string    `json:"url"`
+	Rev     int       `json:"rev,omitempty"`
+	Author  string    `json:"author"`
+	Time    time.Time `json:"time"`
+	Changes []Change  `json:"changes,omitempty"`
+	Comment string    `json:"comment,omitempty"`
+}
+
+// Summary describes the event in one line, e.g. "Ada changed State: New -> Active"
+func (e Event) Summary() string {
+	if e.Kind == KindCommented {
+		return fmt.Sprintf("%s commented: %s", e.Author, e.Comment)
+	}
+	parts := make([]string, len(e.Changes))
+	for i, c := range e.Changes {
+		parts[i] = fmt.Sprintf("%s: %s -> %s", c.Field, orNone(c.OldValue), orNone(c.NewValue))
+	}
+	return fmt.Sprintf("%s changed %s", e.Author, strings.Join(parts, ", "))
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// SinkConfig is one entry of the notifications config list
+type SinkConfig struct {
+	Name     string   `mapstructure:"name"`
+	Type     string   `mapstructure:"type"` // webhook, slack, teams or command
+	URL      string   `mapstructure:"url"`
+	Command  string   `mapstructure:"command"`
+	Template string   `mapstructure:"template"`
+	Events   []string `mapstructure:"events"` // kinds to deliver; empty for all
+}
+
+// webhookTransport is http.DefaultTransport as Go set it up. Webhooks use it so
+// they bypass the retries, tracing, fixtures and CA bundle azb adds to the
+// default transport for Azure DevOps requests.
+var webhookTransport = http.DefaultTransport
+
+// Sink delivers events to one destination
+type Sink struct {
+	config   SinkConfig
+	template *template.Template
+	client   *http.Client
+}
+
+// NewSink validates a sink configuration and parses its message template
+func NewSink(cfg SinkConfig) (*Sink, error) {
+	cfg.Type = strings.ToLower(strings.TrimSpace(cfg.Type))
+	if cfg.Name == "" {
+		cfg.Name = cfg.Type
+	}
+
+	switch cfg.Type {
+	case "webhook", "slack", "teams":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("notification sink %s: url is required", cfg.Name)
+		}
+	case "command":
+		if cfg.Command == "" {
+			return nil, fmt.Errorf("notification sink %s: command is required", cfg.Name)
+		}
+	default:
+		return nil, fmt.Errorf("notification sink %s: unknown type %q (use webhook, slack, teams or command)", cfg.Name, cfg.Type)
+	}
+
+	text := cfg.Template
+	if text == "" {
+		text = DefaultTemplate
+	}
+	tmpl, err := template.New(cfg.Name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("notification sink %s: invalid template: %w", cfg.Name, err)
+	}
+
+	return &Sink{config: cfg, template: tmpl, client: &http.Client{Timeout: httpTimeout, Transport: webhookTransport}}, nil
+}
+
+// Name returns the configured name of the sink
+func (s *Sink) Name() string {
+	return s.config.Name
+}
+
+// Wants reports whether the sink is configured to receive events of a kind
+func (s *Sink) Wants(kind string) bool {
+	if len(s.config.Events) == 0 {
+		return true
+	}
+	for _, k := range s.config.Events {
+		if strings.EqualFold(k, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// Format renders the message of an event with the sink's template
+func (s *Sink) Format(event Event) (string, error) {
+	var b bytes.Buffer
+	if err := s.template.Execute(&b, event); err != nil {
+		return "", fmt.Errorf("failed to format message for %s: %w", s.config.Name, err)
+	}
+	return b.String(), nil
+}
+
+// Deliver sends an event to the sink
+func (s *Sink) Deliver(ctx context.Context, event Event) error {
+	message, err := s.Format(event)
+	if err != nil {
+		return err
+	}
+
+	switch s.config.Type {
+	case "slack", "teams":
+		// Both incoming webhook flavors accept a plain text payload
+		return s.post(ctx, map[string]string{"text": message})
+	case "webhook":
+		return s.post(ctx, struct {
+			Event
+			Message string `json:"message"`
+		}{event, message})
+	default:
+		return s.run(ctx, event, message)
+	}
+}
+
+// post sends a JSON payload to the sink's URL
+func (s *Sink) post(ctx context.Context, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notification sink %s: %w", s.config.Name, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to notify %s: %w", s.config.Name, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to notify %s: %s", s.config.Name, resp.Status)
+	}
+	return nil
+}
+
+// run executes the sink's command with the event as JSON on stdin and the
+// message and main fields in AZB_* environment variables
+func (s *Sink) run(ctx context.Context, event Event, message string) error {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", s.config.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", s.config.Command)
+	}
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"AZB_MESSAGE="+message,
+		"AZB_EVENT="+event.Kind,
+		"AZB_WORK_ITEM_ID="+strconv.Itoa(event.ID),
+		"AZB_WORK_ITEM_URL="+event.URL,
+	)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notification command %s failed: %w: %s", s.config.Name, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Load returns the sinks configured under the notifications key
+func Load() ([]*Sink, error) {
+	var configs []SinkConfig
+	if err := viper.UnmarshalKey("notifications", &configs); err != nil {
+		return nil, fmt.Errorf("failed to read notifications config: %w", err)
+	}
+
+	sinks := make([]*Sink, 0, len(configs))
+	for _, cfg := range configs {
+		sink, err := NewSink(cfg)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+// Dispatch delivers an event to every sink that wants it and returns the
+// errors of the sinks that failed
+func Dispatch(ctx context.Context, sinks []*Sink, event Event) []error {
+	var errs []error
+	for _, sink := range sinks {
+		if !sink.Wants(event.Kind) {
+			continue
+		}
+		if err := sink.Deliver(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,152 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+var testEvent = Event{
+	Kind:    KindUpdated,
+	ID:      42,
+	Title:   "Login fails",
+	URL:     "https://dev.azure.com/org/proj/_workitems/edit/42",
+	Author:  "Ada",
+	Changes: []Change{{Field: "System.State", OldValue: "New", NewValue: "Active"}, {Field: "System.Tags", NewValue: "ui"}},
+}
+
+func TestNewSinkValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     SinkConfig
+		wantErr string
+	}{
+		{"slack without url", SinkConfig{Type: "slack"}, "url is required"},
+		{"command without command", SinkConfig{Type: "command"}, "command is required"},
+		{"unknown type", SinkConfig{Type: "email"}, "unknown type"},
+		{"bad template", SinkConfig{Type: "webhook", URL: "http://x", Template: "{{.ID"}, "invalid template"},
+		{"valid", SinkConfig{Type: "Teams", URL: "http://x"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSink(tt.cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("NewSink() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewSink() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	sink, err := NewSink(SinkConfig{Type: "slack", URL: "http://x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := sink.Format(testEvent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "#42 Login fails: Ada changed System.State: New -> Active, System.Tags: (none) -> ui"
+	if got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+
+	sink, _ = NewSink(SinkConfig{Type: "slack", URL: "http://x", Template: "{{.Author}} on <{{.URL}}|#{{.ID}}>"})
+	if got, _ := sink.Format(testEvent); got != "Ada on <https://dev.azure.com/org/proj/_workitems/edit/42|#42>" {
+		t.Errorf("Format() with template = %q", got)
+	}
+}
+
+func TestDeliverHTTP(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("invalid JSON payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	slack, _ := NewSink(SinkConfig{Type: "slack", URL: server.URL, Template: "{{.Title}}"})
+	if err := slack.Deliver(context.Background(), testEvent); err != nil {
+		t.Fatalf("Deliver() to slack error = %v", err)
+	}
+	if received["text"] != "Login fails" {
+		t.Errorf("slack payload = %v, want text", received)
+	}
+
+	webhook, _ := NewSink(SinkConfig{Type: "webhook", URL: server.URL, Template: "{{.Title}}"})
+	if err := webhook.Deliver(context.Background(), testEvent); err != nil {
+		t.Fatalf("Deliver() to webhook error = %v", err)
+	}
+	if received["message"] != "Login fails" || received["id"] != 42.0 || received["kind"] != KindUpdated {
+		t.Errorf("webhook payload = %v, want event and message", received)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer failing.Close()
+	teams, _ := NewSink(SinkConfig{Name: "teams-channel", Type: "teams", URL: failing.URL})
+	if err := teams.Deliver(context.Background(), testEvent); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Deliver() to failing sink error = %v, want 403", err)
+	}
+}
+
+func TestDeliverCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	out := filepath.Join(t.TempDir(), "event")
+	sink, _ := NewSink(SinkConfig{Type: "command", Command: `printf '%s\n' "$AZB_WORK_ITEM_ID $AZB_MESSAGE" > ` + out + ` && cat >> ` + out, Template: "{{.Title}}"})
+	if err := sink.Deliver(context.Background(), testEvent); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, rest, _ := strings.Cut(string(data), "\n")
+	if first != "42 Login fails" {
+		t.Errorf("command environment = %q, want %q", first, "42 Login fails")
+	}
+	var event Event
+	if err := json.Unmarshal([]byte(rest), &event); err != nil || event.ID != 42 {
+		t.Errorf("command stdin = %q, want event JSON", rest)
+	}
+}
+
+func TestDispatchFiltersEvents(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { calls++ }))
+	defer server.Close()
+
+	all, _ := NewSink(SinkConfig{Type: "webhook", URL: server.URL})
+	comments, _ := NewSink(SinkConfig{Type: "webhook", URL: server.URL, Events: []string{"Commented"}})
+
+	if errs := Dispatch(context.Background(), []*Sink{all, comments}, testEvent); len(errs) > 0 {
+		t.Fatalf("Dispatch() errors = %v", errs)
+	}
+	if calls != 1 {
+		t.Errorf("updated event delivered %d times, want 1", calls)
+	}
+
+	comment := testEvent
+	comment.Kind = KindCommented
+	Dispatch(context.Background(), []*Sink{all, comments}, comment)
+	if calls != 3 {
+		t.Errorf("after comment event, %d deliveries, want 3", calls)
+	}
+}
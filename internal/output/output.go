@@ -0,0 +1,144 @@
+// Package output renders command results in the formats of the global --output flag.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats are the formats every read command supports
+var Formats = []string{"table", "json", "yaml", "csv", "tsv", "ids"}
+
+// Table is the tabular form of a result. Its first column identifies each
+// row, so it is what the ids format prints.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// Valid reports whether format is one of Formats
+func Valid(format string) bool {
+	return slices.Contains(Formats, format)
+}
+
+// Write renders a result: value as JSON or YAML, and table as an aligned
+// table, CSV, TSV or IDs
+func Write(w io.Writer, format string, value interface{}, table Table) error {
+	switch format {
+	case "json":
+		return WriteJSON(w, value)
+	case "yaml":
+		return WriteYAML(w, value)
+	case "csv":
+		return WriteCSV(w, table)
+	case "tsv":
+		return WriteTSV(w, table)
+	case "ids":
+		return WriteIDs(w, table)
+	case "table":
+		return WriteTable(w, table)
+	default:
+		return fmt.Errorf("unsupported output format '%s' (expected one of: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// WriteJSON writes value as indented JSON
+func WriteJSON(w io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// WriteYAML writes value as YAML with the same keys as its JSON
+func WriteYAML(w io.Writer, value interface{}) error {
+	// Round-trip through JSON so json tags and custom marshalers apply
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	var plain interface{}
+	if err := json.Unmarshal(data, &plain); err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(plain); err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	return encoder.Close()
+}
+
+// WriteCSV writes a table as CSV with a header row
+func WriteCSV(w io.Writer, table Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Headers); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(table.Rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// WriteTSV writes a table as tab-separated values with a header row. Tabs and
+// line breaks in cells become spaces so every row stays on one line.
+func WriteTSV(w io.Writer, table Table) error {
+	clean := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+	for _, row := range append([][]string{table.Headers}, table.Rows...) {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = clean.Replace(cell)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteIDs writes the first column of a table, one value per line
+func WriteIDs(w io.Writer, table Table) error {
+	for _, row := range table.Rows {
+		if len(row) > 0 && row[0] != "" {
+			if _, err := fmt.Fprintln(w, row[0]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteTable writes a table with aligned columns
+func WriteTable(w io.Writer, table Table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	clean := strings.NewReplacer("\t", " ", "\n", " ")
+	for _, row := range append([][]string{table.Headers}, table.Rows...) {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = clean.Replace(cell)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// FitCell truncates a cell to width runes, ending in "...", and pads it with
+// spaces, for columns laid out by hand
+func FitCell(cell string, width int) string {
+	runes := []rune(cell)
+	if len(runes) > width {
+		if width > 3 {
+			return string(runes[:width-3]) + "..."
+		}
+		return string(runes[:width])
+	}
+	return cell + strings.Repeat(" ", width-len(runes))
+}
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	value := []map[string]interface{}{{"id": 1, "title": "Fix login"}, {"id": 2, "title": "Tabs\tand\nlines"}}
+	table := Table{
+		Headers: []string{"ID", "Title"},
+		Rows:    [][]string{{"1", "Fix login"}, {"2", "Tabs\tand\nlines"}},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"json", "[\n  {\n    \"id\": 1,\n    \"title\": \"Fix login\"\n  },\n  {\n    \"id\": 2,\n    \"title\": \"Tabs\\tand\\nlines\"\n  }\n]\n"},
+		{"yaml", "- id: 1\n  title: Fix login\n- id: 2\n  title: |-\n    Tabs\tand\n    lines\n"},
+		{"csv", "ID,Title\n1,Fix login\n2,\"Tabs\tand\nlines\"\n"},
+		{"tsv", "ID\tTitle\n1\tFix login\n2\tTabs and lines\n"},
+		{"ids", "1\n2\n"},
+		{"table", "ID  Title\n1   Fix login\n2   Tabs and lines\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.format, value, table); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Write(%s) =\n%q\nwant\n%q", tt.format, got, tt.want)
+			}
+		})
+	}
+
+	if err := Write(&bytes.Buffer{}, "xml", value, table); err == nil {
+		t.Error("Write(xml) succeeded, want an error")
+	}
+}
+
+func TestFitCell(t *testing.T) {
+	tests := []struct {
+		cell  string
+		width int
+		want  string
+	}{
+		{"Bug", 6, "Bug   "},
+		{"Active", 6, "Active"},
+		{"Fix the login page", 10, "Fix the..."},
+		{"héllo wörld", 8, "héllo..."},
+		{"Größe", 7, "Größe  "},
+		{"abcdef", 2, "ab"},
+	}
+
+	for _, tt := range tests {
+		if got := FitCell(tt.cell, tt.width); got != tt.want {
+			t.Errorf("FitCell(%q, %d) = %q, want %q", tt.cell, tt.width, got, tt.want)
+		}
+	}
+}
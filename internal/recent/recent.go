@@ -0,0 +1,168 @@
+// Package recent stores the work items last viewed or edited with azb.
+package recent
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/log"
+	"github.com/SOMUCHDOG/azb/internal/model"
+)
+
+// MaxItems is how many work items the store keeps
+const MaxItems = 50
+
+// Actions recorded for a work item
+const (
+	Viewed  = "viewed"
+	Edited  = "edited"
+	Created = "created"
+)
+
+// Item is a work item that was viewed or edited
+type Item struct {
+	ID     int       `json:"id"`
+	Title  string    `json:"title,omitempty"`
+	Type   string    `json:"type,omitempty"`
+	Action string    `json:"action"`
+	Org    string    `json:"org,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// Store holds the items saved in ~/.azure-boards-cli/recent.json, most recent first
+type Store struct {
+	Items []Item `json:"items"`
+
+	path string
+}
+
+// Load reads the saved items; a missing file is an empty store
+func Load() (*Store, error) {
+	configDir, err := config.EnsureConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(filepath.Join(configDir, "recent.json"))
+}
+
+// LoadFile reads items from path
+func LoadFile(path string) (*Store, error) {
+	store := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read recent items: %w", err)
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse recent items: %w", err)
+	}
+	return store, nil
+}
+
+// Save writes the items back to disk
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize recent items: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write recent items: %w", err)
+	}
+	return nil
+}
+
+// Record moves a work item to the front, replacing its earlier entry, and
+// drops the oldest items beyond MaxItems. Viewing an item does not hide that
+// it was edited or created earlier the same day.
+func (s *Store) Record(item Item) {
+	for i, existing := range s.Items {
+		if existing.ID != item.ID || existing.Org != item.Org {
+			continue
+		}
+		if item.Action == Viewed && existing.Action != Viewed && sameDay(existing.Time, item.Time) {
+			item.Action = existing.Action
+		}
+		if item.Title == "" {
+			item.Title = existing.Title
+		}
+		if item.Type == "" {
+			item.Type = existing.Type
+		}
+		s.Items = append(s.Items[:i], s.Items[i+1:]...)
+		break
+	}
+
+	s.Items = append([]Item{item}, s.Items...)
+	if len(s.Items) > MaxItems {
+		s.Items = s.Items[:MaxItems]
+	}
+}
+
+// ForOrg returns up to limit items of an organization, most recent first. A
+// limit of 0 returns all of them.
+func (s *Store) ForOrg(org string, limit int) []Item {
+	var items []Item
+	for _, item := range s.Items {
+		if item.Org != org {
+			continue
+		}
+		items = append(items, item)
+		if limit > 0 && len(items) == limit {
+			break
+		}
+	}
+	return items
+}
+
+// trackMu serializes Track calls made concurrently
+var trackMu sync.Mutex
+
+// Track records an item in the saved store
+func Track(item Item) error {
+	trackMu.Lock()
+	defer trackMu.Unlock()
+
+	store, err := Load()
+	if err != nil {
+		return err
+	}
+	store.Record(item)
+	return store.Save()
+}
+
+// TrackWorkItem records an action on a work item of an organization. Failures
+// are only logged, since they should not fail what touched the work item.
+func TrackWorkItem(org string, workItem *workitemtracking.WorkItem, action string) {
+	if workItem == nil || workItem.Id == nil {
+		return
+	}
+	item := model.FromAPI(workItem)
+	err := Track(Item{
+		ID:     item.ID,
+		Title:  item.Title,
+		Type:   item.Type,
+		Action: action,
+		Org:    org,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		log.Warnf("Failed to record recent work item %d: %v", item.ID, err)
+	}
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Local().Date()
+	by, bm, bd := b.Local().Date()
+	return ay == by && am == bm && ad == bd
+}
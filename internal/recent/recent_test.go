@@ -0,0 +1,77 @@
+package recent
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestStoreRecord(t *testing.T) {
+	now := time.Date(2024, 6, 5, 15, 30, 0, 0, time.Local)
+	path := filepath.Join(t.TempDir(), "recent.json")
+
+	store, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	store.Record(Item{ID: 1, Title: "Login fails", Action: Edited, Org: "contoso", Time: now.Add(-time.Hour)})
+	store.Record(Item{ID: 2, Title: "Other org", Action: Viewed, Org: "fabrikam", Time: now.Add(-time.Hour)})
+	store.Record(Item{ID: 3, Title: "Add search", Action: Viewed, Org: "contoso", Time: now.Add(-time.Minute)})
+	store.Record(Item{ID: 1, Action: Viewed, Org: "contoso", Time: now})
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	got := loaded.ForOrg("contoso", 0)
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 3 {
+		t.Fatalf("ForOrg() = %+v, want items 1 and 3", got)
+	}
+	if got[0].Action != Edited || got[0].Title != "Login fails" {
+		t.Errorf("revisited item = %+v, want the earlier edit and title kept", got[0])
+	}
+	if limited := loaded.ForOrg("contoso", 1); len(limited) != 1 {
+		t.Errorf("ForOrg() with limit 1 returned %d items", len(limited))
+	}
+}
+
+func TestStoreRecordLimit(t *testing.T) {
+	store := &Store{}
+	for id := 1; id <= MaxItems+5; id++ {
+		store.Record(Item{ID: id, Action: Viewed})
+	}
+	if len(store.Items) != MaxItems {
+		t.Fatalf("store has %d items, want %d", len(store.Items), MaxItems)
+	}
+	if store.Items[0].ID != MaxItems+5 {
+		t.Errorf("first item = %d, want the most recent", store.Items[0].ID)
+	}
+}
+
+func TestTrackWorkItem(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	id := 42
+	TrackWorkItem("https://dev.azure.com/contoso", nil, Viewed)
+	TrackWorkItem("https://dev.azure.com/contoso", &workitemtracking.WorkItem{Id: &id, Fields: &map[string]interface{}{
+		"System.Title":        "Fix login",
+		"System.WorkItemType": "Bug",
+	}}, Edited)
+
+	store, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Items) != 1 {
+		t.Fatalf("items = %+v, want only the work item with an ID", store.Items)
+	}
+	got := store.Items[0]
+	if got.ID != 42 || got.Title != "Fix login" || got.Type != "Bug" || got.Action != Edited || got.Org != "https://dev.azure.com/contoso" {
+		t.Errorf("item = %+v, want #42 Fix login (Bug) edited in contoso", got)
+	}
+}
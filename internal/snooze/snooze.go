@@ -0,0 +1,262 @@
+// Package snooze stores local reminders that hide work items until a given time.
+package snooze
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/config"
+)
+
+// reminderHour is the local time of day a snooze ends when only a day is given
+const reminderHour = 9
+
+// Reminder hides a work item until a point in time
+type Reminder struct {
+	ID      int       `json:"id"`
+	Title   string    `json:"title,omitempty"`
+	Until   time.Time `json:"until"`
+	Created time.Time `json:"created"`
+}
+
+// Store holds the reminders saved in ~/.azure-boards-cli/snoozed.json
+type Store struct {
+	Reminders []Reminder `json:"reminders"`
+
+	path string
+}
+
+// Load reads the saved reminders; a missing file is an empty store
+func Load() (*Store, error) {
+	configDir, err := config.EnsureConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(filepath.Join(configDir, "snoozed.json"))
+}
+
+// LoadFile reads reminders from path
+func LoadFile(path string) (*Store, error) {
+	store := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read snoozed items: %w", err)
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse snoozed items: %w", err)
+	}
+	return store, nil
+}
+
+// Save writes the reminders back to disk
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize snoozed items: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write snoozed items: %w", err)
+	}
+	return nil
+}
+
+// Snooze hides a work item until the given time, replacing any earlier snooze
+func (s *Store) Snooze(id int, title string, until, now time.Time) {
+	s.Cancel(id)
+	s.Reminders = append(s.Reminders, Reminder{ID: id, Title: title, Until: until, Created: now})
+	sort.Slice(s.Reminders, func(i, j int) bool {
+		return s.Reminders[i].Until.Before(s.Reminders[j].Until)
+	})
+}
+
+// Cancel removes the snooze of a work item and reports whether there was one
+func (s *Store) Cancel(id int) bool {
+	for i, reminder := range s.Reminders {
+		if reminder.ID == id {
+			s.Reminders = append(s.Reminders[:i], s.Reminders[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// IsSnoozed reports whether a work item is hidden at now
+func (s *Store) IsSnoozed(id int, now time.Time) bool {
+	for _, reminder := range s.Reminders {
+		if reminder.ID == id && now.Before(reminder.Until) {
+			return true
+		}
+	}
+	return false
+}
+
+// Active returns the reminders that have not ended yet
+func (s *Store) Active(now time.Time) []Reminder {
+	var active []Reminder
+	for _, reminder := range s.Reminders {
+		if now.Before(reminder.Until) {
+			active = append(active, reminder)
+		}
+	}
+	return active
+}
+
+// TakeDue removes and returns the reminders that have ended, so each one
+// resurfaces once. Call Save afterwards to persist the change.
+func (s *Store) TakeDue(now time.Time) []Reminder {
+	var due, remaining []Reminder
+	for _, reminder := range s.Reminders {
+		if now.Before(reminder.Until) {
+			remaining = append(remaining, reminder)
+		} else {
+			due = append(due, reminder)
+		}
+	}
+	s.Reminders = remaining
+	return due
+}
+
+// Hide loads the saved reminders and removes the snoozed work items; see Store.Hide.
+// If the reminders cannot be read, all work items are returned with the error.
+func Hide(workItems []workitemtracking.WorkItem, now time.Time) ([]workitemtracking.WorkItem, []Reminder, error) {
+	store, err := Load()
+	if err != nil {
+		return workItems, nil, err
+	}
+	return store.Hide(workItems, now)
+}
+
+// Hide removes the work items that are still snoozed. It also takes and
+// returns the reminders that have ended, saving the store, so callers can
+// report each one once.
+func (s *Store) Hide(workItems []workitemtracking.WorkItem, now time.Time) ([]workitemtracking.WorkItem, []Reminder, error) {
+	due := s.TakeDue(now)
+	var err error
+	if len(due) > 0 {
+		err = s.Save()
+	}
+
+	var visible []workitemtracking.WorkItem
+	for _, wi := range workItems {
+		if wi.Id != nil && s.IsSnoozed(*wi.Id, now) {
+			continue
+		}
+		visible = append(visible, wi)
+	}
+	return visible, due, err
+}
+
+// String describes a reminder, e.g. "#123 Fix login"
+func (r Reminder) String() string {
+	description := fmt.Sprintf("#%d", r.ID)
+	if r.Title != "" {
+		description += " " + r.Title
+	}
+	return description
+}
+
+// ParseUntil reads when a snooze ends. It accepts durations ("3h", "2d", "1w"),
+// "tomorrow", "next week", weekday names ("monday", "fri"), and dates
+// ("2024-06-01", "2024-06-01 14:00"). Days without a time end at 09:00 local time.
+func ParseUntil(value string, now time.Time) (time.Time, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return time.Time{}, fmt.Errorf("snooze time is required")
+	}
+
+	morning := func(day time.Time) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), reminderHour, 0, 0, 0, now.Location())
+	}
+
+	switch value {
+	case "tomorrow":
+		return morning(now.AddDate(0, 0, 1)), nil
+	case "next week", "next-week":
+		return morning(nextWeekday(now, time.Monday)), nil
+	}
+
+	if weekday, ok := parseWeekday(value); ok {
+		return morning(nextWeekday(now, weekday)), nil
+	}
+
+	if until, ok := parseDuration(value, now); ok {
+		return until, nil
+	}
+
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return checkFuture(t, now, value)
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return checkFuture(morning(t), now, value)
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(value)); err == nil {
+		return checkFuture(t, now, value)
+	}
+
+	return time.Time{}, fmt.Errorf("invalid snooze time '%s' (try 3h, 2d, tomorrow, monday or 2024-06-01)", value)
+}
+
+func checkFuture(t, now time.Time, value string) (time.Time, error) {
+	if !t.After(now) {
+		return time.Time{}, fmt.Errorf("snooze time '%s' is in the past", value)
+	}
+	return t, nil
+}
+
+// parseDuration reads a number followed by m, h, d or w
+func parseDuration(value string, now time.Time) (time.Time, bool) {
+	if len(value) < 2 {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n <= 0 {
+		return time.Time{}, false
+	}
+
+	switch value[len(value)-1] {
+	case 'm':
+		return now.Add(time.Duration(n) * time.Minute), true
+	case 'h':
+		return now.Add(time.Duration(n) * time.Hour), true
+	case 'd':
+		return now.AddDate(0, 0, n), true
+	case 'w':
+		return now.AddDate(0, 0, 7*n), true
+	}
+	return time.Time{}, false
+}
+
+// parseWeekday reads a full or three-letter weekday name
+func parseWeekday(value string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if value == name || value == name[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// nextWeekday returns the next date falling on weekday, at least one day after now
+func nextWeekday(now time.Time, weekday time.Weekday) time.Time {
+	days := (int(weekday) - int(now.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return now.AddDate(0, 0, days)
+}
@@ -0,0 +1,124 @@
+package snooze
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestParseUntil(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2024, 6, 5, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "3h", want: now.Add(3 * time.Hour)},
+		{value: "2d", want: now.AddDate(0, 0, 2)},
+		{value: "1w", want: now.AddDate(0, 0, 7)},
+		{value: "tomorrow", want: time.Date(2024, 6, 6, 9, 0, 0, 0, time.UTC)},
+		{value: "Monday", want: time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)},
+		{value: "wed", want: time.Date(2024, 6, 12, 9, 0, 0, 0, time.UTC)},
+		{value: "next week", want: time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)},
+		{value: "2024-06-20", want: time.Date(2024, 6, 20, 9, 0, 0, 0, time.UTC)},
+		{value: "2024-06-20 14:00", want: time.Date(2024, 6, 20, 14, 0, 0, 0, time.UTC)},
+		{value: "2024-06-01", wantErr: true},
+		{value: "0h", wantErr: true},
+		{value: "someday", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseUntil(tt.value, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseUntil(%q) = %v, want error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseUntil(%q) error: %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseUntil(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreTakeDue(t *testing.T) {
+	now := time.Date(2024, 6, 5, 15, 30, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "snoozed.json")
+
+	store, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	store.Snooze(1, "Ended", now.Add(time.Hour), now)
+	store.Snooze(2, "Active", now.Add(48*time.Hour), now)
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	later := now.Add(2 * time.Hour)
+	store, err = LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	if store.IsSnoozed(1, later) || !store.IsSnoozed(2, later) {
+		t.Fatalf("IsSnoozed() = %v/%v, want false/true", store.IsSnoozed(1, later), store.IsSnoozed(2, later))
+	}
+
+	due := store.TakeDue(later)
+	if len(due) != 1 || due[0].ID != 1 {
+		t.Fatalf("TakeDue() = %v, want only #1", due)
+	}
+	if due := store.TakeDue(later); len(due) != 0 {
+		t.Errorf("second TakeDue() = %v, want nothing", due)
+	}
+	if active := store.Active(later); len(active) != 1 || active[0].ID != 2 {
+		t.Errorf("Active() = %v, want only #2", active)
+	}
+}
+
+func TestStoreHide(t *testing.T) {
+	now := time.Date(2024, 6, 5, 15, 30, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "snoozed.json")
+
+	store, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	store.Snooze(1, "Ended", now.Add(-time.Hour), now.Add(-2*time.Hour))
+	store.Snooze(2, "Active", now.Add(time.Hour), now)
+
+	ids := []int{1, 2, 3}
+	var workItems []workitemtracking.WorkItem
+	for i := range ids {
+		workItems = append(workItems, workitemtracking.WorkItem{Id: &ids[i]})
+	}
+
+	visible, due, err := store.Hide(workItems, now)
+	if err != nil {
+		t.Fatalf("Hide() error: %v", err)
+	}
+	if len(visible) != 2 || *visible[0].Id != 1 || *visible[1].Id != 3 {
+		t.Errorf("Hide() visible = %v, want #1 and #3", visible)
+	}
+	if len(due) != 1 || due[0].ID != 1 {
+		t.Errorf("Hide() due = %v, want only #1", due)
+	}
+
+	saved, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	if len(saved.Reminders) != 1 || saved.Reminders[0].ID != 2 {
+		t.Errorf("saved reminders = %v, want only #2", saved.Reminders)
+	}
+}
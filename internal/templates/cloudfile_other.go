@@ -0,0 +1,8 @@
+//go:build !windows
+
+package templates
+
+// isCloudFileError reports sync client errors that only exist on Windows
+func isCloudFileError(err error) bool {
+	return false
+}
@@ -0,0 +1,26 @@
+//go:build windows
+
+package templates
+
+import (
+	"errors"
+	"syscall"
+)
+
+// Windows errors returned while OneDrive or another sync client holds a file
+// or is still downloading a placeholder
+var cloudFileErrors = []syscall.Errno{
+	32,  // ERROR_SHARING_VIOLATION
+	33,  // ERROR_LOCK_VIOLATION
+	362, // ERROR_CLOUD_FILE_PROVIDER_NOT_RUNNING
+	426, // ERROR_CLOUD_FILE_REQUEST_TIMEOUT
+}
+
+func isCloudFileError(err error) bool {
+	for _, errno := range cloudFileErrors {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// TeamNamespace prefixes the names of the team's shared templates, e.g. "@team/bug".
+// They live outside the templates directory and are read-only.
+const TeamNamespace = "@team"
+
+// ErrTeamReadOnly is returned when a team template would be changed
+var ErrTeamReadOnly = errors.New("team templates are read-only; copy one to change it")
+
+// GetTeamTemplatesDir returns the folder of the team's shared templates: the
+// team_templates_dir config key when set, e.g. an org-level shared folder,
+// otherwise ~/.azure-boards-cli/team-templates, where azb template sync clones them
+func GetTeamTemplatesDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	if dir := strings.TrimSpace(viper.GetString("team_templates_dir")); dir != "" {
+		if dir == "~" || strings.HasPrefix(dir, "~/") || strings.HasPrefix(dir, `~\`) {
+			dir = filepath.Join(home, dir[1:])
+		}
+		return dir, nil
+	}
+	return filepath.Join(home, ".azure-boards-cli", "team-templates"), nil
+}
+
+// IsTeam reports whether a template or folder name is in the team namespace
+func IsTeam(name string) bool {
+	name = filepath.ToSlash(name)
+	return name == TeamNamespace || strings.HasPrefix(name, TeamNamespace+"/")
+}
+
+// teamPath returns the file of a name in the team namespace
+func teamPath(name string) (string, error) {
+	dir, err := GetTeamTemplatesDir()
+	if err != nil {
+		return "", err
+	}
+	rest := strings.TrimPrefix(strings.TrimPrefix(filepath.ToSlash(name), TeamNamespace), "/")
+	return filepath.Join(dir, filepath.FromSlash(rest)), nil
+}
+
+// ListTeam lists the team's shared templates, named in the team namespace, and
+// the ones that cannot be loaded as problems. It returns nothing when there are
+// no team templates.
+func ListTeam() ([]*Template, []Problem, error) {
+	dir, err := GetTeamTemplatesDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+
+	var list []*Template
+	var problems []Problem
+	err = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(entry.Name(), ".yaml") && !strings.HasSuffix(entry.Name(), ".yml") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := TeamNamespace + "/" + strings.TrimSuffix(strings.TrimSuffix(filepath.ToSlash(rel), ".yaml"), ".yml")
+		data, err := readFile(path)
+		if err != nil {
+			problems = append(problems, Problem{Path: TeamNamespace + "/" + filepath.ToSlash(rel), Err: err})
+			return nil
+		}
+		template, _, err := Parse(data)
+		if err != nil {
+			problems = append(problems, Problem{Path: TeamNamespace + "/" + filepath.ToSlash(rel), Err: err})
+			return nil
+		}
+		template.Name = name
+		list = append(list, template)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list team templates: %w", err)
+	}
+	return list, problems, nil
+}
+
+// teamTree returns the team's shared templates as a folder of the template
+// tree, or nil when there are none
+func teamTree(problems *[]Problem) *TemplateNode {
+	dir, err := GetTeamTemplatesDir()
+	if err != nil {
+		return nil
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	children, err := listTreeRecursive(dir, TeamNamespace, "", TeamNamespace, problems)
+	if err != nil {
+		*problems = append(*problems, Problem{Path: TeamNamespace, Err: err})
+		return nil
+	}
+	if len(children) == 0 {
+		return nil
+	}
+	return &TemplateNode{Name: TeamNamespace, Path: TeamNamespace, IsDir: true, Children: children}
+}
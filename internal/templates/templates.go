@@ -1,14 +1,35 @@
 package templates
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 )
 
+// readAttempts is how often a read is tried when it fails with a transient error,
+// e.g. while a cloud-synced file (OneDrive, Dropbox, iCloud) is being downloaded
+const readAttempts = 3
+
+// readRetryDelay is the wait before the first retry; later retries wait longer
+var readRetryDelay = 200 * time.Millisecond
+
+// Problem describes a template file or folder that could not be read
+type Problem struct {
+	Path string // Relative path from templates dir
+	Err  error
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %v", p.Path, p.Err)
+}
+
 // Template represents a work item template
 type Template struct {
 	Name        string                 `yaml:"name"`
@@ -33,17 +54,56 @@ type ChildWorkItem struct {
 	Fields      map[string]interface{} `yaml:"fields,omitempty"`
 }
 
+// WorkItemFields builds the type and fields of the child, given the fields of
+// its parent. The type defaults to Task and an assignee of @me is left unset.
+// System.State is dropped, since new work items start in their initial state.
+// Area, iteration and Custom.* fields not set on the child are inherited.
+func (c ChildWorkItem) WorkItemFields(parent map[string]interface{}) (string, map[string]interface{}) {
+	fields := make(map[string]interface{})
+	if c.Title != "" {
+		fields["System.Title"] = c.Title
+	}
+	if c.Description != "" {
+		fields["System.Description"] = c.Description
+	}
+	if c.AssignedTo != "" && c.AssignedTo != "@me" {
+		fields["System.AssignedTo"] = c.AssignedTo
+	}
+
+	for name, value := range c.Fields {
+		if name != "System.State" {
+			fields[name] = value
+		}
+	}
+
+	for name, value := range parent {
+		inherited := name == "System.AreaPath" || name == "System.IterationPath" || strings.HasPrefix(name, "Custom.")
+		if _, set := fields[name]; inherited && !set {
+			fields[name] = value
+		}
+	}
+
+	childType := c.Type
+	if childType == "" {
+		childType = "Task"
+	}
+	return childType, fields
+}
+
 // TemplateNode represents a node in the template tree (file or directory)
 type TemplateNode struct {
 	Name       string          // Display name (filename without extension or directory name)
 	Path       string          // Relative path from templates dir
 	IsDir      bool            // True if this is a directory
-	Template   *Template       // Populated if this is a template file
+	Template   *Template       // Populated if this is a template file that could be parsed
+	Err        error           // Why the template file cannot be used
+	Warnings   []string        // Unknown keys in the template file
 	Children   []*TemplateNode // Populated if this is a directory
 	ParentPath string          // Path of parent directory
 }
 
-// GetTemplatesDir returns the path to the templates directory
+// GetTemplatesDir returns the path to the templates directory: the templates_dir
+// config key when set (a leading ~ is expanded), otherwise ~/.azure-boards-cli/templates
 func GetTemplatesDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -51,6 +111,12 @@ func GetTemplatesDir() (string, error) {
 	}
 
 	templatesDir := filepath.Join(home, ".azure-boards-cli", "templates")
+	if dir := strings.TrimSpace(viper.GetString("templates_dir")); dir != "" {
+		if dir == "~" || strings.HasPrefix(dir, "~/") || strings.HasPrefix(dir, `~\`) {
+			dir = filepath.Join(home, dir[1:])
+		}
+		templatesDir = dir
+	}
 	if err := os.MkdirAll(templatesDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create templates directory: %w", err)
 	}
@@ -71,6 +137,9 @@ func GetTemplatePath(name string) (string, error) {
 		name += ".yaml"
 	}
 
+	if IsTeam(name) {
+		return teamPath(name)
+	}
 	return filepath.Join(templatesDir, name), nil
 }
 
@@ -81,7 +150,7 @@ func Load(name string) (*Template, error) {
 		return nil, err
 	}
 
-	data, err := os.ReadFile(path)
+	data, err := readFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("template '%s' not found", name)
@@ -89,16 +158,35 @@ func Load(name string) (*Template, error) {
 		return nil, fmt.Errorf("failed to read template: %w", err)
 	}
 
-	var template Template
-	if err := yaml.Unmarshal(data, &template); err != nil {
-		return nil, fmt.Errorf("failed to parse template: %w", err)
+	template, _, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("template '%s': %w", name, err)
+	}
+	return template, nil
+}
+
+// ReadRaw returns the YAML of a template exactly as it is stored
+func ReadRaw(name string) ([]byte, error) {
+	path, err := GetTemplatePath(name)
+	if err != nil {
+		return nil, err
 	}
 
-	return &template, nil
+	data, err := readFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("template '%s' not found", name)
+		}
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	return data, nil
 }
 
 // Save saves a template
 func Save(template *Template) error {
+	if IsTeam(template.Name) {
+		return ErrTeamReadOnly
+	}
 	path, err := GetTemplatePath(template.Name)
 	if err != nil {
 		return err
@@ -122,19 +210,21 @@ func Save(template *Template) error {
 	return nil
 }
 
-// List lists all available templates
-func List() ([]*Template, error) {
+// List lists all available templates. Templates that cannot be loaded are
+// returned as problems, so they can be found and fixed.
+func List() ([]*Template, []Problem, error) {
 	templatesDir, err := GetTemplatesDir()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	entries, err := os.ReadDir(templatesDir)
+	entries, err := readDir(templatesDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+		return nil, nil, fmt.Errorf("failed to read templates directory: %w", err)
 	}
 
 	var templates []*Template
+	var problems []Problem
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -144,21 +234,28 @@ func List() ([]*Template, error) {
 			continue
 		}
 
-		name := strings.TrimSuffix(strings.TrimSuffix(entry.Name(), ".yaml"), ".yml")
-		template, err := Load(name)
+		data, err := readFile(filepath.Join(templatesDir, entry.Name()))
+		if err != nil {
+			problems = append(problems, Problem{Path: entry.Name(), Err: err})
+			continue
+		}
+		template, _, err := Parse(data)
 		if err != nil {
-			// Skip invalid templates
+			problems = append(problems, Problem{Path: entry.Name(), Err: err})
 			continue
 		}
 
 		templates = append(templates, template)
 	}
 
-	return templates, nil
+	return templates, problems, nil
 }
 
 // Delete deletes a template by name
 func Delete(name string) error {
+	if IsTeam(name) {
+		return ErrTeamReadOnly
+	}
 	path, err := GetTemplatePath(name)
 	if err != nil {
 		return err
@@ -194,6 +291,9 @@ func Exists(name string) (bool, error) {
 
 // Rename renames a template or directory
 func Rename(oldName, newName string) error {
+	if IsTeam(oldName) || IsTeam(newName) {
+		return ErrTeamReadOnly
+	}
 	templatesDir, err := GetTemplatesDir()
 	if err != nil {
 		return err
@@ -243,26 +343,94 @@ func Rename(oldName, newName string) error {
 	return nil
 }
 
-// ListTree recursively lists all templates in a tree structure
-func ListTree() ([]*TemplateNode, error) {
+// Move moves a template into another folder, keeping its file name, and
+// returns its new name. An empty folder is the top of the templates directory.
+func Move(name, folder string) (string, error) {
+	if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
+		name += ".yaml"
+	}
+	name = filepath.Clean(name)
+
+	folder = filepath.Clean(folder)
+	if folder == "." {
+		folder = ""
+	}
+	if filepath.IsAbs(folder) || folder == ".." || strings.HasPrefix(folder, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("folder '%s' is outside the templates directory", folder)
+	}
+
+	path, err := GetTemplatePath(name)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return "", fmt.Errorf("'%s' is a folder, not a template", name)
+	}
+
+	newName := filepath.Join(folder, filepath.Base(name))
+	if newName == name {
+		return "", fmt.Errorf("'%s' is already in that folder", name)
+	}
+	if err := Rename(name, newName); err != nil {
+		return "", err
+	}
+	return newName, nil
+}
+
+// ListFolders returns every folder in the templates directory, relative to it
+func ListFolders() ([]string, error) {
 	templatesDir, err := GetTemplatesDir()
 	if err != nil {
 		return nil, err
 	}
 
-	return listTreeRecursive(templatesDir, "", "")
+	var folders []string
+	err = filepath.WalkDir(templatesDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() || path == templatesDir {
+			return nil
+		}
+		rel, err := filepath.Rel(templatesDir, path)
+		if err != nil {
+			return err
+		}
+		folders = append(folders, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list folders: %w", err)
+	}
+	return folders, nil
 }
 
-// listTreeRecursive is a helper that recursively builds the template tree
-func listTreeRecursive(baseDir, relativePath, parentPath string) ([]*TemplateNode, error) {
-	var currentDir string
-	if relativePath == "" {
-		currentDir = baseDir
-	} else {
-		currentDir = filepath.Join(baseDir, relativePath)
+// ListTree recursively lists all templates in a tree structure. Files and
+// folders that cannot be read or parsed are left out and returned as problems.
+func ListTree() ([]*TemplateNode, []Problem, error) {
+	templatesDir, err := GetTemplatesDir()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	entries, err := os.ReadDir(currentDir)
+	var problems []Problem
+	nodes, err := listTreeRecursive(templatesDir, "", "", "", &problems)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The team's shared templates are listed after the local ones
+	if team := teamTree(&problems); team != nil {
+		nodes = append(nodes, team)
+	}
+	return nodes, problems, nil
+}
+
+// listTreeRecursive is a helper that recursively builds the template tree. The
+// paths of the nodes start with namespace, e.g. TeamNamespace for team templates.
+func listTreeRecursive(baseDir, namespace, relativePath, parentPath string, problems *[]Problem) ([]*TemplateNode, error) {
+	currentDir := filepath.Join(baseDir, relativePath)
+
+	entries, err := readDir(currentDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read directory %s: %w", currentDir, err)
 	}
@@ -270,18 +438,17 @@ func listTreeRecursive(baseDir, relativePath, parentPath string) ([]*TemplateNod
 	var nodes []*TemplateNode
 
 	for _, entry := range entries {
-		var nodePath string
-		if relativePath == "" {
-			nodePath = entry.Name()
-		} else {
-			nodePath = filepath.Join(relativePath, entry.Name())
-		}
+		relativeEntry := filepath.Join(relativePath, entry.Name())
+		nodePath := filepath.Join(namespace, relativeEntry)
 
 		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				continue
+			}
 			// Recursively process subdirectory
-			children, err := listTreeRecursive(baseDir, nodePath, relativePath)
+			children, err := listTreeRecursive(baseDir, namespace, relativeEntry, filepath.Join(namespace, relativePath), problems)
 			if err != nil {
-				// Skip directories that can't be read
+				*problems = append(*problems, Problem{Path: nodePath, Err: err})
 				continue
 			}
 
@@ -298,13 +465,14 @@ func listTreeRecursive(baseDir, relativePath, parentPath string) ([]*TemplateNod
 				continue
 			}
 
-			// Load the template
-			templateName := strings.TrimSuffix(strings.TrimSuffix(nodePath, ".yaml"), ".yml")
-			template, err := Load(templateName)
+			// Files that cannot be read are problems; broken templates stay in
+			// the tree with the reason, so they can be found and fixed
+			data, err := readFile(filepath.Join(currentDir, entry.Name()))
 			if err != nil {
-				// Skip invalid templates
+				*problems = append(*problems, Problem{Path: nodePath, Err: err})
 				continue
 			}
+			template, warnings, err := Parse(data)
 
 			displayName := strings.TrimSuffix(strings.TrimSuffix(entry.Name(), ".yaml"), ".yml")
 			nodes = append(nodes, &TemplateNode{
@@ -312,6 +480,8 @@ func listTreeRecursive(baseDir, relativePath, parentPath string) ([]*TemplateNod
 				Path:       nodePath,
 				IsDir:      false,
 				Template:   template,
+				Err:        err,
+				Warnings:   warnings,
 				ParentPath: parentPath,
 			})
 		}
@@ -319,3 +489,49 @@ func listTreeRecursive(baseDir, relativePath, parentPath string) ([]*TemplateNod
 
 	return nodes, nil
 }
+
+// readFile reads a file, retrying transient errors
+func readFile(path string) ([]byte, error) {
+	var data []byte
+	err := retryTransient(func() error {
+		var err error
+		data, err = os.ReadFile(path)
+		return err
+	})
+	return data, err
+}
+
+// readDir reads a directory, retrying transient errors
+func readDir(path string) ([]os.DirEntry, error) {
+	var entries []os.DirEntry
+	err := retryTransient(func() error {
+		var err error
+		entries, err = os.ReadDir(path)
+		return err
+	})
+	return entries, err
+}
+
+func retryTransient(read func() error) error {
+	var err error
+	for attempt := 1; attempt <= readAttempts; attempt++ {
+		if err = read(); err == nil || !isTransient(err) {
+			return err
+		}
+		if attempt < readAttempts {
+			time.Sleep(time.Duration(attempt) * readRetryDelay)
+		}
+	}
+	return err
+}
+
+// isTransient reports whether a read may succeed when tried again. Cloud sync
+// clients report files that are still being downloaded or locked this way.
+func isTransient(err error) bool {
+	for _, errno := range []syscall.Errno{syscall.EAGAIN, syscall.EBUSY, syscall.EINTR, syscall.ETIMEDOUT, syscall.EIO, syscall.EDEADLK} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return isCloudFileError(err)
+}
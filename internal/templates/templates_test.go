@@ -0,0 +1,297 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestRetryTransient(t *testing.T) {
+	readRetryDelay = 0
+	transient := &fs.PathError{Op: "read", Path: "bug.yaml", Err: syscall.EAGAIN}
+
+	tests := []struct {
+		name      string
+		failures  int
+		err       error
+		wantCalls int
+		wantErr   bool
+	}{
+		{"succeeds after transient errors", 2, transient, 3, false},
+		{"gives up after all attempts", readAttempts, transient, readAttempts, true},
+		{"does not retry other errors", 1, fs.ErrNotExist, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := retryTransient(func() error {
+				calls++
+				if calls <= tt.failures {
+					return tt.err
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("retryTransient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("read called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestListTreeReportsProblems(t *testing.T) {
+	dir := t.TempDir()
+	viper.Set("templates_dir", dir)
+	defer viper.Set("templates_dir", "")
+
+	files := map[string]string{
+		"bug.yaml":          "name: bug\ntype: Bug\n",
+		"broken.yaml":       "name: [unclosed\n",
+		"stories/epic.yaml": "name: stories/epic\ntype: Epic\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	nodes, problems, err := ListTree()
+	if err != nil {
+		t.Fatalf("ListTree() error: %v", err)
+	}
+
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	if got := fmt.Sprint(names); got != "[broken bug stories]" {
+		t.Errorf("ListTree() nodes = %s, want [broken bug stories]", got)
+	}
+	// Broken templates stay in the tree with the reason; problems are files that cannot be read
+	if nodes[0].Err == nil || nodes[0].Template != nil {
+		t.Errorf("broken template node = %+v, want an error and no template", nodes[0])
+	}
+	if len(problems) != 0 {
+		t.Errorf("ListTree() problems = %v, want none", problems)
+	}
+
+	// List returns the broken template as a problem instead of dropping it
+	list, problems, err := List()
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "bug" {
+		t.Errorf("List() = %v, want [bug]", list)
+	}
+	if len(problems) != 1 || problems[0].Path != "broken.yaml" {
+		t.Errorf("List() problems = %v, want broken.yaml", problems)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		yaml         string
+		wantWarnings []string
+		wantErr      string
+	}{
+		{name: "valid", yaml: "name: bug\ntype: Bug\nfields:\n  System.Title: Crash\n"},
+		{
+			name:         "unknown keys",
+			yaml:         "name: bug\ntype: Bug\nfeilds:\n  System.Title: Crash\nrelations:\n  children:\n    - title: Fix\n      asignedTo: me\n",
+			wantWarnings: []string{"line 3: unknown key 'feilds'", "line 8: unknown key 'asignedTo'"},
+		},
+		{name: "missing type", yaml: "name: bug\n", wantWarnings: []string{"no 'type'; the work item type must be given with --type"}},
+		{name: "child title in fields", yaml: "type: Bug\nrelations:\n  children:\n    - fields:\n        System.Title: Fix\n"},
+		{name: "child without title", yaml: "type: Bug\nrelations:\n  children:\n    - type: Task\n", wantWarnings: []string{"child 1 has no 'title' or System.Title field"}},
+		{name: "invalid yaml", yaml: "name: [unclosed\n", wantErr: "failed to parse template"},
+		{name: "empty", yaml: "\n", wantErr: "template is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, warnings, err := Parse([]byte(tt.yaml))
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+			}
+			if fmt.Sprint(warnings) != fmt.Sprint(tt.wantWarnings) {
+				t.Errorf("Parse() warnings = %q, want %q", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestMove(t *testing.T) {
+	tests := []struct {
+		name    string
+		folder  string
+		want    string
+		wantErr bool
+	}{
+		{name: "bug", folder: "bugs", want: "bugs/bug.yaml"},
+		{name: "bug.yaml", folder: "new/folder", want: "new/folder/bug.yaml"},
+		{name: "stories/epic.yaml", folder: "", want: "epic.yaml"},
+		{name: "stories/epic.yaml", folder: "stories", wantErr: true},
+		{name: "bug", folder: "../outside", wantErr: true},
+		{name: "stories", folder: "bugs", wantErr: true},
+		{name: "missing", folder: "bugs", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" to "+tt.folder, func(t *testing.T) {
+			dir := t.TempDir()
+			viper.Set("templates_dir", dir)
+			defer viper.Set("templates_dir", "")
+			for _, name := range []string{"bug.yaml", "stories/epic.yaml", "bugs/.keep"} {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, nil, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := Move(tt.name, tt.folder)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Move(%q, %q) error = %v, wantErr %v", tt.name, tt.folder, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != filepath.FromSlash(tt.want) {
+				t.Errorf("Move(%q, %q) = %q, want %q", tt.name, tt.folder, got, tt.want)
+			}
+			if _, err := os.Stat(filepath.Join(dir, got)); err != nil {
+				t.Errorf("moved template not found: %v", err)
+			}
+		})
+	}
+}
+
+func TestTeamTemplates(t *testing.T) {
+	local, team := t.TempDir(), t.TempDir()
+	viper.Set("templates_dir", local)
+	viper.Set("team_templates_dir", team)
+	defer viper.Set("templates_dir", "")
+	defer viper.Set("team_templates_dir", "")
+
+	files := map[string]string{
+		"bug.yaml":          "name: bug\ntype: Bug\n",
+		"stories/epic.yaml": "name: epic\ntype: Epic\n",
+		"broken.yaml":       "name: [unclosed\n",
+		".git/config.yaml":  "not: a template\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(team, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, problems, err := ListTeam()
+	if err != nil {
+		t.Fatalf("ListTeam() error: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Path != "@team/broken.yaml" {
+		t.Errorf("ListTeam() problems = %v, want @team/broken.yaml", problems)
+	}
+	var names []string
+	for _, template := range list {
+		names = append(names, template.Name)
+	}
+	if got := fmt.Sprint(names); got != "[@team/bug @team/stories/epic]" {
+		t.Errorf("ListTeam() = %s, want [@team/bug @team/stories/epic]", got)
+	}
+
+	if template, err := Load("@team/stories/epic"); err != nil || template.Type != "Epic" {
+		t.Errorf("Load(@team/stories/epic) = %+v, %v", template, err)
+	}
+
+	nodes, _, err := ListTree()
+	if err != nil {
+		t.Fatalf("ListTree() error: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Path != TeamNamespace || len(nodes[0].Children) != 3 {
+		t.Fatalf("ListTree() = %+v, want the @team folder with 3 entries", nodes)
+	}
+	if got := nodes[0].Children[2].Children[0].Path; got != filepath.Join(TeamNamespace, "stories", "epic.yaml") {
+		t.Errorf("team template path = %q", got)
+	}
+
+	if err := Delete("@team/bug"); !errors.Is(err, ErrTeamReadOnly) {
+		t.Errorf("Delete(@team/bug) error = %v, want ErrTeamReadOnly", err)
+	}
+	if err := Save(&Template{Name: "@team/new", Type: "Bug"}); !errors.Is(err, ErrTeamReadOnly) {
+		t.Errorf("Save(@team/new) error = %v, want ErrTeamReadOnly", err)
+	}
+	if _, err := Move("@team/bug.yaml", ""); !errors.Is(err, ErrTeamReadOnly) {
+		t.Errorf("Move(@team/bug) error = %v, want ErrTeamReadOnly", err)
+	}
+}
+
+func TestChildWorkItemFields(t *testing.T) {
+	parent := map[string]interface{}{
+		"System.Title":                   "Login page",
+		"System.AreaPath":                "Web\\Auth",
+		"System.IterationPath":           "Sprint 1",
+		"Custom.Team":                    "Auth",
+		"Microsoft.VSTS.Common.Priority": 1,
+	}
+
+	tests := []struct {
+		name     string
+		child    ChildWorkItem
+		wantType string
+		want     map[string]interface{}
+	}{
+		{
+			name:     "inherits area, iteration and custom fields",
+			child:    ChildWorkItem{Title: "Write tests"},
+			wantType: "Task",
+			want:     map[string]interface{}{"System.Title": "Write tests", "System.AreaPath": "Web\\Auth", "System.IterationPath": "Sprint 1", "Custom.Team": "Auth"},
+		},
+		{
+			name:     "child values win, state and @me are dropped",
+			child:    ChildWorkItem{Type: "Bug", Title: "Fix", AssignedTo: "@me", Fields: map[string]interface{}{"System.State": "Active", "System.AreaPath": "Web", "Custom.Team": "QA"}},
+			wantType: "Bug",
+			want:     map[string]interface{}{"System.Title": "Fix", "System.AreaPath": "Web", "System.IterationPath": "Sprint 1", "Custom.Team": "QA"},
+		},
+		{
+			name:     "title from fields",
+			child:    ChildWorkItem{AssignedTo: "ada@example.com", Fields: map[string]interface{}{"System.Title": "Review"}},
+			wantType: "Task",
+			want:     map[string]interface{}{"System.Title": "Review", "System.AssignedTo": "ada@example.com", "System.AreaPath": "Web\\Auth", "System.IterationPath": "Sprint 1", "Custom.Team": "Auth"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotType, got := tt.child.WorkItemFields(parent)
+			if gotType != tt.wantType || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WorkItemFields() = %s %v, want %s %v", gotType, got, tt.wantType, tt.want)
+			}
+		})
+	}
+}
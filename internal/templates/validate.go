@@ -0,0 +1,74 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// unknownFieldPattern matches the errors yaml.v3 reports for keys a strict
+// decode does not know, e.g. "line 3: field titel not found in type templates.Template"
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (.+) not found in type \S+$`)
+
+// Parse parses the YAML of a template. Keys the template format does not know,
+// such as a misspelt "relation", and a missing type or child title are returned
+// as warnings; YAML that cannot be parsed is an error.
+func Parse(data []byte) (*Template, []string, error) {
+	var template Template
+	if err := yaml.Unmarshal(data, &template); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil, errors.New("template is empty")
+	}
+
+	// Decoding again, strictly, finds the keys that were ignored
+	var warnings []string
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var typeErr *yaml.TypeError
+	if err := decoder.Decode(&Template{}); errors.As(err, &typeErr) {
+		for _, message := range typeErr.Errors {
+			if match := unknownFieldPattern.FindStringSubmatch(message); match != nil {
+				message = fmt.Sprintf("line %s: unknown key '%s'", match[1], match[2])
+			}
+			warnings = append(warnings, message)
+		}
+	} else if err != nil && err != io.EOF {
+		return nil, nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	return &template, append(warnings, template.check()...), nil
+}
+
+// check returns what is missing to create work items from the template without
+// further input. A template without a type needs --type when it is used, and a
+// child may set its title in fields as System.Title instead of in title.
+func (t *Template) check() []string {
+	var warnings []string
+	if t.Type == "" {
+		warnings = append(warnings, "no 'type'; the work item type must be given with --type")
+	}
+	if t.Relations != nil {
+		for i, child := range t.Relations.Children {
+			if child.Title == "" && child.Fields["System.Title"] == nil {
+				warnings = append(warnings, fmt.Sprintf("child %d has no 'title' or System.Title field", i+1))
+			}
+		}
+	}
+	return warnings
+}
+
+// Lint reads a template and returns its warnings, or why it cannot be used
+func Lint(name string) ([]string, error) {
+	data, err := ReadRaw(name)
+	if err != nil {
+		return nil, err
+	}
+	_, warnings, err := Parse(data)
+	return warnings, err
+}
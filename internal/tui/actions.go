@@ -10,7 +10,8 @@ type ActionController struct {
 	keybinds      *KeybindController
 }
 
-// PendingAction represents an action in progress
+// PendingAction represents an action in progress: a prompt, selection or
+// confirmation the user has not answered yet, or data loading for its next step
 type PendingAction struct {
 	Type     ActionType
 	Context  interface{}
@@ -26,10 +27,16 @@ const (
 	ActionEditWorkItem     ActionType = "edit_work_item"
 	ActionDeleteWorkItem   ActionType = "delete_work_item"
 	ActionCreateWorkItem   ActionType = "create_work_item"
+	ActionCreateTemplate   ActionType = "create_from_template"
 	ActionChangeState      ActionType = "change_state"
 	ActionAssign           ActionType = "assign"
 	ActionAddTags          ActionType = "add_tags"
+	ActionComment          ActionType = "add_comment"
 	ActionCopyTemplate     ActionType = "copy_template"
+	ActionRenameTemplate   ActionType = "rename_template"
+	ActionMoveTemplate     ActionType = "move_template"
+	ActionNewTemplate      ActionType = "new_template"
+	ActionNewFolder        ActionType = "new_folder"
 	ActionDeleteTemplate   ActionType = "delete_template"
 )
 
@@ -38,6 +45,7 @@ type ActionStep string
 
 const (
 	StepIdle     ActionStep = "idle"
+	StepLoading  ActionStep = "loading"  // Awaiting data for the next step
 	StepInput    ActionStep = "input"    // Awaiting user input
 	StepConfirm  ActionStep = "confirm"  // Awaiting confirmation
 	StepExecute  ActionStep = "execute"  // Executing action
@@ -62,8 +70,8 @@ func (ac *ActionController) CanExecuteAction(tab Tab) bool {
 	// Check tab-specific conditions
 	switch t := tab.(type) {
 	case *WorkItemsTab:
-		// Can't execute actions while list is filtering
-		if t.list.FilterState() == list.Filtering {
+		// Can't execute actions while list is filtering, items are being compared or history is shown
+		if t.list.FilterState() == list.Filtering || t.comparing || t.showingHistory {
 			return false
 		}
 	case *QueriesTab:
@@ -105,3 +113,19 @@ func (ac *ActionController) AdvanceStep(nextStep ActionStep) {
 		ac.pendingAction.Step = nextStep
 	}
 }
+
+// ContinueAction keeps an action pending at its next step with a new context,
+// for flows with more than one step
+func (ac *ActionController) ContinueAction(action *PendingAction, nextStep ActionStep, context interface{}) {
+	action.Step = nextStep
+	action.Context = context
+	ac.pendingAction = action
+}
+
+// PendingAt returns the pending action if it has the given type and step
+func (ac *ActionController) PendingAt(actionType ActionType, step ActionStep) *PendingAction {
+	if ac.pendingAction == nil || ac.pendingAction.Type != actionType || ac.pendingAction.Step != step {
+		return nil
+	}
+	return ac.pendingAction
+}
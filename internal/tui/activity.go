@@ -0,0 +1,348 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/log"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
+)
+
+const (
+	// activityWindow is how far back the Activity tab looks
+	activityWindow = 24 * time.Hour
+	// activityMaxItems caps the work items whose history is loaded
+	activityMaxItems = 50
+)
+
+// activityCamelCase splits field names like AssignedTo into words
+var activityCamelCase = regexp.MustCompile(`([a-z])([A-Z])`)
+
+// activityChange is one revision of a work item, summarized
+type activityChange struct {
+	Author  string
+	Date    time.Time
+	Summary string
+}
+
+// activityItem is a work item changed in the activity window, with its recent revisions
+type activityItem struct {
+	ID      int
+	Title   string
+	Type    string
+	State   string
+	Changed time.Time
+	Changes []activityChange
+}
+
+// ActivityTab shows recent changes to work items of the user's teams
+type ActivityTab struct {
+	TabBase
+	client      api.APIClient
+	viewport    viewport.Model
+	items       []activityItem
+	teams       []string
+	loading     bool
+	initialized bool
+	err         error
+	loadedAt    time.Time
+}
+
+// NewActivityTab creates a new activity tab
+func NewActivityTab(client api.APIClient, width, height int) *ActivityTab {
+	return &ActivityTab{
+		TabBase:  NewTabBase(width, height),
+		client:   client,
+		viewport: viewport.New(width, height),
+	}
+}
+
+// Name returns the tab name
+func (t *ActivityTab) Name() string {
+	return "Activity"
+}
+
+// Init initializes the tab
+func (t *ActivityTab) Init(width, height int) tea.Cmd {
+	t.SetSize(width, height)
+	if width > 0 && height > 0 {
+		t.initialized = true
+		t.loading = true
+		return t.fetchActivity()
+	}
+	return nil
+}
+
+// Update handles messages
+func (t *ActivityTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		if !t.initialized && !t.loading && msg.Width > 0 && msg.Height > 0 {
+			t.initialized = true
+			t.loading = true
+			return t, t.fetchActivity()
+		}
+
+	case ActivityLoadedMsg:
+		t.loading = false
+		t.err = msg.Error
+		if msg.Error != nil {
+			log.Errorf("Failed to load activity: %v", msg.Error)
+			return t, nil
+		}
+		t.items = msg.Items
+		t.teams = msg.Teams
+		t.loadedAt = time.Now()
+		t.viewport.SetContent(t.formatActivity())
+		t.viewport.GotoTop()
+		return t, nil
+
+	case tea.KeyMsg:
+		if key.Matches(msg, key.NewBinding(key.WithKeys("r"))) && !t.loading {
+			t.loading = true
+			return t, t.fetchActivity()
+		}
+	}
+
+	var cmd tea.Cmd
+	t.viewport, cmd = t.viewport.Update(msg)
+	return t, cmd
+}
+
+// View renders the tab
+func (t *ActivityTab) View() string {
+	if t.loading {
+		return RenderLoading("Loading activity...")
+	}
+	if t.err != nil {
+		return RenderErrorWithRetry(t.err)
+	}
+
+	scope := "project"
+	if len(t.teams) > 0 {
+		scope = strings.Join(t.teams, ", ")
+	}
+	header := TitleStyle.Render(fmt.Sprintf("Activity in the last 24h · %d work item(s)", len(t.items))) + "  " +
+		MutedStyle.Render(fmt.Sprintf("%s · updated %s", scope, t.loadedAt.Format("15:04")))
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", t.viewport.View())
+}
+
+// SetSize updates the tab dimensions
+func (t *ActivityTab) SetSize(width, height int) {
+	t.TabBase.SetSize(width, height)
+	t.viewport.Width = width
+	t.viewport.Height = t.ContentHeight() - 2
+	if t.items != nil {
+		t.viewport.SetContent(t.formatActivity())
+	}
+}
+
+// GetHelpEntries returns the list of available actions for the Activity tab
+func (t *ActivityTab) GetHelpEntries() []HelpEntry {
+	return []HelpEntry{
+		{Action: "refresh", Description: "Refresh activity"},
+	}
+}
+
+// fetchActivity loads the work items changed in the activity window and their recent revisions
+func (t *ActivityTab) fetchActivity() tea.Cmd {
+	client := t.client
+	return func() tea.Msg {
+		since := time.Now().Add(-activityWindow)
+
+		teams, err := client.GetMyTeams()
+		if err != nil {
+			log.Warnf("Failed to load teams, showing activity for the whole project: %v", err)
+		}
+
+		ids, err := client.QueryWorkItemIDs(activityWIQL(client.GetProject(), teams), activityMaxItems)
+		if err != nil {
+			return ActivityLoadedMsg{Error: err}
+		}
+		if len(ids) == 0 {
+			return ActivityLoadedMsg{Items: []activityItem{}, Teams: teams}
+		}
+
+		workItems, err := client.GetWorkItems(ids)
+		if err != nil {
+			return ActivityLoadedMsg{Error: err}
+		}
+
+		// Revisions are fetched in parallel, as many at once as bulk operations
+		found := make([]*activityItem, len(workItems))
+		api.ForEach(len(workItems), func(i int) {
+			wi := &workItems[i]
+			if wi.Id == nil {
+				return
+			}
+			revisions, err := client.GetWorkItemUpdates(*wi.Id)
+			if err != nil {
+				log.Warnf("Failed to load history of work item #%d: %v", *wi.Id, err)
+				return
+			}
+			changes := summarizeRevisions(revisions, since)
+			if len(changes) == 0 {
+				return
+			}
+			found[i] = &activityItem{
+				ID:      *wi.Id,
+				Title:   model.FieldString(wi.Fields, "System.Title"),
+				Type:    model.FieldString(wi.Fields, "System.WorkItemType"),
+				State:   model.FieldString(wi.Fields, "System.State"),
+				Changed: changes[len(changes)-1].Date,
+				Changes: changes,
+			}
+		})
+
+		items := make([]activityItem, 0, len(workItems))
+		for _, item := range found {
+			if item != nil {
+				items = append(items, *item)
+			}
+		}
+
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Changed.After(items[j].Changed) })
+		return ActivityLoadedMsg{Items: items, Teams: teams}
+	}
+}
+
+// activityWIQL selects work items changed since yesterday in the area paths of
+// the given teams, or in the whole project when there are none. @Today has day
+// precision, so revisions are trimmed to the activity window afterwards.
+func activityWIQL(project string, teams []string) string {
+	query := `SELECT [System.Id] FROM WorkItems
+WHERE [System.TeamProject] = @project
+AND [System.ChangedDate] >= @Today - 1`
+
+	if len(teams) > 0 {
+		areas := make([]string, len(teams))
+		for i, team := range teams {
+			areas[i] = fmt.Sprintf("[System.AreaPath] IN (@TeamAreas [%s]\\%s)", project, team)
+		}
+		query += "\nAND (" + strings.Join(areas, " OR ") + ")"
+	}
+
+	return query + "\nORDER BY [System.ChangedDate] DESC"
+}
+
+// summarizeRevisions turns the revisions made after since into one-line summaries, oldest first
+func summarizeRevisions(revisions []api.WorkItemRevision, since time.Time) []activityChange {
+	var changes []activityChange
+	for _, rev := range revisions {
+		if rev.Date.IsZero() || rev.Date.Before(since) {
+			continue
+		}
+
+		summary := summarizeRevision(rev)
+		if summary == "" {
+			continue
+		}
+
+		author := rev.Author
+		if author == "" {
+			author = "Someone"
+		}
+		changes = append(changes, activityChange{Author: author, Date: rev.Date, Summary: summary})
+	}
+	return changes
+}
+
+// summarizeRevision describes the field and link changes of one revision
+func summarizeRevision(rev api.WorkItemRevision) string {
+	if rev.Rev == 1 {
+		return "created"
+	}
+
+	var parts []string
+	for _, change := range rev.Changes {
+		if api.IsDerivedField(change.Field) {
+			continue
+		}
+		label := activityFieldLabel(change.Field)
+		oldValue, newValue := activityValue(change.OldValue), activityValue(change.NewValue)
+		switch {
+		case isLongValue(change.OldValue) || isLongValue(change.NewValue):
+			parts = append(parts, "edited "+label)
+		case oldValue == "":
+			parts = append(parts, fmt.Sprintf("%s → %s", label, newValue))
+		case newValue == "":
+			parts = append(parts, fmt.Sprintf("cleared %s", label))
+		default:
+			parts = append(parts, fmt.Sprintf("%s: %s → %s", label, oldValue, newValue))
+		}
+	}
+
+	if rev.LinksAdded > 0 {
+		parts = append(parts, fmt.Sprintf("added %d link(s)", rev.LinksAdded))
+	}
+	if rev.LinksRemoved > 0 {
+		parts = append(parts, fmt.Sprintf("removed %d link(s)", rev.LinksRemoved))
+	}
+	if rev.Comment != "" {
+		parts = append(parts, "commented")
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// activityFieldLabel turns a reference name like System.AssignedTo into "Assigned To"
+func activityFieldLabel(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return activityCamelCase.ReplaceAllString(name, "$1 $2")
+}
+
+// activityValue renders a field value for a summary
+func activityValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if identity, ok := value.(map[string]interface{}); ok {
+		if name, ok := identity["displayName"].(string); ok {
+			return name
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// isLongValue reports whether a value is rich text or too long to show in a summary
+func isLongValue(value interface{}) bool {
+	text, ok := value.(string)
+	return ok && (len(text) > 40 || strings.Contains(text, "<"))
+}
+
+// formatActivity renders the feed grouped by work item, most recently changed first
+func (t *ActivityTab) formatActivity() string {
+	if len(t.items) == 0 {
+		return MutedStyle.Render("No changes in the last 24 hours. Press r to refresh.")
+	}
+
+	width := t.Width() - 2
+	var b strings.Builder
+	for i, item := range t.items {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("#%d %s", item.ID, item.Title)))
+		b.WriteString(" " + MutedStyle.Render(item.Type+" · ") + stateStyle(item.State).Render(item.State) + "\n")
+		for _, change := range item.Changes {
+			line := fmt.Sprintf("  %s %s: %s", change.Date.Local().Format("15:04"), change.Author, change.Summary)
+			if width > 0 && len(line) > width {
+				line = output.FitCell(line, width)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
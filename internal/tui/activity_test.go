@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/api/apitest"
+)
+
+func TestSummarizeRevisions(t *testing.T) {
+	now := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-activityWindow)
+
+	revision := func(rev int, changed time.Time, changes ...api.FieldChange) api.WorkItemRevision {
+		return api.WorkItemRevision{Rev: rev, Author: "Ada", Date: changed, Changes: changes}
+	}
+
+	revisions := []api.WorkItemRevision{
+		revision(1, now.Add(-48*time.Hour), api.FieldChange{Field: "System.Title", NewValue: "Login fails"}),
+		revision(2, now.Add(-3*time.Hour),
+			api.FieldChange{Field: "System.AssignedTo", NewValue: map[string]interface{}{"displayName": "Grace"}},
+			api.FieldChange{Field: "System.State", OldValue: "New", NewValue: "Active"},
+		),
+		revision(3, now.Add(-2*time.Hour),
+			api.FieldChange{Field: "System.Description", OldValue: "Steps", NewValue: "<div>Steps to reproduce</div>"},
+		),
+		revision(4, now.Add(-time.Hour), api.FieldChange{Field: "System.Tags", OldValue: "ui"}),
+		revision(5, now.Add(-30*time.Minute)),
+		// A revision changing only derived fields is left out
+		revision(6, now.Add(-10*time.Minute), api.FieldChange{Field: "System.CommentCount", OldValue: 1, NewValue: 2}),
+	}
+	revisions[2].Comment = "<div>Looking into it</div>"
+	revisions[4].LinksAdded = 2
+
+	want := []string{
+		"Assigned To → Grace; State: New → Active",
+		"edited Description; commented",
+		"cleared Tags",
+		"added 2 link(s)",
+	}
+
+	got := summarizeRevisions(revisions, since)
+	if len(got) != len(want) {
+		t.Fatalf("summarizeRevisions() returned %d changes, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].Summary != want[i] {
+			t.Errorf("change %d summary = %q, want %q", i, got[i].Summary, want[i])
+		}
+		if got[i].Author != "Ada" {
+			t.Errorf("change %d author = %q, want Ada", i, got[i].Author)
+		}
+	}
+}
+
+func TestSummarizeRevisionCreated(t *testing.T) {
+	rev := api.WorkItemRevision{Rev: 1, Changes: []api.FieldChange{{Field: "System.Title", NewValue: "Login fails"}}}
+	if got := summarizeRevision(rev); got != "created" {
+		t.Errorf("summarizeRevision() = %q, want created", got)
+	}
+}
+
+func TestActivityWIQL(t *testing.T) {
+	want := `SELECT [System.Id] FROM WorkItems
+WHERE [System.TeamProject] = @project
+AND [System.ChangedDate] >= @Today - 1
+AND ([System.AreaPath] IN (@TeamAreas [Fabrikam]\Web) OR [System.AreaPath] IN (@TeamAreas [Fabrikam]\Mobile))
+ORDER BY [System.ChangedDate] DESC`
+	if got := activityWIQL("Fabrikam", []string{"Web", "Mobile"}); got != want {
+		t.Errorf("activityWIQL() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFetchActivity(t *testing.T) {
+	client := apitest.New()
+	client.QueryWorkItemIDsFunc = func(wiql string, top int) ([]int, error) {
+		return []int{1, 2, 3}, nil
+	}
+	client.GetWorkItemsFunc = func(ids []int) ([]workitemtracking.WorkItem, error) {
+		var workItems []workitemtracking.WorkItem
+		for i := range ids {
+			workItems = append(workItems, workitemtracking.WorkItem{Id: &ids[i], Fields: &map[string]interface{}{"System.Title": fmt.Sprintf("Item %d", ids[i])}})
+		}
+		return workItems, nil
+	}
+	client.GetWorkItemUpdatesFunc = func(id int) ([]api.WorkItemRevision, error) {
+		if id == 2 {
+			return nil, errors.New("not found")
+		}
+		ago := map[int]time.Duration{1: time.Hour, 3: time.Minute}[id]
+		return []api.WorkItemRevision{{Rev: 2, Date: time.Now().Add(-ago), Changes: []api.FieldChange{
+			{Field: "System.State", OldValue: "New", NewValue: "Active"},
+		}}}, nil
+	}
+
+	msg, ok := NewActivityTab(client, 80, 24).fetchActivity()().(ActivityLoadedMsg)
+	if !ok || msg.Error != nil {
+		t.Fatalf("fetchActivity() = %+v, want the activity", msg)
+	}
+	if len(msg.Items) != 2 || msg.Items[0].ID != 3 || msg.Items[1].ID != 1 {
+		t.Errorf("items = %+v, want #3 then #1", msg.Items)
+	}
+}
@@ -3,17 +3,55 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// maxNotificationHistory is how many notifications the message log keeps
+const maxNotificationHistory = 100
+
+// How long notifications stay on screen by default; errors stay longer so they can be read
+const (
+	DefaultNotificationTimeout = 4 * time.Second
+	DefaultErrorTimeout        = 10 * time.Second
+)
+
+var (
+	notificationTimeout      = DefaultNotificationTimeout
+	errorNotificationTimeout = DefaultErrorTimeout
+)
+
+// SetNotificationTimeouts sets how long success and error notifications stay
+// on screen. A timeout of 0 keeps them until the next notification.
+func SetNotificationTimeouts(success, errorTimeout time.Duration) {
+	notificationTimeout = success
+	errorNotificationTimeout = errorTimeout
+}
+
+// confirmDefaultYes selects the Yes button when a confirmation opens
+var confirmDefaultYes bool
+
+// SetConfirmDefault sets which button confirmation dialogs select first
+func SetConfirmDefault(yes bool) {
+	confirmDefaultYes = yes
+}
+
 // Notification displays temporary success or error messages
 type Notification struct {
 	Message string
 	IsError bool
 	Visible bool
+	History []NotificationEntry // shown messages, oldest first
+}
+
+// NotificationEntry is a notification kept for the message log
+type NotificationEntry struct {
+	Message string
+	IsError bool
+	Time    time.Time
 }
 
 // NewNotification creates a new notification
@@ -30,6 +68,11 @@ func (n *Notification) Show(message string, isError bool) {
 	n.Message = message
 	n.IsError = isError
 	n.Visible = true
+
+	n.History = append(n.History, NotificationEntry{Message: message, IsError: isError, Time: time.Now()})
+	if len(n.History) > maxNotificationHistory {
+		n.History = n.History[len(n.History)-maxNotificationHistory:]
+	}
 }
 
 // Clear clears the notification
@@ -126,30 +169,80 @@ func (i *InputPrompt) View() string {
 
 // ConfirmationDialog displays a yes/no confirmation prompt
 type ConfirmationDialog struct {
-	Prompt  string
-	Active  bool
-	Action  string      // What action to confirm
-	Context interface{} // Additional context for the action
+	Prompt     string
+	Active     bool
+	Action     string  // What action to confirm, for logging
+	OnConfirm  tea.Cmd // Runs when the user confirms
+	YesFocused bool    // Which button is currently selected
+	Phrase     string  // When set, the user must type this phrase to confirm
+	Input      textinput.Model
 }
 
 // NewConfirmationDialog creates a new confirmation dialog
 func NewConfirmationDialog() *ConfirmationDialog {
+	ti := textinput.New()
+	ti.CharLimit = 100
+	ti.Width = 50
+
 	return &ConfirmationDialog{
 		Active: false,
+		Input:  ti,
 	}
 }
 
-// Show displays the confirmation dialog
-func (c *ConfirmationDialog) Show(prompt, action string, context interface{}) {
+// Show displays the confirmation dialog with the configured default button
+// selected, "No" unless set otherwise
+func (c *ConfirmationDialog) Show(prompt, action string, onConfirm tea.Cmd) {
+	c.ShowWithDefault(prompt, action, onConfirm, confirmDefaultYes)
+}
+
+// ShowWithDefault displays the confirmation dialog with the given button selected
+func (c *ConfirmationDialog) ShowWithDefault(prompt, action string, onConfirm tea.Cmd, defaultYes bool) {
 	c.Prompt = prompt
 	c.Action = action
-	c.Context = context
+	c.OnConfirm = onConfirm
 	c.Active = true
+	c.YesFocused = defaultYes
+	c.Phrase = ""
+	c.Input.Blur()
+}
+
+// ShowWithPhrase displays the confirmation dialog and requires the user to type
+// the given phrase before the action is confirmed (used for destructive bulk actions)
+func (c *ConfirmationDialog) ShowWithPhrase(prompt, action string, onConfirm tea.Cmd, phrase string) {
+	c.ShowWithDefault(prompt, action, onConfirm, false)
+	c.Phrase = phrase
+	c.Input.Placeholder = phrase
+	c.Input.SetValue("")
+	c.Input.Focus()
 }
 
 // Hide hides the confirmation dialog
 func (c *ConfirmationDialog) Hide() {
 	c.Active = false
+	c.Input.Blur()
+}
+
+// RequiresPhrase returns true if the user must type a phrase to confirm
+func (c *ConfirmationDialog) RequiresPhrase() bool {
+	return c.Phrase != ""
+}
+
+// PhraseMatches returns true if the typed phrase matches the required phrase
+func (c *ConfirmationDialog) PhraseMatches() bool {
+	return strings.TrimSpace(c.Input.Value()) == c.Phrase
+}
+
+// ToggleSelection switches focus between the Yes and No buttons
+func (c *ConfirmationDialog) ToggleSelection() {
+	c.YesFocused = !c.YesFocused
+}
+
+// Update updates the phrase input
+func (c *ConfirmationDialog) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	c.Input, cmd = c.Input.Update(msg)
+	return cmd
 }
 
 // View renders the confirmation dialog
@@ -160,9 +253,25 @@ func (c *ConfirmationDialog) View() string {
 
 	title := DialogTitleStyle.Render("⚠ Confirmation Required")
 	prompt := NormalStyle.Render(c.Prompt)
-	help := MutedStyle.Render("(y/n)")
 
-	content := fmt.Sprintf("%s\n\n%s\n\n%s", title, prompt, help)
+	if c.RequiresPhrase() {
+		instruction := NormalStyle.Render(fmt.Sprintf("Type '%s' to confirm:", c.Phrase))
+		help := MutedStyle.Render("(Enter to confirm, Esc to cancel)")
+		content := fmt.Sprintf("%s\n\n%s\n\n%s\n%s\n\n%s", title, prompt, instruction, c.Input.View(), help)
+		return DialogBoxStyle.Render(content)
+	}
+
+	yes := NormalStyle.Render("  Yes  ")
+	no := NormalStyle.Render("  No  ")
+	if c.YesFocused {
+		yes = SelectedButtonStyle.Render("  Yes  ")
+	} else {
+		no = SelectedButtonStyle.Render("  No  ")
+	}
+	buttons := lipgloss.JoinHorizontal(lipgloss.Top, yes, "   ", no)
+	help := MutedStyle.Render("(←/→ or tab: select, Enter: confirm, y/n, Esc: cancel)")
+
+	content := fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", title, prompt, buttons, help)
 	return DialogBoxStyle.Render(content)
 }
 
@@ -255,6 +364,16 @@ func (s *SelectionDialog) Show(title string, options []string, action string, co
 	s.Selected = 0
 }
 
+// Select moves the selection to an option, if the dialog has it
+func (s *SelectionDialog) Select(value string) {
+	for i, option := range s.Options {
+		if option == value {
+			s.Selected = i
+			return
+		}
+	}
+}
+
 // Hide hides the selection dialog
 func (s *SelectionDialog) Hide() {
 	s.Active = false
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestConfirmationDialog_DefaultSelection(t *testing.T) {
+	tests := []struct {
+		name       string
+		defaultYes bool
+	}{
+		{name: "default no", defaultYes: false},
+		{name: "default yes", defaultYes: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConfirmationDialog()
+			c.ShowWithDefault("Delete?", "delete_work_item", nil, tt.defaultYes)
+
+			if !c.Active {
+				t.Fatal("dialog should be active after ShowWithDefault")
+			}
+			if c.YesFocused != tt.defaultYes {
+				t.Errorf("YesFocused = %v, want %v", c.YesFocused, tt.defaultYes)
+			}
+
+			c.ToggleSelection()
+			if c.YesFocused == tt.defaultYes {
+				t.Error("ToggleSelection() did not switch the focused button")
+			}
+		})
+	}
+}
+
+func TestConfirmationDialog_ShowDefaultsToNo(t *testing.T) {
+	c := NewConfirmationDialog()
+	c.ShowWithDefault("first", "a", nil, true)
+	c.Show("second", "b", nil)
+
+	if c.YesFocused {
+		t.Error("Show() should select No by default")
+	}
+	if c.RequiresPhrase() {
+		t.Error("Show() should clear any previous phrase requirement")
+	}
+}
+
+func TestConfirmationDialog_Phrase(t *testing.T) {
+	c := NewConfirmationDialog()
+	c.ShowWithPhrase("Delete folder?", "delete_template", nil, "bugs")
+
+	if !c.RequiresPhrase() {
+		t.Fatal("RequiresPhrase() = false, want true")
+	}
+
+	c.Input.SetValue("bug")
+	if c.PhraseMatches() {
+		t.Error("PhraseMatches() = true for partial phrase")
+	}
+
+	c.Input.SetValue(" bugs ")
+	if !c.PhraseMatches() {
+		t.Error("PhraseMatches() = false for matching phrase with surrounding spaces")
+	}
+}
+
+func TestNotification_History(t *testing.T) {
+	n := NewNotification("", false)
+	for i := 1; i <= maxNotificationHistory+5; i++ {
+		n.Show(fmt.Sprintf("message %d", i), i%2 == 0)
+	}
+	n.Clear()
+
+	if len(n.History) != maxNotificationHistory {
+		t.Fatalf("len(History) = %d, want %d", len(n.History), maxNotificationHistory)
+	}
+	if first := n.History[0]; first.Message != "message 6" || !first.IsError {
+		t.Errorf("oldest entry = %+v, want message 6 as an error", first)
+	}
+	if last := n.History[len(n.History)-1]; last.Message != fmt.Sprintf("message %d", maxNotificationHistory+5) {
+		t.Errorf("newest entry = %+v", last)
+	}
+
+	log := NewMessageLogController()
+	log.Toggle()
+	view := log.View(n.History, 120, 20)
+	newest := strings.Index(view, fmt.Sprintf("message %d", maxNotificationHistory+5))
+	older := strings.Index(view, fmt.Sprintf("message %d", maxNotificationHistory+4))
+	if newest < 0 || older < 0 || newest > older {
+		t.Errorf("message log should list the newest messages first:\n%s", view)
+	}
+}
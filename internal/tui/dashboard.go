@@ -2,49 +2,26 @@ package tui
 
 import (
 	"fmt"
-	"io"
-	"log"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/auth"
+	"github.com/SOMUCHDOG/azb/internal/config"
+	"github.com/SOMUCHDOG/azb/internal/editor"
+	"github.com/SOMUCHDOG/azb/internal/log"
+	"github.com/SOMUCHDOG/azb/internal/templates"
 )
 
-var (
-	logger  *log.Logger
-	logFile *os.File
-)
-
-func init() {
-	// Set up logging to file
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		logger = log.New(io.Discard, "", 0)
-		return
-	}
-
-	logDir := filepath.Join(homeDir, ".azure-boards-cli")
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		logger = log.New(io.Discard, "", 0)
-		return
-	}
-
-	logFile, err = os.OpenFile(filepath.Join(logDir, "tui.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
-	if err != nil {
-		logger = log.New(io.Discard, "", 0)
-		return
-	}
-
-	logger = log.New(logFile, "[TUI] ", log.LstdFlags)
-}
-
 // Dashboard is the main TUI model that coordinates tabs
 type Dashboard struct {
-	client       *api.Client
+	client       api.APIClient
 	tabs         []Tab
 	currentTab   int
 	width        int
@@ -55,14 +32,27 @@ type Dashboard struct {
 	confirmation *ConfirmationDialog
 	err          error
 
+	// count is the count prefix typed before a movement key, e.g. the 5 of 5j
+	count int
+
+	// notificationID counts the notifications shown, so a dismiss timer only
+	// clears its own notification
+	notificationID int
+
+	// tokenWarning is shown under the header while the access token expires soon
+	tokenWarning string
+
 	// Controllers
-	keybinds *KeybindController
-	actions  *ActionController
-	help     *HelpController
+	keybinds   *KeybindController
+	actions    *ActionController
+	help       *HelpController
+	messageLog *MessageLogController
+	palette    *CommandPalette
+	onboarding *OnboardingController
 }
 
 // NewDashboard creates a new dashboard
-func NewDashboard(client *api.Client) *Dashboard {
+func NewDashboard(client api.APIClient) *Dashboard {
 	// Initialize keybind controller first
 	keybinds := NewKeybindController()
 
@@ -75,6 +65,10 @@ func NewDashboard(client *api.Client) *Dashboard {
 		keybinds:     keybinds,
 		actions:      NewActionController(keybinds),
 		help:         NewHelpController(keybinds),
+		messageLog:   NewMessageLogController(),
+		palette:      NewCommandPalette(),
+		onboarding:   NewOnboardingController(keybinds),
+		tokenWarning: auth.TokenExpiryWarning(time.Now()),
 	}
 
 	// Initialize tabs
@@ -82,9 +76,18 @@ func NewDashboard(client *api.Client) *Dashboard {
 		NewQueriesTab(client, 0, 0),
 		NewWorkItemsTab(client, 0, 0),
 		NewTemplatesTab(client, 0, 0),
+		NewActivityTab(client, 0, 0),
 		//	NewPipelinesTab(0, 0),
 		//	NewAgentsTab(0, 0),
 	}
+	if workItemsTab, ok := dashboard.tabs[1].(*WorkItemsTab); ok {
+		closeBinding, _ := keybinds.GetBinding("workitems", "close_compare")
+		compareBinding, _ := keybinds.GetBinding("workitems", "compare")
+		workItemsTab.setCompareKeys(closeBinding, compareBinding)
+	}
+	if filterBinding, ok := keybinds.GetBinding("global", "filter"); ok {
+		dashboard.setListFilterKey(filterBinding)
+	}
 
 	return dashboard
 }
@@ -94,10 +97,10 @@ func (d *Dashboard) Init() tea.Cmd {
 	// Initialize all tabs
 	var cmds []tea.Cmd
 	for i, tab := range d.tabs {
-		if cmd := tab.Init(d.width, d.height); cmd != nil {
+		if cmd := tab.Init(d.width, d.tabHeight()); cmd != nil {
 			cmds = append(cmds, cmd)
 		}
-		logger.Printf("Initialized tab %d: %s", i, tab.Name())
+		log.Debugf("Initialized tab %d: %s", i, tab.Name())
 	}
 	return tea.Batch(cmds...)
 }
@@ -114,7 +117,7 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Propagate size to all tabs and forward the message for initialization
 		for i, tab := range d.tabs {
-			tab.SetSize(d.width, d.height)
+			tab.SetSize(d.width, d.tabHeight())
 			// Forward WindowSizeMsg to each tab so they can trigger initial fetch
 			updatedTab, tabCmd := tab.Update(msg)
 			d.tabs[i] = updatedTab
@@ -123,7 +126,7 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		logger.Printf("Window resized to %dx%d", d.width, d.height)
+		log.Debugf("Window resized to %dx%d", d.width, d.height)
 		return d, tea.Batch(cmds...)
 
 	case tea.KeyMsg:
@@ -138,82 +141,28 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return d, nil
 			case "enter":
 				value := d.selectionDlg.SelectedValue()
-				action := d.selectionDlg.Action
-				context := d.selectionDlg.Context
 				d.selectionDlg.Hide()
-
-				// Handle action based on type
-				if action == "change_state" {
-					if workItemID, ok := context.(int); ok {
-						logger.Printf("Changing state of work item #%d to '%s'", workItemID, value)
-						return d, changeWorkItemState(d.client, workItemID, value)
-					}
-				}
-
-				logger.Printf("Selection submitted: %s (action: %s)", value, action)
-				return d, nil
+				return d, d.submitAction(value)
 			case "esc":
-				d.selectionDlg.Hide()
-				return d, nil
+				return d, d.cancelAction()
 			}
 			return d, nil
 		}
 
+		// Esc abandons an action whose next step is still loading
+		if pending := d.actions.GetPendingAction(); pending != nil && pending.Step == StepLoading && msg.String() == "esc" {
+			return d, d.cancelAction()
+		}
+
 		// Handle global input prompt
 		if d.inputPrompt.Active {
 			switch msg.Type {
 			case tea.KeyEnter:
 				value := d.inputPrompt.Value()
-				action := d.inputPrompt.Action
-				context := d.inputPrompt.Context
 				d.inputPrompt.Hide()
-
-				// Handle action based on type
-				if action == "rename_template" {
-					if oldPath, ok := context.(string); ok {
-						logger.Printf("Renaming template '%s' to '%s'", oldPath, value)
-						return d, renameTemplate(oldPath, value)
-					}
-				} else if action == "copy_template" {
-					if oldPath, ok := context.(string); ok {
-						logger.Printf("Copying template '%s' to '%s'", oldPath, value)
-						return d, copyTemplate(oldPath, value)
-					}
-				} else if action == "new_template" {
-					logger.Printf("Creating new template: %s", value)
-					return d, tea.Batch(
-						createNewTemplate(value),
-						func() tea.Msg {
-							// Refresh templates after a brief delay
-							return RefreshTemplatesMsg{}
-						},
-					)
-				} else if action == "new_folder" {
-					logger.Printf("Creating new folder: %s", value)
-					return d, tea.Batch(
-						createNewFolder(value),
-						func() tea.Msg {
-							// Refresh templates after a brief delay
-							return RefreshTemplatesMsg{}
-						},
-					)
-				} else if action == "assign_work_item" {
-					if workItemID, ok := context.(int); ok {
-						logger.Printf("Assigning work item #%d to '%s'", workItemID, value)
-						return d, assignWorkItem(d.client, workItemID, value)
-					}
-				} else if action == "add_tags" {
-					if workItemID, ok := context.(int); ok {
-						logger.Printf("Adding tags '%s' to work item #%d", value, workItemID)
-						return d, addWorkItemTags(d.client, workItemID, value)
-					}
-				}
-
-				logger.Printf("Input submitted: %s (action: %s)", value, action)
-				return d, nil
+				return d, d.submitAction(value)
 			case tea.KeyEsc:
-				d.inputPrompt.Hide()
-				return d, nil
+				return d, d.cancelAction()
 			default:
 				cmd = d.inputPrompt.Update(msg)
 				return d, cmd
@@ -222,31 +171,54 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Handle global confirmation dialog
 		if d.confirmation.Active {
-			switch msg.String() {
-			case "y", "Y":
-				action := d.confirmation.Action
-				context := d.confirmation.Context
-				d.confirmation.Hide()
-
-				// Handle confirmed action
-				if action == "delete_work_item" {
-					if ctx, ok := context.(ConfirmDeleteWorkItemMsg); ok {
-						logger.Printf("Executing delete for work item #%d with %d children", ctx.WorkItemID, len(ctx.ChildIDs))
-						return d, deleteWorkItemWithChildren(d.client, ctx.WorkItemID, ctx.ChildIDs)
-					}
-				} else if action == "delete_template" {
-					if ctx, ok := context.(ConfirmDeleteTemplateMsg); ok {
-						logger.Printf("Executing delete for template: %s", ctx.Path)
-						return d, deleteTemplate(ctx.Path, ctx.IsDir)
+			// Typed-phrase confirmation: every key goes to the input except Enter/Esc
+			if d.confirmation.RequiresPhrase() {
+				switch msg.Type {
+				case tea.KeyEnter:
+					if !d.confirmation.PhraseMatches() {
+						return d, func() tea.Msg {
+							return NotificationMsg{
+								Message: fmt.Sprintf("Type '%s' exactly to confirm", d.confirmation.Phrase),
+								IsError: true,
+							}
+						}
 					}
+					return d, d.confirmAction()
+				case tea.KeyEsc:
+					return d, d.cancelConfirmation()
+				default:
+					return d, d.confirmation.Update(msg)
 				}
+			}
 
-				logger.Printf("Confirmed action: %s", action)
+			switch msg.String() {
+			case "left", "right", "h", "l", "tab", "shift+tab":
+				d.confirmation.ToggleSelection()
 				return d, nil
+			case "enter":
+				if d.confirmation.YesFocused {
+					return d, d.confirmAction()
+				}
+				return d, d.cancelConfirmation()
+			case "y", "Y":
+				return d, d.confirmAction()
 			case "n", "N", "esc":
-				d.confirmation.Hide()
-				logger.Printf("Cancelled action: %s", d.confirmation.Action)
-				return d, nil
+				return d, d.cancelConfirmation()
+			}
+			return d, nil
+		}
+
+		// The first-run overlay takes all input until dismissed; the help key goes straight to help
+		if d.onboarding.IsVisible() {
+			switch {
+			case msg.String() == "enter" || msg.String() == "esc":
+				d.onboarding.Dismiss()
+			case d.keybinds.Matches(msg, "global", "help"):
+				d.onboarding.Dismiss()
+				d.help.Show(d.tabs[d.currentTab].Name())
+			case d.keybinds.Matches(msg, "global", "quit"):
+				d.onboarding.Dismiss()
+				return d, tea.Quit
 			}
 			return d, nil
 		}
@@ -255,11 +227,11 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if d.keybinds.Matches(msg, "global", "help") {
 			if d.help.IsVisible() {
 				d.help.Hide()
-				logger.Printf("Help hidden")
+				log.Debugf("Help hidden")
 			} else {
 				currentTabName := d.tabs[d.currentTab].Name()
 				d.help.Show(currentTabName)
-				logger.Printf("Help shown for tab: %s", currentTabName)
+				log.Debugf("Help shown for tab: %s", currentTabName)
 			}
 			return d, nil
 		}
@@ -269,6 +241,43 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return d, nil
 		}
 
+		// The message log takes all input until closed
+		if d.messageLog.IsVisible() {
+			switch {
+			case msg.String() == "up" || msg.String() == "k":
+				d.messageLog.ScrollUp()
+			case msg.String() == "down" || msg.String() == "j":
+				d.messageLog.ScrollDown(len(d.notification.History))
+			case msg.String() == "esc" || d.keybinds.Matches(msg, "global", "messages"):
+				d.messageLog.Hide()
+			case d.keybinds.Matches(msg, "global", "quit"):
+				return d, tea.Quit
+			}
+			return d, nil
+		}
+
+		// The command palette and search take all input until closed
+		if d.palette.Active {
+			switch msg.String() {
+			case "up", "ctrl+p":
+				d.palette.MoveUp()
+			case "down", "ctrl+n":
+				d.palette.MoveDown()
+			case "esc":
+				d.palette.Hide()
+			case "enter":
+				command, ok := d.palette.SelectedCommand()
+				d.palette.Hide()
+				if ok {
+					log.Infof("Running '%s' from the command palette", command.Title)
+					return d, command.run(d)
+				}
+			default:
+				return d, d.palette.Update(msg)
+			}
+			return d, nil
+		}
+
 		// Handle quit
 		if d.keybinds.Matches(msg, "global", "quit") {
 			return d, tea.Quit
@@ -277,57 +286,153 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle tab switching
 		if d.keybinds.Matches(msg, "global", "next_tab") {
 			d.currentTab = (d.currentTab + 1) % len(d.tabs)
-			logger.Printf("Switched to tab %d: %s", d.currentTab, d.tabs[d.currentTab].Name())
+			log.Debugf("Switched to tab %d: %s", d.currentTab, d.tabs[d.currentTab].Name())
 			return d, nil
 		}
 		if d.keybinds.Matches(msg, "global", "prev_tab") {
 			d.currentTab = (d.currentTab - 1 + len(d.tabs)) % len(d.tabs)
-			logger.Printf("Switched to tab %d: %s", d.currentTab, d.tabs[d.currentTab].Name())
+			log.Debugf("Switched to tab %d: %s", d.currentTab, d.tabs[d.currentTab].Name())
 			return d, nil
 		}
 
 		// Check if actions can be executed (not during filtering, etc.)
 		if d.actions.CanExecuteAction(d.tabs[d.currentTab]) {
+			// Message log (L key)
+			if d.keybinds.Matches(msg, "global", "messages") {
+				d.count = 0
+				d.messageLog.Toggle()
+				return d, nil
+			}
+
+			// Command palette (ctrl+p)
+			if d.keybinds.Matches(msg, "global", "palette") {
+				d.count = 0
+				return d, d.palette.Show("Command Palette", "Type to search commands, queries and templates", d.paletteCommands())
+			}
+
+			// Search queries, templates and work items (/ key)
+			if d.keybinds.Matches(msg, "global", "search") {
+				d.count = 0
+				return d, d.palette.Show("Search", "Search queries, templates and loaded work items", d.searchResults())
+			}
+
+			// Vim-style movement with count prefixes (5j, 10G, ctrl+d)
+			if cmd, ok := d.handleNavigation(msg); ok {
+				return d, cmd
+			}
+
+			// Handle Queries tab actions
+			if queriesTab, ok := d.tabs[d.currentTab].(*QueriesTab); ok {
+				// Count results in the background (c key)
+				if d.keybinds.Matches(msg, "queries", "count") {
+					return d, queriesTab.countQuery()
+				}
+			}
+
 			// Handle Work Items tab actions
 			if d.tabs[d.currentTab].Name() == "Work Items" {
+				if d.client.ReadOnly() {
+					for _, action := range readOnlyWorkItemActions {
+						if d.keybinds.Matches(msg, "workitems", action) {
+							return d, readOnlyNotification()
+						}
+					}
+				}
+				// New work item (n key)
+				if d.keybinds.Matches(msg, "workitems", "create") {
+					log.Infof("Create action triggered")
+					d.startLoading(ActionCreateWorkItem, nil)
+					return d, loadCreatableTypes(d.client)
+				}
 				if workitemsTab, ok := d.tabs[d.currentTab].(*WorkItemsTab); ok {
 					// Download work item (w key)
 					if d.keybinds.Matches(msg, "workitems", "download") {
-						logger.Printf("Download action triggered")
+						log.Infof("Download action triggered")
 						return d, workitemsTab.handleDownloadAction()
 					}
 					// Edit work item (e key)
 					if d.keybinds.Matches(msg, "workitems", "edit") {
-						logger.Printf("Edit action triggered")
+						log.Infof("Edit action triggered")
 						return d, workitemsTab.handleEditAction()
 					}
 					// Delete work item (d key)
 					if d.keybinds.Matches(msg, "workitems", "delete") {
-						logger.Printf("Delete action triggered")
+						log.Infof("Delete action triggered")
 						return d, workitemsTab.handleDeleteAction()
 					}
 					// Change state (s key)
 					if d.keybinds.Matches(msg, "workitems", "change_state") {
-						logger.Printf("Change state action triggered")
-						if selectionDlg := workitemsTab.handleChangeStateAction(); selectionDlg != nil {
-							d.selectionDlg = selectionDlg
+						log.Infof("Change state action triggered")
+						read, cmd := workitemsTab.handleChangeStateAction()
+						if read != nil {
+							d.startLoading(ActionChangeState, read)
 						}
-						return d, nil
+						return d, cmd
 					}
 					// Assign work item (a key)
 					if d.keybinds.Matches(msg, "workitems", "assign") {
-						logger.Printf("Assign action triggered")
+						log.Infof("Assign action triggered")
 						if prompt := workitemsTab.handleAssignAction(); prompt != nil {
-							d.inputPrompt = prompt
+							d.showPrompt(prompt)
 						}
 						return d, nil
 					}
 					// Add tags (t key)
 					if d.keybinds.Matches(msg, "workitems", "add_tags") {
-						logger.Printf("Add tags action triggered")
+						log.Infof("Add tags action triggered")
 						if prompt := workitemsTab.handleAddTagsAction(); prompt != nil {
-							d.inputPrompt = prompt
+							d.showPrompt(prompt)
+						}
+						return d, nil
+					}
+					// Toggle tree view (v key)
+					if d.keybinds.Matches(msg, "workitems", "tree_view") {
+						log.Infof("Tree view toggled")
+						return d, workitemsTab.toggleTreeView()
+					}
+					// Expand/collapse tree node (space key)
+					if d.keybinds.Matches(msg, "workitems", "toggle_node") {
+						return d, workitemsTab.toggleTreeNode()
+					}
+					// Add comment (C key)
+					if d.keybinds.Matches(msg, "workitems", "comment") {
+						log.Infof("Add comment action triggered")
+						cmd, prompt := workitemsTab.handleAddCommentAction()
+						if prompt != nil {
+							d.showPrompt(prompt)
 						}
+						return d, cmd
+					}
+					// Cycle sort order (S key)
+					if d.keybinds.Matches(msg, "workitems", "cycle_sort") {
+						return d, workitemsTab.cycleSort()
+					}
+					// Switch to the next configured view (V key)
+					if d.keybinds.Matches(msg, "workitems", "next_view") {
+						return d, workitemsTab.nextView()
+					}
+					// Toggle recently viewed and edited work items (R key)
+					if d.keybinds.Matches(msg, "workitems", "recent") {
+						return d, workitemsTab.toggleRecent()
+					}
+					// Pin for comparison (p key)
+					if d.keybinds.Matches(msg, "workitems", "compare") {
+						return d, workitemsTab.togglePin()
+					}
+					// Revision history of the detailed work item (H key)
+					if d.keybinds.Matches(msg, "workitems", "history") {
+						return d, workitemsTab.openHistory()
+					}
+					// Details pane below or beside the list (| key), and its size (< and > keys)
+					if d.keybinds.Matches(msg, "workitems", "toggle_split") {
+						return d, workitemsTab.toggleSplit()
+					}
+					if d.keybinds.Matches(msg, "workitems", "grow_details") {
+						workitemsTab.resizeDetails(1)
+						return d, nil
+					}
+					if d.keybinds.Matches(msg, "workitems", "shrink_details") {
+						workitemsTab.resizeDetails(-1)
 						return d, nil
 					}
 				}
@@ -336,45 +441,69 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Handle Templates tab actions
 			if d.tabs[d.currentTab].Name() == "Templates" {
 				if templatesTab, ok := d.tabs[d.currentTab].(*TemplatesTab); ok {
+					// Summary or raw YAML preview (y key)
+					if d.keybinds.Matches(msg, "templates", "raw_yaml") {
+						templatesTab.toggleRawPreview()
+						return d, nil
+					}
+					// Team templates can only be used and copied
+					if templatesTab.selectedIsTeam() && (d.keybinds.Matches(msg, "templates", "edit") ||
+						d.keybinds.Matches(msg, "templates", "rename") ||
+						d.keybinds.Matches(msg, "templates", "move") ||
+						d.keybinds.Matches(msg, "templates", "delete")) {
+						return d, d.notify(templates.ErrTeamReadOnly.Error(), true)
+					}
 					// Edit template (e key)
 					if d.keybinds.Matches(msg, "templates", "edit") {
-						logger.Printf("Edit template action triggered")
+						log.Infof("Edit template action triggered")
 						return d, templatesTab.handleEditAction()
 					}
 					// Rename template (m key)
 					if d.keybinds.Matches(msg, "templates", "rename") {
-						logger.Printf("Rename action triggered")
+						log.Infof("Rename action triggered")
 						if prompt := templatesTab.handleRenameAction(); prompt != nil {
-							d.inputPrompt = prompt
+							d.showPrompt(prompt)
+						}
+						return d, nil
+					}
+					// Move template to another folder (M key)
+					if d.keybinds.Matches(msg, "templates", "move") {
+						path, folders, err := templatesTab.moveTargets()
+						if err != nil {
+							return d, d.notify(err.Error(), true)
+						}
+						if path != "" {
+							d.actions.StartAction(ActionMoveTemplate, path, d.currentTab)
+							d.selectionDlg.Show(fmt.Sprintf("Move '%s' to", path), folders, string(ActionMoveTemplate), path)
 						}
 						return d, nil
 					}
 					// Delete template (d key)
 					if d.keybinds.Matches(msg, "templates", "delete") {
-						logger.Printf("Delete template action triggered")
+						log.Infof("Delete template action triggered")
 						return d, templatesTab.handleDeleteAction()
 					}
 					// Copy template (c key)
 					if d.keybinds.Matches(msg, "templates", "copy") {
-						logger.Printf("Copy template action triggered")
+						log.Infof("Copy template action triggered")
 						if prompt := templatesTab.handleCopyAction(); prompt != nil {
-							d.inputPrompt = prompt
+							d.showPrompt(prompt)
 						}
 						return d, nil
 					}
 					// New template (n key)
 					if d.keybinds.Matches(msg, "templates", "new_template") {
-						logger.Printf("New template action triggered")
+						log.Infof("New template action triggered")
 						if prompt := templatesTab.handleNewTemplateAction(); prompt != nil {
-							d.inputPrompt = prompt
+							d.showPrompt(prompt)
 						}
 						return d, nil
 					}
 					// New folder (f key)
 					if d.keybinds.Matches(msg, "templates", "new_folder") {
-						logger.Printf("New folder action triggered")
+						log.Infof("New folder action triggered")
 						if prompt := templatesTab.handleNewFolderAction(); prompt != nil {
-							d.inputPrompt = prompt
+							d.showPrompt(prompt)
 						}
 						return d, nil
 					}
@@ -387,41 +516,126 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		d.tabs[d.currentTab] = tab
 		return d, cmd
 
-	case NotificationMsg:
-		d.notification.Show(msg.Message, msg.IsError)
-		logger.Printf("Notification: %s (error: %v)", msg.Message, msg.IsError)
+	case WorkItemStatesLoadedMsg:
+		// Ignore states for a state change that was abandoned or replaced
+		pending := d.actions.PendingAt(ActionChangeState, StepLoading)
+		if pending == nil || pending.Context != msg.WorkItem {
+			return d, nil
+		}
+		if msg.Error != nil {
+			d.actions.ClearPendingAction()
+			return d, d.notify(fmt.Sprintf("Failed to load states: %v", msg.Error), true)
+		}
+		if len(msg.States) == 0 {
+			d.actions.ClearPendingAction()
+			log.Infof("No states found for work item type '%s'", msg.WorkItemType)
+			return d, d.notify(fmt.Sprintf("No states found for %s", msg.WorkItemType), true)
+		}
+		d.actions.ContinueAction(pending, StepInput, msg.WorkItem)
+		d.selectionDlg.Show(
+			fmt.Sprintf("Change State for Work Item #%d", msg.WorkItem.ID),
+			msg.States,
+			string(ActionChangeState),
+			msg.WorkItem,
+		)
+		d.selectionDlg.Select(msg.CurrentState)
+		log.Infof("Showing state selection dialog for work item #%d (%d states)", msg.WorkItem.ID, len(msg.States))
+		return d, nil
+
+	case WorkItemTypesLoadedMsg:
+		pending := d.actions.PendingAt(ActionCreateWorkItem, StepLoading)
+		if pending == nil || pending.Context != nil {
+			return d, nil
+		}
+		if msg.Error != nil {
+			d.actions.ClearPendingAction()
+			return d, d.notify(fmt.Sprintf("Failed to load work item types: %v", msg.Error), true)
+		}
+		if len(msg.Types) == 0 {
+			d.actions.ClearPendingAction()
+			return d, d.notify("No work item types found", true)
+		}
+		d.actions.ContinueAction(pending, StepInput, nil)
+		d.selectionDlg.Show("New Work Item: Type", msg.Types, string(ActionCreateWorkItem), nil)
 		return d, nil
 
+	case CreateStepsLoadedMsg:
+		if msg.Template != nil {
+			pending := d.actions.PendingAt(ActionCreateTemplate, StepLoading)
+			if pending == nil || pending.Context != msg.Template {
+				return d, nil
+			}
+			if len(msg.Steps) == 0 {
+				d.actions.ClearPendingAction()
+				return d, executeCreateWorkItemFromTemplate(d.client, msg.Template)
+			}
+			wizard := newCreateWizard(msg.WorkItemType, msg.Steps)
+			wizard.template = msg.Template
+			d.actions.ContinueAction(pending, StepInput, wizard)
+			log.Infof("Asking %d fields before creating from template '%s'", len(msg.Steps), msg.Template.Name)
+			return d, d.showCreateStep(wizard)
+		}
+		pending := d.actions.PendingAt(ActionCreateWorkItem, StepLoading)
+		if pending == nil || pending.Context != msg.WorkItemType {
+			return d, nil
+		}
+		if msg.Error != nil {
+			d.actions.ClearPendingAction()
+			return d, d.notify(fmt.Sprintf("Failed to load fields of %s: %v", msg.WorkItemType, msg.Error), true)
+		}
+		wizard := newCreateWizard(msg.WorkItemType, msg.Steps)
+		d.actions.ContinueAction(pending, StepInput, wizard)
+		log.Infof("Starting create wizard for %s (%d steps)", msg.WorkItemType, len(msg.Steps))
+		return d, d.showCreateStep(wizard)
+
+	case NotificationMsg:
+		log.Infof("Notification: %s (error: %v)", msg.Message, msg.IsError)
+		return d, d.notify(msg.Message, msg.IsError)
+
 	case ClearNotificationMsg:
-		d.notification.Clear()
+		if msg.ID == 0 || msg.ID == d.notificationID {
+			d.notification.Clear()
+		}
 		return d, nil
 
 	case SwitchToTabMsg:
 		if msg.TabIndex >= 0 && msg.TabIndex < len(d.tabs) {
 			d.currentTab = msg.TabIndex
-			logger.Printf("Switched to tab %d: %s", d.currentTab, d.tabs[d.currentTab].Name())
+			log.Debugf("Switched to tab %d: %s", d.currentTab, d.tabs[d.currentTab].Name())
 		}
 		return d, nil
 
-	case ConfirmDeleteWorkItemMsg:
-		// Show confirmation dialog for work item deletion
-		childCount := len(msg.ChildIDs)
-		childText := ""
-		if childCount > 0 {
-			childText = fmt.Sprintf(" and its %d child task(s)", childCount)
+	case ConfirmMsg:
+		d.actions.StartAction(ActionType(msg.Action), nil, d.currentTab)
+		d.actions.AdvanceStep(StepConfirm)
+		if msg.Phrase != "" {
+			d.confirmation.ShowWithPhrase(msg.Prompt, msg.Action, msg.OnConfirm, msg.Phrase)
+		} else {
+			d.confirmation.Show(msg.Prompt, msg.Action, msg.OnConfirm)
 		}
+		log.Infof("Showing confirmation: %s", msg.Action)
+		return d, nil
 
-		d.confirmation.Show(
-			fmt.Sprintf("Delete work item #%d: '%s'%s?", msg.WorkItemID, msg.Title, childText),
-			"delete_work_item",
-			msg, // Store context for when user confirms
-		)
-		logger.Printf("Showing delete confirmation for work item #%d with %d children", msg.WorkItemID, childCount)
+	case spinner.TickMsg:
+		// The Work Items tab's loading spinner keeps turning while another tab is shown
+		if len(d.tabs) > 1 {
+			tab, cmd := d.tabs[1].Update(msg)
+			d.tabs[1] = tab
+			return d, cmd
+		}
 		return d, nil
 
-	case WorkItemsLoadedMsg, QueryExecutedMsg, WorkItemDeletedMsg, WorkItemCreatedMsg:
+	case AutoRefreshMsg:
+		// Hold off while the user is in a dialog, the palette or a list filter
+		msg.Busy = d.palette.Active || !d.actions.CanExecuteAction(d.tabs[1])
+		tab, cmd := d.tabs[1].Update(msg)
+		d.tabs[1] = tab
+		return d, cmd
+
+	case WorkItemsLoadedMsg, QueryExecutedMsg, WorkItemDeletedMsg, WorkItemCreatedMsg, WorkItemTreeLoadedMsg,
+		WorkItemCommentsLoadedMsg, CommentAddedMsg, WorkItemHistoryLoadedMsg:
 		// Route work item messages to Work Items tab (index 1)
-		logger.Printf("Routing work item message to Work Items tab")
+		log.Debugf("Routing work item message to Work Items tab")
 		if len(d.tabs) > 1 {
 			tab, cmd := d.tabs[1].Update(msg)
 			d.tabs[1] = tab
@@ -440,7 +654,7 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				// Switch to Work Items tab (index 1)
 				d.currentTab = 1
-				logger.Printf("Switched to Work Items tab after creation")
+				log.Infof("Switched to Work Items tab after creation")
 
 				cmds = append(cmds, func() tea.Msg {
 					return NotificationMsg{
@@ -453,9 +667,9 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return d, tea.Batch(cmds...)
 
-	case QueriesLoadedMsg:
+	case QueriesLoadedMsg, QueryChildrenLoadedMsg, QueryDetailsLoadedMsg, QueryCountedMsg:
 		// Route queries messages to Queries tab (index 0)
-		logger.Printf("Routing queries message to Queries tab")
+		log.Debugf("Routing queries message to Queries tab")
 		if len(d.tabs) > 0 {
 			tab, cmd := d.tabs[0].Update(msg)
 			d.tabs[0] = tab
@@ -465,7 +679,7 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case TemplatesLoadedMsg:
 		// Route templates messages to Templates tab (index 2)
-		logger.Printf("Routing templates message to Templates tab")
+		log.Debugf("Routing templates message to Templates tab")
 		if len(d.tabs) > 2 {
 			tab, cmd := d.tabs[2].Update(msg)
 			d.tabs[2] = tab
@@ -473,10 +687,20 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return d, tea.Batch(cmds...)
 
+	case ActivityLoadedMsg:
+		// Route activity messages to Activity tab (index 3)
+		log.Debugf("Routing activity message to Activity tab")
+		if len(d.tabs) > 3 {
+			tab, cmd := d.tabs[3].Update(msg)
+			d.tabs[3] = tab
+			cmds = append(cmds, cmd)
+		}
+		return d, tea.Batch(cmds...)
+
 	case TemplateRenamedMsg:
 		// Show notification and refresh templates
 		if msg.Error != nil {
-			logger.Printf("Failed to rename template: %v", msg.Error)
+			log.Errorf("Failed to rename template: %v", msg.Error)
 			return d, func() tea.Msg {
 				return NotificationMsg{
 					Message: fmt.Sprintf("Failed to rename: %v", msg.Error),
@@ -485,7 +709,7 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		logger.Printf("Template renamed successfully")
+		log.Infof("Template renamed successfully")
 
 		// Show success notification
 		cmds = append(cmds, func() tea.Msg {
@@ -504,20 +728,25 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return d, tea.Batch(cmds...)
 
-	case ConfirmDeleteTemplateMsg:
-		// Show confirmation dialog for template deletion
-		d.confirmation.Show(
-			msg.Prompt,
-			"delete_template",
-			msg,
-		)
-		logger.Printf("Showing delete confirmation for template: %s", msg.Path)
-		return d, nil
+	case TemplateMovedMsg:
+		if msg.Error != nil {
+			return d, d.notify(fmt.Sprintf("Failed to move: %v", msg.Error), true)
+		}
+		cmds = append(cmds, d.notify(fmt.Sprintf("Moved to '%s'", msg.NewPath), false))
+
+		// Refresh templates list, keeping the moved template selected
+		if len(d.tabs) > 2 {
+			if templatesTab, ok := d.tabs[2].(*TemplatesTab); ok {
+				cmds = append(cmds, templatesTab.FetchTemplates())
+			}
+		}
+		d.followTemplate(msg.NewPath)
+		return d, tea.Batch(cmds...)
 
 	case TemplateDeletedMsg:
 		// Show notification and refresh templates
 		if msg.Error != nil {
-			logger.Printf("Failed to delete template: %v", msg.Error)
+			log.Errorf("Failed to delete template: %v", msg.Error)
 			return d, func() tea.Msg {
 				return NotificationMsg{
 					Message: fmt.Sprintf("Failed to delete: %v", msg.Error),
@@ -526,7 +755,7 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		logger.Printf("Template deleted successfully")
+		log.Infof("Template deleted successfully")
 
 		// Show success notification
 		cmds = append(cmds, func() tea.Msg {
@@ -548,7 +777,7 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case TemplateCopiedMsg:
 		// Show notification and refresh templates
 		if msg.Error != nil {
-			logger.Printf("Failed to copy template: %v", msg.Error)
+			log.Errorf("Failed to copy template: %v", msg.Error)
 			return d, func() tea.Msg {
 				return NotificationMsg{
 					Message: fmt.Sprintf("Failed to copy: %v", msg.Error),
@@ -557,7 +786,7 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		logger.Printf("Template copied successfully")
+		log.Infof("Template copied successfully")
 
 		// Show success notification
 		cmds = append(cmds, func() tea.Msg {
@@ -589,7 +818,7 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case RefreshTemplatesMsg:
 		// Refresh templates list
-		logger.Printf("Refreshing templates list")
+		log.Infof("Refreshing templates list")
 		if len(d.tabs) > 2 {
 			if templatesTab, ok := d.tabs[2].(*TemplatesTab); ok {
 				return d, templatesTab.FetchTemplates()
@@ -599,70 +828,79 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case OpenEditorForTemplateMsg:
 		// Open editor to edit template file
-		logger.Printf("Opening editor for template: %s", msg.FilePath)
+		log.Infof("Opening editor for template: %s", msg.FilePath)
 
-		// Get editor from environment
-		editor := os.Getenv("EDITOR")
-		if editor == "" {
-			editor = "vi" // fallback to vi
-		}
-
-		// Create command to open editor
-		c := exec.Command(editor, msg.FilePath)
+		c := editor.Command(msg.FilePath)
 
 		// Return tea.ExecProcess to suspend TUI and run editor
 		return d, tea.ExecProcess(c, func(err error) tea.Msg {
 			if err != nil {
-				logger.Printf("Editor error: %v", err)
+				log.Errorf("Editor error: %v", err)
 				return NotificationMsg{
 					Message: fmt.Sprintf("Editor error: %v", err),
 					IsError: true,
 				}
 			}
-			logger.Printf("Editor closed for template")
+			log.Infof("Editor closed for template")
 			// Refresh templates list after edit
 			return RefreshTemplatesMsg{}
 		})
 
 	case OpenEditorMsg:
 		// Open editor to edit work item
-		logger.Printf("Opening editor for work item #%d at %s", msg.WorkItemID, msg.FilePath)
-
-		// Get editor from environment
-		editor := os.Getenv("EDITOR")
-		if editor == "" {
-			editor = "vi" // fallback to vi
-		}
+		log.Infof("Opening editor for work item #%d at %s", msg.WorkItemID, msg.FilePath)
 
-		// Create command to open editor
-		c := exec.Command(editor, msg.FilePath)
+		c := editor.Command(msg.FilePath)
 
 		// Return tea.ExecProcess to suspend TUI and run editor
 		return d, tea.ExecProcess(c, func(err error) tea.Msg {
 			if err != nil {
-				logger.Printf("Editor error: %v", err)
+				log.Errorf("Editor error: %v", err)
 				return NotificationMsg{
 					Message: fmt.Sprintf("Editor error: %v", err),
 					IsError: true,
 				}
 			}
-			logger.Printf("Editor closed, processing edited work item #%d", msg.WorkItemID)
+			log.Infof("Editor closed, processing edited work item #%d", msg.WorkItemID)
 			return ProcessEditedWorkItemMsg{
 				FilePath:   msg.FilePath,
 				WorkItemID: msg.WorkItemID,
+				Rev:        msg.Rev,
+				Original:   msg.Original,
 				Client:     msg.Client,
 			}
 		})
 
+	case OpenCommentEditorMsg:
+		// Compose a comment in the editor, then post it
+		log.Infof("Opening editor for comment on work item #%d", msg.WorkItemID)
+
+		c := editor.Command(msg.FilePath)
+
+		return d, tea.ExecProcess(c, func(err error) tea.Msg {
+			if err != nil {
+				log.Errorf("Editor error: %v", err)
+				return NotificationMsg{
+					Message: fmt.Sprintf("Editor error: %v", err),
+					IsError: true,
+				}
+			}
+			return postCommentFromFile(d.client, msg.FilePath, msg.WorkItemID)()
+		})
+
 	case ProcessEditedWorkItemMsg:
 		// Process the edited work item after editor closes
-		logger.Printf("Processing edited work item #%d", msg.WorkItemID)
-		return d, processEditedWorkItem(msg.FilePath, msg.WorkItemID, msg.Client)
+		log.Infof("Processing edited work item #%d", msg.WorkItemID)
+		return d, processEditedWorkItem(msg)
 
 	case CreateWorkItemFromTemplateMsg:
-		// Create work item from template
-		logger.Printf("Creating work item from template: %s", msg.Template.Name)
-		return d, executeCreateWorkItemFromTemplate(d.client, msg.Template)
+		if d.client.ReadOnly() {
+			return d, readOnlyNotification()
+		}
+		// Ask for the title and missing required fields, then create it
+		log.Infof("Creating work item from template: %s", msg.Template.Name)
+		d.startLoading(ActionCreateTemplate, msg.Template)
+		return d, loadTemplateSteps(d.client, msg.Template)
 
 	default:
 		// Route all other messages to the active tab
@@ -674,6 +912,195 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return d, tea.Batch(cmds...)
 }
 
+// readOnlyWorkItemActions are the Work Items tab actions that change work items
+var readOnlyWorkItemActions = []string{"create", "edit", "delete", "change_state", "assign", "add_tags", "comment"}
+
+// readOnlyNotification explains why an action did nothing in read-only mode
+func readOnlyNotification() tea.Cmd {
+	return func() tea.Msg {
+		return NotificationMsg{
+			Message: "Read-only mode: changes to work items are disabled",
+			IsError: true,
+		}
+	}
+}
+
+// confirmAction hides the confirmation dialog and executes the confirmed action
+func (d *Dashboard) confirmAction() tea.Cmd {
+	onConfirm := d.confirmation.OnConfirm
+	d.confirmation.OnConfirm = nil
+	d.confirmation.Hide()
+	d.actions.ClearPendingAction()
+
+	log.Infof("Confirmed action: %s", d.confirmation.Action)
+	return onConfirm
+}
+
+// notify shows a notification and returns the timer that dismisses it
+func (d *Dashboard) notify(message string, isError bool) tea.Cmd {
+	d.notification.Show(message, isError)
+	d.notificationID++
+
+	timeout := notificationTimeout
+	if isError {
+		timeout = errorNotificationTimeout
+	}
+	if timeout <= 0 {
+		return nil
+	}
+	id := d.notificationID
+	return tea.Tick(timeout, func(time.Time) tea.Msg {
+		return ClearNotificationMsg{ID: id}
+	})
+}
+
+// cancelConfirmation hides the confirmation dialog without executing the action
+func (d *Dashboard) cancelConfirmation() tea.Cmd {
+	d.confirmation.OnConfirm = nil
+	return d.cancelAction()
+}
+
+// showPrompt shows an input prompt built by a tab and makes its action pending
+func (d *Dashboard) showPrompt(prompt *InputPrompt) {
+	d.inputPrompt = prompt
+	d.actions.StartAction(ActionType(prompt.Action), prompt.Context, d.currentTab)
+}
+
+// startLoading makes an action pending while the data for its first step loads
+func (d *Dashboard) startLoading(actionType ActionType, context interface{}) {
+	d.actions.StartAction(actionType, context, d.currentTab)
+	d.actions.AdvanceStep(StepLoading)
+}
+
+// cancelAction hides the open dialog and drops the pending action
+func (d *Dashboard) cancelAction() tea.Cmd {
+	d.selectionDlg.Hide()
+	d.inputPrompt.Hide()
+	d.confirmation.Hide()
+	if pending := d.actions.GetPendingAction(); pending != nil {
+		log.Infof("Cancelled action: %s", pending.Type)
+	}
+	d.actions.ClearPendingAction()
+	return nil
+}
+
+// submitAction runs the pending action with the value entered or selected for
+// it. Actions with more steps stay pending; the others are done once their
+// command is returned.
+func (d *Dashboard) submitAction(value string) tea.Cmd {
+	pending := d.actions.GetPendingAction()
+	if pending == nil {
+		log.Warnf("Input submitted without a pending action: %s", value)
+		return nil
+	}
+	d.actions.ClearPendingAction()
+	blank := strings.TrimSpace(value) == ""
+
+	switch pending.Type {
+	case ActionChangeState:
+		if read, ok := pending.Context.(*workItemSnapshot); ok {
+			log.Infof("Changing state of work item #%d to '%s'", read.ID, value)
+			return changeWorkItemState(d.client, read, value)
+		}
+	case ActionCreateWorkItem:
+		if wizard, ok := pending.Context.(*createWizard); ok {
+			return d.answerCreateStep(pending, wizard, value)
+		}
+		log.Infof("Loading fields of '%s' for the create wizard", value)
+		d.actions.ContinueAction(pending, StepLoading, value)
+		return loadCreateSteps(d.client, value)
+	case ActionCreateTemplate:
+		if wizard, ok := pending.Context.(*createWizard); ok {
+			return d.answerCreateStep(pending, wizard, value)
+		}
+	case ActionAssign:
+		if read, ok := pending.Context.(*workItemSnapshot); ok && !blank {
+			log.Infof("Assigning work item #%d to '%s'", read.ID, value)
+			return assignWorkItem(d.client, read, value)
+		}
+	case ActionAddTags:
+		if workItemID, ok := pending.Context.(int); ok && !blank {
+			log.Infof("Adding tags '%s' to work item #%d", value, workItemID)
+			return addWorkItemTags(d.client, workItemID, value)
+		}
+	case ActionComment:
+		if workItemID, ok := pending.Context.(int); ok && !blank {
+			return postComment(d.client, workItemID, value)
+		}
+	case ActionRenameTemplate:
+		if oldPath, ok := pending.Context.(string); ok {
+			log.Infof("Renaming template '%s' to '%s'", oldPath, value)
+			return renameTemplate(oldPath, value)
+		}
+	case ActionMoveTemplate:
+		if path, ok := pending.Context.(string); ok {
+			log.Infof("Moving template '%s' to '%s'", path, value)
+			return moveTemplate(path, value)
+		}
+	case ActionCopyTemplate:
+		if oldPath, ok := pending.Context.(string); ok {
+			log.Infof("Copying template '%s' to '%s'", oldPath, value)
+			return copyTemplate(oldPath, value)
+		}
+	case ActionNewTemplate:
+		if blank {
+			break
+		}
+		// New templates go into the folder that was selected
+		folder, _ := pending.Context.(string)
+		name := filepath.Join(folder, strings.TrimSpace(value))
+		log.Infof("Creating new template: %s", name)
+		d.followTemplate(templatePath(name))
+		return tea.Sequence(
+			createNewTemplate(name),
+			func() tea.Msg {
+				// Refresh templates once it is written
+				return RefreshTemplatesMsg{}
+			},
+		)
+	case ActionNewFolder:
+		if blank {
+			break
+		}
+		folder, _ := pending.Context.(string)
+		name := filepath.Join(folder, strings.TrimSpace(value))
+		log.Infof("Creating new folder: %s", name)
+		d.followTemplate(name)
+		return createNewFolder(name)
+	}
+
+	log.Infof("Input submitted: %s (action: %s)", value, pending.Type)
+	return nil
+}
+
+// loadingText describes what a pending action is loading
+func loadingText(pending *PendingAction) string {
+	switch pending.Type {
+	case ActionChangeState:
+		if read, ok := pending.Context.(*workItemSnapshot); ok {
+			return fmt.Sprintf("Loading states for #%d...", read.ID)
+		}
+	case ActionCreateWorkItem:
+		if workItemType, ok := pending.Context.(string); ok {
+			return fmt.Sprintf("Loading fields of %s...", workItemType)
+		}
+		return "Loading work item types..."
+	case ActionCreateTemplate:
+		if template, ok := pending.Context.(*templates.Template); ok {
+			return fmt.Sprintf("Loading fields of %s...", template.Type)
+		}
+	}
+	return "Loading..."
+}
+
+// tabHeight returns the height left for tabs, less the token warning if shown
+func (d *Dashboard) tabHeight() int {
+	if d.tokenWarning != "" {
+		return d.height - 1
+	}
+	return d.height
+}
+
 // View renders the dashboard
 func (d *Dashboard) View() string {
 	if d.err != nil {
@@ -686,22 +1113,35 @@ func (d *Dashboard) View() string {
 		tabNames[i] = tab.Name()
 	}
 
+	header := RenderHeader()
+	if d.client.ReadOnly() {
+		header = lipgloss.JoinHorizontal(lipgloss.Center, header, "  ", WarningStyle.Render("READ-ONLY"))
+	}
+
 	// Render components
-	parts := []string{
-		RenderHeader(),
-		RenderTabBar(tabNames, d.currentTab),
-		d.tabs[d.currentTab].View(),
+	parts := []string{header}
+	if d.tokenWarning != "" {
+		parts = append(parts, WarningStyle.Render("⚠ "+d.tokenWarning))
 	}
+	parts = append(parts, RenderTabBar(tabNames, d.currentTab), d.tabs[d.currentTab].View())
 
 	// Add overlays
 	if d.notification.Visible {
 		parts = append(parts, d.notification.View())
 	}
 
+	if d.count > 0 {
+		parts = append(parts, MutedStyle.Render(fmt.Sprintf("%d", d.count)))
+	}
+
 	if d.inputPrompt.Active {
 		parts = append(parts, d.inputPrompt.View())
 	}
 
+	if pending := d.actions.GetPendingAction(); pending != nil && pending.Step == StepLoading {
+		parts = append(parts, RenderLoading(loadingText(pending)+" (esc to cancel)"))
+	}
+
 	if d.selectionDlg.Active {
 		parts = append(parts, d.selectionDlg.View())
 	}
@@ -717,15 +1157,41 @@ func (d *Dashboard) View() string {
 		return d.help.View(d.width, d.height)
 	}
 
+	if d.messageLog.IsVisible() {
+		return d.messageLog.View(d.notification.History, d.width, d.height)
+	}
+
+	if d.palette.Active {
+		return d.palette.View(d.width, d.height)
+	}
+
+	if d.onboarding.IsVisible() {
+		return d.onboarding.View(d.width, d.height)
+	}
+
 	return mainView
 }
 
 // Run starts the dashboard TUI
-func Run(client *api.Client) error {
+func Run(client api.APIClient) error {
+	// Logging to stderr would draw over the dashboard, so log to tui.log unless --log-file was given
+	if configDir, err := config.EnsureConfigDir(); err == nil {
+		if err := log.UseFile(filepath.Join(configDir, "tui.log")); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
 	dashboard := NewDashboard(client)
 
 	p := tea.NewProgram(dashboard, tea.WithAltScreen())
 
+	// Show throttling and retries as notifications instead of failing silently
+	api.SetThrottleHandler(func(event api.ThrottleEvent) {
+		log.Infof("%s %s: %s", event.Method, event.URL, event)
+		p.Send(NotificationMsg{Message: event.String(), IsError: false})
+	})
+	defer api.SetThrottleHandler(nil)
+
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("error running dashboard: %w", err)
 	}
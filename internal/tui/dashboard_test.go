@@ -0,0 +1,306 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/api/apitest"
+)
+
+// newTestDashboard returns a dashboard with its dialogs but without tabs
+func newTestDashboard(client api.APIClient) *Dashboard {
+	return &Dashboard{
+		client:       client,
+		notification: NewNotification("", false),
+		inputPrompt:  NewInputPrompt(),
+		selectionDlg: NewSelectionDialog(),
+		confirmation: NewConfirmationDialog(),
+		actions:      NewActionController(nil),
+	}
+}
+
+func TestDashboardWorkItemStatesLoaded(t *testing.T) {
+	item42, item7 := &workItemSnapshot{ID: 42, Rev: 3}, &workItemSnapshot{ID: 7, Rev: 1}
+	tests := []struct {
+		name        string
+		loadingFor  *workItemSnapshot
+		msg         WorkItemStatesLoadedMsg
+		wantDialog  bool
+		wantError   bool
+		wantCleared bool
+	}{
+		{"opens dialog", item42, WorkItemStatesLoadedMsg{WorkItem: item42, CurrentState: "Active", States: []string{"New", "Active"}}, true, false, true},
+		{"abandoned", nil, WorkItemStatesLoadedMsg{WorkItem: item42, States: []string{"New"}}, false, false, true},
+		{"replaced by another item", item7, WorkItemStatesLoadedMsg{WorkItem: item42, States: []string{"New"}}, false, false, false},
+		{"error", item42, WorkItemStatesLoadedMsg{WorkItem: item42, Error: errors.New("timeout")}, false, true, true},
+		{"no states", item42, WorkItemStatesLoadedMsg{WorkItem: item42, WorkItemType: "Task"}, false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDashboard(nil)
+			if tt.loadingFor != nil {
+				d.startLoading(ActionChangeState, tt.loadingFor)
+			}
+			d.Update(tt.msg)
+
+			if d.selectionDlg.Active != tt.wantDialog {
+				t.Errorf("selection dialog active = %v, want %v", d.selectionDlg.Active, tt.wantDialog)
+			}
+			if tt.wantDialog && d.selectionDlg.Context != item42 {
+				t.Errorf("selection dialog context = %v, want #42 at rev 3", d.selectionDlg.Context)
+			}
+			if tt.wantDialog && d.selectionDlg.SelectedValue() != tt.msg.CurrentState {
+				t.Errorf("selected state = %q, want the current state %q", d.selectionDlg.SelectedValue(), tt.msg.CurrentState)
+			}
+			if got := d.notification.Visible && d.notification.IsError; got != tt.wantError {
+				t.Errorf("error notification = %v, want %v", got, tt.wantError)
+			}
+			if loading := d.actions.PendingAt(ActionChangeState, StepLoading) != nil; loading == tt.wantCleared {
+				t.Errorf("still loading = %v, want cleared %v", loading, tt.wantCleared)
+			}
+		})
+	}
+}
+
+func TestDashboardWorkItemDialogsUpdateWorkItem(t *testing.T) {
+	tests := []struct {
+		name       string
+		open       func(d *Dashboard)
+		wantFields map[string]interface{}
+	}{
+		{
+			name: "change state",
+			open: func(d *Dashboard) {
+				read := &workItemSnapshot{ID: 42, Rev: 3}
+				d.actions.StartAction(ActionChangeState, read, 1)
+				d.selectionDlg.Show("Change State", []string{"New", "Active"}, string(ActionChangeState), read)
+				d.selectionDlg.MoveDown()
+			},
+			wantFields: map[string]interface{}{"System.State": "Active"},
+		},
+		{
+			name: "assign",
+			open: func(d *Dashboard) {
+				prompt := NewInputPrompt()
+				prompt.Show("Assign", "", string(ActionAssign), &workItemSnapshot{ID: 42, Rev: 3})
+				prompt.Input.SetValue("jane@contoso.com")
+				d.showPrompt(prompt)
+			},
+			wantFields: map[string]interface{}{"System.AssignedTo": "jane@contoso.com"},
+		},
+		{
+			name: "blank assignee is ignored",
+			open: func(d *Dashboard) {
+				prompt := NewInputPrompt()
+				prompt.Show("Assign", "", string(ActionAssign), &workItemSnapshot{ID: 42, Rev: 3})
+				prompt.Input.SetValue("  ")
+				d.showPrompt(prompt)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := apitest.New()
+			var gotFields map[string]interface{}
+			client.UpdateWorkItemAtRevisionFunc = func(id, rev int, fields map[string]interface{}) (*workitemtracking.WorkItem, error) {
+				if id != 42 || rev != 3 {
+					t.Errorf("UpdateWorkItemAtRevision() id = %d, rev = %d, want 42 at rev 3", id, rev)
+				}
+				gotFields = fields
+				return &workitemtracking.WorkItem{Id: &id}, nil
+			}
+			d := newTestDashboard(client)
+			tt.open(d)
+
+			_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			if d.selectionDlg.Active || d.inputPrompt.Active {
+				t.Error("dialog still open after enter")
+			}
+			if d.actions.GetPendingAction() != nil {
+				t.Errorf("pending action = %+v, want none after enter", d.actions.GetPendingAction())
+			}
+			if (cmd != nil) != (tt.wantFields != nil) {
+				t.Fatalf("Update() cmd = %v, want a command %v", cmd != nil, tt.wantFields != nil)
+			}
+			if cmd == nil {
+				return
+			}
+			if msg, ok := cmd().(WorkItemUpdatedMsg); !ok || msg.Error != nil {
+				t.Fatalf("command returned %+v, want WorkItemUpdatedMsg", msg)
+			}
+			for field, want := range tt.wantFields {
+				if gotFields[field] != want {
+					t.Errorf("UpdateWorkItemAtRevision() %s = %v, want %v", field, gotFields[field], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDashboardNotificationDismiss(t *testing.T) {
+	d := newTestDashboard(nil)
+
+	_, first := d.Update(NotificationMsg{Message: "Saved"})
+	if first == nil {
+		t.Fatal("expected a dismiss timer for the notification")
+	}
+	d.Update(NotificationMsg{Message: "Failed", IsError: true})
+
+	// The first notification's timer must not clear the one that replaced it
+	d.Update(ClearNotificationMsg{ID: 1})
+	if !d.notification.Visible || d.notification.Message != "Failed" {
+		t.Fatalf("notification = %q (visible %v), want the error still shown", d.notification.Message, d.notification.Visible)
+	}
+	d.Update(ClearNotificationMsg{ID: 2})
+	if d.notification.Visible {
+		t.Error("notification still visible after its timer fired")
+	}
+
+	SetNotificationTimeouts(0, 0)
+	defer SetNotificationTimeouts(DefaultNotificationTimeout, DefaultErrorTimeout)
+	if _, cmd := d.Update(NotificationMsg{Message: "Saved"}); cmd != nil {
+		t.Error("expected no dismiss timer with a timeout of 0")
+	}
+}
+
+func TestDashboardConfirmMsg(t *testing.T) {
+	type confirmedMsg struct{}
+	tests := []struct {
+		name    string
+		msg     ConfirmMsg
+		keys    []tea.KeyMsg
+		wantRun bool
+	}{
+		{
+			name:    "confirmed",
+			msg:     ConfirmMsg{Prompt: "Close 3 work items?", Action: "bulk_close"},
+			keys:    []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("y")}},
+			wantRun: true,
+		},
+		{
+			name: "cancelled",
+			msg:  ConfirmMsg{Prompt: "Close 3 work items?", Action: "bulk_close"},
+			keys: []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("n")}},
+		},
+		{
+			name:    "typed phrase",
+			msg:     ConfirmMsg{Prompt: "Delete folder 'bugs'?", Action: "delete_template", Phrase: "ok"},
+			keys:    []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("o")}, {Type: tea.KeyRunes, Runes: []rune("k")}, {Type: tea.KeyEnter}},
+			wantRun: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDashboard(nil)
+			tt.msg.OnConfirm = func() tea.Msg { return confirmedMsg{} }
+			d.Update(tt.msg)
+			if !d.confirmation.Active {
+				t.Fatal("confirmation dialog not shown")
+			}
+
+			var cmd tea.Cmd
+			for _, key := range tt.keys {
+				_, cmd = d.Update(key)
+			}
+			if d.confirmation.Active || d.actions.GetPendingAction() != nil {
+				t.Error("confirmation still pending")
+			}
+			ran := cmd != nil
+			if ran {
+				_, ran = cmd().(confirmedMsg)
+			}
+			if ran != tt.wantRun {
+				t.Errorf("OnConfirm ran = %v, want %v", ran, tt.wantRun)
+			}
+		})
+	}
+}
+
+func TestDashboardCreateWizardFlow(t *testing.T) {
+	client := apitest.New()
+	var created map[string]interface{}
+	client.CreateWorkItemFunc = func(workItemType string, fields map[string]interface{}, parentID int) (*workitemtracking.WorkItem, error) {
+		created = fields
+		id := 7
+		return &workitemtracking.WorkItem{Id: &id}, nil
+	}
+	d := newTestDashboard(client)
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	d.startLoading(ActionCreateWorkItem, nil)
+	d.Update(WorkItemTypesLoadedMsg{Types: []string{"Bug", "Task"}})
+	d.selectionDlg.MoveDown()
+	d.Update(enter)
+	if pending := d.actions.PendingAt(ActionCreateWorkItem, StepLoading); pending == nil || pending.Context != "Task" {
+		t.Fatalf("pending action = %+v, want the fields of Task loading", d.actions.GetPendingAction())
+	}
+
+	// Fields of a type that is no longer being created are ignored
+	d.Update(CreateStepsLoadedMsg{WorkItemType: "Bug", Steps: []createStep{{Field: "System.Title", Name: "Title", Required: true}}})
+	if d.inputPrompt.Active {
+		t.Fatal("wizard started for the wrong type")
+	}
+
+	d.Update(CreateStepsLoadedMsg{WorkItemType: "Task", Steps: []createStep{
+		{Field: "System.Title", Name: "Title", Required: true},
+		{Field: "System.Tags", Name: "Tags"},
+	}})
+	d.inputPrompt.Input.SetValue("Write docs")
+	d.Update(enter)
+	if !d.inputPrompt.Active || d.actions.GetPendingAction() == nil {
+		t.Fatal("expected the next wizard step to be asked")
+	}
+
+	_, cmd := d.Update(enter)
+	if cmd == nil {
+		t.Fatal("expected the work item to be created after the last step")
+	}
+	if _, ok := cmd().(WorkItemCreatedMsg); !ok || created["System.Title"] != "Write docs" {
+		t.Errorf("created fields = %v, want the title from the wizard", created)
+	}
+	if d.actions.GetPendingAction() != nil {
+		t.Error("action still pending after the work item was created")
+	}
+}
+
+func TestDashboardEscCancelsPendingAction(t *testing.T) {
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+	tests := []struct {
+		name  string
+		start func(d *Dashboard)
+	}{
+		{"loading", func(d *Dashboard) { d.startLoading(ActionChangeState, &workItemSnapshot{ID: 42}) }},
+		{"selection", func(d *Dashboard) {
+			read := &workItemSnapshot{ID: 42}
+			d.actions.StartAction(ActionChangeState, read, 1)
+			d.selectionDlg.Show("Change State", []string{"New"}, string(ActionChangeState), read)
+		}},
+		{"input", func(d *Dashboard) {
+			prompt := NewInputPrompt()
+			prompt.Show("Assign", "", string(ActionAssign), &workItemSnapshot{ID: 42})
+			d.showPrompt(prompt)
+		}},
+		{"confirmation", func(d *Dashboard) { d.Update(ConfirmMsg{Prompt: "Delete?", Action: "delete_work_item"}) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDashboard(nil)
+			tt.start(d)
+			d.Update(esc)
+			if d.actions.GetPendingAction() != nil {
+				t.Errorf("pending action = %+v, want none after esc", d.actions.GetPendingAction())
+			}
+			if d.selectionDlg.Active || d.inputPrompt.Active || d.confirmation.Active {
+				t.Error("dialog still shown after esc")
+			}
+		})
+	}
+}
@@ -1,14 +1,87 @@
 package tui
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"gopkg.in/yaml.v3"
+
+	"github.com/SOMUCHDOG/azb/internal/log"
 )
 
+// defaultKeybindsYAML is written to keybinds.yaml when it does not exist
+const defaultKeybindsYAML = `# Azure Boards CLI Keybindings
+# Customize keybindings for the TUI dashboard
+# Multiple keys can be bound to the same action
+
+global:
+  quit: ["q", "ctrl+c"]
+  help: ["?"]
+  next_tab: ["tab"]
+  prev_tab: ["shift+tab"]
+  refresh: ["r"]
+  messages: ["L"]          # Show recent notifications and errors
+  palette: ["ctrl+p"]      # Search and run any action
+  search: ["/"]            # Search queries, templates and work items
+  filter: ["F"]            # Filter the list of the current tab
+  down: ["j", "down"]      # Move down; a count such as 5j moves 5 lines
+  up: ["k", "up"]          # Move up
+  top: ["g", "home"]       # Go to the top; with a count, to that line (5g)
+  bottom: ["G", "end"]     # Go to the bottom; with a count, to that line (5G)
+  half_page_down: ["ctrl+d"]
+  half_page_up: ["ctrl+u"]
+
+queries:
+  execute: ["enter"]
+  count: ["c"]             # Run the query in the background and show its result count
+  expand_all: ["E"]
+  collapse_all: ["C"]
+
+work_items:
+  details: ["enter"]
+  download: ["w"]          # Download work item as YAML template
+  edit: ["e"]              # Edit work item in $EDITOR
+  delete: ["d"]            # Delete work item (with confirmation)
+  create: ["n"]            # Create new work item
+  change_state: ["s"]      # Change work item state
+  assign: ["a"]            # Assign to user
+  add_tags: ["t"]          # Add tags
+  tree_view: ["v"]         # Toggle flat list / hierarchy tree
+  toggle_node: [" "]       # Expand/collapse node in tree view
+  comment: ["C"]           # Add a comment ($EDITOR, or inline if unset)
+  cycle_sort: ["S"]        # Cycle sort order (columns are set in columns.yaml)
+  next_view: ["V"]         # Switch to the next view configured under dashboard.views
+  recent: ["R"]            # Toggle work items recently viewed or edited with azb
+  compare: ["p"]           # Pin for comparison; pin a second item to compare side by side
+  close_compare: ["esc"]   # Close the comparison (the compare key closes it too)
+  history: ["H"]           # Show revision history of the item in the details view
+  toggle_split: ["|"]      # Show the details pane below or beside the list
+  grow_details: [">"]      # Make the details pane larger
+  shrink_details: ["<"]    # Make the details pane smaller
+
+templates:
+  copy: ["c"]              # Copy template
+  new_template: ["n"]      # Create new template
+  new_folder: ["f"]        # Create new folder
+  edit: ["e"]              # Edit template in $EDITOR
+  rename: ["m"]            # Rename template or folder
+  delete: ["d"]            # Delete template (with confirmation)
+  raw_yaml: ["y"]          # Preview the template's YAML as stored
+  move: ["M"]              # Move template to another folder
+`
+
+// DefaultKeybindConfig returns the default keybindings as configuration
+func DefaultKeybindConfig() KeybindConfig {
+	var config KeybindConfig
+	if err := yaml.Unmarshal([]byte(defaultKeybindsYAML), &config); err != nil {
+		panic(fmt.Sprintf("invalid default keybinds: %v", err))
+	}
+	return config
+}
+
 // KeybindController manages keybindings for all tabs
 type KeybindController struct {
 	global    map[string]key.Binding // Global actions (quit, help, etc.)
@@ -21,28 +94,51 @@ type KeybindController struct {
 // KeybindConfig represents the YAML configuration structure
 type KeybindConfig struct {
 	Global struct {
-		Quit    []string `yaml:"quit"`
-		Help    []string `yaml:"help"`
-		NextTab []string `yaml:"next_tab"`
-		PrevTab []string `yaml:"prev_tab"`
-		Refresh []string `yaml:"refresh"`
+		Quit         []string `yaml:"quit"`
+		Help         []string `yaml:"help"`
+		NextTab      []string `yaml:"next_tab"`
+		PrevTab      []string `yaml:"prev_tab"`
+		Refresh      []string `yaml:"refresh"`
+		Messages     []string `yaml:"messages"`
+		Palette      []string `yaml:"palette"`
+		Search       []string `yaml:"search"`
+		Filter       []string `yaml:"filter"`
+		Down         []string `yaml:"down"`
+		Up           []string `yaml:"up"`
+		Top          []string `yaml:"top"`
+		Bottom       []string `yaml:"bottom"`
+		HalfPageDown []string `yaml:"half_page_down"`
+		HalfPageUp   []string `yaml:"half_page_up"`
 	} `yaml:"global"`
 
 	Queries struct {
 		Execute     []string `yaml:"execute"`
+		Count       []string `yaml:"count"`
 		ExpandAll   []string `yaml:"expand_all"`
 		CollapseAll []string `yaml:"collapse_all"`
 	} `yaml:"queries"`
 
 	WorkItems struct {
-		Details     []string `yaml:"details"`
-		Download    []string `yaml:"download"`
-		Edit        []string `yaml:"edit"`
-		Delete      []string `yaml:"delete"`
-		Create      []string `yaml:"create"`
-		ChangeState []string `yaml:"change_state"`
-		Assign      []string `yaml:"assign"`
-		AddTags     []string `yaml:"add_tags"`
+		Details       []string `yaml:"details"`
+		Download      []string `yaml:"download"`
+		Edit          []string `yaml:"edit"`
+		Delete        []string `yaml:"delete"`
+		Create        []string `yaml:"create"`
+		ChangeState   []string `yaml:"change_state"`
+		Assign        []string `yaml:"assign"`
+		AddTags       []string `yaml:"add_tags"`
+		TreeView      []string `yaml:"tree_view"`
+		ToggleNode    []string `yaml:"toggle_node"`
+		Comment       []string `yaml:"comment"`
+		CycleSort     []string `yaml:"cycle_sort"`
+		NextView      []string `yaml:"next_view"`
+		Recent        []string `yaml:"recent"`
+		Compare       []string `yaml:"compare"`
+		CloseCompare  []string `yaml:"close_compare"`
+		History       []string `yaml:"history"`
+		ToggleSplit   []string `yaml:"toggle_split"`
+		GrowDetails   []string `yaml:"grow_details"`
+		ShrinkDetails []string `yaml:"shrink_details"`
 	} `yaml:"work_items"`
 
 	Templates struct {
@@ -52,6 +148,8 @@ type KeybindConfig struct {
 		Edit        []string `yaml:"edit"`
 		Rename      []string `yaml:"rename"`
 		Delete      []string `yaml:"delete"`
+		RawYAML     []string `yaml:"raw_yaml"`
+		Move        []string `yaml:"move"`
 	} `yaml:"templates"`
 }
 
@@ -67,7 +165,7 @@ func NewKeybindController() *KeybindController {
 	// Always load defaults first, then overlay user config
 	kc.LoadDefaults()
 	if err := kc.LoadConfig(); err != nil {
-		logger.Printf("Failed to load keybinds config: %v, using defaults only", err)
+		log.Warnf("Failed to load keybinds config: %v, using defaults only", err)
 	}
 
 	return kc
@@ -123,12 +221,56 @@ func (kc *KeybindController) LoadDefaults() {
 		key.WithKeys("r"),
 		key.WithHelp("r", "refresh"),
 	)
+	kc.global["messages"] = key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "message log"),
+	)
+	kc.global["palette"] = key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "command palette"),
+	)
+	kc.global["search"] = key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search"),
+	)
+	kc.global["filter"] = key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "filter"),
+	)
+	kc.global["down"] = key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("j", "move down"),
+	)
+	kc.global["up"] = key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("k", "move up"),
+	)
+	kc.global["top"] = key.NewBinding(
+		key.WithKeys("g", "home"),
+		key.WithHelp("g", "go to top"),
+	)
+	kc.global["bottom"] = key.NewBinding(
+		key.WithKeys("G", "end"),
+		key.WithHelp("G", "go to bottom"),
+	)
+	kc.global["half_page_down"] = key.NewBinding(
+		key.WithKeys("ctrl+d"),
+		key.WithHelp("ctrl+d", "half page down"),
+	)
+	kc.global["half_page_up"] = key.NewBinding(
+		key.WithKeys("ctrl+u"),
+		key.WithHelp("ctrl+u", "half page up"),
+	)
 
 	// Queries bindings
 	kc.queries["execute"] = key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "execute query"),
 	)
+	kc.queries["count"] = key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "count results"),
+	)
 	kc.queries["expand_all"] = key.NewBinding(
 		key.WithKeys("E"),
 		key.WithHelp("E", "expand all folders"),
@@ -171,6 +313,54 @@ func (kc *KeybindController) LoadDefaults() {
 		key.WithKeys("t"),
 		key.WithHelp("t", "add tags"),
 	)
+	kc.workitems["tree_view"] = key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "toggle tree view"),
+	)
+	kc.workitems["toggle_node"] = key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "expand/collapse"),
+	)
+	kc.workitems["comment"] = key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "add comment"),
+	)
+	kc.workitems["cycle_sort"] = key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "cycle sort order"),
+	)
+	kc.workitems["next_view"] = key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "next view"),
+	)
+	kc.workitems["recent"] = key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "recent items"),
+	)
+	kc.workitems["compare"] = key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "pin to compare"),
+	)
+	kc.workitems["close_compare"] = key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "close comparison"),
+	)
+	kc.workitems["history"] = key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "show history"),
+	)
+	kc.workitems["toggle_split"] = key.NewBinding(
+		key.WithKeys("|"),
+		key.WithHelp("|", "toggle split"),
+	)
+	kc.workitems["grow_details"] = key.NewBinding(
+		key.WithKeys(">"),
+		key.WithHelp(">", "grow details"),
+	)
+	kc.workitems["shrink_details"] = key.NewBinding(
+		key.WithKeys("<"),
+		key.WithHelp("<", "shrink details"),
+	)
 
 	// Templates bindings
 	kc.templates["copy"] = key.NewBinding(
@@ -197,6 +387,14 @@ func (kc *KeybindController) LoadDefaults() {
 		key.WithKeys("d"),
 		key.WithHelp("d", "delete template"),
 	)
+	kc.templates["raw_yaml"] = key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "toggle YAML preview"),
+	)
+	kc.templates["move"] = key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "move template"),
+	)
 }
 
 // buildBindings converts config to key.Binding objects
@@ -236,6 +434,66 @@ func (kc *KeybindController) buildBindings() {
 			key.WithHelp(kc.config.Global.Refresh[0], "refresh"),
 		)
 	}
+	if len(kc.config.Global.Messages) > 0 {
+		kc.global["messages"] = key.NewBinding(
+			key.WithKeys(kc.config.Global.Messages...),
+			key.WithHelp(kc.config.Global.Messages[0], "message log"),
+		)
+	}
+	if len(kc.config.Global.Palette) > 0 {
+		kc.global["palette"] = key.NewBinding(
+			key.WithKeys(kc.config.Global.Palette...),
+			key.WithHelp(kc.config.Global.Palette[0], "command palette"),
+		)
+	}
+	if len(kc.config.Global.Search) > 0 {
+		kc.global["search"] = key.NewBinding(
+			key.WithKeys(kc.config.Global.Search...),
+			key.WithHelp(kc.config.Global.Search[0], "search"),
+		)
+	}
+	if len(kc.config.Global.Filter) > 0 {
+		kc.global["filter"] = key.NewBinding(
+			key.WithKeys(kc.config.Global.Filter...),
+			key.WithHelp(kc.config.Global.Filter[0], "filter"),
+		)
+	}
+	if len(kc.config.Global.Down) > 0 {
+		kc.global["down"] = key.NewBinding(
+			key.WithKeys(kc.config.Global.Down...),
+			key.WithHelp(kc.config.Global.Down[0], "move down"),
+		)
+	}
+	if len(kc.config.Global.Up) > 0 {
+		kc.global["up"] = key.NewBinding(
+			key.WithKeys(kc.config.Global.Up...),
+			key.WithHelp(kc.config.Global.Up[0], "move up"),
+		)
+	}
+	if len(kc.config.Global.Top) > 0 {
+		kc.global["top"] = key.NewBinding(
+			key.WithKeys(kc.config.Global.Top...),
+			key.WithHelp(kc.config.Global.Top[0], "go to top"),
+		)
+	}
+	if len(kc.config.Global.Bottom) > 0 {
+		kc.global["bottom"] = key.NewBinding(
+			key.WithKeys(kc.config.Global.Bottom...),
+			key.WithHelp(kc.config.Global.Bottom[0], "go to bottom"),
+		)
+	}
+	if len(kc.config.Global.HalfPageDown) > 0 {
+		kc.global["half_page_down"] = key.NewBinding(
+			key.WithKeys(kc.config.Global.HalfPageDown...),
+			key.WithHelp(kc.config.Global.HalfPageDown[0], "half page down"),
+		)
+	}
+	if len(kc.config.Global.HalfPageUp) > 0 {
+		kc.global["half_page_up"] = key.NewBinding(
+			key.WithKeys(kc.config.Global.HalfPageUp...),
+			key.WithHelp(kc.config.Global.HalfPageUp[0], "half page up"),
+		)
+	}
 
 	// Build queries bindings
 	if len(kc.config.Queries.Execute) > 0 {
@@ -244,6 +502,12 @@ func (kc *KeybindController) buildBindings() {
 			key.WithHelp(kc.config.Queries.Execute[0], "execute query"),
 		)
 	}
+	if len(kc.config.Queries.Count) > 0 {
+		kc.queries["count"] = key.NewBinding(
+			key.WithKeys(kc.config.Queries.Count...),
+			key.WithHelp(kc.config.Queries.Count[0], "count results"),
+		)
+	}
 	if len(kc.config.Queries.ExpandAll) > 0 {
 		kc.queries["expand_all"] = key.NewBinding(
 			key.WithKeys(kc.config.Queries.ExpandAll...),
@@ -306,6 +570,78 @@ func (kc *KeybindController) buildBindings() {
 			key.WithHelp(kc.config.WorkItems.AddTags[0], "add tags"),
 		)
 	}
+	if len(kc.config.WorkItems.TreeView) > 0 {
+		kc.workitems["tree_view"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.TreeView...),
+			key.WithHelp(kc.config.WorkItems.TreeView[0], "toggle tree view"),
+		)
+	}
+	if len(kc.config.WorkItems.ToggleNode) > 0 {
+		kc.workitems["toggle_node"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.ToggleNode...),
+			key.WithHelp(kc.config.WorkItems.ToggleNode[0], "expand/collapse"),
+		)
+	}
+	if len(kc.config.WorkItems.Comment) > 0 {
+		kc.workitems["comment"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.Comment...),
+			key.WithHelp(kc.config.WorkItems.Comment[0], "add comment"),
+		)
+	}
+	if len(kc.config.WorkItems.CycleSort) > 0 {
+		kc.workitems["cycle_sort"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.CycleSort...),
+			key.WithHelp(kc.config.WorkItems.CycleSort[0], "cycle sort order"),
+		)
+	}
+	if len(kc.config.WorkItems.NextView) > 0 {
+		kc.workitems["next_view"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.NextView...),
+			key.WithHelp(kc.config.WorkItems.NextView[0], "next view"),
+		)
+	}
+	if len(kc.config.WorkItems.Recent) > 0 {
+		kc.workitems["recent"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.Recent...),
+			key.WithHelp(kc.config.WorkItems.Recent[0], "recent items"),
+		)
+	}
+	if len(kc.config.WorkItems.Compare) > 0 {
+		kc.workitems["compare"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.Compare...),
+			key.WithHelp(kc.config.WorkItems.Compare[0], "pin to compare"),
+		)
+	}
+	if len(kc.config.WorkItems.CloseCompare) > 0 {
+		kc.workitems["close_compare"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.CloseCompare...),
+			key.WithHelp(kc.config.WorkItems.CloseCompare[0], "close comparison"),
+		)
+	}
+	if len(kc.config.WorkItems.History) > 0 {
+		kc.workitems["history"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.History...),
+			key.WithHelp(kc.config.WorkItems.History[0], "show history"),
+		)
+	}
+	if len(kc.config.WorkItems.ToggleSplit) > 0 {
+		kc.workitems["toggle_split"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.ToggleSplit...),
+			key.WithHelp(kc.config.WorkItems.ToggleSplit[0], "toggle split"),
+		)
+	}
+	if len(kc.config.WorkItems.GrowDetails) > 0 {
+		kc.workitems["grow_details"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.GrowDetails...),
+			key.WithHelp(kc.config.WorkItems.GrowDetails[0], "grow details"),
+		)
+	}
+	if len(kc.config.WorkItems.ShrinkDetails) > 0 {
+		kc.workitems["shrink_details"] = key.NewBinding(
+			key.WithKeys(kc.config.WorkItems.ShrinkDetails...),
+			key.WithHelp(kc.config.WorkItems.ShrinkDetails[0], "shrink details"),
+		)
+	}
 
 	// Build templates bindings
 	if len(kc.config.Templates.Copy) > 0 {
@@ -344,54 +680,31 @@ func (kc *KeybindController) buildBindings() {
 			key.WithHelp(kc.config.Templates.Delete[0], "delete template"),
 		)
 	}
+	if len(kc.config.Templates.RawYAML) > 0 {
+		kc.templates["raw_yaml"] = key.NewBinding(
+			key.WithKeys(kc.config.Templates.RawYAML...),
+			key.WithHelp(kc.config.Templates.RawYAML[0], "toggle YAML preview"),
+		)
+	}
+	if len(kc.config.Templates.Move) > 0 {
+		kc.templates["move"] = key.NewBinding(
+			key.WithKeys(kc.config.Templates.Move...),
+			key.WithHelp(kc.config.Templates.Move[0], "move template"),
+		)
+	}
 }
 
 // CreateDefaultConfig creates a default keybinds.yaml file
 func (kc *KeybindController) CreateDefaultConfig(path string) error {
-	defaultConfig := `# Azure Boards CLI Keybindings
-# Customize keybindings for the TUI dashboard
-# Multiple keys can be bound to the same action
-
-global:
-  quit: ["q", "ctrl+c"]
-  help: ["?"]
-  next_tab: ["tab"]
-  prev_tab: ["shift+tab"]
-  refresh: ["r"]
-
-queries:
-  execute: ["enter"]
-  expand_all: ["E"]
-  collapse_all: ["C"]
-
-work_items:
-  details: ["enter"]
-  download: ["w"]          # Download work item as YAML template
-  edit: ["e"]              # Edit work item in $EDITOR
-  delete: ["d"]            # Delete work item (with confirmation)
-  create: ["n"]            # Create new work item
-  change_state: ["s"]      # Change work item state
-  assign: ["a"]            # Assign to user
-  add_tags: ["t"]          # Add tags
-
-templates:
-  copy: ["c"]              # Copy template
-  new_template: ["n"]      # Create new template
-  new_folder: ["f"]        # Create new folder
-  edit: ["e"]              # Edit template in $EDITOR
-  rename: ["m"]            # Rename template or folder
-  delete: ["d"]            # Delete template (with confirmation)
-`
-
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(path, []byte(defaultConfig), 0600); err != nil {
+	if err := os.WriteFile(path, []byte(defaultKeybindsYAML), 0600); err != nil {
 		return err
 	}
 
-	logger.Printf("Created default keybinds config at %s", path)
+	log.Infof("Created default keybinds config at %s", path)
 
 	// Load the defaults into memory
 	kc.LoadDefaults()
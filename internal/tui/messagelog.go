@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MessageLogController manages the message log overlay, which lists the
+// notifications shown since the dashboard started, newest first
+type MessageLogController struct {
+	visible bool
+	offset  int // entries scrolled past from the newest
+}
+
+// NewMessageLogController creates a new message log controller
+func NewMessageLogController() *MessageLogController {
+	return &MessageLogController{}
+}
+
+// Toggle shows or hides the message log, starting at the newest message
+func (mc *MessageLogController) Toggle() {
+	mc.visible = !mc.visible
+	mc.offset = 0
+}
+
+// Hide hides the message log
+func (mc *MessageLogController) Hide() {
+	mc.visible = false
+}
+
+// IsVisible returns whether the message log is visible
+func (mc *MessageLogController) IsVisible() bool {
+	return mc.visible
+}
+
+// ScrollDown moves towards older messages
+func (mc *MessageLogController) ScrollDown(entries int) {
+	if mc.offset < entries-1 {
+		mc.offset++
+	}
+}
+
+// ScrollUp moves towards newer messages
+func (mc *MessageLogController) ScrollUp() {
+	if mc.offset > 0 {
+		mc.offset--
+	}
+}
+
+// View renders the message log overlay
+func (mc *MessageLogController) View(history []NotificationEntry, width, height int) string {
+	if !mc.visible {
+		return ""
+	}
+
+	logWidth := min(width-4, 100)
+	lines := []string{TitleStyle.Render(fmt.Sprintf("Messages (%d)", len(history))), ""}
+
+	if len(history) == 0 {
+		lines = append(lines, MutedStyle.Render("No messages yet"))
+	}
+	// Leave room for the title, the footer and the box border
+	room := height - 10
+	for i := len(history) - 1 - mc.offset; i >= 0 && room > 0; i-- {
+		entry := history[i]
+		icon, style := "✓", NotificationSuccessStyle
+		if entry.IsError {
+			icon, style = "✗", NotificationErrorStyle
+		}
+		line := fmt.Sprintf("%s %s %s", MutedStyle.Render(entry.Time.Format("15:04:05")), style.Render(icon), entry.Message)
+		wrapped := lipgloss.NewStyle().Width(logWidth - 4).Render(line)
+		lines = append(lines, wrapped)
+		room -= strings.Count(wrapped, "\n") + 1
+	}
+
+	lines = append(lines, "", MutedStyle.Render("↑/↓ to scroll • L or esc to close"))
+
+	box := BoxStyle.
+		Width(logWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
@@ -13,13 +14,53 @@ type NotificationMsg struct {
 	IsError bool
 }
 
-// ClearNotificationMsg clears the current notification
-type ClearNotificationMsg struct{}
+// ClearNotificationMsg clears the current notification. A non-zero ID only
+// clears the notification it was scheduled for, not one shown after it.
+type ClearNotificationMsg struct {
+	ID int
+}
 
 // WorkItemsLoadedMsg is sent when work items are loaded
 type WorkItemsLoadedMsg struct {
-	WorkItems []workitemtracking.WorkItem
-	Error     error
+	WorkItems  []workitemtracking.WorkItem
+	Resurfaced []string // snoozed work items whose snooze has ended
+	Error      error
+}
+
+// AutoRefreshMsg is sent when the Work Items tab's refresh interval elapses
+type AutoRefreshMsg struct {
+	ID   int  // refresh it belongs to; superseded refreshes are ignored
+	Busy bool // set by the dashboard while the user is in a dialog
+}
+
+// WorkItemStatesLoadedMsg is sent when the states for a state change are loaded
+type WorkItemStatesLoadedMsg struct {
+	WorkItem     *workItemSnapshot // the work item as read when the action started
+	WorkItemType string
+	CurrentState string // selected when the dialog opens
+	States       []string
+	Error        error
+}
+
+// WorkItemTypesLoadedMsg is sent when the types for the create wizard are loaded
+type WorkItemTypesLoadedMsg struct {
+	Types []string
+	Error error
+}
+
+// CreateStepsLoadedMsg is sent when the fields of a work item type are loaded for the create wizard
+type CreateStepsLoadedMsg struct {
+	WorkItemType string
+	Steps        []createStep
+	Template     *templates.Template // set when the steps fill in a template
+	Error        error
+}
+
+// ActivityLoadedMsg is sent when the activity feed is loaded
+type ActivityLoadedMsg struct {
+	Items []activityItem
+	Teams []string // teams whose area paths were searched; empty for the whole project
+	Error error
 }
 
 // QueriesLoadedMsg is sent when queries are loaded
@@ -28,9 +69,60 @@ type QueriesLoadedMsg struct {
 	Error   error
 }
 
+// QueryChildrenLoadedMsg is sent when the children of a query folder are loaded on demand
+type QueryChildrenLoadedMsg struct {
+	FolderPath string
+	Children   []workitemtracking.QueryHierarchyItem
+	Error      error
+}
+
+// QueryDetailsLoadedMsg is sent when a query is loaded with its WIQL for the preview
+type QueryDetailsLoadedMsg struct {
+	Path  string
+	Query *workitemtracking.QueryHierarchyItem
+	Error error
+}
+
+// QueryCountedMsg is sent when a query run in the background returns its result count
+type QueryCountedMsg struct {
+	Path  string
+	Name  string
+	Count int
+	Error error
+}
+
+// WorkItemTreeLoadedMsg is sent when related work items are loaded for the tree view
+type WorkItemTreeLoadedMsg struct {
+	ParentID  int // Work item whose children were loaded, or 0 for the parents of the listed items
+	WorkItems []workitemtracking.WorkItem
+	Error     error
+}
+
+// WorkItemCommentsLoadedMsg is sent when the comment thread of a work item is loaded
+type WorkItemCommentsLoadedMsg struct {
+	WorkItemID int
+	Comments   []workitemtracking.Comment
+	Error      error
+}
+
+// WorkItemHistoryLoadedMsg is sent when the revision history of a work item is loaded
+type WorkItemHistoryLoadedMsg struct {
+	WorkItemID int
+	Revisions  []api.WorkItemRevision
+	Error      error
+}
+
+// CommentAddedMsg is sent when a comment has been posted to a work item
+type CommentAddedMsg struct {
+	WorkItemID int
+	Warnings   []string // @mentions that were left as text
+	Error      error
+}
+
 // TemplatesLoadedMsg is sent when templates are loaded
 type TemplatesLoadedMsg struct {
 	Templates []*templates.TemplateNode
+	Problems  []templates.Problem // entries that could not be read
 	Error     error
 }
 
@@ -91,30 +183,49 @@ type TemplateRenamedMsg struct {
 	Error   error
 }
 
+// TemplateMovedMsg is sent when a template is moved to another folder
+type TemplateMovedMsg struct {
+	OldPath string
+	NewPath string
+	Error   error
+}
+
 // SwitchToTabMsg is sent to switch to a specific tab
 type SwitchToTabMsg struct {
 	TabIndex int
 }
 
-// ConfirmDeleteWorkItemMsg is sent to request confirmation for deleting a work item
-type ConfirmDeleteWorkItemMsg struct {
-	WorkItemID int
-	Title      string
-	ChildIDs   []int
+// ConfirmMsg is sent to ask the user to confirm an action before running it.
+// Any tab can send it; the dashboard shows the dialog and runs OnConfirm.
+type ConfirmMsg struct {
+	Prompt    string
+	Action    string  // Names the action in the log
+	Phrase    string  // When set, the user must type this phrase, e.g. for bulk deletes
+	OnConfirm tea.Cmd // Runs when the user confirms
 }
 
 // OpenEditorMsg is sent to open an editor for a work item
 type OpenEditorMsg struct {
 	FilePath   string
 	WorkItemID int
-	Client     *api.Client
+	Rev        int    // revision the work item was read at
+	Original   []byte // YAML as written, to find what was changed
+	Client     api.APIClient
+}
+
+// OpenCommentEditorMsg is sent to compose a work item comment in an editor
+type OpenCommentEditorMsg struct {
+	FilePath   string
+	WorkItemID int
 }
 
 // ProcessEditedWorkItemMsg is sent after the editor closes to process changes
 type ProcessEditedWorkItemMsg struct {
 	FilePath   string
 	WorkItemID int
-	Client     *api.Client
+	Rev        int
+	Original   []byte
+	Client     api.APIClient
 }
 
 // OpenEditorForTemplateMsg is sent to open an editor for a template
@@ -122,14 +233,6 @@ type OpenEditorForTemplateMsg struct {
 	FilePath string
 }
 
-// ConfirmDeleteTemplateMsg is sent to request confirmation for deleting a template
-type ConfirmDeleteTemplateMsg struct {
-	Path   string
-	Name   string
-	IsDir  bool
-	Prompt string
-}
-
 // RefreshTemplatesMsg is sent to trigger a templates list refresh
 type RefreshTemplatesMsg struct{}
 
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxCount caps count prefixes such as 5j
+const maxCount = 999
+
+// handleNavigation handles the vim-style movement keys of the global keybinds
+// and their count prefixes. The movements are replayed to the current tab as
+// arrow, Home and End keys, so every list and scrolling view follows them.
+// It reports whether the key was used.
+func (d *Dashboard) handleNavigation(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// Digits build the count; a leading 0 is left to the tab
+	if digit := msg.String(); len(digit) == 1 && digit[0] >= '0' && digit[0] <= '9' && (digit != "0" || d.count > 0) {
+		d.count = min(d.count*10+int(digit[0]-'0'), maxCount)
+		return nil, true
+	}
+
+	count := d.count
+	d.count = 0
+	times := max(count, 1)
+	tab := d.tabs[d.currentTab]
+
+	var keys []tea.KeyMsg
+	switch {
+	case d.keybinds.Matches(msg, "global", "down"):
+		keys = repeatKey(tea.KeyDown, times)
+	case d.keybinds.Matches(msg, "global", "up"):
+		keys = repeatKey(tea.KeyUp, times)
+	case d.keybinds.Matches(msg, "global", "half_page_down"):
+		keys = repeatKey(tea.KeyDown, times*halfPage(tab))
+	case d.keybinds.Matches(msg, "global", "half_page_up"):
+		keys = repeatKey(tea.KeyUp, times*halfPage(tab))
+	case d.keybinds.Matches(msg, "global", "top"), d.keybinds.Matches(msg, "global", "bottom"):
+		// With a count both go to that line, like 5G in vim
+		if count > 0 {
+			keys = append(repeatKey(tea.KeyHome, 1), repeatKey(tea.KeyDown, count-1)...)
+		} else if d.keybinds.Matches(msg, "global", "top") {
+			keys = repeatKey(tea.KeyHome, 1)
+		} else {
+			keys = repeatKey(tea.KeyEnd, 1)
+		}
+	default:
+		return nil, false
+	}
+
+	var cmds []tea.Cmd
+	for _, key := range keys {
+		var cmd tea.Cmd
+		tab, cmd = tab.Update(key)
+		cmds = append(cmds, cmd)
+	}
+	d.tabs[d.currentTab] = tab
+	return tea.Batch(cmds...), true
+}
+
+// repeatKey returns a key press n times
+func repeatKey(keyType tea.KeyType, n int) []tea.KeyMsg {
+	keys := make([]tea.KeyMsg, n)
+	for i := range keys {
+		keys[i] = tea.KeyMsg{Type: keyType}
+	}
+	return keys
+}
+
+// halfPage returns how many lines half a page of a tab moves
+func halfPage(tab Tab) int {
+	lines := 0
+	switch t := tab.(type) {
+	case *WorkItemsTab:
+		lines = t.list.Paginator.PerPage / 2
+	case *QueriesTab:
+		lines = t.list.Paginator.PerPage / 2
+	case *TemplatesTab:
+		lines = t.list.Paginator.PerPage / 2
+	case *ActivityTab:
+		lines = t.viewport.Height / 2
+	}
+	return max(lines, 1)
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyRecorder is a tab that records the keys it receives
+type keyRecorder struct {
+	keys []string
+}
+
+func (r *keyRecorder) Name() string                   { return "Recorder" }
+func (r *keyRecorder) Init(width, height int) tea.Cmd { return nil }
+func (r *keyRecorder) View() string                   { return "" }
+func (r *keyRecorder) SetSize(width, height int)      {}
+func (r *keyRecorder) GetHelpEntries() []HelpEntry    { return nil }
+func (r *keyRecorder) Update(msg tea.Msg) (Tab, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		r.keys = append(r.keys, key.String())
+	}
+	return r, nil
+}
+
+func TestHandleNavigation(t *testing.T) {
+	press := func(keys string) []tea.KeyMsg {
+		var msgs []tea.KeyMsg
+		for _, key := range strings.Fields(keys) {
+			switch key {
+			case "ctrl+d":
+				msgs = append(msgs, tea.KeyMsg{Type: tea.KeyCtrlD})
+			default:
+				msgs = append(msgs, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+			}
+		}
+		return msgs
+	}
+	tests := []struct {
+		name string
+		keys string
+		want []string
+	}{
+		{"down", "j", []string{"down"}},
+		{"count", "3 k", []string{"up", "up", "up"}},
+		{"two-digit count", "1 2 j", strings.Fields(strings.Repeat("down ", 12))},
+		{"top", "g", []string{"home"}},
+		{"bottom", "G", []string{"end"}},
+		{"line", "3 G", []string{"home", "down", "down"}},
+		{"half page", "ctrl+d", []string{"down"}},
+		{"count reset by other key", "5 x j", []string{"x", "down"}},
+		{"leading zero", "0", []string{"0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &keyRecorder{}
+			d := newTestDashboard(nil)
+			d.keybinds = &KeybindController{
+				global:    map[string]key.Binding{},
+				queries:   map[string]key.Binding{},
+				workitems: map[string]key.Binding{},
+				templates: map[string]key.Binding{},
+			}
+			d.keybinds.LoadDefaults()
+			d.tabs = []Tab{recorder}
+
+			for _, msg := range press(tt.keys) {
+				if _, ok := d.handleNavigation(msg); !ok {
+					recorder.Update(msg)
+				}
+			}
+			if !reflect.DeepEqual(recorder.keys, tt.want) {
+				t.Errorf("keys %q sent %v, want %v", tt.keys, recorder.keys, tt.want)
+			}
+		})
+	}
+}
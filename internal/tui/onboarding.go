@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/SOMUCHDOG/azb/internal/log"
+)
+
+// OnboardingController shows a one-time introduction on the first dashboard launch
+type OnboardingController struct {
+	visible  bool
+	keybinds *KeybindController
+}
+
+// NewOnboardingController creates the controller, visible when no dashboard state was saved yet
+func NewOnboardingController(keybinds *KeybindController) *OnboardingController {
+	oc := &OnboardingController{keybinds: keybinds}
+
+	state, exists, err := LoadUIState()
+	if err != nil {
+		log.Warnf("Failed to load dashboard state: %v", err)
+		return oc
+	}
+	oc.visible = !exists || !state.OnboardingSeen
+	return oc
+}
+
+// IsVisible returns whether the overlay is shown
+func (oc *OnboardingController) IsVisible() bool {
+	return oc.visible
+}
+
+// Dismiss hides the overlay and remembers that it was seen
+func (oc *OnboardingController) Dismiss() {
+	oc.visible = false
+
+	state, _, err := LoadUIState()
+	if err != nil {
+		state = &UIState{}
+	}
+	state.OnboardingSeen = true
+	if err := SaveUIState(state); err != nil {
+		log.Warnf("Failed to save dashboard state: %v", err)
+	}
+}
+
+// keyFor returns the first key bound to an action, or fallback when unbound
+func (oc *OnboardingController) keyFor(scope, action, fallback string) string {
+	if binding, ok := oc.keybinds.GetBinding(scope, action); ok && binding.Help().Key != "" {
+		return binding.Help().Key
+	}
+	return fallback
+}
+
+// View renders the overlay centered on the screen
+func (oc *OnboardingController) View(width, height int) string {
+	if !oc.visible {
+		return ""
+	}
+
+	key := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondary))
+	bold := lipgloss.NewStyle().Bold(true)
+
+	lines := []string{
+		TitleStyle.Render("Welcome to the Azure Boards dashboard"),
+		"",
+		bold.Render("Getting around:"),
+		fmt.Sprintf("  %s / %s  switch between the Work Items, Queries and Templates tabs",
+			key.Render(oc.keyFor("global", "next_tab", "tab")), key.Render(oc.keyFor("global", "prev_tab", "shift+tab"))),
+		fmt.Sprintf("  %s  move through lists,  %s  open the selected item",
+			key.Render("↑/↓"), key.Render("enter")),
+		fmt.Sprintf("  %s  refresh,  %s  quit",
+			key.Render(oc.keyFor("global", "refresh", "r")), key.Render(oc.keyFor("global", "quit", "q"))),
+		"",
+		bold.Render("Help:"),
+		fmt.Sprintf("  Press %s on any tab to see every key for that tab", key.Render(oc.keyFor("global", "help", "?"))),
+		"",
+		bold.Render("Customizing keys:"),
+		"  Edit ~/.azure-boards-cli/keybinds.yaml and restart the dashboard.",
+		"  Run 'azb doctor' if a change doesn't take effect.",
+		"",
+		MutedStyle.Render("Press enter or esc to start. This is shown only once."),
+	}
+
+	content := strings.Join(lines, "\n")
+
+	boxWidth := min(width-4, 76)
+	boxHeight := min(height-4, len(lines)+4)
+
+	box := BoxStyle.
+		Width(boxWidth).
+		Height(boxHeight).
+		Render(content)
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
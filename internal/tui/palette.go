@@ -0,0 +1,300 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+// maxPaletteResults is how many matching commands the palette lists
+const maxPaletteResults = 12
+
+// paletteCommand is an entry of the command palette
+type paletteCommand struct {
+	Title string // e.g. "Work Items: Change work item state"
+	Key   string // key that runs the command directly, shown as a hint
+	run   func(d *Dashboard) tea.Cmd
+}
+
+// CommandPalette is a fuzzy-searchable list of the dashboard's commands. The
+// global search uses it too, with commands that jump to what was found.
+type CommandPalette struct {
+	Active   bool
+	Title    string
+	Input    textinput.Model
+	Selected int
+
+	commands []paletteCommand
+	matches  []int // indexes into commands, best match first
+}
+
+// NewCommandPalette creates a new command palette
+func NewCommandPalette() *CommandPalette {
+	ti := textinput.New()
+	ti.CharLimit = 100
+	ti.Width = 60
+
+	return &CommandPalette{Input: ti}
+}
+
+// Show opens the palette with the given commands
+func (p *CommandPalette) Show(title, placeholder string, commands []paletteCommand) tea.Cmd {
+	p.commands = commands
+	p.Title = title
+	p.Input.Placeholder = placeholder
+	p.Active = true
+	p.Input.SetValue("")
+	p.filter()
+	return p.Input.Focus()
+}
+
+// Hide closes the palette
+func (p *CommandPalette) Hide() {
+	p.Active = false
+	p.Input.Blur()
+}
+
+// MoveUp selects the previous match
+func (p *CommandPalette) MoveUp() {
+	if p.Selected > 0 {
+		p.Selected--
+	}
+}
+
+// MoveDown selects the next match
+func (p *CommandPalette) MoveDown() {
+	if p.Selected < len(p.matches)-1 {
+		p.Selected++
+	}
+}
+
+// SelectedCommand returns the selected command, if any command matches
+func (p *CommandPalette) SelectedCommand() (paletteCommand, bool) {
+	if p.Selected < 0 || p.Selected >= len(p.matches) {
+		return paletteCommand{}, false
+	}
+	return p.commands[p.matches[p.Selected]], true
+}
+
+// Update updates the search text and the matching commands
+func (p *CommandPalette) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	p.Input, cmd = p.Input.Update(msg)
+	p.filter()
+	return cmd
+}
+
+// filter matches the commands against the search text, fuzzily
+func (p *CommandPalette) filter() {
+	p.Selected = 0
+	p.matches = p.matches[:0]
+
+	term := strings.TrimSpace(p.Input.Value())
+	if term == "" {
+		for i := range p.commands {
+			p.matches = append(p.matches, i)
+		}
+		return
+	}
+
+	titles := make([]string, len(p.commands))
+	for i, command := range p.commands {
+		titles[i] = command.Title
+	}
+	for _, rank := range list.DefaultFilter(term, titles) {
+		p.matches = append(p.matches, rank.Index)
+	}
+}
+
+// View renders the palette overlay
+func (p *CommandPalette) View(width, height int) string {
+	if !p.Active {
+		return ""
+	}
+
+	paletteWidth := min(width-4, 80)
+	lines := []string{TitleStyle.Render(p.Title), "", p.Input.View(), ""}
+
+	if len(p.matches) == 0 {
+		lines = append(lines, MutedStyle.Render("No matches"))
+	}
+	// Keep the selection in view when it is past the first page
+	first := max(0, p.Selected-maxPaletteResults+1)
+	for i := first; i < len(p.matches) && i < first+maxPaletteResults; i++ {
+		command := p.commands[p.matches[i]]
+		line := command.Title
+		if command.Key != "" {
+			line += "  " + MutedStyle.Render(command.Key)
+		}
+		if i == p.Selected {
+			line = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimary)).Bold(true).Render("▶ ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	if len(p.matches) > maxPaletteResults {
+		lines = append(lines, MutedStyle.Render(fmt.Sprintf("  … %d more", len(p.matches)-maxPaletteResults)))
+	}
+
+	lines = append(lines, "", MutedStyle.Render("↑/↓ to select • enter to run • esc to close"))
+
+	box := BoxStyle.
+		Width(paletteWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// paletteCommands lists the commands the palette offers: switching tabs, the
+// global actions, the current tab's actions, the loaded saved queries and the
+// templates. Actions run by replaying their key, so they behave exactly as if
+// the key had been pressed.
+func (d *Dashboard) paletteCommands() []paletteCommand {
+	var commands []paletteCommand
+
+	for i, tab := range d.tabs {
+		index := i
+		commands = append(commands, paletteCommand{
+			Title: "Go to tab: " + tab.Name(),
+			run: func(d *Dashboard) tea.Cmd {
+				d.currentTab = index
+				return nil
+			},
+		})
+	}
+
+	global := d.keybinds.GetAllBindings("global")
+	for _, action := range []string{"help", "messages", "refresh", "quit"} {
+		if binding, ok := global[action]; ok && len(binding.Keys()) > 0 {
+			commands = append(commands, keyCommand(capitalize(binding.Help().Desc), binding.Keys()[0]))
+		}
+	}
+
+	tab := d.tabs[d.currentTab]
+	scope := d.keybinds.GetAllBindings(keybindScope(tab.Name()))
+	for _, entry := range tab.GetHelpEntries() {
+		keyName := ""
+		if binding, ok := scope[entry.Action]; ok && len(binding.Keys()) > 0 {
+			keyName = binding.Keys()[0]
+		} else if binding, ok := global[entry.Action]; ok && len(binding.Keys()) > 0 {
+			keyName = binding.Keys()[0]
+		} else if entry.Action == "execute" {
+			keyName = "enter"
+		}
+		if keyName == "" {
+			continue
+		}
+		commands = append(commands, keyCommand(tab.Name()+": "+entry.Description, keyName))
+	}
+
+	for _, tab := range d.tabs {
+		switch t := tab.(type) {
+		case *QueriesTab:
+			commands = append(commands, queryCommands(t, t.queries)...)
+		case *TemplatesTab:
+			commands = append(commands, templateCommands(t.templates)...)
+		}
+	}
+	return commands
+}
+
+// keyCommand is a palette command that presses a key
+func keyCommand(title, keyName string) paletteCommand {
+	return paletteCommand{
+		Title: title,
+		Key:   keyName,
+		run: func(d *Dashboard) tea.Cmd {
+			_, cmd := d.Update(keyMsg(keyName))
+			return cmd
+		},
+	}
+}
+
+// queryCommands returns a command running each loaded saved query
+func queryCommands(tab *QueriesTab, queries []workitemtracking.QueryHierarchyItem) []paletteCommand {
+	var commands []paletteCommand
+	for _, query := range queries {
+		if query.IsFolder != nil && *query.IsFolder {
+			if query.Children != nil {
+				commands = append(commands, queryCommands(tab, *query.Children)...)
+			}
+			continue
+		}
+		if query.Path == nil {
+			continue
+		}
+		q := query
+		commands = append(commands, paletteCommand{
+			Title: "Run query: " + *q.Path,
+			run: func(d *Dashboard) tea.Cmd {
+				return tea.Batch(tab.executeQuery(q), func() tea.Msg {
+					return SwitchToTabMsg{TabIndex: 1}
+				})
+			},
+		})
+	}
+	return commands
+}
+
+// templateCommands returns a command opening each template in $EDITOR
+func templateCommands(nodes []*templates.TemplateNode) []paletteCommand {
+	var commands []paletteCommand
+	for _, node := range nodes {
+		if node.IsDir {
+			commands = append(commands, templateCommands(node.Children)...)
+			continue
+		}
+		path := node.Path
+		commands = append(commands, paletteCommand{
+			Title: "Open template: " + path,
+			run: func(d *Dashboard) tea.Cmd {
+				return prepareEditTemplate(path)
+			},
+		})
+	}
+	return commands
+}
+
+// keybindScope returns the keybinds scope of a tab
+func keybindScope(tabName string) string {
+	switch tabName {
+	case "Queries":
+		return "queries"
+	case "Work Items":
+		return "workitems"
+	case "Templates":
+		return "templates"
+	default:
+		return "global"
+	}
+}
+
+// keyMsg returns the key press whose name is keyName, e.g. "n", "ctrl+d" or "enter"
+func keyMsg(keyName string) tea.KeyMsg {
+	alt := false
+	if rest, ok := strings.CutPrefix(keyName, "alt+"); ok && rest != "" {
+		alt, keyName = true, rest
+	}
+	for keyType := tea.KeyType(-256); keyType < 256; keyType++ {
+		if keyType != tea.KeyRunes && (tea.Key{Type: keyType}).String() == keyName {
+			return tea.KeyMsg{Type: keyType, Alt: alt}
+		}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keyName), Alt: alt}
+}
+
+// capitalize upper-cases the first letter of a help description
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"testing"
+)
+
+func TestKeyMsg(t *testing.T) {
+	for _, name := range []string{"n", "L", "enter", "esc", "ctrl+d", "shift+tab", "alt+x", "?"} {
+		if got := keyMsg(name).String(); got != name {
+			t.Errorf("keyMsg(%q).String() = %q", name, got)
+		}
+	}
+}
+
+func TestCommandPaletteFilter(t *testing.T) {
+	commands := []paletteCommand{
+		{Title: "Go to tab: Queries"},
+		{Title: "Go to tab: Templates"},
+		{Title: "Run query: Shared Queries/Active Bugs"},
+		{Title: "Open template: bugs/regression"},
+	}
+	tests := []struct {
+		name string
+		term string
+		want string // selected command title, empty when nothing matches
+	}{
+		{"empty shows all", "", "Go to tab: Queries"},
+		{"fuzzy", "actbug", "Run query: Shared Queries/Active Bugs"},
+		{"template", "regression", "Open template: bugs/regression"},
+		{"no match", "zzz", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewCommandPalette()
+			p.Show("Command Palette", "", commands)
+			p.Input.SetValue(tt.term)
+			p.filter()
+
+			got, ok := p.SelectedCommand()
+			if tt.want == "" {
+				if ok {
+					t.Errorf("selected %q, want no match", got.Title)
+				}
+				return
+			}
+			if !ok || got.Title != tt.want {
+				t.Errorf("selected %q, want %q", got.Title, tt.want)
+			}
+		})
+	}
+}
@@ -7,43 +7,61 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/log"
 )
 
 // QueriesTab displays saved queries in a tree view
 type QueriesTab struct {
 	TabBase
-	client          *api.Client
+	client          api.APIClient
 	queries         []workitemtracking.QueryHierarchyItem
 	list            list.Model
 	expandedFolders map[string]bool
+	loadingFolders  map[string]bool
 	loading         bool
 	err             error
+	preview         viewport.Model
+	previewPath     string                  // Query shown in the preview pane
+	details         map[string]queryDetails // Queries loaded with their WIQL, by path
+	loadingDetails  map[string]bool
+	counts          map[string]queryCount // Result counts of queries run in the background, by path
+	counting        map[string]bool
 }
 
 // NewQueriesTab creates a new queries tab
-func NewQueriesTab(client *api.Client, width, height int) *QueriesTab {
+func NewQueriesTab(client api.APIClient, width, height int) *QueriesTab {
 	tab := &QueriesTab{
 		TabBase:         NewTabBase(width, height),
 		client:          client,
 		expandedFolders: make(map[string]bool),
+		loadingFolders:  make(map[string]bool),
 		loading:         true,
+		details:         make(map[string]queryDetails),
+		loadingDetails:  make(map[string]bool),
+		counts:          make(map[string]queryCount),
+		counting:        make(map[string]bool),
 	}
 
 	// Initialize list with empty delegate for now
-	tab.list = list.New([]list.Item{}, queryDelegate{expandedFolders: tab.expandedFolders}, width, tab.ContentHeight())
+	tab.list = list.New([]list.Item{}, tab.delegate(), width, tab.ContentHeight())
 	tab.list.Title = "Saved Queries"
 	tab.list.SetShowStatusBar(true)
 	tab.list.SetFilteringEnabled(true)
+	tab.list.KeyMap.Filter = listFilterKey
 	tab.list.Styles.Title = lipgloss.NewStyle().
 		Background(lipgloss.Color(ColorSecondary)).
 		Foreground(lipgloss.Color(ColorYellow)).
 		Padding(0, 1)
 
+	// Initialize preview viewport (shown beside the list when a query is selected)
+	tab.preview = viewport.New(width/2-4, tab.ContentHeight()-5)
+
 	return tab
 }
 
@@ -71,18 +89,40 @@ func (t *QueriesTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 		}
 		t.queries = msg.Queries
 		t.rebuildList()
+		return t, t.updatePreview()
+
+	case QueryChildrenLoadedMsg:
+		delete(t.loadingFolders, msg.FolderPath)
+		if msg.Error != nil {
+			t.expandedFolders[msg.FolderPath] = false
+			t.rebuildList()
+			return t, func() tea.Msg {
+				return NotificationMsg{Message: fmt.Sprintf("Failed to load folder: %v", msg.Error), IsError: true}
+			}
+		}
+		mergeQueryChildren(t.queries, msg.FolderPath, msg.Children)
+		t.rebuildList()
+		return t, nil
+
+	case QueryDetailsLoadedMsg:
+		t.handleDetailsLoaded(msg)
 		return t, nil
 
+	case QueryCountedMsg:
+		return t, t.handleQueryCounted(msg)
+
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
 			return t.handleEnter()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
 			t.loading = true
+			t.details = make(map[string]queryDetails)
 			return t, t.fetchQueries()
 		default:
+			// Update list and preview on selection change
 			t.list, cmd = t.list.Update(msg)
-			return t, cmd
+			return t, tea.Batch(cmd, t.updatePreview())
 		}
 	}
 
@@ -100,13 +140,33 @@ func (t *QueriesTab) View() string {
 		return RenderErrorWithRetry(t.err)
 	}
 
+	// Show preview beside the list when a query is selected
+	if t.previewPath != "" {
+		previewPane := RenderDetailsPane("Query Preview", t.preview.View())
+		return lipgloss.JoinHorizontal(lipgloss.Top, t.list.View(), previewPane)
+	}
+
 	return t.list.View()
 }
 
 // SetSize updates the tab dimensions
 func (t *QueriesTab) SetSize(width, height int) {
 	t.TabBase.SetSize(width, height)
-	t.list.SetSize(width, t.ContentHeight())
+	t.updateSizes()
+}
+
+// updateSizes updates list and viewport sizes based on whether preview is shown
+func (t *QueriesTab) updateSizes() {
+	if t.previewPath != "" {
+		// Split view: list on the left, preview on the right
+		listWidth := t.Width() / 2
+		t.list.SetSize(listWidth, t.ContentHeight())
+		t.preview.Width = t.Width() - listWidth - 4
+		// Account for header (1) + BoxStyle border (2) + padding (2) = 5 lines
+		t.preview.Height = t.ContentHeight() - 5
+	} else {
+		t.list.SetSize(t.Width(), t.ContentHeight())
+	}
 }
 
 // handleEnter toggles folders or executes queries
@@ -116,6 +176,14 @@ func (t *QueriesTab) handleEnter() (Tab, tea.Cmd) {
 		if item.IsFolder {
 			// Toggle folder expand/collapse
 			t.expandedFolders[item.Path] = !t.expandedFolders[item.Path]
+
+			// Folders beyond the initial fetch depth load their children on first expand
+			if t.expandedFolders[item.Path] && api.NeedsChildren(&item.query) && !t.loadingFolders[item.Path] {
+				t.loadingFolders[item.Path] = true
+				t.rebuildList()
+				return t, t.fetchQueryChildren(item.query)
+			}
+
 			t.rebuildList()
 			return t, nil
 		}
@@ -133,11 +201,15 @@ func (t *QueriesTab) handleEnter() (Tab, tea.Cmd) {
 // rebuildList rebuilds the list with current expanded state
 func (t *QueriesTab) rebuildList() {
 	items := t.flattenQueries(t.queries, 0)
-	delegate := queryDelegate{expandedFolders: t.expandedFolders}
-	t.list.SetDelegate(delegate)
+	t.list.SetDelegate(t.delegate())
 	t.list.SetItems(items)
 }
 
+// delegate returns the list delegate with the current folder and count state
+func (t *QueriesTab) delegate() queryDelegate {
+	return queryDelegate{expandedFolders: t.expandedFolders, loadingFolders: t.loadingFolders, counts: t.counts, counting: t.counting}
+}
+
 // flattenQueries recursively flattens the query hierarchy
 func (t *QueriesTab) flattenQueries(queries []workitemtracking.QueryHierarchyItem, depth int) []list.Item {
 	var items []list.Item
@@ -193,7 +265,53 @@ func (t *QueriesTab) fetchQueries() tea.Cmd {
 	}
 }
 
-// executeQuery executes a saved query
+// fetchQueryChildren loads the children of a folder that was beyond the initial fetch depth
+func (t *QueriesTab) fetchQueryChildren(folder workitemtracking.QueryHierarchyItem) tea.Cmd {
+	return func() tea.Msg {
+		path := ""
+		if folder.Path != nil {
+			path = *folder.Path
+		}
+
+		queryRef := path
+		if folder.Id != nil {
+			queryRef = folder.Id.String()
+		}
+
+		log.Debugf("QueriesTab: Loading children of folder '%s'", path)
+		childrenPtr, err := t.client.GetQueryChildren(queryRef)
+		if err != nil {
+			return QueryChildrenLoadedMsg{FolderPath: path, Error: err}
+		}
+
+		var children []workitemtracking.QueryHierarchyItem
+		if childrenPtr != nil {
+			children = *childrenPtr
+		}
+
+		return QueryChildrenLoadedMsg{FolderPath: path, Children: children}
+	}
+}
+
+// mergeQueryChildren attaches lazily loaded children to the folder with the given path
+func mergeQueryChildren(queries []workitemtracking.QueryHierarchyItem, folderPath string, children []workitemtracking.QueryHierarchyItem) bool {
+	for i := range queries {
+		q := &queries[i]
+		if q.Path != nil && *q.Path == folderPath {
+			q.Children = &children
+			return true
+		}
+		if q.Children != nil && mergeQueryChildren(*q.Children, folderPath, children) {
+			return true
+		}
+	}
+	return false
+}
+
+// queryResultLimit is how many work items opening a saved query loads
+const queryResultLimit = 100
+
+// executeQuery executes a saved query, loading up to queryResultLimit work items
 func (t *QueriesTab) executeQuery(query workitemtracking.QueryHierarchyItem) tea.Cmd {
 	return func() tea.Msg {
 		queryID := ""
@@ -201,7 +319,7 @@ func (t *QueriesTab) executeQuery(query workitemtracking.QueryHierarchyItem) tea
 			queryID = query.Id.String()
 		}
 
-		workItemsPtr, err := t.client.ExecuteQuery(queryID, 100)
+		workItemsPtr, err := t.client.ExecuteQuery(queryID, queryResultLimit)
 		if err != nil {
 			return QueryExecutedMsg{Error: err}
 		}
@@ -218,6 +336,9 @@ func (t *QueriesTab) executeQuery(query workitemtracking.QueryHierarchyItem) tea
 // queryDelegate implements list.ItemDelegate for query items
 type queryDelegate struct {
 	expandedFolders map[string]bool
+	loadingFolders  map[string]bool
+	counts          map[string]queryCount
+	counting        map[string]bool
 }
 
 func (d queryDelegate) Height() int                             { return 1 }
@@ -251,6 +372,11 @@ func (d queryDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 	if len(name) > 60 {
 		name = name[:57] + "..."
 	}
+	if d.loadingFolders[queryItem.Path] || d.counting[queryItem.Path] {
+		name += " (loading...)"
+	} else if count, ok := d.counts[queryItem.Path]; ok {
+		name += " " + count.badge()
+	}
 
 	var output string
 	if index == m.Index() {
@@ -277,6 +403,7 @@ func (i queryListItem) FilterValue() string { return i.Name }
 func (t *QueriesTab) GetHelpEntries() []HelpEntry {
 	return []HelpEntry{
 		{Action: "execute", Description: "Execute query or expand folder"},
+		{Action: "count", Description: "Count results without leaving the tab"},
 		{Action: "expand_all", Description: "Expand all folders"},
 		{Action: "collapse_all", Description: "Collapse all folders"},
 		{Action: "refresh", Description: "Refresh queries list"},
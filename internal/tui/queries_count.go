@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/SOMUCHDOG/azb/internal/log"
+)
+
+// queryCount is the number of work items a query returned, or the error running it
+type queryCount struct {
+	count int
+	err   error
+}
+
+// countQuery runs the selected query in the background and shows how many work
+// items it returns, without switching to the Work Items tab
+func (t *QueriesTab) countQuery() tea.Cmd {
+	item, ok := t.selectedQuery()
+	if !ok {
+		return func() tea.Msg {
+			return NotificationMsg{Message: "Select a query to count its results", IsError: true}
+		}
+	}
+	if t.counting[item.Path] {
+		return nil
+	}
+	t.counting[item.Path] = true
+	t.rebuildList()
+
+	queryID := ""
+	if item.query.Id != nil {
+		queryID = item.query.Id.String()
+	}
+	path, name := item.Path, item.Name
+	return func() tea.Msg {
+		log.Debugf("QueriesTab: Counting results of query '%s'", path)
+		wiql, err := t.client.GetQueryWIQL(queryID)
+		if err != nil {
+			return QueryCountedMsg{Path: path, Name: name, Error: err}
+		}
+		ids, err := t.client.QueryWorkItemIDs(wiql, 0)
+		if err != nil {
+			return QueryCountedMsg{Path: path, Name: name, Error: err}
+		}
+		return QueryCountedMsg{Path: path, Name: name, Count: len(ids)}
+	}
+}
+
+// handleQueryCounted records a query's result count as a badge in the list
+func (t *QueriesTab) handleQueryCounted(msg QueryCountedMsg) tea.Cmd {
+	delete(t.counting, msg.Path)
+	t.counts[msg.Path] = queryCount{count: msg.Count, err: msg.Error}
+	t.rebuildList()
+
+	if msg.Error != nil {
+		log.Warnf("QueriesTab: Failed to count query '%s': %v", msg.Path, msg.Error)
+		return func() tea.Msg {
+			return NotificationMsg{Message: fmt.Sprintf("Failed to run %s: %v", msg.Name, msg.Error), IsError: true}
+		}
+	}
+	message := fmt.Sprintf("%s: %d work item(s) (Enter to open)", msg.Name, msg.Count)
+	if msg.Count > queryResultLimit {
+		message = fmt.Sprintf("%s: %d work item(s) (Enter opens the first %d)", msg.Name, msg.Count, queryResultLimit)
+	}
+	return func() tea.Msg {
+		return NotificationMsg{Message: message}
+	}
+}
+
+// badge returns the result count shown after a query's name, marked with a
+// "+" when opening the query loads only the first queryResultLimit of them
+func (c queryCount) badge() string {
+	if c.err != nil {
+		return "[error]"
+	}
+	if c.count > queryResultLimit {
+		return fmt.Sprintf("[%d+]", queryResultLimit)
+	}
+	return fmt.Sprintf("[%d]", c.count)
+}
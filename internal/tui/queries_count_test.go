@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api/apitest"
+)
+
+func TestQueriesTab_CountQuery(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	name, path := "Active Bugs", "Shared Queries/Active Bugs"
+	many := make([]int, queryResultLimit+50)
+
+	tests := []struct {
+		name      string
+		ids       []int
+		err       error
+		wantBadge string
+		wantError bool
+		wantText  string
+	}{
+		{"counted", []int{1, 2, 3}, nil, "[3]", false, "3 work item(s)"},
+		{"empty", nil, nil, "[0]", false, "0 work item(s)"},
+		{"more than opening loads", many, nil, "[100+]", false, "150 work item(s) (Enter opens the first 100)"},
+		{"failed", nil, errors.New("invalid query"), "[error]", true, "invalid query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := apitest.New()
+			client.GetQueryWIQLFunc = func(string) (string, error) { return "SELECT [System.Id] FROM WorkItems", nil }
+			client.QueryWorkItemIDsFunc = func(string, int) ([]int, error) { return tt.ids, tt.err }
+			tab := NewQueriesTab(client, 80, 24)
+			tab.Update(QueriesLoadedMsg{Queries: []workitemtracking.QueryHierarchyItem{{Name: &name, Path: &path}}})
+
+			cmd := tab.countQuery()
+			if !tab.counting[path] {
+				t.Fatal("query should be marked as counting")
+			}
+			_, notify := tab.Update(cmd())
+
+			if got := tab.counts[path].badge(); got != tt.wantBadge {
+				t.Errorf("badge = %q, want %q", got, tt.wantBadge)
+			}
+			if msg, ok := notify().(NotificationMsg); !ok || msg.IsError != tt.wantError || !strings.Contains(msg.Message, tt.wantText) {
+				t.Errorf("notification = %+v, want IsError %v with %q", msg, tt.wantError, tt.wantText)
+			}
+		})
+	}
+}
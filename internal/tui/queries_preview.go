@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/log"
+)
+
+// queryDetails is a query loaded with its WIQL, or the error loading it
+type queryDetails struct {
+	query *workitemtracking.QueryHierarchyItem
+	err   error
+}
+
+// selectedQuery returns the selected list item when it is a query, not a folder
+func (t *QueriesTab) selectedQuery() (queryListItem, bool) {
+	item, ok := t.list.SelectedItem().(queryListItem)
+	if !ok || item.IsFolder {
+		return queryListItem{}, false
+	}
+	return item, true
+}
+
+// updatePreview shows the selected query in the preview pane and fetches its
+// WIQL the first time it is selected
+func (t *QueriesTab) updatePreview() tea.Cmd {
+	item, ok := t.selectedQuery()
+	showing := t.previewPath != ""
+	t.previewPath = item.Path
+	if showing != ok {
+		t.updateSizes()
+	}
+	if !ok {
+		return nil
+	}
+
+	t.preview.SetContent(t.formatQueryPreview(item))
+	if _, loaded := t.details[item.Path]; loaded || t.loadingDetails[item.Path] {
+		return nil
+	}
+	t.loadingDetails[item.Path] = true
+	return t.fetchQueryDetails(item.query)
+}
+
+// fetchQueryDetails loads a query with its WIQL
+func (t *QueriesTab) fetchQueryDetails(query workitemtracking.QueryHierarchyItem) tea.Cmd {
+	return func() tea.Msg {
+		path := ""
+		if query.Path != nil {
+			path = *query.Path
+		}
+		queryRef := path
+		if query.Id != nil {
+			queryRef = query.Id.String()
+		}
+
+		log.Debugf("QueriesTab: Loading details of query '%s'", path)
+		details, err := t.client.GetQuery(queryRef)
+		if err != nil {
+			log.Warnf("QueriesTab: Failed to load query '%s': %v", path, err)
+		}
+		return QueryDetailsLoadedMsg{Path: path, Query: details, Error: err}
+	}
+}
+
+// handleDetailsLoaded stores loaded query details and refreshes the preview
+func (t *QueriesTab) handleDetailsLoaded(msg QueryDetailsLoadedMsg) {
+	delete(t.loadingDetails, msg.Path)
+	t.details[msg.Path] = queryDetails{query: msg.Query, err: msg.Error}
+	if item, ok := t.selectedQuery(); ok && item.Path == msg.Path {
+		t.preview.SetContent(t.formatQueryPreview(item))
+	}
+}
+
+// formatQueryPreview describes a query: whether it is shared, its owner, when
+// it last changed, its columns and its WIQL, once loaded
+func (t *QueriesTab) formatQueryPreview(item queryListItem) string {
+	query := item.query
+	details, loaded := t.details[item.Path]
+	if loaded && details.query != nil {
+		query = *details.query
+	}
+
+	var b strings.Builder
+	b.WriteString(TitleStyle.Render(item.Name) + "\n\n")
+	b.WriteString(MutedStyle.Render("Path: ") + item.Path + "\n")
+
+	visibility := "Personal (My Queries)"
+	if query.IsPublic != nil && *query.IsPublic {
+		visibility = "Shared"
+	}
+	b.WriteString(MutedStyle.Render("Visibility: ") + visibility + "\n")
+	if query.QueryType != nil {
+		b.WriteString(MutedStyle.Render("Type: ") + string(*query.QueryType) + "\n")
+	}
+	if owner := identityName(query.CreatedBy); owner != "" {
+		b.WriteString(MutedStyle.Render("Owner: ") + owner + "\n")
+	}
+	if query.LastModifiedDate != nil {
+		modified := query.LastModifiedDate.Time.Local().Format("2006-01-02 15:04")
+		if by := identityName(query.LastModifiedBy); by != "" {
+			modified += " by " + by
+		}
+		b.WriteString(MutedStyle.Render("Last modified: ") + modified + "\n")
+	}
+
+	switch {
+	case !loaded:
+		b.WriteString("\n" + MutedStyle.Render("Loading WIQL..."))
+	case details.err != nil:
+		b.WriteString("\n" + ErrorStyle.Render(fmt.Sprintf("Could not load query: %v", details.err)))
+	default:
+		if query.Columns != nil && len(*query.Columns) > 0 {
+			var columns []string
+			for _, column := range *query.Columns {
+				if column.Name != nil {
+					columns = append(columns, *column.Name)
+				} else if column.ReferenceName != nil {
+					columns = append(columns, *column.ReferenceName)
+				}
+			}
+			b.WriteString("\n" + MutedStyle.Render("Columns: ") + strings.Join(columns, ", ") + "\n")
+		}
+		if query.Wiql != nil {
+			b.WriteString("\n" + MutedStyle.Render("WIQL:") + "\n" + *query.Wiql + "\n")
+		}
+	}
+	return b.String()
+}
+
+// identityName returns the display name of an identity, if set
+func identityName(identity *workitemtracking.IdentityReference) string {
+	if identity == nil {
+		return ""
+	}
+	if identity.DisplayName != nil {
+		return *identity.DisplayName
+	}
+	if identity.UniqueName != nil {
+		return *identity.UniqueName
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api/apitest"
+)
+
+func TestQueriesTab_Preview(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	name, path, wiql := "Active Bugs", "Shared Queries/Active Bugs", "SELECT [System.Id] FROM WorkItems"
+	public, owner := true, "Jane Smith"
+
+	tests := []struct {
+		name    string
+		details *workitemtracking.QueryHierarchyItem
+		err     error
+		want    []string
+	}{
+		{
+			name: "loaded",
+			details: &workitemtracking.QueryHierarchyItem{
+				Name: &name, Path: &path, Wiql: &wiql, IsPublic: &public,
+				CreatedBy: &workitemtracking.IdentityReference{DisplayName: &owner},
+			},
+			want: []string{"Shared", "Owner: Jane Smith", wiql},
+		},
+		{
+			name: "failed",
+			err:  errors.New("not found"),
+			want: []string{"Personal", "Could not load query: not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			client := apitest.New()
+			client.GetQueryFunc = func(string) (*workitemtracking.QueryHierarchyItem, error) {
+				calls++
+				return tt.details, tt.err
+			}
+			tab := NewQueriesTab(client, 120, 40)
+
+			_, cmd := tab.Update(QueriesLoadedMsg{Queries: []workitemtracking.QueryHierarchyItem{{Name: &name, Path: &path}}})
+			if tab.previewPath != path {
+				t.Fatalf("previewPath = %q, want %q", tab.previewPath, path)
+			}
+			if !strings.Contains(tab.formatQueryPreview(tab.list.SelectedItem().(queryListItem)), "Loading WIQL") {
+				t.Error("preview should say the WIQL is loading")
+			}
+
+			tab.Update(cmd())
+			// Selecting the query again uses the loaded details
+			if cmd := tab.updatePreview(); cmd != nil || calls != 1 {
+				t.Errorf("query fetched %d times, want once", calls)
+			}
+			preview := tab.formatQueryPreview(tab.list.SelectedItem().(queryListItem))
+			for _, want := range tt.want {
+				if !strings.Contains(preview, want) {
+					t.Errorf("preview does not contain %q:\n%s", want, preview)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+// listFilterKey filters the dashboard lists until NewDashboard sets the
+// configured filter binding; / opens the global search instead
+var listFilterKey = key.NewBinding(
+	key.WithKeys("F"),
+	key.WithHelp("F", "filter"),
+)
+
+// setListFilterKey sets the key that filters the lists of the tabs
+func (d *Dashboard) setListFilterKey(binding key.Binding) {
+	for _, tab := range d.tabs {
+		switch tab := tab.(type) {
+		case *QueriesTab:
+			tab.list.KeyMap.Filter = binding
+		case *WorkItemsTab:
+			tab.list.KeyMap.Filter = binding
+		case *TemplatesTab:
+			tab.list.KeyMap.Filter = binding
+		}
+	}
+}
+
+// searchResults lists what the global search finds: the loaded queries and
+// templates and the work items of the Work Items tab. Each jumps to its tab
+// and selects the result.
+func (d *Dashboard) searchResults() []paletteCommand {
+	var results []paletteCommand
+	for i, tab := range d.tabs {
+		index := i
+		jump := func(d *Dashboard, cmd tea.Cmd, selected bool, what string) tea.Cmd {
+			d.currentTab = index
+			if selected {
+				return cmd
+			}
+			return func() tea.Msg {
+				return NotificationMsg{Message: fmt.Sprintf("%s is no longer listed", what), IsError: true}
+			}
+		}
+
+		switch t := tab.(type) {
+		case *QueriesTab:
+			for _, path := range queryPaths(t.queries) {
+				results = append(results, paletteCommand{
+					Title: "Query: " + path,
+					run: func(d *Dashboard) tea.Cmd {
+						cmd, ok := t.selectQuery(path)
+						return jump(d, cmd, ok, path)
+					},
+				})
+			}
+		case *TemplatesTab:
+			for _, path := range templatePaths(t.templates) {
+				results = append(results, paletteCommand{
+					Title: "Template: " + path,
+					run: func(d *Dashboard) tea.Cmd {
+						return jump(d, nil, t.selectTemplate(path), path)
+					},
+				})
+			}
+		case *WorkItemsTab:
+			for _, item := range t.list.Items() {
+				workItem, ok := item.(workItemItem)
+				if !ok {
+					continue
+				}
+				id := workItem.ID
+				results = append(results, paletteCommand{
+					Title: fmt.Sprintf("Work item: #%d %s", id, workItem.Title),
+					Key:   workItem.State,
+					run: func(d *Dashboard) tea.Cmd {
+						cmd, ok := t.selectWorkItem(id)
+						return jump(d, cmd, ok, fmt.Sprintf("#%d", id))
+					},
+				})
+			}
+		}
+	}
+	return results
+}
+
+// queryPaths returns the paths of the loaded queries, leaving out folders
+func queryPaths(queries []workitemtracking.QueryHierarchyItem) []string {
+	var paths []string
+	for _, query := range queries {
+		if query.IsFolder != nil && *query.IsFolder {
+			if query.Children != nil {
+				paths = append(paths, queryPaths(*query.Children)...)
+			}
+			continue
+		}
+		if query.Path != nil {
+			paths = append(paths, *query.Path)
+		}
+	}
+	return paths
+}
+
+// templatePaths returns the paths of the templates, leaving out folders
+func templatePaths(nodes []*templates.TemplateNode) []string {
+	var paths []string
+	for _, node := range nodes {
+		if node.IsDir {
+			paths = append(paths, templatePaths(node.Children)...)
+			continue
+		}
+		paths = append(paths, node.Path)
+	}
+	return paths
+}
+
+// queryFolders returns the paths of the folders containing a query, outermost first
+func queryFolders(queries []workitemtracking.QueryHierarchyItem, path string) ([]string, bool) {
+	for _, query := range queries {
+		if query.Path == nil {
+			continue
+		}
+		if *query.Path == path {
+			return nil, true
+		}
+		if query.Children != nil {
+			if folders, ok := queryFolders(*query.Children, path); ok {
+				return append([]string{*query.Path}, folders...), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// templateFolders returns the paths of the folders containing a template, outermost first
+func templateFolders(nodes []*templates.TemplateNode, path string) ([]string, bool) {
+	for _, node := range nodes {
+		if node.Path == path {
+			return nil, true
+		}
+		if folders, ok := templateFolders(node.Children, path); ok {
+			return append([]string{node.Path}, folders...), true
+		}
+	}
+	return nil, false
+}
+
+// selectQuery expands the folders of a query and selects it, clearing any filter
+func (t *QueriesTab) selectQuery(path string) (tea.Cmd, bool) {
+	folders, ok := queryFolders(t.queries, path)
+	if !ok {
+		return nil, false
+	}
+	for _, folder := range folders {
+		t.expandedFolders[folder] = true
+	}
+	t.list.ResetFilter()
+	t.rebuildList()
+	for i, item := range t.list.Items() {
+		if query, ok := item.(queryListItem); ok && query.Path == path {
+			t.list.Select(i)
+			break
+		}
+	}
+	return t.updatePreview(), true
+}
+
+// selectTemplate expands the folders of a template and selects it, clearing any filter
+func (t *TemplatesTab) selectTemplate(path string) bool {
+	folders, ok := templateFolders(t.templates, path)
+	if !ok {
+		return false
+	}
+	for _, folder := range folders {
+		t.expandedFolders[folder] = true
+	}
+	t.list.ResetFilter()
+	t.rebuildList()
+	for i, item := range t.list.Items() {
+		if template, ok := item.(templateListItem); ok && template.Path == path {
+			t.list.Select(i)
+			break
+		}
+	}
+	t.updatePreview()
+	return true
+}
+
+// selectWorkItem selects a work item of the list, clearing a filter that
+// hides it, and shows it in the details pane when that is open
+func (t *WorkItemsTab) selectWorkItem(id int) (tea.Cmd, bool) {
+	workItem, ok := t.selectListItem(id)
+	if !ok {
+		t.list.ResetFilter()
+		if workItem, ok = t.selectListItem(id); !ok {
+			return nil, false
+		}
+	}
+	if !t.showDetails {
+		return nil, true
+	}
+	t.selectedItem = &workItem.workItem
+	t.viewport.SetContent(t.formatWorkItemDetails(workItem.workItem))
+	t.viewport.GotoTop()
+	return t.loadComments(id), true
+}
+
+// selectListItem moves the cursor to a work item among the visible items of the list
+func (t *WorkItemsTab) selectListItem(id int) (workItemItem, bool) {
+	for i, item := range t.list.VisibleItems() {
+		if workItem, ok := item.(workItemItem); ok && workItem.ID == id {
+			t.list.Select(i)
+			return workItem, true
+		}
+	}
+	return workItemItem{}, false
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+func TestDashboardSearchResults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	folder, isFolder := "Shared Queries/Bugs", true
+	name, path := "Active", "Shared Queries/Bugs/Active"
+	queries := NewQueriesTab(nil, 80, 24)
+	queries.Update(QueriesLoadedMsg{Queries: []workitemtracking.QueryHierarchyItem{{
+		Path:     &folder,
+		IsFolder: &isFolder,
+		Children: &[]workitemtracking.QueryHierarchyItem{{Name: &name, Path: &path}},
+	}}})
+
+	templatesTab := NewTemplatesTab(nil, 80, 24)
+	templatesTab.Update(TemplatesLoadedMsg{Templates: []*templates.TemplateNode{{
+		Name:  "bugs",
+		Path:  "bugs",
+		IsDir: true,
+		Children: []*templates.TemplateNode{
+			{Name: "regression", Path: "bugs/regression", Template: &templates.Template{Name: "regression"}},
+		},
+	}}})
+
+	workItems := NewWorkItemsTab(nil, 80, 24)
+	workItems.Update(WorkItemsLoadedMsg{WorkItems: []workitemtracking.WorkItem{treeTestWorkItem(7, 0), treeTestWorkItem(8, 0)}})
+
+	d := &Dashboard{tabs: []Tab{queries, workItems, templatesTab}}
+
+	tests := []struct {
+		title   string
+		wantTab int
+		check   func() bool
+	}{
+		{"Query: Shared Queries/Bugs/Active", 0, func() bool {
+			item, ok := queries.list.SelectedItem().(queryListItem)
+			return ok && item.Path == path
+		}},
+		{"Template: bugs/regression", 2, func() bool {
+			item, ok := templatesTab.list.SelectedItem().(templateListItem)
+			return ok && item.Path == "bugs/regression"
+		}},
+		{"Work item: #8 Item 8", 1, func() bool {
+			item, ok := workItems.list.SelectedItem().(workItemItem)
+			return ok && item.ID == 8
+		}},
+	}
+
+	results := d.searchResults()
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			for _, result := range results {
+				if result.Title != tt.title {
+					continue
+				}
+				result.run(d)
+				if d.currentTab != tt.wantTab {
+					t.Errorf("currentTab = %d, want %d", d.currentTab, tt.wantTab)
+				}
+				if !tt.check() {
+					t.Error("result is not selected")
+				}
+				return
+			}
+			t.Errorf("no result %q", tt.title)
+		})
+	}
+}
+
+func TestDashboardSetListFilterKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	queries := NewQueriesTab(nil, 80, 24)
+	workItems := NewWorkItemsTab(nil, 80, 24)
+	templatesTab := NewTemplatesTab(nil, 80, 24)
+	d := &Dashboard{tabs: []Tab{queries, workItems, templatesTab}}
+
+	d.setListFilterKey(key.NewBinding(key.WithKeys("ctrl+f"), key.WithHelp("ctrl+f", "filter")))
+
+	for name, got := range map[string]key.Binding{
+		"queries":    queries.list.KeyMap.Filter,
+		"work items": workItems.list.KeyMap.Filter,
+		"templates":  templatesTab.list.KeyMap.Filter,
+	} {
+		if keys := got.Keys(); len(keys) != 1 || keys[0] != "ctrl+f" {
+			t.Errorf("%s filter keys = %v, want [ctrl+f]", name, keys)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/SOMUCHDOG/azb/internal/config"
+)
+
+// UIState is what the dashboard remembers between launches
+type UIState struct {
+	OnboardingSeen bool `yaml:"onboarding_seen"`
+}
+
+// uiStatePath returns ~/.azure-boards-cli/tui_state.yaml
+func uiStatePath() (string, error) {
+	configDir, err := config.EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "tui_state.yaml"), nil
+}
+
+// LoadUIState reads the dashboard state. A missing file means this is the first launch.
+func LoadUIState() (*UIState, bool, error) {
+	path, err := uiStatePath()
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &UIState{}, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read dashboard state: %w", err)
+	}
+
+	var state UIState
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, true, fmt.Errorf("failed to parse dashboard state: %w", err)
+	}
+	return &state, true, nil
+}
+
+// SaveUIState writes the dashboard state
+func SaveUIState(state *UIState) error {
+	path, err := uiStatePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to serialize dashboard state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write dashboard state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package tui
+
+import "testing"
+
+func TestUIStateRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	state, exists, err := LoadUIState()
+	if err != nil {
+		t.Fatalf("LoadUIState() error = %v", err)
+	}
+	if exists || state.OnboardingSeen {
+		t.Fatalf("expected no saved state on first launch, got exists=%v state=%+v", exists, state)
+	}
+
+	if err := SaveUIState(&UIState{OnboardingSeen: true}); err != nil {
+		t.Fatalf("SaveUIState() error = %v", err)
+	}
+
+	state, exists, err = LoadUIState()
+	if err != nil {
+		t.Fatalf("LoadUIState() error = %v", err)
+	}
+	if !exists || !state.OnboardingSeen {
+		t.Errorf("expected saved state, got exists=%v state=%+v", exists, state)
+	}
+}
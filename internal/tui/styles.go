@@ -2,8 +2,8 @@ package tui
 
 import "github.com/charmbracelet/lipgloss"
 
-// Color palette
-const (
+// Color palette. These are the defaults of the dark theme and are replaced by ApplyTheme.
+var (
 	ColorPrimary   = "6"   // Cyan
 	ColorSecondary = "62"  // Purple
 	ColorAccent    = "170" // Pink
@@ -14,118 +14,181 @@ const (
 	ColorMuted     = "8"   // Gray
 	ColorNormal    = "7"   // White
 	ColorYellow    = "230" // Light yellow
+
+	// Work item state colors
+	ColorStateActive  = ColorSuccess
+	ColorStateNew     = ColorInfo
+	ColorStateClosed  = ColorMuted
+	ColorStateBlocked = ColorError
 )
 
-// Common styles
+// Common styles, built from the color palette by buildStyles
 var (
+	// Text styles
+	TitleStyle    lipgloss.Style
+	SelectedStyle lipgloss.Style
+	NormalStyle   lipgloss.Style
+	MutedStyle    lipgloss.Style
+	SuccessStyle  lipgloss.Style
+	ErrorStyle    lipgloss.Style
+	WarningStyle  lipgloss.Style
+	InfoStyle     lipgloss.Style
+
+	// State-specific styles for work items
+	StateActiveStyle  lipgloss.Style
+	StateNewStyle     lipgloss.Style
+	StateClosedStyle  lipgloss.Style
+	StateBlockedStyle lipgloss.Style
+
+	// Folder/tree styles
+	FolderStyle lipgloss.Style
+	FileStyle   lipgloss.Style
+
+	// Container styles
+	HeaderStyle lipgloss.Style
+	BoxStyle    lipgloss.Style
+
+	// Tab styles
+	ActiveTabStyle   lipgloss.Style
+	InactiveTabStyle lipgloss.Style
+
+	// Notification styles
+	NotificationSuccessStyle lipgloss.Style
+	NotificationErrorStyle   lipgloss.Style
+
+	// Dialog styles
+	DialogBoxStyle   lipgloss.Style
+	DialogTitleStyle lipgloss.Style
+	InputBoxStyle    lipgloss.Style
+	InputTitleStyle  lipgloss.Style
+
+	// Selection dialog styles
+	SelectedOptionStyle lipgloss.Style
+
+	// Confirmation button styles
+	SelectedButtonStyle lipgloss.Style
+)
+
+func init() {
+	buildStyles()
+}
+
+// buildStyles (re)creates the common styles from the current color palette
+func buildStyles() {
 	// Text styles
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color(ColorPrimary))
+		Bold(true).
+		Foreground(lipgloss.Color(ColorPrimary))
 
 	SelectedStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color(ColorPrimary))
+		Bold(true).
+		Foreground(lipgloss.Color(ColorPrimary))
 
 	NormalStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorNormal))
+		Foreground(lipgloss.Color(ColorNormal))
 
 	MutedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorMuted))
+		Foreground(lipgloss.Color(ColorMuted))
 
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorSuccess))
+		Foreground(lipgloss.Color(ColorSuccess))
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorError))
+		Foreground(lipgloss.Color(ColorError))
 
 	WarningStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorWarning))
+		Foreground(lipgloss.Color(ColorWarning))
 
 	InfoStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorInfo))
+		Foreground(lipgloss.Color(ColorInfo))
 
 	// State-specific styles for work items
 	StateActiveStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(ColorSuccess))
+		Foreground(lipgloss.Color(ColorStateActive))
 
 	StateNewStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorInfo))
+		Foreground(lipgloss.Color(ColorStateNew))
 
 	StateClosedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(ColorMuted))
+		Foreground(lipgloss.Color(ColorStateClosed))
 
 	StateBlockedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(ColorError))
+		Foreground(lipgloss.Color(ColorStateBlocked))
 
 	// Folder/tree styles
 	FolderStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorInfo))
+		Foreground(lipgloss.Color(ColorInfo))
 
 	FileStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorSuccess))
+		Foreground(lipgloss.Color(ColorSuccess))
 
 	// Container styles
 	HeaderStyle = lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorSecondary)).
-			Padding(0, 1)
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorSecondary)).
+		Padding(0, 1)
 
 	BoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorSecondary)).
-			Padding(1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorSecondary)).
+		Padding(1)
 
 	// Tab styles
 	ActiveTabStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorPrimary)).
-			Bold(true).
-			Underline(true).
-			Padding(0, 2)
+		Foreground(lipgloss.Color(ColorPrimary)).
+		Bold(true).
+		Underline(true).
+		Padding(0, 2)
 
 	InactiveTabStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(ColorMuted)).
-				Padding(0, 2)
+		Foreground(lipgloss.Color(ColorMuted)).
+		Padding(0, 2)
 
 	// Notification styles
 	NotificationSuccessStyle = lipgloss.NewStyle().
-					Foreground(lipgloss.Color(ColorSuccess)).
-					Bold(true).
-					Padding(0, 1).
-					Margin(0, 2)
+		Foreground(lipgloss.Color(ColorSuccess)).
+		Bold(true).
+		Padding(0, 1).
+		Margin(0, 2)
 
 	NotificationErrorStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(ColorError)).
-				Bold(true).
-				Padding(0, 1).
-				Margin(0, 2)
+		Foreground(lipgloss.Color(ColorError)).
+		Bold(true).
+		Padding(0, 1).
+		Margin(0, 2)
 
 	// Dialog styles
 	DialogBoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorWarning)).
-			Padding(1, 2).
-			Width(60)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorWarning)).
+		Padding(1, 2).
+		Width(60)
 
 	DialogTitleStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color(ColorWarning))
+		Bold(true).
+		Foreground(lipgloss.Color(ColorWarning))
 
 	InputBoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorPrimary)).
-			Padding(1, 2).
-			Width(60)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorPrimary)).
+		Padding(1, 2).
+		Width(60)
 
 	InputTitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color(ColorPrimary))
+		Bold(true).
+		Foreground(lipgloss.Color(ColorPrimary))
 
 	// Selection dialog styles
 	SelectedOptionStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color(ColorPrimary))
-)
+		Bold(true).
+		Foreground(lipgloss.Color(ColorPrimary))
+
+	// Confirmation button styles
+	SelectedButtonStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorYellow)).
+		Background(lipgloss.Color(ColorSecondary))
+}
 
 // Helper functions for common styling patterns
 
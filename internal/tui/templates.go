@@ -14,24 +14,32 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/log"
 	"github.com/SOMUCHDOG/azb/internal/templates"
 )
 
 // TemplatesTab displays and manages templates
 type TemplatesTab struct {
 	TabBase
-	client           *api.Client
+	client           api.APIClient
 	templates        []*templates.TemplateNode
 	list             list.Model
 	preview          viewport.Model
 	expandedFolders  map[string]bool
 	selectedTemplate *templates.Template
+	selectedBroken   bool   // The selected template file cannot be used; the preview says why
+	showRaw          bool   // Preview shows the template's YAML instead of a summary
+	follow           string // Template to select once the templates reload, e.g. after a move
+	problems         []templates.Problem
 	loading          bool
 	err              error
 }
 
+// maxTemplateProblems is how many unreadable entries are listed above the templates
+const maxTemplateProblems = 3
+
 // NewTemplatesTab creates a new templates tab
-func NewTemplatesTab(client *api.Client, width, height int) *TemplatesTab {
+func NewTemplatesTab(client api.APIClient, width, height int) *TemplatesTab {
 	tab := &TemplatesTab{
 		TabBase:         NewTabBase(width, height),
 		client:          client,
@@ -44,6 +52,7 @@ func NewTemplatesTab(client *api.Client, width, height int) *TemplatesTab {
 	tab.list.Title = "Templates"
 	tab.list.SetShowStatusBar(true)
 	tab.list.SetFilteringEnabled(true)
+	tab.list.KeyMap.Filter = listFilterKey
 	tab.list.Styles.Title = lipgloss.NewStyle().
 		Background(lipgloss.Color(ColorSecondary)).
 		Foreground(lipgloss.Color(ColorYellow)).
@@ -79,7 +88,15 @@ func (t *TemplatesTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 			return t, nil
 		}
 		t.templates = msg.Templates
+		t.problems = msg.Problems
+		for _, problem := range msg.Problems {
+			log.Warnf("TemplatesTab: Skipped %s", problem)
+		}
 		t.rebuildList()
+		if t.follow != "" {
+			t.selectTemplate(t.follow)
+			t.follow = ""
+		}
 		return t, nil
 
 	case tea.KeyMsg:
@@ -116,10 +133,18 @@ func (t *TemplatesTab) View() string {
 		return RenderErrorWithRetry(t.err)
 	}
 
+	listView := t.list.View()
+	if warnings := t.problemLines(); len(warnings) > 0 {
+		listView = lipgloss.JoinVertical(lipgloss.Left, strings.Join(warnings, "\n"), listView)
+	}
+
 	// Show preview at bottom when template is selected
-	if t.selectedTemplate != nil {
-		listView := t.list.View()
-		previewPane := RenderDetailsPane("Template Preview", t.preview.View())
+	if t.previewShown() {
+		title := "Template Preview"
+		if t.showRaw {
+			title = "Template YAML"
+		}
+		previewPane := RenderDetailsPane(title, t.preview.View())
 		combined := lipgloss.JoinVertical(lipgloss.Left, listView, previewPane)
 
 		// Ensure total height doesn't exceed ContentHeight()
@@ -127,7 +152,25 @@ func (t *TemplatesTab) View() string {
 		return lipgloss.NewStyle().MaxHeight(maxHeight).Render(combined)
 	}
 
-	return t.list.View()
+	return listView
+}
+
+// problemLines lists templates that could not be read, e.g. cloud-synced files
+// that are not downloaded yet
+func (t *TemplatesTab) problemLines() []string {
+	if len(t.problems) == 0 {
+		return nil
+	}
+
+	lines := []string{WarningStyle.Render(fmt.Sprintf("⚠ Skipped %d unreadable template(s) (press r to retry):", len(t.problems)))}
+	for i, problem := range t.problems {
+		if i == maxTemplateProblems {
+			lines = append(lines, MutedStyle.Render(fmt.Sprintf("  ... and %d more (see tui.log)", len(t.problems)-maxTemplateProblems)))
+			break
+		}
+		lines = append(lines, MutedStyle.MaxWidth(t.Width()).Render("  "+problem.String()))
+	}
+	return lines
 }
 
 // SetSize updates the tab dimensions
@@ -138,17 +181,18 @@ func (t *TemplatesTab) SetSize(width, height int) {
 
 // updateSizes updates list and viewport sizes based on whether preview is shown
 func (t *TemplatesTab) updateSizes() {
-	if t.selectedTemplate != nil {
+	contentHeight := t.ContentHeight() - len(t.problemLines())
+	if t.previewShown() {
 		// Split view: list on top, preview on bottom
-		listHeight := t.ContentHeight() / 2
-		previewHeight := t.ContentHeight() - listHeight
+		listHeight := contentHeight / 2
+		previewHeight := contentHeight - listHeight
 		t.list.SetSize(t.Width(), listHeight)
 		t.preview.Width = t.Width() - 4
 		// Account for header (1) + BoxStyle border (2) + padding (2) = 5 lines
 		t.preview.Height = previewHeight - 5
 	} else {
 		// Full height for list when no preview
-		t.list.SetSize(t.Width(), t.ContentHeight())
+		t.list.SetSize(t.Width(), contentHeight)
 	}
 }
 
@@ -166,6 +210,12 @@ func (t *TemplatesTab) handleEnter() (Tab, tea.Cmd) {
 		if item.Template != nil {
 			return t, createWorkItemFromTemplate(item.Template)
 		}
+		if item.node != nil && item.node.Err != nil {
+			err := item.node.Err
+			return t, func() tea.Msg {
+				return NotificationMsg{Message: fmt.Sprintf("Cannot use '%s': %v", item.Name, err), IsError: true}
+			}
+		}
 		return t, nil
 	}
 	return t, nil
@@ -178,16 +228,55 @@ func (t *TemplatesTab) updatePreview() {
 		if item.IsDir {
 			t.selectedTemplate = nil
 			t.preview.SetContent(t.formatFolderPreview(item))
-		} else if item.Template != nil {
+		} else if item.Template != nil || item.node != nil && item.node.Err != nil {
 			t.selectedTemplate = item.Template
-			t.preview.SetContent(t.formatTemplatePreview(item.Template))
+			t.selectedBroken = item.Template == nil
+			switch {
+			case t.showRaw:
+				t.preview.SetContent(formatRawTemplate(item.Path))
+			case t.selectedBroken:
+				t.preview.SetContent(formatBrokenTemplate(item))
+			default:
+				t.preview.SetContent(t.formatTemplatePreview(item.Template) + formatTemplateWarnings(item.node))
+			}
+			t.updateSizes()
+			return
 		}
 	} else {
 		t.selectedTemplate = nil
 	}
+	t.selectedBroken = false
 	t.updateSizes()
 }
 
+// previewShown reports whether the preview pane is shown under the list
+func (t *TemplatesTab) previewShown() bool {
+	return t.selectedTemplate != nil || t.selectedBroken
+}
+
+// formatBrokenTemplate explains why a template file cannot be used
+func formatBrokenTemplate(item templateListItem) string {
+	var b strings.Builder
+	b.WriteString(ErrorStyle.Render("✗ "+item.Name) + "\n\n")
+	b.WriteString(item.node.Err.Error() + "\n")
+	b.WriteString(formatTemplateWarnings(item.node))
+	b.WriteString("\n" + MutedStyle.Render("Press y to see its YAML or e to fix it in $EDITOR"))
+	return b.String()
+}
+
+// formatTemplateWarnings lists the unknown keys of a template file, if any
+func formatTemplateWarnings(node *templates.TemplateNode) string {
+	if node == nil || len(node.Warnings) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n" + WarningStyle.Render("⚠ Ignored keys:") + "\n")
+	for _, warning := range node.Warnings {
+		b.WriteString("  " + warning + "\n")
+	}
+	return b.String()
+}
+
 // rebuildList rebuilds the list with current expanded state
 func (t *TemplatesTab) rebuildList() {
 	items := t.flattenTemplates(t.templates, 0)
@@ -229,15 +318,32 @@ func (t *TemplatesTab) flattenTemplates(templateNodes []*templates.TemplateNode,
 // FetchTemplates loads templates from the filesystem
 func (t *TemplatesTab) FetchTemplates() tea.Cmd {
 	return func() tea.Msg {
-		templateNodes, err := templates.ListTree()
+		templateNodes, problems, err := templates.ListTree()
 		if err != nil {
 			return TemplatesLoadedMsg{Error: err}
 		}
 
-		return TemplatesLoadedMsg{Templates: templateNodes}
+		return TemplatesLoadedMsg{Templates: templateNodes, Problems: problems}
 	}
 }
 
+// toggleRawPreview switches the preview between the summary and the raw YAML
+func (t *TemplatesTab) toggleRawPreview() {
+	t.showRaw = !t.showRaw
+	t.updatePreview()
+	t.preview.GotoTop()
+}
+
+// formatRawTemplate returns the highlighted YAML of a template, exactly as
+// stored, so it shows what will be submitted
+func formatRawTemplate(path string) string {
+	data, err := templates.ReadRaw(path)
+	if err != nil {
+		return ErrorStyle.Render(err.Error())
+	}
+	return highlightYAML(strings.TrimRight(string(data), "\n"))
+}
+
 // formatTemplatePreview formats a template for preview display
 func (t *TemplatesTab) formatTemplatePreview(template *templates.Template) string {
 	var b strings.Builder
@@ -289,6 +395,10 @@ func (t *TemplatesTab) formatFolderPreview(item templateListItem) string {
 
 	b.WriteString(TitleStyle.Render("📁 "+item.Name) + "\n\n")
 
+	if item.Path == templates.TeamNamespace {
+		b.WriteString(MutedStyle.Render("Shared team templates: read-only, updated by azb template sync") + "\n\n")
+	}
+
 	if item.node != nil && item.node.Children != nil {
 		count := len(item.node.Children)
 		b.WriteString(fmt.Sprintf("Contains %d item", count))
@@ -334,6 +444,12 @@ func (d templateDelegate) Render(w io.Writer, m list.Model, index int, item list
 			icon = "▶ "
 		}
 		nameStyle = FolderStyle
+	} else if templateItem.node != nil && templateItem.node.Err != nil {
+		icon = "  ✗ "
+		nameStyle = ErrorStyle
+	} else if templateItem.node != nil && len(templateItem.node.Warnings) > 0 {
+		icon = "  ⚠ "
+		nameStyle = WarningStyle
 	} else {
 		icon = "  📄 "
 		nameStyle = FileStyle
@@ -375,7 +491,9 @@ func (t *TemplatesTab) GetHelpEntries() []HelpEntry {
 		{Action: "new_folder", Description: "Create new folder"},
 		{Action: "edit", Description: "Edit template in $EDITOR"},
 		{Action: "rename", Description: "Rename template or folder"},
+		{Action: "move", Description: "Move template to another folder"},
 		{Action: "delete", Description: "Delete template"},
+		{Action: "raw_yaml", Description: "Toggle summary / raw YAML preview"},
 		{Action: "refresh", Description: "Refresh templates list"},
 	}
 }
@@ -386,7 +504,7 @@ func (t *TemplatesTab) handleRenameAction() *InputPrompt {
 	if item, ok := selectedItem.(templateListItem); ok {
 		// Show input prompt for new name
 		prompt := NewInputPrompt()
-		prompt.Show("Enter new name:", item.Name, "rename_template", item.Path)
+		prompt.Show("Enter new name:", item.Name, string(ActionRenameTemplate), item.Path)
 		return prompt
 	}
 	return nil
@@ -395,18 +513,18 @@ func (t *TemplatesTab) handleRenameAction() *InputPrompt {
 // renameTemplate renames a template or folder
 func renameTemplate(oldPath, newName string) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Renaming '%s' to '%s'", oldPath, newName)
+		log.Infof("Renaming '%s' to '%s'", oldPath, newName)
 
 		// Perform rename
 		if err := templates.Rename(oldPath, newName); err != nil {
-			logger.Printf("Failed to rename: %v", err)
+			log.Errorf("Failed to rename: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to rename: %v", err),
 				IsError: true,
 			}
 		}
 
-		logger.Printf("Successfully renamed to '%s'", newName)
+		log.Infof("Successfully renamed to '%s'", newName)
 
 		return TemplateRenamedMsg{
 			OldPath: oldPath,
@@ -437,12 +555,15 @@ func (t *TemplatesTab) handleEditAction() tea.Cmd {
 // prepareEditTemplate opens a template file in the editor
 func prepareEditTemplate(templatePath string) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Preparing to edit template: %s", templatePath)
+		log.Infof("Preparing to edit template: %s", templatePath)
+		if templates.IsTeam(templatePath) {
+			return NotificationMsg{Message: templates.ErrTeamReadOnly.Error(), IsError: true}
+		}
 
 		// Get full path to template
 		templatesDir, err := templates.GetTemplatesDir()
 		if err != nil {
-			logger.Printf("Failed to get templates directory: %v", err)
+			log.Errorf("Failed to get templates directory: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to get templates directory: %v", err),
 				IsError: true,
@@ -453,14 +574,14 @@ func prepareEditTemplate(templatePath string) tea.Cmd {
 
 		// Check if file exists
 		if _, err := os.Stat(fullPath); err != nil {
-			logger.Printf("Template file not found: %v", err)
+			log.Warnf("Template file not found: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Template file not found: %v", err),
 				IsError: true,
 			}
 		}
 
-		logger.Printf("Opening editor for template: %s", fullPath)
+		log.Infof("Opening editor for template: %s", fullPath)
 
 		return OpenEditorForTemplateMsg{
 			FilePath: fullPath,
@@ -477,12 +598,17 @@ func (t *TemplatesTab) handleDeleteAction() tea.Cmd {
 			itemType = "folder"
 		}
 
+		// Deleting a folder removes every template in it - require a typed phrase
+		phrase := ""
+		if item.IsDir {
+			phrase = item.Name
+		}
 		return func() tea.Msg {
-			return ConfirmDeleteTemplateMsg{
-				Path:   item.Path,
-				Name:   item.Name,
-				IsDir:  item.IsDir,
-				Prompt: fmt.Sprintf("Delete %s '%s'?", itemType, item.Name),
+			return ConfirmMsg{
+				Prompt:    fmt.Sprintf("Delete %s '%s'?", itemType, item.Name),
+				Action:    "delete_template",
+				Phrase:    phrase,
+				OnConfirm: deleteTemplate(item.Path, item.IsDir),
 			}
 		}
 	}
@@ -492,11 +618,11 @@ func (t *TemplatesTab) handleDeleteAction() tea.Cmd {
 // deleteTemplate deletes a template or folder
 func deleteTemplate(templatePath string, isDir bool) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Deleting template: %s (isDir: %v)", templatePath, isDir)
+		log.Infof("Deleting template: %s (isDir: %v)", templatePath, isDir)
 
 		templatesDir, err := templates.GetTemplatesDir()
 		if err != nil {
-			logger.Printf("Failed to get templates directory: %v", err)
+			log.Errorf("Failed to get templates directory: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to get templates directory: %v", err),
 				IsError: true,
@@ -508,7 +634,7 @@ func deleteTemplate(templatePath string, isDir bool) tea.Cmd {
 		// Delete file or directory
 		if isDir {
 			if err := os.RemoveAll(fullPath); err != nil {
-				logger.Printf("Failed to delete folder: %v", err)
+				log.Errorf("Failed to delete folder: %v", err)
 				return NotificationMsg{
 					Message: fmt.Sprintf("Failed to delete folder: %v", err),
 					IsError: true,
@@ -516,7 +642,7 @@ func deleteTemplate(templatePath string, isDir bool) tea.Cmd {
 			}
 		} else {
 			if err := os.Remove(fullPath); err != nil {
-				logger.Printf("Failed to delete template: %v", err)
+				log.Errorf("Failed to delete template: %v", err)
 				return NotificationMsg{
 					Message: fmt.Sprintf("Failed to delete template: %v", err),
 					IsError: true,
@@ -524,7 +650,7 @@ func deleteTemplate(templatePath string, isDir bool) tea.Cmd {
 			}
 		}
 
-		logger.Printf("Successfully deleted: %s", templatePath)
+		log.Infof("Successfully deleted: %s", templatePath)
 
 		return TemplateDeletedMsg{
 			TemplatePath: templatePath,
@@ -543,7 +669,7 @@ func (t *TemplatesTab) handleCopyAction() *InputPrompt {
 		}
 
 		prompt := NewInputPrompt()
-		prompt.Show("Copy as:", item.Name+"-copy", "copy_template", item.Path)
+		prompt.Show("Copy as:", item.Name+"-copy", string(ActionCopyTemplate), item.Path)
 		return prompt
 	}
 	return nil
@@ -552,22 +678,33 @@ func (t *TemplatesTab) handleCopyAction() *InputPrompt {
 // copyTemplate copies a template to a new name
 func copyTemplate(oldPath, newName string) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Copying template '%s' to '%s'", oldPath, newName)
+		log.Infof("Copying template '%s' to '%s'", oldPath, newName)
 
 		templatesDir, err := templates.GetTemplatesDir()
 		if err != nil {
-			logger.Printf("Failed to get templates directory: %v", err)
+			log.Errorf("Failed to get templates directory: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to get templates directory: %v", err),
 				IsError: true,
 			}
 		}
 
-		// Read source file
-		sourcePath := filepath.Join(templatesDir, oldPath)
+		if templates.IsTeam(newName) {
+			return NotificationMsg{Message: templates.ErrTeamReadOnly.Error(), IsError: true}
+		}
+
+		// Read source file, which may be a team template
+		sourcePath, err := templates.GetTemplatePath(oldPath)
+		if err != nil {
+			log.Errorf("Failed to find template: %v", err)
+			return NotificationMsg{
+				Message: fmt.Sprintf("Failed to find template: %v", err),
+				IsError: true,
+			}
+		}
 		data, err := os.ReadFile(sourcePath)
 		if err != nil {
-			logger.Printf("Failed to read template: %v", err)
+			log.Errorf("Failed to read template: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to read template: %v", err),
 				IsError: true,
@@ -583,7 +720,7 @@ func copyTemplate(oldPath, newName string) tea.Cmd {
 		// Create parent directories if needed
 		parentDir := filepath.Dir(destPath)
 		if err := os.MkdirAll(parentDir, 0755); err != nil {
-			logger.Printf("Failed to create parent directories: %v", err)
+			log.Errorf("Failed to create parent directories: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to create directories: %v", err),
 				IsError: true,
@@ -592,7 +729,7 @@ func copyTemplate(oldPath, newName string) tea.Cmd {
 
 		// Check if destination already exists
 		if _, err := os.Stat(destPath); err == nil {
-			logger.Printf("Template already exists: %s", newName)
+			log.Warnf("Template already exists: %s", newName)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Template '%s' already exists", newName),
 				IsError: true,
@@ -601,14 +738,14 @@ func copyTemplate(oldPath, newName string) tea.Cmd {
 
 		// Write to destination
 		if err := os.WriteFile(destPath, data, 0600); err != nil {
-			logger.Printf("Failed to write template: %v", err)
+			log.Errorf("Failed to write template: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to write template: %v", err),
 				IsError: true,
 			}
 		}
 
-		logger.Printf("Successfully copied to '%s'", newName)
+		log.Infof("Successfully copied to '%s'", newName)
 
 		return TemplateCopiedMsg{
 			OriginalPath: oldPath,
@@ -618,17 +755,69 @@ func copyTemplate(oldPath, newName string) tea.Cmd {
 	}
 }
 
-// handleNewTemplateAction handles creating a new template (n key)
+// targetFolder returns the folder new templates and folders are created in: the
+// selected folder, or the folder of the selected template ("" for the top level)
+func (t *TemplatesTab) targetFolder() string {
+	item, ok := t.list.SelectedItem().(templateListItem)
+	if !ok {
+		return ""
+	}
+	folder := item.Path
+	if !item.IsDir {
+		folder = filepath.Dir(item.Path)
+	}
+	// Nothing can be created among the read-only team templates
+	if folder == "." || templates.IsTeam(folder) {
+		return ""
+	}
+	return folder
+}
+
+// selectedIsTeam reports whether the selected item is a read-only team template or folder
+func (t *TemplatesTab) selectedIsTeam() bool {
+	item, ok := t.list.SelectedItem().(templateListItem)
+	return ok && templates.IsTeam(item.Path)
+}
+
+// followTemplate selects a template or folder of the Templates tab once the
+// templates next reload, e.g. after it was created or moved
+func (d *Dashboard) followTemplate(path string) {
+	if len(d.tabs) > 2 {
+		if templatesTab, ok := d.tabs[2].(*TemplatesTab); ok {
+			templatesTab.follow = path
+		}
+	}
+}
+
+// templatePath returns the path of the template file with the given name
+func templatePath(name string) string {
+	if strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml") {
+		return name
+	}
+	return name + ".yaml"
+}
+
+// promptIn returns the title of a prompt for a name created in folder
+func promptIn(title, folder string) string {
+	if folder == "" {
+		return title + ":"
+	}
+	return fmt.Sprintf("%s (in %s/):", title, filepath.ToSlash(folder))
+}
+
+// handleNewTemplateAction handles creating a new template (n key) in the
+// selected folder
 func (t *TemplatesTab) handleNewTemplateAction() *InputPrompt {
+	folder := t.targetFolder()
 	prompt := NewInputPrompt()
-	prompt.Show("New template name:", "", "new_template", nil)
+	prompt.Show(promptIn("New template name", folder), "", string(ActionNewTemplate), folder)
 	return prompt
 }
 
 // createNewTemplate creates a new blank template
 func createNewTemplate(name string) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Creating new template: %s", name)
+		log.Infof("Creating new template: %s", name)
 
 		// Create a blank template
 		template := &templates.Template{
@@ -644,14 +833,14 @@ func createNewTemplate(name string) tea.Cmd {
 
 		// Save template (this will create directories if needed)
 		if err := templates.Save(template); err != nil {
-			logger.Printf("Failed to create template: %v", err)
+			log.Errorf("Failed to create template: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to create template: %v", err),
 				IsError: true,
 			}
 		}
 
-		logger.Printf("Successfully created template: %s", name)
+		log.Infof("Successfully created template: %s", name)
 
 		return NotificationMsg{
 			Message: fmt.Sprintf("Created template '%s'", name),
@@ -660,21 +849,22 @@ func createNewTemplate(name string) tea.Cmd {
 	}
 }
 
-// handleNewFolderAction handles creating a new folder (f key)
+// handleNewFolderAction handles creating a new folder (f key) in the selected folder
 func (t *TemplatesTab) handleNewFolderAction() *InputPrompt {
+	folder := t.targetFolder()
 	prompt := NewInputPrompt()
-	prompt.Show("New folder name:", "", "new_folder", nil)
+	prompt.Show(promptIn("New folder name", folder), "", string(ActionNewFolder), folder)
 	return prompt
 }
 
 // createNewFolder creates a new folder in the templates directory
 func createNewFolder(name string) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Creating new folder: %s", name)
+		log.Infof("Creating new folder: %s", name)
 
 		templatesDir, err := templates.GetTemplatesDir()
 		if err != nil {
-			logger.Printf("Failed to get templates directory: %v", err)
+			log.Errorf("Failed to get templates directory: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to get templates directory: %v", err),
 				IsError: true,
@@ -685,7 +875,7 @@ func createNewFolder(name string) tea.Cmd {
 
 		// Check if folder already exists
 		if _, err := os.Stat(folderPath); err == nil {
-			logger.Printf("Folder already exists: %s", name)
+			log.Warnf("Folder already exists: %s", name)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Folder '%s' already exists", name),
 				IsError: true,
@@ -694,14 +884,14 @@ func createNewFolder(name string) tea.Cmd {
 
 		// Create folder
 		if err := os.MkdirAll(folderPath, 0755); err != nil {
-			logger.Printf("Failed to create folder: %v", err)
+			log.Errorf("Failed to create folder: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to create folder: %v", err),
 				IsError: true,
 			}
 		}
 
-		logger.Printf("Successfully created folder: %s", name)
+		log.Infof("Successfully created folder: %s", name)
 
 		return TemplateFolderCreatedMsg{
 			FolderPath: name,
@@ -713,7 +903,7 @@ func createNewFolder(name string) tea.Cmd {
 // createWorkItemFromTemplate creates a work item from the selected template
 func createWorkItemFromTemplate(template *templates.Template) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Creating work item from template: %s", template.Name)
+		log.Infof("Creating work item from template: %s", template.Name)
 		return CreateWorkItemFromTemplateMsg{
 			Template: template,
 		}
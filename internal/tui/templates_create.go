@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/log"
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+// templateTitleStep is asked when the fields of a template's type cannot be loaded
+var templateTitleStep = createStep{Field: "System.Title", Name: "Title", Required: true}
+
+// loadTemplateSteps fetches the fields of a template's work item type and
+// returns the steps to ask before creating a work item from it
+func loadTemplateSteps(client api.APIClient, template *templates.Template) tea.Cmd {
+	return func() tea.Msg {
+		steps := []createStep{templateTitleStep}
+		fields, err := client.GetWorkItemTypeFields(template.Type)
+		if err != nil {
+			// The title can still be asked; Azure DevOps reports what else is missing
+			log.Warnf("Failed to fetch fields of '%s', asking for the title only: %v", template.Type, err)
+		} else if fields != nil {
+			steps = buildCreateSteps(*fields)
+		}
+		return CreateStepsLoadedMsg{WorkItemType: template.Type, Template: template, Steps: templateSteps(template, steps)}
+	}
+}
+
+// templateSteps keeps the steps a template still needs answered: the title,
+// starting from the template's, the required fields it leaves empty and the
+// prefilled area path and iteration when it leaves them empty
+func templateSteps(template *templates.Template, steps []createStep) []createStep {
+	var kept []createStep
+	for _, step := range steps {
+		value := ""
+		if v, ok := template.Fields[step.Field]; ok && v != nil {
+			value = fmt.Sprint(v)
+		}
+		switch {
+		case step.Field == "System.Title":
+			step.Default = value
+		case value != "", !step.Required && step.Default == "":
+			continue
+		}
+		kept = append(kept, step)
+	}
+	return kept
+}
+
+// filledTemplate returns a copy of the wizard's template with the answers set
+func (w *createWizard) filledTemplate() *templates.Template {
+	filled := *w.template
+	filled.Fields = make(map[string]interface{}, len(w.template.Fields)+len(w.fields))
+	for field, value := range w.template.Fields {
+		filled.Fields[field] = value
+	}
+	for field, value := range w.fields {
+		filled.Fields[field] = value
+	}
+	return &filled
+}
+
+// action returns the action the wizard answers
+func (w *createWizard) action() ActionType {
+	if w.template != nil {
+		return ActionCreateTemplate
+	}
+	return ActionCreateWorkItem
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api/apitest"
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+func TestTemplateSteps(t *testing.T) {
+	steps := []createStep{
+		{Field: "System.Title", Name: "Title", Required: true},
+		{Field: "Microsoft.VSTS.Common.Severity", Name: "Severity", Required: true, Allowed: []string{"1 - Critical", "2 - High"}},
+		{Field: "Custom.Team", Name: "Team", Required: true},
+		{Field: "System.Tags", Name: "Tags"},
+		{Field: "System.AreaPath", Name: "Area Path", Default: `Fabrikam\Web`},
+	}
+
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+		want   []createStep
+	}{
+		{
+			name:   "title and missing required fields",
+			fields: map[string]interface{}{"System.Title": "Regression: ", "Custom.Team": "Core", "System.AreaPath": `Fabrikam\API`},
+			want: []createStep{
+				{Field: "System.Title", Name: "Title", Required: true, Default: "Regression: "},
+				{Field: "Microsoft.VSTS.Common.Severity", Name: "Severity", Required: true, Allowed: []string{"1 - Critical", "2 - High"}},
+			},
+		},
+		{
+			name:   "empty required value is missing",
+			fields: map[string]interface{}{"Microsoft.VSTS.Common.Severity": "2 - High", "Custom.Team": ""},
+			want: []createStep{
+				{Field: "System.Title", Name: "Title", Required: true},
+				{Field: "Custom.Team", Name: "Team", Required: true},
+				{Field: "System.AreaPath", Name: "Area Path", Default: `Fabrikam\Web`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := templateSteps(&templates.Template{Fields: tt.fields}, steps)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("templateSteps() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDashboardCreateFromTemplate(t *testing.T) {
+	client := apitest.New()
+	client.GetWorkItemTypeFieldsFunc = func(string) (*[]workitemtracking.WorkItemTypeFieldWithReferences, error) {
+		title, severity, required := "System.Title", "Microsoft.VSTS.Common.Severity", true
+		return &[]workitemtracking.WorkItemTypeFieldWithReferences{
+			{ReferenceName: &title, AlwaysRequired: &required},
+			{ReferenceName: &severity, AlwaysRequired: &required},
+		}, nil
+	}
+	var created map[string]interface{}
+	client.CreateWorkItemFunc = func(workItemType string, fields map[string]interface{}, parentID int) (*workitemtracking.WorkItem, error) {
+		created = fields
+		id := 7
+		return &workitemtracking.WorkItem{Id: &id}, nil
+	}
+	d := newTestDashboard(client)
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	template := &templates.Template{Name: "regression", Type: "Bug", Fields: map[string]interface{}{
+		"System.Title": "Regression: ",
+		"System.Tags":  "regression",
+	}}
+	_, cmd := d.Update(CreateWorkItemFromTemplateMsg{Template: template})
+	if cmd == nil {
+		t.Fatal("expected the template's fields to load")
+	}
+	d.Update(cmd())
+	if !d.inputPrompt.Active || d.inputPrompt.Input.Value() != "Regression: " {
+		t.Fatalf("title prompt active = %v, value = %q, want the template's title", d.inputPrompt.Active, d.inputPrompt.Input.Value())
+	}
+
+	d.inputPrompt.Input.SetValue("Regression: login fails")
+	d.Update(enter)
+	d.inputPrompt.Input.SetValue("2 - High")
+	_, cmd = d.Update(enter)
+	if cmd == nil {
+		t.Fatal("expected the work item to be created")
+	}
+	cmd()
+
+	want := map[string]interface{}{
+		"System.Title":                   "Regression: login fails",
+		"System.Tags":                    "regression",
+		"Microsoft.VSTS.Common.Severity": "2 - High",
+	}
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("created fields = %v, want %v", created, want)
+	}
+	if template.Fields["System.Title"] != "Regression: " {
+		t.Error("the template itself was changed")
+	}
+}
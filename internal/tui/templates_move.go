@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/SOMUCHDOG/azb/internal/log"
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+// topLevelFolder is the folder picker's option for the top of the templates directory
+const topLevelFolder = "(top level)"
+
+// moveTargets returns the selected template and the folders it can be moved to,
+// leaving out the folder it is already in
+func (t *TemplatesTab) moveTargets() (string, []string, error) {
+	item, ok := t.list.SelectedItem().(templateListItem)
+	if !ok {
+		return "", nil, nil
+	}
+	if item.IsDir {
+		return "", nil, fmt.Errorf("only templates can be moved; rename '%s' to move a folder", item.Name)
+	}
+
+	folders, err := templates.ListFolders()
+	if err != nil {
+		return "", nil, err
+	}
+
+	current := filepath.Dir(item.Path)
+	var targets []string
+	if current != "." {
+		targets = append(targets, topLevelFolder)
+	}
+	for _, folder := range folders {
+		if folder != current {
+			targets = append(targets, folder)
+		}
+	}
+	if len(targets) == 0 {
+		return "", nil, fmt.Errorf("no other folder to move '%s' to; create one with the new folder action", item.Name)
+	}
+	return item.Path, targets, nil
+}
+
+// moveTemplate moves a template into the folder picked for it
+func moveTemplate(path, folder string) tea.Cmd {
+	return func() tea.Msg {
+		if folder == topLevelFolder {
+			folder = ""
+		}
+		newPath, err := templates.Move(path, folder)
+		if err != nil {
+			log.Errorf("Failed to move template '%s': %v", path, err)
+		} else {
+			log.Infof("Moved template '%s' to '%s'", path, newPath)
+		}
+		return TemplateMovedMsg{OldPath: path, NewPath: newPath, Error: err}
+	}
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+func TestTemplatesTabMoveTargets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	viper.Set("templates_dir", dir)
+	defer viper.Set("templates_dir", "")
+	for _, folder := range []string{"bugs", "stories/epics"} {
+		if err := os.MkdirAll(filepath.Join(dir, folder), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tab := NewTemplatesTab(nil, 80, 24)
+	tab.expandedFolders["bugs"] = true
+	tab.Update(TemplatesLoadedMsg{Templates: []*templates.TemplateNode{
+		{Name: "bugs", Path: "bugs", IsDir: true, Children: []*templates.TemplateNode{
+			{Name: "regression", Path: "bugs/regression.yaml", Template: &templates.Template{}},
+		}},
+		{Name: "task", Path: "task.yaml", Template: &templates.Template{}},
+	}})
+
+	tests := []struct {
+		index   int
+		want    string
+		wantErr bool
+	}{
+		{index: 0, wantErr: true}, // a folder
+		{index: 1, want: "[(top level) stories stories/epics]"},
+		{index: 2, want: "[bugs stories stories/epics]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.index), func(t *testing.T) {
+			tab.list.Select(tt.index)
+			_, folders, err := tab.moveTargets()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("moveTargets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := fmt.Sprint(folders); !tt.wantErr && got != tt.want {
+				t.Errorf("moveTargets() folders = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+func TestTemplatesTabNewInSelectedFolder(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tab := NewTemplatesTab(nil, 80, 24)
+	tab.expandedFolders["bugs"] = true
+	tab.Update(TemplatesLoadedMsg{Templates: []*templates.TemplateNode{
+		{Name: "bugs", Path: "bugs", IsDir: true, Children: []*templates.TemplateNode{
+			{Name: "regression", Path: "bugs/regression.yaml", Template: &templates.Template{}},
+		}},
+		{Name: "task", Path: "task.yaml", Template: &templates.Template{}},
+	}})
+
+	tests := []struct {
+		index      int
+		wantFolder string
+		wantTitle  string
+	}{
+		{index: 0, wantFolder: "bugs", wantTitle: "New template name (in bugs/):"},
+		{index: 1, wantFolder: "bugs", wantTitle: "New template name (in bugs/):"},
+		{index: 2, wantFolder: "", wantTitle: "New template name:"},
+	}
+
+	for _, tt := range tests {
+		tab.list.Select(tt.index)
+		prompt := tab.handleNewTemplateAction()
+		if prompt.Context != tt.wantFolder {
+			t.Errorf("item %d: folder = %v, want %q", tt.index, prompt.Context, tt.wantFolder)
+		}
+		if prompt.Title != tt.wantTitle {
+			t.Errorf("item %d: title = %q, want %q", tt.index, prompt.Title, tt.wantTitle)
+		}
+	}
+}
+
+func TestTemplatesTabBrokenTemplate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tab := NewTemplatesTab(nil, 80, 60)
+	tab.Update(TemplatesLoadedMsg{Templates: []*templates.TemplateNode{
+		{Name: "broken", Path: "broken.yaml", Err: errors.New("failed to parse template"), Warnings: []string{"line 2: unknown key 'feilds'"}},
+	}})
+
+	if !tab.previewShown() {
+		t.Fatal("expected the preview of the broken template")
+	}
+	for _, want := range []string{"failed to parse template", "unknown key 'feilds'"} {
+		if !strings.Contains(tab.preview.View(), want) {
+			t.Errorf("preview does not show %q:\n%s", want, tab.preview.View())
+		}
+	}
+
+	_, cmd := tab.handleEnter()
+	if cmd == nil {
+		t.Fatal("expected a notification")
+	}
+	if msg, ok := cmd().(NotificationMsg); !ok || !msg.IsError {
+		t.Errorf("handleEnter() = %+v, want an error notification", msg)
+	}
+}
+
+func TestTemplatesTabProblemsFitHeight(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tab := NewTemplatesTab(nil, 80, 40)
+	var nodes []*templates.TemplateNode
+	for i := 0; i < 30; i++ {
+		name := fmt.Sprintf("bug-%02d", i)
+		nodes = append(nodes, &templates.TemplateNode{Name: name, Path: name + ".yaml", Template: &templates.Template{Name: name, Type: "Bug"}})
+	}
+	tab.Update(TemplatesLoadedMsg{Templates: nodes, Problems: []templates.Problem{
+		{Path: "a.yaml", Err: errors.New("bad")},
+		{Path: "b.yaml", Err: errors.New("bad")},
+	}})
+
+	if !tab.previewShown() {
+		t.Fatal("expected the preview of the first template")
+	}
+	view := tab.View()
+	lines := strings.Split(view, "\n")
+	if got := lipgloss.Height(view); got > tab.ContentHeight() || !strings.HasPrefix(lines[len(lines)-1], "╰") {
+		t.Errorf("View() is %d lines high, want the whole preview in %d:\n%s", got, tab.ContentHeight(), view)
+	}
+}
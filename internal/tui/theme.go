@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultThemeName is used when no theme is configured
+const DefaultThemeName = "dark"
+
+// Theme assigns a color to each role in the TUI. Colors are ANSI color
+// numbers ("6", "62") or hex values ("#2aa198").
+type Theme struct {
+	Primary   string      `yaml:"primary"`
+	Secondary string      `yaml:"secondary"`
+	Accent    string      `yaml:"accent"`
+	Success   string      `yaml:"success"`
+	Warning   string      `yaml:"warning"`
+	Error     string      `yaml:"error"`
+	Info      string      `yaml:"info"`
+	Muted     string      `yaml:"muted"`
+	Normal    string      `yaml:"normal"`
+	Highlight string      `yaml:"highlight"` // Text on secondary backgrounds, e.g. list titles
+	States    ThemeStates `yaml:"states"`
+}
+
+// ThemeStates are the colors of work item states
+type ThemeStates struct {
+	Active  string `yaml:"active"`
+	New     string `yaml:"new"`
+	Closed  string `yaml:"closed"`
+	Blocked string `yaml:"blocked"`
+}
+
+// builtinThemes are the themes that can be selected with 'azb config set theme <name>'
+var builtinThemes = map[string]Theme{
+	"dark": {
+		Primary:   "6",
+		Secondary: "62",
+		Accent:    "170",
+		Success:   "10",
+		Warning:   "11",
+		Error:     "9",
+		Info:      "12",
+		Muted:     "8",
+		Normal:    "7",
+		Highlight: "230",
+		States:    ThemeStates{Active: "10", New: "12", Closed: "8", Blocked: "9"},
+	},
+	"light": {
+		Primary:   "31",
+		Secondary: "25",
+		Accent:    "125",
+		Success:   "28",
+		Warning:   "130",
+		Error:     "160",
+		Info:      "26",
+		Muted:     "245",
+		Normal:    "235",
+		Highlight: "231",
+		States:    ThemeStates{Active: "28", New: "26", Closed: "245", Blocked: "160"},
+	},
+	"solarized": {
+		Primary:   "#2aa198",
+		Secondary: "#6c71c4",
+		Accent:    "#d33682",
+		Success:   "#859900",
+		Warning:   "#b58900",
+		Error:     "#dc322f",
+		Info:      "#268bd2",
+		Muted:     "#586e75",
+		Normal:    "#839496",
+		Highlight: "#fdf6e3",
+		States:    ThemeStates{Active: "#859900", New: "#268bd2", Closed: "#586e75", Blocked: "#dc322f"},
+	},
+}
+
+// ThemeNames returns the names of the built-in themes
+func ThemeNames() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadTheme returns the named built-in theme with any colors from
+// ~/.azure-boards-cli/theme.yaml layered on top. An empty name selects the default theme.
+func LoadTheme(name string) (*Theme, error) {
+	if name == "" {
+		name = DefaultThemeName
+	}
+
+	base, ok := builtinThemes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme '%s' (available: %v)", name, ThemeNames())
+	}
+	theme := base
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return &theme, nil
+	}
+
+	themePath := filepath.Join(homeDir, ".azure-boards-cli", "theme.yaml")
+	data, err := os.ReadFile(themePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &theme, nil
+		}
+		return nil, fmt.Errorf("failed to read theme file: %w", err)
+	}
+
+	var custom Theme
+	if err := yaml.Unmarshal(data, &custom); err != nil {
+		return nil, fmt.Errorf("failed to parse theme file %s: %w", themePath, err)
+	}
+	theme.merge(custom)
+
+	return &theme, nil
+}
+
+// merge overrides colors that are set in other
+func (t *Theme) merge(other Theme) {
+	override := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	override(&t.Primary, other.Primary)
+	override(&t.Secondary, other.Secondary)
+	override(&t.Accent, other.Accent)
+	override(&t.Success, other.Success)
+	override(&t.Warning, other.Warning)
+	override(&t.Error, other.Error)
+	override(&t.Info, other.Info)
+	override(&t.Muted, other.Muted)
+	override(&t.Normal, other.Normal)
+	override(&t.Highlight, other.Highlight)
+	override(&t.States.Active, other.States.Active)
+	override(&t.States.New, other.States.New)
+	override(&t.States.Closed, other.States.Closed)
+	override(&t.States.Blocked, other.States.Blocked)
+}
+
+// ApplyTheme sets the color palette and rebuilds the common styles.
+// Call it before creating the dashboard.
+func ApplyTheme(theme *Theme) {
+	ColorPrimary = theme.Primary
+	ColorSecondary = theme.Secondary
+	ColorAccent = theme.Accent
+	ColorSuccess = theme.Success
+	ColorWarning = theme.Warning
+	ColorError = theme.Error
+	ColorInfo = theme.Info
+	ColorMuted = theme.Muted
+	ColorNormal = theme.Normal
+	ColorYellow = theme.Highlight
+	ColorStateActive = theme.States.Active
+	ColorStateNew = theme.States.New
+	ColorStateClosed = theme.States.Closed
+	ColorStateBlocked = theme.States.Blocked
+
+	buildStyles()
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTheme(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	t.Run("default theme", func(t *testing.T) {
+		theme, err := LoadTheme("")
+		if err != nil {
+			t.Fatalf("LoadTheme() error = %v", err)
+		}
+		if theme.Primary != builtinThemes[DefaultThemeName].Primary {
+			t.Errorf("Primary = %q, want default", theme.Primary)
+		}
+	})
+
+	t.Run("unknown theme", func(t *testing.T) {
+		if _, err := LoadTheme("neon"); err == nil {
+			t.Error("LoadTheme() expected error for unknown theme")
+		}
+	})
+
+	t.Run("theme file overrides built-in colors", func(t *testing.T) {
+		dir := filepath.Join(home, ".azure-boards-cli")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		content := "primary: \"#ff0000\"\nstates:\n  blocked: \"201\"\n"
+		if err := os.WriteFile(filepath.Join(dir, "theme.yaml"), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		theme, err := LoadTheme("light")
+		if err != nil {
+			t.Fatalf("LoadTheme() error = %v", err)
+		}
+		if theme.Primary != "#ff0000" {
+			t.Errorf("Primary = %q, want %q", theme.Primary, "#ff0000")
+		}
+		if theme.States.Blocked != "201" {
+			t.Errorf("States.Blocked = %q, want %q", theme.States.Blocked, "201")
+		}
+		if theme.Error != builtinThemes["light"].Error {
+			t.Errorf("Error = %q, want light theme value", theme.Error)
+		}
+	})
+}
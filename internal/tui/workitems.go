@@ -5,11 +5,15 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -17,13 +21,18 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/log"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
+	"github.com/SOMUCHDOG/azb/internal/recent"
+	"github.com/SOMUCHDOG/azb/internal/snooze"
 	"github.com/SOMUCHDOG/azb/internal/templates"
 )
 
 // WorkItemsTab displays and manages work items
 type WorkItemsTab struct {
 	TabBase
-	client           *api.Client
+	client           api.APIClient
 	workItems        []workitemtracking.WorkItem
 	workItemCache    map[int]*workitemtracking.WorkItem
 	relationshipData map[int]*relationshipInfo
@@ -32,10 +41,38 @@ type WorkItemsTab struct {
 	selectedItem     *workitemtracking.WorkItem
 	showDetails      bool
 	loading          bool
+	spinner          spinner.Model // Animated while work items are loading
 	//nolint:unused // Reserved for future feature: async relationship loading
 	loadingRelations bool
 	initialized      bool
 	err              error
+	treeView         bool
+	expandedItems    map[int]bool
+	loadingItems     map[int]bool
+	comments         map[int][]workitemtracking.Comment
+	loadingComments  map[int]bool
+	columns          []ColumnConfig
+	sort             SortConfig
+	pinned           *workitemtracking.WorkItem // Work item pinned for comparison
+	comparing        bool
+	compareItems     [2]workitemtracking.WorkItem
+	compareView      viewport.Model
+	closeCompare     key.Binding // keys that close the comparison, see setCompareKeys
+	showingHistory   bool
+	historyFor       int // Work item whose history is shown
+	history          []api.WorkItemRevision
+	historyErr       error
+	historyLoading   bool
+	historyView      viewport.Model
+	views            []WorkItemView // Queries the tab can show, the first being the default
+	viewIndex        int
+	showingRecent    bool          // Recent view shown instead of views[viewIndex]
+	loadedAt         time.Time     // When the view was last loaded
+	refreshing       bool          // Auto-refresh running in the background
+	refreshID        int           // Latest scheduled auto-refresh
+	refreshBackoff   time.Duration // Delay of the last held off or failed auto-refresh
+	layout           DetailsLayout // Where the details pane is placed
+	detailsSize      int           // Percent of the tab the details pane takes
 }
 
 // relationshipInfo stores formatted relationship data for a work item
@@ -45,28 +82,42 @@ type relationshipInfo struct {
 	//nolint:unused // Reserved for future feature: display child work items
 	children []string
 	//nolint:unused // Reserved for future feature: display related pull requests
-	prs []string
-	//nolint:unused // Reserved for future feature: display related deployments
-	deployments []string
-	//nolint:unused // Reserved for future feature: track relationship loading state
-	loaded bool
+	prs         []string
+	deployments []string // Build, release and Git links, with builds resolved
+	loaded      bool
 }
 
 // NewWorkItemsTab creates a new work items tab
-func NewWorkItemsTab(client *api.Client, width, height int) *WorkItemsTab {
+func NewWorkItemsTab(client api.APIClient, width, height int) *WorkItemsTab {
 	tab := &WorkItemsTab{
 		TabBase:          NewTabBase(width, height),
 		client:           client,
 		workItemCache:    make(map[int]*workitemtracking.WorkItem),
 		relationshipData: make(map[int]*relationshipInfo),
+		expandedItems:    make(map[int]bool),
+		loadingItems:     make(map[int]bool),
+		comments:         make(map[int][]workitemtracking.Comment),
+		loadingComments:  make(map[int]bool),
 		loading:          false, // Don't load until properly initialized
+		views:            workItemViews,
+		layout:           detailsLayout,
+		detailsSize:      detailsSize,
 	}
 
+	// Load configurable columns and sort order
+	listConfig, err := LoadListConfig()
+	if err != nil {
+		log.Warnf("Failed to load columns config: %v, using defaults", err)
+	}
+	tab.columns = listConfig.Columns
+	tab.sort = listConfig.Sort
+
 	// Initialize list
-	tab.list = list.New([]list.Item{}, workItemDelegate{}, width, tab.ContentHeight())
-	tab.list.Title = "Work Items"
+	tab.list = list.New([]list.Item{}, workItemDelegate{columns: tab.columns}, width, tab.ContentHeight())
+	tab.list.Title = tab.listTitle()
 	tab.list.SetShowStatusBar(true)
 	tab.list.SetFilteringEnabled(true)
+	tab.list.KeyMap.Filter = listFilterKey
 	tab.list.Styles.Title = lipgloss.NewStyle().
 		Background(lipgloss.Color(ColorSecondary)).
 		Foreground(lipgloss.Color(ColorYellow)).
@@ -74,6 +125,13 @@ func NewWorkItemsTab(client *api.Client, width, height int) *WorkItemsTab {
 
 	// Initialize viewport
 	tab.viewport = viewport.New(width-4, tab.ContentHeight()/2-4)
+	tab.compareView = viewport.New(width, tab.ContentHeight()-2)
+	tab.setCompareKeys(key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close comparison")), key.NewBinding(key.WithKeys("p")))
+	tab.historyView = viewport.New(width, tab.ContentHeight()-2)
+	tab.spinner = spinner.New(
+		spinner.WithSpinner(spinner.Dot),
+		spinner.WithStyle(lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimary))),
+	)
 
 	return tab
 }
@@ -103,25 +161,71 @@ func (t *WorkItemsTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		// Trigger initial fetch when we get proper dimensions for the first time
 		if !t.initialized && !t.loading && msg.Width > 0 && msg.Height > 0 {
-			logger.Printf("WorkItemsTab: Triggering initial fetch (dimensions: %dx%d)", msg.Width, msg.Height)
+			log.Debugf("WorkItemsTab: Triggering initial fetch (dimensions: %dx%d)", msg.Width, msg.Height)
 			t.initialized = true
 			t.loading = true
 			return t, t.fetchWorkItems()
 		}
-		logger.Printf("WorkItemsTab: WindowSizeMsg received (initialized=%v, loading=%v, %dx%d)",
+		log.Debugf("WorkItemsTab: WindowSizeMsg received (initialized=%v, loading=%v, %dx%d)",
 			t.initialized, t.loading, msg.Width, msg.Height)
 
 	case WorkItemsLoadedMsg:
-		logger.Printf("WorkItemsTab: Received WorkItemsLoadedMsg with %d items (error: %v)", len(msg.WorkItems), msg.Error)
+		log.Debugf("WorkItemsTab: Received WorkItemsLoadedMsg with %d items (error: %v)", len(msg.WorkItems), msg.Error)
+		background := t.refreshing
 		t.loading = false
+		t.refreshing = false
 		if msg.Error != nil {
+			if background {
+				return t, t.handleRefreshFailed(msg.Error)
+			}
 			t.err = msg.Error
 			return t, nil
 		}
+		selected, _ := t.list.SelectedItem().(workItemItem)
 		t.workItems = msg.WorkItems
+		t.loadedAt = time.Now()
+		t.refreshBackoff = 0
+		t.list.Title = t.listTitle()
 		t.rebuildList()
+		if background {
+			t.keepSelection(selected.ID)
+		}
+		cmds := []tea.Cmd{t.scheduleRefresh(refreshInterval)}
+		if len(msg.Resurfaced) > 0 {
+			message := "Snooze ended: " + strings.Join(msg.Resurfaced, ", ")
+			cmds = append(cmds, func() tea.Msg {
+				return NotificationMsg{Message: message}
+			})
+		}
+		if t.treeView {
+			cmds = append(cmds, t.fetchTreeParents())
+		}
+		return t, tea.Batch(cmds...)
+
+	case WorkItemTreeLoadedMsg:
+		return t, t.handleTreeLoaded(msg)
+
+	case WorkItemCommentsLoadedMsg:
+		return t, t.handleCommentsLoaded(msg)
+
+	case CommentAddedMsg:
+		return t, t.handleCommentAdded(msg)
+
+	case WorkItemHistoryLoadedMsg:
+		t.handleHistoryLoaded(msg)
 		return t, nil
 
+	case AutoRefreshMsg:
+		return t, t.handleAutoRefresh(msg)
+
+	case spinner.TickMsg:
+		// Let the spinner stop once loading has finished
+		if !t.loading {
+			return t, nil
+		}
+		t.spinner, cmd = t.spinner.Update(msg)
+		return t, cmd
+
 	case QueryExecutedMsg:
 		// Handle query results
 		t.loading = false
@@ -131,10 +235,28 @@ func (t *WorkItemsTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 			}
 		}
 		t.workItems = msg.WorkItems
+		// Query results are not reloaded, so auto-refresh pauses until the next load
+		t.refreshID++
+		t.loadedAt = time.Time{}
+		t.list.Title = t.listTitle()
 		t.rebuildList()
-		return t, func() tea.Msg {
+		notify := func() tea.Msg {
 			return NotificationMsg{Message: fmt.Sprintf("Loaded %d work items", len(msg.WorkItems)), IsError: false}
 		}
+		if t.treeView {
+			return t, tea.Batch(notify, t.fetchTreeParents())
+		}
+		return t, notify
+
+	case WorkItemCreatedMsg:
+		if msg.Error == nil {
+			org := t.client.GetOrganizationURL()
+			return t, func() tea.Msg {
+				recent.TrackWorkItem(org, msg.WorkItem, recent.Created)
+				return nil
+			}
+		}
+		return t, nil
 
 	case WorkItemDeletedMsg:
 		if msg.Error != nil {
@@ -157,8 +279,13 @@ func (t *WorkItemsTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 		}
 		// Trigger a refresh to get the latest work item data
 		t.loading = true
+		org := t.client.GetOrganizationURL()
 		return t, tea.Batch(
 			t.fetchWorkItems(),
+			func() tea.Msg {
+				recent.TrackWorkItem(org, msg.WorkItem, recent.Edited)
+				return nil
+			},
 			func() tea.Msg {
 				return NotificationMsg{
 					Message: fmt.Sprintf("Work item #%d updated successfully", *msg.WorkItem.Id),
@@ -168,6 +295,12 @@ func (t *WorkItemsTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 		)
 
 	case tea.KeyMsg:
+		if t.comparing {
+			return t, t.updateComparison(msg)
+		}
+		if t.showingHistory {
+			return t, t.updateHistory(msg)
+		}
 		switch {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
 			// Toggle details view
@@ -176,16 +309,22 @@ func (t *WorkItemsTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 				selectedItem := t.list.SelectedItem()
 				if item, ok := selectedItem.(workItemItem); ok {
 					t.selectedItem = &item.workItem
+					org := t.client.GetOrganizationURL()
+					cmd = tea.Batch(t.loadComments(item.ID), func() tea.Msg {
+						recent.TrackWorkItem(org, &item.workItem, recent.Viewed)
+						return nil
+					})
 					t.viewport.SetContent(t.formatWorkItemDetails(item.workItem))
 				}
 			}
 			t.updateSizes()
-			return t, nil
+			return t, cmd
 
 		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
 			t.loading = true
 			t.workItemCache = make(map[int]*workitemtracking.WorkItem)
 			t.relationshipData = make(map[int]*relationshipInfo)
+			t.comments = make(map[int][]workitemtracking.Comment)
 			return t, t.fetchWorkItems()
 
 		case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
@@ -219,6 +358,7 @@ func (t *WorkItemsTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 			// Only update if selection actually changed
 			if currentID != previousID {
 				t.selectedItem = &item.workItem
+				cmds = append(cmds, t.loadComments(currentID))
 				t.viewport.SetContent(t.formatWorkItemDetails(item.workItem))
 				t.viewport.GotoTop() // Reset scroll position for new item
 			}
@@ -231,17 +371,28 @@ func (t *WorkItemsTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 // View renders the tab
 func (t *WorkItemsTab) View() string {
 	if t.loading {
-		return RenderLoading("Loading work items...")
+		return RenderLoading(t.spinner.View() + " Loading work items...")
 	}
 
 	if t.err != nil {
 		return RenderErrorWithRetry(t.err)
 	}
 
+	if t.comparing {
+		return t.viewComparison()
+	}
+
+	if t.showingHistory {
+		return t.viewHistory()
+	}
+
 	if t.showDetails {
 		listView := t.list.View()
 		detailsPane := RenderDetailsPane("Work Item Details", t.viewport.View())
 		combined := lipgloss.JoinVertical(lipgloss.Left, listView, detailsPane)
+		if t.sideBySide() {
+			combined = lipgloss.JoinHorizontal(lipgloss.Top, listView, detailsPane)
+		}
 
 		// Ensure total height doesn't exceed ContentHeight()
 		maxHeight := t.ContentHeight()
@@ -259,8 +410,13 @@ func (t *WorkItemsTab) SetSize(width, height int) {
 
 // updateSizes updates list and viewport sizes based on view mode
 func (t *WorkItemsTab) updateSizes() {
-	if t.showDetails {
-		listHeight := t.ContentHeight() / 2
+	if t.showDetails && t.sideBySide() {
+		detailsWidth := t.Width() * t.detailsSize / 100
+		t.list.SetSize(t.Width()-detailsWidth, t.ContentHeight())
+		t.viewport.Width = detailsWidth - 4
+		t.viewport.Height = t.ContentHeight() - 5
+	} else if t.showDetails {
+		listHeight := t.ContentHeight() * (100 - t.detailsSize) / 100
 		detailsHeight := t.ContentHeight() - listHeight
 		t.list.SetSize(t.Width(), listHeight)
 		t.viewport.Width = t.Width() - 4
@@ -269,24 +425,36 @@ func (t *WorkItemsTab) updateSizes() {
 	} else {
 		t.list.SetSize(t.Width(), t.ContentHeight())
 	}
+	if t.comparing {
+		t.compareView.Width = t.Width()
+		t.compareView.Height = t.ContentHeight() - 2
+		t.compareView.SetContent(t.formatComparison())
+	}
+	if t.showingHistory {
+		t.historyView.Width = t.Width()
+		t.historyView.Height = t.ContentHeight() - 2
+		if !t.historyLoading {
+			t.historyView.SetContent(t.formatHistory())
+		}
+	}
 }
 
 // rebuildList rebuilds the list with current work items
 func (t *WorkItemsTab) rebuildList() {
-	items := make([]list.Item, 0, len(t.workItems))
-	for _, wi := range t.workItems {
-		id := 0
-		if wi.Id != nil {
-			id = *wi.Id
-		}
+	t.list.SetDelegate(workItemDelegate{
+		columns:       t.columns,
+		treeView:      t.treeView,
+		expandedItems: t.expandedItems,
+		loadingItems:  t.loadingItems,
+	})
+	if t.treeView {
+		t.list.SetItems(t.flattenTree())
+		return
+	}
 
-		items = append(items, workItemItem{
-			ID:         id,
-			Title:      getStringField(&wi, "System.Title"),
-			State:      getStringField(&wi, "System.State"),
-			AssignedTo: cleanAssignedTo(getStringField(&wi, "System.AssignedTo")),
-			workItem:   wi,
-		})
+	items := make([]list.Item, 0, len(t.workItems))
+	for _, wi := range t.sortedWorkItems() {
+		items = append(items, newWorkItemItem(&wi))
 	}
 	t.list.SetItems(items)
 }
@@ -301,22 +469,30 @@ func (t *WorkItemsTab) removeWorkItem(id int) {
 	}
 }
 
-// fetchWorkItems loads work items from the API
+// fetchWorkItems loads work items from the API and animates the loading spinner meanwhile
 func (t *WorkItemsTab) fetchWorkItems() tea.Cmd {
-	return func() tea.Msg {
-		logger.Printf("WorkItemsTab: Starting fetchWorkItems()")
-		// Default query: User Stories assigned to me, excluding closed and removed items
-		wiql := "SELECT [System.Id], [System.Title], [System.State], [System.AssignedTo], [System.WorkItemType], " +
-			"[System.Description], [Microsoft.VSTS.Common.AcceptanceCriteria], [System.CreatedDate], " +
-			"[System.ChangedDate], [Microsoft.VSTS.Common.Priority], [System.Tags] " +
-			"FROM WorkItems WHERE [System.AssignedTo] = @me AND [System.WorkItemType] = 'User Story' " +
-			"AND [System.State] <> 'Closed' AND [System.State] <> 'Removed' " +
-			"ORDER BY [System.State] ASC"
-
-		logger.Printf("WorkItemsTab: Executing WIQL query")
+	view := t.currentView()
+	defaultView := !t.showingRecent && t.viewIndex == 0
+	return tea.Batch(t.spinner.Tick, func() tea.Msg {
+		log.Debugf("WorkItemsTab: Starting fetchWorkItems()")
+		if view.recent {
+			workItems, err := fetchRecentWorkItems(t.client)
+			if err != nil {
+				log.Errorf("WorkItemsTab: Error fetching recent work items: %v", err)
+			}
+			return WorkItemsLoadedMsg{WorkItems: workItems, Error: err}
+		}
+
+		wiql, err := viewWIQL(t.client, view)
+		if err != nil {
+			log.Errorf("WorkItemsTab: Error resolving view '%s': %v", view.Name, err)
+			return WorkItemsLoadedMsg{Error: err}
+		}
+
+		log.Debugf("WorkItemsTab: Executing WIQL query for view '%s'", view.Name)
 		workItemsPtr, err := t.client.ListWorkItems(wiql, 100)
 		if err != nil {
-			logger.Printf("WorkItemsTab: Error fetching work items: %v", err)
+			log.Errorf("WorkItemsTab: Error fetching work items: %v", err)
 			return WorkItemsLoadedMsg{Error: err}
 		}
 
@@ -325,9 +501,66 @@ func (t *WorkItemsTab) fetchWorkItems() tea.Cmd {
 			workItems = *workItemsPtr
 		}
 
-		logger.Printf("WorkItemsTab: Successfully fetched %d work items", len(workItems))
-		return WorkItemsLoadedMsg{WorkItems: workItems}
+		log.Infof("WorkItemsTab: Successfully fetched %d work items", len(workItems))
+		if !defaultView {
+			return WorkItemsLoadedMsg{WorkItems: workItems}
+		}
+
+		// Only the default view hides work items snoozed with 'azb snooze'
+		workItems, due, err := snooze.Hide(workItems, time.Now())
+		if err != nil {
+			log.Warnf("WorkItemsTab: Failed to update snoozed items: %v", err)
+		}
+		var resurfaced []string
+		for _, reminder := range due {
+			resurfaced = append(resurfaced, reminder.String())
+		}
+		return WorkItemsLoadedMsg{WorkItems: workItems, Resurfaced: resurfaced}
+	})
+}
+
+// artifactLines describes the build, release and Git links of a work item.
+// Builds are looked up once per work item and cached in relationshipData.
+func (t *WorkItemsTab) artifactLines(wi *workitemtracking.WorkItem) []string {
+	id := getIntField(wi, "System.Id")
+	if info, ok := t.relationshipData[id]; ok && info.loaded {
+		return info.deployments
+	}
+
+	var lines []string
+	for _, rel := range *wi.Relations {
+		if rel.Rel == nil || *rel.Rel != api.ArtifactLinkRelation || rel.Url == nil {
+			continue
+		}
+
+		label := "Artifact"
+		if rel.Attributes != nil {
+			if name, ok := (*rel.Attributes)["name"].(string); ok && name != "" {
+				label = name
+			}
+		}
+
+		artifact, ok := api.ParseArtifactURL(*rel.Url)
+		if !ok {
+			lines = append(lines, fmt.Sprintf("  %s: %s", label, *rel.Url))
+			continue
+		}
+
+		target := fmt.Sprintf("%s %s", artifact.Type, artifact.ShortID())
+		if artifact.IsBuild() {
+			if buildID, err := strconv.Atoi(artifact.ID); err == nil {
+				if summary, err := t.client.GetBuildSummary(buildID); err == nil {
+					target = summary.String()
+				} else {
+					log.Errorf("Failed to load build %d: %v", buildID, err)
+				}
+			}
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", label, target))
 	}
+
+	t.relationshipData[id] = &relationshipInfo{deployments: lines, loaded: true}
+	return lines
 }
 
 // formatWorkItemDetails formats a work item for display
@@ -335,16 +568,16 @@ func (t *WorkItemsTab) formatWorkItemDetails(wi workitemtracking.WorkItem) strin
 	var details string
 
 	id := getIntField(&wi, "System.Id")
-	title := getStringField(&wi, "System.Title")
-	workItemType := getStringField(&wi, "System.WorkItemType")
-	state := getStringField(&wi, "System.State")
-	assignedTo := getStringField(&wi, "System.AssignedTo")
-	description := getStringField(&wi, "System.Description")
-	acceptanceCriteria := getStringField(&wi, "Microsoft.VSTS.Common.AcceptanceCriteria")
-	createdDate := getStringField(&wi, "System.CreatedDate")
-	changedDate := getStringField(&wi, "System.ChangedDate")
-	priority := getStringField(&wi, "Microsoft.VSTS.Common.Priority")
-	tags := getStringField(&wi, "System.Tags")
+	title := model.FieldString(wi.Fields, "System.Title")
+	workItemType := model.FieldString(wi.Fields, "System.WorkItemType")
+	state := model.FieldString(wi.Fields, "System.State")
+	assignedTo := model.FieldString(wi.Fields, "System.AssignedTo")
+	description := model.FieldString(wi.Fields, "System.Description")
+	acceptanceCriteria := model.FieldString(wi.Fields, "Microsoft.VSTS.Common.AcceptanceCriteria")
+	createdDate := model.FieldString(wi.Fields, "System.CreatedDate")
+	changedDate := model.FieldString(wi.Fields, "System.ChangedDate")
+	priority := model.FieldString(wi.Fields, "Microsoft.VSTS.Common.Priority")
+	tags := model.FieldString(wi.Fields, "System.Tags")
 
 	details += lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("#%d - %s\n\n", id, title))
 	details += fmt.Sprintf("Type: %s | State: %s | Priority: %s\n\n", workItemType, state, priority)
@@ -374,6 +607,10 @@ func (t *WorkItemsTab) formatWorkItemDetails(wi workitemtracking.WorkItem) strin
 			}
 
 			relType := *rel.Rel
+			if relType == api.ArtifactLinkRelation {
+				// Shown below with the resolved build details
+				continue
+			}
 			relID := extractWorkItemIDFromURL(*rel.Url)
 
 			// Fetch work item title if we have an ID
@@ -381,12 +618,12 @@ func (t *WorkItemsTab) formatWorkItemDetails(wi workitemtracking.WorkItem) strin
 			if relID > 0 {
 				// Check cache first
 				if cachedWI, ok := t.workItemCache[relID]; ok {
-					relTitle = getStringField(cachedWI, "System.Title")
+					relTitle = model.FieldString(cachedWI.Fields, "System.Title")
 				} else {
 					// Fetch from API
 					relWI, err := t.client.GetWorkItem(relID)
 					if err == nil && relWI != nil {
-						relTitle = getStringField(relWI, "System.Title")
+						relTitle = model.FieldString(relWI.Fields, "System.Title")
 						t.workItemCache[relID] = relWI
 					}
 				}
@@ -433,6 +670,9 @@ func (t *WorkItemsTab) formatWorkItemDetails(wi workitemtracking.WorkItem) strin
 		for _, o := range others {
 			details += o + "\n"
 		}
+		for _, d := range t.artifactLines(&wi) {
+			details += d + "\n"
+		}
 		details += "\n"
 	}
 
@@ -446,30 +686,14 @@ func (t *WorkItemsTab) formatWorkItemDetails(wi workitemtracking.WorkItem) strin
 
 	details += fmt.Sprintf("\nCreated: %s | Updated: %s\n", createdDate, changedDate)
 
+	if comments := t.formatComments(id); comments != "" {
+		details += "\n" + comments
+	}
+
 	return details
 }
 
 // Helper functions
-func getStringField(wi *workitemtracking.WorkItem, fieldName string) string {
-	if wi.Fields == nil {
-		return ""
-	}
-	if value, ok := (*wi.Fields)[fieldName]; ok {
-		// Handle identity fields
-		if fieldName == "System.AssignedTo" || fieldName == "System.CreatedBy" || fieldName == "System.ChangedBy" {
-			if identityMap, ok := value.(map[string]interface{}); ok {
-				if displayName, ok := identityMap["displayName"].(string); ok {
-					return displayName
-				}
-				if uniqueName, ok := identityMap["uniqueName"].(string); ok {
-					return uniqueName
-				}
-			}
-		}
-		return fmt.Sprintf("%v", value)
-	}
-	return ""
-}
 
 // getEmailField extracts the email (uniqueName) from identity fields, falling back to displayName
 // This is used for templates where email is preferred for uniqueness
@@ -525,7 +749,12 @@ func extractWorkItemIDFromURL(url string) int {
 }
 
 // workItemDelegate implements list.ItemDelegate
-type workItemDelegate struct{}
+type workItemDelegate struct {
+	columns       []ColumnConfig
+	treeView      bool
+	expandedItems map[int]bool
+	loadingItems  map[int]bool
+}
 
 func (d workItemDelegate) Height() int                             { return 1 }
 func (d workItemDelegate) Spacing() int                            { return 0 }
@@ -536,40 +765,50 @@ func (d workItemDelegate) Render(w io.Writer, m list.Model, index int, item list
 		return
 	}
 
-	id := fmt.Sprintf("%-8d", workItem.ID)
-	titleStr := workItem.Title
-	if len(titleStr) > 40 {
-		titleStr = titleStr[:37] + "..."
-	}
-	titleStr = fmt.Sprintf("%-40s", titleStr)
-
-	state := workItem.State
-	var stateStyle lipgloss.Style
-	switch state {
-	case "Active":
-		stateStyle = StateActiveStyle
-	case "New":
-		stateStyle = StateNewStyle
-	case "Closed", "Resolved":
-		stateStyle = StateClosedStyle
-	case "Blocked":
-		stateStyle = StateBlockedStyle
-	default:
-		stateStyle = MutedStyle
+	columns := d.columns
+	if len(columns) == 0 {
+		columns = defaultListConfig().Columns
 	}
-	stateStr := stateStyle.Render(fmt.Sprintf("%-12s", state))
 
-	assignee := workItem.AssignedTo
-	if len(assignee) > 20 {
-		assignee = assignee[:17] + "..."
+	cells := make([]string, 0, len(columns))
+	for _, column := range columns {
+		switch column.Field {
+		case "title":
+			// In tree view the title is indented by depth and prefixed with an expand icon
+			prefix := ""
+			if d.treeView {
+				prefix = strings.Repeat("  ", workItem.Depth)
+				switch {
+				case !workItem.HasChildren:
+					prefix += "  "
+				case d.expandedItems[workItem.ID]:
+					prefix += "▼ "
+				default:
+					prefix += "▶ "
+				}
+			}
+			titleWidth := column.Width - len([]rune(prefix))
+			if titleWidth < 10 {
+				titleWidth = 10
+			}
+			title := workItem.Title
+			if d.loadingItems[workItem.ID] {
+				title += " (loading...)"
+			}
+			cells = append(cells, prefix+output.FitCell(title, titleWidth))
+		case "state":
+			cells = append(cells, stateStyle(workItem.State).Render(output.FitCell(workItem.State, column.Width)))
+		default:
+			cells = append(cells, output.FitCell(columnValue(&workItem.workItem, column.Field), column.Width))
+		}
 	}
-	assigneeStr := fmt.Sprintf("%-20s", assignee)
+	row := strings.Join(cells, " │ ")
 
 	var output string
 	if index == m.Index() {
-		output = SelectedStyle.Render(fmt.Sprintf("> %s │ %s │ %s │ %s", id, titleStr, stateStr, assigneeStr))
+		output = SelectedStyle.Render("> " + row)
 	} else {
-		output = NormalStyle.Render(fmt.Sprintf("  %s │ %s │ %s │ %s", id, titleStr, stateStr, assigneeStr))
+		output = NormalStyle.Render("  " + row)
 	}
 
 	fmt.Fprint(w, output)
@@ -577,15 +816,29 @@ func (d workItemDelegate) Render(w io.Writer, m list.Model, index int, item list
 
 // workItemItem wraps a work item for the list
 type workItemItem struct {
-	ID         int
-	Title      string
-	State      string
-	AssignedTo string
-	workItem   workitemtracking.WorkItem
+	ID          int
+	Title       string
+	State       string
+	AssignedTo  string
+	Depth       int  // Nesting level in tree view
+	HasChildren bool // Whether the item has child work items (tree view)
+	workItem    workitemtracking.WorkItem
 }
 
 func (i workItemItem) FilterValue() string { return i.Title }
 
+// newWorkItemItem creates the list item of a work item
+func newWorkItemItem(wi *workitemtracking.WorkItem) workItemItem {
+	item := model.FromAPI(wi)
+	return workItemItem{
+		ID:         item.ID,
+		Title:      item.Title,
+		State:      item.State,
+		AssignedTo: cleanAssignedTo(item.AssignedTo.String()),
+		workItem:   *wi,
+	}
+}
+
 // GetHelpEntries returns the list of available actions for the Work Items tab
 func (t *WorkItemsTab) GetHelpEntries() []HelpEntry {
 	return []HelpEntry{
@@ -597,6 +850,17 @@ func (t *WorkItemsTab) GetHelpEntries() []HelpEntry {
 		{Action: "change_state", Description: "Change work item state"},
 		{Action: "assign", Description: "Assign to user"},
 		{Action: "add_tags", Description: "Add tags"},
+		{Action: "tree_view", Description: "Toggle flat list / hierarchy tree"},
+		{Action: "toggle_node", Description: "Expand/collapse node in tree view"},
+		{Action: "comment", Description: "Add a comment"},
+		{Action: "cycle_sort", Description: "Cycle sort order"},
+		{Action: "next_view", Description: "Switch to the next configured view"},
+		{Action: "recent", Description: "Toggle recently viewed and edited items"},
+		{Action: "compare", Description: "Pin for side-by-side comparison"},
+		{Action: "history", Description: "Show revision history (details view)"},
+		{Action: "toggle_split", Description: "Show details below or beside the list"},
+		{Action: "grow_details", Description: "Make the details pane larger"},
+		{Action: "shrink_details", Description: "Make the details pane smaller"},
 		{Action: "refresh", Description: "Refresh work items list"},
 	}
 }
@@ -611,7 +875,7 @@ func (t *WorkItemsTab) handleDownloadAction() tea.Cmd {
 }
 
 // downloadWorkItem downloads a work item as a YAML template
-func downloadWorkItem(client *api.Client, wi workitemtracking.WorkItem) tea.Cmd {
+func downloadWorkItem(client api.APIClient, wi workitemtracking.WorkItem) tea.Cmd {
 	return func() tea.Msg {
 		// Get work item ID
 		id := 0
@@ -619,12 +883,12 @@ func downloadWorkItem(client *api.Client, wi workitemtracking.WorkItem) tea.Cmd
 			id = *wi.Id
 		}
 
-		logger.Printf("Downloading work item #%d as template", id)
+		log.Infof("Downloading work item #%d as template", id)
 
 		// Fetch full work item details (with relations)
 		fullWI, err := client.GetWorkItem(id)
 		if err != nil {
-			logger.Printf("Failed to fetch work item #%d: %v", id, err)
+			log.Errorf("Failed to fetch work item #%d: %v", id, err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to fetch work item #%d: %v", id, err),
 				IsError: true,
@@ -637,7 +901,7 @@ func downloadWorkItem(client *api.Client, wi workitemtracking.WorkItem) tea.Cmd
 		// Serialize to YAML
 		yamlData, err := yaml.Marshal(template)
 		if err != nil {
-			logger.Printf("Failed to serialize work item: %v", err)
+			log.Errorf("Failed to serialize work item: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to serialize work item: %v", err),
 				IsError: true,
@@ -649,20 +913,20 @@ func downloadWorkItem(client *api.Client, wi workitemtracking.WorkItem) tea.Cmd
 		templatesDir := filepath.Join(homeDir, ".azure-boards-cli", "templates")
 		os.MkdirAll(templatesDir, 0755)
 
-		title := getStringField(fullWI, "System.Title")
+		title := model.FieldString(fullWI.Fields, "System.Title")
 		sanitized := sanitizeFilename(title)
 		filename := fmt.Sprintf("workitem-%d-%s.yaml", id, sanitized)
 		filePath := filepath.Join(templatesDir, filename)
 
 		if err := os.WriteFile(filePath, yamlData, 0600); err != nil {
-			logger.Printf("Failed to save template: %v", err)
+			log.Errorf("Failed to save template: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to save template: %v", err),
 				IsError: true,
 			}
 		}
 
-		logger.Printf("Downloaded work item #%d as template: %s", id, filename)
+		log.Infof("Downloaded work item #%d as template: %s", id, filename)
 		return NotificationMsg{
 			Message: fmt.Sprintf("Downloaded work item #%d as template: %s", id, filename),
 			IsError: false,
@@ -671,10 +935,10 @@ func downloadWorkItem(client *api.Client, wi workitemtracking.WorkItem) tea.Cmd
 }
 
 // convertWorkItemToTemplate converts a work item to a template
-func convertWorkItemToTemplate(client *api.Client, wi *workitemtracking.WorkItem) *templates.Template {
+func convertWorkItemToTemplate(client api.APIClient, wi *workitemtracking.WorkItem) *templates.Template {
 	template := &templates.Template{
-		Name:        getStringField(wi, "System.Title"),
-		Type:        getStringField(wi, "System.WorkItemType"),
+		Name:        model.FieldString(wi.Fields, "System.Title"),
+		Type:        model.FieldString(wi.Fields, "System.WorkItemType"),
 		Description: fmt.Sprintf("Template created from work item #%d", *wi.Id),
 		Fields:      make(map[string]interface{}),
 	}
@@ -740,10 +1004,10 @@ func convertWorkItemToTemplate(client *api.Client, wi *workitemtracking.WorkItem
 						if client != nil {
 							childWI, err := client.GetWorkItem(childID)
 							if err == nil && childWI != nil {
-								childTitle = getStringField(childWI, "System.Title")
-								childDescription = getStringField(childWI, "System.Description")
+								childTitle = model.FieldString(childWI.Fields, "System.Title")
+								childDescription = model.FieldString(childWI.Fields, "System.Description")
 								childAssignedTo = getEmailField(childWI, "System.AssignedTo")
-								childWorkItemType := getStringField(childWI, "System.WorkItemType")
+								childWorkItemType := model.FieldString(childWI.Fields, "System.WorkItemType")
 								if childWorkItemType != "" {
 									childType = childWorkItemType
 								}
@@ -792,19 +1056,19 @@ func (t *WorkItemsTab) handleDeleteAction() tea.Cmd {
 }
 
 // fetchWorkItemForDelete fetches work item with relationships for deletion
-func fetchWorkItemForDelete(client *api.Client, wi workitemtracking.WorkItem) tea.Cmd {
+func fetchWorkItemForDelete(client api.APIClient, wi workitemtracking.WorkItem) tea.Cmd {
 	return func() tea.Msg {
 		id := 0
 		if wi.Id != nil {
 			id = *wi.Id
 		}
 
-		logger.Printf("Fetching work item #%d for deletion", id)
+		log.Infof("Fetching work item #%d for deletion", id)
 
 		// Fetch full work item with relationships
 		fullWI, err := client.GetWorkItem(id)
 		if err != nil {
-			logger.Printf("Failed to fetch work item details: %v", err)
+			log.Errorf("Failed to fetch work item details: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to fetch work item details: %v", err),
 				IsError: true,
@@ -827,48 +1091,56 @@ func fetchWorkItemForDelete(client *api.Client, wi workitemtracking.WorkItem) te
 			}
 		}
 
-		title := getStringField(fullWI, "System.Title")
+		title := model.FieldString(fullWI.Fields, "System.Title")
 
-		logger.Printf("Work item #%d has %d child tasks", id, len(childIDs))
+		log.Infof("Work item #%d has %d child tasks", id, len(childIDs))
 
-		return ConfirmDeleteWorkItemMsg{
-			WorkItemID: id,
-			Title:      title,
-			ChildIDs:   childIDs,
+		childText := ""
+		phrase := ""
+		if len(childIDs) > 0 {
+			childText = fmt.Sprintf(" and its %d child task(s)", len(childIDs))
+			// Deleting children too is a bulk delete - require a typed phrase
+			phrase = fmt.Sprintf("delete %d", id)
+		}
+		return ConfirmMsg{
+			Prompt:    fmt.Sprintf("Delete work item #%d: '%s'%s?", id, title, childText),
+			Action:    "delete_work_item",
+			Phrase:    phrase,
+			OnConfirm: deleteWorkItemWithChildren(client, id, childIDs),
 		}
 	}
 }
 
 // deleteWorkItemWithChildren deletes a work item and all its children
-func deleteWorkItemWithChildren(client *api.Client, parentID int, childIDs []int) tea.Cmd {
+func deleteWorkItemWithChildren(client api.APIClient, parentID int, childIDs []int) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Deleting work item #%d with %d children", parentID, len(childIDs))
+		log.Infof("Deleting work item #%d with %d children", parentID, len(childIDs))
 
 		// Delete children first (in reverse order to avoid dependency issues)
 		for i := len(childIDs) - 1; i >= 0; i-- {
 			childID := childIDs[i]
 			if err := client.DeleteWorkItem(childID); err != nil {
-				logger.Printf("Failed to delete child work item #%d: %v", childID, err)
+				log.Errorf("Failed to delete child work item #%d: %v", childID, err)
 				return NotificationMsg{
 					Message: fmt.Sprintf("Failed to delete child work item #%d: %v", childID, err),
 					IsError: true,
 				}
 			}
-			logger.Printf("Deleted child work item #%d", childID)
+			log.Infof("Deleted child work item #%d", childID)
 		}
 
 		// Delete parent
 		if err := client.DeleteWorkItem(parentID); err != nil {
-			logger.Printf("Failed to delete work item #%d: %v", parentID, err)
+			log.Errorf("Failed to delete work item #%d: %v", parentID, err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to delete work item #%d: %v", parentID, err),
 				IsError: true,
 			}
 		}
 
-		logger.Printf("Deleted parent work item #%d", parentID)
+		log.Infof("Deleted parent work item #%d", parentID)
 		if len(childIDs) > 0 {
-			logger.Printf("Also deleted %d child task(s)", len(childIDs))
+			log.Infof("Also deleted %d child task(s)", len(childIDs))
 		}
 
 		return WorkItemDeletedMsg{
@@ -888,15 +1160,15 @@ func (t *WorkItemsTab) handleEditAction() tea.Cmd {
 }
 
 // prepareEditWorkItem fetches full work item, converts to YAML, and creates temp file
-func prepareEditWorkItem(client *api.Client, wi workitemtracking.WorkItem) tea.Cmd {
+func prepareEditWorkItem(client api.APIClient, wi workitemtracking.WorkItem) tea.Cmd {
 	return func() tea.Msg {
 		id := *wi.Id
-		logger.Printf("Preparing to edit work item #%d", id)
+		log.Infof("Preparing to edit work item #%d", id)
 
 		// Fetch full work item with all fields
 		fullWI, err := client.GetWorkItem(id)
 		if err != nil {
-			logger.Printf("Failed to fetch work item #%d: %v", id, err)
+			log.Errorf("Failed to fetch work item #%d: %v", id, err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to fetch work item #%d: %v", id, err),
 				IsError: true,
@@ -909,7 +1181,7 @@ func prepareEditWorkItem(client *api.Client, wi workitemtracking.WorkItem) tea.C
 		// Serialize to YAML
 		yamlData, err := yaml.Marshal(template)
 		if err != nil {
-			logger.Printf("Failed to serialize work item #%d to YAML: %v", id, err)
+			log.Errorf("Failed to serialize work item #%d to YAML: %v", id, err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to serialize work item: %v", err),
 				IsError: true,
@@ -919,7 +1191,7 @@ func prepareEditWorkItem(client *api.Client, wi workitemtracking.WorkItem) tea.C
 		// Create temporary file
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
-			logger.Printf("Failed to get home directory: %v", err)
+			log.Errorf("Failed to get home directory: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to get home directory: %v", err),
 				IsError: true,
@@ -928,7 +1200,7 @@ func prepareEditWorkItem(client *api.Client, wi workitemtracking.WorkItem) tea.C
 
 		tempDir := filepath.Join(homeDir, ".azure-boards-cli", "tmp")
 		if err := os.MkdirAll(tempDir, 0755); err != nil {
-			logger.Printf("Failed to create temp directory: %v", err)
+			log.Errorf("Failed to create temp directory: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to create temp directory: %v", err),
 				IsError: true,
@@ -937,89 +1209,90 @@ func prepareEditWorkItem(client *api.Client, wi workitemtracking.WorkItem) tea.C
 
 		tempFile := filepath.Join(tempDir, fmt.Sprintf("edit-workitem-%d.yaml", id))
 		if err := os.WriteFile(tempFile, yamlData, 0600); err != nil {
-			logger.Printf("Failed to write temp file: %v", err)
+			log.Errorf("Failed to write temp file: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to write temp file: %v", err),
 				IsError: true,
 			}
 		}
 
-		logger.Printf("Created temp file for editing: %s", tempFile)
+		log.Infof("Created temp file for editing: %s", tempFile)
 
+		rev := 0
+		if fullWI.Rev != nil {
+			rev = *fullWI.Rev
+		}
 		return OpenEditorMsg{
 			FilePath:   tempFile,
 			WorkItemID: id,
+			Rev:        rev,
+			Original:   yamlData,
 			Client:     client,
 		}
 	}
 }
 
-// processEditedWorkItem reads the edited YAML and updates the work item
-func processEditedWorkItem(filePath string, workItemID int, client *api.Client) tea.Cmd {
+// processEditedWorkItem reads the edited YAML and updates the fields that were
+// changed in it, see applyWorkItemEdit
+func processEditedWorkItem(msg ProcessEditedWorkItemMsg) tea.Cmd {
+	filePath, workItemID := msg.FilePath, msg.WorkItemID
 	return func() tea.Msg {
-		logger.Printf("Processing edited work item #%d from %s", workItemID, filePath)
+		log.Infof("Processing edited work item #%d from %s", workItemID, filePath)
 
 		// Read edited YAML
 		yamlData, err := os.ReadFile(filePath)
 		if err != nil {
-			logger.Printf("Failed to read edited file: %v", err)
+			log.Errorf("Failed to read edited file: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to read edited file: %v", err),
 				IsError: true,
 			}
 		}
 
-		// Parse YAML
-		var template templates.Template
+		// Parse YAML, and the snapshot it was written from the same way
+		var template, original templates.Template
 		if err := yaml.Unmarshal(yamlData, &template); err != nil {
-			logger.Printf("Failed to parse edited YAML: %v", err)
+			log.Errorf("Failed to parse edited YAML: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to parse edited YAML: %v", err),
 				IsError: true,
 			}
 		}
-
-		// Build update document from template
-		updateFields := buildUpdateDocument(&template)
-
-		// Update work item
-		_, err = client.UpdateWorkItem(workItemID, updateFields)
-		if err != nil {
-			logger.Printf("Failed to update work item #%d: %v", workItemID, err)
+		if err := yaml.Unmarshal(msg.Original, &original); err != nil {
+			log.Errorf("Failed to parse the original YAML: %v", err)
 			return NotificationMsg{
-				Message: fmt.Sprintf("Failed to update work item #%d: %v", workItemID, err),
+				Message: fmt.Sprintf("Failed to parse the original YAML: %v", err),
 				IsError: true,
 			}
 		}
 
-		logger.Printf("Successfully updated work item #%d", workItemID)
-
-		// Clean up temp file
-		os.Remove(filePath)
-
-		return NotificationMsg{
-			Message: fmt.Sprintf("Successfully updated work item #%d", workItemID),
-			IsError: false,
+		updateFields := changedFields(original.Fields, template.Fields)
+		if len(updateFields) == 0 {
+			log.Infof("No changes to work item #%d", workItemID)
+			os.Remove(filePath)
+			return NotificationMsg{Message: fmt.Sprintf("No changes to work item #%d", workItemID)}
 		}
+
+		return applyWorkItemEdit(msg, original.Fields, updateFields)
 	}
 }
 
-// buildUpdateDocument converts template fields to API update format
-func buildUpdateDocument(template *templates.Template) map[string]interface{} {
+// changedFields returns the fields whose edited value differs from the
+// original. Fields removed while editing are left as they are.
+func changedFields(original, edited map[string]interface{}) map[string]interface{} {
 	fields := make(map[string]interface{})
-
-	// Copy all fields from template
-	for fieldName, value := range template.Fields {
-		fields[fieldName] = value
+	for fieldName, value := range edited {
+		if before, ok := original[fieldName]; !ok || !reflect.DeepEqual(before, value) {
+			fields[fieldName] = value
+		}
 	}
-
 	return fields
 }
 
 // executeCreateWorkItemFromTemplate creates a work item from a template
-func executeCreateWorkItemFromTemplate(client *api.Client, template *templates.Template) tea.Cmd {
+func executeCreateWorkItemFromTemplate(client api.APIClient, template *templates.Template) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Executing create work item from template: %s", template.Name)
+		log.Infof("Executing create work item from template: %s", template.Name)
 
 		// Build fields map from template
 		fields := make(map[string]interface{})
@@ -1035,7 +1308,7 @@ func executeCreateWorkItemFromTemplate(client *api.Client, template *templates.T
 
 		workItem, err := client.CreateWorkItem(template.Type, fields, parentID)
 		if err != nil {
-			logger.Printf("Failed to create work item from template: %v", err)
+			log.Errorf("Failed to create work item from template: %v", err)
 			return NotificationMsg{
 				Message: fmt.Sprintf("Failed to create work item: %v", err),
 				IsError: true,
@@ -1043,86 +1316,34 @@ func executeCreateWorkItemFromTemplate(client *api.Client, template *templates.T
 		}
 
 		workItemID := *workItem.Id
-		logger.Printf("Created work item #%d from template", workItemID)
+		log.Infof("Created work item #%d from template", workItemID)
 
-		// Create child work items if specified
-		childCount := 0
+		// Create child work items if specified, concurrently
+		var childIDs []string
 		var childErrors []string
 		if template.Relations != nil && len(template.Relations.Children) > 0 {
+			children := make([]api.NewWorkItem, len(template.Relations.Children))
 			for i, child := range template.Relations.Children {
-				childFields := make(map[string]interface{})
-				childFields["System.Title"] = child.Title
-				if child.Description != "" {
-					childFields["System.Description"] = child.Description
-				}
-				if child.AssignedTo != "" {
-					childFields["System.AssignedTo"] = child.AssignedTo
-				}
-
-				// Add any custom fields from child (except System.State which is read-only during creation)
-				for fieldName, value := range child.Fields {
-					if fieldName != "System.State" {
-						childFields[fieldName] = value
-					}
-				}
-
-				// Inherit fields from parent template if not specified in child
-				if template.Fields != nil {
-					// Inherit Custom.ApplicationName
-					if parentAppName, hasParentAppName := template.Fields["Custom.ApplicationName"]; hasParentAppName {
-						if _, hasChildAppName := childFields["Custom.ApplicationName"]; !hasChildAppName {
-							childFields["Custom.ApplicationName"] = parentAppName
-						}
-					}
-
-					// Inherit AreaPath if not in child
-					if parentAreaPath, hasAreaPath := template.Fields["System.AreaPath"]; hasAreaPath {
-						if _, hasChildAreaPath := childFields["System.AreaPath"]; !hasChildAreaPath {
-							childFields["System.AreaPath"] = parentAreaPath
-						}
-					}
-
-					// Inherit IterationPath if not in child
-					if parentIteration, hasIteration := template.Fields["System.IterationPath"]; hasIteration {
-						if _, hasChildIteration := childFields["System.IterationPath"]; !hasChildIteration {
-							childFields["System.IterationPath"] = parentIteration
-						}
-					}
-
-					// Inherit any other custom fields not already set
-					for fieldName, value := range template.Fields {
-						if strings.HasPrefix(fieldName, "Custom.") {
-							if _, hasChildField := childFields[fieldName]; !hasChildField {
-								childFields[fieldName] = value
-							}
-						}
-					}
-				}
-
-				// Determine child type
-				childType := child.Type
-				if childType == "" {
-					childType = "Task"
-				}
+				childType, childFields := child.WorkItemFields(fields)
+				children[i] = api.NewWorkItem{Type: childType, Fields: childFields, Relations: []api.WorkItemLink{client.ParentLink(workItemID)}}
+			}
 
-				// Create child work item with parent relationship
-				_, err := client.CreateWorkItem(childType, childFields, workItemID)
-				if err != nil {
-					errMsg := fmt.Sprintf("Child #%d (%s): %v", i+1, child.Title, err)
-					logger.Printf("Failed to create child work item: %s", errMsg)
+			for i, result := range client.CreateWorkItems(children) {
+				if result.Error != nil {
+					errMsg := fmt.Sprintf("Child #%d (%s): %v", i+1, template.Relations.Children[i].Title, result.Error)
+					log.Errorf("Failed to create child work item: %s", errMsg)
 					childErrors = append(childErrors, errMsg)
-					// Continue creating other children even if one fails
 					continue
 				}
-				childCount++
+				childIDs = append(childIDs, fmt.Sprintf("#%d", *result.WorkItem.Id))
 			}
-			logger.Printf("Created %d child work items", childCount)
+			log.Infof("Created %d child work items", len(childIDs))
 		}
 
 		// Build notification message
 		message := fmt.Sprintf("Created work item #%d", workItemID)
-		if childCount > 0 {
-			message += fmt.Sprintf(" with %d child task(s)", childCount)
+		if len(childIDs) > 0 {
+			message += fmt.Sprintf(" with %d child task(s): %s", len(childIDs), strings.Join(childIDs, ", "))
 		}
 		if len(childErrors) > 0 {
 			message += fmt.Sprintf("\n\nWarning: %d child task(s) failed to create:\n- %s",
@@ -1137,38 +1358,35 @@ func executeCreateWorkItemFromTemplate(client *api.Client, template *templates.T
 	}
 }
 
-// handleChangeStateAction fetches valid states and shows a selection dialog
-func (t *WorkItemsTab) handleChangeStateAction() *SelectionDialog {
+// handleChangeStateAction starts loading the valid states of the selected work
+// item in the background. It returns the work item's ID, or 0 if nothing is
+// selected; WorkItemStatesLoadedMsg then opens the selection dialog.
+func (t *WorkItemsTab) handleChangeStateAction() (*workItemSnapshot, tea.Cmd) {
 	selectedItem := t.list.SelectedItem()
 	if item, ok := selectedItem.(workItemItem); ok {
-		workItemID := *item.workItem.Id
-		workItemType := getStringField(&item.workItem, "System.WorkItemType")
+		read := snapshotOf(&item.workItem)
+		workItemType := model.FieldString(item.workItem.Fields, "System.WorkItemType")
+		currentState := model.FieldString(item.workItem.Fields, "System.State")
+		return read, loadWorkItemStates(t.client, read, workItemType, currentState)
+	}
+	return nil, nil
+}
 
-		// Fetch valid states for this work item type
-		states, err := t.client.GetWorkItemStates(workItemType)
+// loadWorkItemStates fetches the states a work item can be moved to
+func loadWorkItemStates(client api.APIClient, read *workItemSnapshot, workItemType, currentState string) tea.Cmd {
+	return func() tea.Msg {
+		states, err := client.GetWorkItemStates(workItemType)
 		if err != nil {
-			logger.Printf("Failed to fetch states for work item type '%s': %v", workItemType, err)
-			// Return nil so no dialog is shown
-			return nil
+			log.Errorf("Failed to fetch states for work item type '%s': %v", workItemType, err)
 		}
-
-		if len(states) == 0 {
-			logger.Printf("No states found for work item type '%s'", workItemType)
-			return nil
+		return WorkItemStatesLoadedMsg{
+			WorkItem:     read,
+			WorkItemType: workItemType,
+			CurrentState: currentState,
+			States:       states,
+			Error:        err,
 		}
-
-		// Create and show selection dialog
-		dialog := NewSelectionDialog()
-		dialog.Show(
-			fmt.Sprintf("Change State for Work Item #%d", workItemID),
-			states,
-			"change_state",
-			workItemID,
-		)
-		logger.Printf("Showing state selection dialog for work item #%d (%d states)", workItemID, len(states))
-		return dialog
 	}
-	return nil
 }
 
 // handleAssignAction shows an input prompt for assigning a work item
@@ -1182,10 +1400,10 @@ func (t *WorkItemsTab) handleAssignAction() *InputPrompt {
 		prompt.Show(
 			fmt.Sprintf("Assign Work Item #%d", workItemID),
 			"Enter assignee email or display name",
-			"assign_work_item",
-			workItemID,
+			string(ActionAssign),
+			snapshotOf(&item.workItem),
 		)
-		logger.Printf("Showing assign input prompt for work item #%d", workItemID)
+		log.Infof("Showing assign input prompt for work item #%d", workItemID)
 		return prompt
 	}
 	return nil
@@ -1202,144 +1420,78 @@ func (t *WorkItemsTab) handleAddTagsAction() *InputPrompt {
 		prompt.Show(
 			fmt.Sprintf("Add Tags to Work Item #%d", workItemID),
 			"Enter tags separated by commas",
-			"add_tags",
+			string(ActionAddTags),
 			workItemID,
 		)
-		logger.Printf("Showing add tags input prompt for work item #%d", workItemID)
+		log.Infof("Showing add tags input prompt for work item #%d", workItemID)
 		return prompt
 	}
 	return nil
 }
 
-// changeWorkItemState changes the state of a work item
-func changeWorkItemState(client *api.Client, workItemID int, newState string) tea.Cmd {
+// changeWorkItemState changes the state of a work item at the revision the
+// state dialog was opened at
+func changeWorkItemState(client api.APIClient, read *workItemSnapshot, newState string) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Changing state of work item #%d to '%s'", workItemID, newState)
-
-		// Update the work item state
-		fields := map[string]interface{}{
-			"System.State": newState,
-		}
-
-		workItem, err := client.UpdateWorkItem(workItemID, fields)
-		if err != nil {
-			logger.Printf("Failed to change state of work item #%d: %v", workItemID, err)
-			return WorkItemUpdatedMsg{
-				WorkItem: nil,
-				Error:    err,
-			}
-		}
-
-		logger.Printf("Successfully changed state of work item #%d to '%s'", workItemID, newState)
-		return WorkItemUpdatedMsg{
-			WorkItem: workItem,
-			Error:    nil,
+		log.Infof("Changing state of work item #%d to '%s'", read.ID, newState)
+		msg := updateWorkItemAt(client, read, map[string]interface{}{"System.State": newState})
+		if updated, ok := msg.(WorkItemUpdatedMsg); ok && updated.Error != nil {
+			log.Errorf("Failed to change state of work item #%d: %v", read.ID, updated.Error)
 		}
+		return msg
 	}
 }
 
-// assignWorkItem assigns a work item to a user
-func assignWorkItem(client *api.Client, workItemID int, assignee string) tea.Cmd {
+// assignWorkItem assigns a work item to a user at the revision the assign
+// prompt was opened at
+func assignWorkItem(client api.APIClient, read *workItemSnapshot, assignee string) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Assigning work item #%d to '%s'", workItemID, assignee)
-
-		// Update the work item assignee
-		fields := map[string]interface{}{
-			"System.AssignedTo": assignee,
-		}
-
-		workItem, err := client.UpdateWorkItem(workItemID, fields)
-		if err != nil {
-			logger.Printf("Failed to assign work item #%d: %v", workItemID, err)
-			return WorkItemUpdatedMsg{
-				WorkItem: nil,
-				Error:    err,
-			}
-		}
-
-		logger.Printf("Successfully assigned work item #%d to '%s'", workItemID, assignee)
-		return WorkItemUpdatedMsg{
-			WorkItem: workItem,
-			Error:    nil,
+		log.Infof("Assigning work item #%d to '%s'", read.ID, assignee)
+		msg := updateWorkItemAt(client, read, map[string]interface{}{"System.AssignedTo": assignee})
+		if updated, ok := msg.(WorkItemUpdatedMsg); ok && updated.Error != nil {
+			log.Errorf("Failed to assign work item #%d: %v", read.ID, updated.Error)
 		}
+		return msg
 	}
 }
 
-// addWorkItemTags adds tags to a work item
-func addWorkItemTags(client *api.Client, workItemID int, tagsInput string) tea.Cmd {
+// addWorkItemTags adds tags to a work item. The tags are saved at the revision
+// they were read at, and read again when the work item changed meanwhile, so
+// tags added by someone else are not lost.
+func addWorkItemTags(client api.APIClient, workItemID int, tagsInput string) tea.Cmd {
 	return func() tea.Msg {
-		logger.Printf("Adding tags '%s' to work item #%d", tagsInput, workItemID)
-
-		// Fetch the current work item to get existing tags
-		workItem, err := client.GetWorkItem(workItemID)
-		if err != nil {
-			logger.Printf("Failed to fetch work item #%d: %v", workItemID, err)
-			return NotificationMsg{
-				Message: fmt.Sprintf("Failed to fetch work item: %v", err),
-				IsError: true,
-			}
-		}
-
-		// Get existing tags
-		var existingTags []string
-		if workItem.Fields != nil {
-			if tagsValue, ok := (*workItem.Fields)["System.Tags"]; ok {
-				if tagsStr, ok := tagsValue.(string); ok && tagsStr != "" {
-					existingTags = strings.Split(tagsStr, ";")
-					// Trim spaces
-					for i := range existingTags {
-						existingTags[i] = strings.TrimSpace(existingTags[i])
-					}
-				}
-			}
-		}
-
-		// Parse new tags from input (comma-separated)
-		newTags := strings.Split(tagsInput, ",")
-		for i := range newTags {
-			newTags[i] = strings.TrimSpace(newTags[i])
-		}
-
-		// Merge tags, avoiding duplicates
-		tagSet := make(map[string]bool)
-		for _, tag := range existingTags {
-			if tag != "" {
-				tagSet[tag] = true
-			}
-		}
-		for _, tag := range newTags {
-			if tag != "" {
-				tagSet[tag] = true
-			}
-		}
+		log.Infof("Adding tags '%s' to work item #%d", tagsInput, workItemID)
 
-		// Convert back to slice
-		var allTags []string
-		for tag := range tagSet {
-			allTags = append(allTags, tag)
-		}
-
-		// Join with semicolons (Azure DevOps format)
-		tagsStr := strings.Join(allTags, ";")
-
-		// Update the work item tags
-		fields := map[string]interface{}{
-			"System.Tags": tagsStr,
-		}
-
-		updatedWorkItem, err := client.UpdateWorkItem(workItemID, fields)
+		updatedWorkItem, err := api.UpdateFromCurrent(client, workItemID, func(workItem *workitemtracking.WorkItem) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"System.Tags": mergeTags(model.FieldString(workItem.Fields, "System.Tags"), tagsInput),
+			}, nil
+		})
 		if err != nil {
-			logger.Printf("Failed to add tags to work item #%d: %v", workItemID, err)
+			log.Errorf("Failed to add tags to work item #%d: %v", workItemID, err)
 			return WorkItemUpdatedMsg{
 				WorkItem: nil,
 				Error:    err,
 			}
 		}
 
-		logger.Printf("Successfully added tags to work item #%d", workItemID)
+		log.Infof("Successfully added tags to work item #%d", workItemID)
 		return WorkItemUpdatedMsg{
 			WorkItem: updatedWorkItem,
 			Error:    nil,
 		}
 	}
 }
+
+// mergeTags adds the comma-separated tags of input to a System.Tags value,
+// keeping the order of the existing tags and skipping ones it already has
+func mergeTags(tags, input string) string {
+	result := model.ParseTags(tags)
+	for _, tag := range strings.Split(input, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.ContainsFunc(result, func(existing string) bool { return strings.EqualFold(existing, tag) }) {
+			result = append(result, tag)
+		}
+	}
+	return model.FormatTags(result)
+}
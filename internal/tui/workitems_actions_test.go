@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/api/apitest"
+)
+
+func TestChangeWorkItemState(t *testing.T) {
+	read := &workItemSnapshot{ID: 42, Rev: 3, Fields: map[string]interface{}{"System.State": "New", "System.Title": "Login fails"}}
+
+	t.Run("updates at the revision read", func(t *testing.T) {
+		client := apitest.New()
+		var gotID, gotRev int
+		var gotFields map[string]interface{}
+		client.UpdateWorkItemAtRevisionFunc = func(id, rev int, fields map[string]interface{}) (*workitemtracking.WorkItem, error) {
+			gotID, gotRev, gotFields = id, rev, fields
+			return &workitemtracking.WorkItem{Id: &id}, nil
+		}
+
+		msg, ok := changeWorkItemState(client, read, "Active")().(WorkItemUpdatedMsg)
+		if !ok || msg.Error != nil || msg.WorkItem == nil {
+			t.Fatalf("changeWorkItemState() = %+v, want the updated work item", msg)
+		}
+		if gotID != 42 || gotRev != 3 || gotFields["System.State"] != "Active" {
+			t.Errorf("UpdateWorkItemAtRevision(%d, %d, %v), want 42 at rev 3 with System.State Active", gotID, gotRev, gotFields)
+		}
+	})
+
+	t.Run("offers to re-apply on a conflict", func(t *testing.T) {
+		client := apitest.New()
+		var revs []int
+		client.UpdateWorkItemAtRevisionFunc = func(id, rev int, fields map[string]interface{}) (*workitemtracking.WorkItem, error) {
+			revs = append(revs, rev)
+			if rev == 3 {
+				return nil, api.ErrRevisionConflict
+			}
+			return &workitemtracking.WorkItem{Id: &id}, nil
+		}
+		client.GetWorkItemFunc = func(id int) (*workitemtracking.WorkItem, error) {
+			rev := 4
+			return &workitemtracking.WorkItem{Id: &id, Rev: &rev, Fields: &map[string]interface{}{"System.State": "Closed", "System.Title": "Login fails"}}, nil
+		}
+
+		confirm, ok := changeWorkItemState(client, read, "Active")().(ConfirmMsg)
+		if !ok {
+			t.Fatal("changeWorkItemState() after a conflict did not ask to re-apply")
+		}
+		if !strings.Contains(confirm.Prompt, "State: New → Closed") || !strings.Contains(confirm.Prompt, "You both changed: State") {
+			t.Errorf("prompt = %q, want their State change marked as changed by both", confirm.Prompt)
+		}
+		if msg, ok := confirm.OnConfirm().(WorkItemUpdatedMsg); !ok || msg.Error != nil {
+			t.Fatalf("re-apply = %+v, want the updated work item", msg)
+		}
+		if len(revs) != 2 || revs[1] != 4 {
+			t.Errorf("UpdateWorkItemAtRevision revs = %v, want the state re-applied at rev 4", revs)
+		}
+	})
+}
+
+func TestWorkItemsTabFetchError(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	client := apitest.New()
+	client.ListWorkItemsFunc = func(string, int) (*[]workitemtracking.WorkItem, error) {
+		return nil, errors.New("unauthorized")
+	}
+
+	tab := NewWorkItemsTab(client, 80, 24)
+	var loaded *WorkItemsLoadedMsg
+	for _, cmd := range tab.fetchWorkItems()().(tea.BatchMsg) {
+		if msg, ok := cmd().(WorkItemsLoadedMsg); ok {
+			loaded = &msg
+		}
+	}
+	if loaded == nil || loaded.Error == nil {
+		t.Fatalf("fetchWorkItems() = %+v, want an error", loaded)
+	}
+	if !client.Called("ListWorkItems") {
+		t.Errorf("calls = %v, want ListWorkItems", client.Calls())
+	}
+}
@@ -0,0 +1,236 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"gopkg.in/yaml.v3"
+
+	"github.com/SOMUCHDOG/azb/internal/log"
+	"github.com/SOMUCHDOG/azb/internal/model"
+)
+
+// ColumnConfig is a column of the Work Items list
+type ColumnConfig struct {
+	Field string `yaml:"field"`
+	Width int    `yaml:"width"`
+}
+
+// SortConfig is the initial sort order of the Work Items list
+type SortConfig struct {
+	Field      string `yaml:"field"`
+	Descending bool   `yaml:"descending"`
+}
+
+// ListConfig configures the Work Items list, loaded from ~/.azure-boards-cli/columns.yaml
+type ListConfig struct {
+	Columns []ColumnConfig `yaml:"columns"`
+	Sort    SortConfig     `yaml:"sort"`
+}
+
+// listColumns maps column names to the work item field they show and their default width
+var listColumns = map[string]struct {
+	field string
+	width int
+}{
+	"id":          {"System.Id", 8},
+	"title":       {"System.Title", 40},
+	"type":        {"System.WorkItemType", 12},
+	"state":       {"System.State", 12},
+	"assigned_to": {"System.AssignedTo", 20},
+	"priority":    {"Microsoft.VSTS.Common.Priority", 8},
+	"tags":        {"System.Tags", 20},
+	"changed":     {"System.ChangedDate", 10},
+}
+
+// sortFields is the order the sort keybinding cycles through; an empty field keeps the query order
+var sortFields = []SortConfig{
+	{Field: ""},
+	{Field: "id"},
+	{Field: "title"},
+	{Field: "state"},
+	{Field: "assigned_to"},
+	{Field: "priority"},
+	{Field: "changed", Descending: true},
+}
+
+// defaultListConfig returns the built-in columns and sort order
+func defaultListConfig() *ListConfig {
+	return &ListConfig{
+		Columns: []ColumnConfig{
+			{Field: "id", Width: 8},
+			{Field: "title", Width: 40},
+			{Field: "state", Width: 12},
+			{Field: "assigned_to", Width: 20},
+		},
+	}
+}
+
+// LoadListConfig loads the Work Items list configuration, falling back to defaults.
+// Unknown columns are skipped and missing widths use the column's default width.
+func LoadListConfig() (*ListConfig, error) {
+	cfg := defaultListConfig()
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(homeDir, ".azure-boards-cli", "columns.yaml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+
+	var loaded ListConfig
+	if err := yaml.Unmarshal(data, &loaded); err != nil {
+		return cfg, err
+	}
+
+	var columns []ColumnConfig
+	for _, column := range loaded.Columns {
+		def, ok := listColumns[column.Field]
+		if !ok {
+			log.Warnf("Ignoring unknown work item column '%s'", column.Field)
+			continue
+		}
+		if column.Width <= 0 {
+			column.Width = def.width
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) > 0 {
+		cfg.Columns = columns
+	}
+
+	if _, ok := listColumns[loaded.Sort.Field]; ok {
+		cfg.Sort = loaded.Sort
+	}
+
+	return cfg, nil
+}
+
+// cycleSort moves to the next sort field and rebuilds the list
+func (t *WorkItemsTab) cycleSort() tea.Cmd {
+	next := 0
+	for i, s := range sortFields {
+		if s.Field == t.sort.Field {
+			next = (i + 1) % len(sortFields)
+			break
+		}
+	}
+	t.sort = sortFields[next]
+	t.list.Title = t.listTitle()
+	t.rebuildList()
+
+	message := "Sorted by query order"
+	if t.sort.Field != "" {
+		message = fmt.Sprintf("Sorted by %s", sortDescription(t.sort))
+	}
+	return func() tea.Msg {
+		return NotificationMsg{Message: message, IsError: false}
+	}
+}
+
+// sortedListTitle returns the list title including the active sort
+func sortedListTitle(s SortConfig) string {
+	if s.Field == "" {
+		return "Work Items"
+	}
+	return fmt.Sprintf("Work Items (by %s)", sortDescription(s))
+}
+
+// sortDescription describes a sort order, e.g. "title ↑"
+func sortDescription(s SortConfig) string {
+	if s.Descending {
+		return strings.ReplaceAll(s.Field, "_", " ") + " ↓"
+	}
+	return strings.ReplaceAll(s.Field, "_", " ") + " ↑"
+}
+
+// sortedWorkItems returns the loaded work items in the active sort order
+func (t *WorkItemsTab) sortedWorkItems() []workitemtracking.WorkItem {
+	items := make([]workitemtracking.WorkItem, len(t.workItems))
+	copy(items, t.workItems)
+	sortWorkItems(items, t.sort)
+	return items
+}
+
+// sortWorkItems sorts work items in place by a column; an empty field keeps the order
+func sortWorkItems(items []workitemtracking.WorkItem, s SortConfig) {
+	if s.Field == "" {
+		return
+	}
+
+	less := func(a, b *workitemtracking.WorkItem) bool {
+		switch s.Field {
+		case "id", "priority":
+			return numericColumnValue(a, s.Field) < numericColumnValue(b, s.Field)
+		default:
+			return strings.ToLower(columnValue(a, s.Field)) < strings.ToLower(columnValue(b, s.Field))
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if s.Descending {
+			return less(&items[j], &items[i])
+		}
+		return less(&items[i], &items[j])
+	})
+}
+
+// columnValue returns the display value of a column for a work item
+func columnValue(wi *workitemtracking.WorkItem, column string) string {
+	switch column {
+	case "id":
+		return strconv.Itoa(getIntField(wi, "System.Id"))
+	case "assigned_to":
+		return cleanAssignedTo(model.FieldString(wi.Fields, "System.AssignedTo"))
+	case "changed":
+		// Dates are shown as YYYY-MM-DD
+		value := model.FieldString(wi.Fields, "System.ChangedDate")
+		if len(value) > 10 {
+			value = value[:10]
+		}
+		return value
+	default:
+		if def, ok := listColumns[column]; ok {
+			return model.FieldString(wi.Fields, def.field)
+		}
+		return ""
+	}
+}
+
+// numericColumnValue returns a column as a number for sorting; missing values sort last
+func numericColumnValue(wi *workitemtracking.WorkItem, column string) float64 {
+	value, err := strconv.ParseFloat(columnValue(wi, column), 64)
+	if err != nil {
+		return 1 << 31
+	}
+	return value
+}
+
+// stateStyle returns the style used to render a work item state
+func stateStyle(state string) lipgloss.Style {
+	switch state {
+	case "Active":
+		return StateActiveStyle
+	case "New":
+		return StateNewStyle
+	case "Closed", "Resolved":
+		return StateClosedStyle
+	case "Blocked":
+		return StateBlockedStyle
+	default:
+		return MutedStyle
+	}
+}
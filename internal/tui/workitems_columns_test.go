@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func columnTestWorkItem(id int, title, priority string) workitemtracking.WorkItem {
+	fields := map[string]interface{}{"System.Title": title}
+	if priority != "" {
+		fields["Microsoft.VSTS.Common.Priority"] = priority
+	}
+	return workitemtracking.WorkItem{Id: &id, Fields: &fields}
+}
+
+func TestSortWorkItems(t *testing.T) {
+	items := func() []workitemtracking.WorkItem {
+		return []workitemtracking.WorkItem{
+			columnTestWorkItem(3, "beta", "2"),
+			columnTestWorkItem(1, "Gamma", ""),
+			columnTestWorkItem(2, "alpha", "1"),
+		}
+	}
+
+	tests := []struct {
+		name string
+		sort SortConfig
+		want []int
+	}{
+		{"query order", SortConfig{}, []int{3, 1, 2}},
+		{"id ascending", SortConfig{Field: "id"}, []int{1, 2, 3}},
+		{"id descending", SortConfig{Field: "id", Descending: true}, []int{3, 2, 1}},
+		{"title ignores case", SortConfig{Field: "title"}, []int{2, 3, 1}},
+		{"missing priority sorts last", SortConfig{Field: "priority"}, []int{2, 3, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := items()
+			sortWorkItems(got, tt.sort)
+			for i, id := range tt.want {
+				if *got[i].Id != id {
+					t.Fatalf("position %d = #%d, want #%d", i, *got[i].Id, id)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package tui
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/log"
+	"github.com/SOMUCHDOG/azb/internal/mentions"
+)
+
+// commentEditorHeader is written to the comment file; its instruction lines
+// are removed again, other lines starting with '#' are kept
+const commentEditorHeader = `
+# Write your comment above. These instructions are removed.
+# Save an empty comment to cancel.
+`
+
+var (
+	commentBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</li>`)
+	commentTagPattern   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// loadComments fetches the comment thread of a work item unless it is cached or already loading
+func (t *WorkItemsTab) loadComments(workItemID int) tea.Cmd {
+	if workItemID == 0 || t.loadingComments[workItemID] {
+		return nil
+	}
+	if _, ok := t.comments[workItemID]; ok {
+		return nil
+	}
+
+	t.loadingComments[workItemID] = true
+	client := t.client
+	return func() tea.Msg {
+		comments, err := client.GetComments(workItemID)
+		return WorkItemCommentsLoadedMsg{WorkItemID: workItemID, Comments: comments, Error: err}
+	}
+}
+
+// handleCommentsLoaded caches a loaded comment thread and refreshes the details pane
+func (t *WorkItemsTab) handleCommentsLoaded(msg WorkItemCommentsLoadedMsg) tea.Cmd {
+	delete(t.loadingComments, msg.WorkItemID)
+	if msg.Error != nil {
+		log.Errorf("Failed to load comments for work item #%d: %v", msg.WorkItemID, msg.Error)
+		return nil
+	}
+
+	t.comments[msg.WorkItemID] = msg.Comments
+	t.refreshDetails(msg.WorkItemID)
+	return nil
+}
+
+// handleCommentAdded reloads the comment thread after a comment was posted
+func (t *WorkItemsTab) handleCommentAdded(msg CommentAddedMsg) tea.Cmd {
+	if msg.Error != nil {
+		return func() tea.Msg {
+			return NotificationMsg{Message: fmt.Sprintf("Failed to add comment: %v", msg.Error), IsError: true}
+		}
+	}
+
+	delete(t.comments, msg.WorkItemID)
+	return tea.Batch(
+		t.loadComments(msg.WorkItemID),
+		func() tea.Msg {
+			message := fmt.Sprintf("Comment added to work item #%d", msg.WorkItemID)
+			if len(msg.Warnings) > 0 {
+				message += "; " + strings.Join(msg.Warnings, "; ")
+			}
+			return NotificationMsg{Message: message, IsError: false}
+		},
+	)
+}
+
+// refreshDetails redraws the details pane if it shows the given work item
+func (t *WorkItemsTab) refreshDetails(workItemID int) {
+	if !t.showDetails || t.selectedItem == nil || t.selectedItem.Id == nil || *t.selectedItem.Id != workItemID {
+		return
+	}
+	t.viewport.SetContent(t.formatWorkItemDetails(*t.selectedItem))
+}
+
+// handleAddCommentAction starts composing a comment for the selected work item.
+// The comment is written in $EDITOR when set, otherwise in an inline prompt.
+func (t *WorkItemsTab) handleAddCommentAction() (tea.Cmd, *InputPrompt) {
+	item, ok := t.list.SelectedItem().(workItemItem)
+	if !ok || item.workItem.Id == nil {
+		return nil, nil
+	}
+	workItemID := *item.workItem.Id
+
+	if os.Getenv("EDITOR") == "" {
+		prompt := NewInputPrompt()
+		prompt.Show(
+			fmt.Sprintf("Comment on Work Item #%d", workItemID),
+			"Enter comment text",
+			string(ActionComment),
+			workItemID,
+		)
+		log.Infof("Showing comment input prompt for work item #%d", workItemID)
+		return nil, prompt
+	}
+
+	return prepareCommentFile(workItemID), nil
+}
+
+// prepareCommentFile creates the temp file the comment is composed in
+func prepareCommentFile(workItemID int) tea.Cmd {
+	return func() tea.Msg {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return NotificationMsg{Message: fmt.Sprintf("Failed to get home directory: %v", err), IsError: true}
+		}
+
+		tempDir := filepath.Join(homeDir, ".azure-boards-cli", "tmp")
+		if err := os.MkdirAll(tempDir, 0755); err != nil {
+			return NotificationMsg{Message: fmt.Sprintf("Failed to create temp directory: %v", err), IsError: true}
+		}
+
+		tempFile := filepath.Join(tempDir, fmt.Sprintf("comment-workitem-%d.md", workItemID))
+		if err := os.WriteFile(tempFile, []byte(commentEditorHeader), 0600); err != nil {
+			return NotificationMsg{Message: fmt.Sprintf("Failed to write temp file: %v", err), IsError: true}
+		}
+
+		return OpenCommentEditorMsg{FilePath: tempFile, WorkItemID: workItemID}
+	}
+}
+
+// postCommentFromFile reads a composed comment and posts it
+func postCommentFromFile(client api.APIClient, filePath string, workItemID int) tea.Cmd {
+	return func() tea.Msg {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return NotificationMsg{Message: fmt.Sprintf("Failed to read comment: %v", err), IsError: true}
+		}
+		if err := os.Remove(filePath); err != nil {
+			log.Warnf("Failed to remove comment file %s: %v", filePath, err)
+		}
+
+		text := parseCommentFile(string(data))
+		if text == "" {
+			return NotificationMsg{Message: "Empty comment, nothing posted", IsError: false}
+		}
+
+		return postComment(client, workItemID, text)()
+	}
+}
+
+// postComment posts plain text as a comment on a work item, turning @alias
+// mentions into identity mentions so the people mentioned are notified
+func postComment(client api.APIClient, workItemID int, text string) tea.Cmd {
+	return func() tea.Msg {
+		log.Infof("Adding comment to work item #%d", workItemID)
+
+		cache, err := mentions.LoadCache()
+		if err != nil {
+			log.Warnf("Mention cache unavailable: %v", err)
+		}
+		resolver := mentions.NewResolver(client, cache)
+		body, warnings := resolver.Expand(commentTextToHTML(text))
+		if err := resolver.Save(); err != nil {
+			log.Warnf("Failed to save mention cache: %v", err)
+		}
+
+		_, err = client.AddComment(workItemID, body)
+		return CommentAddedMsg{WorkItemID: workItemID, Warnings: warnings, Error: err}
+	}
+}
+
+// parseCommentFile drops the instruction lines of commentEditorHeader and
+// surrounding whitespace from an edited comment
+func parseCommentFile(content string) string {
+	instructions := make(map[string]bool)
+	for _, line := range strings.Split(commentEditorHeader, "\n") {
+		if strings.HasPrefix(line, "#") {
+			instructions[line] = true
+		}
+	}
+
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if instructions[strings.TrimRight(line, " \r")] {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// commentTextToHTML escapes plain text and keeps its line breaks, since comments are stored as HTML
+func commentTextToHTML(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
+
+// commentHTMLToText converts a comment's HTML to plain text for display
+func commentHTMLToText(text string) string {
+	text = commentBreakPattern.ReplaceAllString(text, "\n")
+	text = commentTagPattern.ReplaceAllString(text, "")
+	return strings.TrimSpace(html.UnescapeString(text))
+}
+
+// formatComments renders the comment section of the details pane
+func (t *WorkItemsTab) formatComments(workItemID int) string {
+	if t.loadingComments[workItemID] {
+		return "Comments: loading...\n\n"
+	}
+
+	comments, ok := t.comments[workItemID]
+	if !ok {
+		return ""
+	}
+	if len(comments) == 0 {
+		return "Comments: none\n\n"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Comments (%d):\n", len(comments))
+	for _, comment := range comments {
+		author := "Unknown"
+		if comment.CreatedBy != nil && comment.CreatedBy.DisplayName != nil {
+			author = *comment.CreatedBy.DisplayName
+		}
+		date := ""
+		if comment.CreatedDate != nil {
+			date = comment.CreatedDate.Time.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(&b, "  %s · %s\n", author, date)
+
+		text := ""
+		if comment.Text != nil {
+			text = commentHTMLToText(*comment.Text)
+		}
+		for _, line := range strings.Split(text, "\n") {
+			fmt.Fprintf(&b, "    %s\n", line)
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
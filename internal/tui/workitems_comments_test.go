@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCommentFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"only header", commentEditorHeader, ""},
+		{"text above header", "Looks good to me\n" + commentEditorHeader, "Looks good to me"},
+		{"multiple lines", "\nFirst line\nSecond line\n" + commentEditorHeader, "First line\nSecond line"},
+		{"keeps other lines starting with #", "# Steps\n#1234 is related\n" + commentEditorHeader, "# Steps\n#1234 is related"},
+		{"windows line endings", "Done\r\n" + strings.ReplaceAll(commentEditorHeader, "\n", "\r\n"), "Done"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCommentFile(tt.content); got != tt.want {
+				t.Errorf("parseCommentFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentHTMLConversion(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(string) string
+		in   string
+		want string
+	}{
+		{"escape and keep line breaks", commentTextToHTML, "a < b\nnext", "a &lt; b<br>next"},
+		{"strip tags", commentHTMLToText, "<div>Hello <b>world</b></div>", "Hello world"},
+		{"breaks become newlines", commentHTMLToText, "one<br>two<br/>three", "one\ntwo\nthree"},
+		{"unescape entities", commentHTMLToText, "a &amp; b", "a & b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.in); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/model"
+)
+
+// compareFieldOrder lists the fields shown first in the comparison view; the
+// remaining fields follow alphabetically
+var compareFieldOrder = []string{
+	"System.Title",
+	"System.WorkItemType",
+	"System.State",
+	"System.Reason",
+	"System.AssignedTo",
+	"System.AreaPath",
+	"System.IterationPath",
+	"Microsoft.VSTS.Common.Priority",
+	"System.Tags",
+	"System.Description",
+	"Microsoft.VSTS.Common.AcceptanceCriteria",
+	"System.CreatedDate",
+	"System.CreatedBy",
+	"System.ChangedDate",
+}
+
+// compareLabelWidth is the width of the field name column
+const compareLabelWidth = 28
+
+// compareRow is one field of the comparison view
+type compareRow struct {
+	Field   string
+	Left    string
+	Right   string
+	Differs bool
+}
+
+// togglePin pins the selected work item for comparison. Pinning a second work
+// item opens the comparison view; pinning the pinned item again unpins it.
+func (t *WorkItemsTab) togglePin() tea.Cmd {
+	item, ok := t.list.SelectedItem().(workItemItem)
+	if !ok {
+		return nil
+	}
+
+	var message string
+	switch {
+	case t.pinned == nil:
+		wi := item.workItem
+		t.pinned = &wi
+		message = fmt.Sprintf("Pinned #%d for comparison - pin another work item to compare", item.ID)
+	case getIntField(t.pinned, "System.Id") == item.ID:
+		t.pinned = nil
+		message = fmt.Sprintf("Unpinned #%d", item.ID)
+	default:
+		t.openComparison(*t.pinned, item.workItem)
+		return nil
+	}
+
+	return func() tea.Msg {
+		return NotificationMsg{Message: message}
+	}
+}
+
+// openComparison shows left and right side by side
+func (t *WorkItemsTab) openComparison(left, right workitemtracking.WorkItem) {
+	t.comparing = true
+	t.compareItems = [2]workitemtracking.WorkItem{left, right}
+	t.compareView.Width = t.Width()
+	t.compareView.Height = t.ContentHeight() - 2
+	t.compareView.SetContent(t.formatComparison())
+	t.compareView.GotoTop()
+}
+
+// setCompareKeys sets the keys that close the comparison: the close binding
+// and the compare binding that opened it
+func (t *WorkItemsTab) setCompareKeys(closeBinding, compareBinding key.Binding) {
+	keys := append(append([]string{}, closeBinding.Keys()...), compareBinding.Keys()...)
+	t.closeCompare = key.NewBinding(key.WithKeys(keys...), key.WithHelp(closeBinding.Help().Key, "close"))
+}
+
+// closeComparison leaves the comparison view and clears the pin
+func (t *WorkItemsTab) closeComparison() {
+	t.comparing = false
+	t.pinned = nil
+}
+
+// updateComparison handles input while the comparison view is open
+func (t *WorkItemsTab) updateComparison(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(msg, t.closeCompare) {
+			t.closeComparison()
+			return nil
+		}
+	}
+
+	var cmd tea.Cmd
+	t.compareView, cmd = t.compareView.Update(msg)
+	return cmd
+}
+
+// viewComparison renders the comparison view
+func (t *WorkItemsTab) viewComparison() string {
+	rows := compareFields(&t.compareItems[0], &t.compareItems[1])
+	differing := 0
+	for _, row := range rows {
+		if row.Differs {
+			differing++
+		}
+	}
+
+	header := TitleStyle.Render("Compare Work Items") + "  " +
+		MutedStyle.Render(fmt.Sprintf("%d of %d fields differ • ↑/↓ scroll • %s %s", differing, len(rows), t.closeCompare.Help().Key, t.closeCompare.Help().Desc))
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", t.compareView.View())
+}
+
+// formatComparison renders the field-by-field comparison table
+func (t *WorkItemsTab) formatComparison() string {
+	left, right := &t.compareItems[0], &t.compareItems[1]
+	valueWidth := (t.Width() - compareLabelWidth - 4) / 2
+	if valueWidth < 10 {
+		valueWidth = 10
+	}
+
+	labelStyle := lipgloss.NewStyle().Width(compareLabelWidth).PaddingRight(2)
+	valueStyle := lipgloss.NewStyle().Width(valueWidth).PaddingRight(2)
+
+	var lines []string
+	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top,
+		labelStyle.Render(""),
+		valueStyle.Inherit(TitleStyle).Render(fmt.Sprintf("#%d %s", getIntField(left, "System.Id"), model.FieldString(left.Fields, "System.Title"))),
+		valueStyle.Inherit(TitleStyle).Render(fmt.Sprintf("#%d %s", getIntField(right, "System.Id"), model.FieldString(right.Fields, "System.Title"))),
+	))
+
+	for _, row := range compareFields(left, right) {
+		label, values := labelStyle.Inherit(MutedStyle), valueStyle.Inherit(NormalStyle)
+		if row.Differs {
+			label, values = labelStyle.Inherit(WarningStyle).Bold(true), valueStyle.Inherit(WarningStyle)
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top,
+			label.Render(row.Field),
+			values.Render(orDash(row.Left)),
+			values.Render(orDash(row.Right)),
+		))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// compareFields lines up the fields of two work items, common fields first
+func compareFields(left, right *workitemtracking.WorkItem) []compareRow {
+	names := make(map[string]bool)
+	for _, wi := range []*workitemtracking.WorkItem{left, right} {
+		if wi.Fields == nil {
+			continue
+		}
+		for name := range *wi.Fields {
+			names[name] = true
+		}
+	}
+	// IDs and revisions always differ and are shown in the header
+	delete(names, "System.Id")
+	delete(names, "System.Rev")
+
+	var ordered []string
+	for _, name := range compareFieldOrder {
+		if names[name] {
+			ordered = append(ordered, name)
+			delete(names, name)
+		}
+	}
+	var rest []string
+	for name := range names {
+		rest = append(rest, name)
+	}
+	sort.Strings(rest)
+	ordered = append(ordered, rest...)
+
+	rows := make([]compareRow, 0, len(ordered))
+	for _, name := range ordered {
+		l, r := compareValue(left, name), compareValue(right, name)
+		rows = append(rows, compareRow{Field: name, Left: l, Right: r, Differs: l != r})
+	}
+	return rows
+}
+
+// compareValue formats a field for display: identities by display name and
+// HTML as plain text
+func compareValue(wi *workitemtracking.WorkItem, name string) string {
+	if wi.Fields == nil {
+		return ""
+	}
+	value, ok := (*wi.Fields)[name]
+	if !ok || value == nil {
+		return ""
+	}
+	if identity, ok := value.(map[string]interface{}); ok {
+		if displayName, ok := identity["displayName"].(string); ok {
+			return displayName
+		}
+		if uniqueName, ok := identity["uniqueName"].(string); ok {
+			return uniqueName
+		}
+	}
+	text := fmt.Sprintf("%v", value)
+	if strings.Contains(text, "<") {
+		text = commentHTMLToText(text)
+	}
+	return strings.TrimSpace(text)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestCompareFields(t *testing.T) {
+	left := workitemtracking.WorkItem{Fields: &map[string]interface{}{
+		"System.Id":          1,
+		"System.Title":       "Login fails",
+		"System.State":       "Active",
+		"System.AssignedTo":  map[string]interface{}{"displayName": "Ada"},
+		"Custom.Zeta":        "x",
+		"System.Description": "<div>Steps</div>",
+	}}
+	right := workitemtracking.WorkItem{Fields: &map[string]interface{}{
+		"System.Id":          2,
+		"System.Title":       "Login fails",
+		"System.State":       "New",
+		"System.AssignedTo":  map[string]interface{}{"displayName": "Ada"},
+		"Custom.Alpha":       "y",
+		"System.Description": "Steps",
+	}}
+
+	want := []compareRow{
+		{Field: "System.Title", Left: "Login fails", Right: "Login fails"},
+		{Field: "System.State", Left: "Active", Right: "New", Differs: true},
+		{Field: "System.AssignedTo", Left: "Ada", Right: "Ada"},
+		{Field: "System.Description", Left: "Steps", Right: "Steps"},
+		{Field: "Custom.Alpha", Right: "y", Differs: true},
+		{Field: "Custom.Zeta", Left: "x", Differs: true},
+	}
+
+	got := compareFields(&left, &right)
+	if len(got) != len(want) {
+		t.Fatalf("compareFields() returned %d rows, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWorkItemsTabCompareKeys(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tab := NewWorkItemsTab(nil, 80, 24)
+	tab.setCompareKeys(key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "close comparison")), key.NewBinding(key.WithKeys("P")))
+
+	for _, k := range []string{"x", "P"} {
+		tab.openComparison(workitemtracking.WorkItem{}, workitemtracking.WorkItem{})
+		tab.updateComparison(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		if tab.comparing {
+			t.Errorf("%q did not close the comparison", k)
+		}
+	}
+
+	tab.openComparison(workitemtracking.WorkItem{}, workitemtracking.WorkItem{})
+	tab.updateComparison(tea.KeyMsg{Type: tea.KeyEsc})
+	if !tab.comparing {
+		t.Error("esc closed the comparison although it is not bound")
+	}
+}
@@ -0,0 +1,252 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/log"
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+// skipOption is offered first when an optional field is picked from a list
+const skipOption = "(skip)"
+
+// createOptionalFields are asked after the required fields, when the type has them
+var createOptionalFields = []string{
+	"System.Description",
+	"System.AssignedTo",
+	"System.Tags",
+	"Microsoft.VSTS.Common.Priority",
+}
+
+// createSkippedFields are required but set by Azure DevOps when left out
+var createSkippedFields = map[string]bool{
+	"System.State":         true,
+	"System.Reason":        true,
+	"System.AreaPath":      true,
+	"System.IterationPath": true,
+	"System.TeamProject":   true,
+	"System.WorkItemType":  true,
+}
+
+// createAreaPath and createIteration prefill the area path and iteration
+// steps of the create wizard, from default_area_path and default_iteration
+var createAreaPath, createIteration string
+
+// SetCreateDefaults sets the area path and iteration new work items start
+// with; empty values leave them to Azure DevOps
+func SetCreateDefaults(areaPath, iteration string) {
+	createAreaPath, createIteration = areaPath, iteration
+}
+
+// createStep is a field asked by the create wizard
+type createStep struct {
+	Field    string
+	Name     string
+	Required bool
+	Allowed  []string // picklist values, chosen from a list instead of typed
+	Default  string   // value the step starts with, e.g. a template's title
+}
+
+// createWizard collects the fields of a new work item one step at a time:
+// the type is picked first, then the required fields, then optional ones
+type createWizard struct {
+	workItemType string
+	steps        []createStep
+	index        int
+	fields       map[string]interface{}
+	template     *templates.Template // set when creating from a template
+}
+
+// newCreateWizard starts a wizard for a work item type
+func newCreateWizard(workItemType string, steps []createStep) *createWizard {
+	return &createWizard{workItemType: workItemType, steps: steps, fields: make(map[string]interface{})}
+}
+
+// current returns the step being asked
+func (w *createWizard) current() createStep {
+	return w.steps[w.index]
+}
+
+// title describes the current step, e.g. "New Bug (2/5): Severity"
+func (w *createWizard) title() string {
+	step := w.current()
+	title := fmt.Sprintf("New %s (%d/%d): %s", w.workItemType, w.index+1, len(w.steps), step.Name)
+	if w.template != nil {
+		title = fmt.Sprintf("New %s from '%s' (%d/%d): %s", w.workItemType, w.template.Name, w.index+1, len(w.steps), step.Name)
+	}
+	if step.Required {
+		title += " *"
+	}
+	return title
+}
+
+// answer records the value of the current step and moves to the next one. It
+// reports whether all steps are answered; a missing required value is an error.
+func (w *createWizard) answer(value string) (bool, error) {
+	step := w.current()
+	value = strings.TrimSpace(value)
+	if value == skipOption {
+		value = ""
+	}
+	if value == "" && step.Required {
+		return false, fmt.Errorf("%s is required", step.Name)
+	}
+	if value != "" {
+		w.fields[step.Field] = value
+	}
+	w.index++
+	return w.index >= len(w.steps), nil
+}
+
+// buildCreateSteps turns the fields of a work item type into wizard steps:
+// the title, the required fields without a default, the area path and
+// iteration when defaults are configured, then the optional fields
+func buildCreateSteps(fields []workitemtracking.WorkItemTypeFieldWithReferences) []createStep {
+	byName := make(map[string]workitemtracking.WorkItemTypeFieldWithReferences)
+	for _, field := range fields {
+		if field.ReferenceName != nil {
+			byName[*field.ReferenceName] = field
+		}
+	}
+	step := func(ref string, required bool) createStep {
+		s := createStep{Field: ref, Name: ref, Required: required}
+		field := byName[ref]
+		if field.Name != nil {
+			s.Name = *field.Name
+		}
+		if field.AllowedValues != nil {
+			for _, value := range *field.AllowedValues {
+				s.Allowed = append(s.Allowed, fmt.Sprint(value))
+			}
+		}
+		return s
+	}
+
+	steps := []createStep{step("System.Title", true)}
+	for _, field := range fields {
+		if field.ReferenceName == nil || field.AlwaysRequired == nil || !*field.AlwaysRequired {
+			continue
+		}
+		ref := *field.ReferenceName
+		if ref == "System.Title" || createSkippedFields[ref] || field.DefaultValue != nil {
+			continue
+		}
+		steps = append(steps, step(ref, true))
+	}
+	for _, prefill := range [][2]string{{"System.AreaPath", createAreaPath}, {"System.IterationPath", createIteration}} {
+		if prefill[1] != "" {
+			s := step(prefill[0], false)
+			s.Default = prefill[1]
+			steps = append(steps, s)
+		}
+	}
+	for _, ref := range createOptionalFields {
+		if _, ok := byName[ref]; ok {
+			steps = append(steps, step(ref, false))
+		}
+	}
+	return steps
+}
+
+// showCreateStep asks the current step of the create wizard, from a list for
+// picklist fields and in the input prompt otherwise
+func (d *Dashboard) showCreateStep(wizard *createWizard) tea.Cmd {
+	step := wizard.current()
+	if len(step.Allowed) > 0 {
+		options := step.Allowed
+		if !step.Required {
+			options = append([]string{skipOption}, options...)
+		}
+		d.selectionDlg.Show(wizard.title(), options, string(wizard.action()), wizard)
+		if step.Default != "" {
+			d.selectionDlg.Select(step.Default)
+		}
+		return nil
+	}
+
+	placeholder := "Optional, Enter to skip"
+	if step.Required {
+		placeholder = "Required"
+	}
+	cmd := d.inputPrompt.Show(wizard.title(), placeholder, string(wizard.action()), wizard)
+	if step.Default != "" {
+		d.inputPrompt.Input.SetValue(step.Default)
+		d.inputPrompt.Input.CursorEnd()
+	}
+	return cmd
+}
+
+// answerCreateStep records an answer of the create wizard and asks the next
+// step, keeping the action pending, or creates the work item after the last one
+func (d *Dashboard) answerCreateStep(pending *PendingAction, wizard *createWizard, value string) tea.Cmd {
+	done, err := wizard.answer(value)
+	if err != nil {
+		d.actions.ContinueAction(pending, StepInput, wizard)
+		return tea.Batch(d.showCreateStep(wizard), func() tea.Msg {
+			return NotificationMsg{Message: err.Error(), IsError: true}
+		})
+	}
+	if !done {
+		d.actions.ContinueAction(pending, StepInput, wizard)
+		return d.showCreateStep(wizard)
+	}
+	if wizard.template != nil {
+		return executeCreateWorkItemFromTemplate(d.client, wizard.filledTemplate())
+	}
+	return createWorkItemFromWizard(d.client, wizard.workItemType, wizard.fields)
+}
+
+// loadCreatableTypes fetches the work item types a new work item can have
+func loadCreatableTypes(client api.APIClient) tea.Cmd {
+	return func() tea.Msg {
+		workItemTypes, err := client.GetWorkItemTypes()
+		if err != nil {
+			log.Errorf("Failed to fetch work item types: %v", err)
+			return WorkItemTypesLoadedMsg{Error: err}
+		}
+		var names []string
+		for _, workItemType := range *workItemTypes {
+			if workItemType.Name == nil || (workItemType.IsDisabled != nil && *workItemType.IsDisabled) {
+				continue
+			}
+			names = append(names, *workItemType.Name)
+		}
+		sort.Strings(names)
+		return WorkItemTypesLoadedMsg{Types: names}
+	}
+}
+
+// loadCreateSteps fetches the fields of a work item type for the create wizard
+func loadCreateSteps(client api.APIClient, workItemType string) tea.Cmd {
+	return func() tea.Msg {
+		fields, err := client.GetWorkItemTypeFields(workItemType)
+		if err != nil {
+			log.Errorf("Failed to fetch fields of '%s': %v", workItemType, err)
+			return CreateStepsLoadedMsg{WorkItemType: workItemType, Error: err}
+		}
+		var list []workitemtracking.WorkItemTypeFieldWithReferences
+		if fields != nil {
+			list = *fields
+		}
+		return CreateStepsLoadedMsg{WorkItemType: workItemType, Steps: buildCreateSteps(list)}
+	}
+}
+
+// createWorkItemFromWizard creates the work item the wizard collected
+func createWorkItemFromWizard(client api.APIClient, workItemType string, fields map[string]interface{}) tea.Cmd {
+	return func() tea.Msg {
+		log.Infof("Creating %s from the create wizard", workItemType)
+		workItem, err := client.CreateWorkItem(workItemType, fields, 0)
+		if err != nil {
+			log.Errorf("Failed to create work item: %v", err)
+			return NotificationMsg{Message: fmt.Sprintf("Failed to create work item: %v", err), IsError: true}
+		}
+		return WorkItemCreatedMsg{WorkItem: workItem}
+	}
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestBuildCreateSteps(t *testing.T) {
+	field := func(ref, name string, required bool, def interface{}, allowed ...interface{}) workitemtracking.WorkItemTypeFieldWithReferences {
+		f := workitemtracking.WorkItemTypeFieldWithReferences{ReferenceName: &ref, Name: &name, AlwaysRequired: &required, DefaultValue: def}
+		if len(allowed) > 0 {
+			f.AllowedValues = &allowed
+		}
+		return f
+	}
+	fields := []workitemtracking.WorkItemTypeFieldWithReferences{
+		field("System.State", "State", true, nil, "New", "Active"),
+		field("Microsoft.VSTS.Common.Severity", "Severity", true, nil, "1 - Critical", "2 - High"),
+		field("Custom.Team", "Team", true, "Core"),
+		field("System.Title", "Title", true, nil),
+		field("Microsoft.VSTS.Common.Priority", "Priority", false, nil, 1, 2),
+		field("System.Tags", "Tags", false, nil),
+	}
+
+	want := []createStep{
+		{Field: "System.Title", Name: "Title", Required: true},
+		{Field: "Microsoft.VSTS.Common.Severity", Name: "Severity", Required: true, Allowed: []string{"1 - Critical", "2 - High"}},
+		{Field: "System.Tags", Name: "Tags"},
+		{Field: "Microsoft.VSTS.Common.Priority", Name: "Priority", Allowed: []string{"1", "2"}},
+	}
+	if got := buildCreateSteps(fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("buildCreateSteps() = %+v, want %+v", got, want)
+	}
+
+	// Configured defaults prefill the area path and iteration
+	SetCreateDefaults(`Fabrikam\Web`, `Fabrikam\Sprint 3`)
+	defer SetCreateDefaults("", "")
+	fields = append(fields, field("System.AreaPath", "Area Path", true, nil))
+	want = append(want[:2:2],
+		createStep{Field: "System.AreaPath", Name: "Area Path", Default: `Fabrikam\Web`},
+		createStep{Field: "System.IterationPath", Name: "System.IterationPath", Default: `Fabrikam\Sprint 3`},
+		want[2], want[3])
+	if got := buildCreateSteps(fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("buildCreateSteps() with defaults = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateWizardAnswer(t *testing.T) {
+	wizard := newCreateWizard("Bug", []createStep{
+		{Field: "System.Title", Name: "Title", Required: true},
+		{Field: "System.Tags", Name: "Tags"},
+		{Field: "Microsoft.VSTS.Common.Priority", Name: "Priority", Allowed: []string{"1", "2"}},
+	})
+
+	if _, err := wizard.answer("  "); err == nil {
+		t.Fatal("expected an error for an empty required field")
+	}
+	if wizard.title() != "New Bug (1/3): Title *" {
+		t.Errorf("title() = %q", wizard.title())
+	}
+	for _, value := range []string{"Crash on save", "", skipOption} {
+		done, err := wizard.answer(value)
+		if err != nil {
+			t.Fatalf("answer(%q) error = %v", value, err)
+		}
+		if done != (value == skipOption) {
+			t.Errorf("answer(%q) done = %v", value, done)
+		}
+	}
+
+	want := map[string]interface{}{"System.Title": "Crash on save"}
+	if !reflect.DeepEqual(wizard.fields, want) {
+		t.Errorf("fields = %v, want %v", wizard.fields, want)
+	}
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+	"gopkg.in/yaml.v3"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/log"
+	"github.com/SOMUCHDOG/azb/internal/templates"
+)
+
+// applyWorkItemEdit updates the edited fields. The update only applies if the
+// work item is still at msg.Rev, the revision it was read at; if someone else
+// changed it meanwhile, the user is shown what they changed and asked whether
+// to re-apply the edits on top. original holds the fields as they were read.
+func applyWorkItemEdit(msg ProcessEditedWorkItemMsg, original, fields map[string]interface{}) tea.Msg {
+	var err error
+	if msg.Rev > 0 {
+		_, err = msg.Client.UpdateWorkItemAtRevision(msg.WorkItemID, msg.Rev, fields)
+	} else {
+		_, err = msg.Client.UpdateWorkItem(msg.WorkItemID, fields)
+	}
+	if errors.Is(err, api.ErrRevisionConflict) {
+		log.Warnf("Work item #%d changed since revision %d; edits kept in %s", msg.WorkItemID, msg.Rev, msg.FilePath)
+		return editConflict(msg, original, fields)
+	}
+	if err != nil {
+		log.Errorf("Failed to update work item #%d: %v", msg.WorkItemID, err)
+		return NotificationMsg{
+			Message: fmt.Sprintf("Failed to update work item #%d: %v", msg.WorkItemID, err),
+			IsError: true,
+		}
+	}
+
+	log.Infof("Successfully updated %d field(s) of work item #%d", len(fields), msg.WorkItemID)
+
+	// Clean up temp file
+	os.Remove(msg.FilePath)
+
+	return NotificationMsg{
+		Message: fmt.Sprintf("Successfully updated work item #%d", msg.WorkItemID),
+		IsError: false,
+	}
+}
+
+// editConflict fetches the latest revision of an edited work item and asks
+// whether to re-apply the edited fields to it
+func editConflict(msg ProcessEditedWorkItemMsg, original, fields map[string]interface{}) tea.Msg {
+	kept := NotificationMsg{
+		Message: fmt.Sprintf("Work item #%d was changed by someone else while you edited it; nothing was saved, your edits are in %s", msg.WorkItemID, msg.FilePath),
+		IsError: true,
+	}
+
+	latest, err := msg.Client.GetWorkItem(msg.WorkItemID)
+	if err != nil || latest.Rev == nil {
+		log.Errorf("Failed to fetch the latest revision of work item #%d: %v", msg.WorkItemID, err)
+		return kept
+	}
+
+	// Compare in the form the edit was made in, so values read from the
+	// server and from YAML are alike
+	yamlData, err := yaml.Marshal(convertWorkItemToTemplate(msg.Client, latest))
+	if err != nil {
+		return kept
+	}
+	var current templates.Template
+	if err := yaml.Unmarshal(yamlData, &current); err != nil {
+		return kept
+	}
+
+	retry := msg
+	retry.Rev = *latest.Rev
+	return ConfirmMsg{
+		Prompt: formatEditConflict(msg, api.CompareFields(original, current.Fields), fields),
+		Action: "reapply_edit",
+		OnConfirm: func() tea.Msg {
+			return applyWorkItemEdit(retry, current.Fields, fields)
+		},
+	}
+}
+
+// formatEditConflict describes the changes made by someone else while a work
+// item was edited, marking the fields that were edited too
+func formatEditConflict(msg ProcessEditedWorkItemMsg, theirs []api.FieldChange, fields map[string]interface{}) string {
+	return formatUpdateConflict(msg.WorkItemID, theirs, fields) + "\nIf not, your edits stay in " + msg.FilePath
+}
+
+// formatUpdateConflict describes the changes made by someone else since a work
+// item was read, marks the fields about to be changed too and asks whether to
+// re-apply the changes on top
+func formatUpdateConflict(workItemID int, theirs []api.FieldChange, fields map[string]interface{}) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Work item #%d was changed by someone else since you opened it:\n\n", workItemID)
+	if len(theirs) == 0 {
+		b.WriteString("  " + MutedStyle.Render("no changes to the fields you can edit") + "\n")
+	}
+	for _, line := range historyChangeLines(api.WorkItemRevision{Changes: theirs}) {
+		b.WriteString("  " + line + "\n")
+	}
+
+	var edited, both []string
+	for name := range fields {
+		edited = append(edited, activityFieldLabel(name))
+	}
+	for _, change := range theirs {
+		if _, ok := fields[change.Field]; ok {
+			both = append(both, activityFieldLabel(change.Field))
+		}
+	}
+	sort.Strings(edited)
+
+	if len(both) > 0 {
+		fmt.Fprintf(&b, "\n%s\n", WarningStyle.Render("You both changed: "+strings.Join(both, ", ")))
+	}
+	fmt.Fprintf(&b, "\nRe-apply your changes to %s on top?", strings.Join(edited, ", "))
+	return b.String()
+}
+
+// workItemSnapshot is a work item as an action read it: the revision to update
+// it at and its fields, to show what someone else changed on a conflict
+type workItemSnapshot struct {
+	ID     int
+	Rev    int
+	Fields map[string]interface{}
+}
+
+// snapshotOf returns the snapshot of a work item as read
+func snapshotOf(workItem *workitemtracking.WorkItem) *workItemSnapshot {
+	snapshot := &workItemSnapshot{ID: *workItem.Id}
+	if workItem.Rev != nil {
+		snapshot.Rev = *workItem.Rev
+	}
+	if workItem.Fields != nil {
+		snapshot.Fields = *workItem.Fields
+	}
+	return snapshot
+}
+
+// updateWorkItemAt updates fields of a work item only if it is still at the
+// revision it was read at. If someone else changed it meanwhile, the user is
+// shown what they changed and asked whether to re-apply fields on top.
+func updateWorkItemAt(client api.APIClient, read *workItemSnapshot, fields map[string]interface{}) tea.Msg {
+	var workItem *workitemtracking.WorkItem
+	var err error
+	if read.Rev > 0 {
+		workItem, err = client.UpdateWorkItemAtRevision(read.ID, read.Rev, fields)
+	} else {
+		workItem, err = client.UpdateWorkItem(read.ID, fields)
+	}
+	if errors.Is(err, api.ErrRevisionConflict) {
+		log.Warnf("Work item #%d changed since revision %d", read.ID, read.Rev)
+		latest, getErr := client.GetWorkItem(read.ID)
+		if getErr != nil || latest.Rev == nil || latest.Fields == nil {
+			log.Errorf("Failed to fetch the latest revision of work item #%d: %v", read.ID, getErr)
+			return WorkItemUpdatedMsg{Error: err}
+		}
+		retry := snapshotOf(latest)
+		return ConfirmMsg{
+			Prompt: formatUpdateConflict(read.ID, api.CompareFields(read.Fields, retry.Fields), fields),
+			Action: "reapply_update",
+			OnConfirm: func() tea.Msg {
+				return updateWorkItemAt(client, retry, fields)
+			},
+		}
+	}
+	if err != nil {
+		return WorkItemUpdatedMsg{Error: err}
+	}
+	return WorkItemUpdatedMsg{WorkItem: workItem}
+}
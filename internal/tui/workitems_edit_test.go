@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/api/apitest"
+)
+
+const editOriginal = `type: Bug
+fields:
+  System.Title: Login fails
+  System.State: Active
+  System.Description: <div>Steps</div>
+`
+
+func TestChangedFields(t *testing.T) {
+	original := map[string]interface{}{
+		"System.Title": "Login fails",
+		"System.Tags":  []interface{}{"a", "b"},
+		"Priority":     2,
+	}
+
+	tests := []struct {
+		name   string
+		edited map[string]interface{}
+		want   []string
+	}{
+		{"unchanged", map[string]interface{}{"System.Title": "Login fails", "System.Tags": []interface{}{"a", "b"}, "Priority": 2}, nil},
+		{"changed value", map[string]interface{}{"System.Title": "Login fails on Safari", "Priority": 2}, []string{"System.Title"}},
+		{"changed list", map[string]interface{}{"System.Tags": []interface{}{"a"}}, []string{"System.Tags"}},
+		{"added field", map[string]interface{}{"System.State": "Resolved"}, []string{"System.State"}},
+		{"removed field", map[string]interface{}{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := changedFields(original, tt.edited)
+			if len(got) != len(tt.want) {
+				t.Fatalf("changedFields() = %v, want keys %v", got, tt.want)
+			}
+			for _, key := range tt.want {
+				if _, ok := got[key]; !ok {
+					t.Errorf("changedFields() = %v, missing %s", got, key)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessEditedWorkItem(t *testing.T) {
+	edit := func(t *testing.T, client api.APIClient, edited string) (tea.Msg, string) {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "edit-workitem-7.yaml")
+		if err := os.WriteFile(path, []byte(edited), 0600); err != nil {
+			t.Fatal(err)
+		}
+		msg := processEditedWorkItem(ProcessEditedWorkItemMsg{
+			FilePath:   path,
+			WorkItemID: 7,
+			Rev:        3,
+			Original:   []byte(editOriginal),
+			Client:     client,
+		})()
+		return msg, path
+	}
+
+	t.Run("sends only changed fields at the revision read", func(t *testing.T) {
+		client := apitest.New()
+		var gotRev int
+		var gotFields map[string]interface{}
+		client.UpdateWorkItemAtRevisionFunc = func(id, rev int, fields map[string]interface{}) (*workitemtracking.WorkItem, error) {
+			gotRev, gotFields = rev, fields
+			return &workitemtracking.WorkItem{Id: &id}, nil
+		}
+
+		result, path := edit(t, client, strings.Replace(editOriginal, "Active", "Resolved", 1))
+		if msg, ok := result.(NotificationMsg); !ok || msg.IsError {
+			t.Fatalf("processEditedWorkItem() = %+v, want success", result)
+		}
+		if gotRev != 3 || len(gotFields) != 1 || gotFields["System.State"] != "Resolved" {
+			t.Errorf("UpdateWorkItemAtRevision(rev %d, %v), want rev 3 with only System.State", gotRev, gotFields)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("temp file still exists after a successful update")
+		}
+	})
+
+	t.Run("skips the update when nothing changed", func(t *testing.T) {
+		client := apitest.New()
+		result, _ := edit(t, client, editOriginal)
+		if msg, ok := result.(NotificationMsg); !ok || msg.IsError || !strings.Contains(msg.Message, "No changes") {
+			t.Errorf("processEditedWorkItem() = %+v, want no changes", result)
+		}
+		if len(client.Calls()) != 0 {
+			t.Errorf("calls = %v, want none", client.Calls())
+		}
+	})
+
+	t.Run("offers to re-apply on a conflict", func(t *testing.T) {
+		client := apitest.New()
+		var revs []int
+		var gotFields map[string]interface{}
+		client.UpdateWorkItemAtRevisionFunc = func(id, rev int, fields map[string]interface{}) (*workitemtracking.WorkItem, error) {
+			revs, gotFields = append(revs, rev), fields
+			if rev == 3 {
+				return nil, api.ErrRevisionConflict
+			}
+			return &workitemtracking.WorkItem{Id: &id}, nil
+		}
+		client.GetWorkItemFunc = func(id int) (*workitemtracking.WorkItem, error) {
+			rev := 5
+			return &workitemtracking.WorkItem{Id: &id, Rev: &rev, Fields: &map[string]interface{}{
+				"System.Title":       "Login fails",
+				"System.State":       "Closed",
+				"System.Description": "<div>Steps</div>",
+			}}, nil
+		}
+
+		result, path := edit(t, client, strings.Replace(editOriginal, "Login fails", "Login fails on Safari", 1))
+		confirm, ok := result.(ConfirmMsg)
+		if !ok {
+			t.Fatalf("processEditedWorkItem() = %+v, want a confirmation", result)
+		}
+		if !strings.Contains(confirm.Prompt, "State: Active → Closed") || !strings.Contains(confirm.Prompt, path) {
+			t.Errorf("prompt = %q, want the State change and the edits file", confirm.Prompt)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("temp file removed after a conflict: %v", err)
+		}
+
+		if msg, ok := confirm.OnConfirm().(NotificationMsg); !ok || msg.IsError {
+			t.Fatalf("re-apply = %+v, want success", msg)
+		}
+		if len(revs) != 2 || revs[1] != 5 || len(gotFields) != 1 || gotFields["System.Title"] != "Login fails on Safari" {
+			t.Errorf("UpdateWorkItemAtRevision revs %v with %v, want the title re-applied at rev 5", revs, gotFields)
+		}
+	})
+}
+
+func TestFormatEditConflict(t *testing.T) {
+	msg := ProcessEditedWorkItemMsg{FilePath: "/tmp/edit-workitem-7.yaml", WorkItemID: 7}
+	theirs := []api.FieldChange{
+		{Field: "System.State", OldValue: "Active", NewValue: "Closed"},
+		{Field: "System.Title", OldValue: "Login fails", NewValue: "Login broken"},
+	}
+	fields := map[string]interface{}{"System.Title": "Login fails on Safari", "System.Tags": "web"}
+
+	got := formatEditConflict(msg, theirs, fields)
+	for _, want := range []string{"#7", "State: Active → Closed", "You both changed: Title", "Re-apply your changes to Tags, Title", msg.FilePath} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatEditConflict() = %q, missing %q", got, want)
+		}
+	}
+}
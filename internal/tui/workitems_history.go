@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/model"
+	"github.com/SOMUCHDOG/azb/internal/output"
+)
+
+// historyValueWidth is the longest value shown before it is shortened
+const historyValueWidth = 60
+
+// openHistory opens the revision history of the work item shown in the details view
+func (t *WorkItemsTab) openHistory() tea.Cmd {
+	if !t.showDetails || t.selectedItem == nil || t.selectedItem.Id == nil {
+		return nil
+	}
+
+	id := *t.selectedItem.Id
+	t.showingHistory = true
+	t.historyFor = id
+	t.history = nil
+	t.historyErr = nil
+	t.historyLoading = true
+	t.historyView.Width = t.Width()
+	t.historyView.Height = t.ContentHeight() - 2
+	t.historyView.SetContent(MutedStyle.Render("Loading history..."))
+	t.historyView.GotoTop()
+
+	client := t.client
+	return func() tea.Msg {
+		revisions, err := client.GetWorkItemUpdates(id)
+		return WorkItemHistoryLoadedMsg{WorkItemID: id, Revisions: revisions, Error: err}
+	}
+}
+
+// handleHistoryLoaded shows loaded revisions if the history view is still open for them
+func (t *WorkItemsTab) handleHistoryLoaded(msg WorkItemHistoryLoadedMsg) {
+	if !t.showingHistory || msg.WorkItemID != t.historyFor {
+		return
+	}
+	t.historyLoading = false
+	t.history = msg.Revisions
+	t.historyErr = msg.Error
+	t.historyView.SetContent(t.formatHistory())
+	t.historyView.GotoTop()
+}
+
+// updateHistory handles input while the history view is open
+func (t *WorkItemsTab) updateHistory(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(msg, key.NewBinding(key.WithKeys("esc", "H"))) {
+			t.showingHistory = false
+			return nil
+		}
+	}
+
+	var cmd tea.Cmd
+	t.historyView, cmd = t.historyView.Update(msg)
+	return cmd
+}
+
+// viewHistory renders the history view
+func (t *WorkItemsTab) viewHistory() string {
+	title := fmt.Sprintf("History of #%d", t.historyFor)
+	if t.selectedItem != nil && getIntField(t.selectedItem, "System.Id") == t.historyFor {
+		title += " " + model.FieldString(t.selectedItem.Fields, "System.Title")
+	}
+
+	status := "↑/↓ scroll • esc close"
+	if !t.historyLoading && t.historyErr == nil {
+		status = fmt.Sprintf("%d revisions • %s", len(t.history), status)
+	}
+
+	header := TitleStyle.Render(title) + "  " + MutedStyle.Render(status)
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", t.historyView.View())
+}
+
+// formatHistory lists the revisions, newest first, with the fields each one changed
+func (t *WorkItemsTab) formatHistory() string {
+	if t.historyErr != nil {
+		return ErrorStyle.Render(fmt.Sprintf("Failed to load history: %v", t.historyErr))
+	}
+	if len(t.history) == 0 {
+		return MutedStyle.Render("No revisions found.")
+	}
+
+	var b strings.Builder
+	for i := len(t.history) - 1; i >= 0; i-- {
+		rev := t.history[i]
+
+		author := rev.Author
+		if author == "" {
+			author = "Someone"
+		}
+		header := fmt.Sprintf("Rev %d • %s", rev.Rev, author)
+		if !rev.Date.IsZero() {
+			header += " • " + rev.Date.Local().Format("2006-01-02 15:04")
+		}
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render(header) + "\n")
+
+		lines := historyChangeLines(rev)
+		if len(lines) == 0 {
+			lines = []string{MutedStyle.Render("no field changes")}
+		}
+		for _, line := range lines {
+			b.WriteString("  " + line + "\n")
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// historyChangeLines describes the field, comment and link changes of a revision
+func historyChangeLines(rev api.WorkItemRevision) []string {
+	var lines []string
+	for _, change := range rev.Changes {
+		label := activityFieldLabel(change.Field)
+		oldValue, newValue := historyValue(change.OldValue), historyValue(change.NewValue)
+		switch {
+		case oldValue == "":
+			lines = append(lines, fmt.Sprintf("%s: %s", label, newValue))
+		case newValue == "":
+			lines = append(lines, fmt.Sprintf("%s: %s → %s", label, oldValue, MutedStyle.Render("(cleared)")))
+		default:
+			lines = append(lines, fmt.Sprintf("%s: %s → %s", label, oldValue, newValue))
+		}
+	}
+
+	if rev.Comment != "" {
+		lines = append(lines, "Comment: "+historyValue(rev.Comment))
+	}
+	if rev.LinksAdded > 0 {
+		lines = append(lines, fmt.Sprintf("added %d link(s)", rev.LinksAdded))
+	}
+	if rev.LinksRemoved > 0 {
+		lines = append(lines, fmt.Sprintf("removed %d link(s)", rev.LinksRemoved))
+	}
+	return lines
+}
+
+// historyValue renders a field value on one line, with rich text reduced to plain text
+func historyValue(value interface{}) string {
+	text := activityValue(value)
+	if isLongValue(value) {
+		text = strings.Join(strings.Fields(commentHTMLToText(text)), " ")
+	}
+	if len([]rune(text)) > historyValueWidth {
+		text = output.FitCell(text, historyValueWidth)
+	}
+	return text
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+)
+
+func TestHistoryChangeLines(t *testing.T) {
+	rev := api.WorkItemRevision{
+		Changes: []api.FieldChange{
+			{Field: "System.AssignedTo", NewValue: map[string]interface{}{"displayName": "Ada"}},
+			{Field: "System.Description", OldValue: "<div>Steps</div>", NewValue: "<div>Steps to <b>reproduce</b> the login failure on the second attempt after a timeout</div>"},
+			{Field: "System.State", OldValue: "New", NewValue: "Active"},
+		},
+		Comment:    "<p>Looking into it</p>",
+		LinksAdded: 2,
+	}
+
+	want := []string{
+		"Assigned To: Ada",
+		"Description: Steps → Steps to reproduce the login failure on the second attemp...",
+		"State: New → Active",
+		"Comment: Looking into it",
+		"added 2 link(s)",
+	}
+	if got := historyChangeLines(rev); !reflect.DeepEqual(got, want) {
+		t.Errorf("historyChangeLines() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestHandleHistoryLoadedIgnoresOtherItems(t *testing.T) {
+	tab := &WorkItemsTab{showingHistory: true, historyFor: 7, historyLoading: true}
+
+	tab.handleHistoryLoaded(WorkItemHistoryLoadedMsg{WorkItemID: 42, Revisions: []api.WorkItemRevision{{Rev: 1}}})
+	if !tab.historyLoading || tab.history != nil {
+		t.Errorf("history of another work item was shown")
+	}
+
+	tab.handleHistoryLoaded(WorkItemHistoryLoadedMsg{WorkItemID: 7, Revisions: []api.WorkItemRevision{{Rev: 1}}})
+	if tab.historyLoading || len(tab.history) != 1 {
+		t.Errorf("history = %+v, loading %v, want 1 revision loaded", tab.history, tab.historyLoading)
+	}
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DetailsLayout is where the Work Items tab places the details pane
+type DetailsLayout string
+
+const (
+	DetailsBelow DetailsLayout = "below" // under the list
+	DetailsSide  DetailsLayout = "side"  // beside the list
+	DetailsAuto  DetailsLayout = "auto"  // beside the list on wide terminals
+)
+
+const (
+	// minSideBySideWidth is the terminal width from which auto puts the details beside the list
+	minSideBySideWidth = 140
+
+	DefaultDetailsSize = 50 // percent of the tab the details pane takes
+	MinDetailsSize     = 20
+	MaxDetailsSize     = 80
+	detailsSizeStep    = 10
+)
+
+var (
+	detailsLayout = DetailsBelow
+	detailsSize   = DefaultDetailsSize
+)
+
+// SetDetailsLayout sets where the details pane is placed and the percentage
+// of the tab it takes. Call it before creating the dashboard.
+func SetDetailsLayout(layout DetailsLayout, size int) {
+	detailsLayout = layout
+	detailsSize = clampDetailsSize(size)
+}
+
+// ParseDetailsLayout parses a details layout: below, side or auto
+func ParseDetailsLayout(value string) (DetailsLayout, error) {
+	switch layout := DetailsLayout(strings.ToLower(strings.TrimSpace(value))); layout {
+	case "":
+		return DetailsBelow, nil
+	case DetailsBelow, DetailsSide, DetailsAuto:
+		return layout, nil
+	default:
+		return "", fmt.Errorf("invalid details layout '%s' (expected below, side or auto)", value)
+	}
+}
+
+// clampDetailsSize keeps a details pane size between the minimum and maximum
+func clampDetailsSize(size int) int {
+	return max(MinDetailsSize, min(size, MaxDetailsSize))
+}
+
+// sideBySide reports whether the details pane is shown beside the list
+func (t *WorkItemsTab) sideBySide() bool {
+	return t.layout == DetailsSide || (t.layout == DetailsAuto && t.Width() >= minSideBySideWidth)
+}
+
+// toggleSplit moves the details pane between below and beside the list
+func (t *WorkItemsTab) toggleSplit() tea.Cmd {
+	message := "Details shown beside the list"
+	if t.sideBySide() {
+		t.layout = DetailsBelow
+		message = "Details shown below the list"
+	} else {
+		t.layout = DetailsSide
+	}
+	t.updateSizes()
+	return func() tea.Msg {
+		return NotificationMsg{Message: message}
+	}
+}
+
+// resizeDetails grows or shrinks the details pane by a step
+func (t *WorkItemsTab) resizeDetails(steps int) {
+	t.detailsSize = clampDetailsSize(t.detailsSize + steps*detailsSizeStep)
+	t.updateSizes()
+}
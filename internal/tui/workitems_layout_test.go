@@ -0,0 +1,56 @@
+package tui
+
+import "testing"
+
+func TestWorkItemsTab_DetailsLayout(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tests := []struct {
+		name          string
+		layout        DetailsLayout
+		size          int
+		width         int
+		wantSide      bool
+		wantListWidth int
+	}{
+		{"below", DetailsBelow, 50, 200, false, 200},
+		{"side", DetailsSide, 50, 100, true, 50},
+		{"side with larger details", DetailsSide, 70, 100, true, 30},
+		{"auto on narrow terminal", DetailsAuto, 50, 100, false, 100},
+		{"auto on wide terminal", DetailsAuto, 40, 200, true, 120},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDetailsLayout(tt.layout, tt.size)
+			defer SetDetailsLayout(DetailsBelow, DefaultDetailsSize)
+
+			tab := NewWorkItemsTab(nil, tt.width, 40)
+			tab.showDetails = true
+			tab.updateSizes()
+
+			if got := tab.sideBySide(); got != tt.wantSide {
+				t.Errorf("sideBySide() = %v, want %v", got, tt.wantSide)
+			}
+			if got := tab.list.Width(); got != tt.wantListWidth {
+				t.Errorf("list width = %d, want %d", got, tt.wantListWidth)
+			}
+		})
+	}
+}
+
+func TestWorkItemsTab_ResizeDetails(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tab := NewWorkItemsTab(nil, 100, 40)
+	for range 10 {
+		tab.resizeDetails(1)
+	}
+	if tab.detailsSize != MaxDetailsSize {
+		t.Errorf("detailsSize = %d, want %d", tab.detailsSize, MaxDetailsSize)
+	}
+	for range 10 {
+		tab.resizeDetails(-1)
+	}
+	if tab.detailsSize != MinDetailsSize {
+		t.Errorf("detailsSize = %d, want %d", tab.detailsSize, MinDetailsSize)
+	}
+}
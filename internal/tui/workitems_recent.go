@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/recent"
+)
+
+// recentViewLimit is how many recent work items the Recent view shows
+const recentViewLimit = 20
+
+// recentView lists the work items last viewed or edited with azb
+var recentView = WorkItemView{Name: "Recent", recent: true}
+
+// toggleRecent switches between the Recent view and the view shown before it
+func (t *WorkItemsTab) toggleRecent() tea.Cmd {
+	t.showingRecent = !t.showingRecent
+	t.list.Title = t.listTitle()
+	t.list.ResetSelected()
+	t.showDetails = false
+	t.updateSizes()
+	t.loading = true
+	t.err = nil
+
+	message := fmt.Sprintf("View: %s", t.currentView().Name)
+	return tea.Batch(t.fetchWorkItems(), func() tea.Msg {
+		return NotificationMsg{Message: message, IsError: false}
+	})
+}
+
+// fetchRecentWorkItems loads the recent work items of the organization, most recent first
+func fetchRecentWorkItems(client api.APIClient) ([]workitemtracking.WorkItem, error) {
+	store, err := recent.Load()
+	if err != nil {
+		return nil, err
+	}
+	items := store.ForOrg(client.GetOrganizationURL(), recentViewLimit)
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	workItems, err := client.GetWorkItems(ids)
+	if err != nil {
+		return nil, err
+	}
+	return orderByIDs(workItems, ids), nil
+}
+
+// orderByIDs sorts work items in the order of ids, dropping ones that no longer exist
+func orderByIDs(workItems []workitemtracking.WorkItem, ids []int) []workitemtracking.WorkItem {
+	byID := make(map[int]workitemtracking.WorkItem, len(workItems))
+	for _, wi := range workItems {
+		if wi.Id != nil {
+			byID[*wi.Id] = wi
+		}
+	}
+	ordered := make([]workitemtracking.WorkItem, 0, len(ids))
+	for _, id := range ids {
+		if wi, ok := byID[id]; ok {
+			ordered = append(ordered, wi)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,17 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestOrderByIDs(t *testing.T) {
+	item := func(id int) workitemtracking.WorkItem { return workitemtracking.WorkItem{Id: &id} }
+	workItems := []workitemtracking.WorkItem{item(1), item(2), item(3)}
+
+	got := orderByIDs(workItems, []int{3, 4, 1})
+	if len(got) != 2 || *got[0].Id != 3 || *got[1].Id != 1 {
+		t.Errorf("orderByIDs() = %v, want items 3 and 1", got)
+	}
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/SOMUCHDOG/azb/internal/log"
+)
+
+// refreshRetryDelay is the first delay before retrying an auto-refresh that
+// was held off or failed; it doubles up to the refresh interval
+const refreshRetryDelay = 5 * time.Second
+
+// refreshInterval is how often the Work Items tab reloads its view; 0 disables it
+var refreshInterval time.Duration
+
+// SetRefreshInterval sets how often the Work Items tab reloads its view. An
+// interval of 0 disables auto-refresh. Call it before creating the dashboard.
+func SetRefreshInterval(interval time.Duration) {
+	refreshInterval = interval
+}
+
+// scheduleRefresh schedules the next auto-refresh. Each call supersedes the
+// ones before it, so manual refreshes and view switches restart the interval.
+func (t *WorkItemsTab) scheduleRefresh(delay time.Duration) tea.Cmd {
+	t.refreshID++
+	if refreshInterval <= 0 {
+		return nil
+	}
+	id := t.refreshID
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return AutoRefreshMsg{ID: id}
+	})
+}
+
+// retryDelay returns the delay before retrying a held off or failed
+// auto-refresh, doubling it for the next retry
+func (t *WorkItemsTab) retryDelay() time.Duration {
+	if t.refreshBackoff == 0 {
+		t.refreshBackoff = refreshRetryDelay
+	} else {
+		t.refreshBackoff *= 2
+	}
+	if t.refreshBackoff > refreshInterval {
+		t.refreshBackoff = refreshInterval
+	}
+	return t.refreshBackoff
+}
+
+// handleAutoRefresh reloads the view in the background, unless the user is
+// busy filtering or in a dialog, in which case it is retried a bit later
+func (t *WorkItemsTab) handleAutoRefresh(msg AutoRefreshMsg) tea.Cmd {
+	if msg.ID != t.refreshID || t.loading || t.refreshing {
+		return nil
+	}
+	t.list.Title = t.listTitle()
+	if msg.Busy || t.list.FilterState() == list.Filtering {
+		delay := t.retryDelay()
+		log.Debugf("WorkItemsTab: Auto-refresh held off for %s", delay)
+		return t.scheduleRefresh(delay)
+	}
+
+	log.Debugf("WorkItemsTab: Auto-refreshing '%s'", t.currentView().Name)
+	t.refreshing = true
+	return t.fetchWorkItems()
+}
+
+// handleRefreshFailed keeps the loaded work items when an auto-refresh fails
+// and retries it later; they stay marked stale until a refresh succeeds
+func (t *WorkItemsTab) handleRefreshFailed(err error) tea.Cmd {
+	log.Warnf("WorkItemsTab: Auto-refresh failed: %v", err)
+	return tea.Batch(t.scheduleRefresh(t.retryDelay()), func() tea.Msg {
+		return NotificationMsg{Message: fmt.Sprintf("Auto-refresh failed: %v", err), IsError: true}
+	})
+}
+
+// keepSelection moves the cursor back to the work item selected before an
+// auto-refresh, which may have moved in the list, and updates the details
+// pane with its reloaded fields without scrolling it
+func (t *WorkItemsTab) keepSelection(id int) {
+	if id == 0 {
+		return
+	}
+	workItem, ok := t.selectListItem(id)
+	if !ok || !t.showDetails {
+		return
+	}
+	t.selectedItem = &workItem.workItem
+	t.viewport.SetContent(t.formatWorkItemDetails(workItem.workItem))
+}
+
+// stale reports whether the work items are older than the refresh interval
+func (t *WorkItemsTab) stale() bool {
+	if refreshInterval <= 0 || t.loadedAt.IsZero() {
+		return false
+	}
+	return time.Since(t.loadedAt) >= refreshInterval
+}
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestWorkItemsTab_HandleAutoRefresh(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	SetRefreshInterval(time.Minute)
+	defer SetRefreshInterval(0)
+
+	tests := []struct {
+		name           string
+		msg            func(tab *WorkItemsTab) AutoRefreshMsg
+		loading        bool
+		wantRefreshing bool
+		wantBackoff    time.Duration
+	}{
+		{
+			name:           "refreshes",
+			msg:            func(tab *WorkItemsTab) AutoRefreshMsg { return AutoRefreshMsg{ID: tab.refreshID} },
+			wantRefreshing: true,
+		},
+		{
+			name: "superseded",
+			msg:  func(tab *WorkItemsTab) AutoRefreshMsg { return AutoRefreshMsg{ID: tab.refreshID - 1} },
+		},
+		{
+			name:    "already loading",
+			msg:     func(tab *WorkItemsTab) AutoRefreshMsg { return AutoRefreshMsg{ID: tab.refreshID} },
+			loading: true,
+		},
+		{
+			name:        "busy",
+			msg:         func(tab *WorkItemsTab) AutoRefreshMsg { return AutoRefreshMsg{ID: tab.refreshID, Busy: true} },
+			wantBackoff: refreshRetryDelay,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab := NewWorkItemsTab(nil, 80, 24)
+			tab.scheduleRefresh(refreshInterval)
+			tab.loading = tt.loading
+
+			tab.handleAutoRefresh(tt.msg(tab))
+
+			if tab.refreshing != tt.wantRefreshing {
+				t.Errorf("refreshing = %v, want %v", tab.refreshing, tt.wantRefreshing)
+			}
+			if tab.refreshBackoff != tt.wantBackoff {
+				t.Errorf("refreshBackoff = %v, want %v", tab.refreshBackoff, tt.wantBackoff)
+			}
+		})
+	}
+}
+
+func TestWorkItemsTab_RefreshFailedKeepsWorkItems(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	SetRefreshInterval(10 * time.Second)
+	defer SetRefreshInterval(0)
+
+	tab := NewWorkItemsTab(nil, 80, 24)
+	tab.Update(WorkItemsLoadedMsg{WorkItems: []workitemtracking.WorkItem{treeTestWorkItem(1, 0)}})
+	tab.loadedAt = time.Now().Add(-time.Minute)
+
+	for _, want := range []time.Duration{5 * time.Second, 10 * time.Second, 10 * time.Second} {
+		tab.refreshing = true
+		tab.Update(WorkItemsLoadedMsg{Error: errors.New("offline")})
+		if tab.err != nil || len(tab.workItems) != 1 {
+			t.Fatalf("failed auto-refresh replaced the work items (err %v, %d items)", tab.err, len(tab.workItems))
+		}
+		if tab.refreshBackoff != want {
+			t.Errorf("refreshBackoff = %v, want %v", tab.refreshBackoff, want)
+		}
+	}
+	if !tab.stale() {
+		t.Error("work items older than the interval should be stale")
+	}
+}
+
+func TestWorkItemsTab_RefreshKeepsSelection(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tab := NewWorkItemsTab(nil, 80, 24)
+	tab.Update(WorkItemsLoadedMsg{WorkItems: []workitemtracking.WorkItem{treeTestWorkItem(1, 0), treeTestWorkItem(2, 0), treeTestWorkItem(3, 0)}})
+	tab.list.Select(1)
+
+	// A new work item shows up above the selected one
+	tab.refreshing = true
+	tab.Update(WorkItemsLoadedMsg{WorkItems: []workitemtracking.WorkItem{treeTestWorkItem(4, 0), treeTestWorkItem(1, 0), treeTestWorkItem(2, 0), treeTestWorkItem(3, 0)}})
+
+	if item, ok := tab.list.SelectedItem().(workItemItem); !ok || item.ID != 2 {
+		t.Errorf("selected %+v after the refresh, want #2", tab.list.SelectedItem())
+	}
+}
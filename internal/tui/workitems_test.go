@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/api/apitest"
 )
 
 // Mock client for testing - doesn't make real API calls
@@ -66,86 +69,6 @@ func TestExtractWorkItemIDFromURL(t *testing.T) {
 	}
 }
 
-func TestGetStringField(t *testing.T) {
-	tests := []struct {
-		name      string
-		workItem  *workitemtracking.WorkItem
-		fieldName string
-		expected  string
-	}{
-		{
-			name: "existing string field",
-			workItem: &workitemtracking.WorkItem{
-				Fields: &map[string]interface{}{
-					"System.Title": "Test Work Item",
-				},
-			},
-			fieldName: "System.Title",
-			expected:  "Test Work Item",
-		},
-		{
-			name: "non-existent field",
-			workItem: &workitemtracking.WorkItem{
-				Fields: &map[string]interface{}{},
-			},
-			fieldName: "System.Description",
-			expected:  "",
-		},
-		{
-			name: "nil fields",
-			workItem: &workitemtracking.WorkItem{
-				Fields: nil,
-			},
-			fieldName: "System.Title",
-			expected:  "",
-		},
-		{
-			name: "identity field with display name",
-			workItem: &workitemtracking.WorkItem{
-				Fields: &map[string]interface{}{
-					"System.AssignedTo": map[string]interface{}{
-						"displayName": "John Doe",
-						"uniqueName":  "john.doe@example.com",
-					},
-				},
-			},
-			fieldName: "System.AssignedTo",
-			expected:  "John Doe",
-		},
-		{
-			name: "identity field with only unique name",
-			workItem: &workitemtracking.WorkItem{
-				Fields: &map[string]interface{}{
-					"System.AssignedTo": map[string]interface{}{
-						"uniqueName": "jane.doe@example.com",
-					},
-				},
-			},
-			fieldName: "System.AssignedTo",
-			expected:  "jane.doe@example.com",
-		},
-		{
-			name: "numeric field converted to string",
-			workItem: &workitemtracking.WorkItem{
-				Fields: &map[string]interface{}{
-					"Microsoft.VSTS.Common.Priority": 1,
-				},
-			},
-			fieldName: "Microsoft.VSTS.Common.Priority",
-			expected:  "1",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := getStringField(tt.workItem, tt.fieldName)
-			if result != tt.expected {
-				t.Errorf("getStringField() = %q, want %q", result, tt.expected)
-			}
-		})
-	}
-}
-
 func TestGetEmailField(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -533,3 +456,31 @@ func intPtr(i int) *int {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestAddWorkItemTags(t *testing.T) {
+	// Someone adds "urgent" between the first read and the update
+	client := apitest.New()
+	revisions := []string{"bug; ui", "bug; ui; urgent"}
+	rev := 0
+	client.GetWorkItemFunc = func(id int) (*workitemtracking.WorkItem, error) {
+		rev = min(rev+1, len(revisions))
+		tags, current := revisions[rev-1], rev
+		return &workitemtracking.WorkItem{Id: &id, Rev: &current, Fields: &map[string]interface{}{"System.Tags": tags}}, nil
+	}
+	var saved []interface{}
+	client.UpdateWorkItemAtRevisionFunc = func(id, rev int, fields map[string]interface{}) (*workitemtracking.WorkItem, error) {
+		if rev == 1 {
+			return nil, api.ErrRevisionConflict
+		}
+		saved = append(saved, fields["System.Tags"])
+		return &workitemtracking.WorkItem{Id: &id}, nil
+	}
+
+	msg, ok := addWorkItemTags(client, 7, "UI, web")().(WorkItemUpdatedMsg)
+	if !ok || msg.Error != nil {
+		t.Fatalf("addWorkItemTags() = %+v, want an update", msg)
+	}
+	if len(saved) != 1 || saved[0] != "bug; ui; urgent; web" {
+		t.Errorf("saved tags = %v, want the tags added to the latest revision", saved)
+	}
+}
@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+
+	"github.com/SOMUCHDOG/azb/internal/log"
+)
+
+// toggleTreeView switches between the flat list and the hierarchy tree
+func (t *WorkItemsTab) toggleTreeView() tea.Cmd {
+	t.treeView = !t.treeView
+	t.rebuildList()
+	if t.treeView {
+		return t.fetchTreeParents()
+	}
+	return nil
+}
+
+// toggleTreeNode expands or collapses the selected node, loading its children on first expand
+func (t *WorkItemsTab) toggleTreeNode() tea.Cmd {
+	if !t.treeView {
+		return nil
+	}
+
+	item, ok := t.list.SelectedItem().(workItemItem)
+	if !ok || !item.HasChildren {
+		return nil
+	}
+
+	t.expandedItems[item.ID] = !t.expandedItems[item.ID]
+
+	if t.expandedItems[item.ID] && !t.loadingItems[item.ID] {
+		byID := t.treeWorkItems()
+		var missing []int
+		for _, childID := range childWorkItemIDs(&item.workItem) {
+			if _, ok := byID[childID]; !ok {
+				missing = append(missing, childID)
+			}
+		}
+		if len(missing) > 0 {
+			t.loadingItems[item.ID] = true
+			t.rebuildList()
+			return t.fetchTreeItems(item.ID, missing)
+		}
+	}
+
+	t.rebuildList()
+	return nil
+}
+
+// fetchTreeParents loads the parents of listed work items that are not loaded yet,
+// so the listed items can be grouped under them
+func (t *WorkItemsTab) fetchTreeParents() tea.Cmd {
+	byID := t.treeWorkItems()
+
+	var missing []int
+	requested := make(map[int]bool)
+	for i := range t.workItems {
+		parentID := parentWorkItemID(&t.workItems[i])
+		if parentID == 0 {
+			continue
+		}
+		// Show listed items under their parent right away
+		t.expandedItems[parentID] = true
+		if _, ok := byID[parentID]; !ok && !requested[parentID] {
+			requested[parentID] = true
+			missing = append(missing, parentID)
+		}
+	}
+
+	if len(missing) == 0 {
+		t.rebuildList()
+		return nil
+	}
+	return t.fetchTreeItems(0, missing)
+}
+
+// fetchTreeItems loads work items for the tree view in a single batch
+func (t *WorkItemsTab) fetchTreeItems(parentID int, ids []int) tea.Cmd {
+	client := t.client
+	return func() tea.Msg {
+		log.Debugf("WorkItemsTab: Loading %d tree item(s) for parent %d", len(ids), parentID)
+		workItems, err := client.GetWorkItems(ids)
+		return WorkItemTreeLoadedMsg{ParentID: parentID, WorkItems: workItems, Error: err}
+	}
+}
+
+// handleTreeLoaded caches loaded tree items and rebuilds the list
+func (t *WorkItemsTab) handleTreeLoaded(msg WorkItemTreeLoadedMsg) tea.Cmd {
+	delete(t.loadingItems, msg.ParentID)
+
+	if msg.Error != nil {
+		if msg.ParentID != 0 {
+			t.expandedItems[msg.ParentID] = false
+		}
+		t.rebuildList()
+		return func() tea.Msg {
+			return NotificationMsg{Message: fmt.Sprintf("Failed to load related work items: %v", msg.Error), IsError: true}
+		}
+	}
+
+	for i := range msg.WorkItems {
+		wi := msg.WorkItems[i]
+		if wi.Id != nil {
+			t.workItemCache[*wi.Id] = &wi
+		}
+	}
+
+	t.rebuildList()
+	return nil
+}
+
+// treeWorkItems returns every work item available to the tree: listed items and cached relatives
+func (t *WorkItemsTab) treeWorkItems() map[int]*workitemtracking.WorkItem {
+	byID := make(map[int]*workitemtracking.WorkItem, len(t.workItems)+len(t.workItemCache))
+	for id, wi := range t.workItemCache {
+		byID[id] = wi
+	}
+	for i := range t.workItems {
+		if t.workItems[i].Id != nil {
+			byID[*t.workItems[i].Id] = &t.workItems[i]
+		}
+	}
+	return byID
+}
+
+// flattenTree builds list items for the hierarchy, listing each root followed by
+// the children of expanded nodes
+func (t *WorkItemsTab) flattenTree() []list.Item {
+	byID := t.treeWorkItems()
+
+	// Walk each listed item up to its topmost loaded ancestor
+	var roots []int
+	isRoot := make(map[int]bool)
+	for _, wi := range t.sortedWorkItems() {
+		if wi.Id == nil {
+			continue
+		}
+		root := *wi.Id
+		climbed := map[int]bool{root: true}
+		for {
+			parentID := parentWorkItemID(byID[root])
+			if _, ok := byID[parentID]; !ok || climbed[parentID] {
+				break
+			}
+			climbed[parentID] = true
+			root = parentID
+		}
+		if !isRoot[root] {
+			isRoot[root] = true
+			roots = append(roots, root)
+		}
+	}
+
+	var items []list.Item
+	visited := make(map[int]bool)
+	for _, root := range roots {
+		items = t.appendTreeNode(items, byID, root, 0, visited)
+	}
+	return items
+}
+
+// appendTreeNode adds a node and, if expanded, its loaded children
+func (t *WorkItemsTab) appendTreeNode(items []list.Item, byID map[int]*workitemtracking.WorkItem, id, depth int, visited map[int]bool) []list.Item {
+	wi, ok := byID[id]
+	if !ok || visited[id] {
+		return items
+	}
+	visited[id] = true
+
+	children := childWorkItemIDs(wi)
+	item := newWorkItemItem(wi)
+	item.ID = id
+	item.Depth = depth
+	item.HasChildren = len(children) > 0
+	items = append(items, item)
+
+	if t.expandedItems[id] {
+		for _, childID := range children {
+			items = t.appendTreeNode(items, byID, childID, depth+1, visited)
+		}
+	}
+	return items
+}
+
+// parentWorkItemID returns the ID of a work item's parent, or 0 if it has none
+func parentWorkItemID(wi *workitemtracking.WorkItem) int {
+	if wi == nil || wi.Relations == nil {
+		return 0
+	}
+	for _, rel := range *wi.Relations {
+		if rel.Rel != nil && rel.Url != nil && *rel.Rel == "System.LinkTypes.Hierarchy-Reverse" {
+			return extractWorkItemIDFromURL(*rel.Url)
+		}
+	}
+	return 0
+}
+
+// childWorkItemIDs returns the IDs of a work item's children
+func childWorkItemIDs(wi *workitemtracking.WorkItem) []int {
+	var ids []int
+	if wi == nil || wi.Relations == nil {
+		return ids
+	}
+	for _, rel := range *wi.Relations {
+		if rel.Rel != nil && rel.Url != nil && *rel.Rel == "System.LinkTypes.Hierarchy-Forward" {
+			if id := extractWorkItemIDFromURL(*rel.Url); id > 0 {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// treeTestWorkItem builds a work item with optional parent and child links
+func treeTestWorkItem(id, parent int, children ...int) workitemtracking.WorkItem {
+	var relations []workitemtracking.WorkItemRelation
+	addRelation := func(rel string, target int) {
+		url := fmt.Sprintf("https://dev.azure.com/org/_apis/wit/workItems/%d", target)
+		relations = append(relations, workitemtracking.WorkItemRelation{Rel: &rel, Url: &url})
+	}
+	if parent > 0 {
+		addRelation("System.LinkTypes.Hierarchy-Reverse", parent)
+	}
+	for _, child := range children {
+		addRelation("System.LinkTypes.Hierarchy-Forward", child)
+	}
+
+	fields := map[string]interface{}{"System.Title": fmt.Sprintf("Item %d", id)}
+	return workitemtracking.WorkItem{Id: &id, Fields: &fields, Relations: &relations}
+}
+
+func TestWorkItemsTab_FlattenTree(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tab := NewWorkItemsTab(nil, 80, 24)
+	tab.workItems = []workitemtracking.WorkItem{
+		treeTestWorkItem(2, 1),
+		treeTestWorkItem(3, 1, 5),
+		treeTestWorkItem(4, 0),
+	}
+	parent := treeTestWorkItem(1, 0, 2, 3)
+	tab.workItemCache[1] = &parent
+
+	tests := []struct {
+		name      string
+		expanded  map[int]bool
+		wantIDs   []int
+		wantDepth []int
+	}{
+		{
+			name:      "parent expanded",
+			expanded:  map[int]bool{1: true},
+			wantIDs:   []int{1, 2, 3, 4},
+			wantDepth: []int{0, 1, 1, 0},
+		},
+		{
+			name:      "parent collapsed",
+			expanded:  map[int]bool{},
+			wantIDs:   []int{1, 4},
+			wantDepth: []int{0, 0},
+		},
+		{
+			name:      "unloaded children are skipped",
+			expanded:  map[int]bool{1: true, 3: true},
+			wantIDs:   []int{1, 2, 3, 4},
+			wantDepth: []int{0, 1, 1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab.expandedItems = tt.expanded
+			items := tab.flattenTree()
+
+			if len(items) != len(tt.wantIDs) {
+				t.Fatalf("flattenTree() returned %d items, want %d", len(items), len(tt.wantIDs))
+			}
+			for i, item := range items {
+				wi := item.(workItemItem)
+				if wi.ID != tt.wantIDs[i] || wi.Depth != tt.wantDepth[i] {
+					t.Errorf("item %d = #%d at depth %d, want #%d at depth %d", i, wi.ID, wi.Depth, tt.wantIDs[i], tt.wantDepth[i])
+				}
+			}
+		})
+	}
+}
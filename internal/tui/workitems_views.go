@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/SOMUCHDOG/azb/internal/api"
+	"github.com/SOMUCHDOG/azb/internal/wiql"
+)
+
+// defaultWorkItemsWIQL lists User Stories assigned to me, excluding closed and removed items
+var defaultWorkItemsWIQL = wiql.Select("System.Id", "System.Title", "System.State", "System.AssignedTo", "System.WorkItemType",
+	"System.Description", "Microsoft.VSTS.Common.AcceptanceCriteria", "System.CreatedDate",
+	"System.ChangedDate", "Microsoft.VSTS.Common.Priority", "System.Tags").
+	Where(
+		wiql.Field("System.AssignedTo").Eq(wiql.Me),
+		wiql.Field("System.WorkItemType").Eq(wiql.String("User Story")),
+		wiql.Field("System.State").Ne(wiql.String("Closed")),
+		wiql.Field("System.State").Ne(wiql.String("Removed")),
+	).
+	OrderBy("System.State", false).
+	String()
+
+// WorkItemView is a query the Work Items tab can show. Query is either inline
+// WIQL or the name or path of a saved query.
+type WorkItemView struct {
+	Name  string
+	Query string
+
+	recent bool // lists recently viewed and edited work items instead of running Query
+}
+
+// workItemViews are the views of the Work Items tab, the first one being the default
+var workItemViews = buildWorkItemViews("", nil)
+
+// SetWorkItemViews sets the query the Work Items tab loads first and the other
+// views it can switch to. Call it before creating the dashboard.
+func SetWorkItemViews(defaultQuery string, views []WorkItemView) {
+	workItemViews = buildWorkItemViews(defaultQuery, views)
+}
+
+// buildWorkItemViews puts the default query in front of the configured views,
+// skipping views without a query and naming unnamed ones after their query
+func buildWorkItemViews(defaultQuery string, views []WorkItemView) []WorkItemView {
+	defaultView := WorkItemView{Name: "Assigned to me", Query: defaultWorkItemsWIQL}
+	if query := strings.TrimSpace(defaultQuery); query != "" {
+		defaultView = WorkItemView{Name: "Default", Query: query}
+		if !isWIQL(query) {
+			defaultView.Name = query
+		}
+	}
+
+	result := []WorkItemView{defaultView}
+	for _, view := range views {
+		view.Query = strings.TrimSpace(view.Query)
+		if view.Query == "" {
+			continue
+		}
+		if view.Name == "" {
+			view.Name = view.Query
+			if isWIQL(view.Query) {
+				view.Name = fmt.Sprintf("View %d", len(result)+1)
+			}
+		}
+		result = append(result, view)
+	}
+	return result
+}
+
+// isWIQL reports whether a query is inline WIQL rather than a saved query name
+func isWIQL(query string) bool {
+	fields := strings.Fields(query)
+	return len(fields) > 0 && strings.EqualFold(fields[0], "SELECT")
+}
+
+// currentView returns the view the Work Items tab shows
+func (t *WorkItemsTab) currentView() WorkItemView {
+	if t.showingRecent {
+		return recentView
+	}
+	if t.viewIndex < len(t.views) {
+		return t.views[t.viewIndex]
+	}
+	return WorkItemView{Name: "Assigned to me", Query: defaultWorkItemsWIQL}
+}
+
+// listTitle returns the list title with the active sort and, when several views are configured, the view name
+func (t *WorkItemsTab) listTitle() string {
+	title := sortedListTitle(t.sort)
+	if len(t.views) > 1 || t.showingRecent {
+		title += " • " + t.currentView().Name
+	}
+	if t.stale() {
+		title += " • stale"
+	}
+	return title
+}
+
+// nextView switches to the next configured view and loads its work items
+func (t *WorkItemsTab) nextView() tea.Cmd {
+	if len(t.views) < 2 {
+		return func() tea.Msg {
+			return NotificationMsg{Message: "No other views configured (see dashboard.views in config.yaml)", IsError: false}
+		}
+	}
+
+	if t.showingRecent {
+		t.showingRecent = false
+	} else {
+		t.viewIndex = (t.viewIndex + 1) % len(t.views)
+	}
+	t.list.Title = t.listTitle()
+	t.list.ResetSelected()
+	t.showDetails = false
+	t.updateSizes()
+	t.loading = true
+	t.err = nil
+
+	message := fmt.Sprintf("View: %s", t.currentView().Name)
+	return tea.Batch(t.fetchWorkItems(), func() tea.Msg {
+		return NotificationMsg{Message: message, IsError: false}
+	})
+}
+
+// viewWIQL returns the WIQL of a view, looking up saved queries by path or name
+func viewWIQL(client api.APIClient, view WorkItemView) (string, error) {
+	if isWIQL(view.Query) {
+		return view.Query, nil
+	}
+
+	query, err := client.GetQuery(view.Query)
+	if err != nil || query.Id == nil {
+		query, err = api.FindQuery(client, view.Query)
+		if err != nil {
+			return "", err
+		}
+	}
+	if query.Wiql != nil && *query.Wiql != "" {
+		return *query.Wiql, nil
+	}
+	return client.GetQueryWIQL(query.Id.String())
+}
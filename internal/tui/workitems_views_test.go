@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildWorkItemViews(t *testing.T) {
+	wiql := "select [System.Id] from WorkItems"
+
+	tests := []struct {
+		name         string
+		defaultQuery string
+		views        []WorkItemView
+		want         []WorkItemView
+	}{
+		{
+			name: "built-in default",
+			want: []WorkItemView{{Name: "Assigned to me", Query: defaultWorkItemsWIQL}},
+		},
+		{
+			name:         "saved query as default",
+			defaultQuery: " Active Bugs ",
+			want:         []WorkItemView{{Name: "Active Bugs", Query: "Active Bugs"}},
+		},
+		{
+			name:         "views after the default",
+			defaultQuery: wiql,
+			views: []WorkItemView{
+				{Name: "Bugs", Query: "Shared Queries/Active Bugs"},
+				{Name: "Empty"},
+				{Query: wiql},
+			},
+			want: []WorkItemView{
+				{Name: "Default", Query: wiql},
+				{Name: "Bugs", Query: "Shared Queries/Active Bugs"},
+				{Name: "View 3", Query: wiql},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildWorkItemViews(tt.defaultQuery, tt.views); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildWorkItemViews() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsWIQL(t *testing.T) {
+	tests := map[string]bool{
+		"SELECT [System.Id] FROM WorkItems":   true,
+		"  select [System.Id] from workitems": true,
+		"Selected Bugs":                       false,
+		"Shared Queries/Active Bugs":          false,
+		"":                                    false,
+	}
+	for query, want := range tests {
+		if got := isWIQL(query); got != want {
+			t.Errorf("isWIQL(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// yamlKeyPattern matches the key of a mapping entry, up to its colon
+var yamlKeyPattern = regexp.MustCompile(`^("[^"]*"|'[^']*'|[^\s#'"][^:#]*?):(\s|$)`)
+
+// yamlLiteralPattern matches scalars that are not strings: numbers, booleans and null
+var yamlLiteralPattern = regexp.MustCompile(`^(-?[0-9][0-9_.eE+-]*|0x[0-9a-fA-F]+|true|false|True|False|TRUE|FALSE|yes|no|null|Null|NULL|~)$`)
+
+// yamlStyles are the colors of the parts of a YAML document
+type yamlStyles struct {
+	key, comment, str, literal, punct lipgloss.Style
+}
+
+// highlightYAML colors YAML for display: keys, comments, strings and other
+// scalars. Only colors are added, so the text shown is exactly the input.
+func highlightYAML(src string) string {
+	return yamlStyles{
+		key:     lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimary)),
+		comment: lipgloss.NewStyle().Foreground(lipgloss.Color(ColorMuted)),
+		str:     lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSuccess)),
+		literal: lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning)),
+		punct:   lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccent)),
+	}.highlight(src)
+}
+
+// highlight colors a YAML document line by line
+func (styles yamlStyles) highlight(src string) string {
+	lines := strings.Split(src, "\n")
+	blockIndent := -1 // indent of the key owning a block scalar (| or >) being shown
+	for i, line := range lines {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if blockIndent >= 0 {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if indent > blockIndent {
+				lines[i] = styles.str.Render(line)
+				continue
+			}
+			blockIndent = -1
+		}
+
+		highlighted, block := highlightYAMLLine(line[indent:], styles)
+		lines[i] = line[:indent] + highlighted
+		if block {
+			blockIndent = indent
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// highlightYAMLLine colors a line without its indentation. It reports whether
+// the line starts a block scalar, whose lines follow.
+func highlightYAMLLine(line string, styles yamlStyles) (string, bool) {
+	switch {
+	case line == "":
+		return "", false
+	case strings.HasPrefix(line, "#"):
+		return styles.comment.Render(line), false
+	case line == "---" || line == "...":
+		return styles.punct.Render(line), false
+	case line == "-":
+		return styles.punct.Render(line), false
+	case strings.HasPrefix(line, "- "):
+		rest, block := highlightYAMLLine(strings.TrimLeft(line[2:], " "), styles)
+		spaces := line[2 : len(line)-len(strings.TrimLeft(line[2:], " "))]
+		return styles.punct.Render("-") + " " + spaces + rest, block
+	}
+
+	if match := yamlKeyPattern.FindStringSubmatchIndex(line); match != nil {
+		keyEnd := match[3]
+		rest := line[keyEnd+1:]
+		value, block := highlightYAMLValue(rest, styles)
+		return styles.key.Render(line[:keyEnd]) + ":" + value, block
+	}
+	return highlightYAMLValue(line, styles)
+}
+
+// highlightYAMLValue colors a scalar value and a trailing comment
+func highlightYAMLValue(value string, styles yamlStyles) (string, bool) {
+	trimmed := strings.TrimLeft(value, " ")
+	spaces := value[:len(value)-len(trimmed)]
+	scalar, comment := splitYAMLComment(trimmed)
+	scalar, trailing := strings.TrimRight(scalar, " "), scalar[len(strings.TrimRight(scalar, " ")):]
+
+	block := false
+	var rendered string
+	switch {
+	case scalar == "":
+	case strings.HasPrefix(scalar, "|") || strings.HasPrefix(scalar, ">"):
+		rendered = styles.punct.Render(scalar)
+		block = true
+	case yamlLiteralPattern.MatchString(scalar):
+		rendered = styles.literal.Render(scalar)
+	case strings.HasPrefix(scalar, "[") || strings.HasPrefix(scalar, "{"):
+		rendered = styles.literal.Render(scalar)
+	default:
+		rendered = styles.str.Render(scalar)
+	}
+	if comment != "" {
+		comment = styles.comment.Render(comment)
+	}
+	return spaces + rendered + trailing + comment, block
+}
+
+// splitYAMLComment splits a value from a trailing # comment outside quotes
+func splitYAMLComment(value string) (string, string) {
+	start := 0
+	if value != "" && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			start = end + 2
+		}
+	}
+	for i := start; i < len(value); i++ {
+		if value[i] == '#' && (i == 0 || value[i-1] == ' ') {
+			return value[:i], value[i:]
+		}
+	}
+	return value, ""
+}
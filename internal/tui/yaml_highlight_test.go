@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestHighlightYAML(t *testing.T) {
+	tag := func(name string) lipgloss.Style {
+		return lipgloss.NewStyle().Transform(func(s string) string { return "<" + name + ">" + s + "</" + name + ">" })
+	}
+	styles := yamlStyles{key: tag("k"), comment: tag("c"), str: tag("s"), literal: tag("l"), punct: tag("p")}
+
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"key and string", "type: Bug", "<k>type</k>: <s>Bug</s>"},
+		{"literal with comment", "priority: 2  # high", "<k>priority</k>: <l>2</l>  <c># high</c>"},
+		{"quoted hash is not a comment", `title: "Fix #12"`, `<k>title</k>: <s>"Fix #12"</s>`},
+		{"comment line", "  # note", "  <c># note</c>"},
+		{"nested key", "fields:\n  System.Title: Crash", "<k>fields</k>:\n  <k>System.Title</k>: <s>Crash</s>"},
+		{"list item", "- title: Write tests", "<p>-</p> <k>title</k>: <s>Write tests</s>"},
+		{
+			"block scalar",
+			"description: |\n  Steps: open the app\n\n  Then: crash\ntype: Bug",
+			"<k>description</k>: <p>|</p>\n<s>  Steps: open the app</s>\n\n<s>  Then: crash</s>\n<k>type</k>: <s>Bug</s>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := styles.highlight(tt.src); got != tt.want {
+				t.Errorf("highlight() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
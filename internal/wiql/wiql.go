@@ -0,0 +1,211 @@
+// Package wiql builds Work Item Query Language queries. Values are always
+// quoted and escaped, so names and titles with quotes cannot change a query.
+package wiql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Value is the right-hand side of a condition: a quoted literal or a macro
+type Value struct {
+	text string
+}
+
+// String is a string literal, quoted and escaped
+func String(s string) Value {
+	return Value{text: "'" + strings.ReplaceAll(s, "'", "''") + "'"}
+}
+
+// Int is a number literal
+func Int(n int) Value {
+	return Value{text: fmt.Sprintf("%d", n)}
+}
+
+// Macros understood by the service
+var (
+	Me               = Value{text: "@Me"}
+	Project          = Value{text: "@Project"}
+	CurrentIteration = Value{text: "@CurrentIteration"}
+	Follows          = Value{text: "@Follows"}
+)
+
+// Today is the @Today macro moved by days, e.g. Today(-7) for a week ago
+func Today(days int) Value {
+	switch {
+	case days < 0:
+		return Value{text: fmt.Sprintf("@Today - %d", -days)}
+	case days > 0:
+		return Value{text: fmt.Sprintf("@Today + %d", days)}
+	}
+	return Value{text: "@Today"}
+}
+
+// String returns the value as it appears in WIQL
+func (v Value) String() string {
+	return v.text
+}
+
+// Condition is a WHERE clause predicate
+type Condition struct {
+	text string
+}
+
+// String returns the condition as it appears in WIQL
+func (c Condition) String() string {
+	return c.text
+}
+
+// Field refers to a field by reference name, e.g. Field("System.State")
+type Field string
+
+func (f Field) String() string {
+	return "[" + strings.NewReplacer("[", "", "]", "").Replace(string(f)) + "]"
+}
+
+func (f Field) compare(operator string, v Value) Condition {
+	return Condition{text: fmt.Sprintf("%s %s %s", f, operator, v)}
+}
+
+// Eq matches work items whose field equals v
+func (f Field) Eq(v Value) Condition { return f.compare("=", v) }
+
+// Ne matches work items whose field differs from v
+func (f Field) Ne(v Value) Condition { return f.compare("<>", v) }
+
+// Lt matches work items whose field is less than v
+func (f Field) Lt(v Value) Condition { return f.compare("<", v) }
+
+// Le matches work items whose field is at most v
+func (f Field) Le(v Value) Condition { return f.compare("<=", v) }
+
+// Gt matches work items whose field is greater than v
+func (f Field) Gt(v Value) Condition { return f.compare(">", v) }
+
+// Ge matches work items whose field is at least v
+func (f Field) Ge(v Value) Condition { return f.compare(">=", v) }
+
+// Contains matches text fields containing v and tag fields with the tag v
+func (f Field) Contains(v Value) Condition { return f.compare("CONTAINS", v) }
+
+// Under matches area and iteration paths at or below v
+func (f Field) Under(v Value) Condition { return f.compare("UNDER", v) }
+
+// In matches work items whose field is one of values
+func (f Field) In(values ...Value) Condition {
+	return f.list("IN", values)
+}
+
+// NotIn matches work items whose field is none of values. With no values it
+// is empty, which Where ignores.
+func (f Field) NotIn(values ...Value) Condition {
+	if len(values) == 0 {
+		return Condition{}
+	}
+	return f.list("NOT IN", values)
+}
+
+func (f Field) list(operator string, values []Value) Condition {
+	texts := make([]string, len(values))
+	for i, v := range values {
+		texts[i] = v.text
+	}
+	return Condition{text: fmt.Sprintf("%s %s (%s)", f, operator, strings.Join(texts, ", "))}
+}
+
+// And matches work items matching all conditions. Like Or, it leaves out
+// empty conditions, and with none it is empty itself, which Where ignores.
+func And(conditions ...Condition) Condition {
+	return join("AND", conditions)
+}
+
+// Or matches work items matching any of conditions
+func Or(conditions ...Condition) Condition {
+	return join("OR", conditions)
+}
+
+func join(operator string, conditions []Condition) Condition {
+	var texts []string
+	for _, c := range conditions {
+		if c.text != "" {
+			texts = append(texts, c.text)
+		}
+	}
+	switch len(texts) {
+	case 0:
+		return Condition{}
+	case 1:
+		return Condition{text: texts[0]}
+	}
+	return Condition{text: "(" + strings.Join(texts, " "+operator+" ") + ")"}
+}
+
+// Query is a flat work item query
+type Query struct {
+	fields     []Field
+	conditions []Condition
+	orderBy    []string
+	asOf       time.Time
+}
+
+// Select starts a query returning fields, or only System.Id when none are given
+func Select(fields ...Field) *Query {
+	if len(fields) == 0 {
+		fields = []Field{"System.Id"}
+	}
+	return &Query{fields: fields}
+}
+
+// Where adds conditions, which must all match. Empty conditions are ignored.
+func (q *Query) Where(conditions ...Condition) *Query {
+	for _, c := range conditions {
+		if c.text != "" {
+			q.conditions = append(q.conditions, c)
+		}
+	}
+	return q
+}
+
+// OrderBy sorts by a field, after any earlier sort fields
+func (q *Query) OrderBy(field Field, descending bool) *Query {
+	order := field.String()
+	if descending {
+		order += " DESC"
+	} else {
+		order += " ASC"
+	}
+	q.orderBy = append(q.orderBy, order)
+	return q
+}
+
+// AsOf runs the query against the work items as they were at t
+func (q *Query) AsOf(t time.Time) *Query {
+	q.asOf = t
+	return q
+}
+
+// String returns the query text
+func (q *Query) String() string {
+	fields := make([]string, len(q.fields))
+	for i, f := range q.fields {
+		fields[i] = f.String()
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT " + strings.Join(fields, ", ") + " FROM WorkItems")
+	if len(q.conditions) > 0 {
+		texts := make([]string, len(q.conditions))
+		for i, c := range q.conditions {
+			texts[i] = c.text
+		}
+		b.WriteString(" WHERE " + strings.Join(texts, " AND "))
+	}
+	if len(q.orderBy) > 0 {
+		b.WriteString(" ORDER BY " + strings.Join(q.orderBy, ", "))
+	}
+	if !q.asOf.IsZero() {
+		b.WriteString(" ASOF " + String(q.asOf.UTC().Format(time.RFC3339)).text)
+	}
+	return b.String()
+}
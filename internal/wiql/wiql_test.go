@@ -0,0 +1,69 @@
+package wiql
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConditions(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Condition
+		want string
+	}{
+		{"string", Field("System.Title").Eq(String("Login")), "[System.Title] = 'Login'"},
+		{"quotes are escaped", Field("System.AssignedTo").Eq(String("O'Brien")), "[System.AssignedTo] = 'O''Brien'"},
+		{"injection stays a literal", Field("System.State").Ne(String("x' OR 1=1 OR 'a'='a")), "[System.State] <> 'x'' OR 1=1 OR ''a''=''a'"},
+		{"brackets are stripped from fields", Field("System.Id] = 1 OR [System.Id").Gt(Int(0)), "[System.Id = 1 OR System.Id] > 0"},
+		{"macro", Field("System.AssignedTo").Eq(Me), "[System.AssignedTo] = @Me"},
+		{"today", Field("System.ChangedDate").Ge(Today(-7)), "[System.ChangedDate] >= @Today - 7"},
+		{"in", Field("System.State").In(String("New"), String("Active")), "[System.State] IN ('New', 'Active')"},
+		{"not in", Field("System.Id").NotIn(Int(7), Int(9)), "[System.Id] NOT IN (7, 9)"},
+		{"not in nothing is empty", Field("System.Id").NotIn(), ""},
+		{"under", Field("System.AreaPath").Under(String(`Fabrikam\Web`)), `[System.AreaPath] UNDER 'Fabrikam\Web'`},
+		{"or is grouped", Or(Field("System.AssignedTo").Eq(Me), Field("System.Id").In(Follows)), "([System.AssignedTo] = @Me OR [System.Id] IN (@Follows))"},
+		{"single condition is not grouped", And(Field("System.Tags").Contains(String("ui"))), "[System.Tags] CONTAINS 'ui'"},
+		{"empty and", And(), ""},
+		{"empty groups are left out", Or(And(), Field("System.Id").In(Follows), Or()), "[System.Id] IN (@Follows)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *Query
+		want  string
+	}{
+		{"ids only", Select(), "SELECT [System.Id] FROM WorkItems"},
+		{"empty conditions", Select().Where(And(), Or(And())), "SELECT [System.Id] FROM WorkItems"},
+		{
+			"full",
+			Select("System.Id", "System.Title").
+				Where(Field("System.TeamProject").Eq(Project), Field("System.State").Ne(String("Closed"))).
+				OrderBy("System.Priority", false).
+				OrderBy("System.ChangedDate", true),
+			"SELECT [System.Id], [System.Title] FROM WorkItems WHERE [System.TeamProject] = @Project AND [System.State] <> 'Closed' ORDER BY [System.Priority] ASC, [System.ChangedDate] DESC",
+		},
+		{
+			"as of",
+			Select().Where(Field("System.IterationPath").Eq(String("Sprint 1"))).AsOf(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+			"SELECT [System.Id] FROM WorkItems WHERE [System.IterationPath] = 'Sprint 1' ASOF '2024-01-02T00:00:00Z'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.String(); got != tt.want {
+				t.Errorf("String() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}